@@ -1353,18 +1353,25 @@ func TestErrorRecoveryWorkflow(t *testing.T) {
 	}
 
 	// Test generation with mixed files - should generate docs for valid ones
-	cmd = exec.Command(binaryPath, "gen", "--recursive") // #nosec G204 -- controlled test input
+	validDir := filepath.Join(tmpDir, "valid")
+	testutil.WriteTestFile(t, filepath.Join(validDir, "action.yml"),
+		testutil.MustReadFixture("actions/composite/basic.yml"))
+	cmd = exec.Command(binaryPath, "gen", "--recursive", "--keep-going") // #nosec G204 -- controlled test input
 	cmd.Dir = tmpDir
+	var stdout strings.Builder
+	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	_ = cmd.Run()
-	// Generation might fail due to invalid files, but check what was generated
-	readmeFiles, _ := filepath.Glob(filepath.Join(tmpDir, "**/README*.md"))
-
-	// Should have generated at least some READMEs for valid files
-	if len(readmeFiles) == 0 {
-		t.Log("No READMEs generated, which might be expected with invalid files")
+	if err := cmd.Run(); err == nil {
+		t.Error("expected generation to fail with invalid files")
 	}
+	// The valid actions before and after the invalid one are generated
+	for _, dir := range []string{tmpDir, validDir} {
+		if _, err := os.Stat(filepath.Join(dir, "README.md")); err != nil {
+			t.Errorf("expected README.md for the valid action in %s: %v", dir, err)
+		}
+	}
+	testutil.AssertStringContains(t, stdout.String(), filepath.Join(subDir, "action.yml"))
 }
 
 func TestConfigurationWorkflow(t *testing.T) {
@@ -1587,3 +1594,30 @@ func verifyCompleteServiceChain(t *testing.T, tmpDir string) {
 
 	t.Logf("Complete service chain verification: all %d components verified", foundComponents)
 }
+
+func TestDepsListWithoutToken(t *testing.T) {
+	// Note: Cannot use t.Parallel() because this test uses t.Setenv
+	binaryPath := buildTestBinary(t)
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	// Outside a git repository and without a token, dependencies are still read locally
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(tmpDir, "data"))
+	t.Setenv("GH_README_GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "action.yml"),
+		testutil.MustReadFixture("actions/composite/with-dependencies.yml"))
+
+	cmd := exec.Command(binaryPath, "deps", "list", "--wide") // #nosec G204 -- controlled test input
+	cmd.Dir = tmpDir
+	var stdout strings.Builder
+	cmd.Stdout = &stdout
+	testutil.AssertNoError(t, cmd.Run())
+
+	output := stdout.String()
+	for _, want := range []string{"actions/checkout", "v4", "Total dependencies", "need a GitHub token"} {
+		testutil.AssertStringContains(t, output, want)
+	}
+}
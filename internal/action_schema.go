@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adrg/xdg"
+
+	"github.com/ivuorinen/gh-action-readme/schemas"
+)
+
+// ActionSchemaURL is the published JSON schema of action.yml files, associated
+// with action files in editor settings.
+const ActionSchemaURL = "https://raw.githubusercontent.com/ivuorinen/gh-action-readme/main/schemas/action.schema.json"
+
+// Formats of schema path.
+const (
+	SchemaFormatPath     = "path"           // Path of the local schema file
+	SchemaFormatURL      = "jsonschema-url" // URL of the schema
+	SchemaFormatVSCode   = "vscode"         // yaml.schemas entry of VS Code settings
+	SchemaFormatModeline = "modeline"       // yaml-language-server comment for the top of action.yml
+)
+
+// SchemaFormats lists the formats of schema path.
+var SchemaFormats = []string{SchemaFormatPath, SchemaFormatURL, SchemaFormatVSCode, SchemaFormatModeline}
+
+// actionFilePatterns are the files the action schema is associated with.
+var actionFilePatterns = []string{"action.yml", "action.yaml", "**/action.yml", "**/action.yaml"}
+
+// WriteActionSchema writes the action.yml schema embedded in the binary to
+// the XDG data directory, where editors read it, and returns its path. The
+// file is only rewritten when it differs from the embedded schema.
+func WriteActionSchema() (string, error) {
+	path, err := xdg.DataFile(filepath.Join(toolName, schemas.ActionSchemaFile))
+	if err != nil {
+		return "", fmt.Errorf("failed to get XDG data directory: %w", err)
+	}
+	current, err := os.ReadFile(path) // #nosec G304 -- schema file in the data directory
+	if err == nil && bytes.Equal(current, schemas.ActionSchema) {
+		return path, nil
+	}
+	if err := os.WriteFile(path, schemas.ActionSchema, FilePermDefault); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return path, nil
+}
+
+// FileURL returns the file URL of the absolute path.
+func FileURL(path string) string {
+	slashed := filepath.ToSlash(path)
+	if !strings.HasPrefix(slashed, "/") {
+		slashed = "/" + slashed // Windows drive letters
+	}
+
+	return (&url.URL{Scheme: "file", Path: slashed}).String()
+}
+
+// FormatSchemaAssociation formats the schema at location, a local path or a
+// URL, in one of the SchemaFormats.
+func FormatSchemaAssociation(format, location string) (string, error) {
+	schemaURL := location
+	if !strings.Contains(location, "://") {
+		schemaURL = FileURL(location)
+	}
+
+	switch format {
+	case SchemaFormatPath:
+		return location, nil
+	case SchemaFormatURL:
+		return schemaURL, nil
+	case SchemaFormatModeline:
+		return "# yaml-language-server: $schema=" + schemaURL, nil
+	case SchemaFormatVSCode:
+		settings := map[string]any{"yaml.schemas": map[string]any{location: actionFilePatterns}}
+		data, err := json.MarshalIndent(settings, "", "  ")
+		if err != nil {
+			return "", err
+		}
+
+		return string(data), nil
+	default:
+		return "", fmt.Errorf("unknown schema format %q, supported: %s", format, strings.Join(SchemaFormats, ", "))
+	}
+}
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/schemas"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestFormatSchemaAssociation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		format   string
+		location string
+		want     string
+	}{
+		{"path", SchemaFormatPath, "/data/action.schema.json", "/data/action.schema.json"},
+		{"local url", SchemaFormatURL, "/data/action.schema.json", "file:///data/action.schema.json"},
+		{"drive letter url", SchemaFormatURL, "C:/data/action.schema.json", "file:///C:/data/action.schema.json"},
+		{"remote url", SchemaFormatURL, ActionSchemaURL, ActionSchemaURL},
+		{
+			"modeline", SchemaFormatModeline, "/data/action.schema.json",
+			"# yaml-language-server: $schema=file:///data/action.schema.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := FormatSchemaAssociation(tt.format, tt.location)
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tt.want, got)
+		})
+	}
+
+	snippet, err := FormatSchemaAssociation(SchemaFormatVSCode, ActionSchemaURL)
+	testutil.AssertNoError(t, err)
+	var settings map[string]map[string][]string
+	testutil.AssertNoError(t, json.Unmarshal([]byte(snippet), &settings))
+	testutil.AssertEqual(t, "**/action.yml", settings["yaml.schemas"][ActionSchemaURL][2])
+
+	_, err = FormatSchemaAssociation("emacs", ActionSchemaURL)
+	testutil.AssertError(t, err)
+
+	// The embedded schema is the one in the repository
+	var schema map[string]any
+	testutil.AssertNoError(t, json.Unmarshal(schemas.ActionSchema, &schema))
+	testutil.AssertEqual(t, "GitHub Action", schema["title"])
+}
@@ -0,0 +1,377 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+)
+
+// Sections of a README that the generator manages between marker comments.
+const (
+	SectionUsage   = "usage"
+	SectionInputs  = "inputs"
+	SectionOutputs = "outputs"
+)
+
+// ManagedSections lists the managed sections in the order they are added to a README.
+var ManagedSections = []string{SectionUsage, SectionInputs, SectionOutputs}
+
+// sectionTitles are the headings of managed sections added to a README that lacks them.
+var sectionTitles = map[string]string{
+	SectionUsage:   "Usage",
+	SectionInputs:  "Inputs",
+	SectionOutputs: "Outputs",
+}
+
+// managedSectionTemplates render the content kept between the markers of each section.
+var managedSectionTemplates = map[string]string{
+	SectionUsage: "```yaml\n- uses: {{gitUsesString .}}\n" +
+		"{{- if .Inputs}}\n  with:\n{{- range .InputList}}\n" +
+		"    {{.Name}}: {{if .Default}}{{if isBlockDefault .Default}}{{yamlBlock 6 .Default}}" +
+		"{{else}}{{defaultValue .Default}}{{end}}{{else}}\"\"{{end}}\n{{- end}}{{end}}\n```",
+	SectionInputs: "{{if .Inputs}}| Name | Description | Required | Default |\n" +
+		"|------|-------------|----------|---------|{{range .InputList}}\n" +
+		"| `{{.Name}}` | {{cell .Description}} | {{if .Required}}yes{{else}}no{{end}} | " +
+		"{{if .Default}}{{if isBlockDefault .Default}}_See below_{{else}}`{{cell (defaultValue .Default)}}`{{end}}" +
+		"{{else}}-{{end}} |{{end}}{{range .InputList}}{{if isBlockDefault .Default}}\n\n" +
+		"Default of `{{.Name}}`:\n\n{{defaultBlock .Default}}{{end}}{{end}}{{else}}This action has no inputs.{{end}}",
+	SectionOutputs: "{{if .Outputs}}| Name | Description |\n|------|-------------|" +
+		"{{range .OutputList}}\n| `{{.Name}}` | {{cell .Description}} |{{end}}{{else}}This action has no outputs.{{end}}",
+}
+
+var (
+	// markerRe matches a section marker line such as <!-- gh-action-readme:start inputs -->.
+	markerRe = regexp.MustCompile(`^<!--\s*gh-action-readme:(start|end)\s+([a-z-]+)\s*-->$`)
+	// headingRe matches an ATX heading and captures its level and text.
+	headingRe = regexp.MustCompile(`^(#{1,6})\s+(.*?)[\s#]*$`)
+	// headingWordsRe matches the characters dropped when classifying a heading.
+	headingWordsRe = regexp.MustCompile(`[^a-z ]+`)
+)
+
+// AdoptedSection describes a managed section placed in an adopted README.
+type AdoptedSection struct {
+	Name    string `json:"name"`
+	Heading string `json:"heading"`
+	// Line is the 1-based line of the heading in the original README, 0 for added sections.
+	Line  int  `json:"line"`
+	Added bool `json:"added"`
+}
+
+// AdoptResult is the proposed README produced by adopting a hand-written one.
+type AdoptResult struct {
+	Content  string           `json:"content"`
+	Sections []AdoptedSection `json:"sections"`
+}
+
+// readmeHeading is an ATX heading of a README outside code blocks.
+type readmeHeading struct {
+	index int
+	level int
+	text  string
+}
+
+// SectionStartMarker returns the comment opening a managed section.
+func SectionStartMarker(section string) string {
+	return "<!-- gh-action-readme:start " + section + " -->"
+}
+
+// SectionEndMarker returns the comment closing a managed section.
+func SectionEndMarker(section string) string {
+	return "<!-- gh-action-readme:end " + section + " -->"
+}
+
+// RenderManagedSections renders the content of every managed section for an action.
+func RenderManagedSections(data *TemplateData) (map[string]string, error) {
+	funcs := templateFuncs(tableSettingsOf(data))
+	safe := sanitizeTemplateData(data, OutputFormatMD)
+
+	sections := make(map[string]string, len(managedSectionTemplates))
+	for name, text := range managedSectionTemplates {
+		tmpl, err := template.New(name).Funcs(funcs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s section template: %w", name, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, safe); err != nil {
+			return nil, fmt.Errorf("failed to render %s section: %w", name, err)
+		}
+		sections[name] = resolveHTMLMarkers(buf.String())
+	}
+
+	return sections, nil
+}
+
+// HasSectionMarkers reports whether a README contains managed section markers.
+func HasSectionMarkers(content string) bool {
+	inFence := false
+	for _, line := range splitLines(content) {
+		if isFenceLine(line) {
+			inFence = !inFence
+		} else if !inFence && markerRe.MatchString(strings.TrimSpace(line)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UpdateMarkedSections replaces the content between the markers of each managed
+// section with its newly rendered content and leaves everything else untouched.
+// Sections without rendered content are kept as they are.
+func UpdateMarkedSections(content string, sections map[string]string) (string, error) {
+	lines := splitLines(content)
+	out := make([]string, 0, len(lines))
+	inFence := false
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		out = append(out, line)
+		if isFenceLine(line) {
+			inFence = !inFence
+		}
+		match := markerRe.FindStringSubmatch(strings.TrimSpace(line))
+		if inFence || match == nil {
+			continue
+		}
+		if match[1] == "end" {
+			return "", fmt.Errorf("line %d: %s has no matching start marker", i+1, SectionEndMarker(match[2]))
+		}
+
+		end := findEndMarker(lines, i+1, match[2])
+		if end < 0 {
+			return "", fmt.Errorf("line %d: %s has no matching end marker", i+1, SectionStartMarker(match[2]))
+		}
+		if body, ok := sections[match[2]]; ok {
+			out = append(out, splitLines(body)...)
+		} else {
+			out = append(out, lines[i+1:end]...)
+		}
+		out = append(out, lines[end])
+		i = end
+	}
+
+	return strings.Join(out, "\n") + "\n", nil
+}
+
+// findEndMarker returns the index of the end marker of section at or after
+// start, or -1 when there is none.
+func findEndMarker(lines []string, start int, section string) int {
+	for i := start; i < len(lines); i++ {
+		match := markerRe.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if match != nil && match[1] == "end" && match[2] == section {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// AdoptSections proposes a version of a hand-written README whose usage, inputs
+// and outputs sections are managed by the generator. Each recognized section
+// keeps its heading and has its body replaced with the rendered content wrapped
+// in marker comments; sections that are missing are added after the last
+// recognized one. Everything else is preserved.
+func AdoptSections(readme string, sections map[string]string) (*AdoptResult, error) {
+	if HasSectionMarkers(readme) {
+		return nil, errors.New("README already has gh-action-readme markers, run gen to update it")
+	}
+
+	lines := splitLines(readme)
+	headings := readmeHeadings(lines)
+	found := classifyHeadings(headings)
+
+	result := &AdoptResult{}
+	var out []string
+	insertAt, insertLevel := len(lines), 2
+	for i := 0; i < len(lines); i++ {
+		name, ok := found[i]
+		if !ok {
+			out = append(out, lines[i])
+
+			continue
+		}
+		heading := headingAt(headings, i)
+		end := sectionEnd(headings, heading, found, len(lines))
+		out = append(out, lines[i], "")
+		out = append(out, markedSection(name, sections[name])...)
+		out = append(out, "")
+		result.Sections = append(result.Sections, AdoptedSection{Name: name, Heading: heading.text, Line: i + 1})
+		i = end - 1
+		insertAt, insertLevel = len(out), heading.level
+	}
+
+	added := missingSections(found, sections, insertLevel, result)
+	if insertAt == len(out) && len(added) > 0 && len(out) > 0 && strings.TrimSpace(out[len(out)-1]) != "" {
+		added = append([]string{""}, added...)
+	}
+	out = append(out[:insertAt], append(added, out[insertAt:]...)...)
+	for len(out) > 0 && strings.TrimSpace(out[len(out)-1]) == "" {
+		out = out[:len(out)-1]
+	}
+	result.Content = strings.Join(out, "\n") + "\n"
+
+	return result, nil
+}
+
+// missingSections renders the managed sections the README does not have,
+// recording them in result.
+func missingSections(found map[int]string, sections map[string]string, level int, result *AdoptResult) []string {
+	present := make(map[string]bool, len(found))
+	for _, name := range found {
+		present[name] = true
+	}
+
+	var out []string
+	for _, name := range ManagedSections {
+		if present[name] {
+			continue
+		}
+		heading := strings.Repeat("#", level) + " " + sectionTitles[name]
+		out = append(out, heading, "")
+		out = append(out, markedSection(name, sections[name])...)
+		out = append(out, "")
+		result.Sections = append(result.Sections, AdoptedSection{Name: name, Heading: sectionTitles[name], Added: true})
+	}
+
+	return out
+}
+
+// markedSection wraps the content of a section in its marker comments.
+func markedSection(name, content string) []string {
+	out := []string{SectionStartMarker(name)}
+	out = append(out, splitLines(content)...)
+
+	return append(out, SectionEndMarker(name))
+}
+
+// readmeHeadings returns the ATX headings outside fenced code blocks.
+func readmeHeadings(lines []string) []readmeHeading {
+	var headings []readmeHeading
+	inFence := false
+	for i, line := range lines {
+		if isFenceLine(line) {
+			inFence = !inFence
+
+			continue
+		}
+		if match := headingRe.FindStringSubmatch(line); match != nil && !inFence {
+			headings = append(headings, readmeHeading{index: i, level: len(match[1]), text: match[2]})
+		}
+	}
+
+	return headings
+}
+
+// classifyHeadings maps the line index of the first heading recognized as each
+// managed section to the section name. The document title is never a section.
+func classifyHeadings(headings []readmeHeading) map[int]string {
+	found := map[int]string{}
+	claimed := map[string]bool{}
+	for _, heading := range headings {
+		name := headingSection(heading.text)
+		if heading.level == 1 || name == "" || claimed[name] {
+			continue
+		}
+		found[heading.index] = name
+		claimed[name] = true
+	}
+
+	return found
+}
+
+// headingSection returns the managed section a heading introduces, or "".
+func headingSection(text string) string {
+	words := strings.Join(strings.Fields(headingWordsRe.ReplaceAllString(strings.ToLower(text), " ")), " ")
+	switch {
+	case strings.Contains(words, "output"):
+		return SectionOutputs
+	case strings.Contains(words, "input"):
+		return SectionInputs
+	case strings.Contains(words, "usage"), strings.Contains(words, "quick start"),
+		strings.Contains(words, "quickstart"), strings.Contains(words, "how to use"):
+		return SectionUsage
+	}
+
+	return ""
+}
+
+// headingAt returns the heading on line index.
+func headingAt(headings []readmeHeading, index int) readmeHeading {
+	for _, heading := range headings {
+		if heading.index == index {
+			return heading
+		}
+	}
+
+	return readmeHeading{index: index}
+}
+
+// sectionEnd returns the line index where the body of heading ends: the next
+// heading of the same or a higher level, the next managed section heading or
+// the end of the document.
+func sectionEnd(headings []readmeHeading, heading readmeHeading, found map[int]string, total int) int {
+	for _, next := range headings {
+		if next.index <= heading.index {
+			continue
+		}
+		if _, managed := found[next.index]; managed || next.level <= heading.level {
+			return next.index
+		}
+	}
+
+	return total
+}
+
+// isFenceLine reports whether line opens or closes a fenced code block.
+func isFenceLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// Adopt proposes a merged version of the hand-written README at readmePath
+// with the managed sections of the action at actionPath.
+func (g *Generator) Adopt(actionPath, readmePath string) (*AdoptResult, error) {
+	action, err := g.parseAndValidateAction(actionPath)
+	if err != nil {
+		return nil, err
+	}
+	readme, err := os.ReadFile(readmePath) // #nosec G304 -- README path from user input
+	if err != nil {
+		return nil, fmt.Errorf("failed to read README: %w", err)
+	}
+
+	repoRoot, _ := git.FindRepositoryRoot(g.determineOutputDir(actionPath))
+	sections, err := RenderManagedSections(BuildTemplateData(action, g.Config, repoRoot, actionPath))
+	if err != nil {
+		return nil, err
+	}
+
+	return AdoptSections(string(readme), sections)
+}
+
+// mergeMarkedReadme returns the README at outputPath with only its managed
+// sections updated when it was adopted, or the fully rendered content when the
+// file is missing or has no markers.
+func mergeMarkedReadme(data *TemplateData, outputPath, content string) (string, error) {
+	existing, err := os.ReadFile(outputPath) // #nosec G304 -- output path from configuration
+	if err != nil || !HasSectionMarkers(string(existing)) {
+		return content, nil
+	}
+
+	sections, err := RenderManagedSections(data)
+	if err != nil {
+		return "", err
+	}
+	merged, err := UpdateMarkedSections(string(existing), sections)
+	if err != nil {
+		return "", fmt.Errorf("failed to update managed sections of %s: %w", outputPath, err)
+	}
+
+	return merged, nil
+}
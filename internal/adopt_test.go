@@ -0,0 +1,161 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+var testSections = map[string]string{
+	SectionUsage:   "usage body",
+	SectionInputs:  "inputs body",
+	SectionOutputs: "outputs body",
+}
+
+func TestAdoptSections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		readme        string
+		want          string
+		expectAdded   int
+		expectError   bool
+		expectMatched []string
+	}{
+		{
+			name: "recognized and missing sections",
+			readme: "# Title\n\nIntro.\n\n## 📥 Inputs\n\n| old |\n\n### `token`\n\nDetails.\n\n" +
+				"## Contributing\n\nPRs welcome.\n",
+			want: "# Title\n\nIntro.\n\n## 📥 Inputs\n\n" +
+				"<!-- gh-action-readme:start inputs -->\ninputs body\n<!-- gh-action-readme:end inputs -->\n\n" +
+				"## Usage\n\n<!-- gh-action-readme:start usage -->\nusage body\n<!-- gh-action-readme:end usage -->\n\n" +
+				"## Outputs\n\n<!-- gh-action-readme:start outputs -->\noutputs body\n" +
+				"<!-- gh-action-readme:end outputs -->\n\n## Contributing\n\nPRs welcome.\n",
+			expectAdded:   2,
+			expectMatched: []string{SectionInputs},
+		},
+		{
+			name:   "headings in code blocks are ignored",
+			readme: "# Title\n\n## Example usage\n\n```md\n## Outputs\n```\n\n## Outputs\nold\n",
+			want: "# Title\n\n## Example usage\n\n" +
+				"<!-- gh-action-readme:start usage -->\nusage body\n<!-- gh-action-readme:end usage -->\n\n" +
+				"## Outputs\n\n<!-- gh-action-readme:start outputs -->\noutputs body\n" +
+				"<!-- gh-action-readme:end outputs -->\n\n## Inputs\n\n" +
+				"<!-- gh-action-readme:start inputs -->\ninputs body\n<!-- gh-action-readme:end inputs -->\n",
+			expectAdded:   1,
+			expectMatched: []string{SectionUsage, SectionOutputs},
+		},
+		{
+			name:        "already adopted",
+			readme:      "# Title\n\n<!-- gh-action-readme:start inputs -->\nx\n<!-- gh-action-readme:end inputs -->\n",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := AdoptSections(tt.readme, testSections)
+			if tt.expectError {
+				testutil.AssertError(t, err)
+
+				return
+			}
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tt.want, result.Content)
+
+			var matched []string
+			added := 0
+			for _, section := range result.Sections {
+				if section.Added {
+					added++
+				} else {
+					matched = append(matched, section.Name)
+				}
+			}
+			testutil.AssertEqual(t, tt.expectAdded, added)
+			testutil.AssertEqual(t, strings.Join(tt.expectMatched, ","), strings.Join(matched, ","))
+		})
+	}
+}
+
+func TestUpdateMarkedSections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		want        string
+		expectError bool
+	}{
+		{
+			name: "replaces marked content only",
+			content: "Intro\n<!-- gh-action-readme:start inputs -->\nstale\n<!-- gh-action-readme:end inputs -->\n" +
+				"<!-- gh-action-readme:start custom -->\nkept\n<!-- gh-action-readme:end custom -->\nOutro\n",
+			want: "Intro\n<!-- gh-action-readme:start inputs -->\ninputs body\n<!-- gh-action-readme:end inputs -->\n" +
+				"<!-- gh-action-readme:start custom -->\nkept\n<!-- gh-action-readme:end custom -->\nOutro\n",
+		},
+		{
+			name:    "markers in code blocks are ignored",
+			content: "```\n<!-- gh-action-readme:start inputs -->\n```\n",
+			want:    "```\n<!-- gh-action-readme:start inputs -->\n```\n",
+		},
+		{
+			name:        "missing end marker",
+			content:     "<!-- gh-action-readme:start inputs -->\nstale\n",
+			expectError: true,
+		},
+		{
+			name:        "end marker without start",
+			content:     "<!-- gh-action-readme:end inputs -->\n",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := UpdateMarkedSections(tt.content, testSections)
+			if tt.expectError {
+				testutil.AssertError(t, err)
+
+				return
+			}
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tt.want, got)
+		})
+	}
+}
+
+func TestGenerator_AdoptedReadme(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	readmePath := filepath.Join(tmpDir, "README.md")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	testutil.WriteTestFile(t, readmePath, "# Hand-written\n\n## Inputs\n\nTODO\n\n## License\n\nMIT\n")
+
+	generator := NewGeneratorWithDependencies(DefaultAppConfig(), NewNullOutput(), NewNullProgressManager())
+	result, err := generator.Adopt(actionPath, readmePath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, result.Content, "| `message` | Message to display | yes | - |")
+	testutil.AssertStringContains(t, result.Content, "| `result` | The result of the action |")
+	testutil.WriteTestFile(t, readmePath, result.Content)
+
+	// Regenerating keeps the hand-written parts and leaves an up to date README alone
+	testutil.AssertNoError(t, generator.GenerateFromFile(actionPath))
+	data, err := os.ReadFile(readmePath) // #nosec G304 -- test file path
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, result.Content, string(data))
+
+	generator.Check = true
+	testutil.AssertNoError(t, generator.GenerateFromFile(actionPath))
+}
@@ -0,0 +1,228 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
+)
+
+// ActionAllowlist is the registry of approved actions and versions named by
+// deps.allowlist. Actions maps an action, docker:// image or pattern such as
+// "my-org/*" to its approved versions or version patterns such as "v4.*";
+// an action without versions is approved at any version.
+type ActionAllowlist struct {
+	Source  string              `json:"source"  yaml:"-"`
+	Actions map[string][]string `json:"actions" yaml:"actions"`
+}
+
+// ValidateAllowlistSource rejects deps.allowlist URLs that are not https.
+// Other values are paths to a local registry file.
+func ValidateAllowlistSource(source string) error {
+	if !strings.Contains(source, "://") {
+		return nil
+	}
+	parsed, err := url.Parse(source)
+	if err != nil || parsed.Host == "" || parsed.Scheme != "https" {
+		return fmt.Errorf("invalid deps.allowlist %q, must be a file path or an https URL", source)
+	}
+
+	return nil
+}
+
+// LoadAllowlist reads the registry file at source, an https URL fetched with
+// client (http.DefaultClient when nil) or a path relative to baseDir.
+func LoadAllowlist(ctx context.Context, client *http.Client, source, baseDir string) (*ActionAllowlist, error) {
+	if err := ValidateAllowlistSource(source); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	var err error
+	if strings.HasPrefix(source, "https://") {
+		data, err = fetchAllowlist(ctx, client, source)
+	} else {
+		file := source
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(baseDir, file)
+		}
+		data, err = os.ReadFile(file) // #nosec G304 -- registry file named by the configuration
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allow-list %s: %w", source, err)
+	}
+
+	allowlist := &ActionAllowlist{}
+	if err := yamlsafe.Unmarshal(data, allowlist); err != nil {
+		return nil, fmt.Errorf("failed to parse allow-list %s: %w", source, err)
+	}
+	if len(allowlist.Actions) == 0 {
+		return nil, fmt.Errorf("allow-list %s approves no actions", source)
+	}
+	allowlist.Source = source
+	actions := make(map[string][]string, len(allowlist.Actions))
+	for name, versions := range allowlist.Actions {
+		actions[strings.ToLower(strings.TrimSuffix(name, "/"))] = versions
+	}
+	allowlist.Actions = actions
+
+	return allowlist, nil
+}
+
+// fetchAllowlist downloads the registry file at target.
+func fetchAllowlist(ctx context.Context, client *http.Client, target string) ([]byte, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, yamlsafe.DefaultMaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > yamlsafe.DefaultMaxBytes {
+		return nil, errors.New("file is too large")
+	}
+
+	return data, nil
+}
+
+// versions returns the approved versions of the action or image name, and
+// false when no entry matches it. Exact entries take precedence over
+// patterns, and sub-path actions such as github/codeql-action/init fall back
+// to the entry of their repository.
+func (l *ActionAllowlist) versions(name string) ([]string, bool) {
+	name = strings.ToLower(name)
+	candidates := []string{name}
+	if parts := strings.SplitN(name, "/", 3); !strings.HasPrefix(name, "docker://") && len(parts) == 3 {
+		candidates = append(candidates, parts[0]+"/"+parts[1])
+	}
+
+	for _, candidate := range candidates {
+		if versions, ok := l.Actions[candidate]; ok {
+			return versions, true
+		}
+		for _, pattern := range slices.Sorted(maps.Keys(l.Actions)) {
+			if matched, _ := path.Match(pattern, candidate); matched {
+				return l.Actions[pattern], true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Allows reports whether dep is approved. Shell scripts and actions of the
+// same repository always are. A dependency pinned to a commit SHA is approved
+// when resolve maps one of the approved versions to that SHA.
+func (l *ActionAllowlist) Allows(dep dependencies.Dependency, resolve func(dependencies.Dependency) string) bool {
+	if dep.IsShellScript || dep.IsLocalAction {
+		return true
+	}
+	versions, ok := l.versions(dep.Name)
+	if !ok {
+		return false
+	}
+	if len(versions) == 0 || versionApproved(versions, dep.Version) {
+		return true
+	}
+	if dep.VersionType != dependencies.CommitSHA || dep.Docker != nil || resolve == nil {
+		return false
+	}
+
+	for _, version := range versions {
+		if isVersionPattern(version) {
+			continue
+		}
+		sha := resolve(dependencies.Dependency{Name: dep.Name, Uses: dep.Name + "@" + version})
+		if sha != "" && strings.HasPrefix(strings.ToLower(sha), strings.ToLower(dep.Version)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UpgradeTarget returns the version deps upgrade may move dep to instead of
+// latest: latest when it is approved, otherwise the highest approved version
+// newer than the current one. It returns false when there is none.
+func (l *ActionAllowlist) UpgradeTarget(dep dependencies.Dependency, latest string, classify UpdateTypeFunc) (
+	string,
+	bool,
+) {
+	versions, ok := l.versions(dep.Name)
+	switch {
+	case !ok:
+		return "", false
+	case len(versions) == 0 || versionApproved(versions, latest):
+		return latest, true
+	}
+
+	target := ""
+	for _, version := range versions {
+		if isVersionPattern(version) || !isUpgrade(classify(dep.Version, version)) {
+			continue
+		}
+		if target == "" || isUpgrade(classify(target, version)) {
+			target = version
+		}
+	}
+
+	return target, target != ""
+}
+
+// versionApproved reports whether version matches one of the approved versions or patterns.
+func versionApproved(versions []string, version string) bool {
+	return slices.ContainsFunc(versions, func(approved string) bool {
+		matched, _ := path.Match(approved, version)
+
+		return approved == version || matched
+	})
+}
+
+// isVersionPattern reports whether version is a pattern rather than a tag.
+func isVersionPattern(version string) bool {
+	return strings.ContainsAny(version, "*?[")
+}
+
+// DisallowedDependencies returns the dependencies the allow-list does not
+// approve. A nil allow-list approves every dependency.
+func DisallowedDependencies(
+	allowlist *ActionAllowlist,
+	deps []dependencies.Dependency,
+	resolve func(dependencies.Dependency) string,
+) []dependencies.Dependency {
+	if allowlist == nil {
+		return nil
+	}
+	disallowed := []dependencies.Dependency{}
+	for _, dep := range deps {
+		if !allowlist.Allows(dep, resolve) {
+			disallowed = append(disallowed, dep)
+		}
+	}
+
+	return disallowed
+}
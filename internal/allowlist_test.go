@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+const testAllowlist = `actions:
+  Actions/Checkout: [v4.2.2, v4.1.7]
+  actions/setup-go: []
+  github/codeql-action: ["v3.*"]
+  my-org/*: []
+`
+
+func TestLoadAllowlist(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	testutil.WriteTestFile(t, filepath.Join(dir, ".github", "approved.yml"), testAllowlist)
+	allowlist, err := LoadAllowlist(context.Background(), nil, ".github/approved.yml", dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 4, len(allowlist.Actions))
+	testutil.AssertEqual(t, "v4.2.2,v4.1.7", strings.Join(allowlist.Actions["actions/checkout"], ","))
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/approved.yml" {
+			http.NotFound(w, r)
+
+			return
+		}
+		_, _ = w.Write([]byte(testAllowlist))
+	}))
+	defer server.Close()
+
+	allowlist, err = LoadAllowlist(context.Background(), server.Client(), server.URL+"/approved.yml", dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, server.URL+"/approved.yml", allowlist.Source)
+
+	_, err = LoadAllowlist(context.Background(), server.Client(), server.URL+"/missing.yml", dir)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "404")
+
+	testutil.WriteTestFile(t, filepath.Join(dir, "empty.yml"), "actions: {}\n")
+	_, err = LoadAllowlist(context.Background(), nil, "empty.yml", dir)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "approves no actions")
+
+	_, err = LoadAllowlist(context.Background(), nil, "http://example.com/approved.yml", dir)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "https URL")
+}
+
+func TestActionAllowlist_Allows(t *testing.T) {
+	t.Parallel()
+
+	allowlist := &ActionAllowlist{Actions: map[string][]string{
+		"actions/checkout":     {"v4.2.2", "v4.1.7"},
+		"actions/setup-go":     {},
+		"github/codeql-action": {"v3.*"},
+		"my-org/*":             {},
+	}}
+	sha := "11bd71901bbe5b1630ceea73d27597364c9af683"
+	resolve := func(dep dependencies.Dependency) string {
+		if dep.Uses == "actions/checkout@v4.2.2" {
+			return sha
+		}
+
+		return ""
+	}
+
+	tests := []struct {
+		name string
+		dep  dependencies.Dependency
+		want bool
+	}{
+		{"approved version", dependencies.Dependency{Name: "actions/checkout", Version: "v4.2.2"}, true},
+		{"other version", dependencies.Dependency{Name: "actions/checkout", Version: "v3"}, false},
+		{"any version", dependencies.Dependency{Name: "actions/setup-go", Version: "v5"}, true},
+		{"version pattern", dependencies.Dependency{Name: "github/codeql-action/init", Version: "v3.28.0"}, true},
+		{"outside version pattern", dependencies.Dependency{Name: "github/codeql-action/init", Version: "v2"}, false},
+		{"owner pattern", dependencies.Dependency{Name: "My-Org/build", Version: "main"}, true},
+		{"unknown action", dependencies.Dependency{Name: "someone/action", Version: "v1"}, false},
+		{"local action", dependencies.Dependency{Name: "./build", IsLocalAction: true}, true},
+		{
+			"sha of approved version",
+			dependencies.Dependency{Name: "actions/checkout", Version: sha, VersionType: dependencies.CommitSHA},
+			true,
+		},
+		{
+			"other sha",
+			dependencies.Dependency{Name: "actions/checkout", Version: "b4ffde6", VersionType: dependencies.CommitSHA},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testutil.AssertEqual(t, tt.want, allowlist.Allows(tt.dep, resolve))
+		})
+	}
+
+	deps := []dependencies.Dependency{tests[0].dep, tests[1].dep}
+	testutil.AssertEqual(t, 1, len(DisallowedDependencies(allowlist, deps, resolve)))
+	testutil.AssertEqual(t, 0, len(DisallowedDependencies(nil, deps, resolve)))
+}
+
+func TestActionAllowlist_UpgradeTarget(t *testing.T) {
+	t.Parallel()
+
+	allowlist := &ActionAllowlist{Actions: map[string][]string{
+		"actions/checkout":     {"v4.1.7", "v4.2.2", "v3.6.0"},
+		"actions/setup-go":     {},
+		"github/codeql-action": {"v3.*"},
+	}}
+	classify := (&dependencies.Analyzer{}).UpdateType
+
+	tests := []struct {
+		name       string
+		action     string
+		current    string
+		latest     string
+		want       string
+		wantUpdate bool
+	}{
+		{"highest approved", "actions/checkout", "v4.0.0", "v5.0.0", "v4.2.2", true},
+		{"current is highest", "actions/checkout", "v4.2.2", "v5.0.0", "", false},
+		{"any version", "actions/setup-go", "v4", "v5.5.0", "v5.5.0", true},
+		{"latest matches pattern", "github/codeql-action", "v3.1.0", "v3.28.0", "v3.28.0", true},
+		{"latest outside pattern", "github/codeql-action", "v3.1.0", "v4.0.0", "", false},
+		{"unknown action", "someone/action", "v1", "v2", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dep := dependencies.Dependency{Name: tt.action, Version: tt.current}
+			target, ok := allowlist.UpgradeTarget(dep, tt.latest, classify)
+			testutil.AssertEqual(t, tt.want, target)
+			testutil.AssertEqual(t, tt.wantUpdate, ok)
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// Places an announcement can be posted to with PostAnnouncement.
+const (
+	AnnounceTargetRelease    = "release"
+	AnnounceTargetDiscussion = "discussion"
+)
+
+// DefaultAnnouncementCategory is the discussion category announcements are posted to.
+const DefaultAnnouncementCategory = "Announcements"
+
+// changelogHeading matches second level CHANGELOG.md headings and captures the
+// version, with or without brackets and a v prefix: "## [1.2.0] - 2024-05-01".
+var changelogHeading = regexp.MustCompile(`^##\s+\[?v?([^\]\s]+)\]?`)
+
+// AnnouncementData is the data available to the announcement template: the
+// release notes of the version with its CHANGELOG.md section and usage reference.
+type AnnouncementData struct {
+	*ReleaseNotesData
+	Changelog string `json:"changelog,omitempty"` // CHANGELOG.md section of the version, without its heading
+	Uses      string `json:"uses,omitempty"`      // owner/repo@version reference of the release
+}
+
+// BuildAnnouncement collects the release notes of the action between two refs
+// and the CHANGELOG.md section of the release version from the repository root.
+// The organization and repository settings of config override the git remote.
+func BuildAnnouncement(repoRoot, actionPath, from, to string, config *AppConfig) (*AnnouncementData, error) {
+	notes, err := BuildReleaseNotes(repoRoot, actionPath, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if config.Organization != "" {
+		notes.Git.Organization = config.Organization
+	}
+	if config.Repository != "" {
+		notes.Git.Repository = config.Repository
+	}
+
+	data := &AnnouncementData{ReleaseNotesData: notes}
+	if notes.Version != releaseNotesUnreleased {
+		section, err := ChangelogSection(filepath.Join(repoRoot, "CHANGELOG.md"), notes.Version)
+		if err != nil {
+			return nil, err
+		}
+		data.Changelog = demoteHeadings(section)
+		data.Uses = releaseUses(notes.Git.Organization, notes.Git.Repository, repoRoot, actionPath, notes.Version)
+	}
+
+	return data, nil
+}
+
+// Title returns the title of the announcement discussion.
+func (a *AnnouncementData) Title() string {
+	return fmt.Sprintf("%s %s released", a.Action.Name, a.Version)
+}
+
+// RenderAnnouncement renders the announcement body with the announcement
+// template, or templatePath when it is not empty.
+func RenderAnnouncement(data *AnnouncementData, templatePath string) (string, error) {
+	if templatePath == "" {
+		templatePath = TemplatePathAnnouncement
+	}
+
+	body, err := RenderReadme(data, TemplateOptions{TemplatePath: templatePath, Format: OutputFormatMD})
+	if err != nil {
+		return "", fmt.Errorf("failed to render announcement: %w", err)
+	}
+
+	return body, nil
+}
+
+// ChangelogSection returns the body of the version's section in a Keep a
+// Changelog style file, without its heading. Missing files and versions
+// without a section return an empty string.
+func ChangelogSection(path, version string) (string, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- CHANGELOG.md in the repository root
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	version = strings.TrimPrefix(version, "v")
+	var section []string
+	inSection := false
+	for _, line := range strings.Split(string(content), "\n") {
+		if match := changelogHeading.FindStringSubmatch(line); match != nil {
+			if inSection {
+				break
+			}
+			inSection = match[1] == version
+
+			continue
+		}
+		if inSection {
+			section = append(section, line)
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(section, "\n")), nil
+}
+
+// PostAnnouncement publishes the rendered body to the repository owner/repo:
+// as the description of the GitHub release of data's version, or as a new
+// discussion in category. It returns the URL of the release or discussion.
+func PostAnnouncement(
+	ctx context.Context,
+	client *github.Client,
+	data *AnnouncementData,
+	body, target, category string,
+) (string, error) {
+	owner, repo := data.Git.Organization, data.Git.Repository
+	if owner == "" || repo == "" {
+		return "", errors.New("repository unknown, set organization and repository or add a GitHub remote")
+	}
+
+	switch target {
+	case AnnounceTargetRelease:
+		return postReleaseBody(ctx, client, owner, repo, data.Version, body)
+	case AnnounceTargetDiscussion:
+		return postDiscussion(ctx, client, owner, repo, category, data.Title(), body)
+	default:
+		return "", fmt.Errorf("unknown announcement target '%s', use %s or %s",
+			target, AnnounceTargetRelease, AnnounceTargetDiscussion)
+	}
+}
+
+// postReleaseBody replaces the description of the release tagged tag.
+func postReleaseBody(ctx context.Context, client *github.Client, owner, repo, tag, body string) (string, error) {
+	release, _, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+	if err != nil {
+		return "", fmt.Errorf("failed to find the release of %s in %s/%s, create it first: %w", tag, owner, repo, err)
+	}
+	release, _, err = client.Repositories.EditRelease(ctx, owner, repo, release.GetID(),
+		&github.RepositoryRelease{Body: github.Ptr(body)})
+	if err != nil {
+		return "", fmt.Errorf("failed to update the release of %s: %w", tag, err)
+	}
+
+	return release.GetHTMLURL(), nil
+}
+
+// postDiscussion creates a discussion in the named category. Discussions are
+// only available through the GraphQL API.
+func postDiscussion(
+	ctx context.Context,
+	client *github.Client,
+	owner, repo, category, title, body string,
+) (string, error) {
+	var repository struct {
+		Repository struct {
+			ID                   string `json:"id"`
+			DiscussionCategories struct {
+				Nodes []struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"nodes"`
+			} `json:"discussionCategories"`
+		} `json:"repository"`
+	}
+	err := graphQL(ctx, client, `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) { id discussionCategories(first: 100) { nodes { id name } } }
+}`, map[string]any{"owner": owner, "name": repo}, &repository)
+	if err != nil {
+		return "", err
+	}
+
+	var categoryID string
+	var names []string
+	for _, node := range repository.Repository.DiscussionCategories.Nodes {
+		if strings.EqualFold(node.Name, category) {
+			categoryID = node.ID
+		}
+		names = append(names, node.Name)
+	}
+	if categoryID == "" {
+		return "", fmt.Errorf("discussion category '%s' not found in %s/%s (available: %s)",
+			category, owner, repo, strings.Join(names, ", "))
+	}
+
+	var created struct {
+		CreateDiscussion struct {
+			Discussion struct {
+				URL string `json:"url"`
+			} `json:"discussion"`
+		} `json:"createDiscussion"`
+	}
+	err = graphQL(ctx, client, `mutation($repositoryId: ID!, $categoryId: ID!, $title: String!, $body: String!) {
+  createDiscussion(input: {repositoryId: $repositoryId, categoryId: $categoryId, title: $title, body: $body}) {
+    discussion { url }
+  }
+}`, map[string]any{
+		"repositoryId": repository.Repository.ID,
+		"categoryId":   categoryID,
+		"title":        title,
+		"body":         body,
+	}, &created)
+	if err != nil {
+		return "", err
+	}
+
+	return created.CreateDiscussion.Discussion.URL, nil
+}
+
+// graphQL runs a GraphQL query with the REST client's transport and decodes its data into result.
+func graphQL(ctx context.Context, client *github.Client, query string, variables map[string]any, result any) error {
+	req, err := client.NewRequest(http.MethodPost, "graphql", map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to create GraphQL request: %w", err)
+	}
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if _, err := client.Do(ctx, req, &response); err != nil {
+		return fmt.Errorf("GraphQL request failed: %w", err)
+	}
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("GraphQL request failed: %s", response.Errors[0].Message)
+	}
+
+	if err := json.Unmarshal(response.Data, result); err != nil {
+		return fmt.Errorf("failed to decode GraphQL response: %w", err)
+	}
+
+	return nil
+}
+
+// demoteHeadings moves the markdown headings of section one level down, so the
+// "### Added" groups of a changelog nest below the announcement's own headings.
+// Lines in code blocks are left alone.
+func demoteHeadings(section string) string {
+	lines := strings.Split(section, "\n")
+	inCode := false
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(strings.TrimSpace(line), "```"):
+			inCode = !inCode
+		case !inCode && strings.HasPrefix(line, "#"):
+			lines[i] = "#" + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// releaseUses returns the owner/repo[/path]@version reference of the action at
+// actionPath, or an empty string when the repository is unknown.
+func releaseUses(owner, repo, repoRoot, actionPath, version string) string {
+	if owner == "" || repo == "" {
+		return ""
+	}
+	uses := owner + "/" + repo
+	if rel, err := filepath.Rel(repoRoot, filepath.Dir(actionPath)); err == nil && rel != "." {
+		uses += "/" + filepath.ToSlash(rel)
+	}
+
+	return uses + "@" + version
+}
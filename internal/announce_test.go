@@ -0,0 +1,182 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+const testChangelog = `# Changelog
+
+## [Unreleased]
+
+- Work in progress
+
+## [1.1.0] - 2024-05-01
+
+### Added
+
+- ` + "`greeting`" + ` input
+
+` + "```bash\n# not a heading\n```" + `
+
+## 1.0.0
+
+- Initial release
+`
+
+func TestChangelogSection(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	path := filepath.Join(tmpDir, "CHANGELOG.md")
+	testutil.WriteTestFile(t, path, testChangelog)
+
+	section, err := ChangelogSection(path, "v1.1.0")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "### Added\n\n- `greeting` input\n\n```bash\n# not a heading\n```", section)
+	testutil.AssertEqual(t, "#### Added\n\n- `greeting` input\n\n```bash\n# not a heading\n```",
+		demoteHeadings(section))
+
+	section, err = ChangelogSection(path, "1.0.0")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "- Initial release", section)
+
+	section, err = ChangelogSection(path, "v2.0.0")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "", section)
+
+	section, err = ChangelogSection(filepath.Join(tmpDir, "missing.md"), "v1.1.0")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "", section)
+}
+
+func testAnnouncement() *AnnouncementData {
+	oldAction := compositeAction("actions/setup-node@v4")
+	newAction := compositeAction("actions/setup-node@v5")
+	newAction.Inputs = map[string]ActionInput{"cache": {Description: "Cache dependencies"}}
+
+	return &AnnouncementData{
+		ReleaseNotesData: &ReleaseNotesData{
+			Action:       newAction,
+			Git:          git.RepoInfo{Organization: "acme", Repository: "build"},
+			From:         "v1.0.0",
+			To:           "v1.1.0",
+			Version:      "v1.1.0",
+			Date:         "2024-05-01",
+			Commits:      []git.Commit{{Hash: "0123456789abcdef", Subject: "feat: cache input"}},
+			Interface:    DiffInterfaces(oldAction, newAction),
+			Dependencies: DiffDependencies(oldAction, newAction),
+		},
+		Uses: "acme/build@v1.1.0",
+	}
+}
+
+func TestRenderAnnouncement(t *testing.T) {
+	t.Parallel()
+
+	data := testAnnouncement()
+	body, err := RenderAnnouncement(data, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, body, "**Build v1.1.0** is available (released 2024-05-01).")
+	testutil.AssertStringContains(t, body, "### ✨ New in the interface\n\n- New optional input `cache`")
+	testutil.AssertStringContains(t, body, "### Features\n\n- feat: cache input (0123456)")
+	testutil.AssertStringContains(t, body, "| `actions/setup-node` | `v4` | `v5` |")
+	testutil.AssertStringContains(t, body, "```yaml\n- uses: acme/build@v1.1.0\n```")
+	testutil.AssertStringContains(t, body, "https://github.com/acme/build/compare/v1.0.0...v1.1.0")
+
+	data.Changelog = "#### Added\n\n- Cache input"
+	body, err = RenderAnnouncement(data, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, body, "### 📝 What's changed\n\n#### Added\n\n- Cache input")
+	if strings.Contains(body, "### Features") {
+		t.Error("commits should not be listed when the changelog has a section")
+	}
+}
+
+func TestReleaseUses(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertEqual(t, "acme/build@v1.1.0", releaseUses("acme", "build", "/repo", "/repo/action.yml", "v1.1.0"))
+	testutil.AssertEqual(t, "acme/build/setup@v1.1.0",
+		releaseUses("acme", "build", "/repo", "/repo/setup/action.yml", "v1.1.0"))
+	testutil.AssertEqual(t, "", releaseUses("", "build", "/repo", "/repo/action.yml", "v1.1.0"))
+}
+
+func TestPostAnnouncement_Release(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.MockGitHubClient(map[string]string{
+		"GET https://api.github.com/repos/acme/build/releases/tags/v1.1.0": `{"id": 7}`,
+		"PATCH https://api.github.com/repos/acme/build/releases/7": `{"id": 7, ` +
+			`"html_url": "https://github.com/acme/build/releases/tag/v1.1.0"}`,
+	})
+
+	url, err := PostAnnouncement(context.Background(), client, testAnnouncement(), "body", AnnounceTargetRelease, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "https://github.com/acme/build/releases/tag/v1.1.0", url)
+
+	_, err = PostAnnouncement(context.Background(), testutil.MockGitHubClient(nil), testAnnouncement(), "body",
+		AnnounceTargetRelease, "")
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "create it first")
+}
+
+// graphQLTransport answers GraphQL requests with a canned response per operation.
+type graphQLTransport struct {
+	variables []map[string]any
+}
+
+func (g *graphQLTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var request struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	g.variables = append(g.variables, request.Variables)
+
+	response := `{"data": {"repository": {"id": "R_1", "discussionCategories": {"nodes": [` +
+		`{"id": "C_1", "name": "General"}, {"id": "C_2", "name": "Announcements"}]}}}}`
+	if strings.Contains(request.Query, "createDiscussion") {
+		response = `{"data": {"createDiscussion": {"discussion": {"url": "https://github.com/acme/build/discussions/3"}}}}`
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(response)),
+		Request:    req,
+	}, nil
+}
+
+func TestPostAnnouncement_Discussion(t *testing.T) {
+	t.Parallel()
+
+	transport := &graphQLTransport{}
+	client := github.NewClient(&http.Client{Transport: transport})
+
+	url, err := PostAnnouncement(context.Background(), client, testAnnouncement(), "body",
+		AnnounceTargetDiscussion, "announcements")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "https://github.com/acme/build/discussions/3", url)
+	testutil.AssertEqual(t, 2, len(transport.variables))
+	testutil.AssertEqual(t, "C_2", transport.variables[1]["categoryId"])
+	testutil.AssertEqual(t, "Build v1.1.0 released", transport.variables[1]["title"])
+
+	_, err = PostAnnouncement(context.Background(), client, testAnnouncement(), "body",
+		AnnounceTargetDiscussion, "Releases")
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "available: General, Announcements")
+}
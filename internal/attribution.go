@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// Attribution settings and markers.
+const (
+	// AttributionOff disables the attribution line when used as the attribution setting.
+	AttributionOff = "off"
+	// AttributionMarker is written on the line before the attribution line, inside a
+	// comment of the output format, so gen --check can ignore the attribution.
+	AttributionMarker = "gh-action-readme:attribution"
+
+	toolName = "gh-action-readme"
+	toolURL  = "https://github.com/ivuorinen/gh-action-readme"
+)
+
+// Attribution is the "generated by" line of generated documentation.
+type Attribution struct {
+	Tool string
+	URL  string
+	// Version is empty in reproducible mode.
+	Version string
+	// Command is the command that generated the documentation, such as "gh-action-readme gen".
+	Command string
+	// Text is the custom attribution with its placeholders expanded, empty for
+	// the default wording of the theme.
+	Text string
+}
+
+// toolInfo is the version and command of the running tool, set with SetToolInfo.
+type toolInfo struct {
+	version string
+	command string
+}
+
+// currentToolInfo is used in the attribution of documentation generated afterwards.
+var currentToolInfo atomic.Pointer[toolInfo]
+
+// SetToolInfo sets the tool version and the command named in the attribution of
+// documentation generated afterwards.
+func SetToolInfo(version, command string) {
+	currentToolInfo.Store(&toolInfo{version: version, command: command})
+}
+
+// NewAttribution builds the attribution for config, or returns nil when the
+// attribution setting is "off". A custom attribution may use the {tool},
+// {version} and {command} placeholders.
+func NewAttribution(config *AppConfig) *Attribution {
+	setting := strings.TrimSpace(config.Attribution)
+	if strings.EqualFold(setting, AttributionOff) {
+		return nil
+	}
+
+	info := toolInfo{version: "dev", command: toolName + " gen"}
+	if current := currentToolInfo.Load(); current != nil {
+		info = *current
+	}
+	attribution := &Attribution{
+		Tool:    toolName,
+		URL:     toolURL,
+		Version: toolVersion(config, info.version),
+		Command: info.command,
+	}
+	attribution.Text = strings.NewReplacer(
+		"{tool}", attribution.Tool,
+		"{version}", attribution.Version,
+		"{command}", attribution.Command,
+	).Replace(setting)
+
+	return attribution
+}
+
+// stripAttribution removes every attribution marker line and the attribution
+// line following it, so documentation generated by another version of the tool
+// compares equal.
+func stripAttribution(content string) string {
+	if !strings.Contains(content, AttributionMarker) {
+		return content
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	kept := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		if strings.Contains(lines[i], AttributionMarker) {
+			i++
+
+			continue
+		}
+		kept = append(kept, lines[i])
+	}
+
+	return strings.Join(kept, "")
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestNewAttribution(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultAppConfig()
+	attribution := NewAttribution(config)
+	if attribution == nil {
+		t.Fatal("expected an attribution by default")
+	}
+	testutil.AssertEqual(t, "gh-action-readme", attribution.Tool)
+	testutil.AssertEqual(t, "", attribution.Text)
+
+	config.Attribution = "Docs by {tool} {version} ({command})"
+	attribution = NewAttribution(config)
+	testutil.AssertEqual(t,
+		"Docs by gh-action-readme "+attribution.Version+" ("+attribution.Command+")", attribution.Text)
+
+	config.Attribution = "Off"
+	if NewAttribution(config) != nil {
+		t.Error("expected no attribution when it is turned off")
+	}
+}
+
+func TestStripAttribution(t *testing.T) {
+	t.Parallel()
+
+	content := "# Title\n\n---\n\n<!-- gh-action-readme:attribution -->\n*Generated with gh-action-readme v1.0.0*\n"
+	testutil.AssertEqual(t, "# Title\n\n---\n\n", stripAttribution(content))
+	testutil.AssertEqual(t, "# Title\n", stripAttribution("# Title\n"))
+	testutil.AssertEqual(t, "text\n", stripAttribution("text\n// gh-action-readme:attribution"))
+}
+
+func TestGenerator_CheckIgnoresAttribution(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+
+	config := DefaultAppConfig()
+	config.Theme = ThemeGitHub
+	config.OutputDir = tmpDir
+	config.Quiet = true
+	generator := NewGeneratorWithDependencies(config, NewNullOutput(), NewNullProgressManager())
+	testutil.AssertNoError(t, generator.GenerateFromFile(actionPath))
+
+	readmePath := filepath.Join(tmpDir, "README.md")
+	content, err := os.ReadFile(readmePath) // #nosec G304 -- test file path
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(content), AttributionMarker)
+
+	// Documentation generated by another version of the tool is still up to date.
+	attribution := NewAttribution(config)
+	older := strings.Replace(string(content), " "+attribution.Version+" using", " v0.0.1 using", 1)
+	if older == string(content) {
+		t.Fatal("expected the attribution to name the tool version")
+	}
+	testutil.WriteTestFile(t, readmePath, older)
+	generator.Check = true
+	testutil.AssertNoError(t, generator.GenerateFromFile(actionPath))
+
+	// Turning the attribution off is a change to the documentation.
+	config.Attribution = AttributionOff
+	if err := generator.GenerateFromFile(actionPath); !errors.Is(err, ErrStaleDocumentation) {
+		t.Fatalf("expected stale documentation once the attribution is off, got %v", err)
+	}
+}
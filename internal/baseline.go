@@ -0,0 +1,147 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// baselineVersion is the current baseline file format version.
+const baselineVersion = 1
+
+// BaselineEntry identifies a known finding. Line numbers are intentionally
+// excluded so unrelated edits to a file do not invalidate the baseline.
+type BaselineEntry struct {
+	RuleID  string `json:"rule_id"`
+	File    string `json:"file"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Baseline records pre-existing validation findings that should not fail validation.
+type Baseline struct {
+	Version  int             `json:"version"`
+	Findings []BaselineEntry `json:"findings"`
+
+	root string // Directory finding paths are relative to
+}
+
+// NewBaseline creates a baseline from validation results. File paths are stored
+// relative to root so the baseline can be committed and shared.
+func NewBaseline(results []ValidationResult, root string) *Baseline {
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+	baseline := &Baseline{Version: baselineVersion, Findings: []BaselineEntry{}, root: root}
+	for _, result := range results {
+		for _, finding := range result.Findings {
+			baseline.Findings = append(baseline.Findings, baseline.entryFor(result.File, finding))
+		}
+	}
+
+	sort.Slice(baseline.Findings, func(i, j int) bool {
+		a, b := baseline.Findings[i], baseline.Findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+
+		return a.Field < b.Field
+	})
+
+	return baseline
+}
+
+// LoadBaseline reads a baseline file. Paths in the baseline are resolved
+// relative to the directory containing the file.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- baseline path from user input
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline %s: %w", path, err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s", baseline.Version, path)
+	}
+
+	baseline.root, err = filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve baseline directory: %w", err)
+	}
+
+	return &baseline, nil
+}
+
+// Save writes the baseline as indented JSON.
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), FilePermDefault); err != nil {
+		return fmt.Errorf("failed to write baseline %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Filter removes findings recorded in the baseline from results and returns the
+// number of findings removed. Each baseline entry suppresses at most one finding,
+// so additional occurrences of the same issue are still reported.
+func (b *Baseline) Filter(results []ValidationResult) int {
+	remaining := make(map[BaselineEntry]int, len(b.Findings))
+	for _, entry := range b.Findings {
+		remaining[entry]++
+	}
+
+	suppressed := 0
+	for i := range results {
+		kept := results[i].Findings[:0]
+		for _, finding := range results[i].Findings {
+			entry := b.entryFor(results[i].File, finding)
+			if remaining[entry] > 0 {
+				remaining[entry]--
+				suppressed++
+
+				continue
+			}
+			kept = append(kept, finding)
+		}
+		results[i].Findings = kept
+	}
+
+	return suppressed
+}
+
+// entryFor builds the baseline entry for a finding in the given file.
+func (b *Baseline) entryFor(file string, finding Finding) BaselineEntry {
+	return BaselineEntry{
+		RuleID:  finding.RuleID,
+		File:    b.relativePath(file),
+		Field:   finding.Field,
+		Message: finding.Message,
+	}
+}
+
+// relativePath converts a file path to a slash-separated path relative to the baseline root.
+func (b *Baseline) relativePath(file string) string {
+	if abs, err := filepath.Abs(file); err == nil {
+		file = abs
+	}
+	if b.root != "" {
+		if rel, err := filepath.Rel(b.root, file); err == nil {
+			file = rel
+		}
+	}
+
+	return filepath.ToSlash(file)
+}
@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestBaseline_SaveLoadFilter(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "actions", "build", "action.yml")
+	baselinePath := filepath.Join(tmpDir, "baseline.json")
+
+	existing := []ValidationResult{{
+		File: actionPath,
+		Findings: []Finding{
+			{RuleID: RuleMissingBranding, Severity: SeverityWarning, Field: "branding", Message: "no branding"},
+			{RuleID: RuleUnpinnedStep, Severity: SeverityWarning, Field: "runs.steps[0].uses", Message: "unpinned", Line: 7},
+		},
+	}}
+
+	baseline := NewBaseline(existing, tmpDir)
+	testutil.AssertEqual(t, 2, len(baseline.Findings))
+	testutil.AssertEqual(t, "actions/build/action.yml", baseline.Findings[0].File)
+	testutil.AssertNoError(t, baseline.Save(baselinePath))
+
+	loaded, err := LoadBaseline(baselinePath)
+	testutil.AssertNoError(t, err)
+
+	// Same findings on different lines plus one new finding.
+	current := []ValidationResult{{
+		File: actionPath,
+		Findings: []Finding{
+			{RuleID: RuleMissingBranding, Severity: SeverityWarning, Field: "branding", Message: "no branding"},
+			{RuleID: RuleUnpinnedStep, Severity: SeverityWarning, Field: "runs.steps[0].uses", Message: "unpinned", Line: 9},
+			{RuleID: RuleUnpinnedStep, Severity: SeverityWarning, Field: "runs.steps[1].uses", Message: "unpinned"},
+		},
+	}}
+
+	suppressed := loaded.Filter(current)
+	testutil.AssertEqual(t, 2, suppressed)
+	testutil.AssertEqual(t, 1, len(current[0].Findings))
+	testutil.AssertEqual(t, "runs.steps[1].uses", current[0].Findings[0].Field)
+}
+
+func TestBaseline_FilterSuppressesEachEntryOnce(t *testing.T) {
+	t.Parallel()
+
+	finding := Finding{RuleID: RuleMissingInputs, Severity: SeverityInfo, Field: "inputs", Message: "none"}
+	baseline := NewBaseline([]ValidationResult{{File: "action.yml", Findings: []Finding{finding}}}, ".")
+
+	results := []ValidationResult{{File: "action.yml", Findings: []Finding{finding, finding}}}
+	testutil.AssertEqual(t, 1, baseline.Filter(results))
+	testutil.AssertEqual(t, 1, len(results[0].Findings))
+}
+
+func TestLoadBaseline_Errors(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	_, err := LoadBaseline(filepath.Join(tmpDir, "missing.json"))
+	testutil.AssertError(t, err)
+
+	invalid := filepath.Join(tmpDir, "invalid.json")
+	testutil.WriteTestFile(t, invalid, "{not json")
+	_, err = LoadBaseline(invalid)
+	testutil.AssertError(t, err)
+
+	future := filepath.Join(tmpDir, "future.json")
+	testutil.WriteTestFile(t, future, `{"version": 99, "findings": []}`)
+	_, err = LoadBaseline(future)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "unsupported baseline version")
+}
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// BatchSummary is the outcome of generating the documentation of a batch of
+// action files, written with gen --summary-file.
+type BatchSummary struct {
+	// Check is true when the documentation was checked with gen --check rather than written
+	Check bool `json:"check"`
+	// Processed is the number of action files processed
+	Processed int `json:"processed"`
+	// Generated is the number of action files with documentation written
+	Generated int `json:"generated"`
+	// Unchanged is the number of action files whose documentation was already up to date
+	Unchanged int `json:"unchanged"`
+	// Stale is the number of action files with out of date documentation in check mode
+	Stale int `json:"stale"`
+	// Failed is the number of action files that failed
+	Failed int `json:"failed"`
+	// Skipped is the number of action files not processed after a failure without --keep-going,
+	// or after an interruption
+	Skipped int `json:"skipped"`
+	// Interrupted is true when the batch was stopped by a signal before every file was processed
+	Interrupted bool           `json:"interrupted,omitempty"`
+	Failures    []BatchFailure `json:"failures"`
+}
+
+// BatchFailure is an action file that failed, with the reason.
+type BatchFailure struct {
+	File   string `json:"file"`
+	Reason string `json:"reason"`
+}
+
+// Save writes the summary as JSON to path.
+func (s *BatchSummary) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode summary: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), FilePermDefault); err != nil {
+		return fmt.Errorf("failed to write summary %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// outputWrites counts the files written and left unchanged while generating
+// the documentation of an action.
+type outputWrites struct {
+	written   int
+	unchanged int
+}
+
+// outputWritten records that the file at path was written.
+func (g *Generator) outputWritten(kind, path string) {
+	if g.writes != nil {
+		g.writes.written++
+	}
+	if g.Config.Verbose {
+		g.Output.Success("Generated %s: %s", kind, path)
+	}
+}
+
+// reportSummary shows the outcome of a batch and the reason of each failure.
+func (g *Generator) reportSummary(summary *BatchSummary) {
+	if g.Config.Quiet {
+		return
+	}
+
+	g.Output.Bold("\nSummary:")
+	var table *Table
+	if summary.Check {
+		table = NewTable("Processed", "Up to date", "Out of date", "Failed").
+			AddRow(strconv.Itoa(summary.Processed), strconv.Itoa(summary.Unchanged),
+				strconv.Itoa(summary.Stale), strconv.Itoa(summary.Failed))
+	} else {
+		table = NewTable("Processed", "Generated", "Unchanged", "Failed").
+			AddRow(strconv.Itoa(summary.Processed), strconv.Itoa(summary.Generated),
+				strconv.Itoa(summary.Unchanged), strconv.Itoa(summary.Failed))
+	}
+	g.Output.Printf("%s", table.AlignRight(0, 1, 2, 3).Indent("  ").String())
+
+	if summary.Interrupted {
+		g.Output.Warning("Interrupted, %d file(s) not processed", summary.Skipped)
+	} else if summary.Skipped > 0 {
+		g.Output.Warning("Stopped at the first failure, %d file(s) not processed; use --keep-going to process every file",
+			summary.Skipped)
+	}
+	if len(summary.Failures) == 0 {
+		return
+	}
+	g.Output.Bold("\nFailures:")
+	failures := NewTable("File", "Reason").Indent("  ")
+	for _, failure := range summary.Failures {
+		reason, _, _ := strings.Cut(failure.Reason, "\n") // Parse errors continue with the source excerpt
+		failures.AddRow(failure.File, reason)
+	}
+	g.Output.Printf("%s", failures.String())
+}
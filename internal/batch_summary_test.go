@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestGenerator_ProcessBatchWithSummary(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	validPath := filepath.Join(tmpDir, "valid", "action.yml")
+	invalidPath := filepath.Join(tmpDir, "invalid", "action.yml")
+	testutil.WriteTestFile(t, validPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	testutil.WriteTestFile(t, invalidPath, "name: Broken\nruns: [\n")
+
+	config := DefaultAppConfig()
+	config.Theme = ThemeMinimal
+	config.Quiet = true
+
+	summary, err := NewGenerator(config).ProcessBatchWithSummary([]string{validPath, invalidPath})
+	testutil.AssertError(t, err)
+	testutil.AssertEqual(t, 2, summary.Processed)
+	testutil.AssertEqual(t, 1, summary.Generated)
+	testutil.AssertEqual(t, 1, summary.Failed)
+	testutil.AssertEqual(t, invalidPath, summary.Failures[0].File)
+	testutil.AssertStringContains(t, summary.Failures[0].Reason, "failed to parse")
+
+	// Documentation that already holds the generated content is not written again
+	readmePath := filepath.Join(tmpDir, "valid", "README.md")
+	before, err := os.Stat(readmePath)
+	testutil.AssertNoError(t, err)
+	summary, err = NewGenerator(config).ProcessBatchWithSummary([]string{validPath})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, summary.Generated)
+	testutil.AssertEqual(t, 1, summary.Unchanged)
+	after, err := os.Stat(readmePath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, before.ModTime(), after.ModTime())
+
+	summaryPath := filepath.Join(tmpDir, "summary.json")
+	testutil.AssertNoError(t, summary.Save(summaryPath))
+	data, err := os.ReadFile(summaryPath) // #nosec G304 -- test file path
+	testutil.AssertNoError(t, err)
+	var saved BatchSummary
+	testutil.AssertNoError(t, json.Unmarshal(data, &saved))
+	testutil.AssertEqual(t, 1, saved.Unchanged)
+}
+
+func TestGenerator_ProcessBatchKeepGoing(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	brokenPath := filepath.Join(tmpDir, "broken", "action.yml")
+	validPath := filepath.Join(tmpDir, "valid", "action.yml")
+	testutil.WriteTestFile(t, brokenPath, testutil.MustReadFixture("actions/invalid/missing-description.yml"))
+	testutil.WriteTestFile(t, validPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+
+	config := DefaultAppConfig()
+	config.Theme = ThemeMinimal
+	config.Quiet = true
+
+	// Without KeepGoing the files after the first failure are skipped
+	summary, err := NewGenerator(config).ProcessBatchWithSummary([]string{brokenPath, validPath})
+	testutil.AssertError(t, err)
+	testutil.AssertEqual(t, 1, summary.Processed)
+	testutil.AssertEqual(t, 1, summary.Skipped)
+	if _, err := os.Stat(filepath.Join(tmpDir, "valid", "README.md")); err == nil {
+		t.Error("expected the file after the failure to be skipped")
+	}
+
+	generator := NewGenerator(config)
+	generator.KeepGoing = true
+	summary, err = generator.ProcessBatchWithSummary([]string{brokenPath, validPath})
+	testutil.AssertError(t, err)
+	testutil.AssertEqual(t, 2, summary.Processed)
+	testutil.AssertEqual(t, 1, summary.Generated)
+	testutil.AssertEqual(t, 1, summary.Failed)
+	testutil.AssertEqual(t, brokenPath, summary.Failures[0].File)
+	_, err = os.Stat(filepath.Join(tmpDir, "valid", "README.md"))
+	testutil.AssertNoError(t, err)
+}
+
+// checksContext is canceled once Err has been called checks times.
+type checksContext struct {
+	context.Context
+	checks int
+}
+
+// Err implements context.Context.
+func (c *checksContext) Err() error {
+	if c.checks == 0 {
+		return context.Canceled
+	}
+	c.checks--
+
+	return nil
+}
+
+func TestGenerator_ProcessBatchInterrupted(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	paths := make([]string, 0, 3)
+	for _, name := range []string{"first", "second", "third"} {
+		path := filepath.Join(tmpDir, name, "action.yml")
+		testutil.WriteTestFile(t, path, testutil.MustReadFixture("actions/javascript/simple.yml"))
+		paths = append(paths, path)
+	}
+
+	config := DefaultAppConfig()
+	config.Theme = ThemeMinimal
+	config.Quiet = true
+
+	// The file being generated when the signal arrives is finished, the rest are skipped
+	generator := NewGenerator(config)
+	generator.Context = &checksContext{Context: context.Background(), checks: 1}
+	summary, err := generator.ProcessBatchWithSummary(paths)
+	if !errors.Is(err, ErrInterrupted) {
+		t.Fatalf("expected ErrInterrupted, got %v", err)
+	}
+	testutil.AssertEqual(t, true, summary.Interrupted)
+	testutil.AssertEqual(t, 1, summary.Processed)
+	testutil.AssertEqual(t, 1, summary.Generated)
+	testutil.AssertEqual(t, 2, summary.Skipped)
+
+	for _, name := range []string{"first", "second", "third"} {
+		entries, err := os.ReadDir(filepath.Join(tmpDir, name))
+		testutil.AssertNoError(t, err)
+		want := 1 // action.yml
+		if name == "first" {
+			want = 2 // and README.md, without temporary files
+		}
+		testutil.AssertEqual(t, want, len(entries))
+	}
+}
@@ -0,0 +1,287 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Benchmark stage names.
+const (
+	BenchStageDiscovery = "discovery"
+	BenchStageParse     = "parse"
+	BenchStageValidate  = "validate"
+	BenchStageRender    = "render"
+)
+
+// benchActionsPerGroup controls how many synthetic actions share a parent directory.
+const benchActionsPerGroup = 10
+
+// BenchmarkOptions configures the synthetic monorepo workload.
+type BenchmarkOptions struct {
+	Actions    int    // Number of synthetic action.yml files to generate
+	Iterations int    // Number of times each stage is repeated
+	Theme      string // Theme used for the render stage
+	WorkDir    string // Directory for the synthetic tree (temporary if empty)
+}
+
+// BenchmarkStage holds timing results for a single workload stage.
+type BenchmarkStage struct {
+	Name     string        `json:"name"`
+	Ops      int           `json:"ops"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Throughput returns operations per second for the stage.
+func (s BenchmarkStage) Throughput() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+
+	return float64(s.Ops) / s.Duration.Seconds()
+}
+
+// BenchmarkReport contains the results of a benchmark run.
+type BenchmarkReport struct {
+	Actions    int              `json:"actions"`
+	Iterations int              `json:"iterations"`
+	Stages     []BenchmarkStage `json:"stages"`
+}
+
+// CheckThresholds compares stage throughput against minimum ops/sec thresholds
+// and returns a description of every violated threshold.
+func (r *BenchmarkReport) CheckThresholds(thresholds map[string]float64) []string {
+	names := make([]string, 0, len(thresholds))
+	for name := range thresholds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var violations []string
+	for _, name := range names {
+		minimum := thresholds[name]
+		stage, ok := r.stage(name)
+		if !ok {
+			violations = append(violations, fmt.Sprintf("%s: unknown stage", name))
+
+			continue
+		}
+		if stage.Throughput() < minimum {
+			violations = append(violations, fmt.Sprintf(
+				"%s: %.1f ops/s is below threshold %.1f ops/s", name, stage.Throughput(), minimum,
+			))
+		}
+	}
+
+	return violations
+}
+
+// stage looks up a stage by name.
+func (r *BenchmarkReport) stage(name string) (BenchmarkStage, bool) {
+	for _, stage := range r.Stages {
+		if stage.Name == name {
+			return stage, true
+		}
+	}
+
+	return BenchmarkStage{}, false
+}
+
+// RunBenchmark generates a synthetic monorepo and measures each processing stage.
+func RunBenchmark(opts BenchmarkOptions) (*BenchmarkReport, error) {
+	if opts.Actions <= 0 {
+		return nil, errors.New("benchmark requires at least one action")
+	}
+	if opts.Iterations <= 0 {
+		opts.Iterations = 1
+	}
+
+	workDir := opts.WorkDir
+	if workDir == "" {
+		tmpDir, err := os.MkdirTemp("", "gh-action-readme-bench-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create benchmark directory: %w", err)
+		}
+		defer func() {
+			_ = os.RemoveAll(tmpDir) // Best-effort cleanup of synthetic workload
+		}()
+		workDir = tmpDir
+	}
+
+	if err := WriteSyntheticMonorepo(workDir, opts.Actions); err != nil {
+		return nil, err
+	}
+
+	report := &BenchmarkReport{Actions: opts.Actions, Iterations: opts.Iterations}
+
+	var files []string
+	discovery, err := timeStage(BenchStageDiscovery, opts.Iterations, func() (int, error) {
+		found, err := DiscoverActionFiles(workDir, true)
+		files = found
+
+		return len(found), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.Stages = append(report.Stages, discovery)
+
+	var actions []*ActionYML
+	parse, err := timeStage(BenchStageParse, opts.Iterations, parseStage(files, &actions))
+	if err != nil {
+		return nil, err
+	}
+	report.Stages = append(report.Stages, parse)
+
+	validate, _ := timeStage(BenchStageValidate, opts.Iterations, func() (int, error) {
+		for _, action := range actions {
+			_ = ValidateActionYML(action)
+		}
+
+		return len(actions), nil
+	})
+	report.Stages = append(report.Stages, validate)
+
+	render, err := timeStage(BenchStageRender, opts.Iterations, renderStage(actions, opts.Theme))
+	if err != nil {
+		return nil, err
+	}
+	report.Stages = append(report.Stages, render)
+
+	return report, nil
+}
+
+// parseStage returns a stage function parsing every file into actions. The
+// parse caches are cleared first, so each run parses the files again instead
+// of measuring cache lookups.
+func parseStage(files []string, actions *[]*ActionYML) func() (int, error) {
+	return func() (int, error) {
+		clearParsedActions()
+		*actions = (*actions)[:0]
+		for _, file := range files {
+			action, err := ParseActionYML(file)
+			if err != nil {
+				return 0, fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			*actions = append(*actions, action)
+		}
+
+		return len(files), nil
+	}
+}
+
+// renderStage returns a stage function rendering every action with the given theme.
+func renderStage(actions []*ActionYML, theme string) func() (int, error) {
+	config := DefaultAppConfig()
+	if theme != "" {
+		config.Theme = theme
+	}
+	opts := TemplateOptions{
+		TemplatePath: resolveThemeTemplate(config.Theme),
+		Format:       OutputFormatMD,
+	}
+
+	return func() (int, error) {
+		for _, action := range actions {
+			data := BuildTemplateData(action, config, "", "")
+			if _, err := RenderReadme(data, opts); err != nil {
+				return 0, fmt.Errorf("failed to render %s: %w", action.Name, err)
+			}
+		}
+
+		return len(actions), nil
+	}
+}
+
+// timeStage runs fn the requested number of times and records total ops and duration.
+func timeStage(name string, iterations int, fn func() (int, error)) (BenchmarkStage, error) {
+	stage := BenchmarkStage{Name: name}
+	start := time.Now()
+
+	for range iterations {
+		ops, err := fn()
+		if err != nil {
+			return stage, err
+		}
+		stage.Ops += ops
+	}
+	stage.Duration = time.Since(start)
+
+	return stage, nil
+}
+
+// WriteSyntheticMonorepo writes count synthetic action.yml files below dir,
+// grouped into nested package directories and mixing all runtime types.
+func WriteSyntheticMonorepo(dir string, count int) error {
+	for i := range count {
+		actionDir := filepath.Join(
+			dir,
+			"packages",
+			fmt.Sprintf("group-%03d", i/benchActionsPerGroup),
+			fmt.Sprintf("action-%04d", i),
+		)
+		if err := os.MkdirAll(actionDir, 0750); err != nil { // #nosec G301 -- benchmark directory permissions
+			return fmt.Errorf("failed to create %s: %w", actionDir, err)
+		}
+
+		path := filepath.Join(actionDir, ActionFileNameYML)
+		if err := os.WriteFile(path, []byte(syntheticAction(i)), FilePermDefault); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// syntheticAction returns action.yml content for the i-th synthetic action.
+func syntheticAction(i int) string {
+	header := fmt.Sprintf(`name: Synthetic Action %d
+description: Synthetic action %d used for benchmarking documentation generation.
+inputs:
+  token:
+    description: GitHub token
+    required: true
+  path:
+    description: Working directory
+    required: false
+    default: "."
+  verbose:
+    description: Enable verbose logging
+    required: false
+    default: "false"
+outputs:
+  result:
+    description: Result of the action
+branding:
+  icon: zap
+  color: blue
+`, i, i)
+
+	switch i % 3 {
+	case 0:
+		return header + `runs:
+  using: composite
+  steps:
+    - uses: actions/checkout@v4
+    - uses: actions/setup-node@v4
+      with:
+        node-version: "20"
+    - name: Build
+      run: npm ci && npm run build
+      shell: bash
+`
+	case 1:
+		return header + `runs:
+  using: node20
+  main: dist/index.js
+`
+	default:
+		return header + `runs:
+  using: docker
+  image: Dockerfile
+`
+	}
+}
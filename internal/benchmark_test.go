@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestRunBenchmark(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	report, err := RunBenchmark(BenchmarkOptions{Actions: 12, Iterations: 2, WorkDir: tmpDir})
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertEqual(t, 12, report.Actions)
+	expectedStages := []string{BenchStageDiscovery, BenchStageParse, BenchStageValidate, BenchStageRender}
+	testutil.AssertEqual(t, len(expectedStages), len(report.Stages))
+	for i, name := range expectedStages {
+		testutil.AssertEqual(t, name, report.Stages[i].Name)
+		testutil.AssertEqual(t, 24, report.Stages[i].Ops)
+	}
+}
+
+func TestParseStage_ParsesEachRun(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	testutil.AssertNoError(t, WriteSyntheticMonorepo(tmpDir, 2))
+	files, err := DiscoverActionFiles(tmpDir, true)
+	testutil.AssertNoError(t, err)
+
+	var actions []*ActionYML
+	stage := parseStage(files, &actions)
+	ops, err := stage()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, ops)
+	first := reflect.ValueOf(actions[0].Inputs).Pointer()
+
+	_, err = stage()
+	testutil.AssertNoError(t, err)
+	// Cache hits share their maps with the first parse, fresh parses do not
+	if reflect.ValueOf(actions[0].Inputs).Pointer() == first {
+		t.Error("second run returned the cached action instead of parsing the file again")
+	}
+}
+
+func TestRunBenchmark_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	_, err := RunBenchmark(BenchmarkOptions{Actions: 0})
+	testutil.AssertError(t, err)
+}
+
+func TestBenchmarkReport_CheckThresholds(t *testing.T) {
+	t.Parallel()
+
+	report := &BenchmarkReport{
+		Stages: []BenchmarkStage{
+			{Name: BenchStageParse, Ops: 100, Duration: time.Second},
+			{Name: BenchStageRender, Ops: 10, Duration: time.Second},
+		},
+	}
+
+	violations := report.CheckThresholds(map[string]float64{
+		BenchStageParse:  50,
+		BenchStageRender: 20,
+		"unknown":        1,
+	})
+
+	testutil.AssertEqual(t, 2, len(violations))
+	testutil.AssertStringContains(t, violations[0], BenchStageRender)
+	testutil.AssertStringContains(t, violations[1], "unknown stage")
+}
+
+func BenchmarkParseActionYML(b *testing.B) {
+	tmpDir := b.TempDir()
+	if err := WriteSyntheticMonorepo(tmpDir, 1); err != nil {
+		b.Fatal(err)
+	}
+	files, err := DiscoverActionFiles(tmpDir, true)
+	if err != nil || len(files) != 1 {
+		b.Fatalf("failed to discover synthetic action: %v", err)
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		clearParsedActions() // Measure parsing, not cache lookups
+		if _, err := ParseActionYML(files[0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRenderReadme(b *testing.B) {
+	tmpDir := b.TempDir()
+	if err := WriteSyntheticMonorepo(tmpDir, 1); err != nil {
+		b.Fatal(err)
+	}
+	action, err := ParseActionYML(filepath.Join(tmpDir, "packages", "group-000", "action-0000", ActionFileNameYML))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, theme := range []string{ThemeDefault, ThemeGitHub, ThemeProfessional} {
+		b.Run(theme, func(b *testing.B) {
+			render := renderStage([]*ActionYML{action}, theme)
+			for range b.N {
+				if _, err := render(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDiscoverActionFiles(b *testing.B) {
+	tmpDir := b.TempDir()
+	if err := WriteSyntheticMonorepo(tmpDir, 500); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		files, err := DiscoverActionFiles(tmpDir, true)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(files) != 500 {
+			b.Fatalf("expected 500 files, got %d", len(files))
+		}
+	}
+}
+
+func BenchmarkValidateActionYML(b *testing.B) {
+	action := &ActionYML{
+		Name:        "Benchmark",
+		Description: strings.Repeat("description ", 10),
+		Runs:        map[string]any{"using": "node20", "main": "index.js"},
+	}
+
+	for range b.N {
+		_ = ValidateActionYML(action)
+	}
+}
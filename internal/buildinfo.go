@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/cache"
+	"github.com/ivuorinen/gh-action-readme/templates_embed"
+)
+
+// Features reported by BuildInfo.
+const (
+	// FeatureEmbeddedTemplates is set when the built-in themes are embedded in the binary.
+	FeatureEmbeddedTemplates = "embedded_templates"
+	// FeatureOfflineCache is set when a cache warmed with `cache warm` serves dependency lookups offline.
+	FeatureOfflineCache = "offline_cache"
+	// FeatureCacheLocking is set when processes sharing a cache directory are serialized with file locks.
+	FeatureCacheLocking = "cache_locking"
+)
+
+// Placeholders of build metadata not set at build time.
+const (
+	devVersion     = "dev"
+	unknownCommit  = "none"
+	unknownBuildAt = "unknown"
+)
+
+// pseudoVersion matches the timestamp and commit suffix of Go module pseudo-versions,
+// such as v0.0.0-20250807103000-a1b2c3d4e5f6.
+var pseudoVersion = regexp.MustCompile(`\d{14}-[0-9a-f]{12}$`)
+
+// BuildInfo describes the running binary for `version --json` and `about --json`.
+type BuildInfo struct {
+	Name      string          `json:"name"`
+	URL       string          `json:"url"`
+	Version   string          `json:"version"`
+	Commit    string          `json:"commit"`
+	Date      string          `json:"date"`
+	BuiltBy   string          `json:"built_by"`
+	GoVersion string          `json:"go_version"`
+	Platform  string          `json:"platform"` // GOOS/GOARCH
+	Features  map[string]bool `json:"features"`
+}
+
+// NewBuildInfo returns the build information of the running binary. Metadata
+// not set at build time, as with `go install`, is taken from the module and
+// VCS information recorded by the Go toolchain when available.
+func NewBuildInfo(version, commit, date, builtBy string) BuildInfo {
+	info := BuildInfo{
+		Name:      toolName,
+		URL:       toolURL,
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		BuiltBy:   builtBy,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		Features: map[string]bool{
+			FeatureEmbeddedTemplates: templates_embed.IsEmbeddedTemplateAvailable(TemplatePathDefault),
+			FeatureOfflineCache:      true,
+			FeatureCacheLocking:      cache.FileLockingSupported(),
+		},
+	}
+	if module, ok := debug.ReadBuildInfo(); ok {
+		info.fillFromModule(module)
+	}
+
+	return info
+}
+
+// fillFromModule fills the version, commit and date placeholders from the
+// module version and the vcs.revision and vcs.time build settings. Only
+// tagged releases replace the version; local builds stay "dev".
+func (b *BuildInfo) fillFromModule(module *debug.BuildInfo) {
+	if b.Version == devVersion && isReleaseVersion(module.Main.Version) {
+		b.Version = module.Main.Version
+	}
+	for _, setting := range module.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && b.Commit == unknownCommit:
+			b.Commit = setting.Value
+		case setting.Key == "vcs.time" && b.Date == unknownBuildAt:
+			b.Date = setting.Value
+		}
+	}
+}
+
+// isReleaseVersion reports whether version is a module version of a tagged
+// release rather than a pseudo-version or a build from a modified tree.
+func isReleaseVersion(version string) bool {
+	if version == "" || version == "(devel)" || strings.Contains(version, "+") {
+		return false
+	}
+
+	return !pseudoVersion.MatchString(version)
+}
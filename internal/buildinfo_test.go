@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestNewBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	info := NewBuildInfo("1.2.0", "abc123", "2025-08-07T10:30:00Z", "goreleaser")
+	testutil.AssertEqual(t, "1.2.0", info.Version)
+	testutil.AssertEqual(t, "abc123", info.Commit)
+	testutil.AssertEqual(t, "goreleaser", info.BuiltBy)
+	testutil.AssertEqual(t, runtime.Version(), info.GoVersion)
+	testutil.AssertEqual(t, runtime.GOOS+"/"+runtime.GOARCH, info.Platform)
+
+	for _, feature := range []string{FeatureEmbeddedTemplates, FeatureOfflineCache, FeatureCacheLocking} {
+		if _, ok := info.Features[feature]; !ok {
+			t.Errorf("feature %s missing from %v", feature, info.Features)
+		}
+	}
+}
+
+func TestBuildInfo_FillFromModule(t *testing.T) {
+	t.Parallel()
+
+	module := &debug.BuildInfo{
+		Main: debug.Module{Version: "v1.3.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "def456"},
+			{Key: "vcs.time", Value: "2025-09-01T00:00:00Z"},
+		},
+	}
+
+	info := BuildInfo{Version: devVersion, Commit: unknownCommit, Date: unknownBuildAt}
+	info.fillFromModule(module)
+	testutil.AssertEqual(t, "v1.3.0", info.Version)
+	testutil.AssertEqual(t, "def456", info.Commit)
+	testutil.AssertEqual(t, "2025-09-01T00:00:00Z", info.Date)
+
+	// Metadata set at build time is kept.
+	info = BuildInfo{Version: "1.2.0", Commit: "abc123", Date: "2025-08-07"}
+	info.fillFromModule(module)
+	testutil.AssertEqual(t, "1.2.0", info.Version)
+	testutil.AssertEqual(t, "abc123", info.Commit)
+	testutil.AssertEqual(t, "2025-08-07", info.Date)
+
+	for _, version := range []string{"(devel)", "v0.0.0-20250807103000-a1b2c3d4e5f6", "v1.3.0+dirty"} {
+		info = BuildInfo{Version: devVersion}
+		info.fillFromModule(&debug.BuildInfo{Main: debug.Module{Version: version}})
+		testutil.AssertEqual(t, devVersion, info.Version)
+	}
+}
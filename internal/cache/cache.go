@@ -4,12 +4,27 @@ package cache
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/adrg/xdg"
+
+	"github.com/ivuorinen/gh-action-readme/internal/clock"
+	"github.com/ivuorinen/gh-action-readme/internal/metrics"
+)
+
+// cacheFileName is the file in the cache directory that holds all entries.
+const cacheFileName = "cache.json"
+
+// Cache backends.
+const (
+	// BackendDisk persists entries in the XDG cache directory, shared between runs.
+	BackendDisk = "disk"
+	// BackendMemory keeps entries for the current run only.
+	BackendMemory = "memory"
 )
 
 // Entry represents a cached item with TTL support.
@@ -23,11 +38,14 @@ type Entry struct {
 type Cache struct {
 	path       string           // XDG cache directory
 	data       map[string]Entry // In-memory cache
+	deleted    map[string]bool  // Keys deleted since the last save
 	mutex      sync.RWMutex     // Thread safety
 	ticker     *time.Ticker     // Cleanup ticker
 	done       chan bool        // Cleanup shutdown
 	defaultTTL time.Duration    // Default TTL for entries
+	memoryOnly bool             // Entries are not read from or written to disk
 	saveWG     sync.WaitGroup   // Wait group for pending save operations
+	clock      clock.Clock      // Time source of expirations, nil for clock.Now
 }
 
 // Config represents cache configuration.
@@ -35,6 +53,8 @@ type Config struct {
 	DefaultTTL      time.Duration // Default TTL for entries
 	CleanupInterval time.Duration // How often to clean expired entries
 	MaxSize         int64         // Maximum cache size in bytes (0 = unlimited)
+	Backend         string        // BackendDisk (default) or BackendMemory
+	Clock           clock.Clock   // Time source of expirations, the installed clock when nil
 }
 
 // DefaultConfig returns default cache configuration.
@@ -43,6 +63,7 @@ func DefaultConfig() *Config {
 		DefaultTTL:      15 * time.Minute,  // 15 minutes for API responses
 		CleanupInterval: 5 * time.Minute,   // Clean up every 5 minutes
 		MaxSize:         100 * 1024 * 1024, // 100MB max cache size
+		Backend:         BackendDisk,
 	}
 }
 
@@ -52,26 +73,45 @@ func NewCache(config *Config) (*Cache, error) {
 		config = DefaultConfig()
 	}
 
-	// Get XDG cache directory
+	cacheDir, err := DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return newCacheInDir(cacheDir, config)
+}
+
+// DefaultDir returns the XDG cache directory where NewCache persists entries.
+func DefaultDir() (string, error) {
 	cacheDir, err := xdg.CacheFile("gh-action-readme")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get XDG cache directory: %w", err)
+		return "", fmt.Errorf("failed to get XDG cache directory: %w", err)
 	}
 
+	return filepath.Dir(cacheDir), nil
+}
+
+// newCacheInDir creates a cache that persists its entries in dir.
+func newCacheInDir(dir string, config *Config) (*Cache, error) {
 	// Ensure cache directory exists
-	if err := os.MkdirAll(filepath.Dir(cacheDir), 0750); err != nil { // #nosec G301 -- cache directory permissions
+	if err := os.MkdirAll(dir, 0750); err != nil { // #nosec G301 -- cache directory permissions
 		return nil, fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
 	cache := &Cache{
-		path:       filepath.Dir(cacheDir),
+		path:       dir,
 		data:       make(map[string]Entry),
+		deleted:    make(map[string]bool),
 		defaultTTL: config.DefaultTTL,
+		memoryOnly: config.Backend == BackendMemory,
 		done:       make(chan bool),
+		clock:      config.Clock,
 	}
 
 	// Load existing cache from disk
-	_ = cache.loadFromDisk() // Log error but don't fail - we can start with empty cache
+	if !cache.memoryOnly {
+		_ = cache.loadFromDisk() // Log error but don't fail - we can start with empty cache
+	}
 
 	// Start cleanup goroutine
 	cache.ticker = time.NewTicker(config.CleanupInterval)
@@ -95,7 +135,7 @@ func (c *Cache) SetWithTTL(key string, value any, ttl time.Duration) error {
 
 	entry := Entry{
 		Value:     value,
-		ExpiresAt: time.Now().Add(ttl),
+		ExpiresAt: c.now().Add(ttl),
 		Size:      size,
 	}
 
@@ -114,15 +154,21 @@ func (c *Cache) Get(key string) (any, bool) {
 
 	entry, exists := c.data[key]
 	if !exists {
+		metrics.RecordCacheLookup(false)
+
 		return nil, false
 	}
 
 	// Check if expired
-	if time.Now().After(entry.ExpiresAt) {
+	if c.now().After(entry.ExpiresAt) {
 		// Remove expired entry (will be cleaned up by cleanup goroutine)
+		metrics.RecordCacheLookup(false)
+
 		return nil, false
 	}
 
+	metrics.RecordCacheLookup(true)
+
 	return entry.Value, true
 }
 
@@ -132,6 +178,7 @@ func (c *Cache) Delete(key string) {
 	defer c.mutex.Unlock()
 
 	delete(c.data, key)
+	c.deleted[key] = true
 	go func() {
 		_ = c.saveToDisk() // Async operation, error logged internally
 	}()
@@ -139,13 +186,29 @@ func (c *Cache) Delete(key string) {
 
 // Clear removes all entries from the cache.
 func (c *Cache) Clear() error {
+	if c.memoryOnly {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.data = make(map[string]Entry)
+
+		return nil
+	}
+
+	// The directory lock is always taken before the mutex to keep lock order consistent
+	lock, err := lockDir(c.path, true)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
 	c.data = make(map[string]Entry)
+	c.deleted = make(map[string]bool)
 
 	// Remove cache file
-	cacheFile := filepath.Join(c.path, "cache.json")
+	cacheFile := filepath.Join(c.path, cacheFileName)
 	if err := os.Remove(cacheFile); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to remove cache file: %w", err)
 	}
@@ -160,7 +223,7 @@ func (c *Cache) Stats() map[string]any {
 
 	var totalSize int64
 	expiredCount := 0
-	now := time.Now()
+	now := c.now()
 
 	for _, entry := range c.data {
 		totalSize += entry.Size
@@ -232,7 +295,7 @@ func (c *Cache) cleanup() {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	now := time.Now()
+	now := c.now()
 	for key, entry := range c.data {
 		if now.After(entry.ExpiresAt) {
 			delete(c.data, key)
@@ -245,46 +308,73 @@ func (c *Cache) cleanup() {
 
 // loadFromDisk loads cache data from disk.
 func (c *Cache) loadFromDisk() error {
-	cacheFile := filepath.Join(c.path, "cache.json")
+	// Writers replace the file atomically, so a cache directory that cannot be
+	// locked, such as a read-only one, is still read
+	if lock, err := lockDir(c.path, false); err == nil {
+		defer func() { _ = lock.Unlock() }()
+	}
 
-	data, err := os.ReadFile(cacheFile) // #nosec G304 -- cache file path constructed internally
+	data, err := readCacheFile(filepath.Join(c.path, cacheFileName))
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil // No cache file is fine
-		}
-
-		return fmt.Errorf("failed to read cache file: %w", err)
+		return err
 	}
 
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-
-	if err := json.Unmarshal(data, &c.data); err != nil {
-		return fmt.Errorf("failed to unmarshal cache data: %w", err)
-	}
+	c.data = data
 
 	return nil
 }
 
-// saveToDisk persists cache data to disk.
+// saveToDisk persists cache data to disk. While holding the cache directory lock
+// it merges the unexpired entries other processes saved in the meantime, so
+// concurrent invocations sharing a cache do not drop each other's entries, and
+// replaces the cache file atomically.
 func (c *Cache) saveToDisk() error {
+	if c.memoryOnly {
+		return nil
+	}
+
+	lock, err := lockDir(c.path, true)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	cacheFile := filepath.Join(c.path, cacheFileName)
+	data, err := readCacheFile(cacheFile)
+	if err != nil {
+		data = make(map[string]Entry) // A corrupt cache file is replaced
+	}
+	now := c.now()
+	for key, entry := range data {
+		if now.After(entry.ExpiresAt) {
+			delete(data, key)
+		}
+	}
+
 	c.mutex.RLock()
-	data := make(map[string]Entry)
-	for k, v := range c.data {
-		data[k] = v
+	deleted := maps.Clone(c.deleted)
+	for key := range deleted {
+		delete(data, key)
 	}
+	maps.Copy(data, c.data)
 	c.mutex.RUnlock()
 
 	jsonData, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal cache data: %w", err)
 	}
-
-	cacheFile := filepath.Join(c.path, "cache.json")
-	if err := os.WriteFile(cacheFile, jsonData, 0600); err != nil { // #nosec G306 -- cache file permissions
+	if err := writeFileAtomic(cacheFile, jsonData, 0600); err != nil {
 		return fmt.Errorf("failed to write cache file: %w", err)
 	}
 
+	c.mutex.Lock()
+	for key := range deleted {
+		delete(c.deleted, key)
+	}
+	c.mutex.Unlock()
+
 	return nil
 }
 
@@ -298,6 +388,15 @@ func (c *Cache) saveToDiskAsync() {
 	}()
 }
 
+// now returns the current time of the cache's clock.
+func (c *Cache) now() time.Time {
+	if c.clock != nil {
+		return c.clock.Now()
+	}
+
+	return clock.Now()
+}
+
 // estimateSize provides a rough estimate of the memory size of a value.
 func (c *Cache) estimateSize(value any) int64 {
 	// This is a simple estimation - could be improved with reflection
@@ -308,3 +407,21 @@ func (c *Cache) estimateSize(value any) int64 {
 
 	return int64(len(jsonData))
 }
+
+// readCacheFile reads the entries of a cache file. A missing file has no entries.
+func readCacheFile(path string) (map[string]Entry, error) {
+	data := make(map[string]Entry)
+	content, err := os.ReadFile(path) // #nosec G304 -- cache file path constructed internally
+	if err != nil {
+		if os.IsNotExist(err) {
+			return data, nil // No cache file is fine
+		}
+
+		return nil, fmt.Errorf("failed to read cache file: %w", err)
+	}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cache data: %w", err)
+	}
+
+	return data, nil
+}
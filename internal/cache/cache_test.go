@@ -3,11 +3,13 @@ package cache
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/ivuorinen/gh-action-readme/internal/clock"
 	"github.com/ivuorinen/gh-action-readme/testutil"
 )
 
@@ -150,6 +152,28 @@ func TestCache_TTL(t *testing.T) {
 	}
 }
 
+func TestCache_TTLWithClock(t *testing.T) {
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	fixed := clock.NewFixed(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	config := DefaultConfig()
+	config.Clock = fixed
+	cache, err := newCacheInDir(tmpDir, config)
+	testutil.AssertNoError(t, err)
+	defer func() { _ = cache.Close() }()
+
+	testutil.AssertNoError(t, cache.SetWithTTL("key", "value", time.Hour))
+	fixed.Advance(59 * time.Minute)
+	if _, exists := cache.Get("key"); !exists {
+		t.Fatal("expected value to exist before its TTL")
+	}
+	fixed.Advance(2 * time.Minute)
+	if _, exists := cache.Get("key"); exists {
+		t.Error("expected value to be expired after its TTL")
+	}
+}
+
 func TestCache_GetOrSet(t *testing.T) {
 	tmpDir, cleanup := testutil.TempDir(t)
 	defer cleanup()
@@ -282,6 +306,118 @@ func TestCache_Persistence(t *testing.T) {
 	testutil.AssertEqual(t, "persistent-value", value)
 }
 
+func TestCache_MemoryBackend(t *testing.T) {
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	// Entries saved by a disk cache are not visible to a memory cache
+	diskCache := createTestCache(t, tmpDir)
+	testutil.AssertNoError(t, diskCache.Set("disk-key", "disk-value"))
+	testutil.AssertNoError(t, diskCache.Close())
+
+	config := DefaultConfig()
+	config.Backend = BackendMemory
+	memoryCache, err := NewCache(config)
+	testutil.AssertNoError(t, err)
+	if _, exists := memoryCache.Get("disk-key"); exists {
+		t.Error("expected memory cache not to load entries from disk")
+	}
+
+	testutil.AssertNoError(t, memoryCache.Set("memory-key", "memory-value"))
+	if value, exists := memoryCache.Get("memory-key"); !exists || value != "memory-value" {
+		t.Errorf("expected memory-value, got %v (exists %v)", value, exists)
+	}
+	testutil.AssertNoError(t, memoryCache.Clear())
+	testutil.AssertNoError(t, memoryCache.Close())
+
+	// Neither the memory entries nor Clear touched the cache file
+	reopened := createTestCache(t, tmpDir)
+	defer func() { _ = reopened.Close() }()
+	if _, exists := reopened.Get("memory-key"); exists {
+		t.Error("expected memory cache entries not to be written to disk")
+	}
+	if _, exists := reopened.Get("disk-key"); !exists {
+		t.Error("expected disk entries to survive clearing a memory cache")
+	}
+}
+
+// TestCache_ConcurrentWriters simulates several invocations sharing one cache
+// directory, such as CI matrix jobs on a shared cache volume.
+func TestCache_ConcurrentWriters(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	const numWriters = 5
+	const numEntries = 20
+
+	var wg sync.WaitGroup
+	for i := range numWriters {
+		wg.Add(1)
+		go func(writer int) {
+			defer wg.Done()
+
+			cache, err := newCacheInDir(dir, DefaultConfig())
+			if err != nil {
+				t.Errorf("failed to create cache: %v", err)
+
+				return
+			}
+			for j := range numEntries {
+				_ = cache.Set(fmt.Sprintf("writer-%d-key-%d", writer, j), j)
+			}
+			if err := cache.Close(); err != nil {
+				t.Errorf("failed to close cache: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	cache, err := newCacheInDir(dir, DefaultConfig())
+	testutil.AssertNoError(t, err)
+	defer func() { _ = cache.Close() }()
+
+	for i := range numWriters {
+		for j := range numEntries {
+			key := fmt.Sprintf("writer-%d-key-%d", i, j)
+			if _, exists := cache.Get(key); !exists {
+				t.Errorf("expected %s written by another cache instance to survive", key)
+			}
+		}
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(matches))
+}
+
+func TestCache_DeleteIsNotUndoneByMerge(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writer, err := newCacheInDir(dir, DefaultConfig())
+	testutil.AssertNoError(t, err)
+	_ = writer.Set("stale", "value")
+	_ = writer.Set("kept", "value")
+	testutil.AssertNoError(t, writer.Close())
+
+	deleter, err := newCacheInDir(dir, DefaultConfig())
+	testutil.AssertNoError(t, err)
+	deleter.Delete("stale")
+	testutil.AssertNoError(t, deleter.Close())
+
+	reader, err := newCacheInDir(dir, DefaultConfig())
+	testutil.AssertNoError(t, err)
+	defer func() { _ = reader.Close() }()
+
+	if _, exists := reader.Get("stale"); exists {
+		t.Error("expected deleted key to stay deleted")
+	}
+	if _, exists := reader.Get("kept"); !exists {
+		t.Error("expected other keys to be kept")
+	}
+}
+
 func TestCache_Clear(t *testing.T) {
 	tmpDir, cleanup := testutil.TempDir(t)
 	defer cleanup()
@@ -557,3 +693,49 @@ func createTestCache(t *testing.T, tmpDir string) *Cache {
 
 	return cache
 }
+
+func BenchmarkCache_SetGet(b *testing.B) {
+	b.Setenv("XDG_CACHE_HOME", b.TempDir())
+
+	cache, err := NewCache(DefaultConfig())
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer func() { _ = cache.Close() }()
+
+	value := map[string]string{"version": "v4.1.1", "sha": strings.Repeat("a", 40)}
+
+	b.ResetTimer()
+	for i := range b.N {
+		key := fmt.Sprintf("latest:owner/repo-%d", i%100)
+		if err := cache.Set(key, value); err != nil {
+			b.Fatal(err)
+		}
+		if _, ok := cache.Get(key); !ok {
+			b.Fatalf("expected cache hit for %s", key)
+		}
+	}
+}
+
+func BenchmarkCache_ConcurrentGet(b *testing.B) {
+	b.Setenv("XDG_CACHE_HOME", b.TempDir())
+
+	cache, err := NewCache(DefaultConfig())
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer func() { _ = cache.Close() }()
+
+	for i := range 100 {
+		_ = cache.Set(fmt.Sprintf("key-%d", i), i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_, _ = cache.Get(fmt.Sprintf("key-%d", i%100))
+			i++
+		}
+	})
+}
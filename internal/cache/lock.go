@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// lockFileName is the advisory lock file that serializes cache writes across processes.
+const lockFileName = "cache.lock"
+
+// FileLockingSupported reports whether processes sharing a cache directory are
+// serialized by advisory file locks on this platform.
+func FileLockingSupported() bool {
+	return fileLocking
+}
+
+// dirLock is an advisory lock on a cache directory, held through an open lock file.
+// It coordinates processes sharing a cache directory, such as CI matrix jobs on one
+// cache volume; goroutines of one process are serialized by the cache mutex.
+type dirLock struct {
+	file *os.File
+}
+
+// lockDir acquires the advisory lock of dir, exclusive for writers and shared for
+// readers, blocking until it is available.
+func lockDir(dir string, exclusive bool) (*dirLock, error) {
+	path := filepath.Join(dir, lockFileName)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600) // #nosec G304 -- lock file path constructed internally
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache lock: %w", err)
+	}
+	if err := lockFile(file, exclusive); err != nil {
+		_ = file.Close()
+
+		return nil, fmt.Errorf("failed to lock cache: %w", err)
+	}
+
+	return &dirLock{file: file}, nil
+}
+
+// Unlock releases the lock.
+func (l *dirLock) Unlock() error {
+	unlockErr := unlockFile(l.file)
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("failed to close cache lock: %w", err)
+	}
+	if unlockErr != nil {
+		return fmt.Errorf("failed to unlock cache: %w", unlockErr)
+	}
+
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it over
+// path, so readers see either the previous or the new content, never a partial write.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }() // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+//go:build !unix && !windows
+
+package cache
+
+import "os"
+
+// fileLocking reports that this platform has no advisory file locks.
+const fileLocking = false
+
+// lockFile is a no-op on platforms without advisory file locks; writes stay
+// atomic but concurrent processes may drop each other's new entries.
+func lockFile(_ *os.File, _ bool) error {
+	return nil
+}
+
+// unlockFile is a no-op on platforms without advisory file locks.
+func unlockFile(_ *os.File) error {
+	return nil
+}
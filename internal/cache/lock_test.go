@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestWriteFileAtomic_ConcurrentReaders(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), cacheFileName)
+	testutil.AssertNoError(t, writeFileAtomic(path, []byte(`{"value":""}`), 0600))
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func(writer int) {
+			defer wg.Done()
+			for range 25 {
+				value := strings.Repeat(string(rune('a'+writer)), 64*1024)
+				data, _ := json.Marshal(map[string]string{"value": value})
+				if err := writeFileAtomic(path, data, 0600); err != nil {
+					t.Errorf("write failed: %v", err)
+
+					return
+				}
+			}
+		}(i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	for {
+		select {
+		case <-done:
+			info, err := os.Stat(path)
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, os.FileMode(0600), info.Mode().Perm())
+
+			return
+		default:
+		}
+
+		content, err := os.ReadFile(path) // #nosec G304 -- test file path
+		testutil.AssertNoError(t, err)
+		var decoded map[string]string
+		if err := json.Unmarshal(content, &decoded); err != nil {
+			t.Fatalf("reader saw a partially written file: %v", err)
+		}
+	}
+}
+
+func TestLockDir_Exclusive(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	first, err := lockDir(dir, true)
+	testutil.AssertNoError(t, err)
+
+	acquired := make(chan *dirLock)
+	go func() {
+		second, err := lockDir(dir, true)
+		if err != nil {
+			t.Errorf("failed to lock: %v", err)
+		}
+		acquired <- second
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("expected the second lock to wait for the first")
+	default:
+	}
+
+	testutil.AssertNoError(t, first.Unlock())
+	second := <-acquired
+	testutil.AssertNoError(t, second.Unlock())
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/internal/cache"
+)
+
+// DefaultCacheTTL is how long GitHub API responses are cached by default.
+const DefaultCacheTTL = "1h"
+
+// cacheBackends are the valid values of cache.backend.
+var cacheBackends = []string{cache.BackendDisk, cache.BackendMemory}
+
+// CacheSettings configures the dependency cache. Empty fields use the defaults.
+type CacheSettings struct {
+	TTL     string `mapstructure:"ttl"     yaml:"ttl,omitempty"`     // Lifetime of cached API responses, such as "24h"
+	Backend string `mapstructure:"backend" yaml:"backend,omitempty"` // "disk" (shared between runs) or "memory"
+}
+
+// DefaultCacheSettings returns the default cache settings.
+func DefaultCacheSettings() CacheSettings {
+	return CacheSettings{
+		TTL:     DefaultCacheTTL,
+		Backend: cache.BackendDisk,
+	}
+}
+
+// ValidateCacheSettings rejects TTLs that are not positive durations and unknown backends.
+func ValidateCacheSettings(settings CacheSettings) error {
+	if settings.TTL != "" {
+		ttl, err := time.ParseDuration(settings.TTL)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid cache.ttl '%s', must be a positive duration such as 30m or 24h", settings.TTL)
+		}
+	}
+	if settings.Backend != "" && !containsString(cacheBackends, settings.Backend) {
+		return fmt.Errorf("invalid cache.backend '%s', must be one of: %s",
+			settings.Backend, strings.Join(cacheBackends, ", "))
+	}
+
+	return nil
+}
+
+// TTLDuration returns the cache TTL, or the default for an empty or invalid TTL.
+func (s CacheSettings) TTLDuration() time.Duration {
+	if ttl, err := time.ParseDuration(s.TTL); err == nil && ttl > 0 {
+		return ttl
+	}
+	ttl, _ := time.ParseDuration(DefaultCacheTTL)
+
+	return ttl
+}
+
+// NewCacheConfig returns the configuration of the dependency cache for settings.
+func NewCacheConfig(settings CacheSettings) *cache.Config {
+	config := cache.DefaultConfig()
+	config.DefaultTTL = settings.TTLDuration()
+	if settings.Backend != "" {
+		config.Backend = settings.Backend
+	}
+
+	return config
+}
@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/internal/cache"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestValidateCacheSettings(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, ValidateCacheSettings(CacheSettings{}))
+	testutil.AssertNoError(t, ValidateCacheSettings(CacheSettings{TTL: "24h", Backend: cache.BackendMemory}))
+
+	err := ValidateCacheSettings(CacheSettings{TTL: "-5m"})
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "cache.ttl")
+
+	err = ValidateCacheSettings(CacheSettings{Backend: "redis"})
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "cache.backend")
+}
+
+func TestNewCacheConfig(t *testing.T) {
+	t.Parallel()
+
+	config := NewCacheConfig(CacheSettings{TTL: "24h", Backend: cache.BackendMemory})
+	testutil.AssertEqual(t, 24*time.Hour, config.DefaultTTL)
+	testutil.AssertEqual(t, cache.BackendMemory, config.Backend)
+
+	// Empty settings use the defaults
+	config = NewCacheConfig(CacheSettings{})
+	testutil.AssertEqual(t, time.Hour, config.DefaultTTL)
+	testutil.AssertEqual(t, cache.BackendDisk, config.Backend)
+}
@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+)
+
+// shortHashLength is the number of characters shown for commit hashes.
+const shortHashLength = 7
+
+// DocumentedInterfaceDiff compares the current action with the version of the
+// action that existed when docPath was last committed. It returns the diff and
+// the commit the documentation was generated from.
+func DocumentedInterfaceDiff(action *ActionYML, actionPath, docPath string) (*InterfaceDiff, string, error) {
+	actionPath, err := filepath.Abs(actionPath)
+	if err != nil {
+		return nil, "", err
+	}
+	docPath, err = filepath.Abs(docPath)
+	if err != nil {
+		return nil, "", err
+	}
+	repoRoot, err := git.FindRepositoryRoot(actionPath)
+	if err != nil {
+		return nil, "", err
+	}
+	commit, err := git.LastCommit(repoRoot, docPath)
+	if err != nil {
+		return nil, "", err
+	}
+	documented, err := LoadActionAtRef(repoRoot, commit, actionPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return DiffInterfaces(documented, action), commit, nil
+}
+
+// checkOutput compares generated content with the file on disk, ignoring the
+// attribution line, and, when the documentation is stale, explains which
+// interface changes caused it.
+func (g *Generator) checkOutput(action *ActionYML, actionPath, outputPath, content string) error {
+	existing, err := os.ReadFile(outputPath) // #nosec G304 -- output path from configuration
+	switch {
+	case err == nil && stripAttribution(string(existing)) == stripAttribution(content):
+		if g.Config.Verbose {
+			g.Output.Success("Up to date: %s", outputPath)
+		}
+
+		return nil
+	case os.IsNotExist(err):
+		g.Output.Warning("Missing: %s", outputPath)
+		recordStaleDiagnostic(DiagnosticCodeMissingDocumentation, "Generated documentation is missing", outputPath)
+	case err != nil:
+		return fmt.Errorf("failed to read %s: %w", outputPath, err)
+	default:
+		g.Output.Warning("Out of date: %s", outputPath)
+		g.showStaleReason(action, actionPath, outputPath)
+		recordStaleDiagnostic(DiagnosticCodeStaleDocumentation, "Generated documentation is out of date", outputPath)
+	}
+
+	return fmt.Errorf("%w: %s", ErrStaleDocumentation, outputPath)
+}
+
+// showStaleReason prints the interface changes since the documentation was last
+// committed, falling back to a generic note when no history is available.
+func (g *Generator) showStaleReason(action *ActionYML, actionPath, outputPath string) {
+	diff, commit, err := DocumentedInterfaceDiff(action, actionPath, outputPath)
+	if err != nil {
+		g.Output.Printf("  Generated output differs (no history to compare the interface: %v)\n", err)
+
+		return
+	}
+	if len(commit) > shortHashLength {
+		commit = commit[:shortHashLength]
+	}
+	if len(diff.Changes) == 0 {
+		g.Output.Printf("  Interface unchanged since %s; the differences come from templates, "+
+			"configuration or manual edits\n", commit)
+
+		return
+	}
+
+	g.Output.Printf("  Interface changes since the docs were last updated (%s):\n", commit)
+	for _, change := range diff.Changes {
+		g.Output.Printf("    %-5s  %s\n", change.Level, change.Message)
+	}
+}
+
+// recordStaleDiagnostic records documentation found missing or out of date by --check.
+func recordStaleDiagnostic(code, message, outputPath string) {
+	RecordDiagnostic(Diagnostic{
+		Severity:    SeverityError,
+		Code:        code,
+		Source:      DiagnosticSourceGeneration,
+		Message:     message,
+		File:        outputPath,
+		Suggestions: []string{"Run gh-action-readme gen to regenerate the documentation"},
+	})
+}
@@ -0,0 +1,192 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+)
+
+// Checks run by check-all, in order.
+const (
+	CheckValidation = "validation"
+	CheckDocs       = "docs"
+	CheckSecurity   = "security"
+	CheckLint       = "lint"
+)
+
+// Exit codes of check-all. Each failed check sets its own bit, so the exit
+// code of a run tells which checks failed; 1 means the checks could not run.
+const (
+	ExitOK               = 0
+	ExitError            = 1
+	ExitValidationFailed = 2
+	ExitDocsStale        = 4
+	ExitSecurityFailed   = 8
+	ExitLintFailed       = 16
+)
+
+// CheckStatus is the outcome of a check.
+type CheckStatus string
+
+// Check outcomes.
+const (
+	CheckPassed  CheckStatus = "pass"
+	CheckWarned  CheckStatus = "warn" // Findings that do not fail the check
+	CheckFailed  CheckStatus = "fail"
+	CheckSkipped CheckStatus = "skip"
+)
+
+// checkExitCodes maps checks to the exit code bit set when they fail.
+var checkExitCodes = map[string]int{
+	CheckValidation: ExitValidationFailed,
+	CheckDocs:       ExitDocsStale,
+	CheckSecurity:   ExitSecurityFailed,
+	CheckLint:       ExitLintFailed,
+}
+
+// CheckResult is the outcome of one check of check-all.
+type CheckResult struct {
+	Check   string      `json:"check"`
+	Status  CheckStatus `json:"status"`
+	Details string      `json:"details"`
+}
+
+// CheckAllReport holds the results of the checks of check-all.
+type CheckAllReport struct {
+	Results []CheckResult `json:"results"`
+}
+
+// ExitCode combines the exit codes of the failed checks.
+func (r CheckAllReport) ExitCode() int {
+	code := ExitOK
+	for _, result := range r.Results {
+		if result.Status == CheckFailed {
+			code |= checkExitCodes[result.Check]
+		}
+	}
+
+	return code
+}
+
+// Failed reports whether any check failed.
+func (r CheckAllReport) Failed() bool {
+	return r.ExitCode() != ExitOK
+}
+
+// CheckAll validates the action files, checks that their documentation is up
+// to date, that their dependencies are pinned and reports lint warnings. The
+// files are validated once for the validation and lint checks. Lint warnings
+// fail only in strict mode, floating dependencies only under deps.fail_on. A
+// nil analyzer skips the security check.
+func (g *Generator) CheckAll(paths []string, analyzer *dependencies.Analyzer) CheckAllReport {
+	var report CheckAllReport
+
+	g.Output.Bold("\n▶ Validation")
+	results, parseErrors := g.validateFiles(paths, nil)
+	for _, result := range results {
+		for _, finding := range result.Findings {
+			RecordDiagnostic(FindingDiagnostic(result.File, finding))
+		}
+	}
+	if !g.Config.Quiet {
+		g.reportValidationResults(results, parseErrors)
+	}
+	report.Results = append(report.Results, validationCheck(results, parseErrors))
+
+	g.Output.Bold("\n▶ Documentation")
+	report.Results = append(report.Results, g.docsCheck(paths))
+
+	g.Output.Bold("\n▶ Dependency security")
+	report.Results = append(report.Results, g.securityCheck(paths, analyzer), g.lintCheck(results))
+
+	return report
+}
+
+// validationCheck fails when a file cannot be parsed or has validation errors.
+func validationCheck(results []ValidationResult, parseErrors []string) CheckResult {
+	failed := len(parseErrors)
+	for _, result := range results {
+		if result.HasErrors() {
+			failed++
+		}
+	}
+	total := len(results) + len(parseErrors)
+	if failed > 0 {
+		return CheckResult{CheckValidation, CheckFailed, fmt.Sprintf("%d of %d files have errors", failed, total)}
+	}
+
+	return CheckResult{CheckValidation, CheckPassed, fmt.Sprintf("%d files valid", total)}
+}
+
+// lintCheck reports the validation warnings, which fail in strict mode.
+func (g *Generator) lintCheck(results []ValidationResult) CheckResult {
+	warnings := 0
+	for _, result := range results {
+		warnings += result.Count(SeverityWarning)
+	}
+	switch {
+	case warnings == 0:
+		return CheckResult{CheckLint, CheckPassed, "no warnings"}
+	case g.Config.Strict:
+		return CheckResult{CheckLint, CheckFailed, fmt.Sprintf("%d warnings (strict)", warnings)}
+	default:
+		return CheckResult{CheckLint, CheckWarned, fmt.Sprintf("%d warnings", warnings)}
+	}
+}
+
+// docsCheck checks that the generated documentation is up to date without
+// writing it, like gen --check.
+func (g *Generator) docsCheck(paths []string) CheckResult {
+	checker := *g
+	checker.Check = true
+	checker.KeepGoing = true
+	err := checker.ProcessBatch(paths)
+	switch {
+	case err == nil:
+		return CheckResult{CheckDocs, CheckPassed, "documentation up to date"}
+	case errors.Is(err, ErrStaleDocumentation):
+		return CheckResult{CheckDocs, CheckFailed, "documentation out of date; run gen to update"}
+	default:
+		return CheckResult{CheckDocs, CheckFailed, err.Error()}
+	}
+}
+
+// securityCheck counts the floating dependencies of the action files and
+// applies the deps.fail_on policy, like deps security.
+func (g *Generator) securityCheck(paths []string, analyzer *dependencies.Analyzer) CheckResult {
+	if analyzer == nil {
+		return CheckResult{CheckSecurity, CheckSkipped, "dependency analyzer unavailable"}
+	}
+
+	pinned, floating := 0, 0
+	var all []dependencies.Dependency
+	for _, path := range paths {
+		deps, err := analyzer.AnalyzeActionFile(path)
+		if err != nil {
+			continue
+		}
+		all = append(all, deps...)
+		for _, dep := range deps {
+			if dep.IsPinned {
+				pinned++
+
+				continue
+			}
+			floating++
+			g.Output.Warning("Floating dependency %s@%s in %s", dep.Name, dep.Version, path)
+		}
+	}
+
+	details := fmt.Sprintf("%d pinned, %d floating", pinned, floating)
+	switch {
+	case g.Config.Deps.CheckSecurity(floating) != nil:
+		return CheckResult{CheckSecurity, CheckFailed, details + " (deps.fail_on: " + FailOnFloating + ")"}
+	case g.Config.Deps.CheckLatest(all) != nil:
+		return CheckResult{CheckSecurity, CheckFailed, details + " (deps.fail_on: " + FailOnLatest + ")"}
+	case floating > 0:
+		return CheckResult{CheckSecurity, CheckWarned, details}
+	default:
+		return CheckResult{CheckSecurity, CheckPassed, details}
+	}
+}
@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestCheckAllReport_ExitCode(t *testing.T) {
+	t.Parallel()
+
+	report := CheckAllReport{Results: []CheckResult{
+		{Check: CheckValidation, Status: CheckPassed},
+		{Check: CheckDocs, Status: CheckFailed},
+		{Check: CheckSecurity, Status: CheckSkipped},
+		{Check: CheckLint, Status: CheckWarned},
+	}}
+	testutil.AssertEqual(t, ExitDocsStale, report.ExitCode())
+
+	report.Results[3].Status = CheckFailed
+	testutil.AssertEqual(t, ExitDocsStale|ExitLintFailed, report.ExitCode())
+	testutil.AssertEqual(t, true, report.Failed())
+}
+
+func TestGenerator_CheckAll(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+
+	config := DefaultAppConfig()
+	config.Theme = ThemeMinimal
+	config.OutputDir = tmpDir
+	config.Quiet = true
+
+	statuses := func(report CheckAllReport) map[string]CheckStatus {
+		result := make(map[string]CheckStatus)
+		for _, check := range report.Results {
+			result[check.Check] = check.Status
+		}
+
+		return result
+	}
+
+	report := NewGenerator(config).CheckAll([]string{actionPath}, nil)
+	testutil.AssertEqual(t, 4, len(report.Results))
+	testutil.AssertEqual(t, CheckFailed, statuses(report)[CheckDocs])
+	testutil.AssertEqual(t, CheckPassed, statuses(report)[CheckValidation])
+	testutil.AssertEqual(t, CheckSkipped, statuses(report)[CheckSecurity])
+	testutil.AssertEqual(t, ExitDocsStale, report.ExitCode())
+
+	testutil.AssertNoError(t, NewGenerator(config).GenerateFromFile(actionPath))
+	report = NewGenerator(config).CheckAll([]string{actionPath}, nil)
+	testutil.AssertEqual(t, CheckPassed, statuses(report)[CheckDocs])
+	testutil.AssertEqual(t, ExitOK, report.ExitCode())
+
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/invalid/missing-description.yml"))
+	report = NewGenerator(config).CheckAll([]string{actionPath}, nil)
+	testutil.AssertEqual(t, CheckFailed, statuses(report)[CheckValidation])
+	testutil.AssertEqual(t, ExitValidationFailed, report.ExitCode()&ExitValidationFailed)
+}
@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// commitAll stages and commits every file in dir, initializing the repository if needed.
+func commitAll(t *testing.T, dir, message string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	commands := [][]string{
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", message},
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
+		commands = append([][]string{{"init", "-q"}}, commands...)
+	}
+	for _, args := range commands {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+}
+
+func TestGenerator_Check(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	testutil.SetupTestTemplates(t, tmpDir)
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+
+	config := &AppConfig{
+		OutputFormat: "md",
+		OutputDir:    tmpDir,
+		Quiet:        true,
+		Template:     filepath.Join(tmpDir, "templates", "readme.tmpl"),
+	}
+	generator := NewGenerator(config)
+	generator.Check = true
+
+	err := generator.GenerateFromFile(actionPath)
+	if !errors.Is(err, ErrStaleDocumentation) {
+		t.Fatalf("expected stale documentation error for missing README, got %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(tmpDir, "README.md")); !os.IsNotExist(statErr) {
+		t.Fatal("check mode must not write README.md")
+	}
+
+	generator.Check = false
+	testutil.AssertNoError(t, generator.GenerateFromFile(actionPath))
+
+	generator.Check = true
+	testutil.AssertNoError(t, generator.GenerateFromFile(actionPath))
+	testutil.AssertNoError(t, generator.ProcessBatch([]string{actionPath}))
+
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "README.md"), "# Edited by hand\n")
+	err = generator.ProcessBatch([]string{actionPath})
+	if !errors.Is(err, ErrStaleDocumentation) {
+		t.Fatalf("expected stale documentation error for edited README, got %v", err)
+	}
+}
+
+func TestDocumentedInterfaceDiff(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	docPath := filepath.Join(tmpDir, "README.md")
+	testutil.WriteTestFile(t, actionPath, `name: Build
+description: Builds the project
+inputs:
+  token:
+    description: GitHub token
+    required: true
+runs:
+  using: node20
+  main: index.js
+`)
+	testutil.WriteTestFile(t, docPath, "# Build\n")
+	commitAll(t, tmpDir, "docs")
+
+	testutil.WriteTestFile(t, actionPath, `name: Build
+description: Builds the project
+inputs:
+  token:
+    description: GitHub token
+    required: true
+  cache:
+    description: Enable caching
+runs:
+  using: node20
+  main: index.js
+`)
+	commitAll(t, tmpDir, "add cache input")
+
+	action, err := ParseActionYML(actionPath)
+	testutil.AssertNoError(t, err)
+
+	diff, commit, err := DocumentedInterfaceDiff(action, actionPath, docPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 40, len(commit))
+	testutil.AssertEqual(t, ChangeMinor, diff.Level)
+	testutil.AssertEqual(t, 1, len(diff.Changes))
+	testutil.AssertEqual(t, ChangeInputAdded, diff.Changes[0].Kind)
+
+	_, _, err = DocumentedInterfaceDiff(action, actionPath, filepath.Join(tmpDir, "untracked.md"))
+	testutil.AssertError(t, err)
+}
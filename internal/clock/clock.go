@@ -0,0 +1,148 @@
+// Package clock provides the current time and random identifiers used by
+// gh-action-readme. Code reads them through Now, Since and NewID instead of the
+// time and rand packages, so tests and reproducible runs can install fixed values.
+package clock
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// idBytes is the number of random bytes in an identifier from the system source.
+const idBytes = 8
+
+// Clock tells the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// IDSource generates identifiers, such as cache keys and run IDs.
+type IDSource interface {
+	NewID() string
+}
+
+// System is the real clock and a random IDSource backed by crypto/rand.
+type System struct{}
+
+// Now returns the current time.
+func (System) Now() time.Time {
+	return time.Now()
+}
+
+// NewID returns 16 random hex characters.
+func (System) NewID() string {
+	buf := make([]byte, idBytes)
+	_, _ = rand.Read(buf) // crypto/rand.Read never returns an error
+
+	return hex.EncodeToString(buf)
+}
+
+// Fixed is a clock that stands still until it is set or advanced. It is safe
+// for concurrent use.
+type Fixed struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFixed returns a clock fixed at t.
+func NewFixed(t time.Time) *Fixed {
+	return &Fixed{now: t}
+}
+
+// Now returns the time the clock is fixed at.
+func (f *Fixed) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+// Set moves the clock to t.
+func (f *Fixed) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+}
+
+// Advance moves the clock forward by d.
+func (f *Fixed) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// Sequence is an IDSource returning prefix-1, prefix-2, and so on. It is safe
+// for concurrent use.
+type Sequence struct {
+	prefix string
+	next   atomic.Uint64
+}
+
+// NewSequence returns a Sequence whose identifiers start with prefix.
+func NewSequence(prefix string) *Sequence {
+	return &Sequence{prefix: prefix}
+}
+
+// NewID returns the next identifier of the sequence.
+func (s *Sequence) NewID() string {
+	return fmt.Sprintf("%s-%d", s.prefix, s.next.Add(1))
+}
+
+// The clock and identifier source used by the package functions.
+var (
+	current atomic.Pointer[Clock]
+	ids     atomic.Pointer[IDSource]
+)
+
+// Now returns the current time of the installed clock.
+func Now() time.Time {
+	if c := current.Load(); c != nil {
+		return (*c).Now()
+	}
+
+	return time.Now()
+}
+
+// Since returns the time elapsed since t on the installed clock.
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+// NewID returns an identifier from the installed source.
+func NewID() string {
+	if s := ids.Load(); s != nil {
+		return (*s).NewID()
+	}
+
+	return System{}.NewID()
+}
+
+// Set installs c as the clock of Now and Since and returns a function that
+// restores the previous one. A nil c installs the system clock.
+func Set(c Clock) (restore func()) {
+	var previous *Clock
+	if c == nil {
+		previous = current.Swap(nil)
+	} else {
+		previous = current.Swap(&c)
+	}
+
+	return func() { current.Store(previous) }
+}
+
+// SetIDSource installs s as the source of NewID and returns a function that
+// restores the previous one. A nil s installs the random system source.
+func SetIDSource(s IDSource) (restore func()) {
+	var previous *IDSource
+	if s == nil {
+		previous = ids.Swap(nil)
+	} else {
+		previous = ids.Swap(&s)
+	}
+
+	return func() { ids.Store(previous) }
+}
@@ -0,0 +1,71 @@
+package clock
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFixed(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	fixed := NewFixed(start)
+	if got := fixed.Now(); !got.Equal(start) {
+		t.Fatalf("Now() = %v, want %v", got, start)
+	}
+
+	fixed.Advance(time.Hour)
+	if got := fixed.Now(); !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("Now() after Advance = %v, want %v", got, start.Add(time.Hour))
+	}
+	fixed.Set(start)
+	if got := fixed.Now(); !got.Equal(start) {
+		t.Errorf("Now() after Set = %v, want %v", got, start)
+	}
+}
+
+func TestSet(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	restore := Set(NewFixed(start))
+	if got := Now(); !got.Equal(start) {
+		t.Errorf("Now() = %v, want the installed clock's %v", got, start)
+	}
+	if got := Since(start.Add(-time.Minute)); got != time.Minute {
+		t.Errorf("Since() = %v, want 1m0s", got)
+	}
+
+	restore()
+	if Now().Equal(start) {
+		t.Error("expected the system clock after restore")
+	}
+}
+
+func TestNewID(t *testing.T) {
+	if first, second := NewID(), NewID(); len(first) != 2*idBytes || first == second {
+		t.Errorf("expected distinct random IDs of %d characters, got %q and %q", 2*idBytes, first, second)
+	}
+
+	restore := SetIDSource(NewSequence("run"))
+	defer restore()
+	for _, want := range []string{"run-1", "run-2"} {
+		if got := NewID(); got != want {
+			t.Errorf("NewID() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestSequence_Concurrent(t *testing.T) {
+	sequence := NewSequence("id")
+	seen := sync.Map{}
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, duplicate := seen.LoadOrStore(sequence.NewID(), true); duplicate {
+				t.Error("expected unique IDs from concurrent callers")
+			}
+		}()
+	}
+	wg.Wait()
+}
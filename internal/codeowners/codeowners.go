@@ -0,0 +1,196 @@
+// Package codeowners parses CODEOWNERS files and resolves the owners of a path.
+package codeowners
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// Locations are the paths, relative to the repository root, where GitHub looks
+// for a CODEOWNERS file, in order of precedence.
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Rule assigns owners to the paths matching a pattern.
+type Rule struct {
+	Pattern string
+	Owners  []string
+	Line    int
+
+	re *regexp.Regexp
+}
+
+// File is a parsed CODEOWNERS file. The zero value has no rules.
+type File struct {
+	Rules []Rule
+}
+
+// Parse reads a CODEOWNERS file. Blank lines and comments are skipped.
+func Parse(r io.Reader) (*File, error) {
+	file := &File{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(stripComment(scanner.Text()))
+		if len(fields) == 0 {
+			continue
+		}
+
+		re, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", line, fields[0], err)
+		}
+		file.Rules = append(file.Rules, Rule{Pattern: fields[0], Owners: fields[1:], Line: line, re: re})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CODEOWNERS: %w", err)
+	}
+
+	return file, nil
+}
+
+// Load reads the CODEOWNERS file of the repository at repoRoot. A repository
+// without one gets an empty File.
+func Load(repoRoot string) (*File, error) {
+	for _, location := range Locations {
+		f, err := os.Open(filepath.Join(repoRoot, filepath.FromSlash(location))) // #nosec G304 -- fixed locations
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+
+		return Parse(f)
+	}
+
+	return &File{}, nil
+}
+
+// Fetch downloads and parses the CODEOWNERS file of owner/repo from its default
+// branch. A repository without one gets an empty File.
+func Fetch(ctx context.Context, client *github.Client, owner, repo string) (*File, error) {
+	for _, location := range Locations {
+		content, _, _, err := client.Repositories.GetContents(ctx, owner, repo, location, nil)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s/%s/%s: %w", owner, repo, location, err)
+		}
+		if content == nil {
+			continue
+		}
+
+		text, err := content.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s/%s/%s: %w", owner, repo, location, err)
+		}
+
+		return Parse(strings.NewReader(text))
+	}
+
+	return &File{}, nil
+}
+
+// Owners returns the owners of path, relative to the repository root with
+// forward slashes. As on GitHub, the last matching rule wins; nil means the
+// path has no owners.
+func (f *File) Owners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].re.MatchString(path) {
+			return f.Rules[i].Owners
+		}
+	}
+
+	return nil
+}
+
+// stripComment removes a trailing comment; escaped \# is kept as a literal #.
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '#':
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+// compilePattern converts a gitignore-style CODEOWNERS pattern into a regular
+// expression. Patterns with a leading or inner slash are anchored to the root,
+// others match at any depth. A pattern naming a directory matches everything
+// below it unless its last segment is a wildcard; a trailing slash matches
+// directories only.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	writePatternBody(&b, trimmed)
+	lastSegment := trimmed[strings.LastIndex(trimmed, "/")+1:]
+	switch {
+	case dirOnly:
+		b.WriteString("/.*$")
+	case strings.Contains(lastSegment, "*"):
+		// As on GitHub, docs/* matches the files in docs but not in its subdirectories
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
+
+// writePatternBody writes the regular expression for the wildcards and literal
+// characters of pattern.
+func writePatternBody(b *strings.Builder, pattern string) {
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+}
+
+// isNotFound reports whether err is a GitHub 404 response.
+func isNotFound(err error) bool {
+	var errResp *github.ErrorResponse
+
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
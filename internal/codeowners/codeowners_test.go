@@ -0,0 +1,97 @@
+package codeowners
+
+import (
+	"context"
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+const sample = `# Default owners
+*                   @acme/platform
+
+/actions/build/     @acme/build   # build tooling
+docs/*              docs@example.com
+**/deploy/action.yml @alice @bob
+*.md
+actions/\#hash      @hash
+`
+
+func TestFile_Owners(t *testing.T) {
+	t.Parallel()
+
+	file, err := Parse(strings.NewReader(sample))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 6, len(file.Rules))
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"action.yml", "@acme/platform"},
+		{"actions/build/action.yml", "@acme/build"},
+		{"actions/build/nested/action.yml", "@acme/build"},
+		{"other/actions/build/action.yml", "@acme/platform"},
+		{"docs/action.yml", "docs@example.com"},
+		{"docs/nested/action.yml", "@acme/platform"},
+		{"actions/deploy/action.yml", "@alice @bob"},
+		{"deploy/action.yml", "@alice @bob"},
+		{"actions/build/README.md", ""},
+		{"actions/#hash/action.yml", "@hash"},
+		{"/actions/build/action.yml", "@acme/build"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			testutil.AssertEqual(t, tt.expected, strings.Join(file.Owners(tt.path), " "))
+		})
+	}
+}
+
+func TestFile_OwnersWithoutRules(t *testing.T) {
+	t.Parallel()
+
+	if owners := (&File{}).Owners("action.yml"); owners != nil {
+		t.Errorf("expected no owners, got %v", owners)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	file, err := Load(tmpDir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(file.Rules))
+
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "docs", "CODEOWNERS"), "* @docs\n")
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, ".github", "CODEOWNERS"), "* @github\n")
+	file, err = Load(tmpDir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "@github", strings.Join(file.Owners("action.yml"), " "))
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	content := base64.StdEncoding.EncodeToString([]byte("* @acme/maintainers\n"))
+	client := testutil.MockGitHubClient(map[string]string{
+		"GET https://api.github.com/repos/acme/tool/contents/CODEOWNERS": `{"type": "file", "encoding": "base64", ` +
+			`"content": "` + content + `"}`,
+	})
+
+	file, err := Fetch(context.Background(), client, "acme", "tool")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "@acme/maintainers", strings.Join(file.Owners("action.yml"), " "))
+
+	file, err = Fetch(context.Background(), client, "acme", "missing")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(file.Rules))
+}
@@ -0,0 +1,322 @@
+package internal
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+)
+
+// ChangeLevel is the semantic versioning impact of an interface change.
+type ChangeLevel string
+
+// Change levels in increasing order of impact.
+const (
+	ChangeNone  ChangeLevel = "none"
+	ChangePatch ChangeLevel = "patch"
+	ChangeMinor ChangeLevel = "minor"
+	ChangeMajor ChangeLevel = "major"
+)
+
+// Interface change kinds.
+const (
+	ChangeInputRemoved      = "input-removed"
+	ChangeInputRenamed      = "input-renamed"
+	ChangeInputAdded        = "input-added"
+	ChangeInputRequired     = "input-now-required"
+	ChangeInputOptional     = "input-now-optional"
+	ChangeInputDefault      = "input-default-changed"
+	ChangeInputDescription  = "input-description-changed"
+	ChangeInputDeprecated   = "input-deprecated"
+	ChangeOutputRemoved     = "output-removed"
+	ChangeOutputAdded       = "output-added"
+	ChangeOutputDescription = "output-description-changed"
+	ChangeRuntime           = "runtime-changed"
+	ChangeActionDescription = "description-changed"
+)
+
+// changeLevelRank orders change levels by impact.
+var changeLevelRank = map[ChangeLevel]int{
+	ChangeNone:  0,
+	ChangePatch: 1,
+	ChangeMinor: 2,
+	ChangeMajor: 3,
+}
+
+// InterfaceChange describes one difference between two versions of an action interface.
+type InterfaceChange struct {
+	Kind    string      `json:"kind"`
+	Level   ChangeLevel `json:"level"`
+	Name    string      `json:"name,omitempty"`
+	Message string      `json:"message"`
+}
+
+// InterfaceDiff is the set of changes between two versions of an action interface.
+type InterfaceDiff struct {
+	Level   ChangeLevel       `json:"level"`
+	Changes []InterfaceChange `json:"changes"`
+}
+
+// Rank returns the position of the level in impact order, or -1 for unknown levels.
+func (l ChangeLevel) Rank() int {
+	if rank, ok := changeLevelRank[l]; ok {
+		return rank
+	}
+
+	return -1
+}
+
+// DiffInterfaces compares the public interface of two versions of an action and
+// classifies the overall change as major, minor or patch.
+func DiffInterfaces(oldAction, newAction *ActionYML) *InterfaceDiff {
+	diff := &InterfaceDiff{Level: ChangeNone, Changes: []InterfaceChange{}}
+
+	diffInputs(diff, oldAction.Inputs, newAction.Inputs)
+	diffOutputs(diff, oldAction.Outputs, newAction.Outputs)
+
+	oldRuntime, _ := oldAction.Runs["using"].(string)
+	newRuntime, _ := newAction.Runs["using"].(string)
+	if oldRuntime != newRuntime {
+		diff.add(InterfaceChange{
+			Kind:    ChangeRuntime,
+			Level:   ChangeMajor,
+			Message: fmt.Sprintf("Runtime changed from `%s` to `%s`", oldRuntime, newRuntime),
+		})
+	}
+	if oldAction.Description != newAction.Description {
+		diff.add(InterfaceChange{
+			Kind:    ChangeActionDescription,
+			Level:   ChangePatch,
+			Message: "Action description updated",
+		})
+	}
+
+	return diff
+}
+
+// LoadActionAtRef parses the action file at path as of the given git ref.
+// An empty ref reads the file from the working tree.
+func LoadActionAtRef(repoRoot, ref, path string) (*ActionYML, error) {
+	if ref == "" {
+		return ParseActionYML(path)
+	}
+
+	content, err := git.ShowFile(repoRoot, ref, path)
+	if err != nil {
+		return nil, err
+	}
+	action, err := ParseActionYMLContent(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s at %s: %w", path, ref, err)
+	}
+
+	return action, nil
+}
+
+// Breaking returns the changes that require a major version bump.
+func (d *InterfaceDiff) Breaking() []InterfaceChange {
+	return d.ByLevel(ChangeMajor)
+}
+
+// ByLevel returns the changes with the given level.
+func (d *InterfaceDiff) ByLevel(level ChangeLevel) []InterfaceChange {
+	var changes []InterfaceChange
+	for _, change := range d.Changes {
+		if change.Level == level {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// Markdown renders the diff as a release-notes section.
+func (d *InterfaceDiff) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Interface changes (%s)\n\n", d.Level))
+	if len(d.Changes) == 0 {
+		sb.WriteString("No interface changes.\n")
+
+		return sb.String()
+	}
+
+	sections := []struct {
+		title string
+		level ChangeLevel
+	}{
+		{"⚠️ Breaking changes", ChangeMajor},
+		{"✨ Non-breaking changes", ChangeMinor},
+		{"📝 Documentation changes", ChangePatch},
+	}
+	for _, section := range sections {
+		changes := d.ByLevel(section.level)
+		if len(changes) == 0 {
+			continue
+		}
+		sb.WriteString("### " + section.title + "\n\n")
+		for _, change := range changes {
+			sb.WriteString("- " + change.Message + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// add records a change and raises the overall level if needed.
+func (d *InterfaceDiff) add(change InterfaceChange) {
+	d.Changes = append(d.Changes, change)
+	if change.Level.Rank() > d.Level.Rank() {
+		d.Level = change.Level
+	}
+}
+
+// diffInputs records removed, renamed, added and modified inputs.
+func diffInputs(diff *InterfaceDiff, oldInputs, newInputs map[string]ActionInput) {
+	var removed, added []string
+	for _, name := range slices.Sorted(maps.Keys(oldInputs)) {
+		if _, ok := newInputs[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(newInputs)) {
+		if _, ok := oldInputs[name]; !ok {
+			added = append(added, name)
+		}
+	}
+
+	removed, added = detectRenames(diff, oldInputs, newInputs, removed, added)
+
+	for _, name := range removed {
+		diff.add(InterfaceChange{
+			Kind: ChangeInputRemoved, Level: ChangeMajor, Name: name,
+			Message: fmt.Sprintf("Input `%s` was removed", name),
+		})
+	}
+	for _, name := range added {
+		change := InterfaceChange{
+			Kind: ChangeInputAdded, Level: ChangeMinor, Name: name,
+			Message: fmt.Sprintf("New optional input `%s`", name),
+		}
+		if inputRequired(newInputs[name]) {
+			change.Level = ChangeMajor
+			change.Message = fmt.Sprintf("New required input `%s`", name)
+		}
+		diff.add(change)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(oldInputs)) {
+		if newInput, ok := newInputs[name]; ok {
+			diffInput(diff, name, oldInputs[name], newInput)
+		}
+	}
+}
+
+// detectRenames pairs removed and added inputs that share the same description
+// and records them as renames. The unpaired names are returned.
+func detectRenames(
+	diff *InterfaceDiff,
+	oldInputs, newInputs map[string]ActionInput,
+	removed, added []string,
+) ([]string, []string) {
+	var unmatched []string
+	for _, oldName := range removed {
+		description := strings.TrimSpace(oldInputs[oldName].Description)
+		index := slices.IndexFunc(added, func(newName string) bool {
+			return description != "" && strings.TrimSpace(newInputs[newName].Description) == description
+		})
+		if index < 0 {
+			unmatched = append(unmatched, oldName)
+
+			continue
+		}
+
+		diff.add(InterfaceChange{
+			Kind: ChangeInputRenamed, Level: ChangeMajor, Name: oldName,
+			Message: fmt.Sprintf("Input `%s` was renamed to `%s`", oldName, added[index]),
+		})
+		added = slices.Delete(added, index, index+1)
+	}
+
+	return unmatched, added
+}
+
+// diffInput records changes to an input present in both versions.
+func diffInput(diff *InterfaceDiff, name string, oldInput, newInput ActionInput) {
+	oldRequired, newRequired := inputRequired(oldInput), inputRequired(newInput)
+	switch {
+	case !oldRequired && newRequired:
+		diff.add(InterfaceChange{
+			Kind: ChangeInputRequired, Level: ChangeMajor, Name: name,
+			Message: fmt.Sprintf("Input `%s` is now required", name),
+		})
+	case oldRequired && !newRequired:
+		diff.add(InterfaceChange{
+			Kind: ChangeInputOptional, Level: ChangeMinor, Name: name,
+			Message: fmt.Sprintf("Input `%s` is no longer required", name),
+		})
+	}
+
+	oldDefault, newDefault := formatDefault(oldInput.Default), formatDefault(newInput.Default)
+	if oldDefault != newDefault {
+		diff.add(InterfaceChange{
+			Kind: ChangeInputDefault, Level: ChangeMinor, Name: name,
+			Message: fmt.Sprintf("Default of input `%s` changed from %s to %s", name, oldDefault, newDefault),
+		})
+	}
+	if oldInput.DeprecationMessage == "" && newInput.DeprecationMessage != "" {
+		diff.add(InterfaceChange{
+			Kind: ChangeInputDeprecated, Level: ChangeMinor, Name: name,
+			Message: fmt.Sprintf("Input `%s` is deprecated: %s", name, newInput.DeprecationMessage),
+		})
+	}
+	if oldInput.Description != newInput.Description {
+		diff.add(InterfaceChange{
+			Kind: ChangeInputDescription, Level: ChangePatch, Name: name,
+			Message: fmt.Sprintf("Description of input `%s` updated", name),
+		})
+	}
+}
+
+// diffOutputs records removed, added and modified outputs.
+func diffOutputs(diff *InterfaceDiff, oldOutputs, newOutputs map[string]ActionOutput) {
+	for _, name := range slices.Sorted(maps.Keys(oldOutputs)) {
+		newOutput, ok := newOutputs[name]
+		switch {
+		case !ok:
+			diff.add(InterfaceChange{
+				Kind: ChangeOutputRemoved, Level: ChangeMajor, Name: name,
+				Message: fmt.Sprintf("Output `%s` was removed", name),
+			})
+		case oldOutputs[name].Description != newOutput.Description:
+			diff.add(InterfaceChange{
+				Kind: ChangeOutputDescription, Level: ChangePatch, Name: name,
+				Message: fmt.Sprintf("Description of output `%s` updated", name),
+			})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(newOutputs)) {
+		if _, ok := oldOutputs[name]; !ok {
+			diff.add(InterfaceChange{
+				Kind: ChangeOutputAdded, Level: ChangeMinor, Name: name,
+				Message: fmt.Sprintf("New output `%s`", name),
+			})
+		}
+	}
+}
+
+// inputRequired reports whether callers must provide a value for the input.
+func inputRequired(input ActionInput) bool {
+	return input.Required && input.Default == nil
+}
+
+// formatDefault renders a default value for change messages.
+func formatDefault(value any) string {
+	if value == nil {
+		return "(none)"
+	}
+
+	return fmt.Sprintf("`%v`", value)
+}
@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestDiffInterfaces(t *testing.T) {
+	t.Parallel()
+
+	base := func() *ActionYML {
+		return &ActionYML{
+			Description: "Builds things",
+			Inputs: map[string]ActionInput{
+				"token": {Description: "GitHub token", Required: true},
+				"path":  {Description: "Working directory", Default: "."},
+			},
+			Outputs: map[string]ActionOutput{"result": {Description: "Build result"}},
+			Runs:    map[string]any{"using": "node20"},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(a *ActionYML)
+		wantLevel ChangeLevel
+		wantKinds []string
+	}{
+		{
+			name:      "no changes",
+			modify:    func(_ *ActionYML) {},
+			wantLevel: ChangeNone,
+		},
+		{
+			name:      "input removed",
+			modify:    func(a *ActionYML) { delete(a.Inputs, "path") },
+			wantLevel: ChangeMajor,
+			wantKinds: []string{ChangeInputRemoved},
+		},
+		{
+			name: "input renamed",
+			modify: func(a *ActionYML) {
+				a.Inputs["github-token"] = a.Inputs["token"]
+				delete(a.Inputs, "token")
+			},
+			wantLevel: ChangeMajor,
+			wantKinds: []string{ChangeInputRenamed},
+		},
+		{
+			name:      "optional input added",
+			modify:    func(a *ActionYML) { a.Inputs["verbose"] = ActionInput{Description: "Verbose"} },
+			wantLevel: ChangeMinor,
+			wantKinds: []string{ChangeInputAdded},
+		},
+		{
+			name:      "required input added",
+			modify:    func(a *ActionYML) { a.Inputs["key"] = ActionInput{Description: "Key", Required: true} },
+			wantLevel: ChangeMajor,
+			wantKinds: []string{ChangeInputAdded},
+		},
+		{
+			name:      "required input with default added",
+			modify:    func(a *ActionYML) { a.Inputs["key"] = ActionInput{Description: "Key", Required: true, Default: "x"} },
+			wantLevel: ChangeMinor,
+			wantKinds: []string{ChangeInputAdded},
+		},
+		{
+			name: "input becomes required",
+			modify: func(a *ActionYML) {
+				a.Inputs["path"] = ActionInput{Description: "Working directory", Required: true}
+			},
+			wantLevel: ChangeMajor,
+			wantKinds: []string{ChangeInputRequired, ChangeInputDefault},
+		},
+		{
+			name:      "input no longer required",
+			modify:    func(a *ActionYML) { a.Inputs["token"] = ActionInput{Description: "GitHub token"} },
+			wantLevel: ChangeMinor,
+			wantKinds: []string{ChangeInputOptional},
+		},
+		{
+			name:      "default changed",
+			modify:    func(a *ActionYML) { a.Inputs["path"] = ActionInput{Description: "Working directory", Default: "src"} },
+			wantLevel: ChangeMinor,
+			wantKinds: []string{ChangeInputDefault},
+		},
+		{
+			name: "input deprecated",
+			modify: func(a *ActionYML) {
+				a.Inputs["path"] = ActionInput{Description: "Working directory", Default: ".", DeprecationMessage: "Use dir"}
+			},
+			wantLevel: ChangeMinor,
+			wantKinds: []string{ChangeInputDeprecated},
+		},
+		{
+			name:      "output removed and added",
+			modify:    func(a *ActionYML) { a.Outputs = map[string]ActionOutput{"summary": {Description: "Summary"}} },
+			wantLevel: ChangeMajor,
+			wantKinds: []string{ChangeOutputRemoved, ChangeOutputAdded},
+		},
+		{
+			name:      "runtime changed",
+			modify:    func(a *ActionYML) { a.Runs["using"] = "node24" },
+			wantLevel: ChangeMajor,
+			wantKinds: []string{ChangeRuntime},
+		},
+		{
+			name: "descriptions changed",
+			modify: func(a *ActionYML) {
+				a.Description = "Builds and tests things"
+				a.Outputs["result"] = ActionOutput{Description: "Result of the build"}
+			},
+			wantLevel: ChangePatch,
+			wantKinds: []string{ChangeOutputDescription, ChangeActionDescription},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			newAction := base()
+			tt.modify(newAction)
+			diff := DiffInterfaces(base(), newAction)
+
+			testutil.AssertEqual(t, tt.wantLevel, diff.Level)
+			kinds := make([]string, 0, len(diff.Changes))
+			for _, change := range diff.Changes {
+				kinds = append(kinds, change.Kind)
+			}
+			testutil.AssertEqual(t, strings.Join(tt.wantKinds, ","), strings.Join(kinds, ","))
+		})
+	}
+}
+
+func TestInterfaceDiff_Markdown(t *testing.T) {
+	t.Parallel()
+
+	oldAction := &ActionYML{Inputs: map[string]ActionInput{"token": {Description: "Token"}}}
+	newAction := &ActionYML{Outputs: map[string]ActionOutput{"result": {Description: "Result"}}}
+
+	markdown := DiffInterfaces(oldAction, newAction).Markdown()
+	testutil.AssertStringContains(t, markdown, "## Interface changes (major)")
+	testutil.AssertStringContains(t, markdown, "### ⚠️ Breaking changes\n\n- Input `token` was removed")
+	testutil.AssertStringContains(t, markdown, "### ✨ Non-breaking changes\n\n- New output `result`")
+
+	unchanged := DiffInterfaces(oldAction, oldAction).Markdown()
+	testutil.AssertStringContains(t, unchanged, "No interface changes.")
+}
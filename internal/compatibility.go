@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Compatibility statuses of a runner operating system.
+const (
+	CompatibilitySupported          = "supported"
+	CompatibilityLikelyIncompatible = "likely-incompatible"
+	CompatibilityUnsupported        = "unsupported"
+)
+
+// osDisplayNames are the names of runner operating system families in generated docs.
+var osDisplayNames = map[string]string{
+	OSLinux:   "Linux",
+	OSMacOS:   "macOS",
+	OSWindows: "Windows",
+}
+
+// Compatibility is the operating system compatibility matrix of an action for
+// the runners in the runs_on configuration.
+type Compatibility struct {
+	Systems []OSCompatibility `json:"systems"`
+}
+
+// OSCompatibility is the expected support of an action on one runner operating system.
+type OSCompatibility struct {
+	OS      string   `json:"os"`      // linux, macos or windows
+	Name    string   `json:"name"`    // Display name, such as macOS
+	Runners []string `json:"runners"` // runs_on labels of the operating system
+	Status  string   `json:"status"`  // supported, likely-incompatible or unsupported
+	Notes   []string `json:"notes,omitempty"`
+}
+
+// Supported reports whether no incompatibility was detected.
+func (c OSCompatibility) Supported() bool {
+	return c.Status == CompatibilitySupported
+}
+
+// Issues returns the notes of the operating systems with detected incompatibilities,
+// prefixed with the operating system name.
+func (c *Compatibility) Issues() []string {
+	var issues []string
+	for _, system := range c.Systems {
+		for _, note := range system.Notes {
+			issues = append(issues, system.Name+": "+note)
+		}
+	}
+
+	return issues
+}
+
+// ActionCompatibility returns the compatibility matrix of action for the runs_on
+// labels, or nil when none of them belongs to a known operating system. Docker
+// actions only run on Linux runners; composite steps are checked for shells and
+// commands that other operating systems lack, skipping steps with an if:
+// runner.os condition. The result is a heuristic, not a guarantee.
+func ActionCompatibility(action *ActionYML, runsOn []string) *Compatibility {
+	families := RunnerOSFamilies(runsOn)
+	if len(families) == 0 {
+		return nil
+	}
+
+	compatibility := &Compatibility{}
+	for _, family := range families {
+		system := OSCompatibility{
+			OS:      family,
+			Name:    osDisplayNames[family],
+			Runners: runnersOf(runsOn, family),
+			Status:  CompatibilitySupported,
+		}
+		switch using, _ := action.Runs["using"].(string); {
+		case strings.EqualFold(using, "docker") && family != OSLinux:
+			system.Status = CompatibilityUnsupported
+			system.Notes = []string{"Docker container actions only run on Linux runners"}
+		case strings.EqualFold(using, "composite"):
+			if system.Notes = compositeStepIssues(action, family); len(system.Notes) > 0 {
+				system.Status = CompatibilityLikelyIncompatible
+			}
+		}
+		compatibility.Systems = append(compatibility.Systems, system)
+	}
+
+	return compatibility
+}
+
+// runnersOf returns the labels of runsOn belonging to the operating system family.
+func runnersOf(runsOn []string, family string) []string {
+	var runners []string
+	for _, label := range runsOn {
+		if slices.Equal(RunnerOSFamilies([]string{label}), []string{family}) {
+			runners = append(runners, label)
+		}
+	}
+
+	return runners
+}
+
+// compositeStepIssues describes the composite steps using a shell or command
+// that is not available on the operating system family.
+func compositeStepIssues(action *ActionYML, family string) []string {
+	steps, _ := action.Runs["steps"].([]any)
+
+	var issues []string
+	for i, raw := range steps {
+		step, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		if condition, _ := step["if"].(string); runnerOSCondition.MatchString(condition) {
+			continue
+		}
+		shell, _ := step["shell"].(string)
+		if available, ok := platformShells[strings.TrimSpace(shell)]; ok && !slices.Contains(available, family) {
+			issues = append(issues, fmt.Sprintf("step %d uses shell '%s'", i+1, strings.TrimSpace(shell)))
+
+			continue
+		}
+		script, _ := step["run"].(string)
+		for _, command := range scriptCommands(script) {
+			if available, ok := platformCommands[command]; ok && !slices.Contains(available, family) {
+				issues = append(issues, fmt.Sprintf("step %d runs '%s'", i+1, command))
+
+				break
+			}
+		}
+	}
+
+	return issues
+}
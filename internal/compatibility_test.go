@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestActionCompatibility(t *testing.T) {
+	t.Parallel()
+
+	composite := &ActionYML{Runs: map[string]any{
+		"using": "composite",
+		"steps": []any{
+			map[string]any{"shell": "bash", "run": "sudo apt-get install -y jq"},
+			map[string]any{"shell": "powershell", "if": "runner.os == 'Windows'", "run": "choco install jq"},
+			map[string]any{"shell": "cmd", "run": "echo hi"},
+			map[string]any{"shell": "bash", "run": "echo done"},
+		},
+	}}
+	runsOn := []string{"ubuntu-latest", "ubuntu-22.04", "macos-14", "windows-latest", "self-hosted"}
+
+	compatibility := ActionCompatibility(composite, runsOn)
+	testutil.AssertEqual(t, 3, len(compatibility.Systems))
+
+	linux := compatibility.Systems[0]
+	testutil.AssertEqual(t, "Linux", linux.Name)
+	testutil.AssertEqual(t, CompatibilityLikelyIncompatible, linux.Status)
+	if !slices.Equal([]string{"ubuntu-latest", "ubuntu-22.04"}, linux.Runners) {
+		t.Errorf("unexpected Linux runners: %v", linux.Runners)
+	}
+	if !slices.Equal([]string{"step 3 uses shell 'cmd'"}, linux.Notes) {
+		t.Errorf("unexpected Linux notes: %v", linux.Notes)
+	}
+
+	macos := compatibility.Systems[1]
+	if !slices.Equal([]string{"step 1 runs 'apt-get'", "step 3 uses shell 'cmd'"}, macos.Notes) {
+		t.Errorf("unexpected macOS notes: %v", macos.Notes)
+	}
+	windows := compatibility.Systems[2]
+	if !slices.Equal([]string{"step 1 runs 'sudo'"}, windows.Notes) {
+		t.Errorf("unexpected Windows notes: %v", windows.Notes)
+	}
+	testutil.AssertEqual(t, 4, len(compatibility.Issues()))
+	testutil.AssertEqual(t, "Linux: step 3 uses shell 'cmd'", compatibility.Issues()[0])
+}
+
+func TestActionCompatibility_Runtimes(t *testing.T) {
+	t.Parallel()
+
+	runsOn := []string{"ubuntu-latest", "macos-latest"}
+
+	node := ActionCompatibility(&ActionYML{Runs: map[string]any{"using": "node20", "main": "index.js"}}, runsOn)
+	for _, system := range node.Systems {
+		if !system.Supported() {
+			t.Errorf("node action should be supported on %s", system.Name)
+		}
+	}
+
+	docker := ActionCompatibility(&ActionYML{Runs: map[string]any{"using": "docker", "image": "Dockerfile"}}, runsOn)
+	testutil.AssertEqual(t, CompatibilitySupported, docker.Systems[0].Status)
+	testutil.AssertEqual(t, CompatibilityUnsupported, docker.Systems[1].Status)
+
+	if ActionCompatibility(&ActionYML{}, []string{"self-hosted"}) != nil {
+		t.Error("expected no matrix for runners of unknown operating systems")
+	}
+}
@@ -4,8 +4,10 @@ package internal
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/adrg/xdg"
@@ -14,13 +16,18 @@ import (
 	"github.com/spf13/viper"
 	"golang.org/x/oauth2"
 
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
 	"github.com/ivuorinen/gh-action-readme/internal/git"
+	"github.com/ivuorinen/gh-action-readme/internal/metrics"
 	"github.com/ivuorinen/gh-action-readme/internal/validation"
 	"github.com/ivuorinen/gh-action-readme/templates_embed"
 )
 
 // AppConfig represents the application configuration that can be used at multiple levels.
 type AppConfig struct {
+	// Configuration format version, see CurrentConfigVersion
+	ConfigVersion int `mapstructure:"config_version" yaml:"config_version,omitempty"`
+
 	// GitHub API (Global Only - Security)
 	GitHubToken string `mapstructure:"github_token" yaml:"github_token,omitempty"` // Only in global config
 
@@ -28,18 +35,40 @@ type AppConfig struct {
 	Organization string `mapstructure:"organization" yaml:"organization,omitempty"`
 	Repository   string `mapstructure:"repository"   yaml:"repository,omitempty"`
 	Version      string `mapstructure:"version"      yaml:"version,omitempty"`
+	// Ref links into the repository point at, such as those to scripts and
+	// community files; empty for the checked out branch or tag
+	LinkRef string `mapstructure:"link_ref" yaml:"link_ref,omitempty"`
+	// LinkDefaultBranch points links at the default branch instead of the
+	// checked out ref, for documentation published from other branches
+	LinkDefaultBranch bool `mapstructure:"link_default_branch" yaml:"link_default_branch,omitempty"`
 
 	// Template Settings
 	Theme          string `mapstructure:"theme"           yaml:"theme"`
 	OutputFormat   string `mapstructure:"output_format"   yaml:"output_format"`
 	OutputDir      string `mapstructure:"output_dir"      yaml:"output_dir"`
 	OutputFilename string `mapstructure:"output_filename" yaml:"output_filename,omitempty"`
+	AssetsDir      string `mapstructure:"assets_dir"      yaml:"assets_dir,omitempty"`
+	SortInputs     string `mapstructure:"sort_inputs"     yaml:"sort_inputs,omitempty"`
+	// Maximum width in pixels of action logos in HTML pages; wider logos are resized
+	LogoWidth int `mapstructure:"logo_width" yaml:"logo_width,omitempty"`
+	// Markdown file written next to the README with the badge-minimal theme; empty for none
+	MarketplaceFile string `mapstructure:"marketplace_file" yaml:"marketplace_file,omitempty"`
+	// README written in each output directory; empty to update an existing README or create README.md
+	ReadmeFilename string `mapstructure:"readme_filename" yaml:"readme_filename,omitempty"`
+	// Documents of the theme generated besides the README, such as "contributing"
+	Documents []string `mapstructure:"documents" yaml:"documents,omitempty"`
 
 	// Legacy template fields (backward compatibility)
 	Template string `mapstructure:"template" yaml:"template,omitempty"`
 	Header   string `mapstructure:"header"   yaml:"header,omitempty"`
 	Footer   string `mapstructure:"footer"   yaml:"footer,omitempty"`
 	Schema   string `mapstructure:"schema"   yaml:"schema,omitempty"`
+	// Directories custom templates may include files from besides their own (global config only)
+	TemplateRoots []string `mapstructure:"template_roots" yaml:"template_roots,omitempty"`
+	// Commands run before rendering and after writing each file (global config only)
+	Plugins []PluginConfig `mapstructure:"plugins" yaml:"plugins,omitempty"`
+	// Shell commands run before and after generating each action (global config only)
+	Hooks HookSettings `mapstructure:"hooks" yaml:"hooks,omitempty"`
 
 	// Workflow Requirements
 	Permissions map[string]string `mapstructure:"permissions" yaml:"permissions,omitempty"`
@@ -48,21 +77,90 @@ type AppConfig struct {
 	// Features
 	AnalyzeDependencies bool `mapstructure:"analyze_dependencies" yaml:"analyze_dependencies"`
 	ShowSecurityInfo    bool `mapstructure:"show_security_info"   yaml:"show_security_info"`
+	ShowMetrics         bool `mapstructure:"show_metrics"         yaml:"show_metrics"`
+	ShowSupport         bool `mapstructure:"show_support"         yaml:"show_support"`
+	ShowCompatibility   bool `mapstructure:"show_compatibility"   yaml:"show_compatibility"`
+	// Enrichment enables GitHub API lookups for dependency descriptions and
+	// versions; nil means enabled, so a config file can turn it off with false
+	Enrichment *bool `mapstructure:"enrichment" yaml:"enrichment,omitempty"`
+
+	// Attribution line of generated docs: empty for the theme default, "off", or
+	// custom text with {tool}, {version} and {command} placeholders
+	Attribution string `mapstructure:"attribution" yaml:"attribution,omitempty"`
+
+	// Locale of numbers, sizes and dates in CLI output, such as "de-DE"; empty
+	// for LC_ALL, LC_NUMERIC or LANG
+	Locale string `mapstructure:"locale" yaml:"locale,omitempty"`
+
+	// Markdown table style, description wrapping and line breaks in cells
+	Tables TableSettings `mapstructure:"tables" yaml:"tables,omitempty"`
+
+	// Front matter keys of the docs-site theme
+	FrontMatter FrontMatterSettings `mapstructure:"front_matter" yaml:"front_matter,omitempty"`
 
 	// Custom Template Variables
 	Variables map[string]string `mapstructure:"variables" yaml:"variables,omitempty"`
 
+	// Validation rule overrides: rule ID to "off", "error", "warning" or "info"
+	Rules map[string]string `mapstructure:"rules" yaml:"rules,omitempty"`
+
+	// Resource limits for parsing and batch processing
+	Limits ResourceLimits `mapstructure:"limits" yaml:"limits,omitempty"`
+
+	// Files discovered as actions in directories
+	Discovery DiscoverySettings `mapstructure:"discovery" yaml:"discovery,omitempty"`
+
+	// Dependency cache and the default policy of the deps commands
+	Cache CacheSettings `mapstructure:"cache" yaml:"cache,omitempty"`
+	Deps  DepsPolicy    `mapstructure:"deps"  yaml:"deps,omitempty"`
+
+	// Cloud storage bucket gen --sink uploads the generated documentation to
+	Sink SinkSettings `mapstructure:"sink" yaml:"sink,omitempty"`
+
+	// Chat webhooks the reporting commands post summaries to with --notify
+	Notify NotifySettings `mapstructure:"notify" yaml:"notify,omitempty"`
+
+	// Run history of the reporting commands shown by report trend
+	Trends TrendSettings `mapstructure:"trends" yaml:"trends,omitempty"`
+
 	// Repository-specific overrides (Global config only)
 	RepoOverrides map[string]AppConfig `mapstructure:"repo_overrides" yaml:"repo_overrides,omitempty"`
 
 	// Behavior
 	Verbose bool `mapstructure:"verbose" yaml:"verbose"`
 	Quiet   bool `mapstructure:"quiet"   yaml:"quiet"`
+	Strict  bool `mapstructure:"strict"  yaml:"strict,omitempty"` // Treat validation warnings as failures
+	// Reproducible leaves timestamps and the tool version out of generated output
+	Reproducible bool `mapstructure:"reproducible" yaml:"reproducible,omitempty"`
+	// TrustContent renders action.yml text verbatim instead of escaping raw HTML in it
+	TrustContent bool `mapstructure:"trust_content" yaml:"trust_content,omitempty"`
 
 	// Default values for action.yml files (legacy)
 	Defaults DefaultValues `mapstructure:"defaults" yaml:"defaults,omitempty"`
 }
 
+// EnrichmentEnabled reports whether GitHub API lookups are enabled.
+func (c *AppConfig) EnrichmentEnabled() bool {
+	return c.Enrichment == nil || *c.Enrichment
+}
+
+// applyLinkRef points the links into the repository of info at link_ref, or
+// at the default branch with link_default_branch.
+func (c *AppConfig) applyLinkRef(info *git.RepoInfo) {
+	switch {
+	case c.LinkRef != "":
+		info.Ref = c.LinkRef
+	case c.LinkDefaultBranch:
+		info.Ref = ""
+	}
+}
+
+// GitHubLookups reports whether dependency metadata may be fetched from the
+// GitHub API: a token is configured and enrichment is enabled.
+func (c *AppConfig) GitHubLookups() bool {
+	return c.GitHubToken != "" && c.EnrichmentEnabled()
+}
+
 // DefaultValues stores configurable default values for all fields (legacy support).
 type DefaultValues struct {
 	Name        string         `yaml:"name"`
@@ -99,6 +197,12 @@ func GetGitHubToken(config *AppConfig) string {
 
 // NewGitHubClient creates a new GitHub API client with rate limiting.
 func NewGitHubClient(token string) (*GitHubClient, error) {
+	return newGitHubClient(token, "")
+}
+
+// newGitHubClient creates a GitHub API client with rate limiting for the API
+// at baseURL, github.com when empty.
+func newGitHubClient(token, baseURL string) (*GitHubClient, error) {
 	var client *github.Client
 
 	if token != "" {
@@ -107,7 +211,7 @@ func NewGitHubClient(token string) (*GitHubClient, error) {
 		tc := oauth2.NewClient(ctx, ts)
 
 		// Add rate limiting with proper error handling
-		rateLimiter, err := github_ratelimit.NewRateLimitWaiterClient(tc.Transport)
+		rateLimiter, err := github_ratelimit.NewRateLimitWaiterClient(metrics.Transport(tc.Transport))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create rate limiter: %w", err)
 		}
@@ -115,12 +219,19 @@ func NewGitHubClient(token string) (*GitHubClient, error) {
 		client = github.NewClient(rateLimiter)
 	} else {
 		// For no token, use basic rate limiter
-		rateLimiter, err := github_ratelimit.NewRateLimitWaiterClient(nil)
+		rateLimiter, err := github_ratelimit.NewRateLimitWaiterClient(metrics.Transport(nil))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create rate limiter: %w", err)
 		}
 		client = github.NewClient(rateLimiter)
 	}
+	if baseURL != "" {
+		apiURL, err := url.Parse(strings.TrimSuffix(baseURL, "/") + "/")
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub API URL: %w", err)
+		}
+		client.BaseURL = apiURL
+	}
 
 	return &GitHubClient{
 		Client: client,
@@ -195,6 +306,10 @@ func resolveThemeTemplate(theme string) string {
 		templatePath = TemplatePathMinimal
 	case ThemeProfessional:
 		templatePath = TemplatePathProfessional
+	case ThemeDocsSite:
+		templatePath = TemplatePathDocsSite
+	case ThemeBadgeMinimal:
+		templatePath = TemplatePathBadgeMinimal
 	case "":
 		// Empty theme should return empty path
 		return ""
@@ -215,9 +330,11 @@ func DefaultAppConfig() *AppConfig {
 		Version:      "",
 
 		// Template Settings
-		Theme:        "default", // default, github, gitlab, minimal, professional
+		Theme:        "default", // default, github, gitlab, minimal, professional, docs-site, badge-minimal
 		OutputFormat: "md",
 		OutputDir:    ".",
+		SortInputs:   SortInputsDeclaration, // declaration, alpha, required-first
+		LogoWidth:    DefaultLogoWidth,
 
 		// Legacy template fields (backward compatibility)
 		Template: resolveTemplatePath("templates/readme.tmpl"),
@@ -232,10 +349,26 @@ func DefaultAppConfig() *AppConfig {
 		// Features
 		AnalyzeDependencies: false,
 		ShowSecurityInfo:    false,
+		ShowMetrics:         false,
+		ShowSupport:         false,
+		ShowCompatibility:   false,
+
+		// Markdown tables
+		Tables: DefaultTableSettings(),
+
+		// Front matter of the docs-site theme
+		FrontMatter: DefaultFrontMatterSettings(),
 
 		// Custom Template Variables
 		Variables: map[string]string{},
 
+		// Resource limits
+		Limits: DefaultResourceLimits(),
+
+		// Dependency cache and policy
+		Cache: DefaultCacheSettings(),
+		Deps:  DepsPolicy{PinStrategy: dependencies.PinStrategySHA},
+
 		// Repository-specific overrides (empty by default)
 		RepoOverrides: map[string]AppConfig{},
 
@@ -262,6 +395,7 @@ func MergeConfigs(dst *AppConfig, src *AppConfig, allowTokens bool) {
 	mergeMapFields(dst, src)
 	mergeSliceFields(dst, src)
 	mergeBooleanFields(dst, src)
+	mergeLimitFields(dst, src)
 	mergeSecurityFields(dst, src, allowTokens)
 }
 
@@ -274,13 +408,36 @@ func mergeStringFields(dst *AppConfig, src *AppConfig) {
 		{&dst.Organization, src.Organization},
 		{&dst.Repository, src.Repository},
 		{&dst.Version, src.Version},
+		{&dst.LinkRef, src.LinkRef},
 		{&dst.Theme, src.Theme},
 		{&dst.OutputFormat, src.OutputFormat},
 		{&dst.OutputDir, src.OutputDir},
+		{&dst.OutputFilename, src.OutputFilename},
+		{&dst.AssetsDir, src.AssetsDir},
+		{&dst.MarketplaceFile, src.MarketplaceFile},
+		{&dst.ReadmeFilename, src.ReadmeFilename},
+		{&dst.SortInputs, src.SortInputs},
 		{&dst.Template, src.Template},
 		{&dst.Header, src.Header},
 		{&dst.Footer, src.Footer},
 		{&dst.Schema, src.Schema},
+		{&dst.Attribution, src.Attribution},
+		{&dst.Locale, src.Locale},
+		{&dst.Tables.Style, src.Tables.Style},
+		{&dst.Tables.Newlines, src.Tables.Newlines},
+		{&dst.FrontMatter.Title, src.FrontMatter.Title},
+		{&dst.FrontMatter.Slug, src.FrontMatter.Slug},
+		{&dst.FrontMatter.Description, src.FrontMatter.Description},
+		{&dst.FrontMatter.Tags, src.FrontMatter.Tags},
+		{&dst.Cache.TTL, src.Cache.TTL},
+		{&dst.Cache.Backend, src.Cache.Backend},
+		{&dst.Deps.PinStrategy, src.Deps.PinStrategy},
+		{&dst.Deps.Allowlist, src.Deps.Allowlist},
+		{&dst.Sink.URL, src.Sink.URL},
+		{&dst.Sink.CacheControl, src.Sink.CacheControl},
+		{&dst.Sink.Region, src.Sink.Region},
+		{&dst.Sink.Endpoint, src.Sink.Endpoint},
+		{&dst.Notify.Link, src.Notify.Link},
 	}
 
 	for _, field := range stringFields {
@@ -309,6 +466,24 @@ func mergeMapFields(dst *AppConfig, src *AppConfig) {
 			dst.Variables[k] = v
 		}
 	}
+
+	if len(src.Rules) > 0 {
+		if dst.Rules == nil {
+			dst.Rules = make(map[string]string)
+		}
+		for k, v := range src.Rules {
+			dst.Rules[k] = v
+		}
+	}
+
+	if len(src.Deps.Consolidate) > 0 {
+		if dst.Deps.Consolidate == nil {
+			dst.Deps.Consolidate = make(map[string]string)
+		}
+		for k, v := range src.Deps.Consolidate {
+			dst.Deps.Consolidate[k] = v
+		}
+	}
 }
 
 // mergeSliceFields merges slice fields from src to dst if non-empty.
@@ -317,22 +492,86 @@ func mergeSliceFields(dst *AppConfig, src *AppConfig) {
 		dst.RunsOn = make([]string, len(src.RunsOn))
 		copy(dst.RunsOn, src.RunsOn)
 	}
+	if len(src.Deps.FailOn) > 0 {
+		dst.Deps.FailOn = slices.Clone(src.Deps.FailOn)
+	}
+	if len(src.Discovery.Patterns) > 0 {
+		dst.Discovery.Patterns = slices.Clone(src.Discovery.Patterns)
+	}
+	if len(src.Documents) > 0 {
+		dst.Documents = slices.Clone(src.Documents)
+	}
 }
 
 // mergeBooleanFields merges boolean fields from src to dst if true.
 func mergeBooleanFields(dst *AppConfig, src *AppConfig) {
+	if src.Enrichment != nil {
+		dst.Enrichment = new(bool)
+		*dst.Enrichment = *src.Enrichment
+	}
 	if src.AnalyzeDependencies {
 		dst.AnalyzeDependencies = src.AnalyzeDependencies
 	}
 	if src.ShowSecurityInfo {
 		dst.ShowSecurityInfo = src.ShowSecurityInfo
 	}
+	if src.ShowMetrics {
+		dst.ShowMetrics = src.ShowMetrics
+	}
+	if src.ShowSupport {
+		dst.ShowSupport = src.ShowSupport
+	}
+	if src.ShowCompatibility {
+		dst.ShowCompatibility = src.ShowCompatibility
+	}
 	if src.Verbose {
 		dst.Verbose = src.Verbose
 	}
 	if src.Quiet {
 		dst.Quiet = src.Quiet
 	}
+	if src.Strict {
+		dst.Strict = src.Strict
+	}
+	if src.Reproducible {
+		dst.Reproducible = src.Reproducible
+	}
+	if src.TrustContent {
+		dst.TrustContent = src.TrustContent
+	}
+	if src.LinkDefaultBranch {
+		dst.LinkDefaultBranch = src.LinkDefaultBranch
+	}
+	if src.Trends.Disabled {
+		dst.Trends.Disabled = src.Trends.Disabled
+	}
+}
+
+// mergeLimitFields merges resource limits and other numeric settings from src to dst if set.
+func mergeLimitFields(dst *AppConfig, src *AppConfig) {
+	limitFields := []struct {
+		dst *int
+		src int
+	}{
+		{&dst.Limits.MaxFileSize, src.Limits.MaxFileSize},
+		{&dst.Limits.MaxNodes, src.Limits.MaxNodes},
+		{&dst.Limits.MaxDepth, src.Limits.MaxDepth},
+		{&dst.Limits.MaxSteps, src.Limits.MaxSteps},
+		{&dst.Limits.MaxFiles, src.Limits.MaxFiles},
+		{&dst.Limits.MaxTransitiveDepth, src.Limits.MaxTransitiveDepth},
+		{&dst.Limits.MaxRenderSize, src.Limits.MaxRenderSize},
+		{&dst.Limits.RenderTimeout, src.Limits.RenderTimeout},
+		{&dst.Tables.MaxDescriptionWidth, src.Tables.MaxDescriptionWidth},
+		{&dst.LogoWidth, src.LogoWidth},
+		{&dst.Notify.Top, src.Notify.Top},
+		{&dst.Trends.MaxEntries, src.Trends.MaxEntries},
+	}
+
+	for _, field := range limitFields {
+		if field.src != 0 {
+			*field.dst = field.src
+		}
+	}
 }
 
 // mergeSecurityFields merges security-sensitive fields if allowed.
@@ -341,6 +580,37 @@ func mergeSecurityFields(dst *AppConfig, src *AppConfig, allowTokens bool) {
 		dst.GitHubToken = src.GitHubToken
 	}
 
+	// Webhook URLs are credentials, so repository configuration cannot redirect notifications
+	if allowTokens && src.Notify.SlackWebhook != "" {
+		dst.Notify.SlackWebhook = src.Notify.SlackWebhook
+	}
+	if allowTokens && src.Notify.TeamsWebhook != "" {
+		dst.Notify.TeamsWebhook = src.Notify.TeamsWebhook
+	}
+
+	// Registry credentials are sent to the registry host, so repository
+	// configuration cannot name hosts or tokens
+	if allowTokens && len(src.Deps.Registries) > 0 {
+		if dst.Deps.Registries == nil {
+			dst.Deps.Registries = make(map[string]dependencies.RegistryCredentials)
+		}
+		for k, v := range src.Deps.Registries {
+			dst.Deps.Registries[k] = v
+		}
+	}
+
+	if allowTokens && len(src.TemplateRoots) > 0 {
+		dst.TemplateRoots = slices.Clone(src.TemplateRoots)
+	}
+
+	if allowTokens && len(src.Plugins) > 0 {
+		dst.Plugins = slices.Clone(src.Plugins)
+	}
+
+	if allowTokens {
+		mergeHookSettings(&dst.Hooks, src.Hooks)
+	}
+
 	if allowTokens && len(src.RepoOverrides) > 0 {
 		if dst.RepoOverrides == nil {
 			dst.RepoOverrides = make(map[string]AppConfig)
@@ -351,16 +621,45 @@ func mergeSecurityFields(dst *AppConfig, src *AppConfig, allowTokens bool) {
 	}
 }
 
-// LoadRepoConfig loads repository-level configuration from hidden config files.
-func LoadRepoConfig(repoRoot string) (*AppConfig, error) {
-	// Hidden config file paths in priority order
-	configPaths := []string{
-		".ghreadme.yaml",        // Primary hidden config
-		".config/ghreadme.yaml", // Secondary hidden config
-		".github/ghreadme.yaml", // GitHub ecosystem standard
+// mergeHookSettings merges the hook settings set in src into dst.
+func mergeHookSettings(dst *HookSettings, src HookSettings) {
+	if len(src.PreGen) > 0 {
+		dst.PreGen = slices.Clone(src.PreGen)
+	}
+	if len(src.PostGen) > 0 {
+		dst.PostGen = slices.Clone(src.PostGen)
+	}
+	if src.Timeout != "" {
+		dst.Timeout = src.Timeout
+	}
+	if src.OnFailure != "" {
+		dst.OnFailure = src.OnFailure
+	}
+}
+
+// findRepoOverride returns the override of repoName in overrides. Names match
+// case-insensitively like GitHub repositories, and viper lowercases map keys.
+func findRepoOverride(overrides map[string]AppConfig, repoName string) (AppConfig, bool) {
+	for name, override := range overrides {
+		if strings.EqualFold(name, repoName) {
+			return override, true
+		}
 	}
 
-	for _, configName := range configPaths {
+	return AppConfig{}, false
+}
+
+// repoConfigFiles are the hidden repository configuration files, relative to
+// the repository root, in priority order.
+var repoConfigFiles = []string{
+	".ghreadme.yaml",        // Primary hidden config
+	".config/ghreadme.yaml", // Secondary hidden config
+	".github/ghreadme.yaml", // GitHub ecosystem standard
+}
+
+// LoadRepoConfig loads repository-level configuration from hidden config files.
+func LoadRepoConfig(repoRoot string) (*AppConfig, error) {
+	for _, configName := range repoConfigFiles {
 		configPath := filepath.Join(repoRoot, configName)
 		if _, err := os.Stat(configPath); err == nil {
 			// Config file found, load it
@@ -437,7 +736,7 @@ func LoadConfiguration(configFile, repoRoot, actionDir string) (*AppConfig, erro
 	// 3. Apply repo-specific overrides from global config
 	repoName := DetectRepositoryName(repoRoot)
 	if repoName != "" {
-		if repoOverride, exists := globalConfig.RepoOverrides[repoName]; exists {
+		if repoOverride, exists := findRepoOverride(globalConfig.RepoOverrides, repoName); exists {
 			MergeConfigs(config, &repoOverride, false) // No tokens in overrides
 		}
 	}
@@ -471,18 +770,13 @@ func LoadConfiguration(configFile, repoRoot, actionDir string) (*AppConfig, erro
 	return config, nil
 }
 
-// InitConfig initializes the global configuration using Viper with XDG compliance.
-func InitConfig(configFile string) (*AppConfig, error) {
-	v := viper.New()
-
-	// Set configuration file name and type
-	v.SetConfigName(ConfigFileName)
-	v.SetConfigType("yaml")
-
+// addConfigSearchPaths adds the directories searched for the global configuration
+// file to v: the XDG config directory, the current directory and the fallbacks.
+func addConfigSearchPaths(v *viper.Viper) error {
 	// Add XDG-compliant configuration directory
 	configDir, err := xdg.ConfigFile("gh-action-readme")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get XDG config directory: %w", err)
+		return fmt.Errorf("failed to get XDG config directory: %w", err)
 	}
 	v.AddConfigPath(filepath.Dir(configDir))
 
@@ -491,6 +785,21 @@ func InitConfig(configFile string) (*AppConfig, error) {
 	v.AddConfigPath("$HOME/.config/gh-action-readme") // fallback
 	v.AddConfigPath("/etc/gh-action-readme")          // system-wide
 
+	return nil
+}
+
+// InitConfig initializes the global configuration using Viper with XDG compliance.
+func InitConfig(configFile string) (*AppConfig, error) {
+	v := viper.New()
+
+	// Set configuration file name and type
+	v.SetConfigName(ConfigFileName)
+	v.SetConfigType("yaml")
+
+	if err := addConfigSearchPaths(v); err != nil {
+		return nil, err
+	}
+
 	// Set environment variable prefix
 	v.SetEnvPrefix("GH_ACTION_README")
 	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
@@ -504,14 +813,39 @@ func InitConfig(configFile string) (*AppConfig, error) {
 	v.SetDefault("theme", defaults.Theme)
 	v.SetDefault("output_format", defaults.OutputFormat)
 	v.SetDefault("output_dir", defaults.OutputDir)
+	v.SetDefault("sort_inputs", defaults.SortInputs)
+	v.SetDefault("logo_width", defaults.LogoWidth)
 	v.SetDefault("template", defaults.Template)
 	v.SetDefault("header", defaults.Header)
 	v.SetDefault("footer", defaults.Footer)
 	v.SetDefault("schema", defaults.Schema)
 	v.SetDefault("analyze_dependencies", defaults.AnalyzeDependencies)
 	v.SetDefault("show_security_info", defaults.ShowSecurityInfo)
+	v.SetDefault("show_metrics", defaults.ShowMetrics)
+	v.SetDefault("show_support", defaults.ShowSupport)
+	v.SetDefault("show_compatibility", defaults.ShowCompatibility)
+	v.SetDefault("attribution", defaults.Attribution)
+	v.SetDefault("locale", defaults.Locale)
 	v.SetDefault("verbose", defaults.Verbose)
 	v.SetDefault("quiet", defaults.Quiet)
+	v.SetDefault("limits.max_file_size", defaults.Limits.MaxFileSize)
+	v.SetDefault("limits.max_nodes", defaults.Limits.MaxNodes)
+	v.SetDefault("limits.max_depth", defaults.Limits.MaxDepth)
+	v.SetDefault("limits.max_steps", defaults.Limits.MaxSteps)
+	v.SetDefault("limits.max_files", defaults.Limits.MaxFiles)
+	v.SetDefault("limits.max_transitive_depth", defaults.Limits.MaxTransitiveDepth)
+	v.SetDefault("limits.max_render_size", defaults.Limits.MaxRenderSize)
+	v.SetDefault("limits.render_timeout", defaults.Limits.RenderTimeout)
+	v.SetDefault("tables.style", defaults.Tables.Style)
+	v.SetDefault("tables.newlines", defaults.Tables.Newlines)
+	v.SetDefault("tables.max_description_width", defaults.Tables.MaxDescriptionWidth)
+	v.SetDefault("front_matter.title", defaults.FrontMatter.Title)
+	v.SetDefault("front_matter.slug", defaults.FrontMatter.Slug)
+	v.SetDefault("front_matter.description", defaults.FrontMatter.Description)
+	v.SetDefault("front_matter.tags", defaults.FrontMatter.Tags)
+	v.SetDefault("cache.ttl", defaults.Cache.TTL)
+	v.SetDefault("cache.backend", defaults.Cache.Backend)
+	v.SetDefault("deps.pin_strategy", defaults.Deps.PinStrategy)
 	v.SetDefault("defaults.name", defaults.Defaults.Name)
 	v.SetDefault("defaults.description", defaults.Defaults.Description)
 	v.SetDefault("defaults.branding.icon", defaults.Defaults.Branding.Icon)
@@ -563,17 +897,18 @@ func WriteDefaultConfig() error {
 
 	// Set default values
 	defaults := DefaultAppConfig()
+	v.Set(ConfigKeyConfigVersion, CurrentConfigVersion)
 	v.Set("theme", defaults.Theme)
 	v.Set("output_format", defaults.OutputFormat)
 	v.Set("output_dir", defaults.OutputDir)
+	v.Set("sort_inputs", defaults.SortInputs)
 	v.Set("analyze_dependencies", defaults.AnalyzeDependencies)
 	v.Set("show_security_info", defaults.ShowSecurityInfo)
+	v.Set("show_metrics", defaults.ShowMetrics)
+	v.Set("show_support", defaults.ShowSupport)
+	v.Set("show_compatibility", defaults.ShowCompatibility)
 	v.Set("verbose", defaults.Verbose)
 	v.Set("quiet", defaults.Quiet)
-	v.Set("template", defaults.Template)
-	v.Set("header", defaults.Header)
-	v.Set("footer", defaults.Footer)
-	v.Set("schema", defaults.Schema)
 	v.Set("defaults", defaults.Defaults)
 
 	if err := v.WriteConfig(); err != nil {
@@ -592,3 +927,31 @@ func GetConfigPath() (string, error) {
 
 	return configDir, nil
 }
+
+// GlobalConfigFile returns the global configuration file loaded for configFile,
+// the value of the --config flag, and whether it exists. When no file is found
+// the path written by config init is returned.
+func GlobalConfigFile(configFile string) (string, bool, error) {
+	if configFile != "" {
+		_, err := os.Stat(configFile)
+
+		return configFile, err == nil, nil
+	}
+
+	v := viper.New()
+	v.SetConfigName(ConfigFileName)
+	v.SetConfigType("yaml")
+	if err := addConfigSearchPaths(v); err != nil {
+		return "", false, err
+	}
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+			path, err := GetConfigPath()
+
+			return path, false, err
+		}
+	}
+
+	// A file that fails to parse is still the one that is loaded.
+	return v.ConfigFileUsed(), true, nil
+}
@@ -0,0 +1,325 @@
+package internal
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// notMergedFields are the AppConfig fields MergeConfigs leaves alone: the
+// format version of each file and the legacy defaults.
+var notMergedFields = []string{"ConfigVersion", "Defaults"}
+
+// securityFields are only merged from the global configuration.
+var securityFields = []string{
+	"GitHubToken", "Notify.SlackWebhook", "Notify.TeamsWebhook", "Deps.Registries", "TemplateRoots", "Plugins", "Hooks",
+	"RepoOverrides",
+}
+
+// isSecurityField reports whether the field at path is one of securityFields
+// or belongs to one.
+func isSecurityField(path string) bool {
+	return slices.ContainsFunc(securityFields, func(field string) bool {
+		return path == field || strings.HasPrefix(path, field+".")
+	})
+}
+
+// quickConfig is a configuration with random values in about half of its
+// fields, generated by testing/quick.
+type quickConfig struct {
+	AppConfig
+}
+
+// Generate implements quick.Generator.
+func (quickConfig) Generate(rand *rand.Rand, _ int) reflect.Value {
+	var config quickConfig
+	randomizeFields(reflect.ValueOf(&config.AppConfig).Elem(), "", rand)
+
+	return reflect.ValueOf(config)
+}
+
+// randomizeFields sets random values in about half of the fields of the
+// struct value, recursing into nested settings.
+func randomizeFields(value reflect.Value, prefix string, rand *rand.Rand) {
+	for i := range value.NumField() {
+		field := value.Field(i)
+		path := prefix + value.Type().Field(i).Name
+		switch {
+		case slices.Contains(notMergedFields, path) || rand.Intn(2) == 0:
+			// Unset
+		case path == "RepoOverrides":
+			// Overrides are configurations themselves, one level is enough
+			field.Set(reflect.ValueOf(map[string]AppConfig{"acme/tools": {Theme: ThemeGitHub}}))
+		case field.Kind() == reflect.Struct:
+			randomizeFields(field, path+".", rand)
+		default:
+			if random, ok := quick.Value(field.Type(), rand); ok {
+				field.Set(random)
+			}
+		}
+	}
+}
+
+// applyMergeModel merges src into dst field by field, as MergeConfigs is
+// expected to: set fields of src replace those of dst, booleans are only
+// switched on, maps are merged key by key with src winning, and security
+// fields are only merged when allowed.
+func applyMergeModel(dst, src reflect.Value, prefix string, allowTokens bool) {
+	for i := range dst.NumField() {
+		dstField, srcField := dst.Field(i), src.Field(i)
+		path := prefix + dst.Type().Field(i).Name
+		switch {
+		case slices.Contains(notMergedFields, path) || (!allowTokens && isSecurityField(path)):
+			// Kept
+		case dstField.Kind() == reflect.Struct:
+			applyMergeModel(dstField, srcField, path+".", allowTokens)
+		case dstField.Kind() == reflect.Bool:
+			dstField.SetBool(dstField.Bool() || srcField.Bool())
+		case dstField.Kind() == reflect.Map:
+			if srcField.Len() == 0 {
+				continue
+			}
+			merged := reflect.MakeMap(dstField.Type())
+			for _, source := range []reflect.Value{dstField, srcField} {
+				for entries := source.MapRange(); entries.Next(); {
+					merged.SetMapIndex(entries.Key(), entries.Value())
+				}
+			}
+			dstField.Set(merged)
+		case dstField.Kind() == reflect.Slice:
+			if srcField.Len() > 0 {
+				dstField.Set(srcField)
+			}
+		default:
+			if !srcField.IsZero() {
+				dstField.Set(srcField)
+			}
+		}
+	}
+}
+
+// cloneMaps replaces the map fields of the struct value with copies, so merging
+// into the original leaves them alone.
+func cloneMaps(value reflect.Value) {
+	for i := range value.NumField() {
+		field := value.Field(i)
+		switch field.Kind() {
+		case reflect.Struct:
+			cloneMaps(field)
+		case reflect.Map:
+			if !field.IsNil() {
+				clone := reflect.MakeMap(field.Type())
+				for entries := field.MapRange(); entries.Next(); {
+					clone.SetMapIndex(entries.Key(), entries.Value())
+				}
+				field.Set(clone)
+			}
+		default:
+		}
+	}
+}
+
+// sharedCollections returns the slice and map fields of merged that share
+// their storage with the same field of src.
+func sharedCollections(merged, src reflect.Value, prefix string) []string {
+	var shared []string
+	for i := range merged.NumField() {
+		mergedField, srcField := merged.Field(i), src.Field(i)
+		path := prefix + merged.Type().Field(i).Name
+		switch mergedField.Kind() {
+		case reflect.Struct:
+			shared = append(shared, sharedCollections(mergedField, srcField, path+".")...)
+		case reflect.Slice, reflect.Map:
+			if mergedField.Len() > 0 && mergedField.Pointer() == srcField.Pointer() {
+				shared = append(shared, path)
+			}
+		default:
+		}
+	}
+
+	return shared
+}
+
+// differingFields returns the paths of the fields that differ between the
+// struct values.
+func differingFields(want, got reflect.Value, prefix string) []string {
+	var fields []string
+	for i := range want.NumField() {
+		path := prefix + want.Type().Field(i).Name
+		if want.Field(i).Kind() == reflect.Struct {
+			fields = append(fields, differingFields(want.Field(i), got.Field(i), path+".")...)
+		} else if !reflect.DeepEqual(want.Field(i).Interface(), got.Field(i).Interface()) {
+			fields = append(fields, path)
+		}
+	}
+
+	return fields
+}
+
+func TestMergeConfigs_Properties(t *testing.T) {
+	t.Parallel()
+
+	// Each property reports the offending fields, since the random inputs are unreadable
+	properties := []struct {
+		name     string
+		property func(t *testing.T) any
+	}{
+		{
+			// Set fields take precedence and unset fields lose nothing
+			name: "matches field-wise model",
+			property: func(t *testing.T) any {
+				return func(dst, src quickConfig, allowTokens bool) bool {
+					want := dst.AppConfig
+					applyMergeModel(reflect.ValueOf(&want).Elem(), reflect.ValueOf(src.AppConfig), "", allowTokens)
+					MergeConfigs(&dst.AppConfig, &src.AppConfig, allowTokens)
+					fields := differingFields(reflect.ValueOf(want), reflect.ValueOf(dst.AppConfig), "")
+					if len(fields) > 0 {
+						t.Logf("merged with allowTokens=%t, differs from the model in %s", allowTokens, strings.Join(fields, ", "))
+					}
+
+					return len(fields) == 0
+				}
+			},
+		},
+		{
+			name: "idempotent",
+			property: func(t *testing.T) any {
+				return func(dst, src quickConfig, allowTokens bool) bool {
+					MergeConfigs(&dst.AppConfig, &src.AppConfig, allowTokens)
+					once := dst.AppConfig
+					cloneMaps(reflect.ValueOf(&once).Elem())
+					MergeConfigs(&dst.AppConfig, &src.AppConfig, allowTokens)
+					fields := differingFields(reflect.ValueOf(once), reflect.ValueOf(dst.AppConfig), "")
+					if len(fields) > 0 {
+						t.Logf("merging twice changed %s", strings.Join(fields, ", "))
+					}
+
+					return len(fields) == 0
+				}
+			},
+		},
+		{
+			// Later changes to a source configuration do not leak into the merged one
+			name: "copies collections",
+			property: func(t *testing.T) any {
+				return func(src quickConfig) bool {
+					merged := DefaultAppConfig()
+					MergeConfigs(merged, &src.AppConfig, true)
+					fields := sharedCollections(reflect.ValueOf(*merged), reflect.ValueOf(src.AppConfig), "")
+					if len(fields) > 0 {
+						t.Logf("merged %s share storage with the source", strings.Join(fields, ", "))
+					}
+
+					return len(fields) == 0
+				}
+			},
+		},
+	}
+
+	for _, tt := range properties {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var checkErr *quick.CheckError
+			if err := quick.Check(tt.property(t), &quick.Config{MaxCount: 200}); errors.As(err, &checkErr) {
+				t.Errorf("property failed on run %d", checkErr.Count)
+			} else if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// quickLevels sets the theme and a variable in some of the global, repository
+// and action configuration files.
+type quickLevels struct {
+	Themes [3]string
+	Labels [3]string
+}
+
+// Generate implements quick.Generator.
+func (quickLevels) Generate(rand *rand.Rand, _ int) reflect.Value {
+	themes := []string{"", ThemeGitHub, ThemeMinimal, ThemeProfessional}
+	var levels quickLevels
+	for i := range levels.Themes {
+		levels.Themes[i] = themes[rand.Intn(len(themes))]
+		if rand.Intn(2) == 0 {
+			levels.Labels[i] = fmt.Sprintf("label-%d", rand.Intn(100))
+		}
+	}
+
+	return reflect.ValueOf(levels)
+}
+
+// write writes the levels as the global configuration, .ghreadme.yaml of the
+// repository and config.yaml of the action, and returns their locations.
+func (levels quickLevels) write(t *testing.T, dir string) (configFile, repoRoot, actionDir string) {
+	t.Helper()
+	repoRoot = filepath.Join(dir, "repo")
+	actionDir = filepath.Join(repoRoot, "action")
+	_ = os.MkdirAll(actionDir, 0750) // #nosec G301 -- test directory permissions
+	configFile = filepath.Join(dir, "config.yaml")
+
+	paths := []string{configFile, filepath.Join(repoRoot, ".ghreadme.yaml"), filepath.Join(actionDir, "config.yaml")}
+	for i, path := range paths {
+		content := fmt.Sprintf("variables:\n  level_%d: set\n", i)
+		if levels.Labels[i] != "" {
+			content += "  label: " + levels.Labels[i] + "\n"
+		}
+		if levels.Themes[i] != "" {
+			content += "theme: " + levels.Themes[i] + "\n"
+		}
+		testutil.WriteTestFile(t, path, content)
+	}
+
+	return configFile, repoRoot, actionDir
+}
+
+func TestLoadConfiguration_Precedence(t *testing.T) {
+	t.Parallel()
+
+	loaders := map[string]func(configFile, repoRoot, actionDir string) (*AppConfig, error){
+		"LoadConfiguration":   LoadConfiguration,
+		"ConfigurationLoader": NewConfigurationLoader().LoadConfiguration,
+	}
+	for name, load := range loaders {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			runs := 0
+
+			// Each setting comes from the most specific file setting it, and
+			// map entries of every file are kept
+			property := func(levels quickLevels) bool {
+				runs++
+				config, err := load(levels.write(t, filepath.Join(dir, fmt.Sprint(runs))))
+				if err != nil {
+					t.Log(err)
+
+					return false
+				}
+				wantTheme, wantLabel := ThemeDefault, ""
+				for i := range levels.Themes {
+					wantTheme = cmp.Or(levels.Themes[i], wantTheme)
+					wantLabel = cmp.Or(levels.Labels[i], wantLabel)
+				}
+
+				return config.Theme == wantTheme && config.Variables["label"] == wantLabel &&
+					config.Variables["level_0"] == "set" && config.Variables["level_1"] == "set" &&
+					config.Variables["level_2"] == "set"
+			}
+			if err := quick.Check(property, &quick.Config{MaxCount: 20}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
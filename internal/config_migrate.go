@@ -0,0 +1,287 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
+)
+
+// CurrentConfigVersion is the config_version of the configuration format read
+// by this version of the tool. Files without config_version are version 1.
+const CurrentConfigVersion = 2
+
+// configBackupExtension is appended to the path of a config file backed up by config migrate.
+const configBackupExtension = ".backup"
+
+// ErrConfigVersionTooNew is returned for configuration written for a newer version of the tool.
+var ErrConfigVersionTooNew = errors.New("config_version is newer than this version of gh-action-readme supports")
+
+// renamedConfigKeys maps keys of version 1 configuration to their version 2 names.
+var renamedConfigKeys = map[string]string{
+	"dependencies_enabled": ConfigKeyAnalyzeDependencies,
+}
+
+// builtinConfigPaths are the default values of the legacy path settings. Version
+// 1 files written by config init pin them to the binary directory, so they are
+// recognized by suffix.
+var builtinConfigPaths = map[string]string{
+	"header": "templates/header.tmpl",
+	"footer": "templates/footer.tmpl",
+	"schema": "schemas/schema.json",
+}
+
+// themeTemplates maps the template of each built-in theme to the theme name.
+var themeTemplates = map[string]string{
+	TemplatePathDefault:      ThemeDefault,
+	TemplatePathGitHub:       ThemeGitHub,
+	TemplatePathGitLab:       ThemeGitLab,
+	TemplatePathMinimal:      ThemeMinimal,
+	TemplatePathProfessional: ThemeProfessional,
+	TemplatePathDocsSite:     ThemeDocsSite,
+	TemplatePathBadgeMinimal: ThemeBadgeMinimal,
+}
+
+// ConfigMigration is the result of upgrading a configuration file.
+type ConfigMigration struct {
+	FromVersion int
+	ToVersion   int
+	// Changes describe each key that was renamed, rewritten or removed.
+	Changes []string
+	// Warnings describe settings that were kept but need attention.
+	Warnings []string
+	// Content is the migrated file, comments and key order preserved.
+	Content []byte
+}
+
+// MigrateConfig upgrades configuration file content to CurrentConfigVersion:
+// documented keys that were never read are renamed, a template pointing at a
+// built-in theme becomes theme, and legacy paths equal to their defaults are
+// removed so they are resolved by the installed binary. Content that is
+// already current is returned unchanged.
+func MigrateConfig(content []byte) (*ConfigMigration, error) {
+	file, err := parser.ParseBytes(content, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	var root *ast.MappingNode
+	if len(file.Docs) > 0 && file.Docs[0].Body != nil {
+		switch body := file.Docs[0].Body.(type) {
+		case *ast.MappingNode:
+			root = body
+		case *ast.MappingValueNode:
+			root = ast.Mapping(body.GetToken(), false, body)
+			file.Docs[0].Body = root
+		default:
+			return nil, errors.New("config must be a mapping of settings")
+		}
+	}
+
+	migration := &ConfigMigration{FromVersion: configVersion(root), ToVersion: CurrentConfigVersion}
+	if err := checkConfigVersion(migration.FromVersion); err != nil {
+		return nil, err
+	}
+	if migration.FromVersion == CurrentConfigVersion {
+		migration.Content = content
+
+		return migration, nil
+	}
+
+	if root != nil {
+		migrateConfigMapping(root, "", migration)
+		for _, override := range yamlsafe.MappingValues(yamlsafe.MappingValue(root, "repo_overrides")) {
+			migrateConfigMapping(override.Value, "repo_overrides."+override.Key.String()+".", migration)
+		}
+		root.Values = slices.DeleteFunc(root.Values, func(value *ast.MappingValueNode) bool {
+			return value.Key.String() == ConfigKeyConfigVersion
+		})
+	}
+	migration.Changes = append(migration.Changes,
+		fmt.Sprintf("set %s to %d", ConfigKeyConfigVersion, CurrentConfigVersion))
+
+	migrated := fmt.Sprintf("%s: %d\n", ConfigKeyConfigVersion, CurrentConfigVersion)
+	if root != nil && len(root.Values) > 0 {
+		migrated += strings.TrimRight(file.String(), "\n") + "\n"
+	}
+	migration.Content = []byte(migrated)
+
+	return migration, nil
+}
+
+// MigrateConfigFile migrates the configuration file at path in place and keeps
+// the original next to it with a .backup extension. With dryRun nothing is written.
+func MigrateConfigFile(path string, dryRun bool) (*ConfigMigration, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- config path from user input
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+	migration, err := MigrateConfig(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate %s: %w", path, err)
+	}
+	if dryRun || migration.FromVersion == migration.ToVersion {
+		return migration, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat config %s: %w", path, err)
+	}
+	if err := os.WriteFile(ConfigBackupPath(path), content, FilePermDefault); err != nil {
+		return nil, fmt.Errorf("failed to write backup of %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, migration.Content, info.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("failed to write config %s: %w", path, err)
+	}
+
+	return migration, nil
+}
+
+// checkConfigVersion rejects configuration written for a newer version of the tool.
+func checkConfigVersion(version int) error {
+	if version > CurrentConfigVersion {
+		return fmt.Errorf("%w: %d > %d; upgrade gh-action-readme", ErrConfigVersionTooNew, version, CurrentConfigVersion)
+	}
+
+	return nil
+}
+
+// ConfigBackupPath returns the path config migrate backs the file at path up to.
+func ConfigBackupPath(path string) string {
+	return path + configBackupExtension
+}
+
+// configVersion returns the config_version of a config mapping, 1 when unset.
+func configVersion(root *ast.MappingNode) int {
+	if root == nil {
+		return 1
+	}
+	value := yamlsafe.MappingValue(root, ConfigKeyConfigVersion)
+	if value == nil {
+		return 1
+	}
+	version, err := strconv.Atoi(scalarText(value))
+	if err != nil || version < 1 {
+		return 1
+	}
+
+	return version
+}
+
+// migrateConfigMapping applies the version 2 changes to one mapping of settings.
+// prefix qualifies the keys in the summary, as for repository overrides.
+func migrateConfigMapping(node ast.Node, prefix string, migration *ConfigMigration) {
+	mapping, ok := node.(*ast.MappingNode)
+	if !ok {
+		return
+	}
+
+	hasTheme := yamlsafe.MappingValue(mapping, "theme") != nil
+	kept := mapping.Values[:0]
+	for _, value := range mapping.Values {
+		key := value.Key.String()
+		switch {
+		case renamedConfigKeys[key] != "":
+			newKey := renamedConfigKeys[key]
+			if yamlsafe.MappingValue(mapping, newKey) != nil {
+				migration.Changes = append(migration.Changes,
+					fmt.Sprintf("removed %s%s, superseded by %s", prefix, key, newKey))
+
+				continue
+			}
+			setScalarToken(value.Key, newKey)
+			migration.Changes = append(migration.Changes, fmt.Sprintf("renamed %s%s to %s", prefix, key, newKey))
+		case key == "template":
+			if !migrateTemplate(value, prefix, hasTheme, migration) {
+				continue
+			}
+		case builtinConfigPaths[key] != "" && isBuiltinPath(scalarText(value.Value), builtinConfigPaths[key]):
+			migration.Changes = append(migration.Changes,
+				fmt.Sprintf("removed %s%s, the built-in default is used", prefix, key))
+
+			continue
+		case prefix == "" && !knownConfigKeys()[key]:
+			migration.Warnings = append(migration.Warnings,
+				fmt.Sprintf("unknown setting %s is not used by gh-action-readme", key))
+		}
+		kept = append(kept, value)
+	}
+	mapping.Values = kept
+}
+
+// migrateTemplate turns a template that points at a built-in theme into the
+// theme setting. It reports whether the template entry is kept.
+func migrateTemplate(value *ast.MappingValueNode, prefix string, hasTheme bool, migration *ConfigMigration) bool {
+	path := scalarText(value.Value)
+	for template, theme := range themeTemplates {
+		if !isBuiltinPath(path, template) {
+			continue
+		}
+		if hasTheme {
+			migration.Changes = append(migration.Changes,
+				fmt.Sprintf("removed %stemplate, the theme setting selects the template", prefix))
+
+			return false
+		}
+		setScalarToken(value.Key, "theme")
+		setScalarToken(value.Value, theme)
+		migration.Changes = append(migration.Changes, fmt.Sprintf("replaced %stemplate with theme %s", prefix, theme))
+
+		return true
+	}
+
+	migration.Warnings = append(migration.Warnings,
+		fmt.Sprintf("custom %stemplate %s is only used when theme is set to an empty string", prefix, path))
+
+	return true
+}
+
+// isBuiltinPath reports whether path is the built-in relative path or that
+// path resolved against an installation directory.
+func isBuiltinPath(path, builtin string) bool {
+	path = filepath.ToSlash(path)
+
+	return path == builtin || strings.HasSuffix(path, "/"+builtin)
+}
+
+// scalarText returns the text of a scalar node without quotes or comments.
+func scalarText(node ast.Node) string {
+	if token := node.GetToken(); token != nil {
+		return strings.TrimSpace(token.Value)
+	}
+
+	return ""
+}
+
+// setScalarToken replaces the text of a scalar key or value node.
+func setScalarToken(node ast.Node, text string) {
+	if str, ok := node.(*ast.StringNode); ok {
+		str.Value = text
+	}
+	if token := node.GetToken(); token != nil {
+		token.Value = text
+	}
+}
+
+// knownConfigKeys returns the top-level keys of AppConfig.
+func knownConfigKeys() map[string]bool {
+	keys := make(map[string]bool)
+	configType := reflect.TypeFor[AppConfig]()
+	for i := range configType.NumField() {
+		if key := configType.Field(i).Tag.Get("mapstructure"); key != "" {
+			keys[key] = true
+		}
+	}
+
+	return keys
+}
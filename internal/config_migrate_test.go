@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		want     string
+		changes  int
+		warnings int
+	}{
+		{
+			name: "version 1 file written by config init",
+			input: "# Settings\ntheme: default # the theme\ntemplate: /opt/gh/templates/readme.tmpl\n" +
+				"schema: /opt/gh/schemas/schema.json\ndependencies_enabled: true\ncache_ttl: 3600\n",
+			want: "config_version: 2\n# Settings\ntheme: default # the theme\n" +
+				"analyze_dependencies: true\ncache_ttl: 3600\n",
+			changes:  4,
+			warnings: 1,
+		},
+		{
+			name:    "template of a built-in theme becomes the theme",
+			input:   "template: templates/themes/github/readme.tmpl\n",
+			want:    "config_version: 2\ntheme: github\n",
+			changes: 2,
+		},
+		{
+			name:     "custom template is kept",
+			input:    "theme: \"\"\ntemplate: docs/readme.tmpl\n",
+			want:     "config_version: 2\ntheme: \"\"\ntemplate: docs/readme.tmpl\n",
+			changes:  1,
+			warnings: 1,
+		},
+		{
+			name:    "renamed key does not override the new key",
+			input:   "analyze_dependencies: false\ndependencies_enabled: true\n",
+			want:    "config_version: 2\nanalyze_dependencies: false\n",
+			changes: 2,
+		},
+		{
+			name: "repository overrides are migrated",
+			input: "config_version: 1\nrepo_overrides:\n  acme/tools:\n" +
+				"    template: templates/themes/minimal/readme.tmpl\n",
+			want:    "config_version: 2\nrepo_overrides:\n  acme/tools:\n    theme: minimal\n",
+			changes: 2,
+		},
+		{
+			name:    "empty file",
+			input:   "",
+			want:    "config_version: 2\n",
+			changes: 1,
+		},
+		{
+			name:  "current version is unchanged",
+			input: "config_version: 2\ntemplate: templates/readme.tmpl\n",
+			want:  "config_version: 2\ntemplate: templates/readme.tmpl\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			migration, err := MigrateConfig([]byte(tt.input))
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tt.want, string(migration.Content))
+			testutil.AssertEqual(t, CurrentConfigVersion, migration.ToVersion)
+			if len(migration.Changes) != tt.changes || len(migration.Warnings) != tt.warnings {
+				t.Errorf("got changes %q and warnings %q, want %d and %d",
+					migration.Changes, migration.Warnings, tt.changes, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestMigrateConfig_TooNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := MigrateConfig([]byte("config_version: 99\n"))
+	if !errors.Is(err, ErrConfigVersionTooNew) {
+		t.Fatalf("expected ErrConfigVersionTooNew, got %v", err)
+	}
+}
+
+func TestMigrateConfigFile(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	path := filepath.Join(tmpDir, ".ghreadme.yaml")
+	original := "dependencies_enabled: true\n"
+	testutil.WriteTestFile(t, path, original)
+
+	migration, err := MigrateConfigFile(path, true)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, migration.FromVersion)
+	if _, err := os.Stat(ConfigBackupPath(path)); !os.IsNotExist(err) {
+		t.Fatal("dry run must not write a backup")
+	}
+
+	_, err = MigrateConfigFile(path, false)
+	testutil.AssertNoError(t, err)
+	backup, err := os.ReadFile(ConfigBackupPath(path)) // #nosec G304 -- test file path
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, original, string(backup))
+
+	config, err := NewConfigurationLoader().loadConfigFromFile(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, CurrentConfigVersion, config.ConfigVersion)
+	testutil.AssertEqual(t, true, config.AnalyzeDependencies)
+
+	testutil.WriteTestFile(t, path, "config_version: 3\n")
+	if _, err := NewConfigurationLoader().loadConfigFromFile(path); !errors.Is(err, ErrConfigVersionTooNew) {
+		t.Errorf("expected configuration from a newer version to be rejected, got %v", err)
+	}
+}
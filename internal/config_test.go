@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"context"
+	"net/http"
 	"os"
 	"path/filepath"
 	"testing"
@@ -628,6 +630,18 @@ func TestMergeMapFields(t *testing.T) {
 				Variables:   map[string]string{"VAR1": "value1"},
 			},
 		},
+		{
+			name: "merge rules into existing dst",
+			dst: &AppConfig{
+				Rules: map[string]string{"missing-branding": "off"},
+			},
+			src: &AppConfig{
+				Rules: map[string]string{"unpinned-step": "error"},
+			},
+			expected: &AppConfig{
+				Rules: map[string]string{"missing-branding": "off", "unpinned-step": "error"},
+			},
+		},
 		{
 			name: "empty src does not affect dst",
 			dst: &AppConfig{
@@ -659,11 +673,18 @@ func TestMergeMapFields(t *testing.T) {
 					dst.Variables[k] = v
 				}
 			}
+			if tt.dst.Rules != nil {
+				dst.Rules = make(map[string]string)
+				for k, v := range tt.dst.Rules {
+					dst.Rules[k] = v
+				}
+			}
 
 			mergeMapFields(dst, tt.src)
 
 			testutil.AssertEqual(t, tt.expected.Permissions, dst.Permissions)
 			testutil.AssertEqual(t, tt.expected.Variables, dst.Variables)
+			testutil.AssertEqual(t, tt.expected.Rules, dst.Rules)
 		})
 	}
 }
@@ -730,3 +751,66 @@ func TestMergeSliceFields(t *testing.T) {
 		})
 	}
 }
+
+// TestMergeLimitFields tests that set resource limits override and zero limits are ignored.
+func TestMergeLimitFields(t *testing.T) {
+	t.Parallel()
+
+	dst := &AppConfig{Limits: DefaultResourceLimits()}
+	mergeLimitFields(dst, &AppConfig{Limits: ResourceLimits{MaxFiles: 10}})
+
+	expected := DefaultResourceLimits()
+	expected.MaxFiles = 10
+	testutil.AssertEqual(t, expected, dst.Limits)
+}
+
+func TestMergeConfigs_Enrichment(t *testing.T) {
+	t.Parallel()
+
+	dst := DefaultAppConfig()
+	dst.GitHubToken = "token"
+	testutil.AssertEqual(t, true, dst.EnrichmentEnabled())
+	testutil.AssertEqual(t, true, dst.GitHubLookups())
+
+	MergeConfigs(dst, &AppConfig{Enrichment: new(bool)}, false)
+	testutil.AssertEqual(t, false, dst.EnrichmentEnabled())
+	testutil.AssertEqual(t, false, dst.GitHubLookups())
+
+	// Configs that leave enrichment unset keep the merged value
+	MergeConfigs(dst, &AppConfig{Theme: ThemeMinimal}, false)
+	testutil.AssertEqual(t, false, dst.EnrichmentEnabled())
+}
+
+func TestLoadConfiguration_RepoOverrideCase(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	repoRoot := filepath.Join(dir, "repo")
+	testutil.WriteTestFile(t, filepath.Join(repoRoot, ".git", "config"),
+		"[remote \"origin\"]\n\turl = https://github.com/Acme/Tools.git\n")
+	configFile := filepath.Join(dir, "config.yaml")
+	// viper lowercases the repository names of overrides
+	testutil.WriteTestFile(t, configFile, "repo_overrides:\n  Acme/Tools:\n    theme: minimal\n")
+
+	config, err := LoadConfiguration(configFile, repoRoot, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, ThemeMinimal, config.Theme)
+
+	config, err = NewConfigurationLoader().LoadConfiguration(configFile, repoRoot, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, ThemeMinimal, config.Theme)
+}
+
+func TestNewGitHubClient_RetriesSecondaryRateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := testutil.NewGitHubServer(t, map[string]string{"GET /repos/acme/tool": testutil.GitHubRepoResponse})
+	server.FailSecondaryRateLimit(http.MethodGet, "/repos/acme/tool", 1)
+	client, err := newGitHubClient("token", server.URL)
+	testutil.AssertNoError(t, err)
+
+	repo, _, err := client.Client.Repositories.Get(context.Background(), "acme", "tool")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "checkout", repo.GetName())
+	testutil.AssertEqual(t, 2, len(server.Requests()))
+}
@@ -3,11 +3,12 @@ package internal
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
-	"github.com/adrg/xdg"
 	"github.com/spf13/viper"
 )
 
@@ -133,6 +134,62 @@ func (cl *ConfigurationLoader) ValidateConfiguration(config *AppConfig) error {
 		}
 	}
 
+	// Validate input ordering (if set)
+	validSortModes := []string{SortInputsDeclaration, SortInputsAlpha, SortInputsRequiredFirst}
+	if config.SortInputs != "" && !containsString(validSortModes, config.SortInputs) {
+		return fmt.Errorf("invalid sort_inputs '%s', must be one of: %s",
+			config.SortInputs, strings.Join(validSortModes, ", "))
+	}
+
+	// Validate README filename (if set)
+	if err := ValidateReadmeFilename(config.ReadmeFilename); err != nil {
+		return err
+	}
+
+	// Validate rule overrides
+	if err := ValidateRuleConfig(config.Rules); err != nil {
+		return err
+	}
+
+	// Validate resource limits
+	if err := ValidateResourceLimits(config.Limits); err != nil {
+		return err
+	}
+
+	// Validate discovery patterns
+	if err := ValidateDiscoveryPatterns(config.Discovery.Patterns); err != nil {
+		return err
+	}
+
+	// Validate dependency cache and policy settings
+	if err := ValidateCacheSettings(config.Cache); err != nil {
+		return err
+	}
+	if err := ValidateDepsPolicy(config.Deps); err != nil {
+		return err
+	}
+	if err := ValidateSinkSettings(config.Sink); err != nil {
+		return err
+	}
+	if err := ValidateNotifySettings(config.Notify); err != nil {
+		return err
+	}
+	if err := ValidateTrendSettings(config.Trends); err != nil {
+		return err
+	}
+	if err := ValidateTableSettings(config.Tables); err != nil {
+		return err
+	}
+	if err := ValidateFrontMatterSettings(config.FrontMatter); err != nil {
+		return err
+	}
+	if err := ValidatePlugins(config.Plugins); err != nil {
+		return err
+	}
+	if err := ValidateHookSettings(config.Hooks); err != nil {
+		return err
+	}
+
 	// Validate output directory
 	if config.OutputDir == "" {
 		return errors.New("output directory cannot be empty")
@@ -160,8 +217,8 @@ func containsString(slice []string, str string) bool {
 // GetConfigurationSources returns the currently enabled configuration sources.
 func (cl *ConfigurationLoader) GetConfigurationSources() []ConfigurationSource {
 	var sources []ConfigurationSource
-	for source, enabled := range cl.sources {
-		if enabled {
+	for _, source := range slices.Sorted(maps.Keys(cl.sources)) {
+		if cl.sources[source] {
 			sources = append(sources, source)
 		}
 	}
@@ -256,17 +313,9 @@ func (cl *ConfigurationLoader) loadGlobalConfig(configFile string) (*AppConfig,
 	v.SetConfigName(ConfigFileName)
 	v.SetConfigType("yaml")
 
-	// Add XDG-compliant configuration directory
-	configDir, err := xdg.ConfigFile("gh-action-readme")
-	if err != nil {
-		return nil, fmt.Errorf("failed to get XDG config directory: %w", err)
+	if err := addConfigSearchPaths(v); err != nil {
+		return nil, err
 	}
-	v.AddConfigPath(filepath.Dir(configDir))
-
-	// Add additional search paths
-	v.AddConfigPath(".")                              // current directory
-	v.AddConfigPath("$HOME/.config/gh-action-readme") // fallback
-	v.AddConfigPath("/etc/gh-action-readme")          // system-wide
 
 	// Set environment variable prefix
 	v.SetEnvPrefix("GH_ACTION_README")
@@ -294,6 +343,9 @@ func (cl *ConfigurationLoader) loadGlobalConfig(configFile string) (*AppConfig,
 	if err := v.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	if err := checkConfigVersion(config.ConfigVersion); err != nil {
+		return nil, err
+	}
 
 	// Resolve template paths relative to binary if they're not absolute
 	config.Template = resolveTemplatePath(config.Template)
@@ -306,14 +358,7 @@ func (cl *ConfigurationLoader) loadGlobalConfig(configFile string) (*AppConfig,
 
 // loadRepoConfig loads repository-level configuration from hidden config files.
 func (cl *ConfigurationLoader) loadRepoConfig(repoRoot string) (*AppConfig, error) {
-	// Hidden config file paths in priority order
-	configPaths := []string{
-		".ghreadme.yaml",        // Primary hidden config
-		".config/ghreadme.yaml", // Secondary hidden config
-		".github/ghreadme.yaml", // GitHub ecosystem standard
-	}
-
-	for _, configName := range configPaths {
+	for _, configName := range repoConfigFiles {
 		configPath := filepath.Join(repoRoot, configName)
 		if _, err := os.Stat(configPath); err == nil {
 			// Config file found, load it
@@ -349,6 +394,9 @@ func (cl *ConfigurationLoader) loadConfigFromFile(configPath string) (*AppConfig
 	if err := v.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	if err := checkConfigVersion(config.ConfigVersion); err != nil {
+		return nil, err
+	}
 
 	return &config, nil
 }
@@ -364,7 +412,7 @@ func (cl *ConfigurationLoader) applyRepoOverrides(config *AppConfig, repoRoot st
 		return // No overrides configured
 	}
 
-	if repoOverride, exists := config.RepoOverrides[repoName]; exists {
+	if repoOverride, exists := findRepoOverride(config.RepoOverrides, repoName); exists {
 		cl.mergeConfigs(config, &repoOverride, false) // No tokens in overrides
 	}
 }
@@ -393,14 +441,39 @@ func (cl *ConfigurationLoader) setViperDefaults(v *viper.Viper) {
 	v.SetDefault("theme", defaults.Theme)
 	v.SetDefault("output_format", defaults.OutputFormat)
 	v.SetDefault("output_dir", defaults.OutputDir)
+	v.SetDefault("sort_inputs", defaults.SortInputs)
+	v.SetDefault("logo_width", defaults.LogoWidth)
 	v.SetDefault("template", defaults.Template)
 	v.SetDefault("header", defaults.Header)
 	v.SetDefault("footer", defaults.Footer)
 	v.SetDefault("schema", defaults.Schema)
 	v.SetDefault("analyze_dependencies", defaults.AnalyzeDependencies)
 	v.SetDefault("show_security_info", defaults.ShowSecurityInfo)
+	v.SetDefault("show_metrics", defaults.ShowMetrics)
+	v.SetDefault("show_support", defaults.ShowSupport)
+	v.SetDefault("show_compatibility", defaults.ShowCompatibility)
+	v.SetDefault("attribution", defaults.Attribution)
+	v.SetDefault("locale", defaults.Locale)
 	v.SetDefault("verbose", defaults.Verbose)
 	v.SetDefault("quiet", defaults.Quiet)
+	v.SetDefault("limits.max_file_size", defaults.Limits.MaxFileSize)
+	v.SetDefault("limits.max_nodes", defaults.Limits.MaxNodes)
+	v.SetDefault("limits.max_depth", defaults.Limits.MaxDepth)
+	v.SetDefault("limits.max_steps", defaults.Limits.MaxSteps)
+	v.SetDefault("limits.max_files", defaults.Limits.MaxFiles)
+	v.SetDefault("limits.max_transitive_depth", defaults.Limits.MaxTransitiveDepth)
+	v.SetDefault("limits.max_render_size", defaults.Limits.MaxRenderSize)
+	v.SetDefault("limits.render_timeout", defaults.Limits.RenderTimeout)
+	v.SetDefault("tables.style", defaults.Tables.Style)
+	v.SetDefault("tables.newlines", defaults.Tables.Newlines)
+	v.SetDefault("tables.max_description_width", defaults.Tables.MaxDescriptionWidth)
+	v.SetDefault("front_matter.title", defaults.FrontMatter.Title)
+	v.SetDefault("front_matter.slug", defaults.FrontMatter.Slug)
+	v.SetDefault("front_matter.description", defaults.FrontMatter.Description)
+	v.SetDefault("front_matter.tags", defaults.FrontMatter.Tags)
+	v.SetDefault("cache.ttl", defaults.Cache.TTL)
+	v.SetDefault("cache.backend", defaults.Cache.Backend)
+	v.SetDefault("deps.pin_strategy", defaults.Deps.PinStrategy)
 	v.SetDefault("defaults.name", defaults.Defaults.Name)
 	v.SetDefault("defaults.description", defaults.Defaults.Description)
 	v.SetDefault("defaults.branding.icon", defaults.Defaults.Branding.Icon)
@@ -414,7 +487,7 @@ func (cl *ConfigurationLoader) validateTheme(theme string) error {
 	}
 
 	// Check if it's a built-in theme
-	supportedThemes := []string{"default", "github", "gitlab", "minimal", "professional"}
+	supportedThemes := []string{"default", "github", "gitlab", "minimal", "professional", "docs-site", "badge-minimal"}
 	if containsString(supportedThemes, theme) {
 		return nil
 	}
@@ -422,6 +422,38 @@ func TestConfigurationLoader_ValidateConfiguration(t *testing.T) {
 			expectError: true,
 			errorMsg:    "invalid theme",
 		},
+		{
+			name: "invalid sort_inputs",
+			config: &AppConfig{
+				Theme:        "default",
+				OutputFormat: "md",
+				OutputDir:    ".",
+				SortInputs:   "random",
+			},
+			expectError: true,
+			errorMsg:    "invalid sort_inputs",
+		},
+		{
+			name: "unknown validation rule",
+			config: &AppConfig{
+				Theme:        "default",
+				OutputFormat: "md",
+				OutputDir:    ".",
+				Rules:        map[string]string{"no-such-rule": "off"},
+			},
+			expectError: true,
+			errorMsg:    "unknown validation rule",
+		},
+		{
+			name: "valid sort_inputs",
+			config: &AppConfig{
+				Theme:        "default",
+				OutputFormat: "md",
+				OutputDir:    ".",
+				SortInputs:   SortInputsRequiredFirst,
+			},
+			expectError: false,
+		},
 		{
 			name: "valid built-in themes",
 			config: &AppConfig{
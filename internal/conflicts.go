@@ -0,0 +1,137 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	errCodes "github.com/ivuorinen/gh-action-readme/internal/errors"
+)
+
+// Action conflict kinds.
+const (
+	// ConflictDuplicateName marks actions that declare the same name.
+	ConflictDuplicateName = "duplicate-name"
+	// ConflictOutputPath marks actions whose documentation would be written to the same file.
+	ConflictOutputPath = "output-path"
+)
+
+// ActionConflict is a name or output file shared by several actions of a run.
+type ActionConflict struct {
+	Kind string
+	// Value is the shared action name or output path.
+	Value string
+	// Actions are the action files involved, in discovery order.
+	Actions []string
+}
+
+// FindActionConflicts reports actions in paths that share a name, compared
+// case-insensitively, or whose documentation would be written to the same file
+// in the configured output format. Files that cannot be parsed are skipped;
+// generation reports them.
+func (g *Generator) FindActionConflicts(paths []string) []ActionConflict {
+	names := newConflictGroups()
+	outputs := newConflictGroups()
+	seen := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		clean := filepath.Clean(path)
+		if seen[clean] {
+			continue
+		}
+		seen[clean] = true
+
+		action, err := ParseActionYML(path)
+		if err != nil {
+			continue
+		}
+		if name := strings.TrimSpace(action.Name); name != "" {
+			names.add(strings.ToLower(name), name, path)
+		}
+		outputPath := filepath.Clean(g.outputPath(action, path))
+		outputs.add(outputPath, outputPath, path)
+	}
+
+	return append(names.conflicts(ConflictDuplicateName), outputs.conflicts(ConflictOutputPath)...)
+}
+
+// checkConflicts warns about duplicate action names and rejects runs in which
+// several actions would write the same output file, which would otherwise
+// silently overwrite each other. It returns the reason each colliding action
+// cannot be generated, so that a run that keeps going can skip them.
+func (g *Generator) checkConflicts(paths []string) (map[string]string, error) {
+	collided := make(map[string]string)
+	collisions := 0
+	for _, conflict := range g.FindActionConflicts(paths) {
+		actions := strings.Join(conflict.Actions, ", ")
+		if conflict.Kind == ConflictDuplicateName {
+			message := fmt.Sprintf("Action name '%s' is used by %d actions: %s",
+				conflict.Value, len(conflict.Actions), actions)
+			g.Output.Warning("%s", message)
+			RecordDiagnostic(Diagnostic{
+				Severity:    SeverityWarning,
+				Code:        DiagnosticCodeDuplicateActionName,
+				Source:      DiagnosticSourceGeneration,
+				Message:     message,
+				File:        conflict.Actions[0],
+				Suggestions: []string{"Give each action a unique 'name:' so they can be told apart in documentation"},
+			})
+
+			continue
+		}
+
+		collisions++
+		for _, action := range conflict.Actions {
+			collided[action] = fmt.Sprintf("%d actions would write documentation to %s: %s",
+				len(conflict.Actions), conflict.Value, actions)
+		}
+		g.Output.ErrorWithContext(
+			errCodes.ErrCodeOutputConflict,
+			fmt.Sprintf("%d actions would write documentation to %s", len(conflict.Actions), conflict.Value),
+			map[string]string{
+				"output_path":   conflict.Value,
+				"actions":       actions,
+				"actions_count": strconv.Itoa(len(conflict.Actions)),
+			},
+		)
+	}
+	if collisions > 0 {
+		return collided, fmt.Errorf("%d output file(s) would be written by more than one action", collisions)
+	}
+
+	return collided, nil
+}
+
+// conflictGroups groups action files by a key, remembering the order keys were first seen.
+type conflictGroups struct {
+	keys    []string
+	values  map[string]string
+	actions map[string][]string
+}
+
+// newConflictGroups creates an empty set of groups.
+func newConflictGroups() *conflictGroups {
+	return &conflictGroups{values: map[string]string{}, actions: map[string][]string{}}
+}
+
+// add records that the action at path has key, displayed as value.
+func (c *conflictGroups) add(key, value, path string) {
+	if _, ok := c.actions[key]; !ok {
+		c.keys = append(c.keys, key)
+		c.values[key] = value
+	}
+	c.actions[key] = append(c.actions[key], path)
+}
+
+// conflicts returns the groups with more than one action as conflicts of kind.
+func (c *conflictGroups) conflicts(kind string) []ActionConflict {
+	var conflicts []ActionConflict
+	for _, key := range c.keys {
+		if actions := c.actions[key]; len(actions) > 1 {
+			conflicts = append(conflicts, ActionConflict{Kind: kind, Value: c.values[key], Actions: slices.Clone(actions)})
+		}
+	}
+
+	return conflicts
+}
@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func writeNamedAction(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, "action.yml")
+	testutil.WriteTestFile(t, path, "name: "+name+"\ndescription: Test action\nruns:\n  using: node20\n  main: index.js\n")
+
+	return path
+}
+
+func TestGenerator_FindActionConflicts(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	build := writeNamedAction(t, filepath.Join(tmpDir, "build"), "Build")
+	buildCopy := writeNamedAction(t, filepath.Join(tmpDir, "legacy"), "build")
+	deploy := writeNamedAction(t, filepath.Join(tmpDir, "deploy"), "Deploy")
+	paths := []string{build, buildCopy, deploy, build}
+
+	tests := []struct {
+		name      string
+		configure func(config *AppConfig)
+		want      []ActionConflict
+	}{
+		{
+			name: "markdown next to each action only shares names",
+			want: []ActionConflict{
+				{Kind: ConflictDuplicateName, Value: "Build", Actions: []string{build, buildCopy}},
+			},
+		},
+		{
+			name: "html in one output directory collides on the action name",
+			configure: func(config *AppConfig) {
+				config.OutputFormat = OutputFormatHTML
+				config.OutputDir = filepath.Join(tmpDir, "docs")
+			},
+			want: []ActionConflict{
+				{Kind: ConflictDuplicateName, Value: "Build", Actions: []string{build, buildCopy}},
+			},
+		},
+		{
+			name: "markdown in one output directory collides on README.md",
+			configure: func(config *AppConfig) {
+				config.OutputDir = filepath.Join(tmpDir, "docs")
+			},
+			want: []ActionConflict{
+				{Kind: ConflictDuplicateName, Value: "Build", Actions: []string{build, buildCopy}},
+				{
+					Kind:    ConflictOutputPath,
+					Value:   filepath.Join(tmpDir, "docs", "README.md"),
+					Actions: []string{build, buildCopy, deploy},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := DefaultAppConfig()
+			if tt.configure != nil {
+				tt.configure(config)
+			}
+			generator := NewGeneratorWithDependencies(config, NewNullOutput(), NewNullProgressManager())
+			if got := generator.FindActionConflicts(paths); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindActionConflicts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_ProcessBatchRejectsOutputConflicts(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	testutil.SetupTestTemplates(t, tmpDir)
+	first := writeNamedAction(t, filepath.Join(tmpDir, "one"), "Release")
+	second := writeNamedAction(t, filepath.Join(tmpDir, "two"), "Release")
+
+	config := DefaultAppConfig()
+	config.OutputFormat = OutputFormatHTML
+	config.OutputDir = filepath.Join(tmpDir, "docs")
+	config.Template = filepath.Join(tmpDir, "templates", "readme.tmpl")
+	testutil.AssertNoError(t, os.MkdirAll(config.OutputDir, 0o750))
+	generator := NewGeneratorWithDependencies(config, NewNullOutput(), NewNullProgressManager())
+
+	err := generator.ProcessBatch([]string{first, second})
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "more than one action")
+	if _, err := os.Stat(filepath.Join(tmpDir, "docs", "Release.html")); err == nil {
+		t.Error("expected nothing to be written when outputs conflict")
+	}
+
+	// Distinct names generate one page each.
+	third := writeNamedAction(t, filepath.Join(tmpDir, "three"), "Publish")
+	testutil.AssertNoError(t, generator.ProcessBatch([]string{first, third}))
+
+	// A run that keeps going skips the colliding actions and generates the others.
+	testutil.AssertNoError(t, os.Remove(filepath.Join(tmpDir, "docs", "Publish.html")))
+	generator.KeepGoing = true
+	summary, err := generator.ProcessBatchWithSummary([]string{first, second, third})
+	testutil.AssertError(t, err)
+	testutil.AssertEqual(t, 2, summary.Failed)
+	testutil.AssertStringContains(t, summary.Failures[0].Reason, "Release.html")
+	testutil.AssertEqual(t, 1, summary.Generated)
+	_, err = os.Stat(filepath.Join(tmpDir, "docs", "Publish.html"))
+	testutil.AssertNoError(t, err)
+}
@@ -52,16 +52,32 @@ const (
 	ThemeMinimal = "minimal"
 	// ThemeProfessional is the professional theme identifier.
 	ThemeProfessional = "professional"
+	// ThemeDocsSite is the docs-site theme identifier.
+	ThemeDocsSite = "docs-site"
+	// ThemeBadgeMinimal is the badge-minimal theme identifier.
+	ThemeBadgeMinimal = "badge-minimal"
 	// ThemeDefault is the default theme identifier.
 	ThemeDefault = "default"
 )
 
+// Input sort modes.
+const (
+	// SortInputsDeclaration keeps inputs in the order they are declared in action.yml.
+	SortInputsDeclaration = "declaration"
+	// SortInputsAlpha sorts inputs alphabetically by name.
+	SortInputsAlpha = "alpha"
+	// SortInputsRequiredFirst lists required inputs before optional ones, in declaration order.
+	SortInputsRequiredFirst = "required-first"
+)
+
 // Environment variable names.
 const (
 	// EnvGitHubToken is the tool-specific GitHub token environment variable.
 	EnvGitHubToken = "GH_README_GITHUB_TOKEN" // #nosec G101 -- environment variable name, not a credential
 	// EnvGitHubTokenStandard is the standard GitHub token environment variable.
 	EnvGitHubTokenStandard = "GITHUB_TOKEN" // #nosec G101 -- environment variable name, not a credential
+	// EnvSourceDateEpoch is the Unix time used instead of the current time in generated output.
+	EnvSourceDateEpoch = "SOURCE_DATE_EPOCH"
 )
 
 // Configuration keys and paths.
@@ -82,6 +98,20 @@ const (
 	ConfigKeyAnalyzeDependencies = "analyze_dependencies"
 	// ConfigKeyShowSecurityInfo is the configuration key for security info display.
 	ConfigKeyShowSecurityInfo = "show_security_info"
+	// ConfigKeyShowMetrics is the configuration key for the metrics section in generated docs.
+	ConfigKeyShowMetrics = "show_metrics"
+	// ConfigKeyShowSupport is the configuration key for the support section in generated docs.
+	ConfigKeyShowSupport = "show_support"
+	// ConfigKeyShowCompatibility is the configuration key for the OS compatibility section in generated docs.
+	ConfigKeyShowCompatibility = "show_compatibility"
+	// ConfigKeyConfigVersion is the configuration key for the configuration format version.
+	ConfigKeyConfigVersion = "config_version"
+	// ConfigKeyAttribution is the configuration key for the attribution line of generated docs.
+	ConfigKeyAttribution = "attribution"
+	// ConfigKeyLocale is the configuration key for the locale of CLI output.
+	ConfigKeyLocale = "locale"
+	// ConfigKeySortInputs is the configuration key for input ordering.
+	ConfigKeySortInputs = "sort_inputs"
 )
 
 // Template path constants.
@@ -96,6 +126,16 @@ const (
 	TemplatePathMinimal = "templates/themes/minimal/readme.tmpl"
 	// TemplatePathProfessional is the professional theme template path.
 	TemplatePathProfessional = "templates/themes/professional/readme.tmpl"
+	// TemplatePathDocsSite is the docs-site theme template path.
+	TemplatePathDocsSite = "templates/themes/docs-site/readme.tmpl"
+	// TemplatePathBadgeMinimal is the badge-minimal theme template path.
+	TemplatePathBadgeMinimal = "templates/themes/badge-minimal/readme.tmpl"
+	// TemplatePathReleaseNotes is the default release notes template path.
+	TemplatePathReleaseNotes = "templates/release-notes.tmpl"
+	// TemplatePathAnnouncement is the release announcement template path.
+	TemplatePathAnnouncement = "templates/announcement.tmpl"
+	// TemplatePathPolicyIssue is the dependency policy issue template path.
+	TemplatePathPolicyIssue = "templates/policy-issue.tmpl"
 )
 
 // Config file search patterns.
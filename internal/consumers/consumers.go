@@ -0,0 +1,211 @@
+// Package consumers finds workflows in a GitHub organization that use a given action.
+package consumers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+
+	"github.com/ivuorinen/gh-action-readme/internal/codeowners"
+)
+
+const (
+	// searchPageSize is the number of code search results requested per page.
+	searchPageSize = 100
+
+	// workflowsPath is where GitHub looks for workflow definitions.
+	workflowsPath = ".github/workflows"
+)
+
+// Consumer is a single workflow reference to the searched action.
+type Consumer struct {
+	Repository string `json:"repository"`
+	Workflow   string `json:"workflow"`
+	Line       int    `json:"line"`
+	Uses       string `json:"uses"`
+	Ref        string `json:"ref"`
+}
+
+// VersionUsage groups the repositories consuming the action at one ref.
+type VersionUsage struct {
+	Ref          string   `json:"ref"`
+	Repositories []string `json:"repositories"`
+}
+
+// Finder searches an organization's workflows for usages of an action.
+type Finder struct {
+	client *github.Client
+}
+
+// NewFinder creates a Finder using the given GitHub client.
+func NewFinder(client *github.Client) *Finder {
+	return &Finder{client: client}
+}
+
+// Find returns every workflow step in org that uses action (owner/repo).
+// Results are sorted by repository, workflow path and line.
+func (f *Finder) Find(ctx context.Context, org, action string) ([]Consumer, error) {
+	if f.client == nil {
+		return nil, errors.New("GitHub client is required to search for consumers")
+	}
+	if org == "" {
+		return nil, errors.New("organization is required")
+	}
+	pattern, err := usesPattern(action)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := f.searchWorkflows(ctx, org, action)
+	if err != nil {
+		return nil, err
+	}
+
+	var consumers []Consumer
+	for _, file := range files {
+		content, err := f.fetchFile(ctx, file)
+		if err != nil {
+			return nil, err
+		}
+		consumers = append(consumers, extractConsumers(file.GetRepository().GetFullName(), file.GetPath(),
+			content, pattern)...)
+	}
+
+	slices.SortFunc(consumers, func(a, b Consumer) int {
+		if c := strings.Compare(a.Repository, b.Repository); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Workflow, b.Workflow); c != 0 {
+			return c
+		}
+
+		return a.Line - b.Line
+	})
+
+	return consumers, nil
+}
+
+// Owners returns the owners the CODEOWNERS file of action's repository assigns
+// to its root action.yml, so consumers know who maintains it. The list is empty
+// when the repository has no CODEOWNERS file or no matching rule.
+func (f *Finder) Owners(ctx context.Context, action string) ([]string, error) {
+	if f.client == nil {
+		return []string{}, errors.New("GitHub client is required to read CODEOWNERS")
+	}
+	owner, repo, ok := strings.Cut(action, "/")
+	if !ok || owner == "" || repo == "" {
+		return []string{}, fmt.Errorf("invalid action %q: expected owner/repo", action)
+	}
+
+	file, err := codeowners.Fetch(ctx, f.client, owner, repo)
+	if err != nil {
+		return []string{}, err
+	}
+	if owners := file.Owners("action.yml"); owners != nil {
+		return owners, nil
+	}
+
+	return []string{}, nil
+}
+
+// GroupByRef groups consumers by the ref they pin the action to. Refs are
+// sorted alphabetically and each repository is listed once per ref.
+func GroupByRef(consumers []Consumer) []VersionUsage {
+	repos := make(map[string][]string)
+	for _, consumer := range consumers {
+		if !slices.Contains(repos[consumer.Ref], consumer.Repository) {
+			repos[consumer.Ref] = append(repos[consumer.Ref], consumer.Repository)
+		}
+	}
+
+	usages := make([]VersionUsage, 0, len(repos))
+	for ref, repositories := range repos {
+		slices.Sort(repositories)
+		usages = append(usages, VersionUsage{Ref: ref, Repositories: repositories})
+	}
+	slices.SortFunc(usages, func(a, b VersionUsage) int {
+		return strings.Compare(a.Ref, b.Ref)
+	})
+
+	return usages
+}
+
+// searchWorkflows runs a code search for workflow files mentioning action.
+func (f *Finder) searchWorkflows(ctx context.Context, org, action string) ([]*github.CodeResult, error) {
+	query := fmt.Sprintf("%q org:%s path:%s", action, org, workflowsPath)
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: searchPageSize}}
+
+	var files []*github.CodeResult
+	for {
+		result, resp, err := f.client.Search.Code(ctx, query, opts)
+		if err != nil {
+			return nil, fmt.Errorf("code search failed: %w", err)
+		}
+		files = append(files, result.CodeResults...)
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return files, nil
+}
+
+// fetchFile downloads the content of a code search result from its default branch.
+func (f *Finder) fetchFile(ctx context.Context, file *github.CodeResult) (string, error) {
+	repo := file.GetRepository()
+	content, _, _, err := f.client.Repositories.GetContents(
+		ctx, repo.GetOwner().GetLogin(), repo.GetName(), file.GetPath(), nil,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s/%s: %w", repo.GetFullName(), file.GetPath(), err)
+	}
+	if content == nil {
+		return "", fmt.Errorf("%s/%s is not a file", repo.GetFullName(), file.GetPath())
+	}
+
+	text, err := content.GetContent()
+	if err != nil {
+		return "", fmt.Errorf("failed to decode %s/%s: %w", repo.GetFullName(), file.GetPath(), err)
+	}
+
+	return text, nil
+}
+
+// usesPattern builds a regular expression matching `uses:` lines for action.
+// Sub-path actions (owner/repo/path@ref) are matched as well.
+func usesPattern(action string) (*regexp.Regexp, error) {
+	parts := strings.Split(action, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid action %q: expected owner/repo", action)
+	}
+
+	return regexp.Compile(`(?i)^\s*(?:-\s*)?uses:\s*["']?(` + regexp.QuoteMeta(action) +
+		`(?:/[^@\s"']*)?@([^\s"'#]+))`)
+}
+
+// extractConsumers returns the `uses:` references to the action in a workflow file.
+func extractConsumers(repository, workflow, content string, pattern *regexp.Regexp) []Consumer {
+	var consumers []Consumer
+	for i, line := range strings.Split(content, "\n") {
+		match := pattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		consumers = append(consumers, Consumer{
+			Repository: repository,
+			Workflow:   workflow,
+			Line:       i + 1,
+			Uses:       match[1],
+			Ref:        match[2],
+		})
+	}
+
+	return consumers
+}
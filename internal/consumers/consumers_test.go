@@ -0,0 +1,146 @@
+package consumers
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+const workflowCI = `name: CI
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: acme/setup-tool@v1.2.0
+      - name: Lint
+        uses: "acme/setup-tool/lint@main" # tracks main
+      - uses: acme/setup-tool-extra@v2
+`
+
+const workflowRelease = `jobs:
+  release:
+    steps:
+      - uses: acme/setup-tool@8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e
+`
+
+func searchKey(query string) string {
+	params := url.Values{}
+	params.Set("per_page", "100")
+	params.Set("q", query)
+
+	return "GET https://api.github.com/search/code?" + params.Encode()
+}
+
+func contentResponse(content string) string {
+	return `{"type": "file", "encoding": "base64", "content": "` +
+		base64.StdEncoding.EncodeToString([]byte(content)) + `"}`
+}
+
+func codeResult(repo, path string) string {
+	return `{"path": "` + path + `", "repository": {"name": "` + repo +
+		`", "full_name": "acme/` + repo + `", "owner": {"login": "acme"}}}`
+}
+
+func TestFinder_Find(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.MockGitHubClient(map[string]string{
+		searchKey(`"acme/setup-tool" org:acme path:.github/workflows`): `{
+	"total_count": 2,
+	"items": [` + codeResult("web", ".github/workflows/release.yml") + `,` +
+			codeResult("api", ".github/workflows/ci.yml") + `]
+}`,
+		"GET https://api.github.com/repos/acme/api/contents/.github/workflows/ci.yml":      contentResponse(workflowCI),
+		"GET https://api.github.com/repos/acme/web/contents/.github/workflows/release.yml": contentResponse(workflowRelease),
+	})
+
+	consumers, err := NewFinder(client).Find(context.Background(), "acme", "acme/setup-tool")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 3, len(consumers))
+
+	testutil.AssertEqual(t, Consumer{
+		Repository: "acme/api",
+		Workflow:   ".github/workflows/ci.yml",
+		Line:       8,
+		Uses:       "acme/setup-tool@v1.2.0",
+		Ref:        "v1.2.0",
+	}, consumers[0])
+	testutil.AssertEqual(t, "acme/setup-tool/lint@main", consumers[1].Uses)
+	testutil.AssertEqual(t, "main", consumers[1].Ref)
+	testutil.AssertEqual(t, "acme/web", consumers[2].Repository)
+	testutil.AssertEqual(t, "8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e", consumers[2].Ref)
+
+	usages := GroupByRef(consumers)
+	testutil.AssertEqual(t, 3, len(usages))
+	testutil.AssertEqual(t, "8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e", usages[0].Ref)
+	testutil.AssertEqual(t, "main", usages[1].Ref)
+	testutil.AssertEqual(t, "v1.2.0", usages[2].Ref)
+}
+
+func TestFinder_FindErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		finder *Finder
+		org    string
+		action string
+		want   string
+	}{
+		{"no client", NewFinder(nil), "acme", "acme/tool", "GitHub client is required"},
+		{"no org", NewFinder(testutil.MockGitHubClient(nil)), "", "acme/tool", "organization is required"},
+		{"invalid action", NewFinder(testutil.MockGitHubClient(nil)), "acme", "acme", "expected owner/repo"},
+		{"search failure", NewFinder(testutil.MockGitHubClient(nil)), "acme", "acme/tool", "code search failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tt.finder.Find(context.Background(), tt.org, tt.action)
+			testutil.AssertError(t, err)
+			testutil.AssertStringContains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestFinder_Owners(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.MockGitHubClient(map[string]string{
+		"GET https://api.github.com/repos/acme/setup-tool/contents/.github/CODEOWNERS": contentResponse(
+			"* @acme/maintainers\n/lint/ @acme/lint\n"),
+	})
+	finder := NewFinder(client)
+
+	owners, err := finder.Owners(context.Background(), "acme/setup-tool")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "@acme/maintainers", strings.Join(owners, " "))
+
+	owners, err = finder.Owners(context.Background(), "acme/unowned")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(owners))
+
+	_, err = finder.Owners(context.Background(), "acme")
+	testutil.AssertError(t, err)
+}
+
+func TestGroupByRef_DeduplicatesRepositories(t *testing.T) {
+	t.Parallel()
+
+	usages := GroupByRef([]Consumer{
+		{Repository: "acme/b", Ref: "v1"},
+		{Repository: "acme/a", Ref: "v1"},
+		{Repository: "acme/b", Ref: "v1"},
+	})
+
+	testutil.AssertEqual(t, 1, len(usages))
+	testutil.AssertEqual(t, "acme/a,acme/b", usages[0].Repositories[0]+","+usages[0].Repositories[1])
+	testutil.AssertEqual(t, 2, len(usages[0].Repositories))
+}
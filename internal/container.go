@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// inputReference matches the inputs referenced in an expression as inputs.name,
+// inputs['name'] or inputs["name"].
+var inputReference = regexp.MustCompile(`\binputs\s*(?:\.([A-Za-z_][A-Za-z0-9_-]*)|\[\s*['"]([^'"]+)['"]\s*\])`)
+
+// expression matches a ${{ }} expression.
+var expression = regexp.MustCompile(`\$\{\{(.*?)\}\}`)
+
+// ContainerInterface documents how a docker action receives its inputs: as
+// INPUT_ environment variables, through runs.env, and as runs.args arguments.
+type ContainerInterface struct {
+	Inputs []ContainerInput `json:"inputs,omitempty"`
+	Args   []ContainerArg   `json:"args,omitempty"`
+}
+
+// ContainerInput is an input of a docker action and where the container sees it.
+type ContainerInput struct {
+	Name    string   `json:"name"`
+	Env     string   `json:"env"`                // INPUT_ variable set by the runner
+	EnvRefs []string `json:"env_refs,omitempty"` // runs.env variables set from the input
+	Args    []int    `json:"args,omitempty"`     // Positions of the runs.args referencing the input
+}
+
+// ContainerArg is one of the runs.args passed to the container, in order.
+type ContainerArg struct {
+	Position int      `json:"position"` // 1-based, as $1 in the entrypoint
+	Value    string   `json:"value"`
+	Inputs   []string `json:"inputs,omitempty"` // Inputs referenced by the argument
+}
+
+// InputEnvName returns the environment variable the runner sets for an input:
+// INPUT_ followed by the name in upper case with spaces replaced by underscores.
+func InputEnvName(input string) string {
+	return "INPUT_" + strings.ReplaceAll(strings.ToUpper(input), " ", "_")
+}
+
+// ContainerInterface returns how a docker action receives its inputs, or nil
+// for other actions and docker actions without inputs or arguments.
+func (a *ActionYML) ContainerInterface() *ContainerInterface {
+	if using, _ := a.Runs["using"].(string); !strings.EqualFold(using, "docker") {
+		return nil
+	}
+
+	container := &ContainerInterface{}
+	rawArgs, _ := a.Runs["args"].([]any)
+	for i, raw := range rawArgs {
+		value := fmt.Sprint(raw)
+		container.Args = append(container.Args, ContainerArg{
+			Position: i + 1,
+			Value:    value,
+			Inputs:   referencedInputs(value),
+		})
+	}
+
+	env, _ := a.Runs["env"].(map[string]any)
+	for _, input := range a.InputList() {
+		mapped := ContainerInput{Name: input.Name, Env: InputEnvName(input.Name)}
+		for _, arg := range container.Args {
+			if slices.Contains(arg.Inputs, input.Name) {
+				mapped.Args = append(mapped.Args, arg.Position)
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(env)) {
+			if slices.Contains(referencedInputs(fmt.Sprint(env[name])), input.Name) {
+				mapped.EnvRefs = append(mapped.EnvRefs, name)
+			}
+		}
+		container.Inputs = append(container.Inputs, mapped)
+	}
+
+	if len(container.Inputs) == 0 && len(container.Args) == 0 {
+		return nil
+	}
+
+	return container
+}
+
+// referencedInputs returns the inputs referenced by the expressions in value, in order of appearance.
+func referencedInputs(value string) []string {
+	var inputs []string
+	for _, expr := range expression.FindAllStringSubmatch(value, -1) {
+		for _, match := range inputReference.FindAllStringSubmatch(expr[1], -1) {
+			name := match[1]
+			if name == "" {
+				name = match[2]
+			}
+			if !slices.Contains(inputs, name) {
+				inputs = append(inputs, name)
+			}
+		}
+	}
+
+	return inputs
+}
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestInputEnvName(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertEqual(t, "INPUT_WHO-TO-GREET", InputEnvName("who-to-greet"))
+	testutil.AssertEqual(t, "INPUT_MY_INPUT", InputEnvName("my input"))
+}
+
+func TestActionYML_ContainerInterface(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{
+		Inputs: map[string]ActionInput{
+			"who-to-greet": {Description: "Who to greet"},
+			"greeting":     {Description: "Greeting"},
+			"verbose":      {Description: "Verbose logs"},
+		},
+		InputOrder: []string{"who-to-greet", "greeting", "verbose"},
+		Runs: map[string]any{
+			"using": "docker",
+			"image": "Dockerfile",
+			"env":   map[string]any{"GREETING": "${{ inputs.greeting }}"},
+			"args": []any{
+				"--name",
+				"${{ inputs.who-to-greet }}",
+				"${{ inputs['greeting'] }} ${{ inputs[\"who-to-greet\"] }}",
+			},
+		},
+	}
+
+	container := action.ContainerInterface()
+	if container == nil {
+		t.Fatal("expected a container interface for a docker action")
+	}
+	testutil.AssertEqual(t, 3, len(container.Args))
+	testutil.AssertEqual(t, "--name", container.Args[0].Value)
+	testutil.AssertEqual(t, 0, len(container.Args[0].Inputs))
+	if !slices.Equal([]string{"greeting", "who-to-greet"}, container.Args[2].Inputs) {
+		t.Errorf("unexpected inputs of the third argument: %v", container.Args[2].Inputs)
+	}
+
+	testutil.AssertEqual(t, 3, len(container.Inputs))
+	who, greeting, verbose := container.Inputs[0], container.Inputs[1], container.Inputs[2]
+	testutil.AssertEqual(t, "INPUT_WHO-TO-GREET", who.Env)
+	if !slices.Equal([]int{2, 3}, who.Args) {
+		t.Errorf("unexpected arguments of who-to-greet: %v", who.Args)
+	}
+	if !slices.Equal([]string{"GREETING"}, greeting.EnvRefs) {
+		t.Errorf("unexpected env references of greeting: %v", greeting.EnvRefs)
+	}
+	testutil.AssertEqual(t, 0, len(verbose.Args))
+	testutil.AssertEqual(t, 0, len(verbose.EnvRefs))
+}
+
+func TestActionYML_ContainerInterfaceNotDocker(t *testing.T) {
+	t.Parallel()
+
+	node := &ActionYML{
+		Inputs: map[string]ActionInput{"token": {Description: "Token"}},
+		Runs:   map[string]any{"using": "node20", "main": "index.js"},
+	}
+	if node.ContainerInterface() != nil {
+		t.Error("expected no container interface for a node action")
+	}
+
+	empty := &ActionYML{Runs: map[string]any{"using": "docker", "image": "Dockerfile"}}
+	if empty.ContainerInterface() != nil {
+		t.Error("expected no container interface for a docker action without inputs or args")
+	}
+}
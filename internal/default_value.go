@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// FormatDefault returns the text of an input default: strings as written,
+// without the trailing newline of YAML block scalars, mappings and sequences
+// as YAML, and other scalars in their usual form.
+func FormatDefault(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimRight(strings.ReplaceAll(v, "\r\n", "\n"), "\n")
+	}
+	if !isComplexDefault(value) {
+		return fmt.Sprintf("%v", value)
+	}
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return strings.TrimRight(string(data), "\n")
+}
+
+// IsBlockDefault reports whether a default spans several lines or is a mapping
+// or sequence, so it cannot be shown inline in a table cell or a sentence.
+func IsBlockDefault(value any) bool {
+	return isComplexDefault(value) || strings.Contains(FormatDefault(value), "\n")
+}
+
+// isComplexDefault reports whether value is a YAML mapping or sequence.
+func isComplexDefault(value any) bool {
+	if value == nil {
+		return false
+	}
+	kind := reflect.TypeOf(value).Kind()
+
+	return kind == reflect.Map || kind == reflect.Slice || kind == reflect.Array
+}
+
+// DefaultLanguage returns the code block language of a block default: yaml for
+// mappings and sequences and text for multi-line strings.
+func DefaultLanguage(value any) string {
+	if isComplexDefault(value) {
+		return "yaml"
+	}
+
+	return "text"
+}
+
+// DefaultCodeBlock returns a default as a fenced Markdown code block. The fence
+// is longer than any run of backticks in the value.
+func DefaultCodeBlock(value any) string {
+	text := FormatDefault(value)
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+
+	return fence + DefaultLanguage(value) + "\n" + text + "\n" + fence
+}
+
+// YAMLBlockScalar returns a default as a YAML literal block scalar for use in
+// workflow examples, its lines indented by indent spaces.
+func YAMLBlockScalar(indent int, value any) string {
+	prefix := strings.Repeat(" ", indent)
+	lines := strings.Split(FormatDefault(value), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+
+	return "|\n" + strings.Join(lines, "\n")
+}
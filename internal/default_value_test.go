@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestFormatDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+		block bool
+	}{
+		{name: "nil", value: nil, want: ""},
+		{name: "string", value: "bash", want: "bash"},
+		{name: "folded scalar", value: "one line\n", want: "one line"},
+		{name: "literal scalar", value: "make\nmake test\n", want: "make\nmake test", block: true},
+		{name: "crlf", value: "a\r\nb", want: "a\nb", block: true},
+		{name: "number", value: uint64(3), want: "3"},
+		{name: "bool", value: true, want: "true"},
+		{name: "sequence", value: []any{"src", "docs"}, want: "- src\n- docs", block: true},
+		{name: "mapping", value: map[string]any{"retries": 3}, want: "retries: 3", block: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			testutil.AssertEqual(t, tt.want, FormatDefault(tt.value))
+			testutil.AssertEqual(t, tt.block, IsBlockDefault(tt.value))
+		})
+	}
+}
+
+func TestDefaultCodeBlock(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertEqual(t, "```text\nmake\nmake test\n```", DefaultCodeBlock("make\nmake test\n"))
+	testutil.AssertEqual(t, "```yaml\n- src\n- docs\n```", DefaultCodeBlock([]any{"src", "docs"}))
+	testutil.AssertEqual(t, "````text\n```\ncode\n```\n````", DefaultCodeBlock("```\ncode\n```"))
+}
+
+func TestYAMLBlockScalar(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertEqual(t, "|\n    make\n\n    make test", YAMLBlockScalar(4, "make\n\nmake test\n"))
+	testutil.AssertEqual(t, "|\n  retries: 3", YAMLBlockScalar(2, map[string]any{"retries": 3}))
+}
+
+func TestRenderReadme_MultilineDefaults(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, ActionFileNameYML)
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/composite/multiline-defaults.yml"))
+	action, err := ParseActionYML(actionPath)
+	testutil.AssertNoError(t, err)
+	config := DefaultAppConfig()
+	config.Organization = "acme"
+	config.Repository = "multiline"
+	data := BuildTemplateData(action, config, "", "")
+
+	for _, theme := range []string{ThemeDefault, ThemeGitHub, ThemeGitLab, ThemeMinimal, ThemeProfessional} {
+		doc, err := RenderReadme(data, TemplateOptions{TemplatePath: resolveThemeTemplate(theme), Format: "md"})
+		testutil.AssertNoError(t, err)
+		testutil.AssertStringContains(t, doc, "```yaml\nretries: 3\nverbose: true\n```")
+		testutil.AssertStringContains(t, doc, "```text\necho \"Building | testing\"\nmake all\n```")
+		for _, inline := range []string{"map[", "[src docs]", "one line\n\"", "one line\n`"} {
+			if strings.Contains(doc, inline) {
+				t.Errorf("theme %s renders a default inline as %q", theme, inline)
+			}
+		}
+		if findings := ValidateExamples(doc, "README.md", data, ""); len(findings) > 0 {
+			t.Errorf("theme %s renders invalid examples: %v", theme, findings)
+		}
+	}
+
+	doc, err := RenderReadme(data, TemplateOptions{
+		TemplatePath: resolveTemplatePath("templates/themes/asciidoc/readme.adoc"),
+		Format:       "asciidoc",
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, doc, "[source,yaml]\n----\nretries: 3\nverbose: true\n----")
+	if findings := ValidateExamples(doc, "README.adoc", data, ""); len(findings) > 0 {
+		t.Errorf("asciidoc theme renders invalid examples: %v", findings)
+	}
+
+	sections, err := RenderManagedSections(data)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, sections[SectionInputs], "| `options` | Tool options | no | _See below_ |")
+	testutil.AssertStringContains(t, sections[SectionInputs], "Default of `options`:\n\n```yaml\nretries: 3\n")
+	testutil.AssertStringContains(t, sections[SectionUsage], "    paths: |\n      - src\n      - docs\n")
+
+	usage := NewJSONWriter(config).generateBasicExample(action)
+	testutil.AssertStringContains(t, usage, "    script: |\n      echo \"Building | testing\"\n      make all\n")
+	testutil.AssertStringContains(t, usage, "    folded: \"one line\"\n")
+}
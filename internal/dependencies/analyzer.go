@@ -2,11 +2,12 @@
 package dependencies
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -28,6 +29,11 @@ const (
 	// LocalPath represents a local file path reference.
 	LocalPath VersionType = "local"
 
+	// PinStrategySHA pins updates to the commit SHA with the version as a comment.
+	PinStrategySHA = "sha"
+	// PinStrategyTag pins updates to the exact version tag.
+	PinStrategyTag = "tag"
+
 	// Common string constants.
 	compositeUsing  = "composite"
 	updateTypeNone  = "none"
@@ -41,17 +47,15 @@ const (
 	cacheDefaultTTL = 1 * time.Hour
 
 	// File permission constants.
-	backupFilePerms  = 0600
-	updatedFilePerms = 0600
+	backupFilePerms = 0600
 
 	// GitHub URL patterns.
 	githubBaseURL      = "https://github.com"
 	marketplaceBaseURL = "https://github.com/marketplace/actions/"
 
 	// Version parsing constants.
-	fullSHALength     = 40
-	minSHALength      = 7
-	versionPartsCount = 3
+	fullSHALength = 40
+	minSHALength  = 7
 
 	// File path patterns.
 	dockerPrefix      = "docker://"
@@ -64,12 +68,14 @@ const (
 	// Cache key prefixes.
 	cacheKeyLatest = "latest:"
 	cacheKeyRepo   = "repo:"
+	cacheKeyPinned = "pinned:"
+	cacheKeyRef    = "ref:"
+	cacheKeyNotes  = "notes:"
+	cacheKeyRisk   = "risk:"
+	cacheKeyAction = "action:"
 
 	// YAML structure constants.
-	usesFieldPrefix = "uses: "
-
-	// Special line estimation for script URLs.
-	scriptLineEstimate = 10
+	usesFieldKey = "uses:"
 )
 
 // Dependency represents a GitHub Action dependency with detailed information.
@@ -86,7 +92,11 @@ type Dependency struct {
 	WithParams     map[string]string `json:"with_params,omitempty"`
 	IsLocalAction  bool              `json:"is_local_action"` // Same repo dependency
 	IsShellScript  bool              `json:"is_shell_script"`
-	ScriptURL      string            `json:"script_url,omitempty"` // Link to script line
+	ScriptURL      string            `json:"script_url,omitempty"`  // Link to script line
+	StepNumber     int               `json:"step_number,omitempty"` // Position in runs.steps, from 1
+	StepName       string            `json:"step_name,omitempty"`   // Name or id of the step
+	Line           int               `json:"line,omitempty"`        // Line of the uses or run statement, from 1
+	Docker         *DockerImage      `json:"docker,omitempty"`      // Image of docker:// dependencies
 }
 
 // OutdatedDependency represents a dependency that has newer versions available.
@@ -97,6 +107,11 @@ type OutdatedDependency struct {
 	UpdateType       string     `json:"update_type"` // "major", "minor", "patch"
 	Changelog        string     `json:"changelog,omitempty"`
 	IsSecurityUpdate bool       `json:"is_security_update"`
+	PinnedAt         time.Time  `json:"pinned_at,omitzero"`        // Release or commit date of the current ref
+	AgeDays          int        `json:"age_days,omitempty"`        // Days since PinnedAt
+	StalenessScore   float64    `json:"staleness_score,omitempty"` // AgeDays relative to the max age
+	IsStale          bool       `json:"is_stale,omitempty"`        // Older than the max age
+	RiskHints        []string   `json:"risk_hints,omitempty"`      // Breaking changes named in the release notes
 }
 
 // PinnedUpdate represents an update that pins to a specific commit SHA.
@@ -108,6 +123,8 @@ type PinnedUpdate struct {
 	Version    string `json:"version"`
 	UpdateType string `json:"update_type"` // "major", "minor", "patch"
 	LineNumber int    `json:"line_number"`
+	// RiskHints are the risk hints of the update, see OutdatedDependency
+	RiskHints []string `json:"risk_hints,omitempty"`
 }
 
 // Analyzer analyzes GitHub Action dependencies.
@@ -115,6 +132,20 @@ type Analyzer struct {
 	GitHubClient *github.Client
 	Cache        DependencyCache // High-performance cache interface
 	RepoInfo     git.RepoInfo
+	// CacheTTL is how long API responses are cached, one hour when zero.
+	CacheTTL time.Duration
+	// PinStrategy is PinStrategySHA (default) or PinStrategyTag.
+	PinStrategy string
+	// Registry looks up tags of docker:// images; without it they are never outdated.
+	Registry ImageRegistry
+	// VersionSchemes classify updates, tried in order; DefaultVersionSchemes when empty.
+	VersionSchemes []VersionScheme
+	// MaxTransitiveDepth bounds the levels of composite actions followed
+	// through ActionDependencies; unbounded when zero.
+	MaxTransitiveDepth int
+	// Context cancels API calls and stops checks and updates between
+	// dependencies and files; context.Background when nil.
+	Context context.Context
 }
 
 // DependencyCache defines the caching interface for dependency data.
@@ -124,6 +155,15 @@ type DependencyCache interface {
 	SetWithTTL(key string, value any, ttl time.Duration) error
 }
 
+// ctx returns the context of the analyzer.
+func (a *Analyzer) ctx() context.Context {
+	if a.Context == nil {
+		return context.Background()
+	}
+
+	return a.Context
+}
+
 // Note: Using git.RepoInfo instead of local GitInfo to avoid duplication
 
 // NewAnalyzer creates a new dependency analyzer.
@@ -170,12 +210,29 @@ func (a *Analyzer) AnalyzeActionFileWithProgress(
 
 // CheckOutdated analyzes dependencies and finds those with newer versions available.
 func (a *Analyzer) CheckOutdated(deps []Dependency) ([]OutdatedDependency, error) {
+	return a.CheckOutdatedWithMaxAge(deps, 0)
+}
+
+// CheckOutdatedWithMaxAge works like CheckOutdated and, when maxAge is positive,
+// also reports pins whose release or commit is older than maxAge, even when no
+// newer version exists.
+func (a *Analyzer) CheckOutdatedWithMaxAge(deps []Dependency, maxAge time.Duration) ([]OutdatedDependency, error) {
 	var outdated []OutdatedDependency
 
 	for _, dep := range deps {
+		if err := a.ctx().Err(); err != nil {
+			return outdated, fmt.Errorf("dependency check stopped: %w", err)
+		}
 		if dep.IsShellScript || dep.IsLocalAction {
 			continue // Skip shell scripts and local actions
 		}
+		if dep.Docker != nil {
+			if result, ok := a.checkDockerOutdated(dep); ok {
+				outdated = append(outdated, result)
+			}
+
+			continue
+		}
 
 		owner, repo, currentVersion, _ := a.parseUsesStatement(dep.Uses)
 		if owner == "" || repo == "" {
@@ -187,15 +244,20 @@ func (a *Analyzer) CheckOutdated(deps []Dependency) ([]OutdatedDependency, error
 			continue // Skip on error, don't fail the whole operation
 		}
 
-		updateType := a.compareVersions(currentVersion, latestVersion)
-		if updateType != updateTypeNone {
-			outdated = append(outdated, OutdatedDependency{
-				Current:          dep,
-				LatestVersion:    latestVersion,
-				LatestSHA:        latestSHA,
-				UpdateType:       updateType,
-				IsSecurityUpdate: updateType == updateTypeMajor, // Assume major updates might be security
-			})
+		updateType := a.classifyUpdate(currentVersion, latestVersion, latestSHA)
+		result := OutdatedDependency{
+			Current:          dep,
+			LatestVersion:    latestVersion,
+			LatestSHA:        latestSHA,
+			UpdateType:       updateType,
+			IsSecurityUpdate: updateType == updateTypeMajor, // Assume major updates might be security
+		}
+		if maxAge > 0 {
+			a.scoreStaleness(&result, owner, repo, currentVersion, maxAge)
+		}
+		result.RiskHints = a.riskHints(dep, currentVersion, latestVersion, updateType)
+		if updateType != updateTypeNone || result.IsStale {
+			outdated = append(outdated, result)
 		}
 	}
 
@@ -208,15 +270,25 @@ func (a *Analyzer) GeneratePinnedUpdate(
 	dep Dependency,
 	latestVersion, latestSHA string,
 ) (*PinnedUpdate, error) {
-	if latestSHA == "" {
-		return nil, fmt.Errorf("no commit SHA available for %s", dep.Uses)
+	if dep.Docker != nil {
+		return a.generateDockerPinnedUpdate(actionPath, dep, latestVersion, latestSHA)
 	}
 
-	// Create the new pinned uses string: "owner/repo@sha # version"
 	owner, repo, currentVersion, _ := a.parseUsesStatement(dep.Uses)
-	newUses := fmt.Sprintf("%s/%s@%s # %s", owner, repo, latestSHA, latestVersion)
 
-	updateType := a.compareVersions(currentVersion, latestVersion)
+	// Create the new pinned uses string: "owner/repo@sha # version", or
+	// "owner/repo@version" with the tag strategy
+	var newUses string
+	switch {
+	case a.PinStrategy == PinStrategyTag:
+		newUses = fmt.Sprintf("%s/%s@%s", owner, repo, latestVersion)
+	case latestSHA == "":
+		return nil, fmt.Errorf("no commit SHA available for %s", dep.Uses)
+	default:
+		newUses = fmt.Sprintf("%s/%s@%s # %s", owner, repo, latestSHA, latestVersion)
+	}
+
+	updateType := a.classifyUpdate(currentVersion, latestVersion, latestSHA)
 
 	return &PinnedUpdate{
 		FilePath:   actionPath,
@@ -225,28 +297,10 @@ func (a *Analyzer) GeneratePinnedUpdate(
 		CommitSHA:  latestSHA,
 		Version:    latestVersion,
 		UpdateType: updateType,
-		LineNumber: 0, // Will be determined during file update
+		LineNumber: dep.Line,
 	}, nil
 }
 
-// ApplyPinnedUpdates applies pinned updates to action files.
-func (a *Analyzer) ApplyPinnedUpdates(updates []PinnedUpdate) error {
-	// Group updates by file path
-	updatesByFile := make(map[string][]PinnedUpdate)
-	for _, update := range updates {
-		updatesByFile[update.FilePath] = append(updatesByFile[update.FilePath], update)
-	}
-
-	// Apply updates to each file
-	for filePath, fileUpdates := range updatesByFile {
-		if err := a.updateActionFile(filePath, fileUpdates); err != nil {
-			return fmt.Errorf("failed to update %s: %w", filePath, err)
-		}
-	}
-
-	return nil
-}
-
 // validateAndCheckComposite validates action type and checks if it's composite.
 func (a *Analyzer) validateAndCheckComposite(
 	action *ActionWithComposite,
@@ -268,7 +322,7 @@ func (a *Analyzer) validateAndCheckComposite(
 
 // validateActionType checks if the action type is valid.
 func (a *Analyzer) validateActionType(usingType string) error {
-	validTypes := []string{"node20", "node16", "node12", "docker", "composite"}
+	validTypes := []string{"node24", "node20", "node16", "node12", "docker", "composite"}
 	for _, validType := range validTypes {
 		if usingType == validType {
 			return nil
@@ -293,6 +347,9 @@ func (a *Analyzer) processCompositeSteps(
 
 		dep := a.processStep(step, i+1)
 		if dep != nil {
+			dep.StepNumber = i + 1
+			dep.StepName = cmp.Or(step.Name, step.ID)
+			dep.Line = step.Line
 			dependencies = append(dependencies, *dep)
 		}
 	}
@@ -306,7 +363,14 @@ func (a *Analyzer) processCompositeSteps(
 
 // processStep processes a single step and returns dependency if found.
 func (a *Analyzer) processStep(step CompositeStep, stepNumber int) *Dependency {
-	if step.Uses != "" {
+	if strings.HasPrefix(step.Uses, dockerPrefix) {
+		dep, err := a.analyzeDockerDependency(step)
+		if err != nil {
+			return nil
+		}
+
+		return dep
+	} else if step.Uses != "" {
 		// This is an action dependency
 		dep, err := a.analyzeActionDependency(step, stepNumber)
 		if err != nil {
@@ -353,8 +417,8 @@ func (a *Analyzer) analyzeActionDependency(step CompositeStep, _ int) (*Dependen
 		dep.MarketplaceURL = marketplaceBaseURL + repo
 	}
 
-	// Fetch additional metadata from GitHub API if available
-	if a.GitHubClient != nil && !isLocal {
+	// Fetch additional metadata from the cache or the GitHub API if available
+	if !isLocal {
 		_ = a.enrichWithGitHubData(dep, owner, repo) // Ignore error - we have basic info
 	}
 
@@ -369,18 +433,19 @@ func (a *Analyzer) analyzeShellScript(step CompositeStep, stepNumber int) *Depen
 		name = fmt.Sprintf("Shell Script #%d", stepNumber)
 	}
 
-	// Try to create a link to the script in the repository
+	// Link to the script's line in the repository
 	scriptURL := ""
 	if a.RepoInfo.Organization != "" && a.RepoInfo.Repository != "" {
-		// This would ideally link to the specific line in the action.yml file
 		scriptURL = fmt.Sprintf(
-			"%s/%s/%s/blob/%s/action.yml#L%d",
+			"%s/%s/%s/blob/%s/action.yml",
 			githubBaseURL,
 			a.RepoInfo.Organization,
 			a.RepoInfo.Repository,
-			a.RepoInfo.DefaultBranch,
-			stepNumber*scriptLineEstimate,
-		) // Rough estimate
+			a.RepoInfo.LinkRef(),
+		)
+		if step.Line > 0 {
+			scriptURL += fmt.Sprintf("#L%d", step.Line)
+		}
 	}
 
 	return &Dependency{
@@ -416,8 +481,9 @@ func (a *Analyzer) parseUsesStatement(uses string) (owner, repo, version string,
 		return "", "", uses, LocalPath
 	}
 
-	// Standard GitHub action format: owner/repo@version
-	re := regexp.MustCompile(`^([^/]+)/([^@]+)@(.+)$`)
+	// Standard GitHub action format: owner/repo@version. Whitespace is never
+	// part of a reference, and would end up in API paths and cache keys.
+	re := regexp.MustCompile(`^([^/\s]+)/([^@\s]+)@(\S+)$`)
 	matches := re.FindStringSubmatch(uses)
 	if len(matches) != 4 {
 		return "", "", "", LocalPath
@@ -483,38 +549,42 @@ func (a *Analyzer) convertWithParams(with map[string]any) map[string]string {
 }
 
 // getLatestVersion fetches the latest release/tag for a repository.
+// A cached version is used even without a GitHub client, so a warmed cache works offline.
 func (a *Analyzer) getLatestVersion(owner, repo string) (version, sha string, err error) {
-	if a.GitHubClient == nil {
-		return "", "", errors.New("GitHub client not available")
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout)
-	defer cancel()
-
 	// Check cache first
 	cacheKey := cacheKeyLatest + fmt.Sprintf("%s/%s", owner, repo)
 	if version, sha, found := a.getCachedVersion(cacheKey); found {
 		return version, sha, nil
 	}
 
-	// Try to get latest release first
-	if version, sha, err := a.getLatestRelease(ctx, owner, repo); err == nil {
-		a.cacheVersion(cacheKey, version, sha)
-
-		return version, sha, nil
-	}
-
-	// Fallback to latest tag
-	version, sha, err = a.getLatestTag(ctx, owner, repo)
+	version, sha, err = a.fetchLatestVersion(owner, repo)
 	if err != nil {
 		return "", "", err
 	}
 
-	a.cacheVersion(cacheKey, version, sha)
+	a.cacheVersion(cacheKey, version, sha, a.cacheTTL())
 
 	return version, sha, nil
 }
 
+// fetchLatestVersion queries GitHub for the latest release, falling back to the latest tag.
+func (a *Analyzer) fetchLatestVersion(owner, repo string) (version, sha string, err error) {
+	if a.GitHubClient == nil {
+		return "", "", errors.New("GitHub client not available")
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx(), apiCallTimeout)
+	defer cancel()
+
+	// Try to get latest release first
+	if version, sha, err := a.getLatestRelease(ctx, owner, repo); err == nil {
+		return version, sha, nil
+	}
+
+	// Fallback to latest tag
+	return a.getLatestTag(ctx, owner, repo)
+}
+
 // getCachedVersion retrieves version info from cache if available.
 func (a *Analyzer) getCachedVersion(cacheKey string) (version, sha string, found bool) {
 	if a.Cache == nil {
@@ -526,12 +596,18 @@ func (a *Analyzer) getCachedVersion(cacheKey string) (version, sha string, found
 		return "", "", false
 	}
 
-	versionInfo, ok := cached.(map[string]string)
-	if !ok {
+	// Entries loaded from the cache file decode as generic JSON objects
+	switch versionInfo := cached.(type) {
+	case map[string]string:
+		return versionInfo["version"], versionInfo["sha"], true
+	case map[string]any:
+		version, _ = versionInfo["version"].(string)
+		sha, _ = versionInfo["sha"].(string)
+
+		return version, sha, version != ""
+	default:
 		return "", "", false
 	}
-
-	return versionInfo["version"], versionInfo["sha"], true
 }
 
 // getLatestRelease fetches the latest release and its commit SHA.
@@ -571,113 +647,75 @@ func (a *Analyzer) getLatestTag(ctx context.Context, owner, repo string) (versio
 	return latestTag.GetName(), latestTag.GetCommit().GetSHA(), nil
 }
 
+// cacheTTL returns how long API responses are cached.
+func (a *Analyzer) cacheTTL() time.Duration {
+	if a.CacheTTL > 0 {
+		return a.CacheTTL
+	}
+
+	return cacheDefaultTTL
+}
+
 // cacheVersion stores version information in cache with TTL.
-func (a *Analyzer) cacheVersion(cacheKey, version, sha string) {
+func (a *Analyzer) cacheVersion(cacheKey, version, sha string, ttl time.Duration) {
 	if a.Cache == nil {
 		return
 	}
 
 	versionInfo := map[string]string{"version": version, "sha": sha}
-	_ = a.Cache.SetWithTTL(cacheKey, versionInfo, cacheDefaultTTL)
+	_ = a.Cache.SetWithTTL(cacheKey, versionInfo, ttl)
 }
 
-// compareVersions compares two version strings and returns the update type.
-func (a *Analyzer) compareVersions(current, latest string) string {
-	currentClean := strings.TrimPrefix(current, "v")
-	latestClean := strings.TrimPrefix(latest, "v")
-
-	if currentClean == latestClean {
-		return updateTypeNone
-	}
-
-	// Special case: floating major version (e.g., "4" -> "4.1.1") should be patch
-	if !strings.Contains(currentClean, ".") && strings.HasPrefix(latestClean, currentClean+".") {
-		return updateTypePatch
-	}
-
-	currentParts := a.parseVersionParts(currentClean)
-	latestParts := a.parseVersionParts(latestClean)
-
-	return a.determineUpdateType(currentParts, latestParts)
+// UpdateType returns the type of the update from the current to the latest
+// version: "major", "minor", "patch", "prerelease", "unknown" or "none".
+func (a *Analyzer) UpdateType(current, latest string) string {
+	return a.compareVersions(current, latest)
 }
 
-// parseVersionParts normalizes version string to 3-part semantic version.
-func (a *Analyzer) parseVersionParts(version string) []string {
-	parts := strings.Split(version, ".")
-	// For floating versions like "v4", treat as "v4.0.0" for comparison
-	for len(parts) < versionPartsCount {
-		parts = append(parts, "0")
+// classifyUpdate works like compareVersions, and reports no update for a
+// current commit SHA that is the commit of the latest version, latestSHA.
+func (a *Analyzer) classifyUpdate(current, latestVersion, latestSHA string) string {
+	if latestSHA != "" && a.isCommitSHA(current) && strings.HasPrefix(strings.ToLower(latestSHA), current) {
+		return updateTypeNone
 	}
 
-	return parts
+	return a.compareVersions(current, latestVersion)
 }
 
-// determineUpdateType compares version parts and returns update type.
-func (a *Analyzer) determineUpdateType(currentParts, latestParts []string) string {
-	if currentParts[0] != latestParts[0] {
+// compareVersions classifies the update from current to latest with the
+// first version scheme both tags follow. A commit SHA carries no version, so
+// moving from one to a release counts as a major update.
+func (a *Analyzer) compareVersions(current, latest string) string {
+	if a.isCommitSHA(current) && current != latest {
 		return updateTypeMajor
 	}
-	if currentParts[1] != latestParts[1] {
-		return updateTypeMinor
-	}
-	if currentParts[2] != latestParts[2] {
-		return updateTypePatch
+	schemes := a.VersionSchemes
+	if len(schemes) == 0 {
+		schemes = DefaultVersionSchemes
 	}
-
-	return updateTypeNone
-}
-
-// updateActionFile applies updates to a single action file.
-func (a *Analyzer) updateActionFile(filePath string, updates []PinnedUpdate) error {
-	// Read the file
-	content, err := os.ReadFile(filePath) // #nosec G304 -- file path from function parameter
-	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
-	}
-
-	// Create backup
-	backupPath := filePath + backupExtension
-	if err := os.WriteFile(backupPath, content, backupFilePerms); err != nil { // #nosec G306 -- backup file permissions
-		return fmt.Errorf("failed to create backup: %w", err)
-	}
-
-	// Apply updates to content
-	lines := strings.Split(string(content), "\n")
-	for _, update := range updates {
-		// Find and replace the uses line
-		for i, line := range lines {
-			if strings.Contains(line, update.OldUses) {
-				// Replace the uses statement while preserving indentation
-				indent := strings.Repeat(" ", len(line)-len(strings.TrimLeft(line, " ")))
-				lines[i] = indent + usesFieldPrefix + update.NewUses
-				update.LineNumber = i + 1 // Store line number for reference
-
-				break
-			}
+	for _, scheme := range schemes {
+		if updateType, ok := scheme.Classify(current, latest); ok {
+			return updateType
 		}
 	}
 
-	// Write updated content
-	updatedContent := strings.Join(lines, "\n")
-	if err := os.WriteFile(filePath, []byte(updatedContent), updatedFilePerms); err != nil {
-		// #nosec G306 -- updated file permissions
-		return fmt.Errorf("failed to write updated file: %w", err)
-	}
+	updateType, _ := OpaqueVersion{}.Classify(current, latest)
 
-	// Validate the updated file by trying to parse it
-	if err := a.validateActionFile(filePath); err != nil {
-		// Rollback on validation failure
-		if rollbackErr := os.Rename(backupPath, filePath); rollbackErr != nil {
-			return fmt.Errorf("validation failed and rollback failed: %w (original error: %w)", rollbackErr, err)
-		}
+	return updateType
+}
 
-		return fmt.Errorf("validation failed, rolled back changes: %w", err)
+// usesLineIndex returns the index of the line holding the uses statement of
+// update: its LineNumber when that line still holds it, else the first such
+// line. It returns -1 when no line does.
+func usesLineIndex(lines []string, update PinnedUpdate) int {
+	holdsUses := func(line string) bool {
+		return strings.Contains(line, usesFieldKey) && strings.Contains(line, update.OldUses)
+	}
+	if update.LineNumber > 0 && update.LineNumber <= len(lines) && holdsUses(lines[update.LineNumber-1]) {
+		return update.LineNumber - 1
 	}
 
-	// Remove backup on success
-	_ = os.Remove(backupPath)
-
-	return nil
+	return slices.IndexFunc(lines, holdsUses)
 }
 
 // validateActionFile validates that an action.yml file is still valid after updates.
@@ -689,30 +727,39 @@ func (a *Analyzer) validateActionFile(filePath string) error {
 
 // enrichWithGitHubData fetches additional information from GitHub API.
 func (a *Analyzer) enrichWithGitHubData(dep *Dependency, owner, repo string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout)
+	ctx, cancel := context.WithTimeout(a.ctx(), apiCallTimeout)
 	defer cancel()
 
 	// Check cache first
 	cacheKey := cacheKeyRepo + fmt.Sprintf("%s/%s", owner, repo)
 	if a.Cache != nil {
 		if cached, exists := a.Cache.Get(cacheKey); exists {
-			if repository, ok := cached.(*github.Repository); ok {
+			switch repository := cached.(type) {
+			case *github.Repository:
 				dep.Description = repository.GetDescription()
 
+				return nil
+			case map[string]any: // Loaded from the cache file
+				dep.Description, _ = repository["description"].(string)
+
 				return nil
 			}
 		}
 	}
 
+	if a.GitHubClient == nil {
+		return errors.New("GitHub client not available")
+	}
+
 	// Fetch from API
 	repository, _, err := a.GitHubClient.Repositories.Get(ctx, owner, repo)
 	if err != nil {
 		return fmt.Errorf("failed to fetch repository info: %w", err)
 	}
 
-	// Cache the result with 1 hour TTL
+	// Cache the result
 	if a.Cache != nil {
-		_ = a.Cache.SetWithTTL(cacheKey, repository, cacheDefaultTTL) // Ignore cache errors
+		_ = a.Cache.SetWithTTL(cacheKey, repository, a.cacheTTL()) // Ignore cache errors
 	}
 
 	// Enrich dependency with API data
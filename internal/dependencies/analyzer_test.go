@@ -1,7 +1,10 @@
 package dependencies
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -41,6 +44,13 @@ func TestAnalyzer_AnalyzeActionFile(t *testing.T) {
 			expectedLen:  5, // 3 action dependencies + 2 shell script dependencies
 			expectedDeps: []string{"actions/checkout@v4", "actions/setup-node@v4", "actions/setup-python@v4"},
 		},
+		{
+			name:         "composite action with full schema step fields",
+			actionYML:    testutil.MustReadFixture("actions/composite/full-schema.yml"),
+			expectDeps:   true,
+			expectedLen:  3, // 1 action dependency + 2 shell script dependencies
+			expectedDeps: []string{"actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab"},
+		},
 		{
 			name:        "docker action - no step dependencies",
 			actionYML:   testutil.MustReadFixture("actions/docker/basic.yml"),
@@ -163,6 +173,11 @@ func TestAnalyzer_ParseUsesStatement(t *testing.T) {
 			expectedVersion: "main",
 			expectedType:    BranchName,
 		},
+		{
+			name:         "whitespace in reference",
+			uses:         "actions/checkout@v4 # pinned",
+			expectedType: LocalPath,
+		},
 	}
 
 	analyzer := &Analyzer{}
@@ -369,6 +384,51 @@ func TestAnalyzer_CheckOutdated(t *testing.T) {
 	}
 }
 
+func TestAnalyzer_CheckOutdated_PinnedToLatestSHA(t *testing.T) {
+	t.Parallel()
+
+	analyzer := &Analyzer{GitHubClient: testutil.MockGitHubClient(testutil.MockGitHubResponses())}
+	latestSHA := "8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e" // Commit of v4.1.1, the latest release
+
+	for _, sha := range []string{latestSHA, latestSHA[:7]} {
+		outdated, err := analyzer.CheckOutdated([]Dependency{
+			{Name: "actions/checkout", Uses: "actions/checkout@" + sha, Version: sha, IsPinned: true},
+		})
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, 0, len(outdated))
+	}
+
+	// Other commits still need updating
+	outdated, err := analyzer.CheckOutdated([]Dependency{
+		{Name: "actions/checkout", Uses: "actions/checkout@" + strings.Repeat("a", 40), IsPinned: true},
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(outdated))
+	testutil.AssertEqual(t, "major", outdated[0].UpdateType)
+}
+
+func TestAnalyzer_CheckOutdated_GitHubFailures(t *testing.T) {
+	t.Parallel()
+
+	server := testutil.NewGitHubServer(t, testutil.MockGitHubResponses())
+	analyzer := &Analyzer{GitHubClient: server.Client()}
+	dependencies := []Dependency{{Name: "actions/checkout", Uses: "actions/checkout@v3", Version: "v3"}}
+
+	// A failing release lookup falls back to the tags
+	server.Fail(http.MethodGet, "/repos/actions/checkout/releases/latest", http.StatusBadGateway, 1)
+	outdated, err := analyzer.CheckOutdated(dependencies)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(outdated))
+	testutil.AssertEqual(t, "v4.1.1", outdated[0].LatestVersion)
+	testutil.AssertEqual(t, "GET /repos/actions/checkout/tags?per_page=10", server.Requests()[1])
+
+	// An exhausted rate limit skips the lookups instead of failing
+	server.SetRateLimitRemaining(0)
+	outdated, err = analyzer.CheckOutdated(dependencies)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(outdated))
+}
+
 func TestAnalyzer_CompareVersions(t *testing.T) {
 	t.Parallel()
 
@@ -410,6 +470,25 @@ func TestAnalyzer_CompareVersions(t *testing.T) {
 			latest:       "v4.1.1",
 			expectedType: "patch",
 		},
+		{name: "release candidate to release", current: "v2.0.0-rc.1", latest: "v2.0.0", expectedType: "prerelease"},
+		{name: "between release candidates", current: "v2.0.0-rc.1", latest: "v2.0.0-rc.2", expectedType: "prerelease"},
+		{name: "release to next release candidate", current: "v1.9.0", latest: "v2.0.0-rc.1", expectedType: "major"},
+		{name: "release candidate is older", current: "v2.0.0", latest: "v2.0.0-rc.1", expectedType: "none"},
+		{name: "older latest release", current: "v4.1.1", latest: "v4.0.0", expectedType: "none"},
+		{name: "build metadata is ignored", current: "v1.2.3+build.1", latest: "v1.2.3+build.2", expectedType: "none"},
+		{name: "numeric parts beyond patch", current: "v4.10.0", latest: "v4.9.0", expectedType: "none"},
+		{name: "calendar month", current: "v2023.10.1", latest: "v2023.11.0", expectedType: "minor"},
+		{name: "calendar year", current: "v2023.10.1", latest: "v2024.01.0", expectedType: "major"},
+		{name: "calendar micro", current: "2024.01", latest: "2024.01.2", expectedType: "patch"},
+		{name: "four numeric parts", current: "v1.2.3.4", latest: "v1.2.3.5", expectedType: "patch"},
+		{name: "opaque tags", current: "stable", latest: "edge", expectedType: "unknown"},
+		{name: "same opaque tag", current: "stable", latest: "stable", expectedType: "none"},
+		{
+			name:         "commit SHA to release",
+			current:      "8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e",
+			latest:       "v4.1.1",
+			expectedType: "major",
+		},
 	}
 
 	for _, tt := range tests {
@@ -422,6 +501,32 @@ func TestAnalyzer_CompareVersions(t *testing.T) {
 	}
 }
 
+// majorOnlyScheme reports every change of a "release-N" tag as a major update.
+type majorOnlyScheme struct{}
+
+func (majorOnlyScheme) Name() string { return "release" }
+
+func (majorOnlyScheme) Classify(current, latest string) (string, bool) {
+	if !strings.HasPrefix(current, "release-") || !strings.HasPrefix(latest, "release-") {
+		return "", false
+	}
+	if current == latest {
+		return updateTypeNone, true
+	}
+
+	return updateTypeMajor, true
+}
+
+func TestAnalyzer_CompareVersionsCustomSchemes(t *testing.T) {
+	t.Parallel()
+
+	analyzer := &Analyzer{VersionSchemes: []VersionScheme{majorOnlyScheme{}, SemVer{}}}
+	testutil.AssertEqual(t, "major", analyzer.compareVersions("release-1", "release-2"))
+	testutil.AssertEqual(t, "minor", analyzer.compareVersions("v1.0.0", "v1.1.0"))
+	// Tags no scheme follows are compared as opaque tags
+	testutil.AssertEqual(t, "unknown", analyzer.compareVersions("v2023.10", "edge"))
+}
+
 func TestAnalyzer_GeneratePinnedUpdate(t *testing.T) {
 	t.Parallel()
 
@@ -472,6 +577,123 @@ func TestAnalyzer_GeneratePinnedUpdate(t *testing.T) {
 	testutil.AssertEqual(t, "major", update.UpdateType)
 }
 
+func TestAnalyzer_ApplyPinnedUpdatesPreservesAnchors(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/composite/anchors.yml"))
+
+	newUses := "actions/checkout@8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e"
+	analyzer := &Analyzer{}
+	err := analyzer.ApplyPinnedUpdates([]PinnedUpdate{
+		{FilePath: actionPath, OldUses: "actions/checkout@v4", NewUses: newUses},
+	})
+	testutil.AssertNoError(t, err)
+
+	content, err := os.ReadFile(actionPath) // #nosec G304 -- test file path
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(content), "uses: &checkout-ref "+newUses+"  # shared checkout")
+	testutil.AssertStringContains(t, string(content), "    - *checkout\n")
+
+	action, err := analyzer.parseCompositeAction(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, newUses, action.Runs.Steps[3].Uses)
+}
+
+func TestAnalyzer_StepLines(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/composite/anchors.yml"))
+
+	analyzer := &Analyzer{}
+	action, err := analyzer.parseCompositeAction(actionPath)
+	testutil.AssertNoError(t, err)
+
+	var lines []string
+	for _, step := range action.Runs.Steps {
+		lines = append(lines, strconv.Itoa(step.Line))
+	}
+	testutil.AssertEqual(t, "25,30,32,33", strings.Join(lines, ","))
+
+	deps, err := analyzer.AnalyzeActionFile(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 25, deps[0].Line)
+
+	update, err := analyzer.GeneratePinnedUpdate(actionPath, deps[0], "v4.1.1", "8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 25, update.LineNumber)
+}
+
+func TestAnalyzer_ApplyPinnedUpdatesAtLine(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, `name: Twice
+description: Checks out twice
+runs:
+  using: composite
+  steps:
+    - uses: actions/checkout@v4
+    - uses: actions/checkout@v4
+      with:
+        path: second
+`)
+
+	newUses := "actions/checkout@8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e # v4.1.1"
+	err := (&Analyzer{}).ApplyPinnedUpdates([]PinnedUpdate{
+		{FilePath: actionPath, OldUses: "actions/checkout@v4", NewUses: newUses, LineNumber: 7},
+	})
+	testutil.AssertNoError(t, err)
+
+	content, err := os.ReadFile(actionPath) // #nosec G304 -- test file path
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(content), "    - uses: actions/checkout@v4\n    - uses: "+newUses+"\n")
+}
+
+func TestAnalyzer_ApplyPinnedUpdatesCanceled(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	content := testutil.MustReadFixture("actions/composite/anchors.yml")
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, content)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	analyzer := &Analyzer{Context: ctx}
+	err := analyzer.ApplyPinnedUpdates([]PinnedUpdate{
+		{FilePath: actionPath, OldUses: "actions/checkout@v4", NewUses: "actions/checkout@v5"},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	// Nothing is written once canceled, and no backup is left behind
+	updated, err := os.ReadFile(actionPath) // #nosec G304 -- test file path
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, content, string(updated))
+	if _, err := os.Stat(actionPath + backupExtension); !os.IsNotExist(err) {
+		t.Errorf("expected no backup file, got %v", err)
+	}
+
+	_, err = analyzer.CheckOutdated([]Dependency{{Uses: "actions/checkout@v4"}})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected CheckOutdated to stop with context.Canceled, got %v", err)
+	}
+}
+
 func TestAnalyzer_WithCache(t *testing.T) {
 	t.Parallel()
 
@@ -498,6 +720,39 @@ func TestAnalyzer_WithCache(t *testing.T) {
 	testutil.AssertEqual(t, sha1, sha2)
 }
 
+func TestAnalyzer_CacheTTL(t *testing.T) {
+	t.Parallel()
+
+	githubClient := testutil.MockGitHubClient(testutil.MockGitHubResponses())
+	depCache := newJSONCache()
+	analyzer := &Analyzer{GitHubClient: githubClient, Cache: depCache}
+
+	_, _, err := analyzer.getLatestVersion("actions", "checkout")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, cacheDefaultTTL, depCache.ttls[cacheKeyLatest+"actions/checkout"])
+
+	analyzer.CacheTTL = 24 * time.Hour
+	_, _, err = analyzer.getLatestVersion("actions", "setup-node")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 24*time.Hour, depCache.ttls[cacheKeyLatest+"actions/setup-node"])
+}
+
+func TestAnalyzer_GeneratePinnedUpdateTagStrategy(t *testing.T) {
+	t.Parallel()
+
+	analyzer := &Analyzer{PinStrategy: PinStrategyTag}
+	dep := Dependency{Name: "actions/checkout", Uses: "actions/checkout@v3", Version: "v3"}
+
+	// The tag strategy does not need a commit SHA
+	update, err := analyzer.GeneratePinnedUpdate("action.yml", dep, "v4.1.1", "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "actions/checkout@v4.1.1", update.NewUses)
+
+	analyzer.PinStrategy = PinStrategySHA
+	_, err = analyzer.GeneratePinnedUpdate("action.yml", dep, "v4.1.1", "")
+	testutil.AssertError(t, err)
+}
+
 func TestAnalyzer_RateLimitHandling(t *testing.T) {
 	t.Parallel()
 
@@ -518,11 +773,11 @@ func TestAnalyzer_RateLimitHandling(t *testing.T) {
 	}
 
 	client := github.NewClient(&http.Client{Transport: &mockTransport{client: mockClient}})
-	cacheInstance, _ := cache.NewCache(cache.DefaultConfig())
 
+	// No cache: a version cached on disk by another test would skip the API call
 	analyzer := &Analyzer{
 		GitHubClient: client,
-		Cache:        cacheInstance,
+		Cache:        NewNoOpCache(),
 	}
 
 	// This should handle the rate limit gracefully
@@ -0,0 +1,154 @@
+package dependencies
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// stagedFile is an action file whose updated content waits in a temporary
+// file next to it until every file of the update has been validated.
+type stagedFile struct {
+	path     string // Action file, with symlinks resolved
+	staged   string // Temporary file holding the updated content
+	original []byte // Content before the update, backed up while committing
+}
+
+// ApplyPinnedUpdates applies pinned updates to action files, all or nothing:
+// every file is rewritten to a temporary file and validated first, and only
+// when all of them are valid are they renamed over the originals. A failure
+// leaves every file as it was.
+func (a *Analyzer) ApplyPinnedUpdates(updates []PinnedUpdate) error {
+	// Group updates by file path
+	updatesByFile := make(map[string][]PinnedUpdate)
+	for _, update := range updates {
+		updatesByFile[update.FilePath] = append(updatesByFile[update.FilePath], update)
+	}
+
+	files := make([]stagedFile, 0, len(updatesByFile))
+	defer func() {
+		for _, file := range files {
+			_ = os.Remove(file.staged) // No-op once committed
+		}
+	}()
+	for _, filePath := range slices.Sorted(maps.Keys(updatesByFile)) {
+		if err := a.ctx().Err(); err != nil {
+			return fmt.Errorf("stopped before updating %s, no files were changed: %w", filePath, err)
+		}
+		file, err := a.stageUpdates(filePath, updatesByFile[filePath])
+		if err != nil {
+			return fmt.Errorf("failed to update %s, no files were changed: %w", filePath, err)
+		}
+		files = append(files, file)
+	}
+
+	// Once committing starts it is finished, also when canceled
+	return commitStaged(files)
+}
+
+// stageUpdates writes the content of filePath with updates applied to a
+// temporary file next to it and validates it.
+func (a *Analyzer) stageUpdates(filePath string, updates []PinnedUpdate) (stagedFile, error) {
+	if resolved, err := filepath.EvalSymlinks(filePath); err == nil {
+		filePath = resolved // Update the target of a symlinked action file, not the link
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return stagedFile{}, fmt.Errorf("failed to read file: %w", err)
+	}
+	content, err := os.ReadFile(filePath) // #nosec G304 -- file path from function parameter
+	if err != nil {
+		return stagedFile{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Apply updates to content
+	lines := strings.Split(string(content), "\n")
+	for _, update := range updates {
+		// Replace only the reference so list markers, quotes, anchors and comments survive;
+		// steps that reuse this one through an alias pick up the new reference as well.
+		if i := usesLineIndex(lines, update); i >= 0 {
+			lines[i] = strings.Replace(lines[i], update.OldUses, update.NewUses, 1)
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return stagedFile{}, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	file := stagedFile{path: filePath, staged: tmp.Name(), original: content}
+	_, err = tmp.WriteString(strings.Join(lines, "\n"))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(file.staged, info.Mode().Perm()) // Keep the permissions of the original
+	}
+	if err != nil {
+		_ = os.Remove(file.staged)
+
+		return stagedFile{}, fmt.Errorf("failed to write updated file: %w", err)
+	}
+
+	// Validate the updated file by trying to parse it
+	if err := a.validateActionFile(file.staged); err != nil {
+		_ = os.Remove(file.staged)
+
+		return stagedFile{}, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return file, nil
+}
+
+// commitStaged backs up every action file and renames the staged files over
+// them. When a rename fails, the files already replaced are restored from
+// their backups. The backups are removed once the files are updated or
+// restored.
+func commitStaged(files []stagedFile) error {
+	for i, file := range files {
+		// #nosec G306 -- backup file permissions
+		if err := os.WriteFile(file.path+backupExtension, file.original, backupFilePerms); err != nil {
+			removeBackups(files[:i])
+
+			return fmt.Errorf("failed to create backup of %s, no files were changed: %w", file.path, err)
+		}
+	}
+
+	for i, file := range files {
+		if err := os.Rename(file.staged, file.path); err != nil {
+			removeBackups(files[i:])
+			if rollbackErr := restoreBackups(files[:i]); rollbackErr != nil {
+				return fmt.Errorf("failed to replace %s and rollback failed: %w (original error: %w)",
+					file.path, rollbackErr, err)
+			}
+
+			return fmt.Errorf("failed to replace %s, rolled back changes: %w", file.path, err)
+		}
+	}
+	removeBackups(files)
+
+	return nil
+}
+
+// restoreBackups renames the backups of files over them. Backups that cannot
+// be restored are kept and reported.
+func restoreBackups(files []stagedFile) error {
+	var errs []error
+	for _, file := range files {
+		if err := os.Rename(file.path+backupExtension, file.path); err != nil {
+			errs = append(errs, fmt.Errorf("restore %s from %s: %w", file.path, file.path+backupExtension, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// removeBackups removes the backups of files.
+func removeBackups(files []stagedFile) {
+	for _, file := range files {
+		_ = os.Remove(file.path + backupExtension)
+	}
+}
@@ -0,0 +1,106 @@
+package dependencies
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// checkoutAction is a composite action using actions/checkout@v4.
+const checkoutAction = `name: Checkout
+description: Checks out the repository
+runs:
+  using: composite
+  steps:
+    - uses: actions/checkout@v4
+`
+
+// dirEntries returns the names of the entries of dir, sorted and separated by commas.
+func dirEntries(t *testing.T, dir string) string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	testutil.AssertNoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	return strings.Join(names, ",")
+}
+
+func TestAnalyzer_ApplyPinnedUpdatesAllOrNothing(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	paths := make([]string, 0, 3)
+	for _, name := range []string{"a.yml", "b.yml", "c.yml"} {
+		path := filepath.Join(tmpDir, name)
+		testutil.WriteTestFile(t, path, checkoutAction)
+		testutil.AssertNoError(t, os.Chmod(path, 0644)) // #nosec G302 -- test file permissions
+		paths = append(paths, path)
+	}
+	updates := func(broken string) []PinnedUpdate {
+		updates := make([]PinnedUpdate, 0, len(paths))
+		for _, path := range paths {
+			newUses := "actions/checkout@v5"
+			if path == broken {
+				newUses = "[actions/checkout@v5" // Unclosed flow sequence
+			}
+			updates = append(updates, PinnedUpdate{FilePath: path, OldUses: "actions/checkout@v4", NewUses: newUses})
+		}
+
+		return updates
+	}
+
+	// A file failing validation leaves every file, also those before it, unchanged
+	err := (&Analyzer{}).ApplyPinnedUpdates(updates(paths[1]))
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "no files were changed")
+	for _, path := range paths {
+		content, err := os.ReadFile(path) // #nosec G304 -- test file path
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, checkoutAction, string(content))
+	}
+	testutil.AssertEqual(t, "a.yml,b.yml,c.yml", dirEntries(t, tmpDir))
+
+	testutil.AssertNoError(t, (&Analyzer{}).ApplyPinnedUpdates(updates("")))
+	for _, path := range paths {
+		content, err := os.ReadFile(path) // #nosec G304 -- test file path
+		testutil.AssertNoError(t, err)
+		testutil.AssertStringContains(t, string(content), "- uses: actions/checkout@v5\n")
+		info, err := os.Stat(path)
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, os.FileMode(0644), info.Mode().Perm())
+	}
+	testutil.AssertEqual(t, "a.yml,b.yml,c.yml", dirEntries(t, tmpDir))
+}
+
+func TestCommitStaged_RollsBack(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	files := make([]stagedFile, 0, 2)
+	for _, name := range []string{"a.yml", "b.yml"} {
+		path := filepath.Join(tmpDir, name)
+		testutil.WriteTestFile(t, path, "original\n")
+		files = append(files, stagedFile{path: path, staged: path + ".tmp", original: []byte("original\n")})
+	}
+	testutil.WriteTestFile(t, files[0].staged, "updated\n") // The staged file of b.yml is missing
+
+	err := commitStaged(files)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "rolled back changes")
+	for _, file := range files {
+		content, err := os.ReadFile(file.path) // #nosec G304 -- test file path
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, "original\n", string(content))
+	}
+	testutil.AssertEqual(t, "a.yml,b.yml", dirEntries(t, tmpDir))
+}
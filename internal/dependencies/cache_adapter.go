@@ -31,6 +31,11 @@ func (ca *CacheAdapter) SetWithTTL(key string, value any, ttl time.Duration) err
 	return ca.cache.SetWithTTL(key, value, ttl)
 }
 
+// Close writes pending cache entries to disk and stops the cache.
+func (ca *CacheAdapter) Close() error {
+	return ca.cache.Close()
+}
+
 // NoOpCache implements DependencyCache with no-op operations for when caching is disabled.
 type NoOpCache struct{}
 
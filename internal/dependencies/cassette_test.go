@@ -0,0 +1,54 @@
+package dependencies
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// TestDepsUpgrade_Cassette runs the deps outdated and deps upgrade flow
+// against recorded GitHub API responses.
+func TestDepsUpgrade_Cassette(t *testing.T) {
+	t.Parallel()
+
+	actionPath := filepath.Join(t.TempDir(), "action.yml")
+	testutil.WriteTestFile(t, actionPath, `name: Build
+description: Builds the project
+runs:
+  using: composite
+  steps:
+    - uses: actions/checkout@v3
+    - uses: actions/setup-node@v4.4.0
+      with:
+        node-version: 20
+`)
+	analyzer := &Analyzer{GitHubClient: testutil.NewCassetteClient(t, "deps-upgrade")}
+
+	deps, err := analyzer.AnalyzeActionFile(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(deps))
+	testutil.AssertEqual(t, "Action for checking out a repo", deps[0].Description)
+
+	outdated, err := analyzer.CheckOutdated(deps)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(outdated))
+	testutil.AssertEqual(t, "actions/checkout@v3", outdated[0].Current.Uses)
+	testutil.AssertEqual(t, "v4.2.2", outdated[0].LatestVersion)
+	testutil.AssertEqual(t, updateTypeMajor, outdated[0].UpdateType)
+	testutil.AssertStringContains(t, strings.Join(outdated[0].RiskHints, "\n"), "node20")
+
+	update, err := analyzer.GeneratePinnedUpdate(
+		actionPath, outdated[0].Current, outdated[0].LatestVersion, outdated[0].LatestSHA,
+	)
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, analyzer.ApplyPinnedUpdates([]PinnedUpdate{*update}))
+
+	content, err := os.ReadFile(actionPath) // #nosec G304 -- test file path
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(content),
+		"- uses: actions/checkout@11bd71901bbe5b1630ceea73d27597364c9af683 # v4.2.2")
+	testutil.AssertStringContains(t, string(content), "- uses: actions/setup-node@v4.4.0")
+}
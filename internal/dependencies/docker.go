@@ -0,0 +1,279 @@
+package dependencies
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DockerVersion is the version type of docker:// image references.
+const DockerVersion VersionType = "docker"
+
+// dockerLatestTag is the mutable tag images default to.
+const dockerLatestTag = "latest"
+
+// dockerTagVersion splits image tags such as 3.19, v1.2.3 or 18-alpine into
+// their numeric version and variant suffix.
+var dockerTagVersion = regexp.MustCompile(`^(v?)(\d+(?:\.\d+)*)(-[A-Za-z0-9._-]+)?$`)
+
+// DockerImage is the image of a docker:// dependency.
+type DockerImage struct {
+	Registry   string `json:"registry"`         // Registry host, docker.io for Docker Hub
+	Repository string `json:"repository"`       // Repository, library/alpine for official images
+	Tag        string `json:"tag,omitempty"`    // Tag, latest when neither tag nor digest is given
+	Digest     string `json:"digest,omitempty"` // Content digest such as sha256:…, when pinned
+}
+
+// ParseDockerImage parses the image of a docker:// uses reference, applying
+// Docker's defaults: Docker Hub, the library namespace and the latest tag.
+func ParseDockerImage(uses string) (DockerImage, error) {
+	ref := strings.TrimPrefix(uses, dockerPrefix)
+	if ref == "" || strings.ContainsAny(ref, " \t") {
+		return DockerImage{}, fmt.Errorf("invalid docker image: %s", uses)
+	}
+
+	var image DockerImage
+	ref, image.Digest, _ = strings.Cut(ref, "@")
+	if slash := strings.LastIndex(ref, "/"); strings.LastIndex(ref, ":") > slash {
+		colon := strings.LastIndex(ref, ":")
+		ref, image.Tag = ref[:colon], ref[colon+1:]
+	}
+
+	// The first component is a registry host when it has a dot or port, or is localhost
+	first, rest, found := strings.Cut(ref, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		image.Registry, image.Repository = first, rest
+	} else {
+		image.Registry, image.Repository = dockerHubRegistry, ref
+	}
+	if image.Registry == dockerHubRegistry && !strings.Contains(image.Repository, "/") {
+		image.Repository = "library/" + image.Repository
+	}
+	if image.Tag == "" && image.Digest == "" {
+		image.Tag = dockerLatestTag
+	}
+	if image.Repository == "" {
+		return DockerImage{}, fmt.Errorf("invalid docker image: %s", uses)
+	}
+
+	return image, nil
+}
+
+// String returns the full reference of the image.
+func (i DockerImage) String() string {
+	ref := i.Registry + "/" + i.Repository
+	if i.Tag != "" {
+		ref += ":" + i.Tag
+	}
+	if i.Digest != "" {
+		ref += "@" + i.Digest
+	}
+
+	return ref
+}
+
+// IsLatest reports whether the image uses the mutable latest tag without a digest.
+func (i DockerImage) IsLatest() bool {
+	return i.Tag == dockerLatestTag && i.Digest == ""
+}
+
+// Version returns the tag, or the digest of images referenced by digest only.
+func (i DockerImage) Version() string {
+	return cmp.Or(i.Tag, i.Digest)
+}
+
+// analyzeDockerDependency analyzes a docker:// step. Images are pinned when
+// referenced by digest; the latest tag is called out in the description.
+func (a *Analyzer) analyzeDockerDependency(step CompositeStep) (*Dependency, error) {
+	image, err := ParseDockerImage(step.Uses)
+	if err != nil {
+		return nil, err
+	}
+
+	description := "Docker image " + image.String()
+	if image.IsLatest() {
+		description += " (mutable :latest tag)"
+	}
+	namespace, _, _ := strings.Cut(image.Repository, "/")
+
+	return &Dependency{
+		Name:        dockerPrefix + image.Registry + "/" + image.Repository,
+		Uses:        step.Uses,
+		Version:     image.Version(),
+		VersionType: DockerVersion,
+		IsPinned:    image.Digest != "",
+		Description: description,
+		Author:      namespace,
+		SourceURL:   dockerSourceURL(image),
+		WithParams:  a.convertWithParams(step.With),
+		Docker:      &image,
+	}, nil
+}
+
+// dockerSourceURL links to the page of an image on Docker Hub or GHCR, or to
+// the registry of other images.
+func dockerSourceURL(image DockerImage) string {
+	switch image.Registry {
+	case dockerHubRegistry:
+		if name, ok := strings.CutPrefix(image.Repository, "library/"); ok {
+			return "https://hub.docker.com/_/" + name
+		}
+
+		return "https://hub.docker.com/r/" + image.Repository
+	case "ghcr.io":
+		return "https://github.com/" + strings.SplitN(image.Repository, "/", 2)[0] + "/packages"
+	default:
+		return "https://" + image.Registry + "/" + image.Repository
+	}
+}
+
+// checkDockerOutdated reports a newer tag of a docker:// dependency: the
+// highest version tag with the same number of version parts and variant
+// suffix, such as 3.20 for 3.19 or 20-alpine for 18-alpine. Tags that are not
+// versions, such as latest, have no updates.
+func (a *Analyzer) checkDockerOutdated(dep Dependency) (OutdatedDependency, bool) {
+	image := *dep.Docker
+	if a.Registry == nil || image.Tag == "" || !dockerTagVersion.MatchString(image.Tag) {
+		return OutdatedDependency{}, false
+	}
+
+	cacheKey := cacheKeyLatest + dockerPrefix + image.Registry + "/" + image.Repository + ":" + image.Tag
+	latest, digest, found := a.getCachedVersion(cacheKey)
+	if !found {
+		ctx, cancel := context.WithTimeout(a.ctx(), apiCallTimeout)
+		defer cancel()
+
+		tags, err := a.Registry.Tags(ctx, image)
+		if err != nil {
+			return OutdatedDependency{}, false
+		}
+		latest = newestDockerTag(image.Tag, tags)
+		digest, _ = a.Registry.Digest(ctx, image, latest)
+		a.cacheVersion(cacheKey, latest, digest, a.cacheTTL())
+	}
+
+	updateType := a.compareVersions(dockerTagNumber(image.Tag), dockerTagNumber(latest))
+	if updateType == updateTypeNone {
+		return OutdatedDependency{}, false
+	}
+
+	return OutdatedDependency{
+		Current:          dep,
+		LatestVersion:    latest,
+		LatestSHA:        digest,
+		UpdateType:       updateType,
+		IsSecurityUpdate: updateType == updateTypeMajor,
+	}, true
+}
+
+// newestDockerTag returns the highest version among tags shaped like current,
+// or current when there is none.
+func newestDockerTag(current string, tags []string) string {
+	match := dockerTagVersion.FindStringSubmatch(current)
+	prefix, parts, suffix := match[1], len(strings.Split(match[2], ".")), match[3]
+
+	newest := current
+	for _, tag := range tags {
+		m := dockerTagVersion.FindStringSubmatch(tag)
+		if m == nil || m[1] != prefix || m[3] != suffix || len(strings.Split(m[2], ".")) != parts {
+			continue
+		}
+		if compareDockerTags(tag, newest) > 0 {
+			newest = tag
+		}
+	}
+
+	return newest
+}
+
+// dockerTagNumber returns the numeric version of a version tag.
+func dockerTagNumber(tag string) string {
+	if match := dockerTagVersion.FindStringSubmatch(tag); match != nil {
+		return match[1] + match[2]
+	}
+
+	return tag
+}
+
+// compareDockerTags compares the numeric versions of two version tags.
+func compareDockerTags(x, y string) int {
+	xs := strings.Split(strings.TrimPrefix(dockerTagNumber(x), "v"), ".")
+	ys := strings.Split(strings.TrimPrefix(dockerTagNumber(y), "v"), ".")
+
+	return slices.CompareFunc(xs, ys, func(a, b string) int {
+		an, _ := strconv.Atoi(a)
+		bn, _ := strconv.Atoi(b)
+
+		return cmp.Compare(an, bn)
+	})
+}
+
+// dockerDigest resolves the tag of a docker:// dependency to a digest.
+func (a *Analyzer) dockerDigest(dep Dependency) string {
+	image := *dep.Docker
+	if image.Digest != "" {
+		return image.Digest
+	}
+	if a.Registry == nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx(), apiCallTimeout)
+	defer cancel()
+
+	digest, err := a.Registry.Digest(ctx, image, image.Tag)
+	if err != nil {
+		return ""
+	}
+
+	return digest
+}
+
+// generateDockerPinnedUpdate creates a pinned update moving a docker://
+// dependency to tag. The digest of the current tag is looked up when digest is
+// empty and tag is unchanged, so floating images can be pinned in place.
+func (a *Analyzer) generateDockerPinnedUpdate(
+	actionPath string,
+	dep Dependency,
+	tag, digest string,
+) (*PinnedUpdate, error) {
+	if digest == "" && tag == dep.Docker.Tag {
+		digest = a.dockerDigest(dep)
+	}
+	newUses, err := a.dockerPinnedUses(dep, tag, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PinnedUpdate{
+		FilePath:   actionPath,
+		OldUses:    dep.Uses,
+		NewUses:    newUses,
+		CommitSHA:  digest,
+		Version:    tag,
+		UpdateType: a.compareVersions(dockerTagNumber(dep.Docker.Tag), dockerTagNumber(tag)),
+		LineNumber: dep.Line,
+	}, nil
+}
+
+// dockerPinnedUses returns the uses reference of a docker:// dependency
+// updated to tag: the digest with the tag as a comment, or the tag with the
+// tag pin strategy.
+func (a *Analyzer) dockerPinnedUses(dep Dependency, tag, digest string) (string, error) {
+	uses, _, _ := strings.Cut(dep.Uses, "@")
+	if colon := strings.LastIndex(uses, ":"); colon > strings.LastIndex(uses, "/") {
+		uses = uses[:colon]
+	}
+	if a.PinStrategy == PinStrategyTag {
+		return uses + ":" + tag, nil
+	}
+	if digest == "" {
+		return "", fmt.Errorf("no digest available for %s", dep.Uses)
+	}
+
+	return fmt.Sprintf("%s@%s # %s", uses, digest, tag), nil
+}
@@ -0,0 +1,104 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// fakeRegistry serves fixed tags and digests for docker:// tests.
+type fakeRegistry struct {
+	tags    []string
+	digests map[string]string
+}
+
+func (r fakeRegistry) Tags(_ context.Context, _ DockerImage) ([]string, error) {
+	return r.tags, nil
+}
+
+func (r fakeRegistry) Digest(_ context.Context, _ DockerImage, tag string) (string, error) {
+	if digest, ok := r.digests[tag]; ok {
+		return digest, nil
+	}
+
+	return "", errors.New("unknown tag")
+}
+
+func TestParseDockerImage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		uses string
+		want string
+	}{
+		{"docker://alpine", "docker.io/library/alpine:latest"},
+		{"docker://alpine:3.19", "docker.io/library/alpine:3.19"},
+		{"docker://node:18-alpine", "docker.io/library/node:18-alpine"},
+		{"docker://hadolint/hadolint:v2.12.0", "docker.io/hadolint/hadolint:v2.12.0"},
+		{"docker://ghcr.io/org/tool:1.2", "ghcr.io/org/tool:1.2"},
+		{"docker://localhost:5000/tool", "localhost:5000/tool:latest"},
+		{"docker://alpine@sha256:abc", "docker.io/library/alpine@sha256:abc"},
+	}
+	for _, tt := range tests {
+		image, err := ParseDockerImage(tt.uses)
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, tt.want, image.String())
+	}
+
+	_, err := ParseDockerImage("docker://")
+	testutil.AssertError(t, err)
+}
+
+func TestNewestDockerTag(t *testing.T) {
+	t.Parallel()
+
+	tags := []string{"latest", "3.18", "3.19", "3.20", "3.20.1", "edge", "18-alpine", "20-alpine", "20", "v1.9"}
+	testutil.AssertEqual(t, "3.20", newestDockerTag("3.19", tags))
+	testutil.AssertEqual(t, "20-alpine", newestDockerTag("18-alpine", tags))
+	testutil.AssertEqual(t, "20", newestDockerTag("18", tags))
+	testutil.AssertEqual(t, "v1.2", newestDockerTag("v1.2", []string{"1.9", "v1.1"}))
+}
+
+func TestAnalyzer_DockerDependencies(t *testing.T) {
+	t.Parallel()
+
+	analyzer := &Analyzer{Cache: NewNoOpCache(), Registry: fakeRegistry{
+		tags:    []string{"3.19", "3.20", "latest"},
+		digests: map[string]string{"3.20": "sha256:new", "latest": "sha256:latest"},
+	}}
+	deps, err := analyzer.processCompositeSteps([]CompositeStep{
+		{Name: "Lint", Uses: "docker://alpine:3.19"},
+		{Name: "Latest", Uses: "docker://alpine"},
+		{Name: "Pinned", Uses: "docker://alpine@sha256:old"},
+	}, nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 3, len(deps))
+	testutil.AssertEqual(t, "docker://docker.io/library/alpine", deps[0].Name)
+	testutil.AssertEqual(t, DockerVersion, deps[0].VersionType)
+	testutil.AssertEqual(t, "3.19", deps[0].Version)
+	testutil.AssertEqual(t, false, deps[0].IsPinned)
+	testutil.AssertEqual(t, true, deps[1].Docker.IsLatest())
+	testutil.AssertEqual(t, true, deps[2].IsPinned)
+
+	outdated, err := analyzer.CheckOutdated(deps)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(outdated))
+	testutil.AssertEqual(t, "3.20", outdated[0].LatestVersion)
+	testutil.AssertEqual(t, updateTypeMinor, outdated[0].UpdateType)
+
+	update, err := analyzer.GeneratePinnedUpdate("action.yml", deps[0], "3.20", outdated[0].LatestSHA)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "docker://alpine@sha256:new # 3.20", update.NewUses)
+
+	// Floating tags are pinned in place to the digest of the tag
+	update, err = analyzer.GeneratePinnedUpdate("action.yml", deps[1], "latest", "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "docker://alpine@sha256:latest # latest", update.NewUses)
+
+	analyzer.PinStrategy = PinStrategyTag
+	update, err = analyzer.GeneratePinnedUpdate("action.yml", deps[0], "3.20", "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "docker://alpine:3.20", update.NewUses)
+}
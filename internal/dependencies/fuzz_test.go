@@ -0,0 +1,83 @@
+package dependencies
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// fixtureUses matches the uses statements of the YAML fixtures.
+var fixtureUses = regexp.MustCompile(`(?m)uses:\s*['"]?([^'"\s#]+)`)
+
+// updateTypes are the update types compareVersions returns.
+var updateTypes = []string{
+	updateTypeNone, updateTypeMajor, updateTypeMinor, updateTypePatch, updateTypePrerelease, updateTypeUnknown,
+}
+
+// fixtureUsesSeeds returns the uses statements of the YAML fixtures.
+func fixtureUsesSeeds() []string {
+	var seeds []string
+	for _, name := range testutil.FixtureNames() {
+		for _, match := range fixtureUses.FindAllStringSubmatch(testutil.MustReadFixture(name), -1) {
+			seeds = append(seeds, match[1])
+		}
+	}
+
+	return seeds
+}
+
+func FuzzParseUsesStatement(f *testing.F) {
+	for _, uses := range fixtureUsesSeeds() {
+		f.Add(uses)
+	}
+	for _, uses := range []string{
+		"", "@", "/@", "a/@v1", "a/b@", "a/b/c/d@v1@v2", "docker://", "./", "../../x",
+		"actions/checkout@v４", "actions/checkout@v1.0.0-β", "ａctions/checkout@v1", "a/b@\x00",
+	} {
+		f.Add(uses)
+	}
+
+	analyzer := &Analyzer{}
+	f.Fuzz(func(t *testing.T, uses string) {
+		owner, repo, version, versionType := analyzer.parseUsesStatement(uses)
+		if versionType == LocalPath {
+			return
+		}
+		if owner+"/"+repo+"@"+version != uses {
+			t.Errorf("parsed %q as %q/%q@%q", uses, owner, repo, version)
+		}
+		if strings.Contains(owner, "/") || owner == "" || repo == "" || version == "" ||
+			strings.ContainsAny(uses, " \t\n\f\r") {
+			t.Errorf("invalid parts of %q: %q/%q@%q", uses, owner, repo, version)
+		}
+	})
+}
+
+func FuzzCompareVersions(f *testing.F) {
+	for _, uses := range fixtureUsesSeeds() {
+		if _, version, ok := strings.Cut(uses, "@"); ok {
+			f.Add(version, "v4.2.2")
+		}
+	}
+	for _, pair := range [][2]string{
+		{"v1", "v2"}, {"v1.2.3", "v1.2.3-rc.1"}, {"2023.10.1", "v2024.01"}, {"v1.2.3.4", "v1.2.3.5"},
+		{"v99999999999999999999", "v1"}, {"v1.0.0+build", "v1.0.0"}, {"v٣", "v٤"}, {"v1.²", "v1.3"},
+		{"stable", "latest"}, {"11bd71901bbe5b1630ceea73d27597364c9af683", "v4"}, {"", ""},
+	} {
+		f.Add(pair[0], pair[1])
+	}
+
+	analyzer := &Analyzer{}
+	f.Fuzz(func(t *testing.T, current, latest string) {
+		updateType := analyzer.compareVersions(current, latest)
+		if !slices.Contains(updateTypes, updateType) {
+			t.Errorf("compareVersions(%q, %q) = %q, not an update type", current, latest, updateType)
+		}
+		if same := analyzer.compareVersions(current, current); same != updateTypeNone {
+			t.Errorf("compareVersions(%q, %q) = %q, expected none", current, current, same)
+		}
+	})
+}
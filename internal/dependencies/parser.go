@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
 )
 
 // parseCompositeActionFromFile reads and parses a composite action file.
@@ -17,13 +20,49 @@ func (a *Analyzer) parseCompositeActionFromFile(actionPath string) (*ActionWithC
 
 	// Parse YAML
 	var action ActionWithComposite
-	if err := yaml.Unmarshal(data, &action); err != nil {
+	if err := yamlsafe.Unmarshal(data, &action); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
+	locateSteps(data, action.Runs.Steps)
 
 	return &action, nil
 }
 
+// locateSteps sets the line of the uses or run statement of each step from
+// the YAML source. Steps keep a zero line when the source cannot be mapped.
+func locateSteps(data []byte, steps []CompositeStep) {
+	file, err := parser.ParseBytes(data, 0)
+	if err != nil || len(file.Docs) == 0 {
+		return
+	}
+	sequence, ok := yamlsafe.MappingValue(yamlsafe.MappingValue(file.Docs[0].Body, "runs"), "steps").(*ast.SequenceNode)
+	if !ok || len(sequence.Values) != len(steps) {
+		return
+	}
+
+	for i, node := range sequence.Values {
+		steps[i].Line = stepLine(node)
+	}
+}
+
+// stepLine returns the line of the uses or run key of a step node, or of the
+// step itself when it has neither, such as an alias of another step.
+func stepLine(node ast.Node) int {
+	for _, value := range yamlsafe.MappingValues(node) {
+		if value.Key == nil {
+			continue
+		}
+		if key := value.Key.String(); key == "uses" || key == "run" {
+			return value.Key.GetToken().Position.Line
+		}
+	}
+	if node.GetToken() == nil {
+		return 0
+	}
+
+	return node.GetToken().Position.Line
+}
+
 // parseCompositeAction parses an action.yml file with composite action support.
 func (a *Analyzer) parseCompositeAction(actionPath string) (*ActionWithComposite, error) {
 	// Use the real file parser
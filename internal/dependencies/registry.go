@@ -0,0 +1,382 @@
+package dependencies
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// dockerHubRegistry is the registry of images without a registry host.
+	dockerHubRegistry = "docker.io"
+	// dockerHubAPIHost serves the registry API of Docker Hub.
+	dockerHubAPIHost = "registry-1.docker.io"
+	// dockerHubAuthHost issues the bearer tokens of Docker Hub.
+	dockerHubAuthHost = "auth.docker.io"
+	// maxTagPages caps the pages of tags read for one image.
+	maxTagPages = 10
+	// basicAuthToken stands for the basic credentials of a registry among bearer tokens.
+	basicAuthToken = "\x00basic"
+)
+
+// manifestMediaTypes are the manifests accepted when resolving a tag to a digest,
+// so multi-platform images resolve to the digest of their index.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// challengeParam matches the key="value" parameters of a WWW-Authenticate header.
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// linkNext matches the next page in a Link header.
+var linkNext = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// ImageRegistry reads tags and digests of docker:// images.
+type ImageRegistry interface {
+	Tags(ctx context.Context, image DockerImage) ([]string, error)
+	Digest(ctx context.Context, image DockerImage, tag string) (string, error)
+}
+
+// RegistryCredentials authenticate to an OCI registry, configured per
+// registry host under deps.registries.
+type RegistryCredentials struct {
+	Username string `mapstructure:"username"  yaml:"username,omitempty"`
+	// Token is the password or access token; prefer TokenEnv to keep it out of config files
+	Token string `mapstructure:"token"     yaml:"token,omitempty"`
+	// TokenEnv names the environment variable holding the token
+	TokenEnv string `mapstructure:"token_env" yaml:"token_env,omitempty"`
+}
+
+// ResolvedToken returns the configured token, read from TokenEnv when set.
+func (c RegistryCredentials) ResolvedToken() string {
+	if c.TokenEnv != "" {
+		if token := os.Getenv(c.TokenEnv); token != "" {
+			return token
+		}
+	}
+
+	return c.Token
+}
+
+// RegistryClient reads tags and digests of images from registries that
+// implement the OCI distribution API, such as Docker Hub and GHCR, and pushes
+// artifacts to them. Tokens are requested when a registry asks for them, with
+// the configured credentials of the registry when there are some.
+type RegistryClient struct {
+	HTTPClient *http.Client
+	// Credentials are keyed by registry host, such as ghcr.io or docker.io
+	Credentials map[string]RegistryCredentials
+	// Scheme of registry URLs, https unless set for tests
+	Scheme string
+
+	mu     sync.Mutex
+	tokens map[string]string // Bearer tokens by registry, repository and scope
+}
+
+// NewRegistryClient creates a registry client authenticating with credentials.
+func NewRegistryClient(credentials map[string]RegistryCredentials) *RegistryClient {
+	return &RegistryClient{HTTPClient: &http.Client{Timeout: apiCallTimeout}, Credentials: credentials}
+}
+
+// Tags lists the tags of image.
+func (c *RegistryClient) Tags(ctx context.Context, image DockerImage) ([]string, error) {
+	next := c.baseURL(image) + "/tags/list?n=1000"
+	var tags []string
+	for page := 0; next != "" && page < maxTagPages; page++ {
+		resp, err := c.do(ctx, http.MethodGet, next, image, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var list struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&list)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode tags of %s: %w", image, err)
+		}
+		tags = append(tags, list.Tags...)
+
+		next = ""
+		if match := linkNext.FindStringSubmatch(resp.Header.Get("Link")); match != nil {
+			if ref, err := url.Parse(match[1]); err == nil {
+				next = resp.Request.URL.ResolveReference(ref).String()
+			}
+		}
+	}
+
+	return tags, nil
+}
+
+// Digest returns the digest of the manifest image's tag points to.
+func (c *RegistryClient) Digest(ctx context.Context, image DockerImage, tag string) (string, error) {
+	accept := http.Header{"Accept": {strings.Join(manifestMediaTypes, ", ")}}
+	resp, err := c.do(ctx, http.MethodHead, c.baseURL(image)+"/manifests/"+url.PathEscape(tag), image, accept, nil)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("no digest for %s:%s", image.Repository, tag)
+	}
+
+	return digest, nil
+}
+
+// PushBlob uploads data as a blob of image's repository, unless the registry
+// already has it, and returns its digest.
+func (c *RegistryClient) PushBlob(ctx context.Context, image DockerImage, data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+	if resp, err := c.do(ctx, http.MethodHead, c.baseURL(image)+"/blobs/"+digest, image, nil, nil); err == nil {
+		_ = resp.Body.Close()
+
+		return digest, nil
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, c.baseURL(image)+"/blobs/uploads/", image, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.Header.Get("Location") == "" {
+		return "", fmt.Errorf("registry %s returned no upload location for %s", image.Registry, image.Repository)
+	}
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	header := http.Header{"Content-Type": {"application/octet-stream"}}
+	if resp, err = c.do(ctx, http.MethodPut, location.String(), image, header, data); err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+
+	return digest, nil
+}
+
+// PushManifest uploads manifest as tag of image's repository and returns its digest.
+func (c *RegistryClient) PushManifest(
+	ctx context.Context,
+	image DockerImage,
+	tag, mediaType string,
+	manifest []byte,
+) (string, error) {
+	header := http.Header{"Content-Type": {mediaType}}
+	target := c.baseURL(image) + "/manifests/" + url.PathEscape(tag)
+	resp, err := c.do(ctx, http.MethodPut, target, image, header, manifest)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+
+	sum := sha256.Sum256(manifest)
+
+	return cmp.Or(resp.Header.Get("Docker-Content-Digest"), "sha256:"+hex.EncodeToString(sum[:])), nil
+}
+
+// baseURL returns the API URL of image's repository.
+func (c *RegistryClient) baseURL(image DockerImage) string {
+	return c.scheme() + "://" + apiHost(image) + "/v2/" + image.Repository
+}
+
+// scheme returns the scheme of registry URLs.
+func (c *RegistryClient) scheme() string {
+	return cmp.Or(c.Scheme, "https")
+}
+
+// apiHost returns the host serving the registry API of image.
+func apiHost(image DockerImage) string {
+	if image.Registry == dockerHubRegistry {
+		return dockerHubAPIHost
+	}
+
+	return image.Registry
+}
+
+// trustedRealm reports whether realm may receive the credentials of image's
+// registry: it must use the registry's scheme and be served by the registry
+// host, or by the token service of Docker Hub.
+func (c *RegistryClient) trustedRealm(realm *url.URL, image DockerImage) bool {
+	if realm.Scheme != c.scheme() {
+		return false
+	}
+
+	return realm.Host == apiHost(image) || (image.Registry == dockerHubRegistry && realm.Host == dockerHubAuthHost)
+}
+
+// do sends a request for image, answering an authentication challenge once
+// with a bearer token or the configured basic credentials. Reads ask for pull
+// access, other methods for push access.
+func (c *RegistryClient) do(
+	ctx context.Context,
+	method, target string,
+	image DockerImage,
+	header http.Header,
+	body []byte,
+) (*http.Response, error) {
+	scope := "pull"
+	if method != http.MethodGet && method != http.MethodHead {
+		scope = "pull,push"
+	}
+	key := image.Registry + "/" + image.Repository + ":" + scope
+	resp, err := c.send(ctx, method, target, header, body, c.cachedToken(key), image)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		token, err := c.authenticate(ctx, challenge, image, scope)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		if c.tokens == nil {
+			c.tokens = map[string]string{}
+		}
+		c.tokens[key] = token
+		c.mu.Unlock()
+
+		if resp, err = c.send(ctx, method, target, header, body, token, image); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		_ = resp.Body.Close()
+
+		return nil, fmt.Errorf("registry %s returned %s for %s", image.Registry, resp.Status, image.Repository)
+	}
+
+	return resp, nil
+}
+
+// cachedToken returns the bearer token of a repository, if one was issued.
+func (c *RegistryClient) cachedToken(key string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.tokens[key]
+}
+
+// send sends a request with token as bearer, or the basic credentials of the
+// registry when token is basicAuthToken.
+func (c *RegistryClient) send(
+	ctx context.Context,
+	method, target string,
+	header http.Header,
+	body []byte,
+	token string,
+	image DockerImage,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	switch token {
+	case "":
+	case basicAuthToken:
+		creds := c.Credentials[image.Registry]
+		req.SetBasicAuth(creds.Username, creds.ResolvedToken())
+	default:
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query registry %s: %w", image.Registry, err)
+	}
+
+	return resp, nil
+}
+
+// authenticate answers a WWW-Authenticate challenge: Bearer challenges are
+// exchanged for a token of the repository with scope, such as pull, Basic
+// ones use the configured credentials.
+func (c *RegistryClient) authenticate(
+	ctx context.Context,
+	challenge string,
+	image DockerImage,
+	scope string,
+) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	creds, hasCreds := c.Credentials[image.Registry]
+	if strings.EqualFold(scheme, "Basic") {
+		if !hasCreds {
+			return "", fmt.Errorf("registry %s requires credentials, add them under deps.registries", image.Registry)
+		}
+
+		return basicAuthToken, nil
+	}
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication %q from registry %s", scheme, image.Registry)
+	}
+
+	values := map[string]string{}
+	for _, match := range challengeParam.FindAllStringSubmatch(params, -1) {
+		values[match[1]] = match[2]
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Scheme == "" {
+		return "", fmt.Errorf("invalid authentication realm from registry %s", image.Registry)
+	}
+	if !c.trustedRealm(realm, image) {
+		return "", fmt.Errorf("registry %s names authentication realm %s on another host or without https",
+			image.Registry, realm.Redacted())
+	}
+	query := realm.Query()
+	if service := values["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", "repository:"+image.Repository+":"+scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid token request: %w", err)
+	}
+	if hasCreds {
+		req.SetBasicAuth(creds.Username, creds.ResolvedToken())
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get a token from registry %s: %w", image.Registry, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry %s refused a token for %s: %s", image.Registry, image.Repository, resp.Status)
+	}
+
+	var issued struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issued); err != nil {
+		return "", fmt.Errorf("failed to decode token from registry %s: %w", image.Registry, err)
+	}
+	if token := cmp.Or(issued.Token, issued.AccessToken); token != "" {
+		return token, nil
+	}
+
+	return "", errors.New("registry " + image.Registry + " issued an empty token")
+}
@@ -0,0 +1,95 @@
+package dependencies
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestRegistryClient_TagsAndDigest(t *testing.T) {
+	t.Parallel()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			user, pass, _ := r.BasicAuth()
+			if user != "bot" || pass != "secret" || r.URL.Query().Get("scope") != "repository:org/tool:pull" {
+				w.WriteHeader(http.StatusForbidden)
+
+				return
+			}
+			_, _ = w.Write([]byte(`{"token": "pull-token"}`))
+		case r.Header.Get("Authorization") != "Bearer pull-token":
+			w.Header().Set("WWW-Authenticate",
+				`Bearer realm="`+server.URL+`/token",service="registry.test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/org/tool/tags/list" && r.URL.Query().Get("last") == "":
+			w.Header().Set("Link", `</v2/org/tool/tags/list?last=1.0>; rel="next"`)
+			_, _ = w.Write([]byte(`{"tags": ["1.0"]}`))
+		case r.URL.Path == "/v2/org/tool/tags/list":
+			_, _ = w.Write([]byte(`{"tags": ["1.1", "latest"]}`))
+		case r.URL.Path == "/v2/org/tool/manifests/1.1" && r.Method == http.MethodHead:
+			w.Header().Set("Docker-Content-Digest", "sha256:abc")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	client := NewRegistryClient(map[string]RegistryCredentials{host: {Username: "bot", Token: "secret"}})
+	client.Scheme = "http"
+	image := DockerImage{Registry: host, Repository: "org/tool", Tag: "1.0"}
+
+	tags, err := client.Tags(context.Background(), image)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "1.0,1.1,latest", strings.Join(tags, ","))
+
+	digest, err := client.Digest(context.Background(), image, "1.1")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "sha256:abc", digest)
+
+	_, err = client.Digest(context.Background(), image, "2.0")
+	testutil.AssertError(t, err)
+}
+
+func TestRegistryClient_UntrustedRealm(t *testing.T) {
+	t.Parallel()
+
+	var tokenRequests int
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		tokenRequests++
+		_, _ = w.Write([]byte(`{"token": "stolen"}`))
+	}))
+	defer tokenServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+tokenServer.URL+`/token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	client := NewRegistryClient(map[string]RegistryCredentials{host: {Username: "bot", Token: "secret"}})
+	client.Scheme = "http"
+
+	_, err := client.Tags(context.Background(), DockerImage{Registry: host, Repository: "org/tool"})
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "on another host or without https")
+	testutil.AssertEqual(t, 0, tokenRequests)
+
+	// Production clients only trust https realms, even on the registry host
+	realm, _ := url.Parse("http://ghcr.io/token")
+	testutil.AssertEqual(t, false, (&RegistryClient{}).trustedRealm(realm, DockerImage{Registry: "ghcr.io"}))
+	realm, _ = url.Parse("https://ghcr.io/token")
+	testutil.AssertEqual(t, true, (&RegistryClient{}).trustedRealm(realm, DockerImage{Registry: "ghcr.io"}))
+	realm, _ = url.Parse("https://auth.docker.io/token")
+	testutil.AssertEqual(t, true, (&RegistryClient{}).trustedRealm(realm, DockerImage{Registry: "docker.io"}))
+	testutil.AssertEqual(t, false, (&RegistryClient{}).trustedRealm(realm, DockerImage{Registry: "ghcr.io"}))
+}
@@ -0,0 +1,42 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ReleaseNotes returns the notes of the GitHub release of dep's repository
+// tagged version, cached like other API responses.
+func (a *Analyzer) ReleaseNotes(dep Dependency, version string) (string, error) {
+	owner, repo, _, _ := a.parseUsesStatement(dep.Uses)
+	if owner == "" || repo == "" {
+		return "", fmt.Errorf("%s has no GitHub releases", dep.Name)
+	}
+
+	cacheKey := cacheKeyNotes + fmt.Sprintf("%s/%s@%s", owner, repo, version)
+	if a.Cache != nil {
+		if cached, ok := a.Cache.Get(cacheKey); ok {
+			if notes, ok := cached.(string); ok {
+				return notes, nil
+			}
+		}
+	}
+	if a.GitHubClient == nil {
+		return "", errors.New("GitHub client not available")
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx(), apiCallTimeout)
+	defer cancel()
+
+	release, _, err := a.GitHubClient.Repositories.GetReleaseByTag(ctx, owner, repo, version)
+	if err != nil {
+		return "", fmt.Errorf("no release %s for %s/%s: %w", version, owner, repo, err)
+	}
+	notes := release.GetBody()
+	if a.Cache != nil {
+		_ = a.Cache.SetWithTTL(cacheKey, notes, a.cacheTTL())
+	}
+
+	return notes, nil
+}
@@ -0,0 +1,44 @@
+package dependencies
+
+import (
+	"context"
+)
+
+// ResolveSHA returns the commit SHA the ref of dep points to: the ref itself
+// when it is a commit SHA, otherwise the commit of the tag or branch, looked up
+// on GitHub and cached. It returns an empty string for shell scripts, local
+// and Docker actions, and refs that cannot be resolved.
+func (a *Analyzer) ResolveSHA(dep Dependency) string {
+	owner, repo, ref, versionType := a.parseUsesStatement(dep.Uses)
+	if owner == "" || repo == "" {
+		return ""
+	}
+	if versionType == CommitSHA {
+		return ref
+	}
+
+	cacheKey := cacheKeyRef + owner + "/" + repo + "@" + ref
+	if a.Cache != nil {
+		if cached, ok := a.Cache.Get(cacheKey); ok {
+			if sha, ok := cached.(string); ok && sha != "" {
+				return sha
+			}
+		}
+	}
+	if a.GitHubClient == nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx(), apiCallTimeout)
+	defer cancel()
+
+	sha, _, err := a.GitHubClient.Repositories.GetCommitSHA1(ctx, owner, repo, ref, "")
+	if err != nil || sha == "" {
+		return ""
+	}
+	if a.Cache != nil {
+		_ = a.Cache.SetWithTTL(cacheKey, sha, a.cacheTTL())
+	}
+
+	return sha
+}
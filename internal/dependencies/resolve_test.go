@@ -0,0 +1,46 @@
+package dependencies
+
+import (
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestAnalyzer_ResolveSHA(t *testing.T) {
+	t.Parallel()
+
+	const sha = "8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e"
+	responses := map[string]string{"GET https://api.github.com/repos/actions/checkout/commits/v4": sha}
+	cache := newJSONCache()
+	analyzer := &Analyzer{GitHubClient: testutil.MockGitHubClient(responses), Cache: cache}
+
+	testutil.AssertEqual(t, sha, analyzer.ResolveSHA(Dependency{Uses: "actions/checkout@" + sha}))
+	testutil.AssertEqual(t, sha, analyzer.ResolveSHA(Dependency{Uses: "actions/checkout@v4"}))
+	testutil.AssertEqual(t, "", analyzer.ResolveSHA(Dependency{IsShellScript: true}))
+	testutil.AssertEqual(t, "", analyzer.ResolveSHA(Dependency{Uses: "./local-action"}))
+
+	// Resolved refs are served from the cache without a client
+	offline := &Analyzer{Cache: cache}
+	testutil.AssertEqual(t, sha, offline.ResolveSHA(Dependency{Uses: "actions/checkout@v4"}))
+	testutil.AssertEqual(t, "", offline.ResolveSHA(Dependency{Uses: "actions/setup-node@v4"}))
+}
+
+func TestAnalyzer_ReleaseNotes(t *testing.T) {
+	t.Parallel()
+
+	cache := newJSONCache()
+	analyzer := &Analyzer{GitHubClient: testutil.MockGitHubClient(testutil.MockGitHubResponses()), Cache: cache}
+	dep := Dependency{Name: "actions/checkout", Uses: "actions/checkout@v4"}
+
+	notes, err := analyzer.ReleaseNotes(dep, "v4.1.1")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, notes, "Fix checkout bug")
+
+	// Notes are served from the cache without a client
+	notes, err = (&Analyzer{Cache: cache}).ReleaseNotes(dep, "v4.1.1")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, notes, "Fix checkout bug")
+
+	_, err = analyzer.ReleaseNotes(Dependency{Name: "build", IsShellScript: true}, "v1")
+	testutil.AssertError(t, err)
+}
@@ -0,0 +1,161 @@
+package dependencies
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// maxRiskHints caps the hints reported for one update.
+	maxRiskHints = 3
+	// maxRiskHintLength caps the release note excerpt of a hint.
+	maxRiskHintLength = 100
+)
+
+// riskPattern matches release note lines describing changes that can break
+// callers: breaking changes, removals, changed defaults and runtime bumps.
+var riskPattern = regexp.MustCompile(`(?i)(breaking|no longer|\bremov(e|ed|es|ing)\b|\bdrop(s|ped)?\b.*\bsupport|` +
+	`deprecat|\brenam(e|ed|es)\b|\bchang(e|ed|es|ing) (the )?default|\bdefaults? (is|are|was|now)\b|now defaults?|` +
+	`requires? node|\bnode ?\d+\b)`)
+
+// riskBullet matches list markers and headings at the start of release note lines.
+var riskBullet = regexp.MustCompile(`^(#+|[-*+]|\d+\.)\s*`)
+
+// riskHints returns hints about the risk of updating dep from current to
+// latest: release note lines about breaking changes, prefixed like
+// "major: v3→v4", and a generic hint for major updates whose notes name none.
+// The matching lines of each release are cached, so notes are fetched and
+// parsed once per release.
+func (a *Analyzer) riskHints(dep Dependency, current, latest, updateType string) []string {
+	owner, repo, _, _ := a.parseUsesStatement(dep.Uses)
+	if owner == "" || repo == "" || updateType == updateTypeNone {
+		return nil
+	}
+
+	var lines []string
+	read := false
+	for _, version := range riskReleases(latest, updateType) {
+		releaseLines, ok := a.riskLines(dep, owner, repo, version)
+		read = read || ok
+		for _, line := range releaseLines {
+			if !slices.Contains(lines, line) {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	prefix := fmt.Sprintf("%s: %s→%s", updateType, a.shortVersion(current, updateType),
+		a.shortVersion(latest, updateType))
+	switch {
+	case len(lines) > 0:
+	case updateType != updateTypeMajor:
+		return nil
+	case read:
+		return []string{prefix + " may include breaking changes, none named in the release notes"}
+	default:
+		return []string{prefix + " may include breaking changes"}
+	}
+
+	hints := make([]string, 0, min(len(lines), maxRiskHints))
+	for _, line := range lines[:min(len(lines), maxRiskHints)] {
+		hints = append(hints, prefix+" "+line)
+	}
+
+	return hints
+}
+
+// riskReleases returns the releases whose notes describe the changes up to
+// latest: latest itself and, for major updates, the first release of its
+// major version, where breaking changes are usually listed.
+func riskReleases(latest, updateType string) []string {
+	releases := []string{latest}
+	if updateType != updateTypeMajor {
+		return releases
+	}
+	prefix := ""
+	if strings.HasPrefix(latest, "v") {
+		prefix = "v"
+	}
+	major, _, _ := strings.Cut(strings.TrimPrefix(latest, "v"), ".")
+	if first := prefix + major + ".0.0"; first != latest {
+		releases = append(releases, first)
+	}
+
+	return releases
+}
+
+// riskLines returns the release note lines of version that match riskPattern,
+// cached under the release, and whether the notes could be read.
+func (a *Analyzer) riskLines(dep Dependency, owner, repo, version string) ([]string, bool) {
+	cacheKey := cacheKeyRisk + fmt.Sprintf("%s/%s@%s", owner, repo, version)
+	if a.Cache != nil {
+		if cached, ok := a.Cache.Get(cacheKey); ok {
+			// Entries loaded from the cache file decode as generic JSON arrays
+			switch lines := cached.(type) {
+			case []string:
+				return lines, true
+			case []any:
+				parsed := make([]string, 0, len(lines))
+				for _, line := range lines {
+					if text, ok := line.(string); ok {
+						parsed = append(parsed, text)
+					}
+				}
+
+				return parsed, true
+			}
+		}
+	}
+
+	notes, err := a.ReleaseNotes(dep, version)
+	if err != nil {
+		return nil, false
+	}
+	lines := ParseRiskLines(notes)
+	if a.Cache != nil {
+		_ = a.Cache.SetWithTTL(cacheKey, lines, a.cacheTTL())
+	}
+
+	return lines, true
+}
+
+// ParseRiskLines returns the lines of release notes that describe changes
+// which can break callers, without list markers and shortened for display.
+func ParseRiskLines(notes string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(notes, "\n") {
+		line = strings.TrimSpace(riskBullet.ReplaceAllString(strings.TrimSpace(line), ""))
+		if line == "" || !riskPattern.MatchString(line) {
+			continue
+		}
+		if runes := []rune(line); len(runes) > maxRiskHintLength {
+			line = strings.TrimSpace(string(runes[:maxRiskHintLength-3])) + "..."
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// shortVersion shortens version to the parts that change in an update of
+// updateType, such as v4 for major updates; commit SHAs keep 7 characters.
+func (a *Analyzer) shortVersion(version, updateType string) string {
+	if a.isCommitSHA(version) {
+		return version[:minSHALength]
+	}
+	prefix := ""
+	if strings.HasPrefix(version, "v") {
+		prefix = "v"
+	}
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	switch updateType {
+	case updateTypeMajor:
+		parts = parts[:1]
+	case updateTypeMinor:
+		parts = parts[:min(len(parts), 2)]
+	}
+
+	return prefix + strings.Join(parts, ".")
+}
@@ -0,0 +1,57 @@
+package dependencies
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestParseRiskLines(t *testing.T) {
+	t.Parallel()
+
+	notes := `## What's Changed
+* Change default fetch-depth to 1
+* Fix typo in README
+- BREAKING: the token input is no longer read from the environment
+1. Requires Node 24 runtime
+* Add default value for path`
+
+	testutil.AssertEqual(t, strings.Join([]string{
+		"Change default fetch-depth to 1",
+		"BREAKING: the token input is no longer read from the environment",
+		"Requires Node 24 runtime",
+	}, "|"), strings.Join(ParseRiskLines(notes), "|"))
+}
+
+func TestAnalyzer_RiskHints(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]string{
+		"GET https://api.github.com/repos/actions/checkout/releases/tags/v4.1.1": `{"tag_name": "v4.1.1",
+			"body": "* Fix checkout bug"}`,
+		"GET https://api.github.com/repos/actions/checkout/releases/tags/v4.0.0": `{"tag_name": "v4.0.0",
+			"body": "* Change default fetch-depth to 1\n* Improve performance"}`,
+		"GET https://api.github.com/repos/actions/setup-node/releases/tags/v4.1.0": `{"tag_name": "v4.1.0",
+			"body": "* Add caching"}`,
+	}
+	cache := newJSONCache()
+	analyzer := &Analyzer{GitHubClient: testutil.MockGitHubClient(responses), Cache: cache}
+	checkout := Dependency{Name: "actions/checkout", Uses: "actions/checkout@v3"}
+
+	hints := analyzer.riskHints(checkout, "v3", "v4.1.1", updateTypeMajor)
+	testutil.AssertEqual(t, "major: v3→v4 Change default fetch-depth to 1", strings.Join(hints, "|"))
+
+	// Parsed lines are cached per release and read back after a restart
+	offline := &Analyzer{Cache: cache}
+	testutil.AssertEqual(t, strings.Join(hints, "|"),
+		strings.Join(offline.riskHints(checkout, "v3", "v4.1.1", updateTypeMajor), "|"))
+
+	setupNode := Dependency{Name: "actions/setup-node", Uses: "actions/setup-node@v4.0.0"}
+	testutil.AssertEqual(t, 0, len(analyzer.riskHints(setupNode, "v4.0.0", "v4.1.0", updateTypeMinor)))
+
+	// Major updates are flagged even when the notes cannot be read
+	unknown := Dependency{Name: "org/tool", Uses: "org/tool@v1"}
+	testutil.AssertEqual(t, "major: v1→v2 may include breaking changes",
+		strings.Join(offline.riskHints(unknown, "v1", "v2.0.0", updateTypeMajor), "|"))
+}
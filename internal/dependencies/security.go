@@ -0,0 +1,26 @@
+package dependencies
+
+// SecuritySummary counts pinned and floating dependencies, the same
+// classification as `deps security`.
+type SecuritySummary struct {
+	Pinned   int `json:"pinned"`
+	Floating int `json:"floating"`
+	// FloatingDependencies are the dependencies that are not pinned, in declaration order.
+	FloatingDependencies []Dependency `json:"floating_dependencies,omitempty"`
+}
+
+// SummarizeSecurity classifies deps as pinned or floating.
+func SummarizeSecurity(deps []Dependency) SecuritySummary {
+	var summary SecuritySummary
+	for _, dep := range deps {
+		if dep.IsPinned {
+			summary.Pinned++
+
+			continue
+		}
+		summary.Floating++
+		summary.FloatingDependencies = append(summary.FloatingDependencies, dep)
+	}
+
+	return summary
+}
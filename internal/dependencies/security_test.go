@@ -0,0 +1,24 @@
+package dependencies
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeSecurity(t *testing.T) {
+	t.Parallel()
+
+	pinned := Dependency{Uses: "actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab", IsPinned: true}
+	floating := Dependency{Uses: "actions/setup-node@v4"}
+	script := Dependency{Name: "Shell Script #1", IsShellScript: true, IsPinned: true}
+
+	got := SummarizeSecurity([]Dependency{pinned, floating, script})
+	want := SecuritySummary{Pinned: 2, Floating: 1, FloatingDependencies: []Dependency{floating}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeSecurity() = %+v, want %+v", got, want)
+	}
+
+	if empty := SummarizeSecurity(nil); !reflect.DeepEqual(empty, SecuritySummary{}) {
+		t.Errorf("SummarizeSecurity(nil) = %+v, want zero value", empty)
+	}
+}
@@ -0,0 +1,286 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// MetadataBundleVersion is the format version of metadata bundles.
+const MetadataBundleVersion = 1
+
+// exportActionDepth is how many levels of composite actions ExportMetadata
+// follows, matching the default depth of deps why --transitive.
+const exportActionDepth = 3
+
+// MetadataBundle is a snapshot of the GitHub metadata of dependencies, made
+// on a connected machine so deps commands can run where GitHub is unreachable.
+type MetadataBundle struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	// Repositories holds the metadata of each repository, keyed by lowercase owner/repo
+	Repositories map[string]*RepositoryMetadata `json:"repositories"`
+}
+
+// RepositoryMetadata is the metadata of one repository in a MetadataBundle.
+type RepositoryMetadata struct {
+	Description   string               `json:"description,omitempty"`
+	LatestVersion string               `json:"latest_version,omitempty"`
+	LatestSHA     string               `json:"latest_sha,omitempty"`
+	Refs          map[string]string    `json:"refs,omitempty"`          // Commit SHA of each tag or branch
+	PinnedAt      map[string]time.Time `json:"pinned_at,omitempty"`     // Release or commit date of each ref
+	ReleaseNotes  map[string]string    `json:"release_notes,omitempty"` // Release notes of each tag
+	// ActionFiles holds the action.yml of sub-path actions, keyed by path@ref
+	// with an empty path for the repository root
+	ActionFiles map[string]string `json:"action_files,omitempty"`
+}
+
+// NewMetadataBundle creates an empty metadata bundle made at createdAt.
+func NewMetadataBundle(createdAt time.Time) *MetadataBundle {
+	return &MetadataBundle{
+		Version:      MetadataBundleVersion,
+		CreatedAt:    createdAt.UTC(),
+		Repositories: map[string]*RepositoryMetadata{},
+	}
+}
+
+// Validate rejects bundles of an unknown format version.
+func (b *MetadataBundle) Validate() error {
+	if b.Version != MetadataBundleVersion {
+		return fmt.Errorf("unsupported metadata bundle version %d, expected %d", b.Version, MetadataBundleVersion)
+	}
+
+	return nil
+}
+
+// repository returns the metadata of owner/repo, creating it when missing.
+func (b *MetadataBundle) repository(owner, repo string) *RepositoryMetadata {
+	name := strings.ToLower(owner + "/" + repo)
+	if meta, ok := b.Repositories[name]; ok {
+		return meta
+	}
+	meta := &RepositoryMetadata{
+		Refs:         map[string]string{},
+		PinnedAt:     map[string]time.Time{},
+		ReleaseNotes: map[string]string{},
+		ActionFiles:  map[string]string{},
+	}
+	b.Repositories[name] = meta
+
+	return meta
+}
+
+// ExportMetadata fetches the metadata deps commands read for deps into a
+// bundle: the latest version of each repository with its description and
+// release notes, the commit SHA and date of each ref, and the action files of
+// the referenced actions and, three levels deep or up to MaxTransitiveDepth,
+// of the actions they use.
+// docker:// images are left out. It returns the dependencies that could not
+// be fetched.
+func (a *Analyzer) ExportMetadata(deps []Dependency, createdAt time.Time) (*MetadataBundle, []WarmFailure, error) {
+	if a.GitHubClient == nil {
+		return nil, nil, errors.New("GitHub client not available")
+	}
+
+	bundle := NewMetadataBundle(createdAt)
+	exporter := metadataExporter{analyzer: a, bundle: bundle, seen: map[string]bool{}, failed: map[string]error{}}
+	depth := exportActionDepth
+	if a.MaxTransitiveDepth > 0 {
+		depth = min(depth, a.MaxTransitiveDepth)
+	}
+	for _, dep := range deps {
+		exporter.export(dep, depth)
+	}
+
+	return bundle, exporter.failures, nil
+}
+
+// metadataExporter collects the metadata of dependencies into a bundle.
+type metadataExporter struct {
+	analyzer *Analyzer
+	bundle   *MetadataBundle
+	seen     map[string]bool  // Uses statements already exported
+	failed   map[string]error // Repositories whose latest version could not be fetched
+	failures []WarmFailure
+}
+
+// export adds the metadata of dep and, while depth remains, of the actions
+// its action file uses.
+func (e *metadataExporter) export(dep Dependency, depth int) {
+	if dep.IsShellScript || dep.IsLocalAction || dep.Docker != nil || e.seen[dep.Uses] {
+		return
+	}
+	e.seen[dep.Uses] = true
+	a := e.analyzer
+	owner, repoPath, ref, versionType := a.parseUsesStatement(dep.Uses)
+	if owner == "" || repoPath == "" {
+		return
+	}
+	repo, dir, _ := strings.Cut(repoPath, "/")
+
+	meta, err := e.repository(owner, repo)
+	if err != nil {
+		e.failures = append(e.failures, WarmFailure{Uses: dep.Uses, Err: err})
+
+		return
+	}
+	if versionType != CommitSHA {
+		if sha := a.ResolveSHA(dep); sha != "" {
+			meta.Refs[ref] = sha
+		}
+	}
+	if pinnedAt, err := a.getPinnedDate(owner, repo, ref); err == nil {
+		meta.PinnedAt[ref] = pinnedAt
+	}
+	for _, version := range riskReleases(meta.LatestVersion, a.compareVersions(ref, meta.LatestVersion)) {
+		if notes, err := a.ReleaseNotes(dep, version); err == nil {
+			meta.ReleaseNotes[version] = notes
+		}
+	}
+
+	content, err := a.actionFile(owner, repo, dir, ref)
+	if err != nil {
+		return // Not every repository is an action with an action file
+	}
+	meta.ActionFiles[strings.ToLower(dir)+"@"+ref] = content
+	if depth <= 1 {
+		return
+	}
+	children, err := a.ActionDependencies(dep)
+	if err != nil {
+		return
+	}
+	for _, child := range children {
+		e.export(child, depth-1)
+	}
+}
+
+// repository returns the metadata of owner/repo, fetching its latest version
+// and description the first time.
+func (e *metadataExporter) repository(owner, repo string) (*RepositoryMetadata, error) {
+	name := strings.ToLower(owner + "/" + repo)
+	if err, ok := e.failed[name]; ok {
+		return nil, err
+	}
+	if meta, ok := e.bundle.Repositories[name]; ok {
+		return meta, nil
+	}
+
+	a := e.analyzer
+	version, sha, err := a.getLatestVersion(owner, repo)
+	if err != nil {
+		e.failed[name] = fmt.Errorf("failed to fetch latest version: %w", err)
+
+		return nil, e.failed[name]
+	}
+	meta := e.bundle.repository(owner, repo)
+	meta.LatestVersion, meta.LatestSHA = version, sha
+	if sha != "" {
+		meta.Refs[version] = sha
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx(), apiCallTimeout)
+	defer cancel()
+	if repository, _, err := a.GitHubClient.Repositories.Get(ctx, owner, repo); err == nil {
+		meta.Description = repository.GetDescription()
+	}
+
+	return meta, nil
+}
+
+// SnapshotCache serves cache misses from an imported metadata bundle, so the
+// analyzer answers lookups offline. Writes go to the underlying cache.
+type SnapshotCache struct {
+	cache  DependencyCache
+	bundle *MetadataBundle
+}
+
+// NewSnapshotCache layers bundle under cache.
+func NewSnapshotCache(cache DependencyCache, bundle *MetadataBundle) *SnapshotCache {
+	if cache == nil {
+		cache = NewNoOpCache()
+	}
+
+	return &SnapshotCache{cache: cache, bundle: bundle}
+}
+
+// Get returns the cached value of key or, when it is not cached, the value
+// the bundle holds for it.
+func (c *SnapshotCache) Get(key string) (any, bool) {
+	if value, ok := c.cache.Get(key); ok {
+		return value, true
+	}
+
+	return c.lookup(key)
+}
+
+// Set stores value in the underlying cache.
+func (c *SnapshotCache) Set(key string, value any) error {
+	return c.cache.Set(key, value)
+}
+
+// SetWithTTL stores value in the underlying cache.
+func (c *SnapshotCache) SetWithTTL(key string, value any, ttl time.Duration) error {
+	return c.cache.SetWithTTL(key, value, ttl)
+}
+
+// Close closes the underlying cache when it supports closing.
+func (c *SnapshotCache) Close() error {
+	if closer, ok := c.cache.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// lookup answers a cache key of the analyzer from the bundle, in the shape
+// the analyzer caches it.
+func (c *SnapshotCache) lookup(key string) (any, bool) {
+	prefix, rest, ok := strings.Cut(key, ":")
+	if !ok {
+		return nil, false
+	}
+	target, ref, _ := strings.Cut(rest, "@")
+	parts := strings.SplitN(target, "/", 3)
+	if len(parts) < 2 {
+		return nil, false
+	}
+	meta, ok := c.bundle.Repositories[strings.ToLower(parts[0]+"/"+parts[1])]
+	if !ok {
+		return nil, false
+	}
+
+	var value string
+	switch prefix + ":" {
+	case cacheKeyLatest:
+		if meta.LatestVersion == "" {
+			return nil, false
+		}
+
+		return map[string]string{"version": meta.LatestVersion, "sha": meta.LatestSHA}, true
+	case cacheKeyRepo:
+		return map[string]any{"description": meta.Description}, true
+	case cacheKeyRef:
+		value, ok = meta.Refs[ref]
+	case cacheKeyPinned:
+		var pinnedAt time.Time
+		if pinnedAt, ok = meta.PinnedAt[ref]; ok {
+			value = pinnedAt.Format(time.RFC3339)
+		}
+	case cacheKeyNotes:
+		value, ok = meta.ReleaseNotes[ref]
+	case cacheKeyAction:
+		dir := ""
+		if len(parts) == 3 {
+			dir = strings.ToLower(parts[2])
+		}
+		value, ok = meta.ActionFiles[dir+"@"+ref]
+	default:
+		return nil, false
+	}
+
+	return value, ok
+}
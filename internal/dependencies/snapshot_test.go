@@ -0,0 +1,119 @@
+package dependencies
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestAnalyzer_ExportMetadata(t *testing.T) {
+	t.Parallel()
+
+	sha := "11bd71901bbe5b1630ceea73d27597364c9af683"
+	action := base64.StdEncoding.EncodeToString([]byte(`name: Checkout
+runs:
+  using: composite
+  steps:
+    - uses: actions/setup-node@v4.0.0
+    - uses: unknown/missing@v1
+`))
+	responses := testutil.MockGitHubResponses()
+	responses["GET https://api.github.com/repos/actions/checkout/commits/v4"] = sha
+	responses["GET https://api.github.com/repos/actions/checkout/contents/action.yml?ref=v4"] =
+		`{"type": "file", "encoding": "base64", "content": "` + action + `"}`
+	analyzer := &Analyzer{GitHubClient: testutil.MockGitHubClient(responses), Cache: newJSONCache()}
+
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	bundle, failures, err := analyzer.ExportMetadata([]Dependency{
+		{Name: "actions/checkout", Uses: "actions/checkout@v4"},
+		{Name: "Shell Script #1", IsShellScript: true},
+	}, createdAt)
+	testutil.AssertNoError(t, err)
+	if len(failures) != 1 || failures[0].Uses != "unknown/missing@v1" {
+		t.Fatalf("expected unknown/missing@v1 to fail, got %+v", failures)
+	}
+	testutil.AssertEqual(t, createdAt, bundle.CreatedAt)
+	testutil.AssertEqual(t, 2, len(bundle.Repositories))
+
+	checkout := bundle.Repositories["actions/checkout"]
+	testutil.AssertEqual(t, "v4.1.1", checkout.LatestVersion)
+	testutil.AssertEqual(t, sha, checkout.Refs["v4"])
+	testutil.AssertStringContains(t, checkout.ReleaseNotes["v4.1.1"], "Fix checkout bug")
+	testutil.AssertStringContains(t, checkout.ActionFiles["@v4"], "actions/setup-node@v4.0.0")
+	if checkout.Description == "" {
+		t.Error("expected the repository description")
+	}
+	testutil.AssertEqual(t, "v4.0.0", bundle.Repositories["actions/setup-node"].LatestVersion)
+
+	// MaxTransitiveDepth bounds the levels of action files exported
+	analyzer.MaxTransitiveDepth = 1
+	checkoutOnly := []Dependency{{Name: "actions/checkout", Uses: "actions/checkout@v4"}}
+	bundle, failures, err = analyzer.ExportMetadata(checkoutOnly, createdAt)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(failures))
+	testutil.AssertEqual(t, 1, len(bundle.Repositories))
+
+	_, _, err = (&Analyzer{}).ExportMetadata(nil, createdAt)
+	testutil.AssertError(t, err)
+}
+
+func TestSnapshotCache(t *testing.T) {
+	t.Parallel()
+
+	bundle := NewMetadataBundle(time.Now())
+	meta := bundle.repository("Actions", "Checkout")
+	meta.Description = "Checkout a Git repository"
+	meta.LatestVersion, meta.LatestSHA = "v4.2.2", "11bd71901bbe5b1630ceea73d27597364c9af683"
+	meta.Refs["v3"] = "f43a0e5ff2bd294095638e18286ca9a3d1956744"
+	meta.PinnedAt["v3"] = time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	meta.ReleaseNotes["v4.0.0"] = "## Breaking changes\n* Removed support for Node.js 16"
+	meta.ActionFiles["sub@v3"] = "name: Sub\nruns:\n  using: composite\n  steps:\n    - uses: actions/cache@v4\n"
+
+	// The bundle survives a round trip through its file format
+	data, err := json.Marshal(bundle)
+	testutil.AssertNoError(t, err)
+	var imported MetadataBundle
+	testutil.AssertNoError(t, json.Unmarshal(data, &imported))
+	testutil.AssertNoError(t, imported.Validate())
+
+	cache := newJSONCache()
+	offline := &Analyzer{Cache: NewSnapshotCache(cache, &imported)}
+	checkout := Dependency{Name: "actions/checkout", Uses: "actions/checkout@v3"}
+
+	outdated, err := offline.CheckOutdatedWithMaxAge([]Dependency{checkout}, 24*time.Hour)
+	testutil.AssertNoError(t, err)
+	if len(outdated) != 1 {
+		t.Fatalf("expected an update from the snapshot, got %+v", outdated)
+	}
+	testutil.AssertEqual(t, "v4.2.2", outdated[0].LatestVersion)
+	testutil.AssertEqual(t, meta.LatestSHA, outdated[0].LatestSHA)
+	testutil.AssertEqual(t, true, outdated[0].IsStale)
+	testutil.AssertStringContains(t, strings.Join(outdated[0].RiskHints, "|"), "Removed support for Node.js 16")
+
+	testutil.AssertEqual(t, meta.Refs["v3"], offline.ResolveSHA(checkout))
+	testutil.AssertEqual(t, "", offline.ResolveSHA(Dependency{Uses: "actions/checkout@v2"}))
+
+	dep := Dependency{}
+	testutil.AssertNoError(t, offline.enrichWithGitHubData(&dep, "actions", "checkout"))
+	testutil.AssertEqual(t, meta.Description, dep.Description)
+
+	deps, err := offline.ActionDependencies(Dependency{Name: "actions/checkout/sub", Uses: "actions/checkout/sub@v3"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "actions/cache@v4", deps[0].Uses)
+
+	// Entries the analyzer caches take precedence over the snapshot
+	offline.cacheVersion(cacheKeyLatest+"actions/checkout", "v5.0.0", "", time.Hour)
+	version, _, err := offline.getLatestVersion("actions", "checkout")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "v5.0.0", version)
+
+	_, found := offline.Cache.Get(cacheKeyLatest + "unknown/action")
+	testutil.AssertEqual(t, false, found)
+
+	imported.Version = MetadataBundleVersion + 1
+	testutil.AssertError(t, imported.Validate())
+}
@@ -0,0 +1,125 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/internal/clock"
+)
+
+const (
+	// Units for the day and week suffixes of max-age values.
+	hoursPerDay = 24
+	daysPerWeek = 7
+)
+
+// ParseMaxAge parses a maximum pin age such as "365d", "52w" or any value accepted
+// by time.ParseDuration ("8760h").
+func ParseMaxAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, errors.New("max age is empty")
+	}
+
+	days := 0
+	switch {
+	case strings.HasSuffix(value, "d"):
+		days = 1
+	case strings.HasSuffix(value, "w"):
+		days = daysPerWeek
+	}
+
+	if days == 0 {
+		age, err := time.ParseDuration(value)
+		if err != nil || age <= 0 {
+			return 0, fmt.Errorf("invalid max age %q, use e.g. 365d, 52w or 8760h", value)
+		}
+
+		return age, nil
+	}
+
+	count, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || count <= 0 {
+		return 0, fmt.Errorf("invalid max age %q, use e.g. 365d, 52w or 8760h", value)
+	}
+	if count > int(math.MaxInt64/time.Hour)/(days*hoursPerDay) {
+		return 0, fmt.Errorf("max age %q is too large", value)
+	}
+
+	return time.Duration(count*days*hoursPerDay) * time.Hour, nil
+}
+
+// scoreStaleness fills the age and staleness fields of result from the release
+// or commit date of ref. Lookups that fail leave the fields empty.
+func (a *Analyzer) scoreStaleness(result *OutdatedDependency, owner, repo, ref string, maxAge time.Duration) {
+	pinnedAt, err := a.getPinnedDate(owner, repo, ref)
+	if err != nil {
+		return
+	}
+
+	age := clock.Since(pinnedAt)
+	result.PinnedAt = pinnedAt
+	result.AgeDays = int(age.Hours() / hoursPerDay)
+	result.StalenessScore = math.Round(float64(age)/float64(maxAge)*100) / 100
+	result.IsStale = age > maxAge
+}
+
+// getPinnedDate returns the publish date of the release for ref or, when ref is
+// not a release, the committer date of the commit ref points to.
+func (a *Analyzer) getPinnedDate(owner, repo, ref string) (time.Time, error) {
+	cacheKey := cacheKeyPinned + fmt.Sprintf("%s/%s@%s", owner, repo, ref)
+	if a.Cache != nil {
+		if cached, ok := a.Cache.Get(cacheKey); ok {
+			if date, ok := cached.(string); ok {
+				if pinnedAt, err := time.Parse(time.RFC3339, date); err == nil {
+					return pinnedAt, nil
+				}
+			}
+		}
+	}
+
+	if a.GitHubClient == nil {
+		return time.Time{}, errors.New("GitHub client not available")
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx(), apiCallTimeout)
+	defer cancel()
+
+	pinnedAt, err := a.lookupPinnedDate(ctx, owner, repo, ref)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if a.Cache != nil {
+		_ = a.Cache.SetWithTTL(cacheKey, pinnedAt.Format(time.RFC3339), a.cacheTTL())
+	}
+
+	return pinnedAt, nil
+}
+
+// lookupPinnedDate queries GitHub for the release or commit date of ref.
+func (a *Analyzer) lookupPinnedDate(ctx context.Context, owner, repo, ref string) (time.Time, error) {
+	if !a.isCommitSHA(ref) {
+		release, _, err := a.GitHubClient.Repositories.GetReleaseByTag(ctx, owner, repo, ref)
+		if err == nil && !release.GetPublishedAt().IsZero() {
+			return release.GetPublishedAt().UTC(), nil
+		}
+	}
+
+	commit, _, err := a.GitHubClient.Repositories.GetCommit(ctx, owner, repo, ref, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get commit %s for %s/%s: %w", ref, owner, repo, err)
+	}
+
+	date := commit.GetCommit().GetCommitter().GetDate()
+	if date.IsZero() {
+		return time.Time{}, fmt.Errorf("no commit date for %s/%s@%s", owner, repo, ref)
+	}
+
+	return date.UTC(), nil
+}
@@ -0,0 +1,128 @@
+package dependencies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestParseMaxAge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       string
+		expected    time.Duration
+		expectError bool
+	}{
+		{name: "days", value: "365d", expected: 365 * 24 * time.Hour},
+		{name: "weeks", value: "2w", expected: 14 * 24 * time.Hour},
+		{name: "go duration", value: "36h", expected: 36 * time.Hour},
+		{name: "surrounding spaces", value: " 30d ", expected: 30 * 24 * time.Hour},
+		{name: "empty", value: "", expectError: true},
+		{name: "zero days", value: "0d", expectError: true},
+		{name: "negative duration", value: "-5h", expectError: true},
+		{name: "unknown unit", value: "12y", expectError: true},
+		{name: "not a number", value: "xd", expectError: true},
+		{name: "days overflowing a duration", value: "1000000d", expectError: true},
+		{name: "weeks overflowing a duration", value: "20000w", expectError: true},
+		{name: "largest days", value: "106751d", expected: 106751 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			age, err := ParseMaxAge(tt.value)
+			if tt.expectError {
+				testutil.AssertError(t, err)
+
+				return
+			}
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tt.expected, age)
+		})
+	}
+}
+
+func TestAnalyzer_CheckOutdatedWithMaxAge(t *testing.T) {
+	t.Parallel()
+
+	releaseDate := time.Date(2023, 11, 1, 10, 0, 0, 0, time.UTC)
+	commitDate := time.Date(2023, 11, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		uses          string
+		maxAge        time.Duration
+		expectStale   bool
+		expectListed  bool
+		expectPinned  time.Time
+		expectUpdated string
+	}{
+		{
+			name:          "latest release older than max age is stale",
+			uses:          "actions/checkout@v4.1.1",
+			maxAge:        30 * 24 * time.Hour,
+			expectStale:   true,
+			expectListed:  true,
+			expectPinned:  releaseDate,
+			expectUpdated: updateTypeNone,
+		},
+		{
+			name:          "commit SHA uses the commit date",
+			uses:          "actions/checkout@8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e",
+			maxAge:        30 * 24 * time.Hour,
+			expectStale:   true,
+			expectListed:  true,
+			expectPinned:  commitDate,
+			expectUpdated: updateTypeNone, // The commit of the latest release
+		},
+		{
+			name:         "latest release within max age is not listed",
+			uses:         "actions/checkout@v4.1.1",
+			maxAge:       100 * 365 * 24 * time.Hour,
+			expectListed: false,
+		},
+		{
+			name:         "no max age skips staleness",
+			uses:         "actions/checkout@v4.1.1",
+			expectListed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			analyzer := &Analyzer{
+				GitHubClient: testutil.MockGitHubClient(testutil.MockGitHubResponses()),
+				Cache:        NewNoOpCache(),
+			}
+			deps := []Dependency{{Name: "actions/checkout", Uses: tt.uses}}
+
+			outdated, err := analyzer.CheckOutdatedWithMaxAge(deps, tt.maxAge)
+			testutil.AssertNoError(t, err)
+
+			if !tt.expectListed {
+				testutil.AssertEqual(t, 0, len(outdated))
+
+				return
+			}
+
+			if len(outdated) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(outdated))
+			}
+			result := outdated[0]
+			testutil.AssertEqual(t, tt.expectStale, result.IsStale)
+			testutil.AssertEqual(t, tt.expectUpdated, result.UpdateType)
+			testutil.AssertEqual(t, tt.expectPinned, result.PinnedAt)
+			testutil.AssertEqual(t, int(time.Since(tt.expectPinned).Hours()/24), result.AgeDays)
+			if result.StalenessScore <= 1 {
+				t.Errorf("expected staleness score above 1, got %v", result.StalenessScore)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
+)
+
+// actionFileNames are the metadata files of an action, in lookup order.
+var actionFileNames = []string{"action.yml", "action.yaml"}
+
+// ActionDependencies returns the dependencies of the composite action that
+// dep references, read from its action file at the referenced version and
+// cached like other API responses. Other actions have no dependencies.
+func (a *Analyzer) ActionDependencies(dep Dependency) ([]Dependency, error) {
+	owner, repoPath, version, _ := a.parseUsesStatement(dep.Uses)
+	if owner == "" || repoPath == "" {
+		return nil, nil
+	}
+	repo, dir, _ := strings.Cut(repoPath, "/")
+
+	content, err := a.actionFile(owner, repo, dir, version)
+	if err != nil {
+		return nil, err
+	}
+	var action ActionWithComposite
+	if err := yamlsafe.Unmarshal([]byte(content), &action); err != nil {
+		return nil, fmt.Errorf("failed to parse action file of %s: %w", dep.Uses, err)
+	}
+	if action.Runs.Using != compositeUsing {
+		return nil, nil
+	}
+	locateSteps([]byte(content), action.Runs.Steps)
+
+	// The steps are analyzed without enriching them from the API
+	return (&Analyzer{}).processCompositeSteps(action.Runs.Steps, nil)
+}
+
+// actionFile returns the content of the action file in dir of owner/repo at ref.
+func (a *Analyzer) actionFile(owner, repo, dir, ref string) (string, error) {
+	cacheKey := cacheKeyAction + fmt.Sprintf("%s/%s/%s@%s", owner, repo, dir, ref)
+	if a.Cache != nil {
+		if cached, ok := a.Cache.Get(cacheKey); ok {
+			if content, ok := cached.(string); ok {
+				return content, nil
+			}
+		}
+	}
+	if a.GitHubClient == nil {
+		return "", errors.New("GitHub client not available")
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx(), apiCallTimeout)
+	defer cancel()
+
+	for _, name := range actionFileNames {
+		file, _, resp, err := a.GitHubClient.Repositories.GetContents(
+			ctx, owner, repo, path.Join(dir, name), &github.RepositoryContentGetOptions{Ref: ref},
+		)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch action file of %s/%s@%s: %w", owner, repo, ref, err)
+		}
+		if file == nil {
+			return "", fmt.Errorf("%s in %s/%s is not a file", path.Join(dir, name), owner, repo)
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			return "", fmt.Errorf("failed to decode action file of %s/%s@%s: %w", owner, repo, ref, err)
+		}
+		if a.Cache != nil {
+			_ = a.Cache.SetWithTTL(cacheKey, content, a.cacheTTL())
+		}
+
+		return content, nil
+	}
+
+	return "", fmt.Errorf("no action file in %s/%s@%s", owner, repo, ref)
+}
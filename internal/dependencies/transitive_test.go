@@ -0,0 +1,39 @@
+package dependencies
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestAnalyzer_ActionDependencies(t *testing.T) {
+	t.Parallel()
+
+	action := base64.StdEncoding.EncodeToString([]byte(`name: Setup
+description: Sets up the toolchain
+runs:
+  using: composite
+  steps:
+    - uses: actions/checkout@v3
+    - run: make setup
+      shell: bash
+`))
+	contents := "GET https://api.github.com/repos/org/tools/contents/"
+	analyzer := &Analyzer{GitHubClient: testutil.MockGitHubClient(map[string]string{
+		contents + "setup/action.yaml?ref=v1": `{"type": "file", "encoding": "base64", "content": "` + action + `"}`,
+	})}
+
+	deps, err := analyzer.ActionDependencies(Dependency{Name: "org/tools/setup", Uses: "org/tools/setup@v1"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(deps))
+	testutil.AssertEqual(t, "actions/checkout@v3", deps[0].Uses)
+	testutil.AssertEqual(t, 6, deps[0].Line)
+
+	_, err = analyzer.ActionDependencies(Dependency{Name: "org/other", Uses: "org/other@v1"})
+	testutil.AssertError(t, err)
+
+	deps, err = analyzer.ActionDependencies(Dependency{Name: "alpine", Uses: "docker://alpine:3.20"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(deps))
+}
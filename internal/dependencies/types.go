@@ -2,12 +2,21 @@ package dependencies
 
 // CompositeStep represents a step in a composite action.
 type CompositeStep struct {
-	Name  string            `yaml:"name,omitempty"`
-	Uses  string            `yaml:"uses,omitempty"`
-	With  map[string]any    `yaml:"with,omitempty"`
-	Run   string            `yaml:"run,omitempty"`
-	Shell string            `yaml:"shell,omitempty"`
-	Env   map[string]string `yaml:"env,omitempty"`
+	ID               string         `yaml:"id,omitempty"`
+	Name             string         `yaml:"name,omitempty"`
+	If               string         `yaml:"if,omitempty"`
+	Uses             string         `yaml:"uses,omitempty"`
+	With             map[string]any `yaml:"with,omitempty"`
+	Run              string         `yaml:"run,omitempty"`
+	Shell            string         `yaml:"shell,omitempty"`
+	WorkingDirectory string         `yaml:"working-directory,omitempty"`
+	Env              map[string]any `yaml:"env,omitempty"`
+	// ContinueOnError is a boolean or an expression such as ${{ inputs.allow-failure }}.
+	ContinueOnError any `yaml:"continue-on-error,omitempty"`
+	// TimeoutMinutes is a number or an expression.
+	TimeoutMinutes any `yaml:"timeout-minutes,omitempty"`
+	// Line is the line of the step's uses or run key in the action file, from 1.
+	Line int `yaml:"-"`
 }
 
 // CompositeRuns represents the runs section of a composite action.
@@ -0,0 +1,191 @@
+package dependencies
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// Update types beyond major, minor and patch.
+const (
+	// updateTypePrerelease is an update between releases of the same version,
+	// such as v2.0.0-rc.1 to v2.0.0-rc.2 or v2.0.0.
+	updateTypePrerelease = "prerelease"
+	// updateTypeUnknown is an update between tags without comparable versions.
+	updateTypeUnknown = "unknown"
+)
+
+// calverPattern matches calendar version tags with a four-digit year, such as v2023.10.1 or 2024.01.
+var calverPattern = regexp.MustCompile(`^v?((?:19|20)\d{2})[.-](0?[1-9]|1[0-2])(?:[.-](\d+))?(?:[.-](\d+))?$`)
+
+// numericPattern matches dotted numeric tags that are not semantic versions, such as v1.2.3.4.
+var numericPattern = regexp.MustCompile(`^v?\d+(?:\.\d+)*$`)
+
+// VersionScheme classifies the update between two version tags of one
+// versioning scheme.
+type VersionScheme interface {
+	// Name identifies the scheme, such as "semver".
+	Name() string
+	// Classify returns the update type from current to latest, updateTypeNone
+	// when latest is not newer, and false when a tag does not follow the scheme.
+	Classify(current, latest string) (string, bool)
+}
+
+// DefaultVersionSchemes are tried in order by Analyzer when it has no schemes
+// of its own: calendar versions, semantic versions, dotted numbers and, last,
+// opaque tags that only compare equal or not.
+var DefaultVersionSchemes = []VersionScheme{CalVer{}, SemVer{}, NumericVersion{}, OpaqueVersion{}}
+
+// SemVer compares semantic versions with pre-releases and build metadata.
+// The v prefix is optional and v4 or v4.1 stand for v4.0.0 and v4.1.0.
+type SemVer struct{}
+
+// Name returns "semver".
+func (SemVer) Name() string { return "semver" }
+
+// Classify returns the most significant changed part of the version. Build
+// metadata is ignored, and moving between pre-releases of a version or from a
+// pre-release to its release is a prerelease update. Moving from a floating
+// major tag such as v4 to a release of it is a patch update.
+func (SemVer) Classify(current, latest string) (string, bool) {
+	cur, lat := "v"+strings.TrimPrefix(current, "v"), "v"+strings.TrimPrefix(latest, "v")
+	if !semver.IsValid(cur) || !semver.IsValid(lat) {
+		return "", false
+	}
+	if semver.Compare(lat, cur) <= 0 {
+		return updateTypeNone, true
+	}
+
+	if !strings.Contains(cur, ".") && semver.Major(lat) == cur {
+		return updateTypePatch, true
+	}
+
+	switch {
+	case semver.Major(cur) != semver.Major(lat):
+		return updateTypeMajor, true
+	case semver.MajorMinor(cur) != semver.MajorMinor(lat):
+		return updateTypeMinor, true
+	case semverCore(cur) != semverCore(lat):
+		return updateTypePatch, true
+	default:
+		return updateTypePrerelease, true
+	}
+}
+
+// semverCore returns the major.minor.patch version without pre-release and build metadata.
+func semverCore(version string) string {
+	canonical := semver.Canonical(version)
+
+	return strings.TrimSuffix(canonical, semver.Prerelease(canonical))
+}
+
+// CalVer compares calendar versions such as v2023.10.1: a new year is a major
+// update, a new month a minor update and any later part a patch update.
+type CalVer struct{}
+
+// Name returns "calver".
+func (CalVer) Name() string { return "calver" }
+
+// Classify compares the year, month and later parts of both tags.
+func (CalVer) Classify(current, latest string) (string, bool) {
+	cur, lat := calverParts(current), calverParts(latest)
+	if cur == nil || lat == nil {
+		return "", false
+	}
+
+	return classifyParts(cur, lat), true
+}
+
+// calverParts returns the numeric parts of a calendar version, or nil.
+func calverParts(version string) []int {
+	match := calverPattern.FindStringSubmatch(version)
+	if match == nil {
+		return nil
+	}
+	parts := make([]int, 0, len(match)-1)
+	for _, part := range match[1:] {
+		n, _ := strconv.Atoi(part) // Missing parts are 0
+		parts = append(parts, n)
+	}
+
+	return parts
+}
+
+// NumericVersion compares dotted numeric tags that are not semantic versions,
+// such as v1.2.3.4, part by part.
+type NumericVersion struct{}
+
+// Name returns "numeric".
+func (NumericVersion) Name() string { return "numeric" }
+
+// Classify compares the parts of both tags numerically; the first part is
+// the major version, the second the minor version and the rest patches.
+func (NumericVersion) Classify(current, latest string) (string, bool) {
+	if !numericPattern.MatchString(current) || !numericPattern.MatchString(latest) {
+		return "", false
+	}
+
+	return classifyParts(numericParts(current), numericParts(latest)), true
+}
+
+// numericParts returns the dotted numbers of version.
+func numericParts(version string) []int {
+	fields := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	parts := make([]int, len(fields))
+	for i, field := range fields {
+		parts[i], _ = strconv.Atoi(field)
+	}
+
+	return parts
+}
+
+// OpaqueVersion compares tags without a recognizable version, such as
+// "stable" or "release-candidate": different tags are an unknown update.
+type OpaqueVersion struct{}
+
+// Name returns "opaque".
+func (OpaqueVersion) Name() string { return "opaque" }
+
+// Classify reports an unknown update for different tags.
+func (OpaqueVersion) Classify(current, latest string) (string, bool) {
+	if current == latest {
+		return updateTypeNone, true
+	}
+
+	return updateTypeUnknown, true
+}
+
+// classifyParts returns the update type of the first part that increased,
+// or updateTypeNone when latest is not newer. Missing parts are 0.
+func classifyParts(current, latest []int) string {
+	length := max(len(current), len(latest))
+	current = append(slices.Clone(current), make([]int, length-len(current))...)
+	latest = append(slices.Clone(latest), make([]int, length-len(latest))...)
+
+	for i := range length {
+		switch cmp.Compare(latest[i], current[i]) {
+		case -1:
+			return updateTypeNone
+		case 1:
+			return partUpdateType(i)
+		}
+	}
+
+	return updateTypeNone
+}
+
+// partUpdateType returns the update type of a change in the version part at index.
+func partUpdateType(index int) string {
+	switch index {
+	case 0:
+		return updateTypeMajor
+	case 1:
+		return updateTypeMinor
+	default:
+		return updateTypePatch
+	}
+}
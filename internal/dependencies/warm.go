@@ -0,0 +1,108 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// WarmFailure is a dependency whose metadata could not be fetched while warming the cache.
+type WarmFailure struct {
+	Uses string
+	Err  error
+}
+
+// WarmCache fetches the latest release or tag, the repository metadata and the
+// date of the pinned ref of each dependency and caches them for ttl, so later
+// analysis such as CheckOutdatedWithMaxAge is served from the cache, also
+// offline. Each repository and ref is fetched once. It returns the number of
+// repositories cached and the dependencies that could not be fetched.
+func (a *Analyzer) WarmCache(deps []Dependency, ttl time.Duration) (int, []WarmFailure, error) {
+	if a.GitHubClient == nil {
+		return 0, nil, errors.New("GitHub client not available")
+	}
+	if a.Cache == nil {
+		return 0, nil, errors.New("cache not available")
+	}
+
+	var failures []WarmFailure
+	repositories := make(map[string]error)
+	refs := make(map[string]bool)
+	for _, dep := range deps {
+		if dep.IsShellScript || dep.IsLocalAction {
+			continue
+		}
+		owner, repo, ref, _ := a.parseUsesStatement(dep.Uses)
+		if owner == "" || repo == "" {
+			continue
+		}
+
+		name := owner + "/" + repo
+		if _, seen := repositories[name]; !seen {
+			repositories[name] = a.warmRepository(owner, repo, ttl)
+		}
+		if err := repositories[name]; err != nil {
+			failures = append(failures, WarmFailure{Uses: dep.Uses, Err: err})
+
+			continue
+		}
+
+		if pinned := name + "@" + ref; !refs[pinned] {
+			refs[pinned] = true
+			a.warmPinnedDate(owner, repo, ref, ttl)
+		}
+	}
+
+	warmed := 0
+	for _, err := range repositories {
+		if err == nil {
+			warmed++
+		}
+	}
+
+	return warmed, failures, nil
+}
+
+// Close flushes the cache of the analyzer to disk when it supports closing.
+func (a *Analyzer) Close() error {
+	if closer, ok := a.Cache.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// warmRepository caches the latest version and metadata of a repository. The
+// metadata only adds descriptions, so failing to fetch it is not an error.
+func (a *Analyzer) warmRepository(owner, repo string, ttl time.Duration) error {
+	version, sha, err := a.fetchLatestVersion(owner, repo)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest version: %w", err)
+	}
+	a.cacheVersion(cacheKeyLatest+fmt.Sprintf("%s/%s", owner, repo), version, sha, ttl)
+
+	ctx, cancel := context.WithTimeout(a.ctx(), apiCallTimeout)
+	defer cancel()
+
+	if repository, _, err := a.GitHubClient.Repositories.Get(ctx, owner, repo); err == nil {
+		_ = a.Cache.SetWithTTL(cacheKeyRepo+fmt.Sprintf("%s/%s", owner, repo), repository, ttl)
+	}
+
+	return nil
+}
+
+// warmPinnedDate caches the release or commit date of ref. Branch refs and refs
+// GitHub cannot resolve are skipped; staleness scoring fetches them on demand.
+func (a *Analyzer) warmPinnedDate(owner, repo, ref string, ttl time.Duration) {
+	ctx, cancel := context.WithTimeout(a.ctx(), apiCallTimeout)
+	defer cancel()
+
+	pinnedAt, err := a.lookupPinnedDate(ctx, owner, repo, ref)
+	if err != nil {
+		return
+	}
+	cacheKey := cacheKeyPinned + fmt.Sprintf("%s/%s@%s", owner, repo, ref)
+	_ = a.Cache.SetWithTTL(cacheKey, pinnedAt.Format(time.RFC3339), ttl)
+}
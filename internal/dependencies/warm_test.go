@@ -0,0 +1,112 @@
+package dependencies
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// jsonCache stores values the way the cache file does, so values read back are
+// decoded JSON as after a restart.
+type jsonCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	ttls    map[string]time.Duration
+}
+
+func newJSONCache() *jsonCache {
+	return &jsonCache{entries: map[string][]byte{}, ttls: map[string]time.Duration{}}
+}
+
+func (c *jsonCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+func (c *jsonCache) Set(key string, value any) error {
+	return c.SetWithTTL(key, value, cacheDefaultTTL)
+}
+
+func (c *jsonCache) SetWithTTL(key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = data
+	c.ttls[key] = ttl
+
+	return nil
+}
+
+func TestAnalyzer_WarmCache(t *testing.T) {
+	t.Parallel()
+
+	warmCache := newJSONCache()
+	analyzer := &Analyzer{
+		GitHubClient: testutil.MockGitHubClient(testutil.MockGitHubResponses()),
+		Cache:        warmCache,
+	}
+	deps := []Dependency{
+		{Uses: "actions/checkout@v4.1.1"},
+		{Uses: "actions/checkout@v3"},
+		{Uses: "actions/setup-node@v4.0.0"},
+		{Uses: "unknown/missing@v1"},
+		{Uses: "./local-action", IsLocalAction: true},
+		{Name: "Shell Script #1", IsShellScript: true},
+	}
+
+	warmed, failures, err := analyzer.WarmCache(deps, 48*time.Hour)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, warmed)
+	if len(failures) != 1 || failures[0].Uses != "unknown/missing@v1" {
+		t.Fatalf("expected unknown/missing@v1 to fail, got %+v", failures)
+	}
+	testutil.AssertEqual(t, 48*time.Hour, warmCache.ttls[cacheKeyLatest+"actions/checkout"])
+	testutil.AssertEqual(t, 48*time.Hour, warmCache.ttls[cacheKeyRepo+"actions/checkout"])
+	testutil.AssertEqual(t, 48*time.Hour, warmCache.ttls[cacheKeyPinned+"actions/checkout@v4.1.1"])
+
+	// Without a GitHub client every answer must come from the warmed cache.
+	offline := &Analyzer{Cache: warmCache}
+	outdated, err := offline.CheckOutdatedWithMaxAge([]Dependency{
+		{Name: "actions/checkout", Uses: "actions/checkout@v3"},
+		{Name: "actions/checkout", Uses: "actions/checkout@v4.1.1"},
+	}, 24*time.Hour)
+	testutil.AssertNoError(t, err)
+	if len(outdated) != 2 {
+		t.Fatalf("expected 2 results from the cache, got %+v", outdated)
+	}
+	testutil.AssertEqual(t, "v4.1.1", outdated[0].LatestVersion)
+	testutil.AssertEqual(t, updateTypeMajor, outdated[0].UpdateType)
+	testutil.AssertEqual(t, true, outdated[1].IsStale)
+
+	dep := Dependency{}
+	testutil.AssertNoError(t, offline.enrichWithGitHubData(&dep, "actions", "checkout"))
+	if dep.Description == "" {
+		t.Error("expected the repository description from the cache")
+	}
+}
+
+func TestAnalyzer_WarmCacheRequiresClient(t *testing.T) {
+	t.Parallel()
+
+	analyzer := &Analyzer{Cache: newJSONCache()}
+	_, _, err := analyzer.WarmCache([]Dependency{{Uses: "actions/checkout@v4"}}, time.Hour)
+	testutil.AssertError(t, err)
+}
@@ -0,0 +1,609 @@
+package internal
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+)
+
+// Sort orders of the dependency browser.
+const (
+	BrowserSortFile   = "file"
+	BrowserSortName   = "name"
+	BrowserSortUpdate = "update"
+)
+
+// browserSortOrders lists the sort orders in the order the s key cycles through them.
+var browserSortOrders = []string{BrowserSortFile, BrowserSortName, BrowserSortUpdate}
+
+// browserUpdateRanks orders update types from the most to the least disruptive.
+var browserUpdateRanks = map[string]int{"major": 0, "minor": 1, "patch": 2, "prerelease": 3}
+
+// Terminal control sequences of the full-screen browser.
+const (
+	browserEnterScreen = "\x1b[?1049h\x1b[?25l" // Alternate screen, hidden cursor
+	browserLeaveScreen = "\x1b[?25h\x1b[?1049l"
+	browserClearScreen = "\x1b[H\x1b[2J"
+	browserHighlight   = "\x1b[7m"
+	browserResetStyle  = "\x1b[0m"
+)
+
+// Key help shown at the bottom of each screen.
+const (
+	browserListHelp   = "↑/↓ move  space select  * all  / filter  s sort  n notes  a apply  q quit"
+	browserFilterHelp = "type to filter by file or name, or pinned, floating, outdated, major, minor, patch" +
+		"  enter keep  esc cancel"
+	browserNotesHelp = "↑/↓ scroll  q back"
+)
+
+// Size of the browser when the terminal size is unknown.
+const (
+	browserDefaultWidth  = 80
+	browserDefaultHeight = 24
+)
+
+// browserFooterLines is the number of lines below the list: the status, the
+// message or filter and the key help.
+const browserFooterLines = 3
+
+// Names of the keys the browser handles besides printable characters.
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyPageUp    = "pgup"
+	keyPageDown  = "pgdown"
+	keyHome      = "home"
+	keyEnd       = "end"
+	keyEnter     = "enter"
+	keyEscape    = "esc"
+	keyBackspace = "backspace"
+	keyInterrupt = "ctrl-c"
+)
+
+// browserEscapeKeys maps the CSI and SS3 sequences of terminals to key names.
+var browserEscapeKeys = map[string]string{
+	"A": keyUp, "B": keyDown, "H": keyHome, "F": keyEnd,
+	"1~": keyHome, "4~": keyEnd, "5~": keyPageUp, "6~": keyPageDown,
+}
+
+// BrowserEntry is a dependency shown by the dependency browser.
+type BrowserEntry struct {
+	File       string
+	Dependency dependencies.Dependency
+	Update     *dependencies.OutdatedDependency // Nil when no newer version exists
+}
+
+// updateType returns the type of the available update, or "none".
+func (e BrowserEntry) updateType() string {
+	if e.Update == nil {
+		return "none"
+	}
+
+	return e.Update.UpdateType
+}
+
+// Actionable reports whether selecting the entry changes the action file: it
+// has a newer version or is not pinned yet.
+func (e BrowserEntry) Actionable() bool {
+	return e.Update != nil || !e.Dependency.IsPinned
+}
+
+// browserMode is the screen shown by the dependency browser.
+type browserMode int
+
+const (
+	browserModeList browserMode = iota
+	browserModeFilter
+	browserModeNotes
+)
+
+// DependencyBrowser is a full-screen terminal interface to browse the
+// dependencies of the action files, filter and sort them, read release notes
+// and select the ones to pin or upgrade, driven by keys read from a terminal
+// in raw mode.
+type DependencyBrowser struct {
+	Entries []BrowserEntry
+	// Root is the directory file paths are shown relative to
+	Root string
+	// ReleaseNotes returns the notes of a release of a dependency; nil disables the notes screen
+	ReleaseNotes func(dep dependencies.Dependency, version string) (string, error)
+	// Size returns the width and height of the terminal, zero when unknown
+	Size func() (width, height int)
+
+	in             io.Reader
+	out            io.Writer
+	mode           browserMode
+	filter         string
+	previousFilter string // Restored when editing the filter is canceled
+	sortBy         string
+	visible        []int        // Indexes of the listed entries, in list order
+	selected       map[int]bool // Indexes of the selected entries
+	cursor         int          // Position of the highlighted entry in visible
+	offset         int          // Position of the first entry on screen
+	message        string       // Shown below the status until the next key
+	notes          []string     // Lines of the notes screen
+	notesOffset    int
+}
+
+// NewDependencyBrowser creates a browser of entries reading keys from in and
+// drawing to out.
+func NewDependencyBrowser(entries []BrowserEntry, in io.Reader, out io.Writer) *DependencyBrowser {
+	b := &DependencyBrowser{
+		Entries:  entries,
+		in:       in,
+		out:      out,
+		sortBy:   BrowserSortFile,
+		selected: map[int]bool{},
+	}
+	b.refresh()
+
+	return b
+}
+
+// Run shows the browser on the alternate screen and handles keys until the
+// user applies the selection, returning the selected entries, or quits,
+// returning nil. The end of the input quits.
+func (b *DependencyBrowser) Run() ([]BrowserEntry, error) {
+	_, _ = fmt.Fprint(b.out, browserEnterScreen)
+	defer func() { _, _ = fmt.Fprint(b.out, browserLeaveScreen) }()
+
+	buf := make([]byte, 256)
+	for {
+		b.render()
+		n, err := b.in.Read(buf)
+		for _, key := range parseKeys(buf[:n]) {
+			if selection, done := b.handleKey(key); done {
+				return selection, nil
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keys: %w", err)
+		}
+	}
+}
+
+// parseKeys splits terminal input into key names and printable characters.
+// Unknown control characters and escape sequences are dropped.
+func parseKeys(data []byte) []string {
+	var keys []string
+	for len(data) > 0 {
+		switch {
+		case data[0] == 0x1b && len(data) > 2 && (data[1] == '[' || data[1] == 'O'):
+			end := 2
+			for end < len(data) && (data[end] < 0x40 || data[end] > 0x7e) {
+				end++
+			}
+			if end < len(data) {
+				if key, ok := browserEscapeKeys[string(data[2:end+1])]; ok {
+					keys = append(keys, key)
+				}
+			}
+			data = data[min(end+1, len(data)):]
+
+			continue
+		case data[0] == 0x1b:
+			keys = append(keys, keyEscape)
+		case data[0] == '\r' || data[0] == '\n':
+			keys = append(keys, keyEnter)
+		case data[0] == 0x7f || data[0] == '\b':
+			keys = append(keys, keyBackspace)
+		case data[0] == 0x03:
+			keys = append(keys, keyInterrupt)
+		case data[0] < ' ':
+		default:
+			r, size := utf8.DecodeRune(data)
+			keys = append(keys, string(r))
+			data = data[size:]
+
+			continue
+		}
+		data = data[1:]
+	}
+
+	return keys
+}
+
+// handleKey acts on a key, reporting done with the selection to apply, or
+// with nil to quit.
+func (b *DependencyBrowser) handleKey(key string) ([]BrowserEntry, bool) {
+	if key == keyInterrupt {
+		return nil, true
+	}
+
+	switch b.mode {
+	case browserModeFilter:
+		b.editFilter(key)
+	case browserModeNotes:
+		b.scrollNotes(key)
+	default:
+		return b.listKey(key)
+	}
+
+	return nil, false
+}
+
+// listKey handles a key on the dependency list.
+func (b *DependencyBrowser) listKey(key string) ([]BrowserEntry, bool) {
+	b.message = ""
+	switch key {
+	case keyUp, "k":
+		b.move(-1)
+	case keyDown, "j":
+		b.move(1)
+	case keyPageUp:
+		b.move(-b.listHeight())
+	case keyPageDown:
+		b.move(b.listHeight())
+	case keyHome, "g":
+		b.move(-len(b.visible))
+	case keyEnd, "G":
+		b.move(len(b.visible))
+	case " ":
+		b.toggle()
+	case "*":
+		b.toggleAll()
+	case "/", "f":
+		b.previousFilter = b.filter
+		b.mode = browserModeFilter
+	case "s":
+		next := (slices.Index(browserSortOrders, b.sortBy) + 1) % len(browserSortOrders)
+		b.sortBy = browserSortOrders[next]
+		b.refresh()
+	case "n", keyEnter:
+		b.showNotes()
+	case "a":
+		if selection := b.selection(); len(selection) > 0 {
+			return selection, true
+		}
+		b.message = "Nothing to pin or upgrade is selected, select dependencies with space."
+	case "q", keyEscape:
+		return nil, true
+	}
+
+	return nil, false
+}
+
+// editFilter handles a key while the filter is edited, filtering as the user types.
+func (b *DependencyBrowser) editFilter(key string) {
+	switch {
+	case key == keyEnter:
+		b.mode = browserModeList
+	case key == keyEscape:
+		b.filter = b.previousFilter
+		b.mode = browserModeList
+	case key == keyBackspace:
+		_, size := utf8.DecodeLastRuneInString(b.filter)
+		b.filter = b.filter[:len(b.filter)-size]
+	case utf8.RuneCountInString(key) == 1:
+		b.filter += key
+	default:
+		return
+	}
+	b.refresh()
+}
+
+// scrollNotes handles a key on the notes screen.
+func (b *DependencyBrowser) scrollNotes(key string) {
+	lastOffset := max(0, len(b.notes)-b.notesHeight())
+	switch key {
+	case keyUp, "k":
+		b.notesOffset--
+	case keyDown, "j":
+		b.notesOffset++
+	case keyPageUp:
+		b.notesOffset -= b.notesHeight()
+	case keyPageDown, " ":
+		b.notesOffset += b.notesHeight()
+	case keyHome, "g":
+		b.notesOffset = 0
+	case keyEnd, "G":
+		b.notesOffset = lastOffset
+	case "q", "n", keyEscape, keyEnter:
+		b.mode = browserModeList
+	}
+	b.notesOffset = max(0, min(b.notesOffset, lastOffset))
+}
+
+// refresh recomputes the listed entries from the filter and sort order,
+// keeping the cursor within the list.
+func (b *DependencyBrowser) refresh() {
+	b.visible = b.visible[:0]
+	for i, entry := range b.Entries {
+		if b.matches(entry) {
+			b.visible = append(b.visible, i)
+		}
+	}
+	slices.SortStableFunc(b.visible, func(x, y int) int {
+		ex, ey := b.Entries[x], b.Entries[y]
+		switch b.sortBy {
+		case BrowserSortName:
+			return cmp.Compare(ex.Dependency.Name, ey.Dependency.Name)
+		case BrowserSortUpdate:
+			return cmp.Or(cmp.Compare(updateRank(ex), updateRank(ey)),
+				cmp.Compare(ex.Dependency.Name, ey.Dependency.Name))
+		default:
+			return cmp.Or(cmp.Compare(ex.File, ey.File), cmp.Compare(ex.Dependency.StepNumber, ey.Dependency.StepNumber))
+		}
+	})
+	b.move(0)
+}
+
+// updateRank orders entries by update type, with no update last.
+func updateRank(entry BrowserEntry) int {
+	return updateTypeRank(entry.updateType())
+}
+
+// matches reports whether entry passes the filter.
+func (b *DependencyBrowser) matches(entry BrowserEntry) bool {
+	switch filter := strings.ToLower(b.filter); filter {
+	case "":
+		return true
+	case "pinned":
+		return entry.Dependency.IsPinned
+	case "floating":
+		return !entry.Dependency.IsPinned
+	case "outdated":
+		return entry.Update != nil
+	case "major", "minor", "patch":
+		return entry.updateType() == filter
+	default:
+		return strings.Contains(strings.ToLower(entry.File), filter) ||
+			strings.Contains(strings.ToLower(entry.Dependency.Name), filter)
+	}
+}
+
+// move moves the cursor by delta entries and scrolls it into view.
+func (b *DependencyBrowser) move(delta int) {
+	b.cursor = max(0, min(b.cursor+delta, len(b.visible)-1))
+	height := b.listHeight()
+	switch {
+	case b.cursor < b.offset:
+		b.offset = b.cursor
+	case b.cursor >= b.offset+height:
+		b.offset = b.cursor - height + 1
+	}
+	b.offset = max(0, min(b.offset, len(b.visible)-height))
+}
+
+// current returns the index of the entry under the cursor.
+func (b *DependencyBrowser) current() (int, bool) {
+	if len(b.visible) == 0 {
+		return 0, false
+	}
+
+	return b.visible[b.cursor], true
+}
+
+// toggle selects or deselects the entry under the cursor.
+func (b *DependencyBrowser) toggle() {
+	i, ok := b.current()
+	switch {
+	case !ok:
+	case b.selected[i]:
+		delete(b.selected, i)
+	case !b.Entries[i].Actionable():
+		b.message = b.Entries[i].Dependency.Name + " is pinned and up to date."
+	default:
+		b.selected[i] = true
+	}
+}
+
+// toggleAll selects every listed entry that can be pinned or upgraded, or
+// clears the selection when they are all selected already.
+func (b *DependencyBrowser) toggleAll() {
+	var actionable []int
+	for _, i := range b.visible {
+		if b.Entries[i].Actionable() {
+			actionable = append(actionable, i)
+		}
+	}
+	if len(actionable) > 0 && !slices.ContainsFunc(actionable, func(i int) bool { return !b.selected[i] }) {
+		clear(b.selected)
+
+		return
+	}
+	for _, i := range actionable {
+		b.selected[i] = true
+	}
+}
+
+// showNotes opens the notes screen with the risk hints and release notes of
+// the latest version of the entry under the cursor, or of its current version
+// when it is up to date.
+func (b *DependencyBrowser) showNotes() {
+	i, ok := b.current()
+	if !ok {
+		return
+	}
+	entry := b.Entries[i]
+	if b.ReleaseNotes == nil || entry.Dependency.IsShellScript {
+		b.message = "No release notes available for " + entry.Dependency.Name + "."
+
+		return
+	}
+
+	version := entry.Dependency.Version
+	var lines []string
+	if entry.Update != nil {
+		version = entry.Update.LatestVersion
+		for _, hint := range entry.Update.RiskHints {
+			lines = append(lines, "⚠️  "+hint)
+		}
+	}
+	b.message = fmt.Sprintf("Loading the release notes of %s %s...", entry.Dependency.Name, version)
+	b.render()
+	b.message = ""
+
+	notes, err := b.ReleaseNotes(entry.Dependency, version)
+	switch {
+	case err != nil:
+		b.message = fmt.Sprintf("Could not get the release notes of %s %s: %v", entry.Dependency.Name, version, err)
+
+		return
+	case strings.TrimSpace(notes) == "":
+		lines = append(lines, entry.Dependency.Name+" "+version+" has no release notes.")
+	default:
+		lines = append(lines, "")
+		lines = append(lines, strings.Split(strings.TrimSpace(notes), "\n")...)
+	}
+	b.notes = append([]string{entry.Dependency.Name + " " + version, ""}, lines...)
+	b.notesOffset = 0
+	b.mode = browserModeNotes
+}
+
+// selection returns the selected entries in the order of Entries.
+func (b *DependencyBrowser) selection() []BrowserEntry {
+	var selection []BrowserEntry
+	for i, entry := range b.Entries {
+		if b.selected[i] {
+			selection = append(selection, entry)
+		}
+	}
+
+	return selection
+}
+
+// size returns the terminal size, or the default size when unknown.
+func (b *DependencyBrowser) size() (int, int) {
+	width, height := 0, 0
+	if b.Size != nil {
+		width, height = b.Size()
+	}
+	if width <= 0 || height <= 0 {
+		return browserDefaultWidth, browserDefaultHeight
+	}
+
+	return width, height
+}
+
+// listHeight returns the number of entries that fit on the screen below the
+// table header and above the footer.
+func (b *DependencyBrowser) listHeight() int {
+	_, height := b.size()
+
+	return max(1, height-2-browserFooterLines)
+}
+
+// notesHeight returns the number of notes lines that fit above the key help.
+func (b *DependencyBrowser) notesHeight() int {
+	_, height := b.size()
+
+	return max(1, height-1)
+}
+
+// render redraws the screen of the current mode.
+func (b *DependencyBrowser) render() {
+	width, _ := b.size()
+	b.move(0) // The terminal may have been resized
+	var lines []string
+	if b.mode == browserModeNotes {
+		end := min(len(b.notes), b.notesOffset+b.notesHeight())
+		lines = append(lines, b.notes[b.notesOffset:end]...)
+		for len(lines) < b.notesHeight() {
+			lines = append(lines, "")
+		}
+		lines = append(lines, browserNotesHelp)
+	} else {
+		lines = b.listLines()
+	}
+
+	for i, line := range lines {
+		lines[i] = fitWidth(line, width)
+	}
+	if b.mode != browserModeNotes && len(b.visible) > 0 {
+		row := 2 + b.cursor - b.offset
+		lines[row] = browserHighlight + PadDisplay(lines[row], width, false) + browserResetStyle
+	}
+	_, _ = fmt.Fprint(b.out, browserClearScreen+strings.Join(lines, "\r\n"))
+}
+
+// listLines returns the lines of the list screen: the table of the entries
+// on screen, padded to the list height, and the footer.
+func (b *DependencyBrowser) listLines() []string {
+	table := NewTable("", "", "Dependency", "Version", "Latest", "Update", "File")
+	for _, i := range b.visible {
+		entry := b.Entries[i]
+		mark, latest := " ", "-"
+		if b.selected[i] {
+			mark = "✔"
+		}
+		if entry.Update != nil {
+			latest = entry.Update.LatestVersion
+		}
+		table.AddRow(mark, dependencyIcon(entry.Dependency), entry.Dependency.Name,
+			entry.Dependency.Version, latest, entry.updateType(), b.relative(entry.File))
+	}
+	rows := strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n")
+
+	lines := rows[:2]
+	if len(b.visible) == 0 {
+		lines = append(lines, "No dependencies match the filter.")
+	} else {
+		lines = append(lines, rows[2+b.offset:2+min(len(b.visible), b.offset+b.listHeight())]...)
+	}
+	for len(lines) < 2+b.listHeight() {
+		lines = append(lines, "")
+	}
+
+	status := fmt.Sprintf("%d of %d dependencies, sorted by %s, %d selected",
+		len(b.visible), len(b.Entries), b.sortBy, len(b.selected))
+	if b.filter != "" && b.mode != browserModeFilter {
+		status += ", filter: " + b.filter
+	}
+	switch b.mode {
+	case browserModeFilter:
+		lines = append(lines, status, "Filter: "+b.filter+"▏", browserFilterHelp)
+	default:
+		lines = append(lines, status, b.message, browserListHelp)
+	}
+
+	return lines
+}
+
+// fitWidth cuts line to at most width terminal cells.
+func fitWidth(line string, width int) string {
+	if DisplayWidth(line) <= width {
+		return line
+	}
+
+	var fitted strings.Builder
+	used := 0
+	state := -1
+	rest := line
+	for rest != "" {
+		var cluster string
+		var clusterWidth int
+		cluster, rest, clusterWidth, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if used+clusterWidth > width {
+			break
+		}
+		fitted.WriteString(cluster)
+		used += clusterWidth
+	}
+
+	return fitted.String()
+}
+
+// relative returns path relative to the root of the browser when possible.
+func (b *DependencyBrowser) relative(path string) string {
+	if b.Root == "" {
+		return path
+	}
+	if rel, err := filepath.Rel(b.Root, path); err == nil {
+		return rel
+	}
+
+	return path
+}
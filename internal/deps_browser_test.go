@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func testBrowserEntries() []BrowserEntry {
+	return []BrowserEntry{
+		{
+			File:       "/repo/action.yml",
+			Dependency: dependencies.Dependency{Name: "actions/checkout", Version: "v4", StepNumber: 1},
+			Update:     &dependencies.OutdatedDependency{LatestVersion: "v5.0.0", UpdateType: "major"},
+		},
+		{
+			File: "/repo/action.yml",
+			Dependency: dependencies.Dependency{
+				Name: "actions/cache", Version: "v4.2.0", IsPinned: true, StepNumber: 2,
+			},
+		},
+		{
+			File:       "/repo/build/action.yml",
+			Dependency: dependencies.Dependency{Name: "actions/setup-node", Version: "v4.0.0", StepNumber: 1},
+			Update:     &dependencies.OutdatedDependency{LatestVersion: "v4.1.0", UpdateType: "minor"},
+		},
+	}
+}
+
+// keyDownSequence is the escape sequence of the down arrow key.
+const keyDownSequence = "\x1b[B"
+
+// keyReader returns one chunk of keys per read, like a terminal in raw mode.
+type keyReader struct {
+	chunks []string
+}
+
+// Read implements io.Reader.
+func (r *keyReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.chunks[0])
+	r.chunks = r.chunks[1:]
+
+	return n, nil
+}
+
+// typed returns a reader of keys, one per read.
+func typed(keys ...string) *keyReader {
+	return &keyReader{chunks: keys}
+}
+
+func TestDependencyBrowser_SelectAndApply(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	// Sort by update twice (file, name, update), then select the second and third entries
+	keys := typed("s", "s", keyDownSequence, " ", keyDownSequence, " ", "a")
+	browser := NewDependencyBrowser(testBrowserEntries(), keys, &out)
+	browser.Root = "/repo"
+
+	selection, err := browser.Run()
+	testutil.AssertNoError(t, err)
+	// Sorted by update the list is checkout, setup-node, cache; cache is pinned and up to date
+	testutil.AssertEqual(t, 1, len(selection))
+	testutil.AssertEqual(t, "actions/setup-node", selection[0].Dependency.Name)
+	testutil.AssertStringContains(t, out.String(), "actions/cache is pinned and up to date")
+	testutil.AssertStringContains(t, out.String(), "build/action.yml")
+	testutil.AssertStringContains(t, out.String(), browserEnterScreen)
+	testutil.AssertEqual(t, true, strings.HasSuffix(out.String(), browserLeaveScreen))
+}
+
+func TestDependencyBrowser_FilterAndNotes(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	// Filter outdated and select all, then filter checkout, read its notes and quit
+	keys := typed("/", "outdated", "\r", "*", "/", strings.Repeat("\x7f", 8), "checkout", "\r", "n", "q", "q")
+	browser := NewDependencyBrowser(testBrowserEntries(), keys, &out)
+	var asked string
+	browser.ReleaseNotes = func(dep dependencies.Dependency, version string) (string, error) {
+		asked = dep.Name + "@" + version
+
+		return "Node 24 by default", nil
+	}
+
+	selection, err := browser.Run()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(selection))
+	testutil.AssertEqual(t, "actions/checkout@v5.0.0", asked)
+	testutil.AssertStringContains(t, out.String(), "Node 24 by default")
+	testutil.AssertStringContains(t, out.String(), "2 of 3 dependencies, sorted by file, 2 selected, filter: outdated")
+	testutil.AssertStringContains(t, out.String(), "1 of 3 dependencies, sorted by file, 2 selected, filter: checkout")
+}
+
+func TestDependencyBrowser_EndOfInputQuits(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	browser := NewDependencyBrowser(testBrowserEntries(), typed("a"), &out)
+
+	selection, err := browser.Run()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(selection))
+	testutil.AssertStringContains(t, out.String(), "Nothing to pin or upgrade is selected")
+}
+
+func TestDependencyBrowser_ScrollsWithinTerminalHeight(t *testing.T) {
+	t.Parallel()
+
+	entries := make([]BrowserEntry, 0, 20)
+	for i := range 20 {
+		entries = append(entries, BrowserEntry{
+			File:       "/repo/action.yml",
+			Dependency: dependencies.Dependency{Name: fmt.Sprintf("acme/step-%02d", i), Version: "v1", StepNumber: i},
+		})
+	}
+	var out bytes.Buffer
+	// Move to the last entry and select it on a 40x10 terminal
+	browser := NewDependencyBrowser(entries, typed("G", " ", "a"), &out)
+	browser.Size = func() (int, int) { return 40, 10 }
+
+	selection, err := browser.Run()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(selection))
+	testutil.AssertEqual(t, "acme/step-19", selection[0].Dependency.Name)
+
+	frames := strings.Split(out.String(), browserClearScreen)
+	last := strings.Split(strings.TrimSuffix(frames[len(frames)-1], browserLeaveScreen), "\r\n")
+	testutil.AssertEqual(t, 10, len(last))
+	for _, line := range last {
+		if DisplayWidth(line) > 40 {
+			t.Errorf("line wider than the terminal: %q", line)
+		}
+	}
+	testutil.AssertStringContains(t, last[6], browserHighlight)
+	testutil.AssertStringContains(t, last[6], "acme/step-19")
+}
+
+func TestParseKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := parseKeys([]byte("j\x1b[A\x1bOB\x1b[5~\x1b[6~\x1b\r\x7f\x03ä\x01\x1b[99X"))
+	testutil.AssertEqual(t, "j,up,down,pgup,pgdown,esc,enter,backspace,ctrl-c,ä", strings.Join(keys, ","))
+}
@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+)
+
+// Values of deps.consolidate besides a version to consolidate on.
+const (
+	// ConsolidateHighest consolidates on the highest version in use, the default.
+	ConsolidateHighest = "highest"
+	// ConsolidateOff leaves the versions of a dependency alone.
+	ConsolidateOff = "off"
+)
+
+// Consolidation is an action used at several versions, for deps consolidate.
+type Consolidation struct {
+	Dependency string          `json:"dependency"`
+	Versions   []string        `json:"versions"` // Distinct versions in use, sorted
+	Target     string          `json:"target,omitempty"`
+	Configured bool            `json:"configured"` // Target is set in deps.consolidate
+	Uses       []DependencyUse `json:"uses"`
+}
+
+// UpdateTypeFunc classifies the update from current to latest, such as
+// Analyzer.UpdateType.
+type UpdateTypeFunc func(current, latest string) string
+
+// ValidateConsolidateTargets rejects deps.consolidate entries that do not name
+// an owner/repo action or have no version.
+func ValidateConsolidateTargets(targets map[string]string) error {
+	for name, target := range targets {
+		if owner, repo, ok := strings.Cut(name, "/"); !ok || owner == "" || repo == "" {
+			return fmt.Errorf("invalid deps.consolidate key '%s', must be an owner/repo action", name)
+		}
+		if strings.TrimSpace(target) == "" {
+			return fmt.Errorf("invalid deps.consolidate.%s, must be a version, %s or %s",
+				name, ConsolidateHighest, ConsolidateOff)
+		}
+	}
+
+	return nil
+}
+
+// FindConsolidations returns the actions of files used at more than one
+// version, with the version to consolidate on: the one set in targets, keyed
+// by owner/repo, or else the highest semantic version in use according to
+// classify. Target is empty when no version can be chosen, such as when every
+// use is pinned to a commit SHA.
+func FindConsolidations(
+	files []FileDependencies,
+	targets map[string]string,
+	classify UpdateTypeFunc,
+) []Consolidation {
+	configured := make(map[string]string, len(targets))
+	for name, target := range targets {
+		configured[strings.ToLower(name)] = target
+	}
+
+	byName := map[string]*Consolidation{}
+	var names []string
+	for _, file := range files {
+		for _, dep := range file.Dependencies {
+			if dep.IsShellScript || dep.Docker != nil || dep.Uses == "" {
+				continue
+			}
+			key := strings.ToLower(dep.Name)
+			consolidation, ok := byName[key]
+			if !ok {
+				consolidation = &Consolidation{Dependency: dep.Name}
+				byName[key] = consolidation
+				names = append(names, key)
+			}
+			consolidation.Uses = append(consolidation.Uses, DependencyUse{
+				File: file.File, Step: dependencyStep(dep), Line: dep.Line,
+				Uses: dep.Uses, Version: dep.Version, Pinned: dep.IsPinned, VersionType: dep.VersionType,
+			})
+			if !slices.Contains(consolidation.Versions, dep.Version) {
+				consolidation.Versions = append(consolidation.Versions, dep.Version)
+			}
+		}
+	}
+
+	var consolidations []Consolidation
+	slices.Sort(names)
+	for _, name := range names {
+		consolidation := byName[name]
+		target := cmp.Or(configured[name], ConsolidateHighest)
+		if len(consolidation.Versions) < 2 || target == ConsolidateOff {
+			continue
+		}
+		slices.Sort(consolidation.Versions)
+		if target == ConsolidateHighest {
+			consolidation.Target = highestVersion(consolidation.Uses, classify)
+		} else {
+			consolidation.Target, consolidation.Configured = target, true
+		}
+		consolidations = append(consolidations, *consolidation)
+	}
+
+	return consolidations
+}
+
+// highestVersion returns the highest semantic version used by uses, or an
+// empty string when there is none.
+func highestVersion(uses []DependencyUse, classify UpdateTypeFunc) string {
+	highest := ""
+	for _, use := range uses {
+		if use.VersionType != dependencies.SemanticVersion {
+			continue
+		}
+		if highest == "" || isUpgrade(classify(highest, use.Version)) {
+			highest = use.Version
+		}
+	}
+
+	return highest
+}
+
+// isUpgrade reports whether updateType is a move to a newer version.
+func isUpgrade(updateType string) bool {
+	return updateType != "" && updateType != "none" && updateType != "unknown"
+}
+
+// ConsolidationUpdates returns the updates that move every use of the
+// consolidations to their target. resolve returns the commit SHA to pin the
+// target of a dependency to, or an empty string to reference the target as
+// is; uses pinned to a commit SHA are left alone when the target has none.
+func ConsolidationUpdates(
+	consolidations []Consolidation,
+	resolve func(dep dependencies.Dependency) string,
+	classify UpdateTypeFunc,
+) []dependencies.PinnedUpdate {
+	var updates []dependencies.PinnedUpdate
+	for _, consolidation := range consolidations {
+		if consolidation.Target == "" {
+			continue
+		}
+		target := consolidation.Dependency + "@" + consolidation.Target
+		newUses, sha := target, resolve(dependencies.Dependency{Name: consolidation.Dependency, Uses: target})
+		if sha != "" && sha != consolidation.Target {
+			newUses = fmt.Sprintf("%s@%s # %s", consolidation.Dependency, sha, consolidation.Target)
+		}
+
+		for _, use := range consolidation.Uses {
+			pinnedToSHA := use.VersionType == dependencies.CommitSHA
+			if use.Version == consolidation.Target || use.Version == sha || (pinnedToSHA && sha == "") {
+				continue
+			}
+			updates = append(updates, dependencies.PinnedUpdate{
+				FilePath:   use.File,
+				OldUses:    use.Uses,
+				NewUses:    newUses,
+				CommitSHA:  sha,
+				Version:    consolidation.Target,
+				UpdateType: classify(use.Version, consolidation.Target),
+				LineNumber: use.Line,
+			})
+		}
+	}
+
+	return updates
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// testUpdateType classifies updates like the dependency analyzer.
+func testUpdateType(current, latest string) string {
+	return (&dependencies.Analyzer{}).UpdateType(current, latest)
+}
+
+func TestFindConsolidations(t *testing.T) {
+	t.Parallel()
+
+	action := func(name, version string, versionType dependencies.VersionType, line int) dependencies.Dependency {
+		return dependencies.Dependency{
+			Name: name, Uses: name + "@" + version, Version: version, VersionType: versionType, Line: line,
+		}
+	}
+	sha := "8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e"
+	files := []FileDependencies{
+		{File: "/repo/a/action.yml", Dependencies: []dependencies.Dependency{
+			action("actions/checkout", "v4.1.1", dependencies.SemanticVersion, 6),
+			action("actions/setup-go", "v5", dependencies.SemanticVersion, 8),
+			action("org/tool", sha, dependencies.CommitSHA, 10),
+			{Name: "Shell Script #4", IsShellScript: true},
+		}},
+		{File: "/repo/b/action.yml", Dependencies: []dependencies.Dependency{
+			action("actions/checkout", "v4.10.0", dependencies.SemanticVersion, 6),
+			action("actions/checkout", "v3", dependencies.SemanticVersion, 9),
+			action("actions/setup-go", "v4", dependencies.SemanticVersion, 12),
+			action("org/tool", "v1", dependencies.SemanticVersion, 14),
+			action("org/other", sha, dependencies.CommitSHA, 16),
+			action("org/other", "main", dependencies.BranchName, 18),
+		}},
+	}
+
+	consolidations := FindConsolidations(files, map[string]string{
+		"actions/setup-go": ConsolidateOff,
+		"Org/Tool":         "v1.2.0",
+	}, testUpdateType)
+	testutil.AssertEqual(t, 3, len(consolidations))
+
+	checkout := consolidations[0]
+	testutil.AssertEqual(t, "actions/checkout", checkout.Dependency)
+	testutil.AssertEqual(t, "v3,v4.1.1,v4.10.0", strings.Join(checkout.Versions, ","))
+	testutil.AssertEqual(t, "v4.10.0", checkout.Target)
+	testutil.AssertEqual(t, false, checkout.Configured)
+
+	other := consolidations[1]
+	testutil.AssertEqual(t, "org/other", other.Dependency)
+	testutil.AssertEqual(t, "", other.Target)
+
+	tool := consolidations[2]
+	testutil.AssertEqual(t, "v1.2.0", tool.Target)
+	testutil.AssertEqual(t, true, tool.Configured)
+
+	// Without commit SHAs the targets are referenced as tags and SHA pins are kept
+	updates := ConsolidationUpdates(consolidations, func(dependencies.Dependency) string { return "" }, testUpdateType)
+	var planned []string
+	for _, update := range updates {
+		planned = append(planned, update.OldUses+"->"+update.NewUses+":"+update.UpdateType)
+	}
+	testutil.AssertEqual(t,
+		"actions/checkout@v4.1.1->actions/checkout@v4.10.0:minor,"+
+			"actions/checkout@v3->actions/checkout@v4.10.0:major,"+
+			"org/tool@v1->org/tool@v1.2.0:patch", // A floating major tag moves within it
+		strings.Join(planned, ","),
+	)
+	testutil.AssertEqual(t, 9, updates[1].LineNumber)
+	testutil.AssertEqual(t, "/repo/b/action.yml", updates[1].FilePath)
+
+	pinned := ConsolidationUpdates(consolidations[2:], func(dependencies.Dependency) string { return sha }, testUpdateType)
+	testutil.AssertEqual(t, 1, len(pinned))
+	testutil.AssertEqual(t, "org/tool@"+sha+" # v1.2.0", pinned[0].NewUses)
+}
+
+func TestValidateConsolidateTargets(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, ValidateConsolidateTargets(map[string]string{"actions/checkout": "v4"}))
+	testutil.AssertError(t, ValidateConsolidateTargets(map[string]string{"checkout": "v4"}))
+	testutil.AssertError(t, ValidateConsolidateTargets(map[string]string{"actions/checkout": " "}))
+	testutil.AssertError(t, ValidateDepsPolicy(DepsPolicy{Consolidate: map[string]string{"actions/": "v4"}}))
+}
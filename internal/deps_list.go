@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+)
+
+// DependencyFilter selects the dependencies listed by deps list.
+type DependencyFilter struct {
+	PinnedOnly   bool // Only actions pinned to a commit SHA or full version
+	FloatingOnly bool // Only actions with a floating version such as v4 or main
+	ExternalOnly bool // Only actions from other repositories, without shell steps
+}
+
+// Validate rejects filters that cannot match any dependency.
+func (f DependencyFilter) Validate() error {
+	if f.PinnedOnly && f.FloatingOnly {
+		return errors.New("--pinned-only and --floating-only cannot be combined")
+	}
+
+	return nil
+}
+
+// Match reports whether dep is selected by the filter. Shell steps are not
+// pinned or floating, so they only match without filters.
+func (f DependencyFilter) Match(dep dependencies.Dependency) bool {
+	if dep.IsShellScript && (f.PinnedOnly || f.FloatingOnly || f.ExternalOnly) {
+		return false
+	}
+	switch {
+	case f.PinnedOnly && !dep.IsPinned, f.FloatingOnly && dep.IsPinned:
+		return false
+	case f.ExternalOnly && dep.IsLocalAction:
+		return false
+	default:
+		return true
+	}
+}
+
+// Apply returns the dependencies selected by the filter.
+func (f DependencyFilter) Apply(deps []dependencies.Dependency) []dependencies.Dependency {
+	var selected []dependencies.Dependency
+	for _, dep := range deps {
+		if f.Match(dep) {
+			selected = append(selected, dep)
+		}
+	}
+
+	return selected
+}
+
+// FileDependencies holds the dependencies of an action file for deps list.
+type FileDependencies struct {
+	File         string
+	Dependencies []dependencies.Dependency // Dependencies selected by the filter
+	Total        int                       // Dependencies before filtering
+	Analyzed     bool                      // False when the file could not be analyzed
+}
+
+// EmptyNote explains why the file lists no dependencies, or returns an empty
+// string when it has some.
+func (file FileDependencies) EmptyNote() string {
+	switch {
+	case len(file.Dependencies) > 0:
+		return ""
+	case !file.Analyzed:
+		return "(not analyzed, see warnings)"
+	case file.Total > 0:
+		return "(no matching dependencies)"
+	default:
+		return "(no dependencies)"
+	}
+}
+
+// DependencyTree renders the dependencies of the files as a tree grouped by
+// file and step:
+//
+//	📄 action.yml
+//	└── 1. Checkout
+//	    └── 🔒 actions/checkout@v4.1.1
+func DependencyTree(files []FileDependencies) string {
+	var b strings.Builder
+	for _, file := range files {
+		fmt.Fprintf(&b, "📄 %s\n", file.File)
+		if note := file.EmptyNote(); note != "" {
+			b.WriteString("└── " + note + "\n")
+		}
+		for i, dep := range file.Dependencies {
+			branch, indent := "├── ", "│   "
+			if i == len(file.Dependencies)-1 {
+				branch, indent = "└── ", "    "
+			}
+			fmt.Fprintf(&b, "%s%s\n%s└── %s %s\n", branch, dependencyStep(dep), indent,
+				dependencyIcon(dep), dependencyRef(dep))
+		}
+	}
+
+	return b.String()
+}
+
+// DependencyWideTable lists deps with their versions, the commit SHAs returned
+// by resolve and their descriptions.
+func DependencyWideTable(deps []dependencies.Dependency, resolve func(dependencies.Dependency) string) *Table {
+	table := NewTable("Step", "", "Dependency", "Version", "Resolved SHA", "Description")
+	for _, dep := range deps {
+		name, version := dep.Name, dep.Version
+		if dep.IsShellScript {
+			name, version = "shell script", "-"
+		}
+		table.AddRow(dependencyStep(dep), dependencyIcon(dep), name, version,
+			orDash(resolve(dep)), orDash(dep.Description))
+	}
+
+	return table
+}
+
+// dependencyStep labels the step of dep with its position and name.
+func dependencyStep(dep dependencies.Dependency) string {
+	if dep.StepName == "" {
+		return strconv.Itoa(dep.StepNumber) + ". (unnamed step)"
+	}
+
+	return strconv.Itoa(dep.StepNumber) + ". " + dep.StepName
+}
+
+// dependencyIcon marks shell steps, pinned and floating actions.
+func dependencyIcon(dep dependencies.Dependency) string {
+	switch {
+	case dep.IsShellScript:
+		return "🐚"
+	case dep.IsPinned:
+		return "🔒"
+	default:
+		return "📌"
+	}
+}
+
+// dependencyRef returns the reference of dep as written in the step.
+func dependencyRef(dep dependencies.Dependency) string {
+	if dep.IsShellScript {
+		return "shell script"
+	}
+
+	return dep.Name + "@" + dep.Version
+}
+
+// orDash returns value, or a dash for empty table cells.
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
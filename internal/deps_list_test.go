@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func testListedDependencies() []dependencies.Dependency {
+	return []dependencies.Dependency{
+		{Name: "actions/checkout", Version: "v4", StepNumber: 1, StepName: "Checkout"},
+		{Name: "org/repo", Version: "v1.2.3", IsPinned: true, IsLocalAction: true, StepNumber: 2},
+		{Name: "Build", IsPinned: true, IsLocalAction: true, IsShellScript: true, StepNumber: 3, StepName: "Build"},
+	}
+}
+
+func TestDependencyFilter(t *testing.T) {
+	t.Parallel()
+
+	names := func(deps []dependencies.Dependency) string {
+		var list []string
+		for _, dep := range deps {
+			list = append(list, dep.Name)
+		}
+
+		return strings.Join(list, ",")
+	}
+	deps := testListedDependencies()
+
+	testutil.AssertEqual(t, "actions/checkout,org/repo,Build", names(DependencyFilter{}.Apply(deps)))
+	testutil.AssertEqual(t, "org/repo", names(DependencyFilter{PinnedOnly: true}.Apply(deps)))
+	testutil.AssertEqual(t, "actions/checkout", names(DependencyFilter{FloatingOnly: true}.Apply(deps)))
+	testutil.AssertEqual(t, "actions/checkout", names(DependencyFilter{ExternalOnly: true}.Apply(deps)))
+	testutil.AssertError(t, DependencyFilter{PinnedOnly: true, FloatingOnly: true}.Validate())
+}
+
+func TestDependencyTree(t *testing.T) {
+	t.Parallel()
+
+	tree := DependencyTree([]FileDependencies{
+		{File: "action.yml", Dependencies: testListedDependencies(), Total: 3, Analyzed: true},
+		{File: "filtered/action.yml", Total: 2, Analyzed: true},
+		{File: "broken/action.yml"},
+	})
+
+	want := `📄 action.yml
+├── 1. Checkout
+│   └── 📌 actions/checkout@v4
+├── 2. (unnamed step)
+│   └── 🔒 org/repo@v1.2.3
+└── 3. Build
+    └── 🐚 shell script
+📄 filtered/action.yml
+└── (no matching dependencies)
+📄 broken/action.yml
+└── (not analyzed, see warnings)
+`
+	testutil.AssertEqual(t, want, tree)
+}
+
+func TestDependencyWideTable(t *testing.T) {
+	t.Parallel()
+
+	resolve := func(dep dependencies.Dependency) string {
+		if dep.Name == "actions/checkout" {
+			return "8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e"
+		}
+
+		return ""
+	}
+	table := DependencyWideTable(testListedDependencies(), resolve).String()
+	testutil.AssertStringContains(t, table, "Resolved SHA")
+	testutil.AssertStringContains(t, table, "8f4b7f84bd579b95d7f0b90f8d8b6e5d9b8a7f6e")
+	testutil.AssertStringContains(t, table, "shell script")
+}
@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+)
+
+// Dependency policy rules for deps.fail_on.
+const (
+	// FailOnFloating fails deps security when a dependency is not pinned.
+	FailOnFloating = "floating"
+	// FailOnOutdated fails deps outdated when any dependency has an update.
+	FailOnOutdated = "outdated"
+	// FailOnMajor fails deps outdated when a dependency has a major update.
+	FailOnMajor = "major"
+	// FailOnLatest fails deps security when a docker:// image uses the latest tag.
+	FailOnLatest = "latest"
+)
+
+// ErrDepsPolicy is returned when dependencies violate a deps.fail_on rule.
+var ErrDepsPolicy = errors.New("dependency policy violated")
+
+// pinStrategies are the valid values of deps.pin_strategy.
+var pinStrategies = []string{dependencies.PinStrategySHA, dependencies.PinStrategyTag}
+
+// failOnRules are the valid entries of deps.fail_on.
+var failOnRules = []string{FailOnFloating, FailOnOutdated, FailOnMajor, FailOnLatest}
+
+// DepsPolicy is the default behavior of the deps commands.
+type DepsPolicy struct {
+	// PinStrategy is how deps upgrade and deps pin pin dependencies: "sha" (default) or "tag"
+	PinStrategy string `mapstructure:"pin_strategy" yaml:"pin_strategy,omitempty"`
+	// FailOn lists the rules that make deps security and deps outdated exit with an error
+	FailOn []string `mapstructure:"fail_on" yaml:"fail_on,omitempty"`
+	// Registries are credentials for the registries of docker:// images, keyed by host
+	Registries map[string]dependencies.RegistryCredentials `mapstructure:"registries" yaml:"registries,omitempty"`
+	// Consolidate sets the version deps consolidate moves an action to, keyed by
+	// owner/repo: a version, "highest" (the default) or "off"
+	Consolidate map[string]string `mapstructure:"consolidate" yaml:"consolidate,omitempty"`
+	// Allowlist is the registry file of approved actions and versions, a path
+	// relative to the repository root or an https URL
+	Allowlist string `mapstructure:"allowlist" yaml:"allowlist,omitempty"`
+}
+
+// ghcrRegistry is the GitHub Container Registry, which accepts the GitHub token.
+const ghcrRegistry = "ghcr.io"
+
+// NewRegistryClient creates an OCI registry client with the credentials under
+// deps.registries. The GitHub token authenticates to GHCR unless
+// deps.registries configures it.
+func NewRegistryClient(config *AppConfig) *dependencies.RegistryClient {
+	credentials := maps.Clone(config.Deps.Registries)
+	if _, ok := credentials[ghcrRegistry]; !ok && config.GitHubToken != "" {
+		if credentials == nil {
+			credentials = map[string]dependencies.RegistryCredentials{}
+		}
+		credentials[ghcrRegistry] = dependencies.RegistryCredentials{Username: toolName, Token: config.GitHubToken}
+	}
+
+	return dependencies.NewRegistryClient(credentials)
+}
+
+// ValidateDepsPolicy rejects unknown pin strategies and fail_on rules,
+// invalid consolidation targets and allow-list URLs.
+func ValidateDepsPolicy(policy DepsPolicy) error {
+	if policy.PinStrategy != "" && !containsString(pinStrategies, policy.PinStrategy) {
+		return fmt.Errorf("invalid deps.pin_strategy '%s', must be one of: %s",
+			policy.PinStrategy, strings.Join(pinStrategies, ", "))
+	}
+	for _, rule := range policy.FailOn {
+		if !containsString(failOnRules, rule) {
+			return fmt.Errorf("invalid deps.fail_on rule '%s', must be one of: %s",
+				rule, strings.Join(failOnRules, ", "))
+		}
+	}
+
+	if err := ValidateConsolidateTargets(policy.Consolidate); err != nil {
+		return err
+	}
+
+	return ValidateAllowlistSource(policy.Allowlist)
+}
+
+// CheckSecurity returns ErrDepsPolicy when floating dependencies are not allowed.
+func (p DepsPolicy) CheckSecurity(floating int) error {
+	if floating > 0 && containsString(p.FailOn, FailOnFloating) {
+		return fmt.Errorf("%w: %d floating dependencies (deps.fail_on: %s)", ErrDepsPolicy, floating, FailOnFloating)
+	}
+
+	return nil
+}
+
+// CheckLatest returns ErrDepsPolicy when docker:// images on the latest tag are not allowed.
+func (p DepsPolicy) CheckLatest(deps []dependencies.Dependency) error {
+	latest := 0
+	for _, dep := range deps {
+		if dep.Docker != nil && dep.Docker.IsLatest() {
+			latest++
+		}
+	}
+	if latest > 0 && containsString(p.FailOn, FailOnLatest) {
+		return fmt.Errorf("%w: %d docker images on :latest (deps.fail_on: %s)", ErrDepsPolicy, latest, FailOnLatest)
+	}
+
+	return nil
+}
+
+// CheckOutdated returns ErrDepsPolicy when outdated holds updates the policy fails on.
+// Dependencies reported only for their age do not count.
+func (p DepsPolicy) CheckOutdated(outdated []dependencies.OutdatedDependency) error {
+	updates, major := 0, 0
+	for _, dep := range outdated {
+		switch dep.UpdateType {
+		case "", "none":
+			continue
+		case "major":
+			major++
+		}
+		updates++
+	}
+
+	if major > 0 && containsString(p.FailOn, FailOnMajor) {
+		return fmt.Errorf("%w: %d major updates (deps.fail_on: %s)", ErrDepsPolicy, major, FailOnMajor)
+	}
+	if updates > 0 && containsString(p.FailOn, FailOnOutdated) {
+		return fmt.Errorf("%w: %d outdated dependencies (deps.fail_on: %s)", ErrDepsPolicy, updates, FailOnOutdated)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestValidateDepsPolicy(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, ValidateDepsPolicy(DepsPolicy{}))
+	testutil.AssertNoError(t, ValidateDepsPolicy(DepsPolicy{
+		PinStrategy: dependencies.PinStrategyTag,
+		FailOn:      []string{FailOnFloating, FailOnOutdated, FailOnMajor},
+	}))
+
+	err := ValidateDepsPolicy(DepsPolicy{PinStrategy: "branch"})
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "deps.pin_strategy")
+
+	err = ValidateDepsPolicy(DepsPolicy{FailOn: []string{"stale"}})
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "deps.fail_on")
+
+	testutil.AssertNoError(t, ValidateDepsPolicy(DepsPolicy{Allowlist: ".github/approved-actions.yml"}))
+	err = ValidateDepsPolicy(DepsPolicy{Allowlist: "http://example.com/approved.yml"})
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "deps.allowlist")
+}
+
+func TestDepsPolicy_CheckSecurity(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, DepsPolicy{}.CheckSecurity(3))
+	policy := DepsPolicy{FailOn: []string{FailOnFloating}}
+	testutil.AssertNoError(t, policy.CheckSecurity(0))
+	if err := policy.CheckSecurity(2); !errors.Is(err, ErrDepsPolicy) {
+		t.Errorf("expected ErrDepsPolicy, got %v", err)
+	}
+}
+
+func TestDepsPolicy_CheckLatest(t *testing.T) {
+	t.Parallel()
+
+	latest := dependencies.Dependency{Docker: &dependencies.DockerImage{Tag: "latest"}}
+	tagged := dependencies.Dependency{Docker: &dependencies.DockerImage{Tag: "3.20"}}
+	policy := DepsPolicy{FailOn: []string{FailOnLatest}}
+
+	testutil.AssertNoError(t, DepsPolicy{}.CheckLatest([]dependencies.Dependency{latest}))
+	testutil.AssertNoError(t, policy.CheckLatest([]dependencies.Dependency{tagged, {Name: "actions/checkout"}}))
+	if err := policy.CheckLatest([]dependencies.Dependency{tagged, latest}); !errors.Is(err, ErrDepsPolicy) {
+		t.Errorf("expected ErrDepsPolicy, got %v", err)
+	}
+}
+
+func TestDepsPolicy_CheckOutdated(t *testing.T) {
+	t.Parallel()
+
+	minor := dependencies.OutdatedDependency{UpdateType: "minor"}
+	major := dependencies.OutdatedDependency{UpdateType: "major"}
+	staleOnly := dependencies.OutdatedDependency{UpdateType: "none", IsStale: true}
+
+	tests := []struct {
+		name     string
+		failOn   []string
+		outdated []dependencies.OutdatedDependency
+		wantErr  bool
+	}{
+		{"no rules", nil, []dependencies.OutdatedDependency{major}, false},
+		{"outdated", []string{FailOnOutdated}, []dependencies.OutdatedDependency{minor}, true},
+		{"stale pins are not updates", []string{FailOnOutdated}, []dependencies.OutdatedDependency{staleOnly}, false},
+		{"major without major updates", []string{FailOnMajor}, []dependencies.OutdatedDependency{minor}, false},
+		{"major", []string{FailOnMajor}, []dependencies.OutdatedDependency{minor, major}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := DepsPolicy{FailOn: tt.failOn}.CheckOutdated(tt.outdated)
+			if tt.wantErr != errors.Is(err, ErrDepsPolicy) {
+				t.Errorf("CheckOutdated() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
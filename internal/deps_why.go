@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+)
+
+// DefaultWhyDepth is how many levels of composite actions deps why
+// --transitive follows by default.
+const DefaultWhyDepth = 3
+
+// DependencyUse is a step that pulls in the dependency explained by deps why.
+type DependencyUse struct {
+	File    string `json:"file"`
+	Step    string `json:"step"`
+	Line    int    `json:"line,omitempty"`
+	Uses    string `json:"uses"`
+	Version string `json:"version"`
+	Pinned  bool   `json:"pinned"`
+	// VersionType is semantic, commit or branch
+	VersionType dependencies.VersionType `json:"version_type"`
+	// Via lists the composite actions the step of File reaches the dependency
+	// through, outermost first; empty for direct dependencies
+	Via []string `json:"via,omitempty"`
+}
+
+// DependencyExplanation lists where a dependency is used, for deps why.
+type DependencyExplanation struct {
+	Dependency string          `json:"dependency"`
+	Uses       []DependencyUse `json:"uses"`
+	Versions   []string        `json:"versions"` // Distinct versions in use, sorted
+	// Unresolved lists the actions whose own dependencies could not be read
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+// ActionDependencyFunc returns the dependencies of the action that dep
+// references, such as Analyzer.ActionDependencies.
+type ActionDependencyFunc func(dep dependencies.Dependency) ([]dependencies.Dependency, error)
+
+// ExplainDependency finds the steps of files that use name, an owner/repo
+// action or a docker:// image. With expand, the dependencies of composite
+// actions are followed up to depth levels, at most limits.max_transitive_depth,
+// to find indirect uses.
+func ExplainDependency(
+	name string,
+	files []FileDependencies,
+	expand ActionDependencyFunc,
+	depth int,
+) *DependencyExplanation {
+	explanation := &DependencyExplanation{Dependency: name, Uses: []DependencyUse{}, Versions: []string{}}
+	why := dependencyWalker{name: name, expand: expand, explanation: explanation}
+	depth = min(depth, CurrentResourceLimits().MaxTransitiveDepth)
+	for _, file := range files {
+		for _, dep := range file.Dependencies {
+			why.visit(file.File, dependencyStep(dep), dep, nil, depth)
+		}
+	}
+
+	slices.SortStableFunc(explanation.Uses, func(a, b DependencyUse) int {
+		return cmp.Compare(len(a.Via), len(b.Via))
+	})
+	for _, use := range explanation.Uses {
+		if !slices.Contains(explanation.Versions, use.Version) {
+			explanation.Versions = append(explanation.Versions, use.Version)
+		}
+	}
+	slices.Sort(explanation.Versions)
+
+	return explanation
+}
+
+// dependencyWalker follows the dependencies of the steps of action files.
+type dependencyWalker struct {
+	name        string
+	expand      ActionDependencyFunc
+	explanation *DependencyExplanation
+}
+
+// visit records dep when it is the explained dependency and otherwise
+// follows its own dependencies while depth remains.
+func (w *dependencyWalker) visit(file, step string, dep dependencies.Dependency, via []string, depth int) {
+	if dep.IsShellScript {
+		return
+	}
+	if matchesDependency(dep, w.name) {
+		use := DependencyUse{
+			File: file, Step: step, Uses: dep.Uses, Version: dep.Version, Pinned: dep.IsPinned,
+			VersionType: dep.VersionType, Via: via,
+		}
+		if len(via) == 0 {
+			use.Line = dep.Line
+		}
+		w.explanation.Uses = append(w.explanation.Uses, use)
+
+		return
+	}
+	if w.expand == nil || depth <= 0 || dep.Docker != nil || slices.Contains(via, dep.Uses) {
+		return
+	}
+
+	children, err := w.expand(dep)
+	if err != nil {
+		if !slices.Contains(w.explanation.Unresolved, dep.Uses) {
+			w.explanation.Unresolved = append(w.explanation.Unresolved, dep.Uses)
+		}
+
+		return
+	}
+	chain := append(slices.Clone(via), dep.Uses)
+	for _, child := range children {
+		w.visit(file, step, child, chain, depth-1)
+	}
+}
+
+// matchesDependency reports whether dep is the action or image name, or one
+// of the sub-path actions of the name repository.
+func matchesDependency(dep dependencies.Dependency, name string) bool {
+	depName, name := strings.ToLower(dep.Name), strings.ToLower(strings.TrimSuffix(name, "/"))
+
+	return depName == name || strings.HasPrefix(depName, name+"/")
+}
@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestExplainDependency(t *testing.T) {
+	t.Parallel()
+
+	checkout := func(version string, line int) dependencies.Dependency {
+		return dependencies.Dependency{
+			Name: "actions/checkout", Uses: "actions/checkout@" + version, Version: version, Line: line,
+		}
+	}
+	setup := dependencies.Dependency{Name: "org/tools/setup", Uses: "org/tools/setup@v1", StepNumber: 2}
+	broken := dependencies.Dependency{Name: "org/broken", Uses: "org/broken@v1", StepNumber: 3}
+	files := []FileDependencies{
+		{File: "build/action.yml", Dependencies: []dependencies.Dependency{setup, broken}},
+		{File: "action.yml", Dependencies: []dependencies.Dependency{checkout("v4", 7)}},
+	}
+	expand := func(dep dependencies.Dependency) ([]dependencies.Dependency, error) {
+		switch dep.Uses {
+		case setup.Uses:
+			return []dependencies.Dependency{checkout("v3", 6), setup}, nil // setup uses itself: a cycle
+		case broken.Uses:
+			return nil, errors.New("not found")
+		default:
+			return nil, nil
+		}
+	}
+
+	direct := ExplainDependency("actions/checkout", files, nil, DefaultWhyDepth)
+	testutil.AssertEqual(t, 1, len(direct.Uses))
+	testutil.AssertEqual(t, 7, direct.Uses[0].Line)
+	testutil.AssertEqual(t, "v4", strings.Join(direct.Versions, ","))
+
+	explanation := ExplainDependency("Actions/Checkout", files, expand, DefaultWhyDepth)
+	testutil.AssertEqual(t, 2, len(explanation.Uses))
+	testutil.AssertEqual(t, "action.yml", explanation.Uses[0].File)
+	indirect := explanation.Uses[1]
+	testutil.AssertEqual(t, "build/action.yml", indirect.File)
+	testutil.AssertEqual(t, "2. (unnamed step)", indirect.Step)
+	testutil.AssertEqual(t, "org/tools/setup@v1", strings.Join(indirect.Via, ","))
+	testutil.AssertEqual(t, 0, indirect.Line)
+	testutil.AssertEqual(t, "v3,v4", strings.Join(explanation.Versions, ","))
+	testutil.AssertEqual(t, "org/broken@v1", strings.Join(explanation.Unresolved, ","))
+
+	subPath := ExplainDependency("org/tools", files, nil, DefaultWhyDepth)
+	testutil.AssertEqual(t, 1, len(subPath.Uses))
+}
+
+func TestExplainDependency_MaxTransitiveDepth(t *testing.T) {
+	t.Parallel()
+
+	// level-1 uses level-2 and so on, the last level uses actions/checkout
+	level := func(n int) dependencies.Dependency {
+		name := fmt.Sprintf("org/level-%d", n)
+
+		return dependencies.Dependency{Name: name, Uses: name + "@v1"}
+	}
+	expand := func(levels int) ActionDependencyFunc {
+		return func(dep dependencies.Dependency) ([]dependencies.Dependency, error) {
+			var n int
+			_, _ = fmt.Sscanf(dep.Name, "org/level-%d", &n)
+			if n == levels {
+				return []dependencies.Dependency{{Name: "actions/checkout", Uses: "actions/checkout@v4"}}, nil
+			}
+
+			return []dependencies.Dependency{level(n + 1)}, nil
+		}
+	}
+	files := []FileDependencies{{File: "action.yml", Dependencies: []dependencies.Dependency{level(1)}}}
+
+	within := ExplainDependency("actions/checkout", files, expand(DefaultMaxTransitiveDepth), 100)
+	testutil.AssertEqual(t, 1, len(within.Uses))
+	testutil.AssertEqual(t, DefaultMaxTransitiveDepth, len(within.Uses[0].Via))
+
+	beyond := ExplainDependency("actions/checkout", files, expand(DefaultMaxTransitiveDepth+1), 100)
+	testutil.AssertEqual(t, 0, len(beyond.Uses))
+}
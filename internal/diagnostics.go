@@ -0,0 +1,247 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ivuorinen/gh-action-readme/internal/errors"
+	"github.com/ivuorinen/gh-action-readme/internal/metrics"
+)
+
+// DiagnosticsSchemaVersion is the version of the diagnostics file format.
+const DiagnosticsSchemaVersion = 1
+
+// Diagnostic sources identify the part of the tool that reported a diagnostic.
+const (
+	DiagnosticSourceValidation   = "validation"
+	DiagnosticSourceGeneration   = "generation"
+	DiagnosticSourceDependencies = "deps"
+	DiagnosticSourceCLI          = "cli"
+)
+
+// Diagnostic codes for findings that have no validation rule or error code.
+const (
+	DiagnosticCodeStaleDocumentation   = "STALE_DOCUMENTATION"
+	DiagnosticCodeMissingDocumentation = "MISSING_DOCUMENTATION"
+	DiagnosticCodeDuplicateActionName  = "DUPLICATE_ACTION_NAME"
+)
+
+// commandSources maps top-level commands to the source of their diagnostics.
+var commandSources = map[string]string{
+	"gen":      DiagnosticSourceGeneration,
+	"adopt":    DiagnosticSourceGeneration,
+	"validate": DiagnosticSourceValidation,
+	"deps":     DiagnosticSourceDependencies,
+}
+
+// errorCodeSources maps error codes to the source of their diagnostics. Other
+// codes are attributed to the command of the run.
+var errorCodeSources = map[errors.ErrorCode]string{
+	errors.ErrCodeInvalidYAML:        DiagnosticSourceValidation,
+	errors.ErrCodeInvalidAction:      DiagnosticSourceValidation,
+	errors.ErrCodeValidation:         DiagnosticSourceValidation,
+	errors.ErrCodeTemplateRender:     DiagnosticSourceGeneration,
+	errors.ErrCodeFileWrite:          DiagnosticSourceGeneration,
+	errors.ErrCodeSecretDetected:     DiagnosticSourceGeneration,
+	errors.ErrCodeOutputConflict:     DiagnosticSourceGeneration,
+	errors.ErrCodeDependencyAnalysis: DiagnosticSourceDependencies,
+	errors.ErrCodeGitHubAPI:          DiagnosticSourceDependencies,
+	errors.ErrCodeGitHubRateLimit:    DiagnosticSourceDependencies,
+	errors.ErrCodeGitHubAuth:         DiagnosticSourceDependencies,
+}
+
+// Diagnostic is a warning or error reported during a run, in a form editors
+// and bots can consume. Code is a validation rule ID or an error code.
+type Diagnostic struct {
+	Severity    Severity `json:"severity"`
+	Code        string   `json:"code,omitempty"`
+	Source      string   `json:"source"`
+	Message     string   `json:"message"`
+	File        string   `json:"file,omitempty"`
+	Line        int      `json:"line,omitempty"`
+	Column      int      `json:"column,omitempty"`
+	Field       string   `json:"field,omitempty"`
+	Suggestions []string `json:"suggestions,omitempty"`
+	HelpURL     string   `json:"help_url,omitempty"`
+	// Fixes are machine-applicable text edits that resolve the diagnostic.
+	Fixes []errors.Fix `json:"fixes,omitempty"`
+}
+
+// DiagnosticsSummary counts diagnostics by severity.
+type DiagnosticsSummary struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Info     int `json:"info"`
+}
+
+// DiagnosticsReport is the content of a diagnostics file.
+type DiagnosticsReport struct {
+	SchemaVersion int                `json:"schema_version"`
+	Command       string             `json:"command"`
+	Diagnostics   []Diagnostic       `json:"diagnostics"`
+	Summary       DiagnosticsSummary `json:"summary"`
+	// APIUsage is the GitHub API usage of the run, omitted when it did not use the API.
+	APIUsage *metrics.APIUsage `json:"api_usage,omitempty"`
+}
+
+// DiagnosticsCollector gathers the diagnostics of a run for a diagnostics file.
+// It is safe for concurrent use.
+type DiagnosticsCollector struct {
+	mu          sync.Mutex
+	path        string
+	command     string
+	diagnostics []Diagnostic
+}
+
+// activeDiagnostics is the collector enabled with --diagnostics-file, nil when disabled.
+var activeDiagnostics atomic.Pointer[DiagnosticsCollector]
+
+// NewDiagnosticsCollector creates a collector for the run of command that
+// writes its diagnostics to path.
+func NewDiagnosticsCollector(path, command string) *DiagnosticsCollector {
+	return &DiagnosticsCollector{path: path, command: command}
+}
+
+// Add records a diagnostic. A diagnostic without a source is attributed to the
+// command of the run.
+func (c *DiagnosticsCollector) Add(diagnostic Diagnostic) {
+	if diagnostic.Source == "" {
+		diagnostic.Source = commandSource(c.command)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.diagnostics = append(c.diagnostics, diagnostic)
+}
+
+// Report returns the diagnostics recorded so far.
+func (c *DiagnosticsCollector) Report() DiagnosticsReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := DiagnosticsReport{
+		SchemaVersion: DiagnosticsSchemaVersion,
+		Command:       c.command,
+		Diagnostics:   append([]Diagnostic{}, c.diagnostics...),
+	}
+	for _, diagnostic := range c.diagnostics {
+		switch diagnostic.Severity {
+		case SeverityError:
+			report.Summary.Errors++
+		case SeverityWarning:
+			report.Summary.Warnings++
+		case SeverityInfo:
+			report.Summary.Info++
+		}
+	}
+
+	return report
+}
+
+// Write writes the diagnostics file, replacing any previous version.
+func (c *DiagnosticsCollector) Write() error {
+	report := c.Report()
+	if usage := metrics.Usage(); usage.Active() {
+		report.APIUsage = &usage
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode diagnostics: %w", err)
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), FilePermDefault); err != nil {
+		return fmt.Errorf("failed to write diagnostics file %s: %w", c.path, err)
+	}
+
+	return nil
+}
+
+// EnableDiagnostics starts collecting the diagnostics of the run of command
+// for the diagnostics file at path.
+func EnableDiagnostics(path, command string) {
+	activeDiagnostics.Store(NewDiagnosticsCollector(path, command))
+}
+
+// RecordDiagnostic records a diagnostic when a diagnostics file was requested.
+func RecordDiagnostic(diagnostic Diagnostic) {
+	if collector := activeDiagnostics.Load(); collector != nil {
+		collector.Add(diagnostic)
+	}
+}
+
+// FlushDiagnostics writes the diagnostics file when one was requested. It is
+// called before the process exits and may be called more than once.
+func FlushDiagnostics() error {
+	if collector := activeDiagnostics.Load(); collector != nil {
+		return collector.Write()
+	}
+
+	return nil
+}
+
+// FindingDiagnostic converts a validation finding of file into a diagnostic.
+// Findings located in another file, such as an entrypoint, keep their own file.
+func FindingDiagnostic(file string, finding Finding) Diagnostic {
+	diagnostic := Diagnostic{
+		Severity: finding.Severity,
+		Code:     finding.RuleID,
+		Source:   DiagnosticSourceValidation,
+		Message:  finding.Message,
+		File:     file,
+		Line:     finding.Line,
+		Column:   finding.Column,
+		Field:    finding.Field,
+	}
+	if finding.File != "" {
+		diagnostic.File = finding.File
+	}
+	if finding.Suggestion != "" {
+		diagnostic.Suggestions = []string{finding.Suggestion}
+	}
+	if finding.Fix != nil {
+		diagnostic.Fixes = []errors.Fix{*finding.Fix}
+	}
+
+	return diagnostic
+}
+
+// ContextualErrorDiagnostic converts a contextual error into a diagnostic. The
+// file comes from its path details, when present.
+func ContextualErrorDiagnostic(err *errors.ContextualError) Diagnostic {
+	message := err.Err.Error()
+	if err.Context != "" {
+		message = err.Context + ": " + message
+	}
+
+	diagnostic := Diagnostic{
+		Severity:    SeverityError,
+		Code:        string(err.Code),
+		Source:      errorCodeSources[err.Code],
+		Message:     message,
+		Suggestions: err.Suggestions,
+		HelpURL:     err.HelpURL,
+		Fixes:       err.Fixes,
+	}
+	for _, key := range []string{"path", "file", "output_path", "directory"} {
+		if value := err.Details[key]; value != "" {
+			diagnostic.File = value
+
+			break
+		}
+	}
+
+	return diagnostic
+}
+
+// commandSource returns the diagnostic source of a command path such as "deps list".
+func commandSource(command string) string {
+	name, _, _ := strings.Cut(command, " ")
+	if source, ok := commandSources[name]; ok {
+		return source
+	}
+
+	return DiagnosticSourceCLI
+}
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal/errors"
+	"github.com/ivuorinen/gh-action-readme/internal/metrics"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestDiagnosticsCollector(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	path := filepath.Join(tmpDir, "diagnostics.json")
+
+	collector := NewDiagnosticsCollector(path, "deps list")
+	collector.Add(Diagnostic{Severity: SeverityWarning, Message: "No action files found"})
+	collector.Add(FindingDiagnostic("action.yml", Finding{
+		RuleID:     RuleMissingBranding,
+		Severity:   SeverityError,
+		Field:      "branding",
+		Message:    "Missing recommended field: branding",
+		Line:       3,
+		Suggestion: "Add branding",
+	}))
+	metrics.RecordCacheLookup(true)
+	testutil.AssertNoError(t, collector.Write())
+
+	data, err := os.ReadFile(path) // #nosec G304 -- test file path
+	testutil.AssertNoError(t, err)
+	var report DiagnosticsReport
+	testutil.AssertNoError(t, json.Unmarshal(data, &report))
+
+	testutil.AssertEqual(t, DiagnosticsSchemaVersion, report.SchemaVersion)
+	testutil.AssertEqual(t, "deps list", report.Command)
+	testutil.AssertEqual(t, DiagnosticsSummary{Errors: 1, Warnings: 1}, report.Summary)
+	if len(report.Diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(report.Diagnostics))
+	}
+	testutil.AssertEqual(t, DiagnosticSourceDependencies, report.Diagnostics[0].Source)
+	finding := report.Diagnostics[1]
+	testutil.AssertEqual(t, DiagnosticSourceValidation, finding.Source)
+	testutil.AssertEqual(t, RuleMissingBranding, finding.Code)
+	testutil.AssertEqual(t, 3, finding.Line)
+	testutil.AssertEqual(t, "Add branding", finding.Suggestions[0])
+	if report.APIUsage == nil || report.APIUsage.CacheHits == 0 {
+		t.Errorf("expected the API usage of the run, got %+v", report.APIUsage)
+	}
+}
+
+func TestContextualErrorDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	err := errors.Wrap(os.ErrNotExist, errors.ErrCodeFileNotFound, "failed to read action").
+		WithSuggestions("Check the path").
+		WithDetails(map[string]string{"path": "action.yml"})
+
+	diagnostic := ContextualErrorDiagnostic(err)
+	testutil.AssertEqual(t, SeverityError, diagnostic.Severity)
+	testutil.AssertEqual(t, "FILE_NOT_FOUND", diagnostic.Code)
+	testutil.AssertEqual(t, "failed to read action: file does not exist", diagnostic.Message)
+	testutil.AssertEqual(t, "action.yml", diagnostic.File)
+	testutil.AssertEqual(t, "", diagnostic.Source)
+
+	diagnostic = ContextualErrorDiagnostic(errors.New(errors.ErrCodeGitHubRateLimit, "rate limited"))
+	testutil.AssertEqual(t, DiagnosticSourceDependencies, diagnostic.Source)
+}
+
+func TestGenerationDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	secretErr := errors.New(errors.ErrCodeSecretDetected, "refusing to write output").
+		WithDetails(map[string]string{"output_path": "README.md"})
+	diagnostic := generationDiagnostic("action.yml", secretErr)
+	testutil.AssertEqual(t, "SECRET_DETECTED", diagnostic.Code)
+	testutil.AssertEqual(t, "action.yml", diagnostic.File)
+	testutil.AssertEqual(t, DiagnosticSourceGeneration, diagnostic.Source)
+
+	diagnostic = generationDiagnostic("action.yml", os.ErrPermission)
+	testutil.AssertEqual(t, "", diagnostic.Code)
+	testutil.AssertEqual(t, os.ErrPermission.Error(), diagnostic.Message)
+}
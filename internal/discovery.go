@@ -0,0 +1,178 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultDiscoveryPatterns match action.yml and action.yaml in any directory.
+var DefaultDiscoveryPatterns = []string{"**/" + ActionFileNameYML, "**/" + ActionFileNameYAML}
+
+// DiscoverySettings configures which files are discovered as actions. Empty
+// patterns use DefaultDiscoveryPatterns.
+type DiscoverySettings struct {
+	// Patterns are slash-separated globs relative to the searched directory, where
+	// ** matches any number of directories and file names match case-insensitively
+	Patterns []string `mapstructure:"patterns" yaml:"patterns,omitempty"`
+}
+
+// DiscoveredAction is an action file found by DiscoverActions.
+type DiscoveredAction struct {
+	Path    string // Path of the action file
+	Pattern string // Discovery pattern that matched it
+}
+
+// discoveryPatterns holds the patterns set with SetDiscoveryPatterns.
+var discoveryPatterns atomic.Pointer[[]string]
+
+// SetDiscoveryPatterns sets the patterns used by every discovery afterwards.
+// Empty patterns restore DefaultDiscoveryPatterns.
+func SetDiscoveryPatterns(patterns []string) {
+	patterns = slices.Clone(patterns)
+	discoveryPatterns.Store(&patterns)
+}
+
+// CurrentDiscoveryPatterns returns the patterns set with SetDiscoveryPatterns, or the defaults.
+func CurrentDiscoveryPatterns() []string {
+	if patterns := discoveryPatterns.Load(); patterns != nil && len(*patterns) > 0 {
+		return slices.Clone(*patterns)
+	}
+
+	return slices.Clone(DefaultDiscoveryPatterns)
+}
+
+// ValidateDiscoveryPatterns rejects malformed globs and patterns outside the searched directory.
+func ValidateDiscoveryPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if pattern == "" || path.IsAbs(pattern) || slices.Contains(strings.Split(pattern, "/"), "..") {
+			return fmt.Errorf("invalid discovery pattern '%s', must be a relative glob such as **/action.yml", pattern)
+		}
+		for _, segment := range strings.Split(pattern, "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("invalid discovery pattern '%s': %w", pattern, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// DiscoverActions finds the action files in dir that match the current
+// discovery patterns, with the first pattern that matched each file. Without
+// recursive only files directly in dir are considered, matched against the
+// file name part of each pattern.
+func DiscoverActions(dir string, recursive bool) ([]DiscoveredAction, error) {
+	return discoverActions(dir, recursive, CurrentDiscoveryPatterns())
+}
+
+// discoverActions implements DiscoverActions for the given patterns.
+func discoverActions(dir string, recursive bool, patterns []string) ([]DiscoveredAction, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("directory does not exist: %s", dir)
+	}
+
+	if !recursive {
+		return discoverInDir(dir, patterns)
+	}
+
+	var found []DiscoveredAction
+	err := filepath.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		if pattern, ok := matchDiscoveryPattern(patterns, filepath.ToSlash(rel)); ok {
+			found = append(found, DiscoveredAction{Path: filePath, Pattern: pattern})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory %s: %w", dir, err)
+	}
+
+	return found, nil
+}
+
+// discoverInDir matches the files directly in dir against the file name part
+// of patterns, in pattern order.
+func discoverInDir(dir string, patterns []string) ([]DiscoveredAction, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	var found []DiscoveredAction
+	for _, pattern := range patterns {
+		for _, entry := range entries {
+			filePath := filepath.Join(dir, entry.Name())
+			if entry.IsDir() || slices.ContainsFunc(found, func(d DiscoveredAction) bool { return d.Path == filePath }) {
+				continue
+			}
+			if matchGlob([]string{path.Base(pattern)}, []string{entry.Name()}) {
+				found = append(found, DiscoveredAction{Path: filePath, Pattern: pattern})
+			}
+		}
+	}
+
+	return found, nil
+}
+
+// matchDiscoveryPattern returns the first of patterns matching the slash-separated relative path.
+func matchDiscoveryPattern(patterns []string, rel string) (string, bool) {
+	for _, pattern := range patterns {
+		if matchGlob(strings.Split(pattern, "/"), strings.Split(rel, "/")) {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
+
+// matchGlob matches path segments against pattern segments, where a ** segment
+// matches any number of path segments. The file name, the last segment, is
+// compared case-insensitively.
+func matchGlob(pattern, segments []string) bool {
+	if len(pattern) > 0 && len(segments) > 0 {
+		pattern = slices.Clone(pattern)
+		segments = slices.Clone(segments)
+		pattern[len(pattern)-1] = strings.ToLower(pattern[len(pattern)-1])
+		segments[len(segments)-1] = strings.ToLower(segments[len(segments)-1])
+	}
+
+	return matchSegments(pattern, segments)
+}
+
+// matchSegments implements matchGlob after case folding.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	matched, _ := path.Match(pattern[0], segments[0]) // malformed patterns do not match
+
+	return matched && matchSegments(pattern[1:], segments[1:])
+}
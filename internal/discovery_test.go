@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestMatchDiscoveryPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"**/action.yml", "action.yml", true},
+		{"**/action.yml", "a/b/c/action.yml", true},
+		{"**/action.yml", "a/b/Action.YML", true},
+		{"**/action.yml", "a/action.yaml", false},
+		{"*/action.yaml", "lint/action.yaml", true},
+		{"*/action.yaml", "action.yaml", false},
+		{"*/action.yaml", "a/lint/action.yaml", false},
+		{".github/actions/*/action.yml", ".github/actions/lint/action.yml", true},
+		{".github/actions/*/action.yml", "actions/lint/action.yml", false},
+		{"tools/**/*.action.yml", "tools/ci/build.action.yml", true},
+		{"tools/**", "tools/ci/action.yml", true},
+	}
+	for _, tt := range tests {
+		_, got := matchDiscoveryPattern([]string{tt.pattern}, tt.path)
+		if got != tt.want {
+			t.Errorf("pattern %q on %q: got %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDiscoverActions_Patterns(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	lint := filepath.Join(tmpDir, ".github", "actions", "lint", "action.yml")
+	build := filepath.Join(tmpDir, "tools", "build", "action.yaml")
+	root := filepath.Join(tmpDir, "action.yml")
+	for _, path := range []string{lint, build, root} {
+		testutil.WriteTestFile(t, path, "name: test\n")
+	}
+
+	found, err := discoverActions(tmpDir, true, DefaultDiscoveryPatterns)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 3, len(found))
+
+	patterns := []string{".github/actions/*/action.yml", "tools/**/action.yaml"}
+	found, err = discoverActions(tmpDir, true, patterns)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(found))
+	testutil.AssertEqual(t, lint, found[0].Path)
+	testutil.AssertEqual(t, patterns[0], found[0].Pattern)
+	testutil.AssertEqual(t, build, found[1].Path)
+	testutil.AssertEqual(t, patterns[1], found[1].Pattern)
+
+	// Without recursion only the file name part of each pattern is used.
+	found, err = discoverActions(tmpDir, false, DefaultDiscoveryPatterns)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(found))
+	testutil.AssertEqual(t, root, found[0].Path)
+}
+
+func TestValidateDiscoveryPatterns(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, ValidateDiscoveryPatterns(nil))
+	testutil.AssertNoError(t, ValidateDiscoveryPatterns([]string{"**/action.yml", ".github/actions/*/action.yaml"}))
+
+	for _, pattern := range []string{"", "/etc/action.yml", "../action.yml", "[/action.yml"} {
+		err := ValidateDiscoveryPatterns([]string{pattern})
+		testutil.AssertError(t, err)
+		testutil.AssertStringContains(t, err.Error(), "invalid discovery pattern")
+	}
+}
+
+func TestGenerator_DiscoverActionFilesOnce(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "action.yml"), "name: test\n")
+
+	generator := NewGenerator(DefaultAppConfig())
+	found, err := generator.DiscoverActionFiles(tmpDir, true)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(found))
+
+	// Later services of the same command share the first discovery
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "lint", "action.yml"), "name: lint\n")
+	found, err = generator.DiscoverActionFiles(tmpDir, true)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(found))
+
+	found, err = NewGenerator(DefaultAppConfig()).DiscoverActionFiles(tmpDir, true)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(found))
+}
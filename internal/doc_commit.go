@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultCommitMessage is the message of the commits of gen --commit.
+const DefaultCommitMessage = "docs(actions): regenerate READMEs"
+
+// CommitMessageData is the data of gen --commit-message templates.
+type CommitMessageData struct {
+	// Count is the number of files committed
+	Count int
+	// Files are the committed files, relative to the repository root
+	Files []string
+}
+
+// RenderCommitMessage renders the commit message template text, such as
+// "docs(actions): regenerate {{.Count}} file(s)".
+func RenderCommitMessage(text string, data CommitMessageData) (string, error) {
+	tmpl, err := template.New("commit-message").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid commit message template: %w", err)
+	}
+
+	var message strings.Builder
+	if err := tmpl.Execute(&message, data); err != nil {
+		return "", fmt.Errorf("invalid commit message template: %w", err)
+	}
+	if strings.TrimSpace(message.String()) == "" {
+		return "", errors.New("commit message is empty")
+	}
+
+	return message.String(), nil
+}
@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestRenderCommitMessage(t *testing.T) {
+	t.Parallel()
+
+	data := CommitMessageData{Count: 2, Files: []string{"build/README.md", "test/README.md"}}
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  string
+	}{
+		{name: "default", template: DefaultCommitMessage, want: "docs(actions): regenerate READMEs"},
+		{
+			name:     "count and files",
+			template: "docs: regenerate {{.Count}} file(s)\n\n{{range .Files}}- {{.}}\n{{end}}",
+			want:     "docs: regenerate 2 file(s)\n\n- build/README.md\n- test/README.md\n",
+		},
+		{name: "unknown field", template: "docs: {{.Actions}}", wantErr: "invalid commit message template"},
+		{name: "parse error", template: "docs: {{.Count", wantErr: "invalid commit message template"},
+		{name: "empty", template: "  ", wantErr: "commit message is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			message, err := RenderCommitMessage(tt.template, data)
+			if tt.wantErr != "" {
+				testutil.AssertError(t, err)
+				testutil.AssertStringContains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tt.want, message)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package internal
+
+import (
+	"archive/tar"
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+	"github.com/ivuorinen/gh-action-readme/internal/sink"
+)
+
+// Media types of the documentation artifact pushed by publish oci.
+const (
+	// DocsArtifactType is the artifactType of documentation artifacts.
+	DocsArtifactType = "application/vnd.gh-action-readme.docs.v1"
+
+	docsLayerMediaType    = "application/vnd.oci.image.layer.v1.tar+gzip"
+	docsManifestMediaType = "application/vnd.gh-action-readme.manifest.v1+json"
+	docsSBOMMediaType     = "application/vnd.cyclonedx+json"
+	ociManifestMediaType  = "application/vnd.oci.image.manifest.v1+json"
+	ociEmptyMediaType     = "application/vnd.oci.empty.v1+json"
+
+	docsManifestSchemaVersion = 1
+	cycloneDXSpecVersion      = "1.5"
+	docsArchiveFileMode       = 0o644
+)
+
+// DocsFile is a documentation file of a documentation artifact.
+type DocsFile struct {
+	Path   string `json:"path"`   // Slash-separated path in the archive, relative to the root
+	Action string `json:"action"` // Action file the documentation was generated from
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// DocsManifest lists the contents of a documentation artifact.
+type DocsManifest struct {
+	SchemaVersion int        `json:"schema_version"`
+	Tool          string     `json:"tool"`
+	Version       string     `json:"version,omitempty"` // Empty in reproducible mode
+	Created       string     `json:"created,omitempty"` // RFC 3339, empty in reproducible mode
+	Format        string     `json:"format"`
+	Files         []DocsFile `json:"files"`
+}
+
+// DocsArtifact is a packaged documentation set: the generated documentation
+// as a gzipped tar archive, its manifest and an SBOM of the actions' dependencies.
+type DocsArtifact struct {
+	Manifest     DocsManifest
+	Archive      []byte
+	ManifestJSON []byte
+	SBOM         []byte
+}
+
+// cycloneDXBOM is the subset of a CycloneDX BOM written for documentation artifacts.
+type cycloneDXBOM struct {
+	BOMFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Version     int                  `json:"version"`
+	Metadata    cycloneDXMetadata    `json:"metadata"`
+	Components  []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string `json:"timestamp,omitempty"`
+	Tools     struct {
+		Components []cycloneDXComponent `json:"components"`
+	} `json:"tools"`
+}
+
+type cycloneDXComponent struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	PURL    string `json:"purl,omitempty"`
+}
+
+// ociDescriptor describes a blob of an OCI manifest.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int               `json:"size"`
+	Data        []byte            `json:"data,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociManifest is an OCI image manifest describing an artifact.
+type ociManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType"`
+	Config        ociDescriptor     `json:"config"`
+	Layers        []ociDescriptor   `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// BuildDocsArtifact packages the documentation generated for the action files
+// in paths, which must exist already, with paths in the archive relative to
+// root. The SBOM lists the dependencies analyzer finds; a nil analyzer reads
+// them without network access.
+func (g *Generator) BuildDocsArtifact(
+	root string,
+	paths []string,
+	analyzer *dependencies.Analyzer,
+) (*DocsArtifact, error) {
+	if analyzer == nil {
+		analyzer = dependencies.NewAnalyzer(nil, git.RepoInfo{}, dependencies.NewNoOpCache())
+	}
+
+	artifact := &DocsArtifact{Manifest: DocsManifest{
+		SchemaVersion: docsManifestSchemaVersion,
+		Tool:          toolName,
+		Version:       toolVersion(g.Config, getVersion()),
+		Format:        g.Config.OutputFormat,
+	}}
+	created, hasTime := generationTime(g.Config)
+	if hasTime {
+		artifact.Manifest.Created = created.Format(time.RFC3339)
+	}
+
+	contents := map[string][]byte{}
+	var deps []dependencies.Dependency
+	for _, path := range paths {
+		action, err := g.parseAndValidateAction(path)
+		if err != nil {
+			return nil, err
+		}
+		docPath := g.outputPath(action, path)
+		content, err := os.ReadFile(docPath) // #nosec G304 -- documentation path of a discovered action
+		if err != nil {
+			return nil, fmt.Errorf("no documentation for %s at %s, run gen first: %w", path, docPath, err)
+		}
+		file := DocsFile{
+			Path:   relativeSlashPath(root, docPath),
+			Action: relativeSlashPath(root, path),
+			Size:   len(content),
+			SHA256: sink.SHA256Hex(content),
+		}
+		if _, duplicate := contents[file.Path]; duplicate {
+			return nil, fmt.Errorf("documentation of several actions is written to %s", file.Path)
+		}
+		contents[file.Path] = content
+		artifact.Manifest.Files = append(artifact.Manifest.Files, file)
+
+		actionDeps, err := analyzer.AnalyzeActionFile(path)
+		if err == nil {
+			deps = append(deps, actionDeps...)
+		}
+	}
+	slices.SortFunc(artifact.Manifest.Files, func(a, b DocsFile) int { return strings.Compare(a.Path, b.Path) })
+
+	var err error
+	if artifact.Archive, err = docsArchive(artifact.Manifest.Files, contents, created); err != nil {
+		return nil, err
+	}
+	if artifact.ManifestJSON, err = json.MarshalIndent(artifact.Manifest, "", "  "); err != nil {
+		return nil, fmt.Errorf("failed to encode docs manifest: %w", err)
+	}
+	if artifact.SBOM, err = docsSBOM(deps, artifact.Manifest); err != nil {
+		return nil, err
+	}
+
+	return artifact, nil
+}
+
+// PushDocsArtifact pushes artifact to image's repository under its tag and
+// returns the digest of the pushed manifest.
+func PushDocsArtifact(
+	ctx context.Context,
+	client *dependencies.RegistryClient,
+	image dependencies.DockerImage,
+	artifact *DocsArtifact,
+) (string, error) {
+	empty := []byte("{}")
+	blobs := []struct {
+		mediaType string
+		title     string
+		data      []byte
+	}{
+		{ociEmptyMediaType, "", empty},
+		{docsLayerMediaType, "docs.tar.gz", artifact.Archive},
+		{docsManifestMediaType, "manifest.json", artifact.ManifestJSON},
+		{docsSBOMMediaType, "sbom.cdx.json", artifact.SBOM},
+	}
+
+	manifest := ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		ArtifactType:  DocsArtifactType,
+	}
+	for _, blob := range blobs {
+		digest, err := client.PushBlob(ctx, image, blob.data)
+		if err != nil {
+			return "", fmt.Errorf("failed to push %s: %w", cmp.Or(blob.title, "config"), err)
+		}
+		descriptor := ociDescriptor{MediaType: blob.mediaType, Digest: digest, Size: len(blob.data)}
+		if blob.title == "" {
+			descriptor.Data = empty
+			manifest.Config = descriptor
+
+			continue
+		}
+		descriptor.Annotations = map[string]string{"org.opencontainers.image.title": blob.title}
+		manifest.Layers = append(manifest.Layers, descriptor)
+	}
+	if artifact.Manifest.Created != "" {
+		manifest.Annotations = map[string]string{"org.opencontainers.image.created": artifact.Manifest.Created}
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode OCI manifest: %w", err)
+	}
+
+	return client.PushManifest(ctx, image, image.Tag, ociManifestMediaType, data)
+}
+
+// docsArchive writes the documentation files into a gzipped tar archive that
+// is byte-identical for identical files and modification time.
+func docsArchive(files []DocsFile, contents map[string][]byte, modTime time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, file := range files {
+		header := &tar.Header{
+			Name:    file.Path,
+			Mode:    docsArchiveFileMode,
+			Size:    int64(file.Size),
+			ModTime: modTime,
+			Format:  tar.FormatPAX,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, fmt.Errorf("failed to archive %s: %w", file.Path, err)
+		}
+		if _, err := tw.Write(contents[file.Path]); err != nil {
+			return nil, fmt.Errorf("failed to archive %s: %w", file.Path, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to archive documentation: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress documentation: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// docsSBOM lists the external action and docker:// dependencies as a
+// CycloneDX BOM, each once, ordered by package URL.
+func docsSBOM(deps []dependencies.Dependency, manifest DocsManifest) ([]byte, error) {
+	bom := cycloneDXBOM{BOMFormat: "CycloneDX", SpecVersion: cycloneDXSpecVersion, Version: 1}
+	bom.Metadata.Timestamp = manifest.Created
+	bom.Metadata.Tools.Components = []cycloneDXComponent{{
+		Type: "application", Name: manifest.Tool, Version: manifest.Version,
+	}}
+	bom.Components = []cycloneDXComponent{}
+
+	seen := map[string]bool{}
+	for _, dep := range deps {
+		purl := dependencyPURL(dep)
+		if purl == "" || seen[purl] {
+			continue
+		}
+		seen[purl] = true
+		bom.Components = append(bom.Components, cycloneDXComponent{
+			Type: "application", Name: dep.Name, Version: dep.Version, PURL: purl,
+		})
+	}
+	slices.SortFunc(bom.Components, func(a, b cycloneDXComponent) int { return strings.Compare(a.PURL, b.PURL) })
+
+	data, err := json.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode SBOM: %w", err)
+	}
+
+	return data, nil
+}
+
+// dependencyPURL returns the package URL of an external dependency, such as
+// pkg:github/actions/checkout@v4 or pkg:docker/library/alpine@3.20, or "" for
+// shell scripts and local actions.
+func dependencyPURL(dep dependencies.Dependency) string {
+	switch {
+	case dep.IsShellScript || dep.IsLocalAction || dep.Version == "":
+		return ""
+	case dep.Docker != nil:
+		purl := "pkg:docker/" + dep.Docker.Repository + "@" + strings.ReplaceAll(dep.Version, ":", "%3A")
+		if dep.Docker.Registry != "docker.io" {
+			purl += "?repository_url=" + dep.Docker.Registry
+		}
+
+		return purl
+	case strings.Count(dep.Name, "/") == 1:
+		return "pkg:github/" + dep.Name + "@" + dep.Version
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestGenerator_BuildDocsArtifact(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	testutil.SetupTestTemplates(t, tmpDir)
+
+	simple := filepath.Join(tmpDir, "simple", "action.yml")
+	testutil.WriteTestFile(t, simple, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	composite := filepath.Join(tmpDir, "composite", "action.yml")
+	testutil.WriteTestFile(t, composite, testutil.MustReadFixture("actions/composite/with-dependencies.yml"))
+
+	config := &AppConfig{
+		OutputFormat: "md",
+		Quiet:        true,
+		Reproducible: true,
+		Template:     filepath.Join(tmpDir, "templates", "readme.tmpl"),
+	}
+	generator := NewGenerator(config)
+
+	_, err := generator.BuildDocsArtifact(tmpDir, []string{simple}, nil)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "run gen first")
+
+	testutil.AssertNoError(t, generator.ProcessBatch([]string{simple, composite}))
+	artifact, err := generator.BuildDocsArtifact(tmpDir, []string{simple, composite}, nil)
+	testutil.AssertNoError(t, err)
+
+	files := artifact.Manifest.Files
+	testutil.AssertEqual(t, 2, len(files))
+	testutil.AssertEqual(t, "composite/README.md", files[0].Path)
+	testutil.AssertEqual(t, "composite/action.yml", files[0].Action)
+	testutil.AssertEqual(t, "", artifact.Manifest.Created)
+	testutil.AssertEqual(t, "composite/README.md,simple/README.md", strings.Join(archiveNames(t, artifact.Archive), ","))
+
+	var bom cycloneDXBOM
+	testutil.AssertNoError(t, json.Unmarshal(artifact.SBOM, &bom))
+	purls := make([]string, 0, len(bom.Components))
+	for _, component := range bom.Components {
+		purls = append(purls, component.PURL)
+	}
+	testutil.AssertEqual(t,
+		"pkg:github/actions/checkout@v4,pkg:github/actions/setup-node@v4,pkg:github/actions/setup-python@v4",
+		strings.Join(purls, ","))
+
+	// Packaging the same documentation again gives identical bytes
+	again, err := generator.BuildDocsArtifact(tmpDir, []string{composite, simple}, nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, true, bytes.Equal(artifact.Archive, again.Archive))
+}
+
+func TestPushDocsArtifact(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	blobs := map[string][]byte{}
+	var manifest ociManifest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodHead && strings.HasPrefix(r.URL.Path, "/v2/org/docs/blobs/"):
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/org/docs/blobs/uploads/":
+			w.Header().Set("Location", "/v2/org/docs/blobs/uploads/session")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPut && r.URL.Path == "/v2/org/docs/blobs/uploads/session":
+			data, _ := io.ReadAll(r.Body)
+			blobs[r.URL.Query().Get("digest")] = data
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut && r.URL.Path == "/v2/org/docs/manifests/v1":
+			_ = json.NewDecoder(r.Body).Decode(&manifest)
+			w.Header().Set("Docker-Content-Digest", "sha256:manifest")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := dependencies.NewRegistryClient(nil)
+	client.Scheme = "http"
+	image := dependencies.DockerImage{
+		Registry: strings.TrimPrefix(server.URL, "http://"), Repository: "org/docs", Tag: "v1",
+	}
+	artifact := &DocsArtifact{
+		Manifest:     DocsManifest{Created: "2026-01-02T03:04:05Z"},
+		Archive:      []byte("archive"),
+		ManifestJSON: []byte(`{"files": []}`),
+		SBOM:         []byte(`{"bomFormat": "CycloneDX"}`),
+	}
+
+	digest, err := PushDocsArtifact(context.Background(), client, image, artifact)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "sha256:manifest", digest)
+	testutil.AssertEqual(t, DocsArtifactType, manifest.ArtifactType)
+	testutil.AssertEqual(t, ociEmptyMediaType, manifest.Config.MediaType)
+	testutil.AssertEqual(t, 3, len(manifest.Layers))
+	testutil.AssertEqual(t, "archive", string(blobs[manifest.Layers[0].Digest]))
+	testutil.AssertEqual(t, "2026-01-02T03:04:05Z", manifest.Annotations["org.opencontainers.image.created"])
+	testutil.AssertEqual(t, 4, len(blobs))
+}
+
+// archiveNames returns the names of the files in a gzipped tar archive.
+func archiveNames(t *testing.T, archive []byte) []string {
+	t.Helper()
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	testutil.AssertNoError(t, err)
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return names
+		}
+		testutil.AssertNoError(t, err)
+		names = append(names, header.Name)
+	}
+}
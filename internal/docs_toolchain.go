@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+)
+
+// DocsToolchain describes how the documentation of an action is generated, so
+// contributors regenerate it instead of editing the generated files by hand.
+type DocsToolchain struct {
+	Files      []string `json:"files"`                 // Generated files, relative to the repository root
+	ConfigFile string   `json:"config_file,omitempty"` // Repository configuration, empty when there is none
+	Regenerate string   `json:"regenerate"`            // Command regenerating the documentation
+	Check      string   `json:"check"`                 // Command failing when the documentation is stale
+	// Workflows under .github/workflows running gh-action-readme to check the documentation
+	CheckWorkflows []string `json:"check_workflows,omitempty"`
+}
+
+// DetectDocsToolchain describes the generation of the files at outputPaths
+// from the action at actionPath: the commands regenerating and checking them,
+// the repository configuration they are generated with and the workflows
+// checking them in CI. Without a git repository paths are relative to the
+// directory of the action.
+func DetectDocsToolchain(config *AppConfig, actionPath string, outputPaths []string) *DocsToolchain {
+	actionDir := filepath.Dir(actionPath)
+	repoRoot, err := git.FindRepositoryRoot(actionDir)
+	if err != nil {
+		repoRoot = actionDir
+	}
+
+	toolchain := &DocsToolchain{
+		ConfigFile:     findRepoConfigFile(repoRoot),
+		CheckWorkflows: docsCheckWorkflows(repoRoot),
+	}
+	for _, outputPath := range outputPaths {
+		toolchain.Files = append(toolchain.Files, relativeSlashPath(repoRoot, outputPath))
+	}
+
+	var args []string
+	if toolchain.ConfigFile == "" && config.Theme != "" && config.Theme != ThemeDefault {
+		// Without a repository configuration the theme is only known from this run
+		args = append(args, "--theme", config.Theme)
+	}
+	if dir := relativeSlashPath(repoRoot, actionDir); dir != "." {
+		args = append(args, dir)
+	}
+	toolchain.Regenerate = strings.Join(append([]string{toolName, "gen"}, args...), " ")
+	toolchain.Check = strings.Join(append([]string{toolName, "gen", "--check"}, args...), " ")
+
+	return toolchain
+}
+
+// findRepoConfigFile returns the repository configuration file of repoRoot
+// relative to it, or an empty string when there is none.
+func findRepoConfigFile(repoRoot string) string {
+	for _, name := range repoConfigFiles {
+		if _, err := os.Stat(filepath.Join(repoRoot, name)); err == nil {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// docsCheckWorkflows returns the workflows of repoRoot that run gen --check or
+// check-all, relative to repoRoot.
+func docsCheckWorkflows(repoRoot string) []string {
+	dir := filepath.Join(repoRoot, ".github", "workflows")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var workflows []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name())) // #nosec G304 -- workflow in the repository
+		if err != nil {
+			continue
+		}
+		text := string(content)
+		if strings.Contains(text, toolName) && (strings.Contains(text, "--check") || strings.Contains(text, "check-all")) {
+			workflows = append(workflows, path.Join(".github", "workflows", entry.Name()))
+		}
+	}
+
+	return workflows
+}
@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestGenerator_DocumentationDocument(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	testutil.AssertNoError(t, os.MkdirAll(filepath.Join(tmpDir, ".git"), 0750))
+	actionDir := filepath.Join(tmpDir, "actions", "build")
+	actionPath := filepath.Join(actionDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+
+	config := DefaultAppConfig()
+	config.Theme = ThemeGitHub
+	config.OutputDir = actionDir
+	config.Quiet = true
+	config.Documents = []string{"documentation"}
+	documentation := func() string {
+		t.Helper()
+		testutil.AssertNoError(t, NewGenerator(config).GenerateFromFile(actionPath))
+		content, err := os.ReadFile(filepath.Join(actionDir, "DOCUMENTATION.md"))
+		testutil.AssertNoError(t, err)
+
+		return string(content)
+	}
+
+	// Without a repository configuration the commands carry the theme
+	content := documentation()
+	testutil.AssertStringContains(t, content, "- `actions/build/README.md`\n- `actions/build/DOCUMENTATION.md`")
+	testutil.AssertStringContains(t, content, "gh-action-readme gen --theme github actions/build\n")
+	testutil.AssertStringContains(t, content, "Check that the documentation is up to date")
+
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, ".ghreadme.yaml"), "theme: github\n")
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, ".github", "workflows", "docs.yml"),
+		"jobs:\n  docs:\n    steps:\n      - run: gh-action-readme gen --check -r\n")
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, ".github", "workflows", "test.yml"),
+		"jobs:\n  test:\n    steps:\n      - run: go test ./...\n")
+	content = documentation()
+	testutil.AssertStringContains(t, content, "Change `action.yml` or `.ghreadme.yaml` instead.")
+	testutil.AssertStringContains(t, content, "gh-action-readme gen --check actions/build\n")
+	testutil.AssertStringContains(t, content, "see `.github/workflows/docs.yml`.\n")
+
+	// The document is generated, so check mode compares it like the README
+	checker := NewGenerator(config)
+	checker.Check = true
+	testutil.AssertNoError(t, checker.GenerateFromFile(actionPath))
+}
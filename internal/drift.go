@@ -0,0 +1,255 @@
+package internal
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+)
+
+// DriftSchemaVersion is the version of the drift report JSON schema. It changes
+// only when fields are removed or change meaning; new fields may be added.
+const DriftSchemaVersion = "1"
+
+// Documentation freshness states.
+const (
+	DocsFresh = "fresh"
+	DocsStale = "stale"
+	DocsError = "error"
+)
+
+// Validation states.
+const (
+	ValidationPassed   = "passed"
+	ValidationWarnings = "warnings"
+	ValidationFailed   = "failed"
+	ValidationError    = "error"
+)
+
+// DriftReport summarizes how far each action has drifted from its documentation,
+// pinning policy, latest dependency versions and validation rules.
+type DriftReport struct {
+	SchemaVersion string `json:"schema_version"`
+	// GeneratedAt is nil in reproducible mode without SOURCE_DATE_EPOCH.
+	GeneratedAt *time.Time    `json:"generated_at,omitempty"`
+	Summary     DriftSummary  `json:"summary"`
+	Actions     []ActionDrift `json:"actions"`
+}
+
+// DriftSummary holds totals across all actions in a drift report.
+type DriftSummary struct {
+	Actions            int `json:"actions"`
+	StaleDocs          int `json:"stale_docs"`
+	NonCompliantPins   int `json:"non_compliant_pins"`
+	OutdatedDeps       int `json:"outdated_dependencies"`
+	ValidationFailures int `json:"validation_failures"`
+	Unowned            int `json:"unowned"`
+}
+
+// ActionDrift is the drift report entry for one action file.
+type ActionDrift struct {
+	File       string           `json:"file"`
+	Name       string           `json:"name"`
+	Docs       DocFreshness     `json:"docs"`
+	Pins       PinCompliance    `json:"pins"`
+	Outdated   *OutdatedSummary `json:"outdated"` // nil when not checked (no GitHub token)
+	Validation ValidationStatus `json:"validation"`
+	Owners     []string         `json:"owners"` // From the sidecar owners: list or CODEOWNERS
+}
+
+// DocFreshness reports whether the generated documentation matches the file on disk.
+type DocFreshness struct {
+	Status string `json:"status"` // fresh, stale or error
+	Error  string `json:"error,omitempty"`
+}
+
+// PinCompliance counts dependencies pinned to a commit SHA or full version.
+type PinCompliance struct {
+	Total     int    `json:"total"`
+	Pinned    int    `json:"pinned"`
+	Floating  int    `json:"floating"`
+	Compliant bool   `json:"compliant"`
+	Error     string `json:"error,omitempty"`
+}
+
+// OutdatedSummary counts dependencies with newer versions available, by update type.
+type OutdatedSummary struct {
+	Total int `json:"total"`
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+	Patch int `json:"patch"`
+}
+
+// ValidationStatus reports the validation result of an action.
+type ValidationStatus struct {
+	Status   string `json:"status"` // passed, warnings, failed or error
+	Errors   int    `json:"errors"`
+	Warnings int    `json:"warnings"`
+	Error    string `json:"error,omitempty"`
+}
+
+// DriftReport builds a drift report for paths. File names are reported relative
+// to baseDir. Outdated dependencies are only checked when analyzer has a GitHub
+// client; a nil analyzer reads dependencies without network access.
+func (g *Generator) DriftReport(paths []string, baseDir string, analyzer *dependencies.Analyzer) (*DriftReport, error) {
+	if err := g.checkBatchSize(paths); err != nil {
+		return nil, err
+	}
+	if analyzer == nil {
+		analyzer = dependencies.NewAnalyzer(nil, git.RepoInfo{}, dependencies.NewNoOpCache())
+	}
+
+	report := &DriftReport{
+		SchemaVersion: DriftSchemaVersion,
+		Actions:       make([]ActionDrift, 0, len(paths)),
+	}
+	if generatedAt, ok := generationTime(g.Config); ok {
+		report.GeneratedAt = &generatedAt
+	}
+	for _, path := range paths {
+		entry := g.actionDrift(path, analyzer)
+		if relPath, err := filepath.Rel(baseDir, path); err == nil {
+			entry.File = relPath
+		}
+		report.Actions = append(report.Actions, entry)
+		report.Summary.add(entry)
+	}
+
+	return report, nil
+}
+
+// add counts entry in the summary totals.
+func (s *DriftSummary) add(entry ActionDrift) {
+	s.Actions++
+	if entry.Docs.Status != DocsFresh {
+		s.StaleDocs++
+	}
+	if !entry.Pins.Compliant {
+		s.NonCompliantPins++
+	}
+	if entry.Outdated != nil {
+		s.OutdatedDeps += entry.Outdated.Total
+	}
+	if entry.Validation.Status == ValidationFailed || entry.Validation.Status == ValidationError {
+		s.ValidationFailures++
+	}
+	if len(entry.Owners) == 0 {
+		s.Unowned++
+	}
+}
+
+// actionDrift collects every drift signal for one action file.
+func (g *Generator) actionDrift(path string, analyzer *dependencies.Analyzer) ActionDrift {
+	entry := ActionDrift{
+		File:       path,
+		Docs:       g.docFreshness(path),
+		Validation: g.validationStatus(path),
+	}
+	if action, err := ParseActionYML(path); err == nil {
+		entry.Name = action.Name
+	}
+	repoRoot, _ := git.FindRepositoryRoot(filepath.Dir(path))
+	if owners, err := ActionOwners(path, repoRoot); err == nil && owners != nil {
+		entry.Owners = owners
+	} else {
+		entry.Owners = []string{}
+	}
+
+	deps, err := analyzer.AnalyzeActionFile(path)
+	if err != nil {
+		entry.Pins = PinCompliance{Error: err.Error()}
+
+		return entry
+	}
+	entry.Pins = pinCompliance(deps)
+	if analyzer.GitHubClient != nil {
+		entry.Outdated = outdatedSummary(analyzer, deps)
+	}
+
+	return entry
+}
+
+// docFreshness regenerates the documentation for path in check mode without
+// printing anything.
+func (g *Generator) docFreshness(path string) DocFreshness {
+	checker := NewGeneratorWithDependencies(g.Config, NewNullOutput(), NewNullProgressManager())
+	checker.Check = true
+
+	err := checker.GenerateFromFile(path)
+	switch {
+	case err == nil:
+		return DocFreshness{Status: DocsFresh}
+	case errors.Is(err, ErrStaleDocumentation):
+		return DocFreshness{Status: DocsStale}
+	default:
+		return DocFreshness{Status: DocsError, Error: err.Error()}
+	}
+}
+
+// validationStatus validates path with the configured rule overrides.
+func (g *Generator) validationStatus(path string) ValidationStatus {
+	result, err := ValidateActionFile(path)
+	if err != nil {
+		return ValidationStatus{Status: ValidationError, Error: err.Error()}
+	}
+	result.ApplyRules(g.Config.Rules)
+
+	status := ValidationStatus{
+		Status:   ValidationPassed,
+		Errors:   result.Count(SeverityError),
+		Warnings: result.Count(SeverityWarning),
+	}
+	switch {
+	case result.Failed(g.Config.Strict):
+		status.Status = ValidationFailed
+	case status.Warnings > 0:
+		status.Status = ValidationWarnings
+	}
+
+	return status
+}
+
+// pinCompliance counts pinned and floating dependencies. Local actions and
+// shell scripts are not counted.
+func pinCompliance(deps []dependencies.Dependency) PinCompliance {
+	var pins PinCompliance
+	for _, dep := range deps {
+		if dep.IsLocalAction || dep.IsShellScript {
+			continue
+		}
+		pins.Total++
+		if dep.IsPinned {
+			pins.Pinned++
+		} else {
+			pins.Floating++
+		}
+	}
+	pins.Compliant = pins.Floating == 0
+
+	return pins
+}
+
+// outdatedSummary counts dependencies with newer versions, or returns nil when
+// they could not be checked.
+func outdatedSummary(analyzer *dependencies.Analyzer, deps []dependencies.Dependency) *OutdatedSummary {
+	outdated, err := analyzer.CheckOutdated(deps)
+	if err != nil {
+		return nil
+	}
+
+	summary := &OutdatedSummary{Total: len(outdated)}
+	for _, dep := range outdated {
+		switch dep.UpdateType {
+		case "major":
+			summary.Major++
+		case "minor":
+			summary.Minor++
+		case "patch":
+			summary.Patch++
+		}
+	}
+
+	return summary
+}
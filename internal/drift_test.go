@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestGenerator_DriftReport(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	testutil.SetupTestTemplates(t, tmpDir)
+
+	documented := filepath.Join(tmpDir, "documented", "action.yml")
+	testutil.WriteTestFile(t, documented, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "documented", "action.meta.yml"), "owners: ['@acme/docs']\n")
+	floating := filepath.Join(tmpDir, "floating", "action.yml")
+	testutil.WriteTestFile(t, floating, testutil.MustReadFixture("actions/composite/with-dependencies.yml"))
+	invalid := filepath.Join(tmpDir, "invalid", "action.yml")
+	testutil.WriteTestFile(t, invalid, testutil.MustReadFixture("actions/invalid/missing-description.yml"))
+
+	config := &AppConfig{
+		OutputFormat: "md",
+		Quiet:        true,
+		Template:     filepath.Join(tmpDir, "templates", "readme.tmpl"),
+	}
+	generator := NewGenerator(config)
+	testutil.AssertNoError(t, generator.GenerateFromFile(documented))
+
+	report, err := generator.DriftReport([]string{documented, floating, invalid}, tmpDir, nil)
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertEqual(t, DriftSchemaVersion, report.SchemaVersion)
+	testutil.AssertEqual(t, DriftSummary{
+		Actions:            3,
+		StaleDocs:          2,
+		NonCompliantPins:   1,
+		ValidationFailures: 1,
+		Unowned:            2,
+	}, report.Summary)
+
+	byFile := make(map[string]ActionDrift)
+	for _, action := range report.Actions {
+		byFile[action.File] = action
+	}
+
+	fresh := byFile[filepath.Join("documented", "action.yml")]
+	testutil.AssertEqual(t, DocsFresh, fresh.Docs.Status)
+	testutil.AssertEqual(t, ValidationPassed, fresh.Validation.Status)
+	testutil.AssertEqual(t, "Simple JavaScript Action", fresh.Name)
+	testutil.AssertEqual(t, "@acme/docs", strings.Join(fresh.Owners, " "))
+	if fresh.Outdated != nil {
+		t.Error("expected outdated dependencies to be skipped without a GitHub client")
+	}
+
+	unpinned := byFile[filepath.Join("floating", "action.yml")]
+	testutil.AssertEqual(t, DocsStale, unpinned.Docs.Status)
+	testutil.AssertEqual(t, PinCompliance{Total: 3, Floating: 3}, unpinned.Pins)
+
+	failed := byFile[filepath.Join("invalid", "action.yml")]
+	testutil.AssertEqual(t, ValidationFailed, failed.Validation.Status)
+}
@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// quotedName matches a string literal that is not a template literal with substitutions.
+const quotedName = `(?:"([^"\n]+)"|'([^'\n]+)'|` + "`([^`$\n]+)`)"
+
+// Calls and environment reads of @actions/core in JavaScript sources, also in
+// bundles that call them as core_1.getInput(...) or (0, core.getInput)(...).
+var (
+	jsInputCall  = regexp.MustCompile(`\bget(?:Boolean|Multiline)?Input\)?\s*\(\s*` + quotedName)
+	jsOutputCall = regexp.MustCompile(`\bsetOutput\)?\s*\(\s*` + quotedName)
+	jsInputEnv   = regexp.MustCompile(`\bprocess\.env(?:\.(INPUT_\w+)|\[\s*["'](INPUT_[^"'\n]+)["']\s*\])`)
+)
+
+// EntrypointReference is an input or output name used by a JavaScript entrypoint.
+type EntrypointReference struct {
+	Name string // Input or output name, or the INPUT_ variable for environment reads
+	Line int
+	Env  bool // Read from process.env instead of core.getInput
+}
+
+// EntrypointUsage lists the inputs and outputs a JavaScript entrypoint uses,
+// each once at its first use.
+type EntrypointUsage struct {
+	File    string
+	Inputs  []EntrypointReference
+	Outputs []EntrypointReference
+}
+
+// ScanEntrypoint finds the core.getInput, core.setOutput and process.env.INPUT_
+// uses in a JavaScript file. The scan is textual, so names built at runtime are
+// not found.
+func ScanEntrypoint(path string) (EntrypointUsage, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- entrypoint path from action.yml
+	if err != nil {
+		return EntrypointUsage{}, fmt.Errorf("failed to read entrypoint %s: %w", path, err)
+	}
+
+	usage := EntrypointUsage{File: path}
+	for i, line := range strings.Split(string(content), "\n") {
+		for _, match := range jsInputCall.FindAllStringSubmatch(line, -1) {
+			usage.Inputs = addReference(usage.Inputs, EntrypointReference{Name: firstGroup(match), Line: i + 1})
+		}
+		for _, match := range jsInputEnv.FindAllStringSubmatch(line, -1) {
+			usage.Inputs = addReference(usage.Inputs, EntrypointReference{Name: firstGroup(match), Line: i + 1, Env: true})
+		}
+		for _, match := range jsOutputCall.FindAllStringSubmatch(line, -1) {
+			usage.Outputs = addReference(usage.Outputs, EntrypointReference{Name: firstGroup(match), Line: i + 1})
+		}
+	}
+
+	return usage, nil
+}
+
+// ValidateEntrypoints scans the pre, main and post scripts of a node action for
+// inputs and outputs that action.yml does not declare. Other actions have no findings.
+func ValidateEntrypoints(actionPath string, action *ActionYML) ([]Finding, error) {
+	if using, _ := action.Runs["using"].(string); !strings.HasPrefix(strings.ToLower(using), "node") {
+		return nil, nil
+	}
+
+	var findings []Finding
+	for _, key := range []string{"pre", "main", "post"} {
+		script, _ := action.Runs[key].(string)
+		if script == "" {
+			continue
+		}
+		usage, err := ScanEntrypoint(filepath.Join(filepath.Dir(actionPath), script))
+		if err != nil {
+			return findings, err
+		}
+		findings = append(findings, undeclaredFindings(action, usage)...)
+	}
+
+	return findings, nil
+}
+
+// undeclaredFindings reports the inputs and outputs in usage missing from action.
+func undeclaredFindings(action *ActionYML, usage EntrypointUsage) []Finding {
+	declared := make([]string, 0, len(action.Inputs))
+	for name := range action.Inputs {
+		declared = append(declared, InputEnvName(name))
+	}
+
+	var findings []Finding
+	for _, input := range usage.Inputs {
+		env := input.Name
+		if !input.Env {
+			env = InputEnvName(input.Name) // getInput matches names case-insensitively
+		}
+		if slices.Contains(declared, env) {
+			continue
+		}
+		message := fmt.Sprintf("Entrypoint reads input '%s' that is not declared in action.yml", input.Name)
+		if input.Env {
+			message = fmt.Sprintf("Entrypoint reads %s, but no input declared in action.yml maps to it", input.Name)
+		}
+		findings = append(findings, Finding{
+			RuleID:     RuleUndeclaredInput,
+			Severity:   SeverityWarning,
+			Field:      "inputs",
+			Message:    message,
+			File:       usage.File,
+			Line:       input.Line,
+			Suggestion: "Declare the input under 'inputs:' so it is documented and can be set with 'with:'",
+		})
+	}
+	for _, output := range usage.Outputs {
+		if _, ok := action.Outputs[output.Name]; ok {
+			continue
+		}
+		findings = append(findings, Finding{
+			RuleID:     RuleUndeclaredOutput,
+			Severity:   SeverityWarning,
+			Field:      "outputs",
+			Message:    fmt.Sprintf("Entrypoint sets output '%s' that is not declared in action.yml", output.Name),
+			File:       usage.File,
+			Line:       output.Line,
+			Suggestion: "Declare the output under 'outputs:' so it is documented",
+		})
+	}
+
+	return findings
+}
+
+// addReference appends ref unless a reference to the same name is already listed.
+func addReference(refs []EntrypointReference, ref EntrypointReference) []EntrypointReference {
+	if ref.Name == "" || slices.ContainsFunc(refs, func(r EntrypointReference) bool { return r.Name == ref.Name }) {
+		return refs
+	}
+
+	return append(refs, ref)
+}
+
+// firstGroup returns the first non-empty capture group of a regexp match.
+func firstGroup(match []string) string {
+	for _, group := range match[1:] {
+		if group != "" {
+			return group
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+const testEntrypoint = `const core = require('@actions/core');
+const token = core.getInput('token', { required: true });
+const who = (0, core_1.getInput)("Who to greet");
+const dry = core.getBooleanInput(` + "`dry-run`" + `);
+const legacy = process.env.INPUT_TOKEN + process.env['INPUT_LEGACY'];
+const dynamic = core.getInput(` + "`${prefix}-name`" + `);
+core.setOutput('time', new Date());
+core.setOutput("greeting", who);
+core.setOutput('time', new Date());
+function getInput(name, options) { return name; }
+`
+
+func TestScanEntrypoint(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	path := filepath.Join(tmpDir, "index.js")
+	testutil.WriteTestFile(t, path, testEntrypoint)
+
+	usage, err := ScanEntrypoint(path)
+	testutil.AssertNoError(t, err)
+
+	expectedInputs := []EntrypointReference{
+		{Name: "token", Line: 2},
+		{Name: "Who to greet", Line: 3},
+		{Name: "dry-run", Line: 4},
+		{Name: "INPUT_TOKEN", Line: 5, Env: true},
+		{Name: "INPUT_LEGACY", Line: 5, Env: true},
+	}
+	testutil.AssertEqual(t, len(expectedInputs), len(usage.Inputs))
+	for i, input := range usage.Inputs {
+		testutil.AssertEqual(t, expectedInputs[i], input)
+	}
+
+	testutil.AssertEqual(t, 2, len(usage.Outputs))
+	testutil.AssertEqual(t, EntrypointReference{Name: "time", Line: 7}, usage.Outputs[0])
+	testutil.AssertEqual(t, EntrypointReference{Name: "greeting", Line: 8}, usage.Outputs[1])
+}
+
+func TestValidateEntrypoints(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "dist", "index.js"), testEntrypoint)
+
+	action := &ActionYML{
+		Inputs: map[string]ActionInput{
+			"token":        {Description: "Token"},
+			"who to greet": {Description: "Person"},
+		},
+		Outputs: map[string]ActionOutput{"time": {Description: "Time"}},
+		Runs:    map[string]any{"using": "node20", "main": "dist/index.js"},
+	}
+
+	findings, err := ValidateEntrypoints(actionPath, action)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 3, len(findings))
+	testutil.AssertEqual(t, RuleUndeclaredInput, findings[0].RuleID)
+	testutil.AssertStringContains(t, findings[0].Message, "'dry-run'")
+	testutil.AssertEqual(t, filepath.Join(tmpDir, "dist", "index.js"), findings[0].File)
+	testutil.AssertEqual(t, 4, findings[0].Line)
+	testutil.AssertEqual(t, RuleUndeclaredInput, findings[1].RuleID)
+	testutil.AssertStringContains(t, findings[1].Message, "INPUT_LEGACY")
+	testutil.AssertEqual(t, RuleUndeclaredOutput, findings[2].RuleID)
+	testutil.AssertStringContains(t, findings[2].Message, "'greeting'")
+
+	action.Runs["main"] = "dist/missing.js"
+	_, err = ValidateEntrypoints(actionPath, action)
+	testutil.AssertError(t, err)
+
+	docker := &ActionYML{Runs: map[string]any{"using": "docker", "image": "Dockerfile"}}
+	findings, err = ValidateEntrypoints(actionPath, docker)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(findings))
+}
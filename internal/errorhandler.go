@@ -40,6 +40,7 @@ func NewErrorHandler(output *ColoredOutput) *ErrorHandler {
 // HandleError handles contextual errors and exits with appropriate code.
 func (eh *ErrorHandler) HandleError(err *errors.ContextualError) {
 	eh.output.ErrorWithSuggestions(err)
+	_ = FlushDiagnostics()
 	os.Exit(exitCodeError)
 }
 
@@ -4,6 +4,8 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 )
 
@@ -26,6 +28,9 @@ const (
 	ErrCodeFileWrite          ErrorCode = "FILE_WRITE_ERROR"
 	ErrCodeDependencyAnalysis ErrorCode = "DEPENDENCY_ERROR"
 	ErrCodeCacheAccess        ErrorCode = "CACHE_ERROR"
+	ErrCodeSecretDetected     ErrorCode = "SECRET_DETECTED"
+	ErrCodeResourceLimit      ErrorCode = "RESOURCE_LIMIT"
+	ErrCodeOutputConflict     ErrorCode = "OUTPUT_CONFLICT"
 	ErrCodeUnknown            ErrorCode = "UNKNOWN_ERROR"
 )
 
@@ -37,6 +42,8 @@ type ContextualError struct {
 	Suggestions []string
 	HelpURL     string
 	Details     map[string]string
+	// Fixes are machine-applicable fixes for the error, when known.
+	Fixes []Fix
 }
 
 // Error implements the error interface.
@@ -56,8 +63,8 @@ func (ce *ContextualError) Error() string {
 	// Add details if available
 	if len(ce.Details) > 0 {
 		b.WriteString("\n\nDetails:")
-		for key, value := range ce.Details {
-			b.WriteString(fmt.Sprintf("\n  %s: %s", key, value))
+		for _, key := range slices.Sorted(maps.Keys(ce.Details)) {
+			b.WriteString(fmt.Sprintf("\n  %s: %s", key, ce.Details[key]))
 		}
 	}
 
@@ -69,6 +76,14 @@ func (ce *ContextualError) Error() string {
 		}
 	}
 
+	// Add available fixes
+	if len(ce.Fixes) > 0 {
+		b.WriteString("\n\nAvailable fixes:")
+		for _, fix := range ce.Fixes {
+			b.WriteString("\n  • " + fix.Description)
+		}
+	}
+
 	// Add help URL
 	if ce.HelpURL != "" {
 		b.WriteString("\n\nFor more help: " + ce.HelpURL)
@@ -176,6 +191,9 @@ func GetHelpURL(code ErrorCode) string {
 		ErrCodeFileWrite:          "#file-write-errors",
 		ErrCodeDependencyAnalysis: "#dependency-analysis",
 		ErrCodeCacheAccess:        "#cache-errors",
+		ErrCodeSecretDetected:     "#secret-detected",
+		ErrCodeResourceLimit:      "#resource-limits",
+		ErrCodeOutputConflict:     "#output-conflicts",
 	}
 
 	if anchor, ok := anchors[code]; ok {
@@ -122,6 +122,27 @@ func TestContextualError_Error(t *testing.T) {
 	}
 }
 
+func TestContextualError_ErrorDetailsOrder(t *testing.T) {
+	t.Parallel()
+
+	err := &ContextualError{
+		Code: ErrCodeConfiguration,
+		Err:  errors.New("config error"),
+		Details: map[string]string{
+			"zeta":  "3",
+			"alpha": "1",
+			"mid":   "2",
+		},
+	}
+
+	expected := "\n  alpha: 1\n  mid: 2\n  zeta: 3"
+	for range 10 {
+		if result := err.Error(); !strings.Contains(result, expected) {
+			t.Fatalf("expected details in sorted order, got:\n%s", result)
+		}
+	}
+}
+
 func TestContextualError_Unwrap(t *testing.T) {
 	t.Parallel()
 
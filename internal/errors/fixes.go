@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// TextEdit replaces OldText, found at a 1-based line and byte column of a file,
+// with NewText. OldText makes the edit verifiable: it is only applied when the
+// file still contains it at that position.
+type TextEdit struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	OldText string `json:"old_text"`
+	NewText string `json:"new_text"`
+}
+
+// Fix is a machine-applicable fix made of text edits. Safe fixes keep the
+// intent of the file and may be applied without review.
+type Fix struct {
+	Description string     `json:"description"`
+	Edits       []TextEdit `json:"edits"`
+	Safe        bool       `json:"safe"`
+}
+
+// ErrEditMismatch is returned when an edit does not match the content it is applied to.
+var ErrEditMismatch = New(ErrCodeValidation, "edit does not match the file content")
+
+// WithFixes adds machine-applicable fixes to a ContextualError.
+func (ce *ContextualError) WithFixes(fixes ...Fix) *ContextualError {
+	ce.Fixes = append(ce.Fixes, fixes...)
+
+	return ce
+}
+
+// ApplyEdits applies edits to content and returns the edited content. Every
+// edit must match the original content and edits must not overlap; otherwise
+// content is returned unchanged with an error.
+func ApplyEdits(content []byte, edits []TextEdit) ([]byte, error) {
+	type span struct {
+		start, end int
+		text       string
+	}
+
+	lineStarts := lineOffsets(content)
+	spans := make([]span, 0, len(edits))
+	for _, edit := range edits {
+		if edit.Line < 1 || edit.Line > len(lineStarts) || edit.Column < 1 {
+			return content, fmt.Errorf("%w: position %d:%d is outside the file", ErrEditMismatch, edit.Line, edit.Column)
+		}
+		start := lineStarts[edit.Line-1] + edit.Column - 1
+		end := start + len(edit.OldText)
+		if end > len(content) || !bytes.Equal(content[start:end], []byte(edit.OldText)) {
+			return content, fmt.Errorf("%w: expected %q at %d:%d", ErrEditMismatch, edit.OldText, edit.Line, edit.Column)
+		}
+		spans = append(spans, span{start: start, end: end, text: edit.NewText})
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	var out bytes.Buffer
+	last := 0
+	for _, s := range spans {
+		if s.start < last {
+			return content, fmt.Errorf("%w: overlapping edits", ErrEditMismatch)
+		}
+		out.Write(content[last:s.start])
+		out.WriteString(s.text)
+		last = s.end
+	}
+	out.Write(content[last:])
+
+	return out.Bytes(), nil
+}
+
+// lineOffsets returns the byte offset at which each line of content starts.
+func lineOffsets(content []byte) []int {
+	offsets := []int{0}
+	for i, b := range content {
+		if b == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+
+	return offsets
+}
@@ -0,0 +1,88 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestApplyEdits(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("runs:\n  using: node-20\ninputs:\n  debug:\n    default: true\n")
+
+	tests := []struct {
+		name    string
+		edits   []TextEdit
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "applies edits on several lines",
+			edits: []TextEdit{
+				{Line: 5, Column: 14, OldText: "true", NewText: "'true'"},
+				{Line: 2, Column: 10, OldText: "node-20", NewText: "node20"},
+			},
+			want: "runs:\n  using: node20\ninputs:\n  debug:\n    default: 'true'\n",
+		},
+		{
+			name:    "rejects edit that does not match",
+			edits:   []TextEdit{{Line: 2, Column: 10, OldText: "node20", NewText: "node24"}},
+			wantErr: true,
+		},
+		{
+			name:    "rejects position outside the file",
+			edits:   []TextEdit{{Line: 42, Column: 1, OldText: "x", NewText: "y"}},
+			wantErr: true,
+		},
+		{
+			name: "rejects overlapping edits",
+			edits: []TextEdit{
+				{Line: 2, Column: 10, OldText: "node-20", NewText: "node20"},
+				{Line: 2, Column: 14, OldText: "-20", NewText: "20"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ApplyEdits(content, tt.edits)
+			if tt.wantErr {
+				if !errors.Is(err, ErrEditMismatch) {
+					t.Fatalf("expected ErrEditMismatch, got %v", err)
+				}
+				if string(got) != string(content) {
+					t.Errorf("content changed on error: %q", got)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ApplyEdits() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContextualError_WithFixes(t *testing.T) {
+	t.Parallel()
+
+	err := New(ErrCodeValidation, "invalid runtime").WithFixes(Fix{
+		Description: "Replace runtime 'node-20' with 'node20'",
+		Edits:       []TextEdit{{Line: 2, Column: 10, OldText: "node-20", NewText: "node20"}},
+		Safe:        true,
+	})
+
+	if len(err.Fixes) != 1 {
+		t.Fatalf("expected 1 fix, got %d", len(err.Fixes))
+	}
+	if !strings.Contains(err.Error(), "Available fixes:\n  • Replace runtime 'node-20' with 'node20'") {
+		t.Errorf("error message does not list fixes: %s", err.Error())
+	}
+}
@@ -32,6 +32,9 @@ func getSuggestionHandler(code ErrorCode) func(map[string]string) []string {
 		ErrCodeFileWrite:          getFileWriteSuggestions,
 		ErrCodeDependencyAnalysis: getDependencyAnalysisSuggestions,
 		ErrCodeCacheAccess:        getCacheAccessSuggestions,
+		ErrCodeSecretDetected:     getSecretDetectedSuggestions,
+		ErrCodeResourceLimit:      getResourceLimitSuggestions,
+		ErrCodeOutputConflict:     getOutputConflictSuggestions,
 	}
 
 	// Special cases for handlers without context
@@ -43,7 +46,7 @@ func getSuggestionHandler(code ErrorCode) func(map[string]string) []string {
 	case ErrCodeFileNotFound, ErrCodePermission, ErrCodeInvalidYAML, ErrCodeInvalidAction,
 		ErrCodeNoActionFiles, ErrCodeGitHubAPI, ErrCodeConfiguration, ErrCodeValidation,
 		ErrCodeTemplateRender, ErrCodeFileWrite, ErrCodeDependencyAnalysis, ErrCodeCacheAccess,
-		ErrCodeUnknown:
+		ErrCodeSecretDetected, ErrCodeResourceLimit, ErrCodeOutputConflict, ErrCodeUnknown:
 		// These cases are handled by the map above
 	}
 
@@ -343,7 +346,7 @@ func getTemplateSuggestions(context map[string]string) []string {
 		suggestions = append(suggestions,
 			"Current theme: "+theme,
 			"Try using a different theme: --theme github",
-			"Available themes: default, github, gitlab, minimal, professional",
+			"Available themes: default, github, gitlab, minimal, professional, docs-site, badge-minimal",
 		)
 	}
 
@@ -415,3 +418,61 @@ func getCacheAccessSuggestions(context map[string]string) []string {
 
 	return suggestions
 }
+
+func getSecretDetectedSuggestions(context map[string]string) []string {
+	suggestions := []string{
+		"Remove the secret from the template or the configuration variable that feeds it",
+		"Never reference .Config.GitHubToken in templates",
+		"Store credentials in environment variables, not in template variables",
+	}
+
+	if expression, ok := context["expression"]; ok && expression != "" {
+		suggestions = append([]string{
+			"Offending template expression: {{ " + expression + " }}",
+		}, suggestions...)
+	}
+
+	if outputPath, ok := context["output_path"]; ok {
+		suggestions = append(suggestions,
+			"Nothing was written to "+outputPath,
+		)
+	}
+
+	return suggestions
+}
+
+func getResourceLimitSuggestions(context map[string]string) []string {
+	suggestions := []string{}
+
+	if limit, ok := context["limit"]; ok {
+		suggestions = append(suggestions,
+			"Raise limits."+limit+" in your configuration if this input is expected",
+		)
+	}
+
+	suggestions = append(suggestions,
+		"Narrow the run to a specific directory or file instead of a recursive search",
+		"Check the input for unexpectedly large or generated YAML",
+		"See the limits section of docs/configuration.md for all limits and their defaults",
+	)
+
+	return suggestions
+}
+
+func getOutputConflictSuggestions(context map[string]string) []string {
+	suggestions := []string{}
+
+	if actions, ok := context["actions"]; ok {
+		suggestions = append(suggestions,
+			"Conflicting action files: "+actions,
+		)
+	}
+
+	suggestions = append(suggestions,
+		"Give each action a unique 'name:' when generating HTML, which is named after the action",
+		"Do not combine --output-dir or an absolute --output with a recursive run over several actions",
+		"Generate the actions one directory at a time with separate output directories",
+	)
+
+	return suggestions
+}
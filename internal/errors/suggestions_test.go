@@ -310,6 +310,9 @@ func TestGetSuggestions_EmptyContext(t *testing.T) {
 		ErrCodeFileWrite,
 		ErrCodeDependencyAnalysis,
 		ErrCodeCacheAccess,
+		ErrCodeSecretDetected,
+		ErrCodeResourceLimit,
+		ErrCodeOutputConflict,
 	}
 
 	for _, code := range errorCodes {
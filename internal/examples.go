@@ -0,0 +1,285 @@
+package internal
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
+)
+
+// remoteUses matches uses references to actions in other repositories:
+// owner/repo@ref or owner/repo/path@ref.
+var remoteUses = regexp.MustCompile(`^[\w.-]+/[\w.-]+(?:/[^@\s]+)?@[^@\s/][^@\s]*$`)
+
+// Keys GitHub accepts in workflows, jobs and steps.
+var (
+	workflowKeys = []string{"name", "run-name", "on", "permissions", "env", "defaults", "concurrency", "jobs"}
+	jobKeys      = []string{
+		"name", "permissions", "needs", "if", "runs-on", "environment", "concurrency", "outputs", "env",
+		"defaults", "steps", "timeout-minutes", "strategy", "continue-on-error", "container", "services",
+		"uses", "with", "secrets",
+	}
+	stepKeys = []string{
+		"id", "if", "name", "uses", "run", "shell", "with", "env", "continue-on-error", "timeout-minutes",
+		"working-directory",
+	}
+)
+
+// Example is a YAML code block of generated documentation.
+type Example struct {
+	Line    int // Line of the first line of YAML in the document
+	Content string
+}
+
+// ExtractExamples returns the YAML code blocks of a markdown (```yaml) or
+// AsciiDoc ([source,yaml] with ----) document. Indented blocks, such as those in
+// list items, are unindented.
+func ExtractExamples(doc string) []Example {
+	lines := strings.Split(doc, "\n")
+
+	var examples []Example
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		var closing string
+		switch {
+		case trimmed == "```yaml" || trimmed == "```yml":
+			closing = "```"
+		case (strings.HasPrefix(trimmed, "[source,yaml") || strings.HasPrefix(trimmed, "[source,yml")) &&
+			i+1 < len(lines) && strings.TrimSpace(lines[i+1]) == "----":
+			closing = "----"
+			i++
+		default:
+			continue
+		}
+
+		indent := lines[i][:len(lines[i])-len(strings.TrimLeft(lines[i], " \t"))]
+		example := Example{Line: i + 2}
+		var body []string
+		for i++; i < len(lines) && strings.TrimSpace(lines[i]) != closing; i++ {
+			body = append(body, strings.TrimPrefix(lines[i], indent))
+		}
+		example.Content = strings.Join(body, "\n")
+		examples = append(examples, example)
+	}
+
+	return examples
+}
+
+// ValidateExamples checks the YAML examples of a generated document, written to
+// docPath, for the action described by data. Each example must parse; workflows,
+// jobs and steps may only use workflow syntax keys; uses references must be well
+// formed and point at existing local actions, and examples of the action itself
+// may only pass declared inputs. Other YAML, such as GitLab CI, is only parsed.
+func ValidateExamples(doc, docPath string, data *TemplateData, repoRoot string) []Finding {
+	var findings []Finding
+	for _, example := range ExtractExamples(doc) {
+		checker := exampleChecker{data: data, repoRoot: repoRoot, docPath: docPath, line: example.Line}
+
+		var parsed any
+		if err := yamlsafe.Unmarshal([]byte(example.Content), &parsed); err != nil {
+			message, _, _ := strings.Cut(err.Error(), "\n")
+			checker.add(RuleInvalidExample, SeverityError, "Example is not valid YAML: "+message,
+				"Fix the YAML in the template that renders the example")
+		} else {
+			checker.checkDocument(parsed)
+		}
+		findings = append(findings, checker.findings...)
+	}
+
+	return findings
+}
+
+// exampleChecker collects the findings of one example.
+type exampleChecker struct {
+	data     *TemplateData
+	repoRoot string
+	docPath  string
+	line     int
+	findings []Finding
+}
+
+// add records a finding at the start of the example.
+func (c *exampleChecker) add(ruleID string, severity Severity, message, suggestion string) {
+	c.findings = append(c.findings, Finding{
+		RuleID:     ruleID,
+		Severity:   severity,
+		Field:      "examples",
+		Message:    message,
+		File:       c.docPath,
+		Line:       c.line,
+		Suggestion: suggestion,
+	})
+}
+
+// checkDocument checks an example that is a workflow, a job, a list of steps or a step.
+func (c *exampleChecker) checkDocument(doc any) {
+	switch value := doc.(type) {
+	case map[string]any:
+		switch {
+		case value["jobs"] != nil:
+			c.checkWorkflow(value)
+		case value["steps"] != nil:
+			c.checkKeys("Job", value, jobKeys)
+			if steps, ok := value["steps"].([]any); ok {
+				c.checkSteps("Step", steps)
+			}
+		case value["uses"] != nil || value["run"] != nil:
+			c.checkStep("Step", value)
+		}
+	case []any:
+		if slices.ContainsFunc(value, isStepLike) {
+			c.checkSteps("Step", value)
+		}
+	}
+}
+
+// checkWorkflow checks the keys and jobs of a workflow.
+func (c *exampleChecker) checkWorkflow(workflow map[string]any) {
+	c.checkKeys("Workflow", workflow, workflowKeys)
+	if _, ok := workflow["on"]; !ok {
+		c.add(RuleInvalidExample, SeverityError, "Workflow example has no 'on' trigger", "Add an 'on:' trigger")
+	}
+	jobs, ok := workflow["jobs"].(map[string]any)
+	if !ok {
+		c.add(RuleInvalidExample, SeverityError, "Workflow example 'jobs' is not a mapping of job IDs", "")
+
+		return
+	}
+	for _, id := range slices.Sorted(maps.Keys(jobs)) {
+		job, ok := jobs[id].(map[string]any)
+		if !ok {
+			c.add(RuleInvalidExample, SeverityError, fmt.Sprintf("Job '%s' is not a mapping", id), "")
+
+			continue
+		}
+		c.checkJob(fmt.Sprintf("Job '%s'", id), job)
+	}
+}
+
+// checkJob checks the keys and steps of a job, or the reusable workflow it calls.
+func (c *exampleChecker) checkJob(where string, job map[string]any) {
+	c.checkKeys(where, job, jobKeys)
+	if uses, ok := job["uses"].(string); ok {
+		c.checkUses(where, uses, nil)
+
+		return
+	}
+	if _, ok := job["runs-on"]; !ok {
+		c.add(RuleInvalidExample, SeverityError, where+" has no 'runs-on'", "Add 'runs-on: ubuntu-latest'")
+	}
+	steps, ok := job["steps"].([]any)
+	if !ok {
+		c.add(RuleInvalidExample, SeverityError, where+" has no list of 'steps'", "")
+
+		return
+	}
+	c.checkSteps(where+" step", steps)
+}
+
+// checkSteps checks each step of a list, numbered from 1.
+func (c *exampleChecker) checkSteps(where string, steps []any) {
+	for i, raw := range steps {
+		label := fmt.Sprintf("%s %d", where, i+1)
+		step, ok := raw.(map[string]any)
+		if !ok {
+			c.add(RuleInvalidExample, SeverityError, label+" is not a mapping", "")
+
+			continue
+		}
+		c.checkStep(label, step)
+	}
+}
+
+// checkStep checks the keys of a step and the action it uses.
+func (c *exampleChecker) checkStep(where string, step map[string]any) {
+	c.checkKeys(where, step, stepKeys)
+	uses, hasUses := step["uses"]
+	_, hasRun := step["run"]
+	switch {
+	case hasUses && hasRun:
+		c.add(RuleInvalidExample, SeverityError, where+" declares both 'run' and 'uses'", "")
+	case !hasUses && !hasRun:
+		c.add(RuleInvalidExample, SeverityError, where+" declares neither 'run' nor 'uses'", "")
+	case hasUses:
+		with, ok := step["with"].(map[string]any)
+		if _, set := step["with"]; set && !ok {
+			c.add(RuleInvalidExample, SeverityError, where+" 'with' is not a mapping of inputs", "")
+		}
+		c.checkUses(where, fmt.Sprint(uses), with)
+	}
+}
+
+// checkKeys reports the keys of value missing from allowed, in sorted order.
+func (c *exampleChecker) checkKeys(where string, value map[string]any, allowed []string) {
+	for _, key := range slices.Sorted(maps.Keys(value)) {
+		if !slices.Contains(allowed, key) {
+			c.add(RuleInvalidExample, SeverityError, fmt.Sprintf("%s has unknown key '%s'", where, key),
+				"Use only keys of the GitHub workflow syntax")
+		}
+	}
+}
+
+// checkUses checks that uses is a well-formed reference, that local actions
+// exist and that examples of the documented action only pass its inputs.
+func (c *exampleChecker) checkUses(where, uses string, with map[string]any) {
+	switch {
+	case strings.HasPrefix(uses, "./"):
+		if c.repoRoot != "" && !localActionExists(filepath.Join(c.repoRoot, filepath.FromSlash(uses))) {
+			c.add(RuleUnresolvedExampleUses, SeverityError,
+				fmt.Sprintf("%s uses '%s', which is not an action in the repository", where, uses),
+				"Point 'uses' at a directory with an action.yml, relative to the repository root")
+		}
+	case strings.HasPrefix(uses, "docker://"):
+		if strings.TrimPrefix(uses, "docker://") == "" {
+			c.add(RuleUnresolvedExampleUses, SeverityError, where+" uses 'docker://' without an image", "")
+		}
+	case !remoteUses.MatchString(uses):
+		c.add(RuleUnresolvedExampleUses, SeverityError,
+			fmt.Sprintf("%s uses '%s', which is not an owner/repo@ref reference", where, uses),
+			"Reference actions as owner/repo@ref, owner/repo/path@ref, ./path or docker://image")
+	case strings.HasPrefix(uses, defaultOrgPlaceholder+"/"):
+		c.add(RuleUnresolvedExampleUses, SeverityWarning,
+			fmt.Sprintf("%s uses the placeholder '%s'", where, uses),
+			"Set 'organization' and 'repository' in the configuration, or generate inside a clone "+
+				"with a GitHub remote")
+	}
+
+	if c.data == nil || c.data.ActionYML == nil || uses != c.data.UsesStatement {
+		return
+	}
+	for _, input := range slices.Sorted(maps.Keys(with)) {
+		if _, ok := c.data.Inputs[input]; !ok {
+			c.add(RuleUnresolvedExampleUses, SeverityError,
+				fmt.Sprintf("%s passes input '%s', which %s does not declare", where, input, uses),
+				"Remove the input from the example or declare it in action.yml")
+		}
+	}
+}
+
+// isStepLike reports whether value is a mapping with uses or run.
+func isStepLike(value any) bool {
+	step, ok := value.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, hasUses := step["uses"]
+	_, hasRun := step["run"]
+
+	return hasUses || hasRun
+}
+
+// localActionExists reports whether dir contains an action.yml or action.yaml.
+func localActionExists(dir string) bool {
+	for _, name := range []string{ActionFileNameYML, ActionFileNameYAML} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestExtractExamples(t *testing.T) {
+	t.Parallel()
+
+	doc := "# Title\n\n```yaml\nname: CI\n```\n\n- item\n  ```yml\n  with:\n    a: b\n  ```\n\n" +
+		"```bash\necho hi\n```\n\n[source,yaml]\n----\n- run: echo hi\n----\n"
+	examples := ExtractExamples(doc)
+
+	testutil.AssertEqual(t, 3, len(examples))
+	testutil.AssertEqual(t, 4, examples[0].Line)
+	testutil.AssertEqual(t, "name: CI", examples[0].Content)
+	testutil.AssertEqual(t, 9, examples[1].Line)
+	testutil.AssertEqual(t, "with:\n  a: b", examples[1].Content)
+	testutil.AssertEqual(t, 19, examples[2].Line)
+	testutil.AssertEqual(t, "- run: echo hi", examples[2].Content)
+}
+
+func TestValidateExamples(t *testing.T) {
+	t.Parallel()
+
+	repoRoot, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	testutil.WriteTestFile(t, filepath.Join(repoRoot, ".github", "actions", "setup", "action.yml"), "name: Setup\n")
+
+	data := &TemplateData{
+		ActionYML: &ActionYML{
+			Name:   "Greeter",
+			Inputs: map[string]ActionInput{"who": {Description: "Who to greet"}},
+		},
+		UsesStatement: "acme/greeter@v1",
+	}
+
+	tests := []struct {
+		name     string
+		example  string
+		ruleID   string
+		severity Severity
+		message  string
+	}{
+		{name: "valid workflow", example: "name: CI\non: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n" +
+			"    steps:\n      - uses: ./.github/actions/setup\n      - uses: acme/greeter@v1\n        with:\n" +
+			"          who: world\n"},
+		{name: "gitlab ci is only parsed", example: "greeter:\n  stage: build\n  script:\n    - echo hi\n"},
+		{name: "yaml error", example: "- name: x\n y: z\n", ruleID: RuleInvalidExample, severity: SeverityError,
+			message: "Example is not valid YAML"},
+		{name: "missing trigger", example: "jobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n" +
+			"      - run: echo hi\n", ruleID: RuleInvalidExample, severity: SeverityError,
+			message: "Workflow example has no 'on' trigger"},
+		{name: "job without runner", example: "on: push\njobs:\n  build:\n    steps:\n      - run: echo hi\n",
+			ruleID: RuleInvalidExample, severity: SeverityError, message: "Job 'build' has no 'runs-on'"},
+		{name: "unknown step key", example: "- uses: acme/greeter@v1\n  inputs:\n    who: world\n",
+			ruleID: RuleInvalidExample, severity: SeverityError, message: "Step 1 has unknown key 'inputs'"},
+		{name: "run and uses", example: "- uses: acme/greeter@v1\n  run: echo hi\n",
+			ruleID: RuleInvalidExample, severity: SeverityError, message: "declares both 'run' and 'uses'"},
+		{name: "malformed uses", example: "- uses: acme/greeter\n", ruleID: RuleUnresolvedExampleUses,
+			severity: SeverityError, message: "not an owner/repo@ref reference"},
+		{name: "missing local action", example: "- uses: ./.github/actions/missing\n",
+			ruleID: RuleUnresolvedExampleUses, severity: SeverityError, message: "not an action in the repository"},
+		{name: "placeholder", example: "- uses: your-org/your-action@v1\n", ruleID: RuleUnresolvedExampleUses,
+			severity: SeverityWarning, message: "uses the placeholder 'your-org/your-action@v1'"},
+		{name: "undeclared input", example: "- uses: acme/greeter@v1\n  with:\n    whom: world\n",
+			ruleID: RuleUnresolvedExampleUses, severity: SeverityError,
+			message: "passes input 'whom', which acme/greeter@v1 does not declare"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc := "# Greeter\n\n```yaml\n" + tt.example + "```\n"
+			findings := ValidateExamples(doc, "README.md", data, repoRoot)
+			if tt.ruleID == "" {
+				testutil.AssertEqual(t, 0, len(findings))
+
+				return
+			}
+			testutil.AssertEqual(t, 1, len(findings))
+			testutil.AssertEqual(t, tt.ruleID, findings[0].RuleID)
+			testutil.AssertEqual(t, tt.severity, findings[0].Severity)
+			testutil.AssertStringContains(t, findings[0].Message, tt.message)
+			testutil.AssertEqual(t, "README.md:4", findings[0].Location())
+		})
+	}
+}
+
+func TestValidateExamples_Themes(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{
+		Name:        "Greeter Action",
+		Description: "Greets someone from a workflow",
+		Inputs: map[string]ActionInput{
+			"who":   {Description: "Who to greet", Required: true},
+			"greet": {Description: "Greeting", Default: "Hello"},
+		},
+		Outputs: map[string]ActionOutput{"time": {Description: "Greeting time"}},
+		Runs:    map[string]any{"using": "node20", "main": "index.js"},
+	}
+	config := DefaultAppConfig()
+	config.Organization = "acme"
+	config.Repository = "greeter"
+	data := BuildTemplateData(action, config, "", "")
+
+	for _, theme := range []string{ThemeDefault, ThemeGitHub, ThemeGitLab, ThemeMinimal, ThemeProfessional} {
+		doc, err := RenderReadme(data, TemplateOptions{TemplatePath: resolveThemeTemplate(theme), Format: "md"})
+		testutil.AssertNoError(t, err)
+		if findings := ValidateExamples(doc, "README.md", data, ""); len(findings) > 0 {
+			t.Errorf("theme %s renders invalid examples: %v", theme, findings)
+		}
+	}
+	doc, err := RenderReadme(data, TemplateOptions{
+		TemplatePath: resolveTemplatePath("templates/themes/asciidoc/readme.adoc"),
+		Format:       "asciidoc",
+	})
+	testutil.AssertNoError(t, err)
+	if findings := ValidateExamples(doc, "README.adoc", data, ""); len(findings) > 0 {
+		t.Errorf("asciidoc theme renders invalid examples: %v", findings)
+	}
+}
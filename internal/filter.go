@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Filter keys matched against sidecar metadata.
+const (
+	FilterKeyTag      = "tag"
+	FilterKeyCategory = "category"
+)
+
+// ActionFilter selects actions by their sidecar metadata. It matches when the
+// field equals any of Values, or none of them when Negate is set. Values are
+// compared case-insensitively.
+type ActionFilter struct {
+	Key    string
+	Values []string
+	Negate bool
+}
+
+// ParseActionFilter parses a filter expression of the form key=value or
+// key!=value, where value may list alternatives separated by commas
+// (tag=deploy,release). Supported keys are tag and category.
+func ParseActionFilter(expr string) (ActionFilter, error) {
+	key, value, negate := expr, "", false
+	if i := strings.Index(expr, "!="); i >= 0 {
+		key, value, negate = expr[:i], expr[i+2:], true
+	} else if i := strings.Index(expr, "="); i >= 0 {
+		key, value = expr[:i], expr[i+1:]
+	} else {
+		return ActionFilter{}, fmt.Errorf("invalid filter %q, use key=value (e.g. tag=deploy)", expr)
+	}
+
+	key = strings.ToLower(strings.TrimSpace(key))
+	if key != FilterKeyTag && key != FilterKeyCategory {
+		return ActionFilter{}, fmt.Errorf("invalid filter key %q in %q, use %s or %s",
+			key, expr, FilterKeyTag, FilterKeyCategory)
+	}
+
+	filter := ActionFilter{Key: key, Negate: negate}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			filter.Values = append(filter.Values, strings.ToLower(v))
+		}
+	}
+	if len(filter.Values) == 0 {
+		return ActionFilter{}, fmt.Errorf("invalid filter %q, value is empty", expr)
+	}
+
+	return filter, nil
+}
+
+// ParseActionFilters parses several filter expressions; an action must match all of them.
+func ParseActionFilters(exprs []string) ([]ActionFilter, error) {
+	filters := make([]ActionFilter, 0, len(exprs))
+	for _, expr := range exprs {
+		filter, err := ParseActionFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// Matches reports whether metadata satisfies the filter.
+func (f ActionFilter) Matches(metadata *ActionMetadata) bool {
+	var fields []string
+	switch f.Key {
+	case FilterKeyTag:
+		fields = metadata.Tags
+	case FilterKeyCategory:
+		fields = []string{metadata.Category}
+	}
+
+	matched := slices.ContainsFunc(fields, func(field string) bool {
+		return slices.Contains(f.Values, strings.ToLower(field))
+	})
+
+	return matched != f.Negate
+}
+
+// FilterActionFiles returns the action files in paths whose sidecar metadata
+// matches every filter, preserving their order.
+func FilterActionFiles(paths []string, filters []ActionFilter) ([]string, error) {
+	if len(filters) == 0 {
+		return paths, nil
+	}
+
+	var matched []string
+	for _, path := range paths {
+		metadata, err := LoadActionMetadata(path)
+		if err != nil {
+			return nil, err
+		}
+		if matchesAll(metadata, filters) {
+			matched = append(matched, path)
+		}
+	}
+
+	return matched, nil
+}
+
+// matchesAll reports whether metadata satisfies every filter.
+func matchesAll(metadata *ActionMetadata, filters []ActionFilter) bool {
+	for _, filter := range filters {
+		if !filter.Matches(metadata) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestParseActionFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expr        string
+		expected    ActionFilter
+		expectError bool
+	}{
+		{expr: "tag=deploy", expected: ActionFilter{Key: FilterKeyTag, Values: []string{"deploy"}}},
+		{
+			expr:     " Category = Build , Test ",
+			expected: ActionFilter{Key: FilterKeyCategory, Values: []string{"build", "test"}},
+		},
+		{expr: "tag!=legacy", expected: ActionFilter{Key: FilterKeyTag, Values: []string{"legacy"}, Negate: true}},
+		{expr: "deploy", expectError: true},
+		{expr: "owner=me", expectError: true},
+		{expr: "tag=", expectError: true},
+		{expr: "tag=,", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := ParseActionFilter(tt.expr)
+			if tt.expectError {
+				testutil.AssertError(t, err)
+
+				return
+			}
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tt.expected.Key, filter.Key)
+			testutil.AssertEqual(t, tt.expected.Negate, filter.Negate)
+			testutil.AssertEqual(t, strings.Join(tt.expected.Values, ","), strings.Join(filter.Values, ","))
+		})
+	}
+}
+
+func TestFilterActionFiles(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	sidecars := map[string]string{
+		"deploy": "category: release\ntags: [deploy, aws]\n",
+		"build":  "category: Build\ntags: [node]\n",
+		"plain":  "",
+	}
+	paths := make(map[string]string, len(sidecars))
+	var all []string
+	for _, name := range []string{"deploy", "build", "plain"} {
+		paths[name] = filepath.Join(tmpDir, name, "action.yml")
+		all = append(all, paths[name])
+		testutil.WriteTestFile(t, paths[name], testutil.MustReadFixture("actions/javascript/simple.yml"))
+		if sidecars[name] != "" {
+			testutil.WriteTestFile(t, filepath.Join(tmpDir, name, "action.meta.yml"), sidecars[name])
+		}
+	}
+
+	tests := []struct {
+		name     string
+		exprs    []string
+		expected []string
+	}{
+		{name: "no filters", expected: []string{"deploy", "build", "plain"}},
+		{name: "tag", exprs: []string{"tag=aws"}, expected: []string{"deploy"}},
+		{name: "category is case-insensitive", exprs: []string{"category=build"}, expected: []string{"build"}},
+		{name: "any of several values", exprs: []string{"tag=node,deploy"}, expected: []string{"deploy", "build"}},
+		{name: "negation", exprs: []string{"tag!=deploy"}, expected: []string{"build", "plain"}},
+		{name: "all filters must match", exprs: []string{"tag=deploy", "category=build"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filters, err := ParseActionFilters(tt.exprs)
+			testutil.AssertNoError(t, err)
+			matched, err := FilterActionFiles(all, filters)
+			testutil.AssertNoError(t, err)
+
+			var expected []string
+			for _, name := range tt.expected {
+				expected = append(expected, paths[name])
+			}
+			testutil.AssertEqual(t, strings.Join(expected, "\n"), strings.Join(matched, "\n"))
+		})
+	}
+}
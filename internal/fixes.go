@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+
+	errCodes "github.com/ivuorinen/gh-action-readme/internal/errors"
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
+)
+
+// runtimeSeparators are removed from runs.using values when looking for the runtime they meant.
+var runtimeSeparators = strings.NewReplacer("-", "", "_", "", " ", "", ".", "")
+
+// ApplySuggestions applies the safe fixes of the validation findings in paths,
+// rewriting the files in place, and returns the number of fixes applied.
+// Files that cannot be parsed are left for validation to report.
+func (g *Generator) ApplySuggestions(paths []string) (int, error) {
+	applied := 0
+	for _, path := range paths {
+		count, err := applyFileSuggestions(path, g.Config.Rules)
+		if err != nil {
+			return applied, err
+		}
+		if count > 0 {
+			g.Output.Success("Applied %d fix(es) to %s", count, path)
+		}
+		applied += count
+	}
+
+	return applied, nil
+}
+
+// FilesWithSuggestions returns the files of paths that ApplySuggestions would
+// rewrite, those with safe fixes of their findings.
+func (g *Generator) FilesWithSuggestions(paths []string) []string {
+	var files []string
+	for _, path := range paths {
+		if edits, _ := safeFixEdits(path, g.Config.Rules); len(edits) > 0 {
+			files = append(files, path)
+		}
+	}
+
+	return files
+}
+
+// safeFixEdits returns the edits of the safe fixes of the findings of one
+// action file and the number of fixes. Files that cannot be parsed have none.
+func safeFixEdits(path string, rules map[string]string) ([]errCodes.TextEdit, int) {
+	result, err := ValidateActionFile(path)
+	if err != nil {
+		return nil, 0
+	}
+	result.ApplyRules(rules)
+
+	var edits []errCodes.TextEdit
+	count := 0
+	for _, finding := range result.Findings {
+		if finding.Fix == nil || !finding.Fix.Safe {
+			continue
+		}
+		edits = append(edits, finding.Fix.Edits...)
+		count++
+	}
+
+	return edits, count
+}
+
+// applyFileSuggestions applies the safe fixes of the findings of one action file.
+func applyFileSuggestions(path string, rules map[string]string) (int, error) {
+	edits, count := safeFixEdits(path, rules)
+	if count == 0 {
+		return 0, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	content, err := os.ReadFile(path) // #nosec G304 -- path from function parameter
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	fixed, err := errCodes.ApplyEdits(content, edits)
+	if err != nil {
+		return 0, fmt.Errorf("failed to apply fixes to %s: %w", path, err)
+	}
+	if err := writeFileAtomic(path, fixed, info.Mode().Perm(), nil); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return count, nil
+}
+
+// unquotedBooleanFindings reports input defaults written as YAML booleans.
+// Action inputs are strings, so an unquoted true or false only works by accident
+// of conversion and is better written quoted.
+func unquotedBooleanFindings(content []byte, path string) []Finding {
+	file, err := parser.ParseBytes(content, 0)
+	if err != nil {
+		return nil
+	}
+
+	var findings []Finding
+	for _, doc := range file.Docs {
+		for _, input := range yamlsafe.MappingValues(yamlsafe.MappingValue(doc.Body, "inputs")) {
+			value, ok := yamlsafe.MappingValue(input.Value, "default").(*ast.BoolNode)
+			if !ok || value.GetToken() == nil {
+				continue
+			}
+			name := input.Key.String()
+			token := value.GetToken()
+			quoted := "'" + token.Value + "'"
+			findings = append(findings, Finding{
+				RuleID:   RuleUnquotedBoolean,
+				Severity: SeverityWarning,
+				Field:    "inputs." + name + ".default",
+				Message: fmt.Sprintf(
+					"Default of input '%s' is the unquoted boolean %s", name, token.Value,
+				),
+				Line:       token.Position.Line,
+				Column:     token.Position.Column,
+				Suggestion: fmt.Sprintf("Quote the default as %s; action inputs are strings", quoted),
+				Fix: &errCodes.Fix{
+					Description: fmt.Sprintf("Quote %s as %s", token.Value, quoted),
+					Edits: []errCodes.TextEdit{{
+						File:    path,
+						Line:    token.Position.Line,
+						Column:  token.Position.Column,
+						OldText: token.Value,
+						NewText: quoted,
+					}},
+					Safe: true,
+				},
+			})
+		}
+	}
+
+	return findings
+}
+
+// attachFixes derives machine-applicable fixes for located findings from the source.
+func attachFixes(content []byte, path string, findings []Finding) {
+	lines := strings.Split(string(content), "\n")
+	for i := range findings {
+		if findings[i].RuleID != RuleInvalidRuntime || findings[i].Line < 1 || findings[i].Line > len(lines) {
+			continue
+		}
+		findings[i].Fix = runtimeFix(lines[findings[i].Line-1], findings[i].Line, path)
+	}
+}
+
+// runtimeFix replaces a misspelled runs.using value on line, such as "node-20",
+// with the runtime it most likely meant. It returns nil when there is no single
+// obvious runtime.
+func runtimeFix(line string, lineNumber int, path string) *errCodes.Fix {
+	key := strings.Index(line, "using:")
+	if key < 0 {
+		return nil
+	}
+	rest := line[key+len("using:"):]
+	if comment := strings.Index(rest, " #"); comment >= 0 {
+		rest = rest[:comment]
+	}
+	value := strings.Trim(strings.TrimSpace(rest), `"'`)
+	if value == "" {
+		return nil
+	}
+
+	runtime := strings.Replace(runtimeSeparators.Replace(strings.ToLower(value)), "nodejs", "node", 1)
+	if runtime == value || !isValidRuntime(runtime) {
+		return nil
+	}
+
+	return &errCodes.Fix{
+		Description: fmt.Sprintf("Replace runtime '%s' with '%s'", value, runtime),
+		Edits: []errCodes.TextEdit{{
+			File:    path,
+			Line:    lineNumber,
+			Column:  key + len("using:") + strings.Index(rest, value) + 1,
+			OldText: value,
+			NewText: runtime,
+		}},
+		Safe: true,
+	}
+}
+
+// unwrapAnchor returns the node an anchor names, or node itself.
+func unwrapAnchor(node ast.Node) ast.Node {
+	if anchor, ok := node.(*ast.AnchorNode); ok {
+		return anchor.Value
+	}
+
+	return node
+}
@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+const fixableAction = `name: Fixable Action
+description: An action with problems that can be fixed automatically
+inputs:
+  debug:
+    description: Enable debug logging
+    default: true
+  token:
+    description: GitHub token
+    default: "false"
+runs:
+  using: Node-20 # typo
+  main: index.js
+`
+
+func TestValidateActionFile_Fixes(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, fixableAction)
+
+	result, err := ValidateActionFile(actionPath)
+	testutil.AssertNoError(t, err)
+
+	fixes := map[string]Finding{}
+	for _, finding := range result.Findings {
+		if finding.Fix != nil {
+			fixes[finding.RuleID] = finding
+		}
+	}
+
+	boolean, ok := fixes[RuleUnquotedBoolean]
+	if !ok {
+		t.Fatalf("expected a fixable %s finding, got %+v", RuleUnquotedBoolean, result.Findings)
+	}
+	testutil.AssertEqual(t, "inputs.debug.default", boolean.Field)
+	testutil.AssertEqual(t, 6, boolean.Line)
+	testutil.AssertEqual(t, "'true'", boolean.Fix.Edits[0].NewText)
+
+	runtime, ok := fixes[RuleInvalidRuntime]
+	if !ok {
+		t.Fatalf("expected a fixable %s finding, got %+v", RuleInvalidRuntime, result.Findings)
+	}
+	testutil.AssertEqual(t, "Node-20", runtime.Fix.Edits[0].OldText)
+	testutil.AssertEqual(t, "node20", runtime.Fix.Edits[0].NewText)
+	testutil.AssertEqual(t, 2, len(fixes))
+}
+
+func TestRuntimeFix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{line: "  using: node-20", want: "node20"},
+		{line: "  using: 'nodejs_24'", want: "node24"},
+		{line: "  using: Node.js 20", want: "node20"},
+		{line: "  using: dockerfile", want: ""},
+		{line: "  using: python", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			t.Parallel()
+
+			fix := runtimeFix(tt.line, 1, "action.yml")
+			if tt.want == "" {
+				if fix != nil {
+					t.Errorf("expected no fix, got %+v", fix)
+				}
+
+				return
+			}
+			if fix == nil {
+				t.Fatalf("expected fix to %s", tt.want)
+			}
+			testutil.AssertEqual(t, tt.want, fix.Edits[0].NewText)
+		})
+	}
+}
+
+func TestGenerator_ApplySuggestions(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, fixableAction)
+	testutil.AssertNoError(t, os.Chmod(actionPath, 0o640))
+
+	generator := NewGeneratorWithDependencies(DefaultAppConfig(), NewNullOutput(), NewNullProgressManager())
+	applied, err := generator.ApplySuggestions([]string{actionPath})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, applied)
+
+	// The fixed file replaces the original by rename, keeping its permissions
+	info, err := os.Stat(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, os.FileMode(0o640), info.Mode().Perm())
+	leftovers, err := filepath.Glob(filepath.Join(tmpDir, ".action.yml.*.tmp"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(leftovers))
+
+	content, err := os.ReadFile(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(content), "    default: 'true'\n")
+	testutil.AssertStringContains(t, string(content), `    default: "false"`)
+	testutil.AssertStringContains(t, string(content), "  using: node20 # typo\n")
+
+	result, err := ValidateActionFile(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, false, result.HasErrors())
+
+	// Rules turned off are not fixed.
+	testutil.WriteTestFile(t, actionPath, fixableAction)
+	config := DefaultAppConfig()
+	config.Rules = map[string]string{RuleUnquotedBoolean: RuleOff}
+	generator = NewGeneratorWithDependencies(config, NewNullOutput(), NewNullProgressManager())
+	applied, err = generator.ApplySuggestions([]string{actionPath})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, applied)
+}
@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// FrontMatterOff leaves a field out of the front matter of the docs-site theme.
+const FrontMatterOff = "off"
+
+// frontMatterKey matches the keys the front matter fields may be renamed to.
+var frontMatterKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// slugSeparators matches the runs of characters replaced by a hyphen in page slugs.
+var slugSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// FrontMatterSettings names the front matter keys of pages generated with the
+// docs-site theme, so they match what the docs site reads. A field set to
+// "off" is left out; empty fields use the defaults.
+type FrontMatterSettings struct {
+	Title       string `mapstructure:"title"       yaml:"title,omitempty"`
+	Slug        string `mapstructure:"slug"        yaml:"slug,omitempty"`
+	Description string `mapstructure:"description" yaml:"description,omitempty"`
+	Tags        string `mapstructure:"tags"        yaml:"tags,omitempty"`
+}
+
+// DefaultFrontMatterSettings returns the keys read by both MkDocs Material and
+// Docusaurus: title, slug, description and tags.
+func DefaultFrontMatterSettings() FrontMatterSettings {
+	return FrontMatterSettings{
+		Title:       "title",
+		Slug:        "slug",
+		Description: "description",
+		Tags:        "tags",
+	}
+}
+
+// ValidateFrontMatterSettings rejects keys that are not plain YAML keys and
+// fields sharing a key.
+func ValidateFrontMatterSettings(settings FrontMatterSettings) error {
+	seen := map[string]string{}
+	for _, field := range settings.fields() {
+		if field.key == "" || field.key == FrontMatterOff {
+			continue
+		}
+		if !frontMatterKey.MatchString(field.key) {
+			return fmt.Errorf("invalid front_matter.%s key '%s', must start with a letter or underscore "+
+				"followed by letters, digits, '_', '.' or '-'", field.name, field.key)
+		}
+		if other, ok := seen[field.key]; ok {
+			return fmt.Errorf("front_matter.%s and front_matter.%s use the same key '%s'",
+				other, field.name, field.key)
+		}
+		seen[field.key] = field.name
+	}
+
+	return nil
+}
+
+// frontMatterField is a front matter setting with its name in configuration.
+type frontMatterField struct {
+	name string
+	key  string
+}
+
+// fields returns the settings in the order the front matter lists them.
+func (s FrontMatterSettings) fields() []frontMatterField {
+	return []frontMatterField{
+		{"title", s.Title},
+		{"slug", s.Slug},
+		{"description", s.Description},
+		{"tags", s.Tags},
+	}
+}
+
+// FrontMatter returns the YAML front matter of the action's page for docs
+// sites, between "---" lines: its title, slug, description and the tags of its
+// sidecar metadata, under the keys of the front_matter settings.
+func (td *TemplateData) FrontMatter() (string, error) {
+	settings := DefaultFrontMatterSettings()
+	if td.Config != nil {
+		settings = td.Config.FrontMatter.withDefaults()
+	}
+	name := strings.ReplaceAll(td.Name, htmlTagMarker, "<")
+	values := map[string]any{
+		"title":       name,
+		"slug":        PageSlug(name),
+		"description": strings.TrimSpace(strings.ReplaceAll(td.Description, htmlTagMarker, "<")),
+	}
+	if td.Metadata != nil && len(td.Metadata.Tags) > 0 {
+		values["tags"] = td.Metadata.Tags
+	}
+
+	var items yaml.MapSlice
+	for _, field := range settings.fields() {
+		value, ok := values[field.name]
+		if !ok || field.key == FrontMatterOff || value == "" {
+			continue
+		}
+		items = append(items, yaml.MapItem{Key: field.key, Value: value})
+	}
+	if len(items) == 0 {
+		return "", nil
+	}
+	content, err := yaml.Marshal(items)
+	if err != nil {
+		return "", fmt.Errorf("failed to render front matter: %w", err)
+	}
+
+	return "---\n" + string(content) + "---\n", nil
+}
+
+// withDefaults fills the empty settings with the default keys.
+func (s FrontMatterSettings) withDefaults() FrontMatterSettings {
+	defaults := DefaultFrontMatterSettings()
+	for _, pair := range []struct {
+		value    *string
+		fallback string
+	}{
+		{&s.Title, defaults.Title},
+		{&s.Slug, defaults.Slug},
+		{&s.Description, defaults.Description},
+		{&s.Tags, defaults.Tags},
+	} {
+		if *pair.value == "" {
+			*pair.value = pair.fallback
+		}
+	}
+
+	return s
+}
+
+// PageSlug returns the URL slug of a page title: lowercase letters and digits
+// with runs of other characters replaced by a hyphen.
+func PageSlug(title string) string {
+	return strings.Trim(slugSeparators.ReplaceAllString(strings.ToLower(title), "-"), "-")
+}
@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestTemplateData_FrontMatter(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{Name: "Deploy: AWS <prod>", Description: "Deploys the app\n"}
+	tests := []struct {
+		name     string
+		settings FrontMatterSettings
+		metadata *ActionMetadata
+		want     string
+	}{
+		{
+			name:     "defaults",
+			settings: DefaultFrontMatterSettings(),
+			metadata: &ActionMetadata{Tags: []string{"deploy", "aws"}},
+			want: "---\ntitle: \"Deploy: AWS <prod>\"\nslug: deploy-aws-prod\ndescription: Deploys the app\n" +
+				"tags:\n- deploy\n- aws\n---\n",
+		},
+		{
+			name:     "renamed and disabled keys",
+			settings: FrontMatterSettings{Slug: "id", Description: FrontMatterOff, Tags: "keywords"},
+			want:     "---\ntitle: \"Deploy: AWS <prod>\"\nid: deploy-aws-prod\n---\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := DefaultAppConfig()
+			config.FrontMatter = tt.settings
+			data := &TemplateData{ActionYML: action, Config: config, Metadata: tt.metadata}
+			got, err := data.FrontMatter()
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateFrontMatterSettings(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, ValidateFrontMatterSettings(DefaultFrontMatterSettings()))
+	testutil.AssertNoError(t, ValidateFrontMatterSettings(FrontMatterSettings{Slug: FrontMatterOff, Tags: "keywords"}))
+	testutil.AssertError(t, ValidateFrontMatterSettings(FrontMatterSettings{Title: "page title"}))
+	testutil.AssertError(t, ValidateFrontMatterSettings(FrontMatterSettings{Title: "name", Slug: "name"}))
+}
+
+func TestRenderReadme_DocsSiteTheme(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{
+		Name:        "Greeter",
+		Description: "Greets people",
+		Inputs:      map[string]ActionInput{"name": {Description: "Who to greet", Required: true}},
+		Runs:        map[string]any{"using": "node20", "main": "index.js"},
+	}
+	config := DefaultAppConfig()
+	config.Theme = ThemeDocsSite
+	data := BuildTemplateData(action, config, "", "")
+	data.Metadata = &ActionMetadata{Tags: []string{"greeting"}}
+
+	doc, err := RenderReadme(data, TemplateOptions{TemplatePath: resolveThemeTemplate(ThemeDocsSite), Format: "md"})
+	testutil.AssertNoError(t, err)
+	if !strings.HasPrefix(doc, "---\ntitle: Greeter\nslug: greeter\ndescription: Greets people\n"+
+		"tags:\n- greeting\n---\n\nGreets people\n") {
+		t.Errorf("page does not start with front matter and the description:\n%s", doc)
+	}
+	if strings.Contains(doc, "# Greeter") {
+		t.Error("docs-site pages should leave the title heading to the docs site")
+	}
+	testutil.AssertStringContains(t, doc, "| `name` | Who to greet | yes | - |")
+}
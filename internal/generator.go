@@ -2,10 +2,12 @@
 package internal
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -13,9 +15,12 @@ import (
 	"github.com/schollz/progressbar/v3"
 
 	"github.com/ivuorinen/gh-action-readme/internal/cache"
+	"github.com/ivuorinen/gh-action-readme/internal/clock"
 	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
 	errCodes "github.com/ivuorinen/gh-action-readme/internal/errors"
 	"github.com/ivuorinen/gh-action-readme/internal/git"
+	"github.com/ivuorinen/gh-action-readme/internal/metrics"
+	"github.com/ivuorinen/gh-action-readme/internal/secrets"
 )
 
 // Output format constants.
@@ -32,8 +37,41 @@ type Generator struct {
 	Config   *AppConfig
 	Output   CompleteOutput
 	Progress ProgressManager
+
+	// Check compares generated output with the existing files instead of writing them.
+	Check bool
+	// ScanEntrypoints cross-checks node entrypoints against declared inputs and outputs when validating.
+	ScanEntrypoints bool
+	// CheckExamples validates the YAML examples of the generated documentation when validating.
+	CheckExamples bool
+
+	// KeepGoing processes every file of a batch after a failure, skipping actions
+	// with colliding outputs, instead of stopping at the first failure.
+	KeepGoing bool
+
+	// Backup keeps a copy of replaced documentation with OutputBackupExtension.
+	Backup bool
+
+	// Context stops a batch between action files when canceled; the action
+	// being generated is finished first, so no output is left half written.
+	Context context.Context
+
+	// Action files discovered by this generator, shared by the services of a command
+	discovery *discoveryCache
+	// Files written for the action being processed in a batch
+	writes *outputWrites
+	// State of the main output of the action being generated before generation started
+	outputStates map[string]fileState
+	// Files written or found up to date by this generator, for OutputFiles
+	outputFiles map[string]bool
 }
 
+// ErrStaleDocumentation is returned in check mode when generated output differs from the file on disk.
+var ErrStaleDocumentation = errors.New("documentation is out of date")
+
+// ErrInterrupted is returned when the context of a batch is canceled before every file was processed.
+var ErrInterrupted = errors.New("interrupted")
+
 // isUnitTestEnvironment detects if we're running unit tests (not integration tests).
 func isUnitTestEnvironment() bool {
 	// Only enable for unit tests, not integration tests
@@ -82,28 +120,29 @@ func NewGeneratorWithDependencies(
 	progress ProgressManager,
 ) *Generator {
 	return &Generator{
-		Config:   config,
-		Output:   output,
-		Progress: progress,
+		Config:    config,
+		Output:    output,
+		Progress:  progress,
+		discovery: &discoveryCache{discovered: make(map[discoveryKey][]DiscoveredAction)},
 	}
 }
 
 // CreateDependencyAnalyzer creates a dependency analyzer with GitHub client and cache.
+// Without a token or outside a git repository the analyzer still reads
+// dependencies locally; features that need GitHub report it per dependency.
 func (g *Generator) CreateDependencyAnalyzer() (*dependencies.Analyzer, error) {
-	// Get git info
-	repoRoot, err := git.FindRepositoryRoot(".")
-	if err != nil {
-		return nil, fmt.Errorf("failed to find repository root: %w", err)
-	}
-
-	gitInfo, err := git.DetectRepository(repoRoot)
-	if err != nil {
-		return nil, fmt.Errorf("failed to detect repository info: %w", err)
+	// Get git info, which only identifies references to this repository
+	gitInfo := &git.RepoInfo{}
+	if repoRoot, err := git.FindRepositoryRoot("."); err == nil {
+		if gitInfo, err = git.DetectRepository(repoRoot); err != nil {
+			return nil, fmt.Errorf("failed to detect repository info: %w", err)
+		}
 	}
+	g.Config.applyLinkRef(gitInfo)
 
-	// Create GitHub client if token is available
+	// Create GitHub client if token is available and enrichment is enabled
 	var githubClient *github.Client
-	if g.Config.GitHubToken != "" {
+	if g.Config.GitHubLookups() {
 		clientWrapper, err := NewGitHubClient(g.Config.GitHubToken)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create GitHub client: %w", err)
@@ -112,7 +151,7 @@ func (g *Generator) CreateDependencyAnalyzer() (*dependencies.Analyzer, error) {
 	}
 
 	// Create cache
-	depCache, err := cache.NewCache(cache.DefaultConfig())
+	depCache, err := cache.NewCache(NewCacheConfig(g.Config.Cache))
 	if err != nil {
 		// Continue without cache
 		depCache = nil
@@ -126,7 +165,20 @@ func (g *Generator) CreateDependencyAnalyzer() (*dependencies.Analyzer, error) {
 		cacheAdapter = dependencies.NewNoOpCache()
 	}
 
-	return dependencies.NewAnalyzer(githubClient, *gitInfo, cacheAdapter), nil
+	// Without GitHub lookups, the cache falls back to an imported metadata snapshot
+	if githubClient == nil {
+		if cacheAdapter, err = withMetadataSnapshot(cacheAdapter); err != nil {
+			return nil, err
+		}
+	}
+
+	analyzer := dependencies.NewAnalyzer(githubClient, *gitInfo, cacheAdapter)
+	analyzer.CacheTTL = g.Config.Cache.TTLDuration()
+	analyzer.PinStrategy = g.Config.Deps.PinStrategy
+	analyzer.Registry = NewRegistryClient(g.Config)
+	analyzer.MaxTransitiveDepth = CurrentResourceLimits().MaxTransitiveDepth
+
+	return analyzer, nil
 }
 
 // GenerateFromFile processes a single action.yml file and generates documentation.
@@ -135,32 +187,31 @@ func (g *Generator) GenerateFromFile(actionPath string) error {
 		g.Output.Progress("Processing file: %s", actionPath)
 	}
 
-	action, err := g.parseAndValidateAction(actionPath)
-	if err != nil {
-		return err
-	}
-
-	outputDir := g.determineOutputDir(actionPath)
+	start := clock.Now()
+	err := g.generateFromFile(actionPath)
+	metrics.ObserveGeneration(g.Config.OutputFormat, generationStatus(err), clock.Since(start))
 
-	return g.generateByFormat(action, outputDir, actionPath)
+	return err
 }
 
-// DiscoverActionFiles finds action.yml and action.yaml files in the given directory
-// using the centralized parser function and adds verbose logging.
+// DiscoverActionFiles finds action files in the given directory using the
+// centralized discovery and logs the pattern that matched each file in verbose mode.
 func (g *Generator) DiscoverActionFiles(dir string, recursive bool) ([]string, error) {
-	actionFiles, err := DiscoverActionFiles(dir, recursive)
+	discovered, err := g.discoverCached(dir, recursive)
 	if err != nil {
 		return nil, err
 	}
 
-	// Add verbose logging
-	if g.Config.Verbose {
-		for _, file := range actionFiles {
-			if recursive {
-				g.Output.Info("Discovered action file: %s", file)
-			} else {
-				g.Output.Info("Found action file: %s", file)
-			}
+	actionFiles := make([]string, 0, len(discovered))
+	for _, action := range discovered {
+		actionFiles = append(actionFiles, action.Path)
+		if !g.Config.Verbose {
+			continue
+		}
+		if recursive {
+			g.Output.Info("Discovered action file: %s (pattern %s)", action.Path, action.Pattern)
+		} else {
+			g.Output.Info("Found action file: %s (pattern %s)", action.Path, action.Pattern)
 		}
 	}
 
@@ -197,6 +248,7 @@ func (g *Generator) DiscoverActionFilesWithValidation(dir string, recursive bool
 				"directory":  dir,
 				"recursive":  strconv.FormatBool(recursive),
 				"context":    context,
+				"patterns":   strings.Join(CurrentDiscoveryPatterns(), ", "),
 				"suggestion": "Please run this command in a directory containing GitHub Action files (action.yml or action.yaml)",
 			},
 		)
@@ -209,41 +261,83 @@ func (g *Generator) DiscoverActionFilesWithValidation(dir string, recursive bool
 
 // ProcessBatch processes multiple action.yml files.
 func (g *Generator) ProcessBatch(paths []string) error {
+	_, err := g.ProcessBatchWithSummary(paths)
+
+	return err
+}
+
+// ProcessBatchWithSummary processes multiple action.yml files like ProcessBatch
+// and returns the outcome of each file. The summary is nil when the batch is
+// rejected before any file is processed. Processing stops at the first failing
+// file unless KeepGoing is set.
+func (g *Generator) ProcessBatchWithSummary(paths []string) (*BatchSummary, error) {
 	if len(paths) == 0 {
-		return errors.New("no action files to process")
+		return nil, errors.New("no action files to process")
+	}
+	if err := g.checkBatchSize(paths); err != nil {
+		return nil, err
+	}
+	collided, err := g.checkConflicts(paths)
+	if err != nil && !g.KeepGoing {
+		return nil, err
 	}
 
 	bar := g.Progress.CreateProgressBarForFiles("Processing files", paths)
-	errors, successCount := g.processFiles(paths, bar)
+	summary := g.processFiles(paths, collided, bar)
 	g.Progress.FinishProgressBarWithNewline(bar)
-	g.reportResults(successCount, errors)
+	g.reportSummary(summary)
 
-	if len(errors) > 0 {
-		return fmt.Errorf("encountered %d errors during batch processing", len(errors))
+	if summary.Interrupted {
+		return summary, fmt.Errorf("%w, %d of %d file(s) processed", ErrInterrupted, summary.Processed, len(paths))
+	}
+	if summary.Failed > 0 {
+		return summary, fmt.Errorf("encountered %d errors during batch processing", summary.Failed)
+	}
+	if summary.Stale > 0 {
+		return summary, fmt.Errorf("%w for %d file(s); run gen to update", ErrStaleDocumentation, summary.Stale)
 	}
 
-	return nil
+	return summary, nil
 }
 
 // ValidateFiles validates multiple action.yml files and reports results.
 func (g *Generator) ValidateFiles(paths []string) error {
+	return g.ValidateFilesWithBaseline(paths, nil)
+}
+
+// ValidateFilesWithBaseline validates action files, ignoring findings recorded in
+// the baseline, and reports results. A nil baseline reports every finding.
+func (g *Generator) ValidateFilesWithBaseline(paths []string, baseline *Baseline) error {
 	if len(paths) == 0 {
 		return errors.New("no action files to validate")
 	}
+	if err := g.checkBatchSize(paths); err != nil {
+		return err
+	}
 
 	bar := g.Progress.CreateProgressBarForFiles("Validating files", paths)
 	allResults, errors := g.validateFiles(paths, bar)
 	g.Progress.FinishProgressBarWithNewline(bar)
 
+	if baseline != nil {
+		if suppressed := baseline.Filter(allResults); suppressed > 0 && !g.Config.Quiet {
+			g.Output.Info("Ignoring %d finding(s) recorded in the baseline", suppressed)
+		}
+	}
+
+	for _, result := range allResults {
+		for _, finding := range result.Findings {
+			RecordDiagnostic(FindingDiagnostic(result.File, finding))
+		}
+	}
 	if !g.Config.Quiet {
 		g.reportValidationResults(allResults, errors)
 	}
 
-	// Count validation failures (files with missing required fields)
+	// Count validation failures (files with errors, or warnings in strict mode)
 	validationFailures := 0
 	for _, result := range allResults {
-		// Each result starts with "file: <path>" so check if there are actual missing fields beyond that
-		if len(result.MissingFields) > 1 {
+		if result.Failed(g.Config.Strict) {
 			validationFailures++
 		}
 	}
@@ -257,16 +351,236 @@ func (g *Generator) ValidateFiles(paths []string) error {
 	return nil
 }
 
-// generateMarkdown creates a README.md file using the template.
+// WriteBaseline validates action files and records every current finding in a
+// baseline file at path. Files that fail to parse are reported as an error.
+func (g *Generator) WriteBaseline(paths []string, path string) (int, error) {
+	if err := g.checkBatchSize(paths); err != nil {
+		return 0, err
+	}
+
+	bar := g.Progress.CreateProgressBarForFiles("Validating files", paths)
+	allResults, errors := g.validateFiles(paths, bar)
+	g.Progress.FinishProgressBarWithNewline(bar)
+
+	if len(errors) > 0 {
+		g.showParseErrors(errors)
+
+		return 0, fmt.Errorf("cannot create baseline: %d files failed to parse", len(errors))
+	}
+
+	baseline := NewBaseline(allResults, filepath.Dir(path))
+	if err := baseline.Save(path); err != nil {
+		return 0, err
+	}
+
+	return len(baseline.Findings), nil
+}
+
+// RenderHTML parses an action file and renders its HTML page in memory, refusing
+// content that contains a possible secret. It is used to serve documentation on demand.
+func (g *Generator) RenderHTML(actionPath string) (string, error) {
+	action, err := ParseActionYML(actionPath)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := g.renderHTML(action, filepath.Dir(actionPath), actionPath)
+	if err != nil {
+		return "", err
+	}
+	if err := g.checkForSecrets(content, actionPath); err != nil {
+		return "", err
+	}
+
+	return content, nil
+}
+
+// RenderJSON parses an action file and renders its JSON documentation in memory,
+// refusing content that contains a possible secret.
+func (g *Generator) RenderJSON(actionPath string) ([]byte, error) {
+	action, err := ParseActionYML(actionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := NewJSONWriter(g.Config).Marshal(action)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JSON: %w", err)
+	}
+	if err := g.checkForSecrets(string(data), actionPath); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// generateFromFile parses, validates and renders one action file.
+func (g *Generator) generateFromFile(actionPath string) error {
+	action, err := g.parseAndValidateAction(actionPath)
+	if err != nil {
+		return err
+	}
+
+	outputDir := g.determineOutputDir(actionPath)
+	g.warnIncompatibilities(action, actionPath)
+	g.snapshotOutput(action, actionPath)
+
+	if err := g.runHooks(HookPreGen, action, actionPath, outputDir); err != nil {
+		return err
+	}
+	enriched, err := g.runPreRenderPlugins(action, actionPath, outputDir)
+	if err != nil {
+		return err
+	}
+	if err := enriched.generateByFormat(action, outputDir, actionPath); err != nil {
+		return err
+	}
+
+	return g.runHooks(HookPostGen, action, actionPath, outputDir)
+}
+
+// warnIncompatibilities warns about the likely incompatibilities shown in the
+// compatibility section, when it is enabled.
+func (g *Generator) warnIncompatibilities(action *ActionYML, actionPath string) {
+	if !g.Config.ShowCompatibility {
+		return
+	}
+	if compatibility := ActionCompatibility(action, g.Config.RunsOn); compatibility != nil {
+		for _, issue := range compatibility.Issues() {
+			g.Output.Warning("Possible incompatibility in %s: %s", actionPath, issue)
+		}
+	}
+}
+
+// generateMarkdown creates a README.md file using the template, the documents
+// of the theme selected with documents, and the marketplace listing next to it
+// when marketplace_file is set.
 func (g *Generator) generateMarkdown(action *ActionYML, outputDir, actionPath string) error {
 	// Use theme-based template if theme is specified, otherwise use explicit template path
 	templatePath := g.Config.Template
 	if g.Config.Theme != "" {
 		templatePath = resolveThemeTemplate(g.Config.Theme)
 	}
+	docs, err := g.themeDocuments(templatePath, outputDir)
+	if err != nil {
+		return err
+	}
+	readme := docs[0]
+
+	if g.Config.MarketplaceFile != "" {
+		marketplace := markdownDocument{
+			name:         g.Config.MarketplaceFile,
+			templatePath: resolveThemeTemplate(ThemeBadgeMinimal),
+			outputPath:   filepath.Join(outputDir, g.Config.MarketplaceFile),
+			variables:    readme.variables,
+		}
+		marketplace.fullReadme = relativeSlashPath(filepath.Dir(marketplace.outputPath), readme.outputPath)
+		docs = append(docs, marketplace)
+	}
+
+	// Stubs are edited by hand, every other document is generated
+	var generated []string
+	for _, doc := range docs {
+		if !doc.stub {
+			generated = append(generated, doc.outputPath)
+		}
+	}
+	toolchain := DetectDocsToolchain(g.Config, actionPath, generated)
+
+	// Every document is checked so one run reports every stale file
+	var stale []error
+	for _, doc := range docs {
+		doc.toolchain = toolchain
+		err := g.writeMarkdown(action, outputDir, actionPath, doc)
+		if err != nil && !errors.Is(err, ErrStaleDocumentation) {
+			return err
+		}
+		stale = append(stale, err)
+	}
 
+	return errors.Join(stale...)
+}
+
+// themeDocuments returns the README of the theme at templatePath followed by
+// the documents of its manifest selected with documents, rendered with the
+// configured variables and the defaults of the theme's options.
+func (g *Generator) themeDocuments(templatePath, outputDir string) ([]markdownDocument, error) {
+	manifest, err := LoadThemeManifest(templatePath)
+	if err != nil {
+		return nil, err
+	}
+	variables, err := manifest.ResolveVariables(g.Config.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	readmeName := DefaultReadmeFilename
+	if doc, ok := manifest.Document(ThemeDocumentReadme); ok {
+		if doc.Template != "" {
+			templatePath = doc.Template
+		}
+		if doc.Output != "" {
+			readmeName = doc.Output
+		}
+	}
+	if readmeName == DefaultReadmeFilename {
+		readmeName = g.readmeFilename(outputDir)
+		if g.Config.OutputFilename == "" {
+			g.warnDuplicateReadmes(outputDir)
+		}
+	}
+	docs := []markdownDocument{{
+		name:         readmeName,
+		templatePath: templatePath,
+		outputPath:   g.resolveOutputPath(outputDir, readmeName),
+		variables:    variables,
+	}}
+
+	for _, name := range g.Config.Documents {
+		doc, ok := manifest.Document(name)
+		if !ok {
+			return nil, fmt.Errorf("theme has no document %q, available: %s",
+				name, strings.Join(manifest.Names(), ", "))
+		}
+		if name == ThemeDocumentReadme {
+			continue
+		}
+		docs = append(docs, markdownDocument{
+			name:         doc.Output,
+			templatePath: doc.Template,
+			outputPath:   filepath.Join(outputDir, filepath.FromSlash(doc.Output)),
+			stub:         doc.Stub,
+			variables:    variables,
+		})
+	}
+
+	return docs, nil
+}
+
+// markdownDocument is one of the Markdown files generated for an action.
+type markdownDocument struct {
+	name         string // Shown in messages
+	templatePath string
+	outputPath   string
+	fullReadme   string            // Link from a marketplace listing to the full README
+	stub         bool              // Written only when missing, never overwritten
+	variables    map[string]string // Template variables with the defaults of theme options
+	toolchain    *DocsToolchain    // How the generated documents are regenerated and checked
+}
+
+// writeMarkdown renders a Markdown document of an action and writes it, or
+// compares it with the existing file in check mode.
+func (g *Generator) writeMarkdown(action *ActionYML, outputDir, actionPath string, doc markdownDocument) error {
+	if doc.stub {
+		if _, err := os.Stat(doc.outputPath); err == nil {
+			// Stubs are edited by hand once written
+			g.Output.Info("Keeping existing %s: %s", doc.name, doc.outputPath)
+
+			return nil
+		}
+	}
 	opts := TemplateOptions{
-		TemplatePath: templatePath,
+		TemplatePath: doc.templatePath,
 		Format:       "md",
 	}
 
@@ -275,25 +589,76 @@ func (g *Generator) generateMarkdown(action *ActionYML, outputDir, actionPath st
 
 	// Build comprehensive template data
 	templateData := BuildTemplateData(action, g.Config, repoRoot, actionPath)
+	templateData.FullReadme = doc.fullReadme
+	templateData.Toolchain = doc.toolchain
+	if doc.variables != nil {
+		config := *templateData.Config
+		config.Variables = doc.variables
+		templateData.Config = &config
+	}
+	logo, err := g.actionLogo(actionPath, doc.outputPath, false)
+	if err != nil {
+		return err
+	}
+	templateData.Logo = logo
 
 	content, err := RenderReadme(templateData, opts)
 	if err != nil {
 		return fmt.Errorf("failed to render markdown template: %w", err)
 	}
 
-	outputPath := g.resolveOutputPath(outputDir, "README.md")
-	if err := os.WriteFile(outputPath, []byte(content), FilePermDefault); err != nil {
-		// #nosec G306 -- output file permissions
-		return fmt.Errorf("failed to write README.md to %s: %w", outputPath, err)
+	// READMEs adopted with the adopt command only have their marked sections regenerated
+	content, err = mergeMarkedReadme(templateData, doc.outputPath, content)
+	if err != nil {
+		return err
+	}
+	if err := g.checkForSecrets(content, doc.outputPath); err != nil {
+		return err
+	}
+	if g.Check {
+		return g.checkOutput(action, actionPath, doc.outputPath, content)
+	}
+	if err := g.writeOutput(doc.name, doc.outputPath, []byte(content)); err != nil {
+		return fmt.Errorf("failed to write %s to %s: %w", doc.name, doc.outputPath, err)
 	}
 
-	g.Output.Success("Generated README.md: %s", outputPath)
-
-	return nil
+	return g.runPostRenderPlugins(action, actionPath, doc.outputPath, content)
 }
 
 // generateHTML creates an HTML file using the template and optional header/footer.
 func (g *Generator) generateHTML(action *ActionYML, outputDir, actionPath string) error {
+	content, err := g.renderHTML(action, outputDir, actionPath)
+	if err != nil {
+		return err
+	}
+
+	defaultFilename := action.Name + ".html"
+	outputPath := g.resolveOutputPath(outputDir, defaultFilename)
+	logo, err := g.actionLogo(actionPath, outputPath, true)
+	if err != nil {
+		return err
+	}
+	content = insertHTMLLogo(content, logo, action.Name+" logo")
+	if g.Config.AssetsDir != "" {
+		if content, err = g.linkSharedStyles(content, outputPath); err != nil {
+			return err
+		}
+	}
+	if err := g.checkForSecrets(content, outputPath); err != nil {
+		return err
+	}
+	if g.Check {
+		return g.checkOutput(action, actionPath, outputPath, content)
+	}
+	if err := g.writeOutput("HTML", outputPath, []byte(content)); err != nil {
+		return fmt.Errorf("failed to write HTML to %s: %w", outputPath, err)
+	}
+
+	return g.runPostRenderPlugins(action, actionPath, outputPath, content)
+}
+
+// renderHTML renders the HTML page for an action without writing it.
+func (g *Generator) renderHTML(action *ActionYML, outputDir, actionPath string) (string, error) {
 	// Use theme-based template if theme is specified, otherwise use explicit template path
 	templatePath := g.Config.Template
 	if g.Config.Theme != "" {
@@ -315,38 +680,32 @@ func (g *Generator) generateHTML(action *ActionYML, outputDir, actionPath string
 
 	content, err := RenderReadme(templateData, opts)
 	if err != nil {
-		return fmt.Errorf("failed to render HTML template: %w", err)
-	}
-
-	// Use HTMLWriter for consistent HTML output
-	writer := &HTMLWriter{
-		Header: "", // Header/footer are handled by template options
-		Footer: "",
-	}
-
-	defaultFilename := action.Name + ".html"
-	outputPath := g.resolveOutputPath(outputDir, defaultFilename)
-	if err := writer.Write(content, outputPath); err != nil {
-		return fmt.Errorf("failed to write HTML to %s: %w", outputPath, err)
+		return "", fmt.Errorf("failed to render HTML template: %w", err)
 	}
 
-	g.Output.Success("Generated HTML: %s", outputPath)
-
-	return nil
+	return content, nil
 }
 
 // generateJSON creates a JSON file with structured documentation data.
-func (g *Generator) generateJSON(action *ActionYML, outputDir string) error {
+func (g *Generator) generateJSON(action *ActionYML, outputDir, actionPath string) error {
 	writer := NewJSONWriter(g.Config)
 
 	outputPath := g.resolveOutputPath(outputDir, "action-docs.json")
-	if err := writer.Write(action, outputPath); err != nil {
+	data, err := writer.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("failed to render JSON: %w", err)
+	}
+	if err := g.checkForSecrets(string(data), outputPath); err != nil {
+		return err
+	}
+	if g.Check {
+		return g.checkOutput(action, actionPath, outputPath, string(data))
+	}
+	if err := g.writeOutput("JSON", outputPath, data); err != nil {
 		return fmt.Errorf("failed to write JSON to %s: %w", outputPath, err)
 	}
 
-	g.Output.Success("Generated JSON: %s", outputPath)
-
-	return nil
+	return g.runPostRenderPlugins(action, actionPath, outputPath, string(data))
 }
 
 // generateASCIIDoc creates an AsciiDoc file using the template.
@@ -364,59 +723,85 @@ func (g *Generator) generateASCIIDoc(action *ActionYML, outputDir, actionPath st
 
 	// Build comprehensive template data
 	templateData := BuildTemplateData(action, g.Config, repoRoot, actionPath)
+	outputPath := g.resolveOutputPath(outputDir, "README.adoc")
+	logo, err := g.actionLogo(actionPath, outputPath, false)
+	if err != nil {
+		return err
+	}
+	templateData.Logo = logo
 
 	content, err := RenderReadme(templateData, opts)
 	if err != nil {
 		return fmt.Errorf("failed to render AsciiDoc template: %w", err)
 	}
 
-	outputPath := g.resolveOutputPath(outputDir, "README.adoc")
-	if err := os.WriteFile(outputPath, []byte(content), FilePermDefault); err != nil {
-		// #nosec G306 -- output file permissions
+	if err := g.checkForSecrets(content, outputPath); err != nil {
+		return err
+	}
+	if g.Check {
+		return g.checkOutput(action, actionPath, outputPath, content)
+	}
+	if err := g.writeOutput("AsciiDoc", outputPath, []byte(content)); err != nil {
 		return fmt.Errorf("failed to write AsciiDoc to %s: %w", outputPath, err)
 	}
 
-	g.Output.Success("Generated AsciiDoc: %s", outputPath)
-
-	return nil
+	return g.runPostRenderPlugins(action, actionPath, outputPath, content)
 }
 
-// processFiles processes each file and tracks results.
-func (g *Generator) processFiles(paths []string, bar *progressbar.ProgressBar) ([]string, int) {
-	var errors []string
-	successCount := 0
-
-	for _, path := range paths {
-		if err := g.GenerateFromFile(path); err != nil {
-			errorMsg := fmt.Sprintf("failed to process %s: %v", path, err)
-			errors = append(errors, errorMsg)
+// processFiles processes each file and tracks results. Stale documentation found
+// in check mode is counted separately from failures, and files whose outputs
+// already held the generated content are counted as unchanged. Actions in
+// collided fail with the reason recorded there. Without KeepGoing the files
+// after the first failure are skipped.
+func (g *Generator) processFiles(
+	paths []string,
+	collided map[string]string,
+	bar *progressbar.ProgressBar,
+) *BatchSummary {
+	summary := &BatchSummary{Check: g.Check, Failures: []BatchFailure{}}
+
+	for i, path := range paths {
+		if g.Context != nil && g.Context.Err() != nil {
+			summary.Interrupted = true
+			summary.Skipped = len(paths) - i
+
+			break
+		}
+		var err error
+		writes := &outputWrites{}
+		if reason, ok := collided[path]; ok {
+			err = errors.New(reason)
+		} else {
+			g.writes = writes
+			err = g.GenerateFromFile(path)
+			g.writes = nil
+		}
+		summary.Processed++
+		switch {
+		case err == nil && writes.written > 0:
+			summary.Generated++
+		case err == nil:
+			summary.Unchanged++
+		case errors.Is(err, ErrStaleDocumentation):
+			summary.Stale++
+		default:
+			summary.Failed++
+			summary.Failures = append(summary.Failures, BatchFailure{File: path, Reason: err.Error()})
+			RecordDiagnostic(generationDiagnostic(path, err))
 			if g.Config.Verbose {
-				g.Output.Error("%s", errorMsg)
+				g.Output.Error("failed to process %s: %v", path, err)
 			}
-		} else {
-			successCount++
 		}
 
 		g.Progress.UpdateProgressBar(bar)
-	}
+		if summary.Failed > 0 && !g.KeepGoing {
+			summary.Skipped = len(paths) - i - 1
 
-	return errors, successCount
-}
-
-// reportResults displays processing summary.
-func (g *Generator) reportResults(successCount int, errors []string) {
-	if g.Config.Quiet {
-		return
-	}
-
-	g.Output.Bold("\nProcessing complete: %d successful, %d failed", successCount, len(errors))
-
-	if len(errors) > 0 && g.Config.Verbose {
-		g.Output.Error("\nErrors encountered:")
-		for _, errMsg := range errors {
-			g.Output.Printf("  - %s\n", errMsg)
+			break
 		}
 	}
+
+	return summary
 }
 
 // parseAndValidateAction parses and validates an action.yml file.
@@ -453,6 +838,84 @@ func (g *Generator) parseAndValidateAction(actionPath string) (*ActionYML, error
 	return action, nil
 }
 
+// checkBatchSize reports and rejects batches larger than limits.max_files.
+func (g *Generator) checkBatchSize(paths []string) error {
+	if err := checkFileCount(len(paths), CurrentResourceLimits().MaxFiles); err != nil {
+		g.Output.ErrorWithContext(
+			errCodes.ErrCodeResourceLimit,
+			err.Error(),
+			map[string]string{
+				"files_count": strconv.Itoa(len(paths)),
+				"limit":       "max_files",
+			},
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// checkForSecrets scans rendered content and refuses to write it when secrets are found.
+func (g *Generator) checkForSecrets(content, outputPath string) error {
+	findings := g.newSecretScanner().Scan(content)
+	if len(findings) == 0 {
+		return nil
+	}
+
+	first := findings[0]
+	context := map[string]string{
+		"output_path": outputPath,
+		"detector":    first.Detector,
+		"line":        strconv.Itoa(first.Line),
+		"match":       first.Match,
+		"findings":    strconv.Itoa(len(findings)),
+	}
+	if first.Expression != "" {
+		context["expression"] = first.Expression
+	}
+
+	return errCodes.New(errCodes.ErrCodeSecretDetected, "refusing to write output containing a possible secret").
+		WithSuggestions(errCodes.GetSuggestions(errCodes.ErrCodeSecretDetected, context)...).
+		WithHelpURL(errCodes.GetHelpURL(errCodes.ErrCodeSecretDetected)).
+		WithDetails(context)
+}
+
+// newSecretScanner creates a secret scanner aware of sensitive configuration values.
+func (g *Generator) newSecretScanner() *secrets.Scanner {
+	scanner := secrets.NewScanner().
+		WithSecret(".Config.GitHubToken", g.Config.GitHubToken).
+		WithSecret(".Config.GitHubToken", GetGitHubToken(g.Config))
+	slackWebhook, teamsWebhook := g.Config.Notify.Webhooks()
+	scanner.
+		WithSecret(".Config.Notify.SlackWebhook", g.Config.Notify.SlackWebhook).
+		WithSecret(".Config.Notify.SlackWebhook", slackWebhook).
+		WithSecret(".Config.Notify.TeamsWebhook", g.Config.Notify.TeamsWebhook).
+		WithSecret(".Config.Notify.TeamsWebhook", teamsWebhook)
+	hosts := make([]string, 0, len(g.Config.Deps.Registries))
+	for host := range g.Config.Deps.Registries {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		expression := fmt.Sprintf("(index .Config.Deps.Registries %q).Token", host)
+		scanner.
+			WithSecret(expression, g.Config.Deps.Registries[host].Token).
+			WithSecret(expression, g.Config.Deps.Registries[host].ResolvedToken())
+	}
+
+	keys := make([]string, 0, len(g.Config.Variables))
+	for key := range g.Config.Variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		scanner.WithSource(".Config.Variables."+key, g.Config.Variables[key])
+	}
+
+	return scanner
+}
+
 // determineOutputDir calculates the output directory for generated files.
 func (g *Generator) determineOutputDir(actionPath string) string {
 	if g.Config.OutputDir == "" || g.Config.OutputDir == "." {
@@ -475,6 +938,22 @@ func (g *Generator) resolveOutputPath(outputDir, defaultFilename string) string
 	return filepath.Join(outputDir, defaultFilename)
 }
 
+// outputPath returns the file documentation for an action is written to in the
+// configured output format.
+func (g *Generator) outputPath(action *ActionYML, actionPath string) string {
+	outputDir := g.determineOutputDir(actionPath)
+	switch g.Config.OutputFormat {
+	case OutputFormatHTML:
+		return g.resolveOutputPath(outputDir, action.Name+".html")
+	case OutputFormatJSON:
+		return g.resolveOutputPath(outputDir, "action-docs.json")
+	case OutputFormatASCIIDoc:
+		return g.resolveOutputPath(outputDir, "README.adoc")
+	default:
+		return g.resolveOutputPath(outputDir, g.readmeFilename(outputDir))
+	}
+}
+
 // generateByFormat generates documentation in the specified format.
 func (g *Generator) generateByFormat(action *ActionYML, outputDir, actionPath string) error {
 	switch g.Config.OutputFormat {
@@ -483,7 +962,7 @@ func (g *Generator) generateByFormat(action *ActionYML, outputDir, actionPath st
 	case OutputFormatHTML:
 		return g.generateHTML(action, outputDir, actionPath)
 	case OutputFormatJSON:
-		return g.generateJSON(action, outputDir)
+		return g.generateJSON(action, outputDir, actionPath)
 	case OutputFormatASCIIDoc:
 		return g.generateASCIIDoc(action, outputDir, actionPath)
 	default:
@@ -501,16 +980,30 @@ func (g *Generator) validateFiles(paths []string, bar *progressbar.ProgressBar)
 			g.Output.Progress("Validating: %s", path)
 		}
 
-		action, err := ParseActionYML(path)
+		result, err := ValidateActionFile(path)
 		if err != nil {
 			errorMsg := fmt.Sprintf("failed to parse %s: %v", path, err)
 			errors = append(errors, errorMsg)
+			RecordDiagnostic(Diagnostic{
+				Severity: SeverityError,
+				Code:     string(errCodes.ErrCodeInvalidYAML),
+				Source:   DiagnosticSourceValidation,
+				Message:  err.Error(),
+				File:     path,
+			})
 
 			continue
 		}
-
-		result := ValidateActionYML(action)
-		result.MissingFields = append([]string{"file: " + path}, result.MissingFields...)
+		if g.ScanEntrypoints {
+			g.scanEntrypoints(path, &result)
+		}
+		if findings, err := ValidatePlatformSupport(path, g.Config.RunsOn); err == nil {
+			result.Findings = append(result.Findings, findings...)
+		}
+		if g.CheckExamples {
+			g.checkExamples(path, &result)
+		}
+		result.ApplyRules(g.Config.Rules)
 		allResults = append(allResults, result)
 
 		g.Progress.UpdateProgressBar(bar)
@@ -519,6 +1012,55 @@ func (g *Generator) validateFiles(paths []string, bar *progressbar.ProgressBar)
 	return allResults, errors
 }
 
+// scanEntrypoints adds the findings of ValidateEntrypoints to result. Entrypoints
+// that cannot be read are reported as warnings without failing validation.
+func (g *Generator) scanEntrypoints(path string, result *ValidationResult) {
+	action, err := ParseActionYML(path)
+	if err != nil {
+		return
+	}
+	findings, err := ValidateEntrypoints(path, action)
+	if err != nil {
+		g.Output.Warning("Skipping entrypoint scan of %s: %v", path, err)
+	}
+	result.Findings = append(result.Findings, findings...)
+}
+
+// checkExamples adds the findings of ValidateExamples for the documentation the
+// configuration generates for the action at path. Dependency analysis is skipped,
+// as its sections contain no workflow examples.
+func (g *Generator) checkExamples(path string, result *ValidationResult) {
+	action, err := ParseActionYML(path)
+	if err != nil {
+		return
+	}
+
+	config := *g.Config
+	config.AnalyzeDependencies = false
+	outputDir := g.determineOutputDir(path)
+	repoRoot, _ := git.FindRepositoryRoot(outputDir)
+	data := BuildTemplateData(action, &config, repoRoot, path)
+
+	opts := TemplateOptions{TemplatePath: g.Config.Template, Format: "md"}
+	if g.Config.Theme != "" {
+		opts.TemplatePath = resolveThemeTemplate(g.Config.Theme)
+	}
+	docName := g.readmeFilename(outputDir)
+	if g.Config.OutputFormat == OutputFormatASCIIDoc {
+		opts = TemplateOptions{TemplatePath: resolveTemplatePath("templates/themes/asciidoc/readme.adoc"), Format: "asciidoc"}
+		docName = "README.adoc"
+	}
+
+	doc, err := RenderReadme(data, opts)
+	if err != nil {
+		g.Output.Warning("Skipping example validation of %s: %v", path, err)
+
+		return
+	}
+	findings := ValidateExamples(doc, g.resolveOutputPath(outputDir, docName), data, repoRoot)
+	result.Findings = append(result.Findings, findings...)
+}
+
 // reportValidationResults provides a summary of validation results.
 func (g *Generator) reportValidationResults(results []ValidationResult, errors []string) {
 	totalFiles := len(results) + len(errors)
@@ -532,11 +1074,10 @@ func (g *Generator) reportValidationResults(results []ValidationResult, errors [
 // countValidationStats counts valid files and total issues from results.
 func (g *Generator) countValidationStats(results []ValidationResult) (validFiles, totalIssues int) {
 	for _, result := range results {
-		if len(result.MissingFields) == 1 { // Only contains file path
+		if !result.Failed(g.Config.Strict) {
 			validFiles++
-		} else {
-			totalIssues += len(result.MissingFields) - 1 // Subtract file path entry
 		}
+		totalIssues += result.Count(SeverityError) + result.Count(SeverityWarning)
 	}
 
 	return validFiles, totalIssues
@@ -569,7 +1110,8 @@ func (g *Generator) showDetailedIssues(results []ValidationResult, totalIssues i
 	g.Output.Printf("-" + strings.Repeat("-", 35) + "\n")
 
 	for _, result := range results {
-		if len(result.MissingFields) > 1 || len(result.Warnings) > 0 {
+		issues := result.Count(SeverityError) + result.Count(SeverityWarning)
+		if issues > 0 || (g.Config.Verbose && len(result.Findings) > 0) {
 			g.showFileIssues(result)
 		}
 	}
@@ -577,17 +1119,10 @@ func (g *Generator) showDetailedIssues(results []ValidationResult, totalIssues i
 
 // showFileIssues displays issues for a specific file.
 func (g *Generator) showFileIssues(result ValidationResult) {
-	filename := result.MissingFields[0][6:] // Remove "file: " prefix
-	g.Output.Info("📁 File: %s", filename)
-
-	// Show missing fields
-	for _, field := range result.MissingFields[1:] {
-		g.Output.Error("  ❌ Missing required field: %s", field)
-	}
+	g.Output.Info("📁 File: %s", result.File)
 
-	// Show warnings
-	for _, warning := range result.Warnings {
-		g.Output.Warning("  ⚠️  Missing recommended field: %s", warning)
+	for _, finding := range result.Findings {
+		g.showFinding(finding)
 	}
 
 	// Show suggestions
@@ -600,6 +1135,31 @@ func (g *Generator) showFileIssues(result ValidationResult) {
 	g.Output.Printf("\n")
 }
 
+// showFinding displays a single finding according to its severity.
+func (g *Generator) showFinding(finding Finding) {
+	message := fmt.Sprintf("[%s] %s", finding.RuleID, finding.Message)
+	switch {
+	case finding.File != "":
+		message += fmt.Sprintf(" (%s)", finding.Location()) // Reported in another file, such as an entrypoint
+	case finding.Line > 0:
+		message += fmt.Sprintf(" (line %d)", finding.Line)
+	}
+	if finding.Fix != nil && finding.Fix.Safe {
+		message += " [fixable]"
+	}
+
+	switch finding.Severity {
+	case SeverityError:
+		g.Output.Error("  %s", message)
+	case SeverityWarning:
+		g.Output.Warning("  %s", message)
+	case SeverityInfo:
+		if g.Config.Verbose {
+			g.Output.Info("  %s", message)
+		}
+	}
+}
+
 // showParseErrors displays parse errors if any exist.
 func (g *Generator) showParseErrors(errors []string) {
 	if len(errors) == 0 {
@@ -612,3 +1172,33 @@ func (g *Generator) showParseErrors(errors []string) {
 		g.Output.Error("  - %s", errMsg)
 	}
 }
+
+// generationDiagnostic describes the failure to generate documentation for the
+// action at path, keeping the code and suggestions of contextual errors.
+func generationDiagnostic(path string, err error) Diagnostic {
+	diagnostic := Diagnostic{
+		Severity: SeverityError,
+		Source:   DiagnosticSourceGeneration,
+		Message:  err.Error(),
+	}
+	var contextual *errCodes.ContextualError
+	if errors.As(err, &contextual) {
+		diagnostic = ContextualErrorDiagnostic(contextual)
+		diagnostic.Source = DiagnosticSourceGeneration
+	}
+	diagnostic.File = path
+
+	return diagnostic
+}
+
+// generationStatus maps the result of a generation to its metrics status label.
+func generationStatus(err error) string {
+	switch {
+	case err == nil:
+		return metrics.StatusSuccess
+	case errors.Is(err, ErrStaleDocumentation):
+		return metrics.StatusStale
+	default:
+		return metrics.StatusError
+	}
+}
@@ -1,11 +1,13 @@
 package internal
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
 	"github.com/ivuorinen/gh-action-readme/testutil"
 )
 
@@ -561,7 +563,7 @@ func TestGenerator_CreateDependencyAnalyzer(t *testing.T) {
 
 func TestGenerator_WithDifferentThemes(t *testing.T) {
 	t.Parallel()
-	themes := []string{"default", "github", "gitlab", "minimal", "professional"}
+	themes := []string{"default", "github", "gitlab", "minimal", "professional", "docs-site", "badge-minimal"}
 
 	for _, theme := range themes {
 		t.Run("theme_"+theme, func(t *testing.T) {
@@ -599,6 +601,41 @@ func TestGenerator_WithDifferentThemes(t *testing.T) {
 	}
 }
 
+func TestGenerator_MarketplaceFile(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/with-all-fields.yml"))
+
+	config := DefaultAppConfig()
+	config.Theme = ThemeGitHub
+	config.OutputDir = tmpDir
+	config.MarketplaceFile = "MARKETPLACE.md"
+	config.Quiet = true
+	testutil.AssertNoError(t, NewGenerator(config).GenerateFromFile(actionPath))
+
+	readme, err := os.ReadFile(filepath.Join(tmpDir, "README.md"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(readme), "## 📥 Inputs")
+	listing, err := os.ReadFile(filepath.Join(tmpDir, "MARKETPLACE.md"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(listing), "  with:\n    required-input: \"value\"\n```")
+	testutil.AssertStringContains(t, string(listing), "| `optional-input` | no | An optional input parameter |")
+	testutil.AssertStringContains(t, string(listing), "See the [full documentation](README.md) for every option.")
+	if strings.Contains(string(listing), "optional-input: ") {
+		t.Error("the marketplace usage example should only set required inputs")
+	}
+
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "MARKETPLACE.md"), "stale")
+	checker := NewGenerator(config)
+	checker.Check = true
+	if err := checker.GenerateFromFile(actionPath); !errors.Is(err, ErrStaleDocumentation) {
+		t.Errorf("GenerateFromFile() in check mode error = %v, want ErrStaleDocumentation", err)
+	}
+}
+
 func TestGenerator_ErrorHandling(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -649,6 +686,28 @@ func TestGenerator_ErrorHandling(t *testing.T) {
 			},
 			wantError: "permission denied",
 		},
+		{
+			name: "secret in rendered output",
+			setupFunc: func(t *testing.T, tmpDir string) (*Generator, string) {
+				t.Helper()
+				templatePath := filepath.Join(tmpDir, "leaky.tmpl")
+				testutil.WriteTestFile(t, templatePath, "# {{ .Name }}\n\nToken: {{ .Config.Variables.token }}\n")
+
+				config := &AppConfig{
+					Variables:    map[string]string{"token": "ghp_" + strings.Repeat("x", 36)},
+					OutputFormat: "md",
+					OutputDir:    tmpDir,
+					Quiet:        true,
+					Template:     templatePath,
+				}
+				generator := NewGenerator(config)
+				actionPath := filepath.Join(tmpDir, "action.yml")
+				testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+
+				return generator, actionPath
+			},
+			wantError: "possible secret",
+		},
 	}
 
 	for _, tt := range tests {
@@ -667,3 +726,34 @@ func TestGenerator_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerator_SecretScannerRegistryTokens(t *testing.T) {
+	t.Setenv("GH_README_TEST_REGISTRY_TOKEN", "registry-token-from-env")
+
+	config := DefaultAppConfig()
+	config.Deps.Registries = map[string]dependencies.RegistryCredentials{
+		"ghcr.io":            {Username: "bot", Token: "registry-token-inline"},
+		"registry.acme.test": {Username: "bot", TokenEnv: "GH_README_TEST_REGISTRY_TOKEN"},
+	}
+	generator := NewGenerator(config)
+
+	findings := generator.newSecretScanner().Scan("ghcr: registry-token-inline\nacme: registry-token-from-env\n")
+	testutil.AssertEqual(t, 2, len(findings))
+	testutil.AssertEqual(t, `(index .Config.Deps.Registries "ghcr.io").Token`, findings[0].Expression)
+	testutil.AssertEqual(t, `(index .Config.Deps.Registries "registry.acme.test").Token`, findings[1].Expression)
+}
+
+func TestGenerator_SecretScannerNotifyWebhooks(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultAppConfig()
+	config.Notify.SlackWebhook = "https://hooks.slack.com/services/T000/B000/secret"
+	config.Notify.TeamsWebhook = "https://acme.webhook.office.com/webhookb2/secret"
+	generator := NewGenerator(config)
+
+	findings := generator.newSecretScanner().Scan("Slack: " + config.Notify.SlackWebhook +
+		"\nTeams: " + config.Notify.TeamsWebhook + "\n")
+	testutil.AssertEqual(t, 2, len(findings))
+	testutil.AssertEqual(t, ".Config.Notify.SlackWebhook", findings[0].Expression)
+	testutil.AssertEqual(t, ".Config.Notify.TeamsWebhook", findings[1].Expression)
+}
@@ -0,0 +1,217 @@
+package git
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// StageFiles stages paths, relative to repoRoot or absolute, and returns
+// those that differ from HEAD, relative to repoRoot with forward slashes.
+// It works without a git binary.
+func StageFiles(repoRoot string, paths []string) ([]string, error) {
+	relPaths, err := repoRelativePaths(repoRoot, paths)
+	if err != nil || len(relPaths) == 0 {
+		return nil, err
+	}
+
+	repo, err := openRepository(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open worktree: %w", err)
+	}
+	for _, relPath := range relPaths {
+		if err := worktree.AddWithOptions(&gogit.AddOptions{Path: relPath, SkipStatus: true}); err != nil {
+			return nil, fmt.Errorf("failed to stage %s: %w", relPath, err)
+		}
+	}
+
+	staged, err := repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+	head, err := headTree(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, relPath := range relPaths {
+		entry, _ := staged.Entry(relPath)
+		var committed *object.TreeEntry
+		if head != nil {
+			committed, _ = head.FindEntry(relPath)
+		}
+		switch {
+		case entry == nil && committed == nil:
+		case entry == nil || committed == nil || entry.Hash != committed.Hash || entry.Mode != committed.Mode:
+			changed = append(changed, relPath)
+		}
+	}
+	slices.Sort(changed)
+
+	return slices.Compact(changed), nil
+}
+
+// CommitFiles commits paths, relative to repoRoot or absolute, with message,
+// leaving other staged changes out of the commit. With signoff the message
+// gets a Signed-off-by trailer of the committer. It returns the hash of the
+// new commit.
+func CommitFiles(repoRoot string, paths []string, message string, signoff bool) (string, error) {
+	relPaths, err := repoRelativePaths(repoRoot, paths)
+	if err != nil {
+		return "", err
+	}
+
+	repo, err := openRepository(repoRoot)
+	if err != nil {
+		return "", err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to open worktree: %w", err)
+	}
+	author, committer, err := commitIdentities(repo)
+	if err != nil {
+		return "", err
+	}
+	if signoff {
+		message = fmt.Sprintf("%s\n\nSigned-off-by: %s <%s>\n", message, committer.Name, committer.Email)
+	}
+
+	// Like git commit --only: the commit holds HEAD with the staged paths, and
+	// the index keeps every staged change afterwards
+	staged, err := repo.Storer.Index()
+	if err != nil {
+		return "", fmt.Errorf("failed to read index: %w", err)
+	}
+	only, err := onlyIndex(repo, staged, relPaths)
+	if err != nil {
+		return "", err
+	}
+	if err := repo.Storer.SetIndex(only); err != nil {
+		return "", fmt.Errorf("failed to write index: %w", err)
+	}
+	hash, commitErr := worktree.Commit(message, &gogit.CommitOptions{Author: author, Committer: committer})
+	if err := repo.Storer.SetIndex(staged); err != nil && commitErr == nil {
+		commitErr = fmt.Errorf("failed to restore index: %w", err)
+	}
+	if commitErr != nil {
+		return "", fmt.Errorf("failed to commit: %w", commitErr)
+	}
+
+	return hash.String(), nil
+}
+
+// openRepository opens the repository with its worktree at repoRoot.
+func openRepository(repoRoot string) (*gogit.Repository, error) {
+	repo, err := gogit.PlainOpenWithOptions(repoRoot, &gogit.PlainOpenOptions{EnableDotGitCommonDir: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository %s: %w", repoRoot, err)
+	}
+
+	return repo, nil
+}
+
+// headTree returns the tree of HEAD, or nil before the first commit.
+func headTree(repo *gogit.Repository) (*object.Tree, error) {
+	head, err := repo.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD tree: %w", err)
+	}
+
+	return tree, nil
+}
+
+// onlyIndex returns an index with the files of HEAD and the entries of
+// relPaths in staged.
+func onlyIndex(repo *gogit.Repository, staged *index.Index, relPaths []string) (*index.Index, error) {
+	only := &index.Index{Version: staged.Version}
+	tree, err := headTree(repo)
+	if err != nil {
+		return nil, err
+	}
+	if tree != nil {
+		err := tree.Files().ForEach(func(file *object.File) error {
+			if !slices.Contains(relPaths, file.Name) {
+				only.Entries = append(only.Entries, &index.Entry{Name: file.Name, Hash: file.Hash, Mode: file.Mode})
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read HEAD tree: %w", err)
+		}
+	}
+	for _, relPath := range relPaths {
+		if entry, err := staged.Entry(relPath); err == nil {
+			only.Entries = append(only.Entries, entry)
+		}
+	}
+
+	return only, nil
+}
+
+// commitIdentities returns the author and committer of a new commit like git
+// does: from GIT_AUTHOR_* and GIT_COMMITTER_* environment variables, then the
+// author and committer sections of the git configuration, then user.
+func commitIdentities(repo *gogit.Repository) (author, committer *object.Signature, err error) {
+	cfg, err := repo.ConfigScoped(config.SystemScope)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read git configuration: %w", err)
+	}
+
+	now := time.Now()
+	author = &object.Signature{
+		Name:  cmp.Or(os.Getenv("GIT_AUTHOR_NAME"), cfg.Author.Name, cfg.User.Name),
+		Email: cmp.Or(os.Getenv("GIT_AUTHOR_EMAIL"), cfg.Author.Email, cfg.User.Email),
+		When:  now,
+	}
+	committer = &object.Signature{
+		Name:  cmp.Or(os.Getenv("GIT_COMMITTER_NAME"), cfg.Committer.Name, cfg.User.Name),
+		Email: cmp.Or(os.Getenv("GIT_COMMITTER_EMAIL"), cfg.Committer.Email, cfg.User.Email),
+		When:  now,
+	}
+	if author.Name == "" || author.Email == "" || committer.Name == "" || committer.Email == "" {
+		return nil, nil, errors.New("committing needs user.name and user.email in the git configuration")
+	}
+
+	return author, committer, nil
+}
+
+// repoRelativePaths converts paths with repoRelativePath.
+func repoRelativePaths(repoRoot string, paths []string) ([]string, error) {
+	relPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		relPath, err := repoRelativePath(repoRoot, path)
+		if err != nil {
+			return nil, err
+		}
+		relPaths = append(relPaths, relPath)
+	}
+
+	return relPaths, nil
+}
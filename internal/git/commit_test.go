@@ -0,0 +1,95 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// gitOutput runs git with args in dir and returns its output.
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+
+	return string(output)
+}
+
+func TestCommitFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := initTestRepo(t, map[string]string{"actions/build/README.md": "# Build\n", "notes.txt": "notes\n"})
+	gitOutput(t, dir, "config", "user.name", "Docs Bot")
+	gitOutput(t, dir, "config", "user.email", "bot@example.com")
+
+	// Unchanged files are not reported as staged
+	readme := filepath.Join(dir, "actions", "build", "README.md")
+	staged, err := StageFiles(dir, []string{readme})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(staged))
+
+	// Only the given files are committed, other staged changes stay staged
+	testutil.WriteTestFile(t, readme, "# Build\n\nUpdated\n")
+	testutil.WriteTestFile(t, filepath.Join(dir, "actions", "test", "README.md"), "# Test\n")
+	testutil.WriteTestFile(t, filepath.Join(dir, "notes.txt"), "changed\n")
+	gitOutput(t, dir, "add", "notes.txt")
+	paths := []string{readme, "actions/test/README.md"}
+	staged, err = StageFiles(dir, paths)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "actions/build/README.md,actions/test/README.md", strings.Join(staged, ","))
+	hash, err := CommitFiles(dir, paths, "docs(actions): regenerate READMEs", true)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, strings.TrimSpace(gitOutput(t, dir, "rev-parse", "HEAD")), hash)
+
+	message := gitOutput(t, dir, "log", "-1", "--format=%B")
+	testutil.AssertStringContains(t, message, "docs(actions): regenerate READMEs\n")
+	testutil.AssertStringContains(t, message, "Signed-off-by: Docs Bot <bot@example.com>")
+	testutil.AssertEqual(t, "actions/build/README.md\nactions/test/README.md\n",
+		gitOutput(t, dir, "show", "--format=", "--name-only", "HEAD"))
+	testutil.AssertEqual(t, "M  notes.txt\n", gitOutput(t, dir, "status", "--porcelain"))
+
+	_, err = StageFiles(dir, []string{filepath.Join(t.TempDir(), "README.md")})
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "outside repository")
+}
+
+func TestCommitFiles_WithoutGitBinary(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	testutil.AssertNoError(t, err)
+	testutil.WriteTestFile(t, filepath.Join(dir, "README.md"), "# Action\n")
+
+	// No git binary on the PATH, the identity comes from the environment like with git
+	t.Setenv("PATH", "")
+	t.Setenv("GIT_AUTHOR_NAME", "Docs Bot")
+	t.Setenv("GIT_AUTHOR_EMAIL", "bot@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Release Bot")
+	t.Setenv("GIT_COMMITTER_EMAIL", "release@example.com")
+
+	staged, err := StageFiles(dir, []string{"README.md"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "README.md", strings.Join(staged, ","))
+	hash, err := CommitFiles(dir, staged, "docs(actions): regenerate READMEs", true)
+	testutil.AssertNoError(t, err)
+
+	commit, err := repo.CommitObject(plumbing.NewHash(hash))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "Docs Bot", commit.Author.Name)
+	testutil.AssertEqual(t, "Release Bot", commit.Committer.Name)
+	testutil.AssertStringContains(t, commit.Message, "Signed-off-by: Release Bot <release@example.com>")
+	file, err := commit.File("README.md")
+	testutil.AssertNoError(t, err)
+	content, err := file.Contents()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "# Action\n", content)
+}
@@ -3,6 +3,7 @@ package git
 
 import (
 	"bufio"
+	"cmp"
 	"errors"
 	"fmt"
 	"os"
@@ -23,7 +24,15 @@ type RepoInfo struct {
 	Repository    string `json:"repository"`
 	RemoteURL     string `json:"remote_url"`
 	DefaultBranch string `json:"default_branch"`
-	IsGitRepo     bool   `json:"is_git_repo"`
+	// Ref is the checked out branch, or the tag of a detached HEAD; empty otherwise
+	Ref       string `json:"ref,omitempty"`
+	IsGitRepo bool   `json:"is_git_repo"`
+}
+
+// LinkRef returns the ref links into the repository point at: Ref, falling
+// back to the default branch.
+func (r *RepoInfo) LinkRef() string {
+	return cmp.Or(r.Ref, r.DefaultBranch, DefaultBranch)
 }
 
 // GetRepositoryName returns the full repository name in org/repo format.
@@ -83,6 +92,7 @@ func DetectRepository(repoRoot string) (*RepoInfo, error) {
 
 	// Try to get default branch
 	info.DefaultBranch = getDefaultBranch(repoRoot)
+	info.Ref = getCurrentRef(repoRoot)
 
 	return info, nil
 }
@@ -177,6 +187,23 @@ func getDefaultBranch(repoRoot string) string {
 	return DefaultBranch
 }
 
+// getCurrentRef gets the checked out branch or, on a detached HEAD, the tag
+// pointing at it. It returns an empty string for other detached commits.
+func getCurrentRef(repoRoot string) string {
+	for _, args := range [][]string{
+		{"symbolic-ref", "--quiet", "--short", "HEAD"},
+		{"describe", "--tags", "--exact-match", "HEAD"},
+	} {
+		cmd := exec.Command("git", args...) // #nosec G204 -- fixed arguments
+		cmd.Dir = repoRoot
+		if output, err := cmd.Output(); err == nil {
+			return strings.TrimSpace(string(output))
+		}
+	}
+
+	return ""
+}
+
 // branchExists checks if a branch exists in the repository.
 func branchExists(repoRoot, branch string) bool {
 	cmd := exec.Command(
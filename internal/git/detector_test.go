@@ -346,3 +346,34 @@ func TestRepoInfo_GetRepositoryName(t *testing.T) {
 		})
 	}
 }
+
+func TestRepoInfo_LinkRef(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertEqual(t, DefaultBranch, (&RepoInfo{}).LinkRef())
+	testutil.AssertEqual(t, "trunk", (&RepoInfo{DefaultBranch: "trunk"}).LinkRef())
+	testutil.AssertEqual(t, "feature/x", (&RepoInfo{DefaultBranch: "trunk", Ref: "feature/x"}).LinkRef())
+}
+
+func TestDetectRepository_Ref(t *testing.T) {
+	t.Parallel()
+
+	dir := initTestRepo(t, map[string]string{"action.yml": "name: Build\n"})
+	gitOutput(t, dir, "checkout", "-q", "-b", "feature/links")
+	info, err := DetectRepository(dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "feature/links", info.Ref)
+
+	// A detached HEAD links to its tag, or to the default branch without one
+	gitOutput(t, dir, "checkout", "-q", "--detach", "v1.0.0")
+	info, err = DetectRepository(dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "v1.0.0", info.Ref)
+
+	testutil.WriteTestFile(t, filepath.Join(dir, "action.yml"), "name: Changed\n")
+	gitOutput(t, dir, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-am", "change")
+	info, err = DetectRepository(dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "", info.Ref)
+	testutil.AssertEqual(t, info.DefaultBranch, info.LinkRef())
+}
@@ -0,0 +1,174 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Separators used to split git log output into commits and fields.
+const (
+	commitFieldSeparator  = "\x1f"
+	commitRecordSeparator = "\x1e"
+	commitFieldCount      = 4
+)
+
+// ShowFile returns the content of path at the given ref. The path is resolved
+// relative to repoRoot and may be absolute.
+func ShowFile(repoRoot, ref, path string) ([]byte, error) {
+	if err := validateRef(ref); err != nil {
+		return nil, err
+	}
+	relPath, err := repoRelativePath(repoRoot, path)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("git", "show", ref+":"+relPath) // #nosec G204 -- ref and path passed as a single argument
+	cmd.Dir = repoRoot
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at %s: %w", relPath, ref, commandError(err))
+	}
+
+	return output, nil
+}
+
+// Commit is a single commit in the repository history.
+type Commit struct {
+	Hash    string `json:"hash"`
+	Date    string `json:"date"`
+	Subject string `json:"subject"`
+	Body    string `json:"body,omitempty"`
+}
+
+// PreviousTag returns the most recently created tag reachable from ref that
+// is not on ref itself, following every parent of merge commits. It returns
+// an empty string when no earlier commit is tagged.
+func PreviousTag(repoRoot, ref string) (string, error) {
+	if err := validateRef(ref); err != nil {
+		return "", err
+	}
+	cmd := exec.Command( // #nosec G204 -- ref passed as an argument
+		"git", "tag", "--merged", ref, "--no-contains", ref, "--sort=-v:refname", "--sort=-creatordate",
+	)
+	cmd.Dir = repoRoot
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find previous tag of %s: %w", ref, commandError(err))
+	}
+	tag, _, _ := strings.Cut(string(output), "\n")
+
+	return strings.TrimSpace(tag), nil
+}
+
+// LastCommit returns the hash of the most recent commit that changed path.
+func LastCommit(repoRoot, path string) (string, error) {
+	relPath, err := repoRelativePath(repoRoot, path)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command("git", "log", "-1", "--format=%H", "--", relPath) // #nosec G204 -- path passed as an argument
+	cmd.Dir = repoRoot
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find last commit of %s: %w", relPath, commandError(err))
+	}
+	hash := strings.TrimSpace(string(output))
+	if hash == "" {
+		return "", fmt.Errorf("%s has no commit history", relPath)
+	}
+
+	return hash, nil
+}
+
+// Commits returns the commits reachable from to but not from from, newest first.
+// An empty from lists the full history of to.
+func Commits(repoRoot, from, to string) ([]Commit, error) {
+	if err := validateRef(to); err != nil {
+		return nil, err
+	}
+	revRange := to
+	if from != "" {
+		if err := validateRef(from); err != nil {
+			return nil, err
+		}
+		revRange = from + ".." + to
+	}
+
+	cmd := exec.Command( // #nosec G204 -- revision range passed as an argument
+		"git", "log", "--format="+strings.Join([]string{"%H", "%cs", "%s", "%b"}, commitFieldSeparator)+
+			commitRecordSeparator,
+		revRange, "--",
+	)
+	cmd.Dir = repoRoot
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits in %s: %w", revRange, commandError(err))
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(string(output), commitRecordSeparator) {
+		fields := strings.SplitN(strings.TrimSpace(record), commitFieldSeparator, commitFieldCount)
+		if len(fields) < commitFieldCount {
+			continue
+		}
+		commits = append(commits, Commit{
+			Hash:    fields[0],
+			Date:    fields[1],
+			Subject: fields[2],
+			Body:    strings.TrimSpace(fields[3]),
+		})
+	}
+
+	return commits, nil
+}
+
+// repoRelativePath converts path to the slash-separated form git expects for ref:path.
+func repoRelativePath(repoRoot, path string) (string, error) {
+	if filepath.IsAbs(path) {
+		absRoot, err := filepath.Abs(repoRoot)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve repository root: %w", err)
+		}
+		if resolved, err := filepath.EvalSymlinks(absRoot); err == nil {
+			absRoot = resolved
+		}
+		if dir, err := filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+			path = filepath.Join(dir, filepath.Base(path))
+		}
+		rel, err := filepath.Rel(absRoot, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return "", fmt.Errorf("%s is outside repository %s", path, repoRoot)
+		}
+		path = rel
+	}
+
+	return filepath.ToSlash(filepath.Clean(path)), nil
+}
+
+// validateRef rejects refs git would parse as command-line options.
+func validateRef(ref string) error {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid git ref %q", ref)
+	}
+
+	return nil
+}
+
+// commandError includes git's stderr output in the returned error when available.
+func commandError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+
+	return err
+}
@@ -0,0 +1,134 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// initTestRepo creates a git repository with a single commit tagged v1.0.0.
+func initTestRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	for name, content := range files {
+		testutil.WriteTestFile(t, filepath.Join(dir, name), content)
+	}
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial"},
+		{"tag", "v1.0.0"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	return dir
+}
+
+func TestShowFile(t *testing.T) {
+	t.Parallel()
+
+	dir := initTestRepo(t, map[string]string{"actions/build/action.yml": "name: Build\n"})
+	testutil.WriteTestFile(t, filepath.Join(dir, "actions/build/action.yml"), "name: Changed\n")
+
+	content, err := ShowFile(dir, "v1.0.0", "actions/build/action.yml")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "name: Build\n", string(content))
+
+	content, err = ShowFile(dir, "HEAD", filepath.Join(dir, "actions", "build", "action.yml"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "name: Build\n", string(content))
+
+	_, err = ShowFile(dir, "v9.9.9", "actions/build/action.yml")
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "v9.9.9")
+
+	_, err = ShowFile(dir, "--output=/tmp/pwned", "actions/build/action.yml")
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "invalid git ref")
+
+	_, err = ShowFile(dir, "HEAD", filepath.Join(t.TempDir(), "action.yml"))
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "outside repository")
+}
+
+func TestPreviousTagAndCommits(t *testing.T) {
+	t.Parallel()
+
+	dir := initTestRepo(t, map[string]string{"action.yml": "name: Build\n"})
+
+	// The root commit carries the only tag, so there is no earlier release
+	tag, err := PreviousTag(dir, "HEAD")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "", tag)
+
+	testutil.WriteTestFile(t, filepath.Join(dir, "action.yml"), "name: Build v2\n")
+	cmd := exec.Command("git", "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"commit", "-q", "-am", "feat: rename action", "-m", "BREAKING CHANGE: new name")
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git commit failed: %v\n%s", err, output)
+	}
+
+	tag, err = PreviousTag(dir, "HEAD")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "v1.0.0", tag)
+
+	commits, err := Commits(dir, tag, "HEAD")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(commits))
+	testutil.AssertEqual(t, "feat: rename action", commits[0].Subject)
+	testutil.AssertEqual(t, "BREAKING CHANGE: new name", commits[0].Body)
+
+	all, err := Commits(dir, "", "HEAD")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(all))
+	testutil.AssertEqual(t, "initial", all[1].Subject)
+
+	_, err = PreviousTag(dir, "--all")
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "invalid git ref")
+
+	_, err = Commits(dir, "--output=/tmp/pwned", "HEAD")
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "invalid git ref")
+}
+
+func TestPreviousTag_MergedBranch(t *testing.T) {
+	t.Parallel()
+
+	dir := initTestRepo(t, map[string]string{"action.yml": "name: Build\n"})
+	commit := []string{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty"}
+	for _, args := range [][]string{
+		{"checkout", "-q", "-b", "hotfix"},
+		append(slices.Clone(commit), "-m", "fix: patch release"),
+		{"tag", "v1.0.1"},
+		{"checkout", "-q", "-"},
+		append(slices.Clone(commit), "-m", "feat: next feature"),
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "merge", "-q", "--no-ff", "-m", "merge", "hotfix"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	// The tag on the merged branch is newer than the one on the first parent
+	tag, err := PreviousTag(dir, "HEAD")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "v1.0.1", tag)
+}
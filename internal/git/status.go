@@ -0,0 +1,41 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// porcelainPathOffset is where the path starts in git status --porcelain
+// entries, after the two status letters and a space.
+const porcelainPathOffset = 3
+
+// DirtyFiles returns the files of paths, relative to repoRoot or absolute,
+// with uncommitted changes: modified, staged or untracked. The files are
+// returned sorted, relative to repoRoot with forward slashes.
+func DirtyFiles(repoRoot string, paths []string) ([]string, error) {
+	relPaths, err := repoRelativePaths(repoRoot, paths)
+	if err != nil || len(relPaths) == 0 {
+		return nil, err
+	}
+
+	args := append([]string{"status", "--porcelain", "-z", "--no-renames", "--untracked-files=all", "--"}, relPaths...)
+	cmd := exec.Command("git", args...) // #nosec G204 -- paths passed as arguments
+	cmd.Dir = repoRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read working tree status: %w", commandError(err))
+	}
+
+	var dirty []string
+	for _, entry := range strings.Split(string(output), "\x00") {
+		if len(entry) > porcelainPathOffset {
+			dirty = append(dirty, entry[porcelainPathOffset:])
+		}
+	}
+
+	slices.Sort(dirty)
+
+	return dirty, nil
+}
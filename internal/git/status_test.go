@@ -0,0 +1,40 @@
+package git
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestDirtyFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := initTestRepo(t, map[string]string{
+		"actions/build/action.yml": "name: Build\n",
+		"actions/test/action.yml":  "name: Test\n",
+		"actions/lint/action.yml":  "name: Lint\n",
+	})
+	paths := []string{
+		filepath.Join(dir, "actions", "build", "action.yml"),
+		"actions/test/action.yml",
+		"actions/lint/action.yml",
+		"actions/new/action.yml",
+	}
+
+	dirty, err := DirtyFiles(dir, paths)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(dirty))
+
+	testutil.WriteTestFile(t, paths[0], "name: Changed\n")
+	testutil.WriteTestFile(t, filepath.Join(dir, "actions", "test", "action.yml"), "name: Staged\n")
+	gitOutput(t, dir, "add", "actions/test/action.yml")
+	testutil.WriteTestFile(t, filepath.Join(dir, "actions", "new", "action.yml"), "name: New\n")
+	testutil.WriteTestFile(t, filepath.Join(dir, "notes.txt"), "not asked about\n")
+
+	dirty, err = DirtyFiles(dir, paths)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "actions/build/action.yml,actions/new/action.yml,actions/test/action.yml",
+		strings.Join(dirty, ","))
+}
@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Stages of generation that hooks run at.
+const (
+	// HookPreGen runs before the documentation of an action is generated.
+	HookPreGen = "pre_gen"
+	// HookPostGen runs after the documentation of an action is written.
+	HookPostGen = "post_gen"
+)
+
+// Failure policies of hooks.
+const (
+	HookFailureFail   = "fail"   // The generation of the action fails
+	HookFailureWarn   = "warn"   // A warning is shown and generation continues
+	HookFailureIgnore = "ignore" // Generation continues silently
+)
+
+// DefaultHookTimeout is how long each hook command may run when hooks set no timeout.
+const DefaultHookTimeout = "60s"
+
+// HookEnvPrefix prefixes the environment variables describing the action to
+// hook commands. The names do not match configuration keys, so a hook running
+// gh-action-readme does not change its settings.
+const HookEnvPrefix = "GH_ACTION_README_HOOK_"
+
+// hookWaitDelay bounds how long a timed out hook's children may keep its output open.
+const hookWaitDelay = time.Second
+
+// hookFailurePolicies are the valid values of hooks.on_failure.
+var hookFailurePolicies = []string{HookFailureFail, HookFailureWarn, HookFailureIgnore}
+
+// HookSettings are shell commands run before and after the documentation of
+// each action is generated, in the directory of the action file.
+type HookSettings struct {
+	PreGen    []string `mapstructure:"pre_gen"    yaml:"pre_gen,omitempty"`
+	PostGen   []string `mapstructure:"post_gen"   yaml:"post_gen,omitempty"`
+	Timeout   string   `mapstructure:"timeout"    yaml:"timeout,omitempty"`    // Per command, such as "30s"
+	OnFailure string   `mapstructure:"on_failure" yaml:"on_failure,omitempty"` // fail, warn or ignore
+}
+
+// ValidateHookSettings rejects empty commands, invalid timeouts and unknown
+// failure policies.
+func ValidateHookSettings(settings HookSettings) error {
+	for stage, commands := range map[string][]string{HookPreGen: settings.PreGen, HookPostGen: settings.PostGen} {
+		for _, command := range commands {
+			if strings.TrimSpace(command) == "" {
+				return fmt.Errorf("hooks.%s contains an empty command", stage)
+			}
+		}
+	}
+	if settings.Timeout != "" {
+		if timeout, err := time.ParseDuration(settings.Timeout); err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid hooks.timeout '%s', must be a positive duration such as 30s or 5m",
+				settings.Timeout)
+		}
+	}
+	if settings.OnFailure != "" && !containsString(hookFailurePolicies, settings.OnFailure) {
+		return fmt.Errorf("invalid hooks.on_failure '%s', must be one of: %s",
+			settings.OnFailure, strings.Join(hookFailurePolicies, ", "))
+	}
+
+	return nil
+}
+
+// TimeoutDuration returns the timeout of each hook command, or the default for
+// an empty or invalid timeout.
+func (s HookSettings) TimeoutDuration() time.Duration {
+	if timeout, err := time.ParseDuration(s.Timeout); err == nil && timeout > 0 {
+		return timeout
+	}
+	timeout, _ := time.ParseDuration(DefaultHookTimeout)
+
+	return timeout
+}
+
+// failurePolicy returns the failure policy, fail unless set.
+func (s HookSettings) failurePolicy() string {
+	if s.OnFailure == "" {
+		return HookFailureFail
+	}
+
+	return s.OnFailure
+}
+
+// commands returns the commands of stage.
+func (s HookSettings) commands(stage string) []string {
+	if stage == HookPreGen {
+		return s.PreGen
+	}
+
+	return s.PostGen
+}
+
+// runHooks runs the hook commands of stage for an action in order, stopping at
+// the first failure. Failures fail generation, or are reported as warnings or
+// ignored, depending on hooks.on_failure. post_gen hooks do not run in check
+// mode, where nothing is written.
+func (g *Generator) runHooks(stage string, action *ActionYML, actionPath, outputDir string) error {
+	hooks := g.Config.Hooks
+	commands := hooks.commands(stage)
+	if len(commands) == 0 || (stage == HookPostGen && g.Check) {
+		return nil
+	}
+
+	env := g.hookEnv(stage, action, actionPath, outputDir)
+	for _, command := range commands {
+		output, err := runHookCommand(command, filepath.Dir(actionPath), env, hooks.TimeoutDuration())
+		if g.Config.Verbose && output != "" {
+			g.Output.Printf("%s", output)
+		}
+		if err == nil {
+			continue
+		}
+
+		if output = strings.TrimSpace(output); output != "" {
+			err = fmt.Errorf("%w: %s", err, output)
+		}
+		err = fmt.Errorf("%s hook %q failed for %s: %w", stage, command, actionPath, err)
+		switch hooks.failurePolicy() {
+		case HookFailureWarn:
+			g.Output.Warning("%v", err)
+		case HookFailureIgnore:
+			if g.Config.Verbose {
+				g.Output.Info("Ignoring %v", err)
+			}
+		default:
+			return err
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// hookEnv returns the environment of hook commands: the environment of the
+// tool and variables describing the action.
+func (g *Generator) hookEnv(stage string, action *ActionYML, actionPath, outputDir string) []string {
+	absAction, _ := filepath.Abs(actionPath)
+	absOutput, _ := filepath.Abs(outputDir)
+	values := map[string]string{
+		"STAGE":       stage,
+		"ACTION_PATH": absAction,
+		"ACTION_DIR":  filepath.Dir(absAction),
+		"ACTION_NAME": action.Name,
+		"OUTPUT_DIR":  absOutput,
+		"FORMAT":      g.Config.OutputFormat,
+		"THEME":       g.Config.Theme,
+		"CHECK":       strconv.FormatBool(g.Check),
+	}
+
+	env := os.Environ()
+	for name, value := range values {
+		env = append(env, HookEnvPrefix+name+"="+value)
+	}
+
+	return env
+}
+
+// runHookCommand runs command with the system shell in dir and returns its
+// combined output.
+func runHookCommand(command, dir string, env []string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	// #nosec G204 -- hooks are configured in the global configuration only
+	cmd := exec.CommandContext(ctx, shell, flag, command)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.WaitDelay = hookWaitDelay
+	var output bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &output, &output
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return output.String(), fmt.Errorf("timed out after %s", timeout)
+	}
+
+	return output.String(), err
+}
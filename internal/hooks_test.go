@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestValidateHookSettings(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, ValidateHookSettings(HookSettings{}))
+	testutil.AssertNoError(t, ValidateHookSettings(HookSettings{
+		PreGen: []string{"make fetch-metadata"}, Timeout: "5m", OnFailure: HookFailureWarn,
+	}))
+	testutil.AssertError(t, ValidateHookSettings(HookSettings{PostGen: []string{" "}}))
+	testutil.AssertError(t, ValidateHookSettings(HookSettings{Timeout: "-1s"}))
+	testutil.AssertError(t, ValidateHookSettings(HookSettings{OnFailure: "retry"}))
+}
+
+func TestMergeConfigs_HooksGlobalOnly(t *testing.T) {
+	t.Parallel()
+
+	src := &AppConfig{Hooks: HookSettings{PostGen: []string{"./upload.sh"}, OnFailure: HookFailureWarn}}
+	repo := &AppConfig{}
+	MergeConfigs(repo, src, false)
+	testutil.AssertEqual(t, 0, len(repo.Hooks.PostGen))
+
+	global := &AppConfig{Hooks: HookSettings{PreGen: []string{"make fetch-metadata"}}}
+	MergeConfigs(global, src, true)
+	testutil.AssertEqual(t, "make fetch-metadata", global.Hooks.PreGen[0])
+	testutil.AssertEqual(t, "./upload.sh", global.Hooks.PostGen[0])
+	testutil.AssertEqual(t, HookFailureWarn, global.Hooks.OnFailure)
+}
+
+func TestGenerator_Hooks(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	logPath := filepath.Join(tmpDir, "hooks.log")
+
+	config := DefaultAppConfig()
+	config.Theme = ThemeMinimal
+	config.OutputDir = filepath.Join(tmpDir, "docs")
+	config.Quiet = true
+	config.Hooks = HookSettings{
+		PreGen: []string{`echo "pre $GH_ACTION_README_HOOK_STAGE $GH_ACTION_README_HOOK_ACTION_NAME" >> hooks.log`},
+		PostGen: []string{
+			`test -f "$GH_ACTION_README_HOOK_OUTPUT_DIR/README.md" && ` +
+				`echo "post $GH_ACTION_README_HOOK_FORMAT $GH_ACTION_README_HOOK_CHECK" >> hooks.log`,
+		},
+	}
+	testutil.AssertNoError(t, NewGenerator(config).GenerateFromFile(actionPath))
+	log, err := os.ReadFile(logPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "pre pre_gen Simple JavaScript Action\npost md false\n", string(log))
+
+	// post_gen hooks do not run in check mode
+	testutil.AssertNoError(t, os.Remove(logPath))
+	checker := NewGenerator(config)
+	checker.Check = true
+	testutil.AssertNoError(t, checker.GenerateFromFile(actionPath))
+	log, err = os.ReadFile(logPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "pre pre_gen Simple JavaScript Action\n", string(log))
+
+	config.Hooks = HookSettings{PreGen: []string{"echo 'metadata service down'; exit 2"}}
+	err = NewGenerator(config).GenerateFromFile(actionPath)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "metadata service down")
+
+	config.Hooks.OnFailure = HookFailureWarn
+	testutil.AssertNoError(t, NewGenerator(config).GenerateFromFile(actionPath))
+
+	config.Hooks = HookSettings{PreGen: []string{"sleep 5"}, Timeout: "50ms"}
+	err = NewGenerator(config).GenerateFromFile(actionPath)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "timed out after 50ms")
+}
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SharedStylesheetName is the file name of the stylesheet shared by HTML pages
+// generated with assets_dir.
+const SharedStylesheetName = "gh-action-readme.css"
+
+// styleBlockRe matches an inline <style> block with its indentation and line break.
+var styleBlockRe = regexp.MustCompile(`(?is)([ \t]*)<style[^>]*>(.*?)</style>[ \t]*\n?`)
+
+// extractStyles moves the <style> blocks of an HTML page to a stylesheet. It
+// returns the page, with the first block replaced by a link to href and the
+// others removed, and the CSS of all blocks. Pages without styles are returned
+// unchanged with empty CSS.
+func extractStyles(page, href string) (string, string) {
+	matches := styleBlockRe.FindAllStringSubmatchIndex(page, -1)
+	if len(matches) == 0 {
+		return page, ""
+	}
+
+	var out strings.Builder
+	var css []string
+	last := 0
+	for i, m := range matches {
+		out.WriteString(page[last:m[0]])
+		if i == 0 {
+			fmt.Fprintf(&out, "%s<link rel=\"stylesheet\" href=\"%s\">\n", page[m[2]:m[3]], href)
+		}
+		if block := strings.TrimSpace(dedent(page[m[4]:m[5]])); block != "" {
+			css = append(css, block)
+		}
+		last = m[1]
+	}
+	out.WriteString(page[last:])
+
+	return out.String(), strings.Join(css, "\n\n") + "\n"
+}
+
+// dedent removes the indentation shared by the non-blank lines of text.
+func dedent(text string) string {
+	lines := strings.Split(text, "\n")
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		width := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || width < indent {
+			indent = width
+		}
+	}
+	for i, line := range lines {
+		if len(line) >= indent && indent > 0 {
+			lines[i] = line[indent:]
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// stylesheetHref returns the link from the page at pagePath to the shared
+// stylesheet in assetsDir, as a slash-separated relative URL.
+func stylesheetHref(pagePath, assetsDir string) (string, error) {
+	pageDir, err := filepath.Abs(filepath.Dir(pagePath))
+	if err != nil {
+		return "", err
+	}
+	assets, err := filepath.Abs(assetsDir)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(pageDir, assets)
+	if err != nil {
+		return "", fmt.Errorf("failed to link %s to assets directory %s: %w", pagePath, assetsDir, err)
+	}
+
+	return filepath.ToSlash(filepath.Join(rel, SharedStylesheetName)), nil
+}
+
+// linkSharedStyles replaces the inline styles of the HTML page written to
+// pagePath with a link to the shared stylesheet in assets_dir, and writes the
+// stylesheet unless it is up to date or the generator runs in check mode.
+func (g *Generator) linkSharedStyles(page, pagePath string) (string, error) {
+	href, err := stylesheetHref(pagePath, g.Config.AssetsDir)
+	if err != nil {
+		return "", err
+	}
+	page, css := extractStyles(page, href)
+	if css == "" || g.Check {
+		return page, nil
+	}
+
+	stylesheet := filepath.Join(g.Config.AssetsDir, SharedStylesheetName)
+	if err := writeIfChanged(stylesheet, []byte(css)); err != nil {
+		return "", fmt.Errorf("failed to write stylesheet %s: %w", stylesheet, err)
+	}
+	g.recordOutput(stylesheet)
+
+	return page, nil
+}
@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestExtractStyles(t *testing.T) {
+	t.Parallel()
+
+	page := "<head>\n  <style>\n    body { margin: 0; }\n  </style>\n  <style>pre { padding: 1em; }</style>\n</head>\n"
+	got, css := extractStyles(page, "../assets/"+SharedStylesheetName)
+	testutil.AssertEqual(t,
+		"<head>\n  <link rel=\"stylesheet\" href=\"../assets/gh-action-readme.css\">\n</head>\n", got)
+	testutil.AssertEqual(t, "body { margin: 0; }\n\npre { padding: 1em; }\n", css)
+
+	got, css = extractStyles("<p>No styles</p>", SharedStylesheetName)
+	testutil.AssertEqual(t, "<p>No styles</p>", got)
+	testutil.AssertEqual(t, "", css)
+}
+
+func TestGenerator_SharedStylesheet(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionYML := testutil.MustReadFixture("actions/javascript/simple.yml")
+	rootAction := filepath.Join(tmpDir, "action.yml")
+	nestedAction := filepath.Join(tmpDir, "actions", "deploy", "action.yml")
+	testutil.WriteTestFile(t, rootAction, actionYML)
+	testutil.WriteTestFile(t, nestedAction, strings.Replace(actionYML, "name: ", "name: Nested ", 1))
+
+	config := DefaultAppConfig()
+	config.OutputFormat = OutputFormatHTML
+	config.Quiet = true
+	config.AssetsDir = filepath.Join(tmpDir, "assets")
+	generator := NewGenerator(config)
+	testutil.AssertNoError(t, generator.ProcessBatch([]string{rootAction, nestedAction}))
+
+	css, err := os.ReadFile(filepath.Join(tmpDir, "assets", SharedStylesheetName))
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(css), "body {")
+
+	pages := map[string]string{tmpDir: "assets/", filepath.Dir(nestedAction): "../../assets/"}
+	for dir, prefix := range pages {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.html"))
+		if len(files) != 1 {
+			t.Fatalf("expected one HTML page in %s, found %v", dir, files)
+		}
+		page, err := os.ReadFile(files[0])
+		testutil.AssertNoError(t, err)
+		testutil.AssertStringContains(t, string(page),
+			"<link rel=\"stylesheet\" href=\""+prefix+SharedStylesheetName+"\">")
+		if strings.Contains(string(page), "<style") {
+			t.Errorf("page %s still has inline styles", files[0])
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// IDEVSCode is the editor supported by ide init.
+const IDEVSCode = "vscode"
+
+// vscodeYAMLExtension is the VS Code extension applying yaml.schemas.
+const vscodeYAMLExtension = "redhat.vscode-yaml"
+
+// vscodeTaskPrefix starts the labels of the tasks ide init writes, which it
+// replaces on later runs.
+const vscodeTaskPrefix = toolName + ": "
+
+// IDEFile is a workspace file written by InitVSCode.
+type IDEFile struct {
+	Path    string
+	Changed bool // False when the file already held every entry
+}
+
+// InitVSCode adds tasks generating, validating and watching the documentation,
+// the action schema association and the YAML extension recommendation to the
+// .vscode workspace files in dir. Existing files are merged: other tasks,
+// settings and recommendations are kept, and tasks written by an earlier run
+// are replaced. Files that are not plain JSON, such as files with comments,
+// are left untouched and reported as an error.
+func InitVSCode(dir string) ([]IDEFile, error) {
+	vscodeDir := filepath.Join(dir, ".vscode")
+	files := []struct {
+		name  string
+		merge func(map[string]any)
+	}{
+		{"tasks.json", mergeVSCodeTasks},
+		{"settings.json", mergeVSCodeSettings},
+		{"extensions.json", mergeVSCodeExtensions},
+	}
+
+	written := make([]IDEFile, 0, len(files))
+	for _, file := range files {
+		path := filepath.Join(vscodeDir, file.name)
+		changed, err := mergeJSONFile(path, file.merge)
+		if err != nil {
+			return written, err
+		}
+		written = append(written, IDEFile{Path: path, Changed: changed})
+	}
+
+	return written, nil
+}
+
+// mergeJSONFile applies merge to the JSON object in path, creating the file
+// when missing, and reports whether the content changed.
+func mergeJSONFile(path string, merge func(map[string]any)) (bool, error) {
+	original, err := os.ReadFile(path) // #nosec G304 -- workspace file below the chosen directory
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	content := map[string]any{}
+	if len(bytes.TrimSpace(original)) > 0 {
+		if err := json.Unmarshal(original, &content); err != nil {
+			return false, fmt.Errorf("failed to parse %s, merge the entries by hand if it has comments: %w", path, err)
+		}
+	}
+	merge(content)
+
+	data, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return false, err
+	}
+	data = append(data, '\n')
+	if bytes.Equal(data, original) {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil { // #nosec G301 -- workspace directory permissions
+		return false, err
+	}
+
+	return true, os.WriteFile(path, data, FilePermDefault)
+}
+
+// vscodeTasks returns the tasks of gh-action-readme. Output is uncolored so
+// the problem matcher reads validation findings.
+func vscodeTasks() []any {
+	task := func(label, group string, args ...string) map[string]any {
+		return map[string]any{
+			"label":          vscodeTaskPrefix + label,
+			"type":           "shell",
+			"command":        toolName,
+			"args":           args,
+			"group":          group,
+			"options":        map[string]any{"env": map[string]any{"NO_COLOR": "1"}},
+			"problemMatcher": []any{},
+		}
+	}
+
+	generate := task("generate", "build", "gen", "--recursive")
+	validate := task("validate", "test", "validate", "--recursive")
+	validate["problemMatcher"] = map[string]any{
+		"owner":        toolName,
+		"fileLocation": "absolute",
+		"severity":     "warning",
+		"pattern": []any{
+			map[string]any{"regexp": `📁 File: (.+)$`, "file": 1},
+			map[string]any{
+				"regexp": `\[([a-z0-9-]+)\] (.+) \(line (\d+)\)$`,
+				"code":   1, "message": 2, "line": 3, "loop": true,
+			},
+		},
+	}
+	// serve renders the documentation on request and reloads it when action files change
+	watch := task("watch", "build", "serve")
+	watch["isBackground"] = true
+
+	return []any{generate, validate, watch}
+}
+
+// mergeVSCodeTasks replaces the gh-action-readme tasks of tasks.json.
+func mergeVSCodeTasks(content map[string]any) {
+	if _, ok := content["version"]; !ok {
+		content["version"] = "2.0.0"
+	}
+	existing, _ := content["tasks"].([]any)
+	tasks := slices.DeleteFunc(slices.Clone(existing), func(task any) bool {
+		fields, _ := task.(map[string]any)
+		label, _ := fields["label"].(string)
+
+		return strings.HasPrefix(label, vscodeTaskPrefix)
+	})
+	content["tasks"] = append(tasks, vscodeTasks()...)
+}
+
+// mergeVSCodeSettings associates the action schema with action files.
+func mergeVSCodeSettings(content map[string]any) {
+	schemas, ok := content["yaml.schemas"].(map[string]any)
+	if !ok {
+		schemas = map[string]any{}
+	}
+	patterns := make([]any, 0, len(actionFilePatterns))
+	for _, pattern := range actionFilePatterns {
+		patterns = append(patterns, pattern)
+	}
+	schemas[ActionSchemaURL] = patterns
+	content["yaml.schemas"] = schemas
+}
+
+// mergeVSCodeExtensions recommends the YAML extension.
+func mergeVSCodeExtensions(content map[string]any) {
+	recommendations, _ := content["recommendations"].([]any)
+	if !slices.Contains(recommendations, any(vscodeYAMLExtension)) {
+		recommendations = append(recommendations, vscodeYAMLExtension)
+	}
+	content["recommendations"] = recommendations
+}
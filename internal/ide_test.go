@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestInitVSCode(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tasksPath := filepath.Join(dir, ".vscode", "tasks.json")
+	testutil.WriteTestFile(t, tasksPath, `{"version": "2.0.0", "tasks": [
+		{"label": "build", "type": "shell", "command": "make"},
+		{"label": "gh-action-readme: generate", "type": "shell", "command": "old"}
+	]}`)
+	testutil.WriteTestFile(t, filepath.Join(dir, ".vscode", "settings.json"),
+		`{"editor.tabSize": 2, "yaml.schemas": {"https://example.com/workflow.json": ".github/workflows/*"}}`)
+
+	files, err := InitVSCode(dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 3, len(files))
+	for _, file := range files {
+		testutil.AssertEqual(t, true, file.Changed)
+	}
+
+	var tasks struct {
+		Tasks []struct {
+			Label   string `json:"label"`
+			Command string `json:"command"`
+		} `json:"tasks"`
+	}
+	readJSON(t, tasksPath, &tasks)
+	labels := make([]string, 0, len(tasks.Tasks))
+	for _, task := range tasks.Tasks {
+		labels = append(labels, task.Label)
+	}
+	testutil.AssertEqual(t, "build, gh-action-readme: generate, gh-action-readme: validate, gh-action-readme: watch",
+		strings.Join(labels, ", "))
+	testutil.AssertEqual(t, toolName, tasks.Tasks[1].Command)
+
+	var settings map[string]any
+	readJSON(t, filepath.Join(dir, ".vscode", "settings.json"), &settings)
+	testutil.AssertEqual(t, 2.0, settings["editor.tabSize"])
+	schemas, _ := settings["yaml.schemas"].(map[string]any)
+	testutil.AssertEqual(t, 2, len(schemas))
+	if _, ok := schemas[ActionSchemaURL]; !ok {
+		t.Errorf("expected the action schema association, got %v", schemas)
+	}
+
+	// A second run finds every entry in place
+	files, err = InitVSCode(dir)
+	testutil.AssertNoError(t, err)
+	for _, file := range files {
+		testutil.AssertEqual(t, false, file.Changed)
+	}
+
+	testutil.WriteTestFile(t, tasksPath, "{\n  // Team tasks\n  \"tasks\": []\n}\n")
+	_, err = InitVSCode(dir)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "merge the entries by hand")
+}
+
+func readJSON(t *testing.T, path string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(path) // #nosec G304 -- test file path
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, json.Unmarshal(data, v))
+}
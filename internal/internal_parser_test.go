@@ -1,8 +1,12 @@
 package internal
 
 import (
+	"errors"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
 	"github.com/ivuorinen/gh-action-readme/testutil"
 )
 
@@ -32,3 +36,253 @@ func TestParseActionYML_MissingFile(t *testing.T) {
 		t.Error("expected error on missing file")
 	}
 }
+
+func TestParseActionYML_PreservesDeclarationOrder(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, `name: Ordered
+description: Inputs declared out of alphabetical order
+inputs:
+  zeta:
+    description: last alphabetically
+  alpha:
+    description: first alphabetically
+  mid:
+    description: required input
+    required: true
+outputs:
+  result:
+    description: result
+  id:
+    description: id
+runs:
+  using: node20
+  main: index.js
+`)
+
+	action, err := ParseActionYML(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "zeta,alpha,mid", strings.Join(action.InputOrder, ","))
+	testutil.AssertEqual(t, "result,id", strings.Join(action.OutputOrder, ","))
+
+	tests := []struct {
+		mode     string
+		expected string
+	}{
+		{SortInputsDeclaration, "zeta,alpha,mid"},
+		{SortInputsAlpha, "alpha,mid,zeta"},
+		{SortInputsRequiredFirst, "mid,zeta,alpha"},
+		{"", "zeta,alpha,mid"},
+	}
+
+	for _, tt := range tests {
+		var names []string
+		for _, input := range action.SortedInputs(tt.mode) {
+			names = append(names, input.Name)
+		}
+		testutil.AssertEqual(t, tt.expected, strings.Join(names, ","))
+	}
+
+	outputs := action.OutputList()
+	testutil.AssertEqual(t, "result", outputs[0].Name)
+	testutil.AssertEqual(t, "id", outputs[1].Name)
+}
+
+func TestActionYML_InputListWithoutDeclarationOrder(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{
+		Inputs: map[string]ActionInput{
+			"zeta":  {Description: "last"},
+			"alpha": {Description: "first", Required: true},
+			"mid":   {Description: "middle"},
+		},
+		InputOrder: []string{"mid"},
+	}
+
+	for range 10 {
+		inputs := action.InputList()
+		testutil.AssertEqual(t, 3, len(inputs))
+		testutil.AssertEqual(t, "mid", inputs[0].Name)
+		testutil.AssertEqual(t, "alpha", inputs[1].Name)
+		testutil.AssertEqual(t, "zeta", inputs[2].Name)
+		testutil.AssertEqual(t, true, inputs[1].Required)
+	}
+}
+
+func TestActionYML_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		runs     map[string]any
+		expected []LifecycleHook
+	}{
+		{
+			name: "node with pre and post",
+			runs: map[string]any{
+				"using": "node20", "pre": "setup.js", "main": "index.js",
+				"post": "cleanup.js", "post-if": "success()",
+			},
+			expected: []LifecycleHook{
+				{Stage: LifecycleStagePre, Entrypoint: "setup.js"},
+				{Stage: LifecycleStageMain, Entrypoint: "index.js"},
+				{Stage: LifecycleStagePost, Entrypoint: "cleanup.js", If: "success()"},
+			},
+		},
+		{
+			name: "docker with pre-entrypoint",
+			runs: map[string]any{
+				"using": "docker", "image": "Dockerfile", "pre-entrypoint": "pre.sh", "pre-if": "runner.os == 'Linux'",
+			},
+			expected: []LifecycleHook{
+				{Stage: LifecycleStagePre, Entrypoint: "pre.sh", If: "runner.os == 'Linux'"},
+			},
+		},
+		{
+			name: "main only",
+			runs: map[string]any{"using": "node20", "main": "index.js"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hooks := (&ActionYML{Runs: tt.runs}).Lifecycle()
+			testutil.AssertEqual(t, len(tt.expected), len(hooks))
+			for i, hook := range hooks {
+				testutil.AssertEqual(t, tt.expected[i], hook)
+			}
+		})
+	}
+}
+
+func TestParseActionYML_FullSchema(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	compositePath := filepath.Join(tmpDir, "composite.yml")
+	testutil.WriteTestFile(t, compositePath, testutil.MustReadFixture("actions/composite/full-schema.yml"))
+	composite, err := ParseActionYML(compositePath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "Test Author <test@example.com>", composite.Author)
+	testutil.AssertEqual(t, "Use the token input instead.", composite.Inputs["github-token"].DeprecationMessage)
+	testutil.AssertEqual(t, "${{ steps.test.outputs.result }}", composite.Outputs["result"].Value)
+
+	steps, _ := composite.Runs["steps"].([]any)
+	testutil.AssertEqual(t, 3, len(steps))
+	step, _ := steps[1].(map[string]any)
+	testutil.AssertEqual(t, "${{ inputs.allow-failure == 'true' }}", step["continue-on-error"])
+	testutil.AssertEqual(t, "./src", step["working-directory"])
+
+	dockerPath := filepath.Join(tmpDir, "docker.yml")
+	testutil.WriteTestFile(t, dockerPath, testutil.MustReadFixture("actions/docker/full-schema.yml"))
+	docker, err := ParseActionYML(dockerPath)
+	testutil.AssertNoError(t, err)
+	args, _ := docker.Runs["args"].([]any)
+	testutil.AssertEqual(t, 3, len(args))
+	testutil.AssertEqual(t, "${{ inputs.target }}", args[1])
+	env, _ := docker.Runs["env"].(map[string]any)
+	testutil.AssertEqual(t, true, env["VERBOSE"])
+	testutil.AssertEqual(t, 3, len(docker.Lifecycle()))
+}
+
+func TestParseActionYMLContent_DeclarationOrderThroughAliases(t *testing.T) {
+	t.Parallel()
+
+	action, err := ParseActionYMLContent([]byte(`name: Shared
+description: Inputs merged from shared mappings
+x-common: &common
+  token: {description: Token}   # flow style
+  debug:
+    description: Debug
+x-extra: &extra {verbose: {description: Verbose}}
+inputs:
+  zeta:
+    description: Declared first
+  <<: [*common, *extra]
+  debug:
+    description: Overrides the merged input
+outputs: *common
+runs:
+  using: node20
+  main: index.js
+`))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "zeta,token,debug,verbose", strings.Join(action.InputOrder, ","))
+	testutil.AssertEqual(t, "Overrides the merged input", action.Inputs["debug"].Description)
+	testutil.AssertEqual(t, "token,debug", strings.Join(action.OutputOrder, ","))
+}
+
+func TestParseActionYML_AnchorsAndMergeKeys(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/composite/anchors.yml"))
+
+	action, err := ParseActionYML(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "GitHub token", action.Inputs["token"].Description)
+	testutil.AssertEqual(t, true, action.Inputs["token"].Required)
+	testutil.AssertEqual(t, "Registry token", action.Inputs["registry-token"].Description)
+	testutil.AssertEqual(t, false, action.Inputs["registry-token"].Required)
+	testutil.AssertEqual(t, "token,registry-token,path", strings.Join(action.InputOrder, ","))
+
+	steps, _ := action.Runs["steps"].([]any)
+	testutil.AssertEqual(t, 4, len(steps))
+	testStep, _ := steps[2].(map[string]any)
+	testutil.AssertEqual(t, "bash", testStep["shell"])
+	lastStep, _ := steps[3].(map[string]any)
+	testutil.AssertEqual(t, "actions/checkout@v4", lastStep["uses"])
+
+	result, err := ValidateActionFile(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, false, result.HasErrors())
+}
+
+func TestParseActionYMLContent_RejectsAliasExpansion(t *testing.T) {
+	t.Parallel()
+
+	content := "x-a: &a [x, x, x, x, x, x, x, x, x, x]\n"
+	prev := "a"
+	for _, name := range []string{"b", "c", "d", "e", "f", "g"} {
+		content += "x-" + name + ": &" + name + " [" + strings.Repeat("*"+prev+", ", 9) + "*" + prev + "]\n"
+		prev = name
+	}
+
+	_, err := ParseActionYMLContent([]byte(content))
+	if !errors.Is(err, yamlsafe.ErrLimitExceeded) {
+		t.Fatalf("expected alias expansion to be rejected, got %v", err)
+	}
+}
+
+func TestParseActionYML_CachesByContent(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, "name: Cached\ndescription: First\n")
+
+	first, err := ParseActionYML(actionPath)
+	testutil.AssertNoError(t, err)
+	first.Name = "Changed by caller"
+	second, err := ParseActionYML(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "Cached", second.Name)
+
+	testutil.WriteTestFile(t, actionPath, "name: Cached\ndescription: Second\n")
+	third, err := ParseActionYML(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "Second", third.Description)
+}
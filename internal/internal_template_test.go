@@ -2,6 +2,7 @@ package internal
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ivuorinen/gh-action-readme/testutil"
@@ -31,3 +32,269 @@ func TestRenderReadme(t *testing.T) {
 		t.Error("unexpected output content")
 	}
 }
+
+func TestRenderReadme_HonorsSortInputs(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	tmpl := filepath.Join(tmpDir, "order.tmpl")
+	testutil.WriteTestFile(t, tmpl, "{{range .InputList}}{{.Name}},{{end}}")
+
+	action := &ActionYML{
+		Name: "Ordered",
+		Inputs: map[string]ActionInput{
+			"zeta":  {Description: "z"},
+			"alpha": {Description: "a"},
+			"mid":   {Description: "m", Required: true},
+		},
+		InputOrder: []string{"zeta", "alpha", "mid"},
+	}
+
+	tests := map[string]string{
+		SortInputsDeclaration:   "zeta,alpha,mid,",
+		SortInputsAlpha:         "alpha,mid,zeta,",
+		SortInputsRequiredFirst: "mid,zeta,alpha,",
+	}
+
+	for mode, expected := range tests {
+		config := DefaultAppConfig()
+		config.SortInputs = mode
+		data := BuildTemplateData(action, config, "", "")
+
+		out, err := RenderReadme(data, TemplateOptions{TemplatePath: tmpl, Format: "md"})
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, expected, out)
+	}
+}
+
+func TestRenderReadme_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{
+		Name:        "Cache",
+		Description: "Caches things",
+		Runs: map[string]any{
+			"using": "node20", "pre": "setup.js", "main": "index.js",
+			"post": "cleanup.js", "post-if": "success()",
+		},
+	}
+	data := BuildTemplateData(action, DefaultAppConfig(), "", "")
+
+	out, err := RenderReadme(data, TemplateOptions{TemplatePath: "templates/themes/github/readme.tmpl", Format: "md"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "| pre | `setup.js` | `always()` (default) |")
+	testutil.AssertStringContains(t, out, "| post | `cleanup.js` | `success()` |")
+}
+
+func TestRenderReadme_DeprecatedInput(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{
+		Name:        "Build",
+		Description: "Builds things",
+		Inputs: map[string]ActionInput{
+			"github-token": {Description: "Token", DeprecationMessage: "Use token instead."},
+		},
+	}
+	data := BuildTemplateData(action, DefaultAppConfig(), "", "")
+
+	out, err := RenderReadme(data, TemplateOptions{TemplatePath: "templates/themes/github/readme.tmpl", Format: "md"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "| `github-token` | Token<br>⚠️ **Deprecated:** Use token instead. |")
+}
+
+func TestRenderReadme_Metadata(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "action.meta.yml"), "category: build\ntags: [deploy, node_20]\n")
+
+	config := DefaultAppConfig()
+	config.AnalyzeDependencies = false
+	action := &ActionYML{Name: "Build", Description: "Builds things"}
+
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{"templates/themes/github/readme.tmpl", "![Category](https://img.shields.io/badge/category-build-blueviolet)"},
+		{"templates/themes/github/readme.tmpl", "![node_20](https://img.shields.io/badge/tag-node__20-lightgrey)"},
+		{"templates/themes/minimal/readme.tmpl", "**Category:** build · **Tags:** `deploy`, `node_20`"},
+	}
+	for _, tt := range tests {
+		out, err := RenderReadme(BuildTemplateData(action, config, "", actionPath),
+			TemplateOptions{TemplatePath: tt.template, Format: "md"})
+		testutil.AssertNoError(t, err)
+		testutil.AssertStringContains(t, out, tt.expected)
+	}
+
+	out, err := RenderReadme(BuildTemplateData(action, config, "", ""),
+		TemplateOptions{TemplatePath: "templates/themes/github/readme.tmpl", Format: "md"})
+	testutil.AssertNoError(t, err)
+	if strings.Contains(out, "badge/category-") {
+		t.Error("expected no category badge without sidecar metadata")
+	}
+}
+
+func TestRenderReadme_Maintainers(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "CODEOWNERS"), "action.yml @acme/platform dev@example.com\n")
+
+	config := DefaultAppConfig()
+	config.AnalyzeDependencies = false
+	data := BuildTemplateData(&ActionYML{Name: "Build", Description: "Builds things"}, config, tmpDir, actionPath)
+
+	out, err := RenderReadme(data, TemplateOptions{TemplatePath: "templates/themes/github/readme.tmpl", Format: "md"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "## 👥 Maintainers")
+	testutil.AssertStringContains(t, out, "- [@acme/platform](https://github.com/orgs/acme/teams/platform)")
+	testutil.AssertStringContains(t, out, "- [dev@example.com](mailto:dev@example.com)")
+}
+
+func TestRenderReadme_Support(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, ".github", "SECURITY.md"), "# Security\n")
+
+	config := DefaultAppConfig()
+	config.AnalyzeDependencies = false
+	config.Organization = "acme"
+	config.Repository = "tools"
+	action := &ActionYML{Name: "Build", Description: "Builds things"}
+	render := func() string {
+		out, err := RenderReadme(BuildTemplateData(action, config, tmpDir, ""),
+			TemplateOptions{TemplatePath: "templates/themes/github/readme.tmpl", Format: "md"})
+		testutil.AssertNoError(t, err)
+
+		return out
+	}
+
+	if strings.Contains(render(), "## 🆘 Support") {
+		t.Error("expected no support section unless show_support is enabled")
+	}
+
+	config.ShowSupport = true
+	testutil.AssertStringContains(t, render(),
+		"## 🆘 Support\n\n- [Security policy](https://github.com/acme/tools/security/policy)")
+}
+
+func TestRenderReadme_DependencySecurity(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, `name: Build
+description: Builds things
+runs:
+  using: composite
+  steps:
+    - uses: actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab
+    - uses: actions/setup-node@v4
+`)
+
+	config := DefaultAppConfig()
+	config.AnalyzeDependencies = true
+	action, err := ParseActionYML(actionPath)
+	testutil.AssertNoError(t, err)
+	render := func() string {
+		out, err := RenderReadme(BuildTemplateData(action, config, "", actionPath),
+			TemplateOptions{TemplatePath: "templates/themes/github/readme.tmpl", Format: "md"})
+		testutil.AssertNoError(t, err)
+
+		return out
+	}
+
+	if strings.Contains(render(), "## 🔒 Dependency Security") {
+		t.Error("expected no security section unless show_security_info is enabled")
+	}
+
+	config.ShowSecurityInfo = true
+	out := render()
+	testutil.AssertStringContains(t, out,
+		"## 🔒 Dependency Security\n\n| Pinned | Floating |\n|--------|----------|\n| 1 | 1 |")
+	testutil.AssertStringContains(t, out, "- `actions/setup-node@v4`")
+}
+
+func TestRenderReadme_ContainerInterface(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{
+		Name:        "Greeter",
+		Description: "Greets from a container",
+		Inputs:      map[string]ActionInput{"who-to-greet": {Description: "Who to greet", Required: true}},
+		Runs: map[string]any{
+			"using": "docker", "image": "Dockerfile",
+			"args": []any{"${{ inputs.who-to-greet || 'world' }}"},
+		},
+	}
+	data := BuildTemplateData(action, DefaultAppConfig(), "", "")
+
+	out, err := RenderReadme(data, TemplateOptions{TemplatePath: "templates/themes/github/readme.tmpl", Format: "md"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "| `who-to-greet` | `INPUT_WHO-TO-GREET` | `$1` |")
+	testutil.AssertStringContains(t, out, "| `$1` | `${{ inputs.who-to-greet \\|\\| 'world' }}` |")
+}
+
+func TestRenderReadme_Compatibility(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{
+		Name:        "Greeter",
+		Description: "Greets from a container",
+		Runs:        map[string]any{"using": "docker", "image": "Dockerfile"},
+	}
+	config := DefaultAppConfig()
+	config.RunsOn = []string{"ubuntu-latest", "windows-latest"}
+
+	out, err := RenderReadme(BuildTemplateData(action, config, "", ""),
+		TemplateOptions{TemplatePath: "templates/themes/github/readme.tmpl", Format: "md"})
+	testutil.AssertNoError(t, err)
+	if strings.Contains(out, "Compatibility") {
+		t.Error("compatibility section rendered without show_compatibility")
+	}
+
+	config.ShowCompatibility = true
+	out, err = RenderReadme(BuildTemplateData(action, config, "", ""),
+		TemplateOptions{TemplatePath: "templates/themes/github/readme.tmpl", Format: "md"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "| Linux | `ubuntu-latest` | ✅ Supported |")
+	testutil.AssertStringContains(t, out,
+		"| Windows | `windows-latest` | ❌ Not supported: Docker container actions only run on Linux runners |")
+}
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("word ", 40)
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "short", text: "Deploys the app", want: "Deploys the app"},
+		{name: "first paragraph", text: "Deploys the app\nto AWS.\n\nDetails follow.", want: "Deploys the app to AWS."},
+		{name: "cut at a word", text: long, want: strings.TrimSpace(strings.Repeat("word ", 24)) + "…"},
+		{name: "no spaces", text: strings.Repeat("x", 130), want: strings.Repeat("x", 124) + "…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			testutil.AssertEqual(t, tt.want, summary(tt.text))
+		})
+	}
+}
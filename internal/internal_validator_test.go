@@ -1,6 +1,15 @@
 package internal
 
-import "testing"
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml/parser"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
 
 func TestValidateActionYML_Required(t *testing.T) {
 	t.Parallel()
@@ -28,3 +37,323 @@ func TestValidateActionYML_Valid(t *testing.T) {
 		t.Errorf("expected no missing fields, got %v", res.MissingFields)
 	}
 }
+
+func TestValidateActionYML_FindingSeverities(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		action         *ActionYML
+		expectedRule   string
+		expectedSev    Severity
+		expectedErrors bool
+	}{
+		{
+			name:           "missing name is an error",
+			action:         &ActionYML{Description: "A sufficiently long description", Runs: map[string]any{"using": "node20"}},
+			expectedRule:   RuleMissingName,
+			expectedSev:    SeverityError,
+			expectedErrors: true,
+		},
+		{
+			name:           "invalid runtime is an error",
+			action:         &ActionYML{Name: "a", Description: "desc", Runs: map[string]any{"using": "node8"}},
+			expectedRule:   RuleInvalidRuntime,
+			expectedSev:    SeverityError,
+			expectedErrors: true,
+		},
+		{
+			name:         "short description is a warning",
+			action:       &ActionYML{Name: "a", Description: "desc", Runs: map[string]any{"using": "node20"}},
+			expectedRule: RuleDescriptionTooShort,
+			expectedSev:  SeverityWarning,
+		},
+		{
+			name:         "missing branding is a warning",
+			action:       &ActionYML{Name: "a", Description: "desc", Runs: map[string]any{"using": "node20"}},
+			expectedRule: RuleMissingBranding,
+			expectedSev:  SeverityWarning,
+		},
+		{
+			name:         "missing inputs is informational",
+			action:       &ActionYML{Name: "a", Description: "desc", Runs: map[string]any{"using": "node20"}},
+			expectedRule: RuleMissingInputs,
+			expectedSev:  SeverityInfo,
+		},
+		{
+			name: "unpinned composite step is a warning",
+			action: &ActionYML{
+				Name:        "a",
+				Description: "desc",
+				Runs: map[string]any{
+					"using": "composite",
+					"steps": []any{
+						map[string]any{"uses": "./local"},
+						map[string]any{"uses": "actions/setup-node@8f152de45cc393bb48ce5d89d36b731f54556e65"},
+						map[string]any{"uses": "actions/checkout@main"},
+					},
+				},
+			},
+			expectedRule: RuleUnpinnedStep,
+			expectedSev:  SeverityWarning,
+		},
+		{
+			name: "run step without shell is an error",
+			action: &ActionYML{
+				Name:        "a",
+				Description: "desc",
+				Runs: map[string]any{
+					"using": "composite",
+					"steps": []any{
+						map[string]any{"run": "make", "shell": "bash"},
+						map[string]any{"run": "make test"},
+					},
+				},
+			},
+			expectedRule:   RuleInvalidStep,
+			expectedSev:    SeverityError,
+			expectedErrors: true,
+		},
+		{
+			name: "composite output without value is an error",
+			action: &ActionYML{
+				Name:        "a",
+				Description: "desc",
+				Outputs: map[string]ActionOutput{
+					"result": {Description: "Result", Value: "${{ steps.run.outputs.result }}"},
+					"status": {Description: "Status"},
+				},
+				Runs: map[string]any{"using": "composite", "steps": []any{}},
+			},
+			expectedRule:   RuleMissingOutputValue,
+			expectedSev:    SeverityError,
+			expectedErrors: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := ValidateActionYML(tt.action)
+			testutil.AssertEqual(t, tt.expectedErrors, result.HasErrors())
+
+			var matches []Finding
+			for _, finding := range result.Findings {
+				if finding.RuleID == tt.expectedRule {
+					matches = append(matches, finding)
+				}
+			}
+			testutil.AssertEqual(t, 1, len(matches))
+			testutil.AssertEqual(t, tt.expectedSev, matches[0].Severity)
+		})
+	}
+}
+
+func TestValidateActionYML_OnlyCommitSHAsArePinned(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{
+		Name:        "a",
+		Description: "desc",
+		Runs: map[string]any{
+			"using": "composite",
+			"steps": []any{
+				map[string]any{"uses": "actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11"},
+				map[string]any{"uses": "actions/setup-node@v1.2.3"},
+				map[string]any{"uses": "actions/cache@4.0.2"},
+			},
+		},
+	}
+
+	var fields []string
+	for _, finding := range ValidateActionYML(action).Findings {
+		if finding.RuleID == RuleUnpinnedStep {
+			fields = append(fields, finding.Field)
+		}
+	}
+	testutil.AssertEqual(t, "runs.steps[1].uses,runs.steps[2].uses", strings.Join(fields, ","))
+}
+
+func TestValidationResult_Failed(t *testing.T) {
+	t.Parallel()
+
+	warningsOnly := ValidationResult{Findings: []Finding{
+		{RuleID: RuleMissingBranding, Severity: SeverityWarning},
+		{RuleID: RuleMissingInputs, Severity: SeverityInfo},
+	}}
+	testutil.AssertEqual(t, false, warningsOnly.Failed(false))
+	testutil.AssertEqual(t, true, warningsOnly.Failed(true))
+
+	infoOnly := ValidationResult{Findings: []Finding{{RuleID: RuleMissingInputs, Severity: SeverityInfo}}}
+	testutil.AssertEqual(t, false, infoOnly.Failed(true))
+
+	withError := ValidationResult{Findings: []Finding{{RuleID: RuleMissingName, Severity: SeverityError}}}
+	testutil.AssertEqual(t, true, withError.Failed(false))
+}
+
+func TestValidateActionFile_Locations(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, `name: Located
+description: short
+runs:
+  using: composite
+  steps:
+    - uses: actions/checkout@v4
+`)
+
+	result, err := ValidateActionFile(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, actionPath, result.File)
+
+	positions := make(map[string]string)
+	for _, finding := range result.Findings {
+		positions[finding.RuleID] = fmt.Sprintf("%d:%d", finding.Line, finding.Column)
+	}
+	testutil.AssertEqual(t, "2:14", positions[RuleDescriptionTooShort])
+	testutil.AssertEqual(t, "6:13", positions[RuleUnpinnedStep])
+	// Absent top-level fields point at the first key of the document
+	testutil.AssertEqual(t, "1:1", positions[RuleMissingBranding])
+
+	finding := Finding{File: actionPath, Line: 2}
+	testutil.AssertEqual(t, actionPath+":2", finding.Location())
+}
+
+func TestValidateActionFile_NestedLocations(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, `# Leading comment
+name: Nested
+description: Locates findings in nested mappings
+runs:
+  main: index.js
+`)
+
+	result, err := ValidateActionFile(actionPath)
+	testutil.AssertNoError(t, err)
+
+	positions := make(map[string]string)
+	for _, finding := range result.Findings {
+		positions[finding.RuleID] = fmt.Sprintf("%d:%d", finding.Line, finding.Column)
+	}
+	testutil.AssertEqual(t, "4:1", positions[RuleMissingRunsUsing])
+	testutil.AssertEqual(t, "2:1", positions[RuleMissingInputs])
+
+	steps := `name: Steps
+description: Locates invalid steps
+runs:
+  using: composite
+  steps:
+    - run: echo ok
+      shell: bash
+    -   name: Empty step
+`
+	file, err := parser.ParseBytes([]byte(steps), 0)
+	testutil.AssertNoError(t, err)
+	position := fieldPosition(file.Docs[0].Body, "runs.steps[1]")
+	testutil.AssertEqual(t, "8:9", fmt.Sprintf("%d:%d", position.Line, position.Column))
+	position = fieldPosition(file.Docs[0].Body, "runs.steps[0].shell")
+	testutil.AssertEqual(t, "7:14", fmt.Sprintf("%d:%d", position.Line, position.Column))
+	position = fieldPosition(file.Docs[0].Body, "runs.steps[0].uses")
+	testutil.AssertEqual(t, "6:7", fmt.Sprintf("%d:%d", position.Line, position.Column))
+}
+
+func TestValidationResult_ApplyRules(t *testing.T) {
+	t.Parallel()
+
+	result := ValidationResult{Findings: []Finding{
+		{RuleID: RuleMissingBranding, Severity: SeverityWarning},
+		{RuleID: RuleUnpinnedStep, Severity: SeverityWarning},
+		{RuleID: RuleMissingInputs, Severity: SeverityInfo},
+	}}
+
+	result.ApplyRules(map[string]string{
+		RuleMissingBranding: RuleOff,
+		RuleUnpinnedStep:    string(SeverityError),
+	})
+
+	testutil.AssertEqual(t, 2, len(result.Findings))
+	testutil.AssertEqual(t, RuleUnpinnedStep, result.Findings[0].RuleID)
+	testutil.AssertEqual(t, SeverityError, result.Findings[0].Severity)
+	testutil.AssertEqual(t, SeverityInfo, result.Findings[1].Severity)
+	testutil.AssertEqual(t, true, result.HasErrors())
+}
+
+func TestValidateRuleConfig(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, ValidateRuleConfig(nil))
+	testutil.AssertNoError(t, ValidateRuleConfig(map[string]string{
+		RuleMissingBranding: RuleOff,
+		RuleUnpinnedStep:    "error",
+	}))
+
+	err := ValidateRuleConfig(map[string]string{"no-such-rule": "off"})
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "unknown validation rule")
+
+	err = ValidateRuleConfig(map[string]string{RuleMissingBranding: "fatal"})
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "invalid setting")
+}
+
+func TestValidateActionFile_InlineSuppression(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, `name: Suppressed
+# ghreadme:disable-next-line description-too-short
+description: short
+runs:
+  using: composite
+  steps:
+    # ghreadme:disable-next-line
+    - uses: actions/checkout@v4
+    # ghreadme:disable-next-line missing-branding
+    - uses: actions/setup-node@v4
+`)
+
+	result, err := ValidateActionFile(actionPath)
+	testutil.AssertNoError(t, err)
+
+	var rules []string
+	for _, finding := range result.Findings {
+		rules = append(rules, finding.RuleID)
+	}
+	testutil.AssertEqual(t,
+		strings.Join([]string{RuleMissingBranding, RuleMissingInputs, RuleMissingOutputs, RuleUnpinnedStep}, ","),
+		strings.Join(rules, ","),
+	)
+	testutil.AssertEqual(t, 10, result.Findings[3].Line)
+}
+
+func TestValidateActionFile_FullSchemaFixtures(t *testing.T) {
+	t.Parallel()
+
+	for _, fixture := range []string{"actions/composite/full-schema.yml", "actions/docker/full-schema.yml"} {
+		tmpDir, cleanup := testutil.TempDir(t)
+		actionPath := filepath.Join(tmpDir, "action.yml")
+		testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture(fixture))
+
+		result, err := ValidateActionFile(actionPath)
+		testutil.AssertNoError(t, err)
+		for _, finding := range result.Findings {
+			if finding.Severity == SeverityError {
+				t.Errorf("%s: unexpected error finding %s: %s", fixture, finding.RuleID, finding.Message)
+			}
+		}
+		cleanup()
+	}
+}
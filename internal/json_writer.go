@@ -2,7 +2,6 @@ package internal
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
 	"time"
 )
@@ -32,23 +31,28 @@ type MetaInfo struct {
 // ActionYMLForJSON represents the action.yml data in JSON format.
 type ActionYMLForJSON struct {
 	Name        string                         `json:"name"`
+	Author      string                         `json:"author,omitempty"`
 	Description string                         `json:"description"`
 	Inputs      map[string]ActionInputForJSON  `json:"inputs,omitempty"`
 	Outputs     map[string]ActionOutputForJSON `json:"outputs,omitempty"`
 	Runs        map[string]any                 `json:"runs"`
+	Lifecycle   []LifecycleHook                `json:"lifecycle,omitempty"`
+	Container   *ContainerInterface            `json:"container,omitempty"`
 	Branding    *BrandingForJSON               `json:"branding,omitempty"`
 }
 
 // ActionInputForJSON represents an input parameter in JSON format.
 type ActionInputForJSON struct {
-	Description string `json:"description"`
-	Required    bool   `json:"required"`
-	Default     any    `json:"default,omitempty"`
+	Description        string `json:"description"`
+	Required           bool   `json:"required"`
+	Default            any    `json:"default,omitempty"`
+	DeprecationMessage string `json:"deprecation_message,omitempty"`
 }
 
 // ActionOutputForJSON represents an output parameter in JSON format.
 type ActionOutputForJSON struct {
 	Description string `json:"description"`
+	Value       string `json:"value,omitempty"`
 }
 
 // BrandingForJSON represents branding information in JSON format.
@@ -78,7 +82,7 @@ type BadgeInfo struct {
 type SectionInfo struct {
 	Title   string `json:"title"`
 	Content string `json:"content"`
-	Type    string `json:"type"` // "inputs", "outputs", "examples", "text"
+	Type    string `json:"type"` // "inputs", "outputs", "lifecycle", "examples", "text"
 }
 
 // ExampleInfo represents a usage example.
@@ -91,9 +95,9 @@ type ExampleInfo struct {
 
 // GeneratedInfo contains metadata about when and how the documentation was generated.
 type GeneratedInfo struct {
-	Timestamp string `json:"timestamp"`
+	Timestamp string `json:"timestamp,omitempty"`
 	Tool      string `json:"tool"`
-	Version   string `json:"version"`
+	Version   string `json:"version,omitempty"`
 	Theme     string `json:"theme,omitempty"`
 }
 
@@ -109,10 +113,7 @@ func NewJSONWriter(config *AppConfig) *JSONWriter {
 
 // Write generates JSON documentation from the action data.
 func (jw *JSONWriter) Write(action *ActionYML, outputPath string) error {
-	jsonOutput := jw.convertToJSONOutput(action)
-
-	// Marshal to JSON with indentation
-	data, err := json.MarshalIndent(jsonOutput, "", "  ")
+	data, err := jw.Marshal(action)
 	if err != nil {
 		return err
 	}
@@ -121,6 +122,14 @@ func (jw *JSONWriter) Write(action *ActionYML, outputPath string) error {
 	return os.WriteFile(outputPath, data, FilePermDefault) // #nosec G306 -- JSON output file permissions
 }
 
+// Marshal renders the JSON documentation for the action without writing it.
+func (jw *JSONWriter) Marshal(action *ActionYML) ([]byte, error) {
+	jsonOutput := jw.convertToJSONOutput(action)
+
+	// Marshal to JSON with indentation
+	return json.MarshalIndent(jsonOutput, "", "  ")
+}
+
 // convertToJSONOutput converts ActionYML to structured JSON output.
 func (jw *JSONWriter) convertToJSONOutput(action *ActionYML) *JSONOutput {
 	// Convert inputs
@@ -201,6 +210,24 @@ func (jw *JSONWriter) convertToJSONOutput(action *ActionYML) *JSONOutput {
 		})
 	}
 
+	lifecycle := action.Lifecycle()
+	if len(lifecycle) > 0 {
+		sections = append(sections, SectionInfo{
+			Title:   "Lifecycle",
+			Content: "Pre and post scripts run around the main entrypoint",
+			Type:    "lifecycle",
+		})
+	}
+
+	container := action.ContainerInterface()
+	if container != nil {
+		sections = append(sections, SectionInfo{
+			Title:   "Container Interface",
+			Content: "Environment variables and arguments the container receives for the inputs",
+			Type:    "container",
+		})
+	}
+
 	return &JSONOutput{
 		Meta: MetaInfo{
 			Version:   "1.0.0",
@@ -210,10 +237,13 @@ func (jw *JSONWriter) convertToJSONOutput(action *ActionYML) *JSONOutput {
 		},
 		Action: ActionYMLForJSON{
 			Name:        action.Name,
+			Author:      action.Author,
 			Description: action.Description,
 			Inputs:      inputs,
 			Outputs:     outputs,
 			Runs:        action.Runs,
+			Lifecycle:   lifecycle,
+			Container:   container,
 			Branding:    branding,
 		},
 		Documentation: DocumentationInfo{
@@ -227,14 +257,24 @@ func (jw *JSONWriter) convertToJSONOutput(action *ActionYML) *JSONOutput {
 				"repository": "https://github.com/your-org/" + action.Name,
 			},
 		},
-		Examples: examples,
-		Generated: GeneratedInfo{
-			Timestamp: time.Now().UTC().Format(time.RFC3339),
-			Tool:      "gh-action-readme",
-			Version:   getVersion(),
-			Theme:     jw.Config.Theme,
-		},
+		Examples:  examples,
+		Generated: jw.generatedInfo(),
+	}
+}
+
+// generatedInfo describes the run that produced the output. Reproducible runs
+// leave out the timestamp and version unless SOURCE_DATE_EPOCH sets the time.
+func (jw *JSONWriter) generatedInfo() GeneratedInfo {
+	info := GeneratedInfo{
+		Tool:    "gh-action-readme",
+		Version: toolVersion(jw.Config, getVersion()),
+		Theme:   jw.Config.Theme,
+	}
+	if generatedAt, ok := generationTime(jw.Config); ok {
+		info.Timestamp = generatedAt.Format(time.RFC3339)
 	}
+
+	return info
 }
 
 // generateBasicExample creates a basic usage example.
@@ -244,18 +284,26 @@ func (jw *JSONWriter) generateBasicExample(action *ActionYML) string {
 
 	if len(action.Inputs) > 0 {
 		example += "\n  with:"
-		for key, input := range action.Inputs {
-			value := "value"
-			if input.Default != nil {
-				if str, ok := input.Default.(string); ok {
-					value = str
-				} else {
-					value = fmt.Sprintf("%v", input.Default)
-				}
+		for _, input := range action.SortedInputs(jw.sortMode()) {
+			value := `"value"`
+			switch {
+			case IsBlockDefault(input.Default):
+				value = YAMLBlockScalar(6, input.Default)
+			case input.Default != nil:
+				value = `"` + FormatDefault(input.Default) + `"`
 			}
-			example += "\n    " + key + ": \"" + value + "\""
+			example += "\n    " + input.Name + ": " + value
 		}
 	}
 
 	return example
 }
+
+// sortMode returns the configured input sort mode, defaulting to declaration order.
+func (jw *JSONWriter) sortMode() string {
+	if jw.Config == nil || jw.Config.SortInputs == "" {
+		return SortInputsDeclaration
+	}
+
+	return jw.Config.SortInputs
+}
@@ -0,0 +1,148 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
+)
+
+// Default resource limits.
+const (
+	DefaultMaxFileSize = yamlsafe.DefaultMaxBytes
+	DefaultMaxNodes    = yamlsafe.DefaultMaxNodes
+	DefaultMaxDepth    = yamlsafe.DefaultMaxDepth
+	DefaultMaxSteps    = 1000
+	DefaultMaxFiles    = 1000
+
+	DefaultMaxTransitiveDepth = 5 // Levels of composite actions followed by transitive analysis
+
+	DefaultMaxRenderSize = 10 << 20 // Bytes of custom template output
+	DefaultRenderTimeout = 10       // Seconds of custom template rendering
+)
+
+// ErrResourceLimit is returned when a run would process more steps or files than allowed.
+var ErrResourceLimit = errors.New("resource limit exceeded")
+
+// ResourceLimits bounds the work done for a single run. Zero fields use the defaults.
+type ResourceLimits struct {
+	MaxFileSize int `mapstructure:"max_file_size" yaml:"max_file_size,omitempty"` // Bytes per action file
+	MaxNodes    int `mapstructure:"max_nodes"     yaml:"max_nodes,omitempty"`     // YAML nodes after alias expansion
+	MaxDepth    int `mapstructure:"max_depth"     yaml:"max_depth,omitempty"`     // YAML nesting depth
+	MaxSteps    int `mapstructure:"max_steps"     yaml:"max_steps,omitempty"`     // Composite steps per action
+	MaxFiles    int `mapstructure:"max_files"     yaml:"max_files,omitempty"`     // Action files per run
+
+	// Levels of composite actions followed by transitive dependency analysis
+	MaxTransitiveDepth int `mapstructure:"max_transitive_depth" yaml:"max_transitive_depth,omitempty"`
+
+	MaxRenderSize int `mapstructure:"max_render_size" yaml:"max_render_size,omitempty"` // Bytes of custom template output
+	RenderTimeout int `mapstructure:"render_timeout"  yaml:"render_timeout,omitempty"`  // Seconds per custom template
+}
+
+// resourceLimits holds the limits set with SetResourceLimits.
+var resourceLimits atomic.Pointer[ResourceLimits]
+
+// DefaultResourceLimits returns the default resource limits.
+func DefaultResourceLimits() ResourceLimits {
+	return ResourceLimits{
+		MaxFileSize: DefaultMaxFileSize,
+		MaxNodes:    DefaultMaxNodes,
+		MaxDepth:    DefaultMaxDepth,
+		MaxSteps:    DefaultMaxSteps,
+		MaxFiles:    DefaultMaxFiles,
+
+		MaxTransitiveDepth: DefaultMaxTransitiveDepth,
+
+		MaxRenderSize: DefaultMaxRenderSize,
+		RenderTimeout: DefaultRenderTimeout,
+	}
+}
+
+// SetResourceLimits applies limits to every action file parsed afterwards.
+func SetResourceLimits(limits ResourceLimits) {
+	limits = limits.withDefaults()
+	resourceLimits.Store(&limits)
+	yamlsafe.SetLimits(yamlsafe.Limits{
+		MaxBytes: limits.MaxFileSize,
+		MaxNodes: limits.MaxNodes,
+		MaxDepth: limits.MaxDepth,
+	})
+}
+
+// CurrentResourceLimits returns the limits set with SetResourceLimits, or the defaults.
+func CurrentResourceLimits() ResourceLimits {
+	if limits := resourceLimits.Load(); limits != nil {
+		return *limits
+	}
+
+	return DefaultResourceLimits()
+}
+
+// ValidateResourceLimits rejects negative limits.
+func ValidateResourceLimits(limits ResourceLimits) error {
+	fields := []struct {
+		name  string
+		value int
+	}{
+		{"max_file_size", limits.MaxFileSize},
+		{"max_nodes", limits.MaxNodes},
+		{"max_depth", limits.MaxDepth},
+		{"max_steps", limits.MaxSteps},
+		{"max_files", limits.MaxFiles},
+		{"max_transitive_depth", limits.MaxTransitiveDepth},
+		{"max_render_size", limits.MaxRenderSize},
+		{"render_timeout", limits.RenderTimeout},
+	}
+	for _, field := range fields {
+		if field.value < 0 {
+			return fmt.Errorf("invalid limits.%s %d, must be zero (default) or positive", field.name, field.value)
+		}
+	}
+
+	return nil
+}
+
+// checkFileCount rejects runs that would process more action files than limit.
+func checkFileCount(count, limit int) error {
+	if count > limit {
+		return fmt.Errorf("%w: found %d action files, more than limits.max_files (%d)", ErrResourceLimit, count, limit)
+	}
+
+	return nil
+}
+
+// checkStepCount rejects actions with more composite steps than limit.
+func checkStepCount(action *ActionYML, limit int) error {
+	steps, _ := action.Runs["steps"].([]any)
+	if len(steps) > limit {
+		return fmt.Errorf("%w: action has %d steps, more than limits.max_steps (%d)", ErrResourceLimit, len(steps), limit)
+	}
+
+	return nil
+}
+
+// withDefaults fills zero limits with their defaults.
+func (l ResourceLimits) withDefaults() ResourceLimits {
+	defaults := DefaultResourceLimits()
+	fields := []struct {
+		value    *int
+		fallback int
+	}{
+		{&l.MaxFileSize, defaults.MaxFileSize},
+		{&l.MaxNodes, defaults.MaxNodes},
+		{&l.MaxDepth, defaults.MaxDepth},
+		{&l.MaxSteps, defaults.MaxSteps},
+		{&l.MaxFiles, defaults.MaxFiles},
+		{&l.MaxTransitiveDepth, defaults.MaxTransitiveDepth},
+		{&l.MaxRenderSize, defaults.MaxRenderSize},
+		{&l.RenderTimeout, defaults.RenderTimeout},
+	}
+	for _, field := range fields {
+		if *field.value <= 0 {
+			*field.value = field.fallback
+		}
+	}
+
+	return l
+}
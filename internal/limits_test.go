@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestResourceLimits_WithDefaults(t *testing.T) {
+	t.Parallel()
+
+	limits := ResourceLimits{MaxSteps: 5}.withDefaults()
+	testutil.AssertEqual(t, 5, limits.MaxSteps)
+	testutil.AssertEqual(t, DefaultMaxFileSize, limits.MaxFileSize)
+	testutil.AssertEqual(t, DefaultMaxFiles, limits.MaxFiles)
+}
+
+func TestValidateResourceLimits(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, ValidateResourceLimits(ResourceLimits{}))
+	testutil.AssertNoError(t, ValidateResourceLimits(DefaultResourceLimits()))
+
+	err := ValidateResourceLimits(ResourceLimits{MaxDepth: -1})
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "limits.max_depth")
+}
+
+func TestCheckStepCount(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{Runs: map[string]any{"using": "composite", "steps": []any{
+		map[string]any{"run": "a", "shell": "bash"},
+		map[string]any{"run": "b", "shell": "bash"},
+	}}}
+
+	testutil.AssertNoError(t, checkStepCount(action, 2))
+	err := checkStepCount(action, 1)
+	if !errors.Is(err, ErrResourceLimit) {
+		t.Fatalf("expected resource limit error, got %v", err)
+	}
+	testutil.AssertStringContains(t, err.Error(), "limits.max_steps")
+}
+
+func TestCheckFileCount(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, checkFileCount(3, 3))
+	if err := checkFileCount(4, 3); !errors.Is(err, ErrResourceLimit) {
+		t.Fatalf("expected resource limit error, got %v", err)
+	}
+}
+
+func TestParseActionYML_RejectsOversizedFile(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	padding := "# " + strings.Repeat("x", 78) + "\n"
+	testutil.WriteTestFile(t, actionPath, "name: Big\n"+strings.Repeat(padding, DefaultMaxFileSize/len(padding)+1))
+
+	_, err := ParseActionYML(actionPath)
+	if !errors.Is(err, ErrResourceLimit) {
+		t.Fatalf("expected resource limit error, got %v", err)
+	}
+	testutil.AssertStringContains(t, err.Error(), "limits.max_file_size")
+}
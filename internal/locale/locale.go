@@ -0,0 +1,171 @@
+// Package locale formats numbers, sizes and dates in CLI output for the
+// user's locale, taken from the locale setting or the LC_ALL, LC_NUMERIC and
+// LANG environment variables.
+package locale
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Environment variables consulted by Resolve, in order of precedence.
+var envVariables = []string{"LC_ALL", "LC_NUMERIC", "LANG"}
+
+// Locale describes how numbers and dates are written in a locale.
+type Locale struct {
+	Tag        string // BCP 47 tag, such as de-DE, or C
+	Decimal    string // Decimal separator
+	Group      string // Thousands separator, empty for none
+	DateLayout string // time.Format layout of dates
+	TimeLayout string // time.Format layout of times of day
+}
+
+// C is the locale of plain, unlocalized output: no digit grouping and ISO 8601 dates.
+var C = Locale{Tag: "C", Decimal: ".", DateLayout: "2006-01-02", TimeLayout: "15:04:05"}
+
+// locales are the supported locales, keyed by lower-case tag. Languages map to
+// their most common region.
+var locales = map[string]Locale{
+	"en":    {Tag: "en-US", Decimal: ".", Group: ",", DateLayout: "Jan 2, 2006", TimeLayout: "3:04 PM"},
+	"en-gb": {Tag: "en-GB", Decimal: ".", Group: ",", DateLayout: "2 Jan 2006", TimeLayout: "15:04"},
+	"de":    {Tag: "de-DE", Decimal: ",", Group: ".", DateLayout: "02.01.2006", TimeLayout: "15:04"},
+	"es":    {Tag: "es-ES", Decimal: ",", Group: ".", DateLayout: "02/01/2006", TimeLayout: "15:04"},
+	"fi":    {Tag: "fi-FI", Decimal: ",", Group: "\u00a0", DateLayout: "2.1.2006", TimeLayout: "15.04"},
+	"fr":    {Tag: "fr-FR", Decimal: ",", Group: "\u202f", DateLayout: "02/01/2006", TimeLayout: "15:04"},
+	"it":    {Tag: "it-IT", Decimal: ",", Group: ".", DateLayout: "02/01/2006", TimeLayout: "15:04"},
+	"ja":    {Tag: "ja-JP", Decimal: ".", Group: ",", DateLayout: "2006/01/02", TimeLayout: "15:04"},
+	"nl":    {Tag: "nl-NL", Decimal: ",", Group: ".", DateLayout: "02-01-2006", TimeLayout: "15:04"},
+	"pl":    {Tag: "pl-PL", Decimal: ",", Group: "\u00a0", DateLayout: "02.01.2006", TimeLayout: "15:04"},
+	"pt":    {Tag: "pt-BR", Decimal: ",", Group: ".", DateLayout: "02/01/2006", TimeLayout: "15:04"},
+	"ru":    {Tag: "ru-RU", Decimal: ",", Group: "\u00a0", DateLayout: "02.01.2006", TimeLayout: "15:04"},
+	"sv":    {Tag: "sv-SE", Decimal: ",", Group: "\u00a0", DateLayout: "2006-01-02", TimeLayout: "15:04"},
+	"zh":    {Tag: "zh-CN", Decimal: ".", Group: ",", DateLayout: "2006/01/02", TimeLayout: "15:04"},
+}
+
+// current is the locale set with Set.
+var current atomic.Pointer[Locale]
+
+// Parse returns the locale named by value, a BCP 47 tag such as "de-DE" or a
+// POSIX locale such as "de_DE.UTF-8". Unknown regions fall back to their
+// language; "C" and "POSIX" are the C locale. It reports false for unknown locales.
+func Parse(value string) (Locale, bool) {
+	name, _, _ := strings.Cut(strings.TrimSpace(value), ".")
+	name, _, _ = strings.Cut(name, "@")
+	name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	if name == "c" || name == "posix" {
+		return C, true
+	}
+	if l, ok := locales[name]; ok {
+		return l, true
+	}
+	language, _, _ := strings.Cut(name, "-")
+	l, ok := locales[language]
+
+	return l, ok
+}
+
+// Resolve returns the locale of configured, when it names a known locale, or
+// of the first of LC_ALL, LC_NUMERIC and LANG that is set. Without either the
+// C locale is used.
+func Resolve(configured string) Locale {
+	if configured != "" {
+		if l, ok := Parse(configured); ok {
+			return l
+		}
+	}
+	for _, name := range envVariables {
+		if value := os.Getenv(name); value != "" {
+			if l, ok := Parse(value); ok {
+				return l
+			}
+
+			return C
+		}
+	}
+
+	return C
+}
+
+// Set makes l the locale returned by Current.
+func Set(l Locale) {
+	current.Store(&l)
+}
+
+// Current returns the locale set with Set, or the C locale.
+func Current() Locale {
+	if l := current.Load(); l != nil {
+		return *l
+	}
+
+	return C
+}
+
+// Int formats n with the locale's thousands separator.
+func (l Locale) Int(n int) string {
+	return l.group(strconv.Itoa(n))
+}
+
+// Float formats f with decimals digits after the locale's decimal separator.
+func (l Locale) Float(f float64, decimals int) string {
+	formatted := strconv.FormatFloat(f, 'f', decimals, 64)
+	whole, fraction, found := strings.Cut(formatted, ".")
+	whole = l.group(whole)
+	if !found {
+		return whole
+	}
+
+	return whole + l.Decimal + fraction
+}
+
+// Size formats a byte count in binary units: bytes, KB, MB or GB.
+func (l Locale) Size(bytes int64) string {
+	const unit = 1024
+	switch value := float64(bytes); {
+	case bytes < unit:
+		return l.group(strconv.FormatInt(bytes, 10)) + " bytes"
+	case bytes < unit*unit:
+		return l.Float(value/unit, 2) + " KB"
+	case bytes < unit*unit*unit:
+		return l.Float(value/(unit*unit), 2) + " MB"
+	default:
+		return l.Float(value/(unit*unit*unit), 2) + " GB"
+	}
+}
+
+// Date formats the date of t.
+func (l Locale) Date(t time.Time) string {
+	return t.Format(l.DateLayout)
+}
+
+// DateTime formats the date and time of day of t, with its time zone.
+func (l Locale) DateTime(t time.Time) string {
+	return t.Format(l.DateLayout + " " + l.TimeLayout + " MST")
+}
+
+// group inserts the thousands separator into the digits of an integer.
+func (l Locale) group(digits string) string {
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if l.Group == "" || len(digits) <= 3 {
+		return sign + digits
+	}
+
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+
+	return sign + b.String()
+}
@@ -0,0 +1,100 @@
+package locale
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantTag string
+		wantOK  bool
+	}{
+		{"de_DE.UTF-8", "de-DE", true},
+		{"de-AT", "de-DE", true},
+		{"en_GB.UTF-8", "en-GB", true},
+		{"en_US", "en-US", true},
+		{"fr_FR@euro", "fr-FR", true},
+		{"C.UTF-8", "C", true},
+		{"POSIX", "C", true},
+		{"xx_YY", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Parse(tt.value)
+		if ok != tt.wantOK || got.Tag != tt.wantTag {
+			t.Errorf("Parse(%q) = %q, %t; want %q, %t", tt.value, got.Tag, ok, tt.wantTag, tt.wantOK)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_NUMERIC", "")
+	t.Setenv("LANG", "")
+	if got := Resolve(""); got.Tag != "C" {
+		t.Errorf("expected the C locale without settings, got %s", got.Tag)
+	}
+
+	t.Setenv("LANG", "fi_FI.UTF-8")
+	if got := Resolve(""); got.Tag != "fi-FI" {
+		t.Errorf("expected LANG to apply, got %s", got.Tag)
+	}
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+	if got := Resolve(""); got.Tag != "de-DE" {
+		t.Errorf("expected LC_ALL to take precedence over LANG, got %s", got.Tag)
+	}
+	if got := Resolve("en-GB"); got.Tag != "en-GB" {
+		t.Errorf("expected the configured locale to take precedence, got %s", got.Tag)
+	}
+	if got := Resolve("unknown"); got.Tag != "de-DE" {
+		t.Errorf("expected an unknown configured locale to be ignored, got %s", got.Tag)
+	}
+}
+
+func TestLocale_Format(t *testing.T) {
+	en, _ := Parse("en")
+	de, _ := Parse("de")
+	fi, _ := Parse("fi")
+	date := time.Date(2024, 5, 1, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"C int", C.Int(1234567), "1234567"},
+		{"en int", en.Int(1234567), "1,234,567"},
+		{"de int", de.Int(-1234), "-1.234"},
+		{"short int", de.Int(123), "123"},
+		{"en float", en.Float(12345.678, 2), "12,345.68"},
+		{"de float", de.Float(12345.678, 1), "12.345,7"},
+		{"fi float", fi.Float(1234.5, 1), "1\u00a0234,5"},
+		{"C size", C.Size(0), "0 bytes"},
+		{"en size bytes", en.Size(1000), "1,000 bytes"},
+		{"de size", de.Size(1536), "1,50 KB"},
+		{"C size gigabytes", C.Size(3 << 30), "3.00 GB"},
+		{"C date", C.Date(date), "2024-05-01"},
+		{"en date", en.Date(date), "May 1, 2024"},
+		{"de date", de.Date(date), "01.05.2024"},
+		{"en date time", en.DateTime(date), "May 1, 2024 2:30 PM UTC"},
+		{"C date time", C.DateTime(date), "2024-05-01 14:30:00 UTC"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	defer Set(Current())
+
+	de, _ := Parse("de")
+	Set(de)
+	if got := Current().Int(1000); got != "1.000" {
+		t.Errorf("expected the set locale, got %q", got)
+	}
+}
@@ -0,0 +1,289 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+)
+
+// DefaultLogoWidth is the default maximum width in pixels of logos in HTML pages.
+const DefaultLogoWidth = 128
+
+// maxLogoPixels bounds the decoded size of logos resized for HTML pages.
+const maxLogoPixels = 1 << 24
+
+// htmlBodyRe matches the opening body tag of an HTML page and its line break.
+var htmlBodyRe = regexp.MustCompile(`(?i)<body[^>]*>\n?`)
+
+// LogoFileNames are the logo files detected in the directory of an action
+// file, in order of preference, when its sidecar metadata sets no logo.
+var LogoFileNames = []string{"logo.png", "logo.svg", "logo.jpg", "logo.jpeg"}
+
+// logoExtensions are the image formats accepted as action logos.
+var logoExtensions = []string{".png", ".svg", ".jpg", ".jpeg"}
+
+// ActionLogo is the logo of an action as referenced by generated documentation.
+type ActionLogo struct {
+	Path   string `json:"path"`             // URL relative to the generated file
+	Width  int    `json:"width,omitempty"`  // Pixels, for raster images
+	Height int    `json:"height,omitempty"` // Pixels, for raster images
+}
+
+// FindActionLogo returns the logo file of the action at actionPath: the logo
+// set in its sidecar metadata, relative to the action file, or the first of
+// LogoFileNames found next to it. It returns an empty path when the action has
+// no logo, and an error when the configured logo is not a supported image or
+// lies outside the repository of the action.
+func FindActionLogo(actionPath string) (string, error) {
+	dir := filepath.Dir(actionPath)
+	metadata, err := LoadActionMetadata(actionPath)
+	if err != nil {
+		return "", err
+	}
+	if metadata.Logo == "" {
+		for _, name := range LogoFileNames {
+			if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.Mode().IsRegular() {
+				return filepath.Join(dir, name), nil
+			}
+		}
+
+		return "", nil
+	}
+
+	if filepath.IsAbs(metadata.Logo) || !isLogoFile(metadata.Logo) {
+		return "", fmt.Errorf("logo %q must be a relative path to a PNG, JPEG or SVG file", metadata.Logo)
+	}
+	root, err := git.FindRepositoryRoot(dir)
+	if err != nil {
+		root = dir
+	}
+	path := filepath.Join(dir, metadata.Logo)
+	outside := fmt.Errorf("logo %q is outside the repository of %s", metadata.Logo, actionPath)
+	// Check the path itself first so that files outside the repository are not probed
+	absRoot, _ := filepath.Abs(root)
+	if absPath, _ := filepath.Abs(path); !pathWithin(absRoot, absPath) {
+		return "", outside
+	}
+	resolved, err := resolveRealPath(path)
+	if err != nil {
+		return "", fmt.Errorf("logo %q: %w", metadata.Logo, err)
+	}
+	if realRoot, err := resolveRealPath(root); err != nil || !pathWithin(realRoot, resolved) {
+		return "", outside
+	}
+
+	return path, nil
+}
+
+// isLogoFile reports whether name has the extension of a supported logo format.
+func isLogoFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, supported := range logoExtensions {
+		if ext == supported {
+			return true
+		}
+	}
+
+	return false
+}
+
+// actionLogo returns the logo of the action at actionPath for the document
+// written to outputPath, or nil when the action has no logo. Logos outside the
+// output directory are copied into it, and with resize raster logos wider than
+// logo_width are scaled down for HTML pages. Files are not written in check mode.
+func (g *Generator) actionLogo(actionPath, outputPath string, resize bool) (*ActionLogo, error) {
+	source, err := FindActionLogo(actionPath)
+	if err != nil || source == "" {
+		return nil, err
+	}
+	content, err := readLogo(source)
+	if err != nil {
+		return nil, err
+	}
+
+	logo := &ActionLogo{}
+	content, name, err := g.sizeLogo(logo, content, filepath.Base(source), resize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logo %s: %w", source, err)
+	}
+
+	target := filepath.Join(filepath.Dir(outputPath), name)
+	logo.Path = url.PathEscape(name)
+	if g.Check {
+		return logo, nil
+	}
+	if !samePath(source, target) {
+		if err := writeIfChanged(target, content); err != nil {
+			return nil, fmt.Errorf("failed to write logo %s: %w", target, err)
+		}
+	}
+	g.recordOutput(target)
+
+	return logo, nil
+}
+
+// sizeLogo sets the dimensions of logo from the content of the logo file name.
+// With resize, raster logos wider than logo_width are scaled down and renamed,
+// and SVG logos are displayed at logo_width.
+func (g *Generator) sizeLogo(logo *ActionLogo, content []byte, name string, resize bool) ([]byte, string, error) {
+	maxWidth := g.Config.LogoWidth
+	if strings.EqualFold(filepath.Ext(name), ".svg") {
+		if resize {
+			logo.Width = maxWidth
+		}
+
+		return content, name, nil
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", err
+	}
+	logo.Width, logo.Height = config.Width, config.Height
+	if !resize || maxWidth <= 0 || config.Width <= maxWidth {
+		return content, name, nil
+	}
+	if content, err = resizeLogo(content, config, maxWidth); err != nil {
+		return nil, "", err
+	}
+	logo.Width, logo.Height = maxWidth, max(1, config.Height*maxWidth/config.Width)
+	ext := filepath.Ext(name)
+
+	return content, fmt.Sprintf("%s-%dw%s", strings.TrimSuffix(name, ext), maxWidth, ext), nil
+}
+
+// readLogo reads a logo file of at most limits.max_file_size bytes.
+func readLogo(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logo: %w", err)
+	}
+	if limit := CurrentResourceLimits().MaxFileSize; info.Size() > int64(limit) {
+		return nil, fmt.Errorf("%w: logo %s is larger than limits.max_file_size (%d bytes)",
+			ErrResourceLimit, path, limit)
+	}
+
+	return os.ReadFile(path) // #nosec G304 -- logo confined to the action's repository
+}
+
+// resizeLogo scales a PNG or JPEG image down to width pixels, keeping its
+// aspect ratio and format. Each pixel averages the source pixels it covers.
+func resizeLogo(content []byte, config image.Config, width int) ([]byte, error) {
+	if config.Width*config.Height > maxLogoPixels {
+		return nil, fmt.Errorf("%w: image is larger than %d pixels", ErrResourceLimit, maxLogoPixels)
+	}
+	src, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		y0, y1 := sourceSpan(y, height, bounds.Dy())
+		for x := range width {
+			x0, x1 := sourceSpan(x, width, bounds.Dx())
+			area := image.Rect(x0, y0, x1, y1).Add(bounds.Min)
+			dst.Set(x, y, averageColor(src, area))
+		}
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 90})
+	case "png":
+		err = png.Encode(&buf, dst)
+	default:
+		err = fmt.Errorf("unsupported image format %s", format)
+	}
+
+	return buf.Bytes(), err
+}
+
+// sourceSpan returns the source pixels [start, end) covered by pixel i of n
+// when an axis of size pixels is scaled down to n.
+func sourceSpan(i, n, size int) (int, int) {
+	start := i * size / n
+
+	return start, max((i+1)*size/n, start+1)
+}
+
+// averageColor returns the average color of the pixels of img within area.
+func averageColor(img image.Image, area image.Rectangle) color.NRGBA64 {
+	var r, g, b, a, n uint64
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			pr, pg, pb, pa := img.At(x, y).RGBA()
+			r, g, b, a, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), n+1
+		}
+	}
+	if a == 0 {
+		return color.NRGBA64{}
+	}
+
+	// Colors are alpha-premultiplied: divide by the total alpha to un-premultiply.
+	return color.NRGBA64{
+		R: uint16(r * 0xffff / a), // #nosec G115 -- r <= a
+		G: uint16(g * 0xffff / a), // #nosec G115 -- g <= a
+		B: uint16(b * 0xffff / a), // #nosec G115 -- b <= a
+		A: uint16(a / n),          // #nosec G115 -- average of 16-bit values
+	}
+}
+
+// insertHTMLLogo adds the logo of an action to the top of the body of its HTML
+// page, with alt as its alternative text.
+func insertHTMLLogo(page string, logo *ActionLogo, alt string) string {
+	loc := htmlBodyRe.FindStringIndex(page)
+	if logo == nil || loc == nil {
+		return page
+	}
+
+	img := fmt.Sprintf(`<header class="logo"><img src="%s" alt="%s"`,
+		html.EscapeString(logo.Path), html.EscapeString(alt))
+	if logo.Width > 0 {
+		img += fmt.Sprintf(` width="%d"`, logo.Width)
+	}
+	if logo.Height > 0 {
+		img += fmt.Sprintf(` height="%d"`, logo.Height)
+	}
+
+	return page[:loc[1]] + img + "></header>\n" + page[loc[1]:]
+}
+
+// samePath reports whether source and target name the same file, which is the
+// case when a logo is already in the output directory.
+func samePath(source, target string) bool {
+	sourceDir, err := resolveRealPath(filepath.Dir(source))
+	if err != nil {
+		return false
+	}
+	targetDir, err := resolveRealPath(filepath.Dir(target))
+
+	return err == nil && sourceDir == targetDir && filepath.Base(source) == filepath.Base(target)
+}
+
+// writeIfChanged writes content to path, creating its directory, unless the
+// file already has that content.
+func writeIfChanged(path string, content []byte) error {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, content) { // #nosec G304 -- output path
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil { // #nosec G301 -- output directory permissions
+		return err
+	}
+
+	return os.WriteFile(path, content, FilePermDefault) // #nosec G306 -- output file permissions
+}
@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// writeTestPNG writes a solid PNG image of the given size to path.
+func writeTestPNG(t *testing.T, path string, width, height int) {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.NRGBA{R: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	testutil.AssertNoError(t, png.Encode(&buf, img))
+	testutil.WriteTestFile(t, path, buf.String())
+}
+
+func TestFindActionLogo(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "deploy", "action.yml")
+	testutil.WriteTestFile(t, actionPath, "name: Deploy\n")
+
+	logo, err := FindActionLogo(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "", logo)
+
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "deploy", "logo.svg"), "<svg/>")
+	logo, err = FindActionLogo(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, filepath.Join(tmpDir, "deploy", "logo.svg"), logo)
+
+	testutil.AssertNoError(t, os.Mkdir(filepath.Join(tmpDir, ".git"), 0o750))
+	metadataPath := filepath.Join(tmpDir, "deploy", "action.meta.yml")
+	writeTestPNG(t, filepath.Join(tmpDir, "assets", "brand.png"), 4, 4)
+	testutil.WriteTestFile(t, metadataPath, "logo: ../assets/brand.png\n")
+	logo, err = FindActionLogo(actionPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, filepath.Join(tmpDir, "assets", "brand.png"), logo)
+
+	for _, configured := range []string{"../../../etc/logo.png", "/etc/logo.png", "../assets/notes.txt"} {
+		testutil.WriteTestFile(t, metadataPath, "logo: "+configured+"\n")
+		if _, err := FindActionLogo(actionPath); err == nil {
+			t.Errorf("FindActionLogo() accepted logo %q", configured)
+		}
+	}
+}
+
+func TestResizeLogo(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	path := filepath.Join(tmpDir, "logo.png")
+	writeTestPNG(t, path, 300, 150)
+	content, err := os.ReadFile(path)
+	testutil.AssertNoError(t, err)
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	testutil.AssertNoError(t, err)
+	resized, err := resizeLogo(content, config, 128)
+	testutil.AssertNoError(t, err)
+
+	img, format, err := image.Decode(bytes.NewReader(resized))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "png", format)
+	testutil.AssertEqual(t, image.Rect(0, 0, 128, 64), img.Bounds())
+	testutil.AssertEqual(t, color.NRGBA{R: 200, A: 255}, color.NRGBAModel.Convert(img.At(64, 32)))
+}
+
+func TestGenerator_ActionLogo(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	writeTestPNG(t, filepath.Join(tmpDir, "logo.png"), 256, 256)
+	outputDir := filepath.Join(tmpDir, "docs")
+
+	config := DefaultAppConfig()
+	config.Theme = "github"
+	config.OutputDir = outputDir
+	config.Quiet = true
+	testutil.AssertNoError(t, NewGenerator(config).GenerateFromFile(actionPath))
+	readme, err := os.ReadFile(filepath.Join(outputDir, "README.md"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(readme), "![Simple JavaScript Action logo](logo.png)\n\n# Simple")
+	if _, err := os.Stat(filepath.Join(outputDir, "logo.png")); err != nil {
+		t.Errorf("logo was not copied next to the README: %v", err)
+	}
+
+	config.OutputFormat = OutputFormatHTML
+	testutil.AssertNoError(t, NewGenerator(config).GenerateFromFile(actionPath))
+	page, err := os.ReadFile(filepath.Join(outputDir, "Simple JavaScript Action.html"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(page),
+		`<header class="logo"><img src="logo-128w.png" alt="Simple JavaScript Action logo" width="128" height="128">`)
+	if _, err := os.Stat(filepath.Join(outputDir, "logo-128w.png")); err != nil {
+		t.Errorf("resized logo was not written: %v", err)
+	}
+}
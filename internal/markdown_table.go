@@ -0,0 +1,269 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Markdown table styles of generated docs.
+const (
+	// TableStyleCompact writes table rows as the templates render them.
+	TableStyleCompact = "compact"
+	// TableStyleAligned pads cells so the pipes of each column line up.
+	TableStyleAligned = "aligned"
+)
+
+// Handling of line breaks in table cells.
+const (
+	// TableNewlinesBreak keeps line breaks as <br> tags.
+	TableNewlinesBreak = "br"
+	// TableNewlinesSpace joins lines with spaces.
+	TableNewlinesSpace = "space"
+)
+
+// tableBreak separates lines within a table cell.
+const tableBreak = "<br>"
+
+// tableDelimiterRow matches the delimiter row below the header of a Markdown table.
+var tableDelimiterRow = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+
+// TableSettings configures the Markdown tables of generated docs. Empty fields use the defaults.
+type TableSettings struct {
+	Style               string `mapstructure:"style"                 yaml:"style,omitempty"`
+	MaxDescriptionWidth int    `mapstructure:"max_description_width" yaml:"max_description_width,omitempty"`
+	Newlines            string `mapstructure:"newlines"              yaml:"newlines,omitempty"`
+}
+
+// DefaultTableSettings returns the default table settings: compact rows and
+// line breaks kept as <br>, without wrapping.
+func DefaultTableSettings() TableSettings {
+	return TableSettings{
+		Style:    TableStyleCompact,
+		Newlines: TableNewlinesBreak,
+	}
+}
+
+// ValidateTableSettings rejects unknown styles and newline handling and negative widths.
+func ValidateTableSettings(settings TableSettings) error {
+	if settings.Style != "" && settings.Style != TableStyleCompact && settings.Style != TableStyleAligned {
+		return fmt.Errorf("invalid tables.style '%s', must be one of: %s, %s",
+			settings.Style, TableStyleCompact, TableStyleAligned)
+	}
+	if settings.Newlines != "" && settings.Newlines != TableNewlinesBreak && settings.Newlines != TableNewlinesSpace {
+		return fmt.Errorf("invalid tables.newlines '%s', must be one of: %s, %s",
+			settings.Newlines, TableNewlinesBreak, TableNewlinesSpace)
+	}
+	if settings.MaxDescriptionWidth < 0 {
+		return fmt.Errorf("invalid tables.max_description_width %d, must be 0 or more",
+			settings.MaxDescriptionWidth)
+	}
+
+	return nil
+}
+
+// Cell prepares text for a Markdown table cell: surrounding whitespace is
+// trimmed, pipes are escaped, line breaks become <br> tags or spaces, and
+// lines longer than MaxDescriptionWidth are soft-wrapped with <br> between
+// words outside code spans.
+func (s TableSettings) Cell(text string) string {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	text = escapeTablePipes(text)
+
+	lines := strings.Split(text, "\n")
+	if s.Newlines == TableNewlinesSpace {
+		lines = []string{strings.Join(strings.Fields(text), " ")}
+	}
+	for i, line := range lines {
+		lines[i] = wrapCellLine(strings.TrimSpace(line), s.MaxDescriptionWidth)
+	}
+
+	return strings.Join(lines, tableBreak)
+}
+
+// escapeTablePipes escapes the pipes of text that are not escaped yet.
+func escapeTablePipes(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && i+1 < len(text):
+			b.WriteString(text[i : i+2])
+			i++
+		case text[i] == '|':
+			b.WriteString(`\|`)
+		default:
+			b.WriteByte(text[i])
+		}
+	}
+
+	return b.String()
+}
+
+// wrapCellLine breaks line into lines of at most width display cells, joined
+// with <br>. Words longer than width and code spans are kept whole.
+func wrapCellLine(line string, width int) string {
+	if width <= 0 || DisplayWidth(line) <= width {
+		return line
+	}
+
+	var wrapped []string
+	var current string
+	for _, word := range cellWords(line) {
+		switch {
+		case current == "":
+			current = word
+		case DisplayWidth(current)+1+DisplayWidth(word) <= width:
+			current += " " + word
+		default:
+			wrapped = append(wrapped, current)
+			current = word
+		}
+	}
+	if current != "" {
+		wrapped = append(wrapped, current)
+	}
+
+	return strings.Join(wrapped, tableBreak)
+}
+
+// cellWords splits line at spaces outside backtick code spans.
+func cellWords(line string) []string {
+	var words []string
+	var word strings.Builder
+	inCode := false
+	for _, r := range line {
+		switch {
+		case r == '`':
+			inCode = !inCode
+			word.WriteRune(r)
+		case r == ' ' && !inCode:
+			if word.Len() > 0 {
+				words = append(words, word.String())
+				word.Reset()
+			}
+		default:
+			word.WriteRune(r)
+		}
+	}
+	if word.Len() > 0 {
+		words = append(words, word.String())
+	}
+
+	return words
+}
+
+// AlignMarkdownTables pads the cells of every Markdown table in content so the
+// pipes of each column line up. Tables in fenced code blocks are left alone.
+func AlignMarkdownTables(content string) string {
+	lines := strings.Split(content, "\n")
+	inCode := false
+	for i := 0; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "```") {
+			inCode = !inCode
+
+			continue
+		}
+		if inCode || !isTableRow(lines[i]) || i+1 >= len(lines) || !tableDelimiterRow.MatchString(lines[i+1]) {
+			continue
+		}
+
+		end := i + 2
+		for end < len(lines) && isTableRow(lines[end]) {
+			end++
+		}
+		copy(lines[i:end], alignTable(lines[i:end]))
+		i = end - 1
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// isTableRow reports whether line is a pipe-delimited table row.
+func isTableRow(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "|")
+}
+
+// alignTable pads the rows of one table, its delimiter row being the second.
+func alignTable(rows []string) []string {
+	cells := make([][]string, len(rows))
+	var widths []int
+	for i, row := range rows {
+		cells[i] = splitTableRow(row)
+		for j, cell := range cells[i] {
+			if j >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if i != 1 {
+				widths[j] = max(widths[j], DisplayWidth(cell))
+			}
+		}
+	}
+	for j := range widths {
+		widths[j] = max(widths[j], 3) // Shortest delimiter cell, "---"
+	}
+
+	aligned := make([]string, len(rows))
+	for i, row := range cells {
+		padded := make([]string, len(widths))
+		for j, width := range widths {
+			cell := ""
+			if j < len(row) {
+				cell = row[j]
+			}
+			if i == 1 {
+				padded[j] = delimiterCell(cell, width)
+			} else {
+				padded[j] = PadDisplay(cell, width, false)
+			}
+		}
+		aligned[i] = "| " + strings.Join(padded, " | ") + " |"
+	}
+
+	return aligned
+}
+
+// splitTableRow returns the trimmed cells of a table row, split at unescaped pipes.
+func splitTableRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, `\|`) {
+		row = strings.TrimSuffix(row, "|")
+	}
+
+	var cells []string
+	start := 0
+	for i := 0; i < len(row); i++ {
+		switch row[i] {
+		case '\\':
+			i++
+		case '|':
+			cells = append(cells, strings.TrimSpace(row[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(cells, strings.TrimSpace(row[start:]))
+}
+
+// delimiterCell returns a delimiter cell of width, keeping the column alignment colons of cell.
+func delimiterCell(cell string, width int) string {
+	left := strings.HasPrefix(cell, ":")
+	right := strings.HasSuffix(cell, ":")
+	dashes := width
+	if left {
+		dashes--
+	}
+	if right {
+		dashes--
+	}
+
+	delimiter := strings.Repeat("-", dashes)
+	if left {
+		delimiter = ":" + delimiter
+	}
+	if right {
+		delimiter += ":"
+	}
+
+	return delimiter
+}
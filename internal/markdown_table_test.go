@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestTableSettings_Cell(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings TableSettings
+		text     string
+		want     string
+	}{
+		{"plain", DefaultTableSettings(), "Plain text", "Plain text"},
+		{"block scalar", DefaultTableSettings(), "First line\nSecond line\n", "First line<br>Second line"},
+		{"pipes", DefaultTableSettings(), "`a | b` or a \\| b", "`a \\| b` or a \\| b"},
+		{"windows newlines", DefaultTableSettings(), "One\r\nTwo", "One<br>Two"},
+		{"spaces", TableSettings{Newlines: TableNewlinesSpace}, "One\n  two\n\nthree", "One two three"},
+		{
+			"wrapped",
+			TableSettings{Newlines: TableNewlinesBreak, MaxDescriptionWidth: 12},
+			"Wraps long lines between words",
+			"Wraps long<br>lines<br>between<br>words",
+		},
+		{
+			"code spans kept whole",
+			TableSettings{MaxDescriptionWidth: 10},
+			"Use `npm run build` here",
+			"Use<br>`npm run build`<br>here",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testutil.AssertEqual(t, tt.want, tt.settings.Cell(tt.text))
+		})
+	}
+}
+
+func TestAlignMarkdownTables(t *testing.T) {
+	t.Parallel()
+
+	content := strings.Join([]string{
+		"# Title",
+		"",
+		"| Name | Description | Required |",
+		"|------|:-----------:|---------:|",
+		"| `mode` | Mode `a \\| b` | ✅ |",
+		"| `long-name` | Short | ❌ |",
+		"",
+		"```markdown",
+		"| Not | Aligned |",
+		"|---|---|",
+		"```",
+		"",
+		"| Lonely | pipe row without delimiter |",
+	}, "\n")
+
+	want := strings.Join([]string{
+		"# Title",
+		"",
+		"| Name        | Description   | Required |",
+		"| ----------- | :-----------: | -------: |",
+		"| `mode`      | Mode `a \\| b` | ✅       |",
+		"| `long-name` | Short         | ❌       |",
+		"",
+		"```markdown",
+		"| Not | Aligned |",
+		"|---|---|",
+		"```",
+		"",
+		"| Lonely | pipe row without delimiter |",
+	}, "\n")
+
+	testutil.AssertEqual(t, want, AlignMarkdownTables(content))
+	testutil.AssertEqual(t, want, AlignMarkdownTables(want))
+}
+
+func TestValidateTableSettings(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, ValidateTableSettings(DefaultTableSettings()))
+	testutil.AssertNoError(t, ValidateTableSettings(TableSettings{}))
+	for _, settings := range []TableSettings{
+		{Style: "fancy"},
+		{Newlines: "keep"},
+		{MaxDescriptionWidth: -1},
+	} {
+		testutil.AssertError(t, ValidateTableSettings(settings))
+	}
+}
+
+func TestRenderReadme_TableSettings(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{
+		Name:        "Greeter",
+		Description: "Greets people",
+		Inputs: map[string]ActionInput{
+			"name": {Description: "Who to greet.\nUse `a | b` for two people.\n", Required: true},
+		},
+		Runs: map[string]any{"using": "node20", "main": "index.js"},
+	}
+	config := DefaultAppConfig()
+	opts := TemplateOptions{TemplatePath: "templates/themes/github/readme.tmpl", Format: "md"}
+
+	out, err := RenderReadme(BuildTemplateData(action, config, "", ""), opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out,
+		"| `name` | Who to greet.<br>Use `a \\| b` for two people. | ✅ | - |")
+
+	config.Tables.Style = TableStyleAligned
+	out, err = RenderReadme(BuildTemplateData(action, config, "", ""), opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out,
+		"| Parameter | Description                                   | Required | Default |")
+	testutil.AssertStringContains(t, out,
+		"| `name`    | Who to greet.<br>Use `a \\| b` for two people. | ✅       | -       |")
+}
@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
+)
+
+// MetadataFileNames are the sidecar metadata files read from the directory of an
+// action file, in order of preference.
+var MetadataFileNames = []string{"action.meta.yml", "action.meta.yaml"}
+
+// ActionMetadata is catalog metadata kept in a sidecar file next to action.yml,
+// for information GitHub does not allow in action.yml itself.
+type ActionMetadata struct {
+	Category string   `yaml:"category,omitempty" json:"category,omitempty"`
+	Tags     []string `yaml:"tags,omitempty"     json:"tags,omitempty"`
+	Owners   []string `yaml:"owners,omitempty"   json:"owners,omitempty"` // Overrides CODEOWNERS
+	Logo     string   `yaml:"logo,omitempty"     json:"-"`                // Relative to the action file
+}
+
+// IsEmpty reports whether no category, tags or owners are set. The logo is
+// rendered separately, see ActionLogo.
+func (m *ActionMetadata) IsEmpty() bool {
+	return m.Category == "" && len(m.Tags) == 0 && len(m.Owners) == 0
+}
+
+// LoadActionMetadata reads the sidecar metadata for the action at actionPath.
+// Actions without a sidecar file get empty metadata.
+func LoadActionMetadata(actionPath string) (*ActionMetadata, error) {
+	dir := filepath.Dir(actionPath)
+	for _, name := range MetadataFileNames {
+		path := filepath.Join(dir, name)
+		content, err := os.ReadFile(path) // #nosec G304 -- sidecar path next to the action file
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var metadata ActionMetadata
+		if err := yamlsafe.Unmarshal(content, &metadata); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		return &metadata, nil
+	}
+
+	return &ActionMetadata{}, nil
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+)
+
+// DefaultMetadataBundleFile is the file metadata export writes by default.
+const DefaultMetadataBundleFile = "gh-action-readme-metadata.json"
+
+// MetadataSnapshotFile returns the path metadata import stores the imported
+// bundle at in the XDG data directory, such as
+// ~/.local/share/gh-action-readme/metadata-snapshot.json.
+func MetadataSnapshotFile() (string, error) {
+	path, err := xdg.DataFile(filepath.Join(toolName, "metadata-snapshot.json"))
+	if err != nil {
+		return "", fmt.Errorf("failed to get XDG data directory: %w", err)
+	}
+
+	return path, nil
+}
+
+// WriteMetadataBundle writes bundle to path as JSON.
+func WriteMetadataBundle(path string, bundle *dependencies.MetadataBundle) error {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata bundle: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil { // #nosec G301 -- data directory permissions
+		return fmt.Errorf("failed to create directory of %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), FilePermDefault); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// ReadMetadataBundle reads and validates the metadata bundle at path.
+func ReadMetadataBundle(path string) (*dependencies.MetadataBundle, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- bundle named by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var bundle dependencies.MetadataBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata bundle %s: %w", path, err)
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if bundle.Repositories == nil {
+		bundle.Repositories = map[string]*dependencies.RepositoryMetadata{}
+	}
+
+	return &bundle, nil
+}
+
+// ImportMetadataBundle validates the bundle at src and stores it as the
+// metadata snapshot at dst, replacing any earlier import.
+func ImportMetadataBundle(src, dst string) (*dependencies.MetadataBundle, error) {
+	bundle, err := ReadMetadataBundle(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := WriteMetadataBundle(dst, bundle); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// LoadMetadataSnapshot reads the imported metadata snapshot at path. It
+// returns nil without an error when nothing was imported.
+func LoadMetadataSnapshot(path string) (*dependencies.MetadataBundle, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	return ReadMetadataBundle(path)
+}
+
+// HasMetadataSnapshot reports whether a metadata snapshot was imported.
+func HasMetadataSnapshot() bool {
+	path, err := MetadataSnapshotFile()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+
+	return err == nil
+}
+
+// withMetadataSnapshot layers the imported metadata snapshot, if any, under cache.
+func withMetadataSnapshot(cache dependencies.DependencyCache) (dependencies.DependencyCache, error) {
+	path, err := MetadataSnapshotFile()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := LoadMetadataSnapshot(path)
+	if err != nil || snapshot == nil {
+		return cache, err
+	}
+
+	return dependencies.NewSnapshotCache(cache, snapshot), nil
+}
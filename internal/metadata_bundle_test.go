@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestImportMetadataBundle(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	snapshotFile := filepath.Join(dir, "data", "metadata-snapshot.json")
+	snapshot, err := LoadMetadataSnapshot(snapshotFile)
+	testutil.AssertNoError(t, err)
+	if snapshot != nil {
+		t.Fatal("expected no snapshot before the import")
+	}
+
+	bundle := dependencies.NewMetadataBundle(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	bundle.Repositories["actions/checkout"] = &dependencies.RepositoryMetadata{LatestVersion: "v4.2.2"}
+	bundleFile := filepath.Join(dir, DefaultMetadataBundleFile)
+	testutil.AssertNoError(t, WriteMetadataBundle(bundleFile, bundle))
+
+	imported, err := ImportMetadataBundle(bundleFile, snapshotFile)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(imported.Repositories))
+
+	snapshot, err = LoadMetadataSnapshot(snapshotFile)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "v4.2.2", snapshot.Repositories["actions/checkout"].LatestVersion)
+	testutil.AssertEqual(t, bundle.CreatedAt, snapshot.CreatedAt)
+
+	testutil.WriteTestFile(t, filepath.Join(dir, "future.json"), `{"version": 99, "repositories": {}}`)
+	_, err = ImportMetadataBundle(filepath.Join(dir, "future.json"), snapshotFile)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "unsupported metadata bundle version 99")
+
+	testutil.WriteTestFile(t, filepath.Join(dir, "broken.json"), `{"version": `)
+	_, err = ImportMetadataBundle(filepath.Join(dir, "broken.json"), snapshotFile)
+	testutil.AssertError(t, err)
+}
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"strings"
+)
+
+// Cold-start cost estimates.
+const (
+	ColdStartLow    = "low"
+	ColdStartMedium = "medium"
+	ColdStartHigh   = "high"
+)
+
+// compositeHeavyDependencies is the number of external actions above which a
+// composite action is considered expensive to start.
+const compositeHeavyDependencies = 3
+
+// ActionMetrics describes the size and complexity of a single action.
+type ActionMetrics struct {
+	Runtime              string `json:"runtime"`
+	Inputs               int    `json:"inputs"`
+	RequiredInputs       int    `json:"required_inputs"`
+	Outputs              int    `json:"outputs"`
+	Steps                int    `json:"steps"`
+	ExternalDependencies int    `json:"external_dependencies"`
+	LocalDependencies    int    `json:"local_dependencies"`
+	RunLines             int    `json:"run_lines"`
+	ColdStart            string `json:"cold_start"`
+	ColdStartReason      string `json:"cold_start_reason"`
+}
+
+// ComputeMetrics calculates size and complexity metrics for an action.
+func ComputeMetrics(action *ActionYML) ActionMetrics {
+	metrics := ActionMetrics{
+		Inputs:  len(action.Inputs),
+		Outputs: len(action.Outputs),
+	}
+	metrics.Runtime, _ = action.Runs["using"].(string)
+
+	for _, input := range action.Inputs {
+		if input.Required {
+			metrics.RequiredInputs++
+		}
+	}
+
+	if steps, ok := action.Runs["steps"].([]any); ok {
+		metrics.Steps = len(steps)
+		for _, step := range steps {
+			stepMap, ok := step.(map[string]any)
+			if !ok {
+				continue
+			}
+			if uses, ok := stepMap["uses"].(string); ok {
+				if strings.HasPrefix(uses, "./") {
+					metrics.LocalDependencies++
+				} else {
+					metrics.ExternalDependencies++
+				}
+			}
+			if run, ok := stepMap["run"].(string); ok {
+				metrics.RunLines += countCodeLines(run)
+			}
+		}
+	}
+
+	metrics.ColdStart, metrics.ColdStartReason = estimateColdStart(action, metrics)
+
+	return metrics
+}
+
+// estimateColdStart classifies how expensive the action is to start on a fresh runner.
+func estimateColdStart(action *ActionYML, metrics ActionMetrics) (string, string) {
+	runtime := strings.ToLower(metrics.Runtime)
+	switch {
+	case runtime == "docker":
+		image, _ := action.Runs["image"].(string)
+		if strings.HasPrefix(image, "docker://") {
+			return ColdStartMedium, "pulls a prebuilt container image"
+		}
+
+		return ColdStartHigh, "builds a container image on every run"
+	case runtime == "composite":
+		if metrics.ExternalDependencies > compositeHeavyDependencies {
+			return ColdStartHigh, "downloads many external actions"
+		}
+		if metrics.ExternalDependencies > 0 {
+			return ColdStartMedium, "downloads external actions"
+		}
+
+		return ColdStartLow, "runs shell steps only"
+	case strings.HasPrefix(runtime, "node"):
+		return ColdStartLow, "runs bundled JavaScript directly"
+	default:
+		return ColdStartMedium, "unknown runtime"
+	}
+}
+
+// countCodeLines counts non-blank, non-comment lines in a run block.
+func countCodeLines(script string) int {
+	count := 0
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			count++
+		}
+	}
+
+	return count
+}
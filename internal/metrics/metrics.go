@@ -0,0 +1,251 @@
+// Package metrics collects runtime counters for long-running modes and exposes
+// them in the Prometheus text exposition format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metric names.
+const (
+	GenerationsTotal          = "gh_action_readme_generations_total"
+	GenerationDurationSeconds = "gh_action_readme_generation_duration_seconds"
+	CacheRequestsTotal        = "gh_action_readme_cache_requests_total"
+	GitHubRequestsTotal       = "gh_action_readme_github_requests_total"
+	GitHubRateLimit           = "gh_action_readme_github_rate_limit"
+	GitHubRateLimitRemaining  = "gh_action_readme_github_rate_limit_remaining"
+	GitHubRateLimitReset      = "gh_action_readme_github_rate_limit_reset_timestamp_seconds"
+)
+
+// Generation results used as the status label.
+const (
+	StatusSuccess = "success"
+	StatusStale   = "stale"
+	StatusError   = "error"
+)
+
+// contentType is the Prometheus text exposition format version served by Handler.
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// durationBuckets are the upper bounds, in seconds, of the generation duration histogram.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// metricKind is the Prometheus type of a metric family.
+type metricKind string
+
+const (
+	kindCounter   metricKind = "counter"
+	kindGauge     metricKind = "gauge"
+	kindHistogram metricKind = "histogram"
+)
+
+// family is a metric with its samples keyed by rendered label set.
+type family struct {
+	help       string
+	kind       metricKind
+	values     map[string]float64
+	histograms map[string]*histogram
+}
+
+// histogram holds cumulative bucket counts for one label set.
+type histogram struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// Registry holds metric families. The zero value is not usable; use NewRegistry.
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// Default is the registry updated by the package-level functions.
+var Default = NewRegistry()
+
+// NewRegistry creates a registry with every metric family described, so all of
+// them are exposed before the first sample.
+func NewRegistry() *Registry {
+	r := &Registry{families: make(map[string]*family)}
+	r.describe(GenerationsTotal, kindCounter, "Documentation generations by output format and status.")
+	r.describe(GenerationDurationSeconds, kindHistogram, "Time spent generating documentation for one action.")
+	r.describe(CacheRequestsTotal, kindCounter, "Cache lookups by result (hit or miss).")
+	r.describe(GitHubRequestsTotal, kindCounter, "GitHub API requests by HTTP status code.")
+	r.describe(GitHubRateLimit, kindGauge, "GitHub API request quota for the current window.")
+	r.describe(GitHubRateLimitRemaining, kindGauge, "GitHub API requests remaining in the current window.")
+	r.describe(GitHubRateLimitReset, kindGauge, "Unix time at which the GitHub API quota resets.")
+
+	return r
+}
+
+// ObserveGeneration records one documentation generation in the default registry.
+func ObserveGeneration(format, status string, duration time.Duration) {
+	Default.ObserveGeneration(format, status, duration)
+}
+
+// RecordCacheLookup records a cache hit or miss in the default registry.
+func RecordCacheLookup(hit bool) {
+	Default.RecordCacheLookup(hit)
+}
+
+// Handler serves the default registry in the Prometheus text format.
+func Handler() http.Handler {
+	return Default.Handler()
+}
+
+// ObserveGeneration records one documentation generation.
+func (r *Registry) ObserveGeneration(format, status string, duration time.Duration) {
+	labels := renderLabels("format", format, "status", status)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.families[GenerationsTotal].values[labels]++
+	r.observe(GenerationDurationSeconds, renderLabels("format", format), duration.Seconds())
+}
+
+// RecordCacheLookup records a cache hit or miss.
+func (r *Registry) RecordCacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	r.add(CacheRequestsTotal, renderLabels("result", result), 1)
+}
+
+// RecordGitHubResponse counts a GitHub API response and updates the quota
+// gauges from its X-RateLimit headers.
+func (r *Registry) RecordGitHubResponse(resp *http.Response) {
+	r.add(GitHubRequestsTotal, renderLabels("code", strconv.Itoa(resp.StatusCode)), 1)
+
+	headers := map[string]string{
+		"X-RateLimit-Limit":     GitHubRateLimit,
+		"X-RateLimit-Remaining": GitHubRateLimitRemaining,
+		"X-RateLimit-Reset":     GitHubRateLimitReset,
+	}
+	resource := resp.Header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "core"
+	}
+	for header, name := range headers {
+		value, err := strconv.ParseFloat(resp.Header.Get(header), 64)
+		if err != nil {
+			continue
+		}
+		r.set(name, renderLabels("resource", resource), value)
+	}
+}
+
+// Handler serves the registry in the Prometheus text format.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_ = r.Write(w)
+	})
+}
+
+// Write writes every metric family in the Prometheus text format, sorted by
+// name and label set so the output is stable.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(r.families)) {
+		f := r.families[name]
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, f.help, name, f.kind)
+		for _, labels := range slices.Sorted(maps.Keys(f.values)) {
+			fmt.Fprintf(&b, "%s%s %s\n", name, labels, formatValue(f.values[labels]))
+		}
+		for _, labels := range slices.Sorted(maps.Keys(f.histograms)) {
+			writeHistogram(&b, name, labels, f.histograms[labels])
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// describe registers an empty metric family.
+func (r *Registry) describe(name string, kind metricKind, help string) {
+	r.families[name] = &family{
+		help:       help,
+		kind:       kind,
+		values:     make(map[string]float64),
+		histograms: make(map[string]*histogram),
+	}
+}
+
+// add increments a counter sample.
+func (r *Registry) add(name, labels string, delta float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.families[name].values[labels] += delta
+}
+
+// set replaces a gauge sample.
+func (r *Registry) set(name, labels string, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.families[name].values[labels] = value
+}
+
+// observe adds a histogram observation. The caller must hold r.mu.
+func (r *Registry) observe(name, labels string, value float64) {
+	h, ok := r.families[name].histograms[labels]
+	if !ok {
+		h = &histogram{buckets: make([]uint64, len(durationBuckets))}
+		r.families[name].histograms[labels] = h
+	}
+	for i, bound := range durationBuckets {
+		if value <= bound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += value
+}
+
+// writeHistogram writes the bucket, sum and count samples of one histogram.
+func writeHistogram(b *strings.Builder, name, labels string, h *histogram) {
+	for i, bound := range durationBuckets {
+		fmt.Fprintf(b, "%s_bucket%s %d\n", name, withLabel(labels, "le", formatValue(bound)), h.buckets[i])
+	}
+	fmt.Fprintf(b, "%s_bucket%s %d\n", name, withLabel(labels, "le", "+Inf"), h.count)
+	fmt.Fprintf(b, "%s_sum%s %s\n", name, labels, formatValue(h.sum))
+	fmt.Fprintf(b, "%s_count%s %d\n", name, labels, h.count)
+}
+
+// renderLabels renders name/value pairs as a Prometheus label set.
+func renderLabels(pairs ...string) string {
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, pairs[i]+"="+strconv.Quote(pairs[i+1]))
+	}
+
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// withLabel appends one label to a rendered label set.
+func withLabel(labels, name, value string) string {
+	extra := name + "=" + strconv.Quote(value)
+	if labels == "{}" || labels == "" {
+		return "{" + extra + "}"
+	}
+
+	return strings.TrimSuffix(labels, "}") + "," + extra + "}"
+}
+
+// formatValue formats a sample value the way Prometheus clients do.
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
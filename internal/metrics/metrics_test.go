@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestRegistry_Write(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	registry.ObserveGeneration("md", StatusSuccess, 250*time.Millisecond)
+	registry.ObserveGeneration("md", StatusSuccess, 2*time.Second)
+	registry.ObserveGeneration("md", StatusError, 0)
+	registry.RecordCacheLookup(true)
+	registry.RecordCacheLookup(false)
+	registry.RecordCacheLookup(true)
+
+	var b strings.Builder
+	testutil.AssertNoError(t, registry.Write(&b))
+	output := b.String()
+
+	expected := []string{
+		"# TYPE gh_action_readme_generations_total counter",
+		`gh_action_readme_generations_total{format="md",status="success"} 2`,
+		`gh_action_readme_generations_total{format="md",status="error"} 1`,
+		"# TYPE gh_action_readme_generation_duration_seconds histogram",
+		`gh_action_readme_generation_duration_seconds_bucket{format="md",le="0.005"} 1`,
+		`gh_action_readme_generation_duration_seconds_bucket{format="md",le="0.1"} 1`,
+		`gh_action_readme_generation_duration_seconds_bucket{format="md",le="0.25"} 2`,
+		`gh_action_readme_generation_duration_seconds_bucket{format="md",le="2.5"} 3`,
+		`gh_action_readme_generation_duration_seconds_bucket{format="md",le="+Inf"} 3`,
+		`gh_action_readme_generation_duration_seconds_sum{format="md"} 2.25`,
+		`gh_action_readme_generation_duration_seconds_count{format="md"} 3`,
+		`gh_action_readme_cache_requests_total{result="hit"} 2`,
+		`gh_action_readme_cache_requests_total{result="miss"} 1`,
+		"# TYPE gh_action_readme_github_rate_limit_remaining gauge",
+	}
+	for _, line := range expected {
+		testutil.AssertStringContains(t, output, line+"\n")
+	}
+}
+
+func TestRegistry_RecordGitHubResponse(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	header := make(http.Header)
+	header.Set("X-RateLimit-Limit", "5000")
+	header.Set("X-RateLimit-Remaining", "4321")
+	header.Set("X-RateLimit-Reset", "1700000000")
+	registry.RecordGitHubResponse(&http.Response{StatusCode: http.StatusOK, Header: header})
+
+	searchHeader := make(http.Header)
+	searchHeader.Set("X-RateLimit-Resource", "search")
+	searchHeader.Set("X-RateLimit-Remaining", "0")
+	registry.RecordGitHubResponse(&http.Response{StatusCode: http.StatusForbidden, Header: searchHeader})
+
+	var b strings.Builder
+	testutil.AssertNoError(t, registry.Write(&b))
+	output := b.String()
+
+	for _, line := range []string{
+		`gh_action_readme_github_requests_total{code="200"} 1`,
+		`gh_action_readme_github_requests_total{code="403"} 1`,
+		`gh_action_readme_github_rate_limit{resource="core"} 5000`,
+		`gh_action_readme_github_rate_limit_remaining{resource="core"} 4321`,
+		`gh_action_readme_github_rate_limit_remaining{resource="search"} 0`,
+		`gh_action_readme_github_rate_limit_reset_timestamp_seconds{resource="core"} 1.7e+09`,
+	} {
+		testutil.AssertStringContains(t, output, line+"\n")
+	}
+	if strings.Contains(output, `gh_action_readme_github_rate_limit{resource="search"}`) {
+		t.Error("expected no quota gauge for a missing header")
+	}
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	registry.RecordCacheLookup(true)
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	testutil.AssertEqual(t, http.StatusOK, recorder.Code)
+	testutil.AssertEqual(t, contentType, recorder.Header().Get("Content-Type"))
+	testutil.AssertStringContains(t, recorder.Body.String(), `gh_action_readme_cache_requests_total{result="hit"} 1`)
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Resource", "transport-test")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport(nil)}
+	resp, err := client.Get(server.URL)
+	testutil.AssertNoError(t, err)
+	_ = resp.Body.Close()
+
+	var b strings.Builder
+	testutil.AssertNoError(t, Default.Write(&b))
+	testutil.AssertStringContains(t, b.String(),
+		`gh_action_readme_github_rate_limit_remaining{resource="transport-test"} 42`)
+}
+
+func TestRegistry_Usage(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	if registry.Usage().Active() {
+		t.Fatal("expected no usage before any request")
+	}
+
+	header := make(http.Header)
+	header.Set("X-RateLimit-Limit", "5000")
+	header.Set("X-RateLimit-Remaining", "4998")
+	header.Set("X-RateLimit-Reset", "1700000000")
+	registry.RecordGitHubResponse(&http.Response{StatusCode: http.StatusOK, Header: header})
+	header.Set("X-RateLimit-Remaining", "4997")
+	registry.RecordGitHubResponse(&http.Response{StatusCode: http.StatusNotFound, Header: header})
+	searchHeader := make(http.Header)
+	searchHeader.Set("X-RateLimit-Resource", "search")
+	searchHeader.Set("X-RateLimit-Remaining", "0")
+	registry.RecordGitHubResponse(&http.Response{StatusCode: http.StatusForbidden, Header: searchHeader})
+	registry.RecordCacheLookup(true)
+	registry.RecordCacheLookup(false)
+
+	usage := registry.Usage()
+	testutil.AssertEqual(t, 3, usage.Requests)
+	testutil.AssertEqual(t, 1, usage.CacheHits)
+	testutil.AssertEqual(t, 1, usage.CacheMisses)
+	if usage.RateLimit == nil {
+		t.Fatal("expected the core rate limit")
+	}
+	testutil.AssertEqual(t, 5000, usage.RateLimit.Limit)
+	testutil.AssertEqual(t, 4997, usage.RateLimit.Remaining)
+	testutil.AssertEqual(t, int64(1700000000), usage.RateLimit.Reset.Unix())
+	testutil.AssertEqual(t,
+		"GitHub API: 3 requests, 1 cache hit, 1 cache miss, 4997/5000 remaining (resets 22:13 UTC)",
+		usage.String())
+}
+
+func TestAPIUsage_StringWithoutRateLimit(t *testing.T) {
+	t.Parallel()
+
+	usage := APIUsage{CacheHits: 4}
+	testutil.AssertEqual(t, true, usage.Active())
+	testutil.AssertEqual(t, "GitHub API: 0 requests, 4 cache hits, 0 cache misses", usage.String())
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import "net/http"
+
+// transport records every GitHub API response in a registry.
+type transport struct {
+	next     http.RoundTripper
+	registry *Registry
+}
+
+// Transport wraps next, or http.DefaultTransport when next is nil, so every
+// response updates the GitHub request counter and quota gauges of the default
+// registry.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &transport{next: next, registry: Default}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err == nil {
+		t.registry.RecordGitHubResponse(resp)
+	}
+
+	return resp, err
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIUsage summarizes the GitHub API traffic of a run, so cache TTLs and
+// tokens can be tuned.
+type APIUsage struct {
+	Requests    int `json:"requests"`
+	CacheHits   int `json:"cache_hits"`
+	CacheMisses int `json:"cache_misses"`
+	// RateLimit is the core quota reported by the last response, nil when no
+	// response carried rate limit headers.
+	RateLimit *RateLimitStatus `json:"rate_limit,omitempty"`
+}
+
+// RateLimitStatus is the GitHub API quota of the current window.
+type RateLimitStatus struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// Usage returns the GitHub API usage recorded in the default registry.
+func Usage() APIUsage {
+	return Default.Usage()
+}
+
+// Usage returns the GitHub API requests, cache lookups and core quota recorded so far.
+func (r *Registry) Usage() APIUsage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var usage APIUsage
+	for _, count := range r.families[GitHubRequestsTotal].values {
+		usage.Requests += int(count)
+	}
+	usage.CacheHits = int(r.families[CacheRequestsTotal].values[renderLabels("result", "hit")])
+	usage.CacheMisses = int(r.families[CacheRequestsTotal].values[renderLabels("result", "miss")])
+
+	core := renderLabels("resource", "core")
+	limit, hasLimit := r.families[GitHubRateLimit].values[core]
+	remaining, hasRemaining := r.families[GitHubRateLimitRemaining].values[core]
+	if hasLimit || hasRemaining {
+		usage.RateLimit = &RateLimitStatus{Limit: int(limit), Remaining: int(remaining)}
+		if reset, ok := r.families[GitHubRateLimitReset].values[core]; ok {
+			usage.RateLimit.Reset = time.Unix(int64(reset), 0).UTC()
+		}
+	}
+
+	return usage
+}
+
+// Active reports whether the run made GitHub API requests or looked up cached API data.
+func (u APIUsage) Active() bool {
+	return u.Requests > 0 || u.CacheHits > 0 || u.CacheMisses > 0
+}
+
+// String returns a one-line summary such as
+// "GitHub API: 3 requests, 12 cache hits, 3 cache misses, 4997/5000 remaining (resets 15:04 UTC)".
+func (u APIUsage) String() string {
+	parts := []string{
+		plural(u.Requests, "request"),
+		plural(u.CacheHits, "cache hit"),
+		plural(u.CacheMisses, "cache miss"),
+	}
+	if u.RateLimit != nil {
+		quota := fmt.Sprintf("%d/%d remaining", u.RateLimit.Remaining, u.RateLimit.Limit)
+		if !u.RateLimit.Reset.IsZero() {
+			quota += " (resets " + u.RateLimit.Reset.Format("15:04 MST") + ")"
+		}
+		parts = append(parts, quota)
+	}
+
+	return "GitHub API: " + strings.Join(parts, ", ")
+}
+
+// plural formats count with noun, adding the English plural suffix when needed.
+func plural(count int, noun string) string {
+	if count != 1 {
+		if strings.HasSuffix(noun, "s") {
+			noun += "es"
+		} else {
+			noun += "s"
+		}
+	}
+
+	return strconv.Itoa(count) + " " + noun
+}
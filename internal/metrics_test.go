@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestComputeMetrics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		action   *ActionYML
+		expected ActionMetrics
+	}{
+		{
+			name: "node action",
+			action: &ActionYML{
+				Inputs: map[string]ActionInput{
+					"token": {Required: true},
+					"path":  {},
+				},
+				Outputs: map[string]ActionOutput{"result": {}},
+				Runs:    map[string]any{"using": "node20", "main": "dist/index.js"},
+			},
+			expected: ActionMetrics{
+				Runtime:         "node20",
+				Inputs:          2,
+				RequiredInputs:  1,
+				Outputs:         1,
+				ColdStart:       ColdStartLow,
+				ColdStartReason: "runs bundled JavaScript directly",
+			},
+		},
+		{
+			name: "composite action",
+			action: &ActionYML{
+				Runs: map[string]any{
+					"using": "composite",
+					"steps": []any{
+						map[string]any{"uses": "actions/checkout@v4"},
+						map[string]any{"uses": "./.github/actions/setup"},
+						map[string]any{"run": "# build\nnpm ci\n\nnpm run build\n", "shell": "bash"},
+					},
+				},
+			},
+			expected: ActionMetrics{
+				Runtime:              "composite",
+				Steps:                3,
+				ExternalDependencies: 1,
+				LocalDependencies:    1,
+				RunLines:             2,
+				ColdStart:            ColdStartMedium,
+				ColdStartReason:      "downloads external actions",
+			},
+		},
+		{
+			name:   "dockerfile action",
+			action: &ActionYML{Runs: map[string]any{"using": "docker", "image": "Dockerfile"}},
+			expected: ActionMetrics{
+				Runtime:         "docker",
+				ColdStart:       ColdStartHigh,
+				ColdStartReason: "builds a container image on every run",
+			},
+		},
+		{
+			name:   "prebuilt image action",
+			action: &ActionYML{Runs: map[string]any{"using": "docker", "image": "docker://alpine:3"}},
+			expected: ActionMetrics{
+				Runtime:         "docker",
+				ColdStart:       ColdStartMedium,
+				ColdStartReason: "pulls a prebuilt container image",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testutil.AssertEqual(t, tt.expected, ComputeMetrics(tt.action))
+		})
+	}
+}
+
+func TestBuildTemplateData_Metrics(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{Name: "Metrics", Runs: map[string]any{"using": "node20"}}
+
+	config := DefaultAppConfig()
+	data := BuildTemplateData(action, config, "", "")
+	if data.Metrics != nil {
+		t.Fatal("expected no metrics when show_metrics is disabled")
+	}
+
+	config.ShowMetrics = true
+	data = BuildTemplateData(action, config, "", "")
+	if data.Metrics == nil {
+		t.Fatal("expected metrics when show_metrics is enabled")
+	}
+	testutil.AssertEqual(t, "node20", data.Metrics.Runtime)
+}
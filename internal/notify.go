@@ -0,0 +1,369 @@
+package internal
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+)
+
+// Environment variables holding the webhook URLs of --notify. They take
+// precedence over the configuration, so the URLs can be kept in CI secrets.
+const (
+	EnvSlackWebhook = "GH_README_SLACK_WEBHOOK" // #nosec G101 -- environment variable name, not a credential
+	EnvTeamsWebhook = "GH_README_TEAMS_WEBHOOK" // #nosec G101 -- environment variable name, not a credential
+)
+
+// defaultNotifyTop is the number of top offenders listed in a notification.
+const defaultNotifyTop = 5
+
+// NotifySettings configures the Slack and Microsoft Teams incoming webhooks
+// the reporting commands post a summary to with --notify.
+type NotifySettings struct {
+	// SlackWebhook is the Slack incoming webhook URL, GH_README_SLACK_WEBHOOK when set
+	SlackWebhook string `mapstructure:"slack_webhook" yaml:"slack_webhook,omitempty"`
+	// TeamsWebhook is the Teams incoming webhook or workflow URL, GH_README_TEAMS_WEBHOOK when set
+	TeamsWebhook string `mapstructure:"teams_webhook" yaml:"teams_webhook,omitempty"`
+	// Link is the details link of notifications, the GitHub Actions run when empty
+	Link string `mapstructure:"link"          yaml:"link,omitempty"`
+	// Top is the number of top offenders listed, 5 when zero
+	Top int `mapstructure:"top"           yaml:"top,omitempty"`
+}
+
+// NotificationFact is a labelled count of a notification.
+type NotificationFact struct {
+	Name  string
+	Value int
+}
+
+// Notification is the summary of a report posted to chat webhooks.
+type Notification struct {
+	Title     string
+	Summary   string
+	Facts     []NotificationFact
+	Offenders []string // Worst items first
+	Link      string   // Details, such as the CI run; optional
+}
+
+// ValidateNotifySettings rejects webhook URLs that are not absolute http(s)
+// URLs and negative offender counts.
+func ValidateNotifySettings(settings NotifySettings) error {
+	for name, value := range map[string]string{
+		"notify.slack_webhook": settings.SlackWebhook,
+		"notify.teams_webhook": settings.TeamsWebhook,
+		"notify.link":          settings.Link,
+	} {
+		if value == "" {
+			continue
+		}
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+			return fmt.Errorf("invalid %s %q, must be an http(s) URL", name, value)
+		}
+	}
+	if settings.Top < 0 {
+		return fmt.Errorf("invalid notify.top %d, must not be negative", settings.Top)
+	}
+
+	return nil
+}
+
+// Webhooks returns the Slack and Teams webhook URLs of settings, with the
+// environment variables taking precedence.
+func (s NotifySettings) Webhooks() (slack, teams string) {
+	return cmp.Or(os.Getenv(EnvSlackWebhook), s.SlackWebhook), cmp.Or(os.Getenv(EnvTeamsWebhook), s.TeamsWebhook)
+}
+
+// top returns the number of offenders to list.
+func (s NotifySettings) top() int {
+	if s.Top > 0 {
+		return s.Top
+	}
+
+	return defaultNotifyTop
+}
+
+// link returns the configured link, else the URL of the GitHub Actions run.
+func (s NotifySettings) link() string {
+	if s.Link != "" {
+		return s.Link
+	}
+	server, repo, run := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID")
+	if server == "" || repo == "" || run == "" {
+		return ""
+	}
+
+	return server + "/" + repo + "/actions/runs/" + run
+}
+
+// OutdatedNotification summarizes a deps outdated run: counts by update type
+// and the most urgent updates, security and major updates first.
+func OutdatedNotification(settings NotifySettings, outdated []dependencies.OutdatedDependency) Notification {
+	var major, minor, patch, security, stale int
+	for _, dep := range outdated {
+		switch dep.UpdateType {
+		case "major":
+			major++
+		case "minor":
+			minor++
+		case "patch":
+			patch++
+		}
+		if dep.IsSecurityUpdate {
+			security++
+		}
+		if dep.IsStale {
+			stale++
+		}
+	}
+
+	ranked := slices.Clone(outdated)
+	slices.SortStableFunc(ranked, func(a, b dependencies.OutdatedDependency) int {
+		return cmp.Or(
+			compareTrueFirst(a.IsSecurityUpdate, b.IsSecurityUpdate),
+			cmp.Compare(updateTypeRank(a.UpdateType), updateTypeRank(b.UpdateType)),
+			cmp.Compare(b.AgeDays, a.AgeDays),
+			strings.Compare(a.Current.Name, b.Current.Name),
+		)
+	})
+	offenders := make([]string, 0, min(len(ranked), settings.top()))
+	for _, dep := range ranked[:min(len(ranked), settings.top())] {
+		labels := []string{cmp.Or(dep.UpdateType, "update")}
+		if dep.IsSecurityUpdate {
+			labels = append(labels, "security")
+		}
+		if dep.IsStale {
+			labels = append(labels, "stale")
+		}
+		offenders = append(offenders, fmt.Sprintf("%s %s → %s (%s)",
+			dep.Current.Name, dep.Current.Version, dep.LatestVersion, strings.Join(labels, ", ")))
+	}
+
+	summary := "All dependencies are up to date."
+	if len(outdated) > 0 {
+		summary = fmt.Sprintf("%d outdated dependencies found.", len(outdated))
+	}
+
+	return Notification{
+		Title:   "Outdated dependencies",
+		Summary: summary,
+		Facts: []NotificationFact{
+			{"Outdated", len(outdated)},
+			{"Major", major},
+			{"Minor", minor},
+			{"Patch", patch},
+			{"Security", security},
+			{"Stale", stale},
+		},
+		Offenders: offenders,
+		Link:      settings.link(),
+	}
+}
+
+// DriftNotification summarizes a drift report: the totals of the report and
+// the actions with the most drift.
+func DriftNotification(settings NotifySettings, report *DriftReport) Notification {
+	type offender struct {
+		file     string
+		score    int
+		problems []string
+	}
+	var offenders []offender
+	for _, action := range report.Actions {
+		entry := offender{file: action.File}
+		if action.Docs.Status != DocsFresh {
+			entry.score++
+			entry.problems = append(entry.problems, "docs "+action.Docs.Status)
+		}
+		if !action.Pins.Compliant {
+			entry.score++
+			entry.problems = append(entry.problems, fmt.Sprintf("%d unpinned", action.Pins.Floating))
+		}
+		if action.Outdated != nil && action.Outdated.Total > 0 {
+			entry.score += action.Outdated.Total
+			entry.problems = append(entry.problems, fmt.Sprintf("%d outdated", action.Outdated.Total))
+		}
+		if action.Validation.Status == ValidationFailed || action.Validation.Status == ValidationError {
+			entry.score++
+			entry.problems = append(entry.problems, "validation "+action.Validation.Status)
+		}
+		if entry.score > 0 {
+			offenders = append(offenders, entry)
+		}
+	}
+	slices.SortStableFunc(offenders, func(a, b offender) int {
+		return cmp.Or(cmp.Compare(b.score, a.score), strings.Compare(a.file, b.file))
+	})
+
+	lines := make([]string, 0, min(len(offenders), settings.top()))
+	for _, entry := range offenders[:min(len(offenders), settings.top())] {
+		lines = append(lines, entry.file+": "+strings.Join(entry.problems, ", "))
+	}
+
+	summary := report.Summary
+	text := fmt.Sprintf("No drift in %d action(s).", summary.Actions)
+	if len(offenders) > 0 {
+		text = fmt.Sprintf("%d of %d action(s) have drifted.", len(offenders), summary.Actions)
+	}
+
+	return Notification{
+		Title:   "Drift report",
+		Summary: text,
+		Facts: []NotificationFact{
+			{"Actions", summary.Actions},
+			{"Stale docs", summary.StaleDocs},
+			{"Unpinned", summary.NonCompliantPins},
+			{"Outdated dependencies", summary.OutdatedDeps},
+			{"Validation failures", summary.ValidationFailures},
+			{"Unowned", summary.Unowned},
+		},
+		Offenders: lines,
+		Link:      settings.link(),
+	}
+}
+
+// SendNotification posts notification to the Slack and Teams webhooks of
+// settings. It fails when no webhook is configured.
+func SendNotification(
+	ctx context.Context,
+	client *http.Client,
+	settings NotifySettings,
+	notification Notification,
+) error {
+	slack, teams := settings.Webhooks()
+	if slack == "" && teams == "" {
+		return fmt.Errorf("no webhook configured, set notify.slack_webhook or notify.teams_webhook, or %s or %s",
+			EnvSlackWebhook, EnvTeamsWebhook)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var errs []error
+	if slack != "" {
+		if err := postWebhook(ctx, client, slack, slackPayload(notification)); err != nil {
+			errs = append(errs, fmt.Errorf("slack: %w", err))
+		}
+	}
+	if teams != "" {
+		if err := postWebhook(ctx, client, teams, teamsPayload(notification)); err != nil {
+			errs = append(errs, fmt.Errorf("teams: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// slackPayload renders notification as a Slack message with mrkdwn text.
+func slackPayload(notification Notification) any {
+	var text strings.Builder
+	fmt.Fprintf(&text, "*%s*\n%s\n", notification.Title, notification.Summary)
+	facts := make([]string, 0, len(notification.Facts))
+	for _, fact := range notification.Facts {
+		facts = append(facts, fmt.Sprintf("%s: *%d*", fact.Name, fact.Value))
+	}
+	text.WriteString(strings.Join(facts, " · "))
+	for _, offender := range notification.Offenders {
+		text.WriteString("\n• " + offender)
+	}
+	if notification.Link != "" {
+		fmt.Fprintf(&text, "\n<%s|View details>", notification.Link)
+	}
+
+	return map[string]string{"text": text.String()}
+}
+
+// teamsPayload renders notification as a message with an Adaptive Card, which
+// both Teams incoming webhooks and Workflows webhooks accept.
+func teamsPayload(notification Notification) any {
+	facts := make([]map[string]string, 0, len(notification.Facts))
+	for _, fact := range notification.Facts {
+		facts = append(facts, map[string]string{"title": fact.Name, "value": fmt.Sprint(fact.Value)})
+	}
+	body := []map[string]any{
+		{"type": "TextBlock", "text": notification.Title, "weight": "Bolder", "size": "Medium"},
+		{"type": "TextBlock", "text": notification.Summary, "wrap": true},
+		{"type": "FactSet", "facts": facts},
+	}
+	if len(notification.Offenders) > 0 {
+		body = append(body, map[string]any{
+			"type": "TextBlock", "text": "- " + strings.Join(notification.Offenders, "\n- "), "wrap": true,
+		})
+	}
+	card := map[string]any{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if notification.Link != "" {
+		card["actions"] = []map[string]string{
+			{"type": "Action.OpenUrl", "title": "View details", "url": notification.Link},
+		}
+	}
+
+	return map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{
+			{"contentType": "application/vnd.microsoft.card.adaptive", "content": card},
+		},
+	}
+}
+
+// postWebhook posts payload as JSON and fails on responses other than 2xx.
+func postWebhook(ctx context.Context, client *http.Client, webhook string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err // The URL is a credential and is kept out of error messages
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// updateTypeRank orders update types from the most to the least disruptive.
+func updateTypeRank(updateType string) int {
+	if rank, ok := browserUpdateRanks[updateType]; ok {
+		return rank
+	}
+
+	return len(browserUpdateRanks)
+}
+
+// compareTrueFirst orders true before false.
+func compareTrueFirst(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return -1
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestOutdatedNotification(t *testing.T) {
+	t.Parallel()
+
+	outdated := []dependencies.OutdatedDependency{
+		{
+			Current:       dependencies.Dependency{Name: "actions/cache", Version: "v4.0.0"},
+			LatestVersion: "v4.0.1",
+			UpdateType:    "patch",
+		},
+		{
+			Current:       dependencies.Dependency{Name: "actions/setup-go", Version: "v4"},
+			LatestVersion: "v5",
+			UpdateType:    "major",
+		},
+		{
+			Current:          dependencies.Dependency{Name: "actions/checkout", Version: "v4.1.0"},
+			LatestVersion:    "v4.2.0",
+			UpdateType:       "minor",
+			IsSecurityUpdate: true,
+		},
+	}
+
+	notification := OutdatedNotification(NotifySettings{Top: 2, Link: "https://ci.example.com/run/1"}, outdated)
+	testutil.AssertEqual(t, "3 outdated dependencies found.", notification.Summary)
+	testutil.AssertEqual(t, NotificationFact{"Major", 1}, notification.Facts[1])
+	testutil.AssertEqual(t, NotificationFact{"Security", 1}, notification.Facts[4])
+	testutil.AssertEqual(t,
+		"actions/checkout v4.1.0 → v4.2.0 (minor, security)|actions/setup-go v4 → v5 (major)",
+		strings.Join(notification.Offenders, "|"))
+	testutil.AssertEqual(t, "https://ci.example.com/run/1", notification.Link)
+}
+
+func TestDriftNotification(t *testing.T) {
+	t.Parallel()
+
+	report := &DriftReport{
+		Summary: DriftSummary{Actions: 3, StaleDocs: 1, OutdatedDeps: 3},
+		Actions: []ActionDrift{
+			{
+				File: "a/action.yml", Docs: DocFreshness{Status: DocsFresh}, Pins: PinCompliance{Compliant: true},
+				Validation: ValidationStatus{Status: ValidationPassed},
+			},
+			{
+				File: "b/action.yml", Docs: DocFreshness{Status: DocsStale}, Pins: PinCompliance{Compliant: true},
+				Validation: ValidationStatus{Status: ValidationPassed},
+			},
+			{
+				File: "c/action.yml", Docs: DocFreshness{Status: DocsFresh}, Pins: PinCompliance{Floating: 2},
+				Outdated: &OutdatedSummary{Total: 3, Major: 3}, Validation: ValidationStatus{Status: ValidationPassed},
+			},
+		},
+	}
+
+	notification := DriftNotification(NotifySettings{}, report)
+	testutil.AssertEqual(t, "2 of 3 action(s) have drifted.", notification.Summary)
+	testutil.AssertEqual(t, "c/action.yml: 2 unpinned, 3 outdated|b/action.yml: docs stale",
+		strings.Join(notification.Offenders, "|"))
+}
+
+func TestSendNotification(t *testing.T) {
+	var slack, teams map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target := &slack
+		if r.URL.Path == "/teams" {
+			target = &teams
+		}
+		_ = json.NewDecoder(r.Body).Decode(target)
+	}))
+	defer server.Close()
+
+	t.Setenv(EnvSlackWebhook, "")
+	t.Setenv(EnvTeamsWebhook, server.URL+"/teams")
+	settings := NotifySettings{SlackWebhook: server.URL + "/slack"}
+	notification := Notification{
+		Title:     "Drift report",
+		Summary:   "1 of 2 action(s) have drifted.",
+		Facts:     []NotificationFact{{"Actions", 2}},
+		Offenders: []string{"b/action.yml: docs stale"},
+		Link:      "https://ci.example.com/run/1",
+	}
+
+	testutil.AssertNoError(t, SendNotification(context.Background(), server.Client(), settings, notification))
+	testutil.AssertStringContains(t, slack["text"].(string), "*Drift report*")
+	testutil.AssertStringContains(t, slack["text"].(string), "• b/action.yml: docs stale")
+	testutil.AssertStringContains(t, slack["text"].(string), "<https://ci.example.com/run/1|View details>")
+	testutil.AssertEqual(t, "message", teams["type"])
+	attachment := teams["attachments"].([]any)[0].(map[string]any)
+	testutil.AssertEqual(t, "application/vnd.microsoft.card.adaptive", attachment["contentType"])
+
+	t.Setenv(EnvTeamsWebhook, "")
+	err := SendNotification(context.Background(), server.Client(), NotifySettings{}, notification)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "no webhook configured")
+}
+
+func TestValidateNotifySettings(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, ValidateNotifySettings(NotifySettings{SlackWebhook: "https://hooks.slack.com/services/x"}))
+	testutil.AssertError(t, ValidateNotifySettings(NotifySettings{TeamsWebhook: "hooks.example.com"}))
+	testutil.AssertError(t, ValidateNotifySettings(NotifySettings{Top: -1}))
+}
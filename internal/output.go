@@ -2,7 +2,9 @@ package internal
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/fatih/color"
@@ -15,6 +17,9 @@ import (
 type ColoredOutput struct {
 	NoColor bool
 	Quiet   bool
+	// RecordDiagnostics records messages printed with Error and Warning in the
+	// diagnostics file, even in quiet mode.
+	RecordDiagnostics bool
 }
 
 // Compile-time interface checks.
@@ -54,22 +59,27 @@ func (co *ColoredOutput) Success(format string, args ...any) {
 
 // Error prints an error message in red to stderr.
 func (co *ColoredOutput) Error(format string, args ...any) {
-	if co.NoColor {
-		fmt.Fprintf(os.Stderr, "❌ "+format+"\n", args...)
-	} else {
-		_, _ = color.New(color.FgRed).Fprintf(os.Stderr, "❌ "+format+"\n", args...)
-	}
+	co.recordMessage(SeverityError, format, args...)
+	co.printError(format, args...)
 }
 
 // Warning prints a warning message in yellow.
 func (co *ColoredOutput) Warning(format string, args ...any) {
-	if co.Quiet {
-		return
-	}
-	if co.NoColor {
-		fmt.Printf("⚠️  "+format+"\n", args...)
-	} else {
-		color.Yellow("⚠️  "+format, args...)
+	co.recordMessage(SeverityWarning, format, args...)
+	co.printWarning(format, args...)
+}
+
+// Report prints a formatted message according to the severity of diagnostic,
+// like Error, Warning or Info, and records the diagnostic in the diagnostics file.
+func (co *ColoredOutput) Report(diagnostic Diagnostic, format string, args ...any) {
+	RecordDiagnostic(diagnostic)
+	switch diagnostic.Severity {
+	case SeverityError:
+		co.printError(format, args...)
+	case SeverityWarning:
+		co.printWarning(format, args...)
+	case SeverityInfo:
+		co.Info(format, args...)
 	}
 }
 
@@ -117,6 +127,14 @@ func (co *ColoredOutput) Printf(format string, args ...any) {
 	fmt.Printf(format, args...)
 }
 
+// Table prints a width-aligned table (respects quiet mode).
+func (co *ColoredOutput) Table(table *Table) {
+	if co.Quiet {
+		return
+	}
+	fmt.Print(table.String())
+}
+
 // Fprintf prints to specified writer without color formatting.
 func (co *ColoredOutput) Fprintf(w *os.File, format string, args ...any) {
 	_, _ = fmt.Fprintf(w, format, args...)
@@ -127,6 +145,7 @@ func (co *ColoredOutput) ErrorWithSuggestions(err *errors.ContextualError) {
 	if err == nil {
 		return
 	}
+	RecordDiagnostic(ContextualErrorDiagnostic(err))
 
 	// Print main error message
 	if co.NoColor {
@@ -213,7 +232,8 @@ func (co *ColoredOutput) formatDetailsSection(details map[string]string) []strin
 		parts = append(parts, color.New(color.Bold).Sprint("\nDetails:"))
 	}
 
-	for key, value := range details {
+	for _, key := range slices.Sorted(maps.Keys(details)) {
+		value := details[key]
 		if co.NoColor {
 			parts = append(parts, fmt.Sprintf("  %s: %s", key, value))
 		} else {
@@ -259,3 +279,32 @@ func (co *ColoredOutput) formatHelpURLSection(helpURL string) string {
 		color.New(color.Bold).Sprint("For more help"),
 		color.BlueString(helpURL))
 }
+
+// recordMessage records a printed error or warning in the diagnostics file when
+// RecordDiagnostics is set. Its source is the command of the run.
+func (co *ColoredOutput) recordMessage(severity Severity, format string, args ...any) {
+	if co.RecordDiagnostics {
+		RecordDiagnostic(Diagnostic{Severity: severity, Message: strings.TrimSpace(fmt.Sprintf(format, args...))})
+	}
+}
+
+// printError prints an error message in red to stderr.
+func (co *ColoredOutput) printError(format string, args ...any) {
+	if co.NoColor {
+		fmt.Fprintf(os.Stderr, "❌ "+format+"\n", args...)
+	} else {
+		_, _ = color.New(color.FgRed).Fprintf(os.Stderr, "❌ "+format+"\n", args...)
+	}
+}
+
+// printWarning prints a warning message in yellow.
+func (co *ColoredOutput) printWarning(format string, args ...any) {
+	if co.Quiet {
+		return
+	}
+	if co.NoColor {
+		fmt.Printf("⚠️  "+format+"\n", args...)
+	} else {
+		color.Yellow("⚠️  "+format, args...)
+	}
+}
@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// OutputBackupExtension is appended to the path of documentation replaced by
+// gen --backup.
+const OutputBackupExtension = ".backup"
+
+// ErrOutputChanged is returned when an output file changed on disk while its
+// documentation was generated, such as when it was saved in an editor.
+var ErrOutputChanged = errors.New("output file changed on disk during generation")
+
+// fileState identifies the content of a file by its size and modification time.
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// statOutput returns the state of the file at path.
+func statOutput(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+
+	return fileState{exists: true, size: info.Size(), modTime: info.ModTime()}
+}
+
+// snapshotOutput records the state of the main output of an action before its
+// documentation is generated, so that writeOutput can detect changes made to
+// it while hooks, plugins and templates run.
+func (g *Generator) snapshotOutput(action *ActionYML, actionPath string) {
+	path := filepath.Clean(g.outputPath(action, actionPath))
+	g.outputStates = map[string]fileState{path: statOutput(path)}
+}
+
+// writeOutput writes content to path unless the file already holds it. The
+// file is replaced atomically through a temporary file in the same directory.
+// It fails with ErrOutputChanged, leaving the file as it is, when the file
+// changed since the documentation of the action started being generated, or
+// since it was read here for outputs without a snapshot. With Backup the
+// replaced file is kept with OutputBackupExtension.
+func (g *Generator) writeOutput(kind, path string, content []byte) error {
+	expected, ok := g.outputStates[filepath.Clean(path)]
+	current := statOutput(path)
+	if !ok {
+		expected = current
+	}
+	if current != expected {
+		return fmt.Errorf("%w: %s; rerun to regenerate it", ErrOutputChanged, path)
+	}
+	if g.outputUnchanged(path, content) {
+		g.recordOutput(path)
+
+		return nil
+	}
+
+	// #nosec G301 -- output directory permissions
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	replace := func() error {
+		if statOutput(path) != expected {
+			return fmt.Errorf("%w: %s; rerun to regenerate it", ErrOutputChanged, path)
+		}
+		if g.Backup && expected.exists {
+			return backupOutput(path)
+		}
+
+		return nil
+	}
+	if err := writeFileAtomic(path, content, FilePermDefault, replace); err != nil {
+		return err
+	}
+	g.outputWritten(kind, path)
+	g.recordOutput(path)
+
+	return nil
+}
+
+// recordOutput records that the file at path holds generated output.
+func (g *Generator) recordOutput(path string) {
+	if g.outputFiles == nil {
+		g.outputFiles = make(map[string]bool)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	g.outputFiles[path] = true
+}
+
+// OutputFiles returns the files this generator wrote or found up to date, such
+// as documentation pages, stylesheets, logos and search indexes, sorted.
+func (g *Generator) OutputFiles() []string {
+	return slices.Sorted(maps.Keys(g.outputFiles))
+}
+
+// backupOutput copies the file at path to path with OutputBackupExtension.
+func backupOutput(path string) error {
+	existing, err := os.ReadFile(path) // #nosec G304 -- output path from configuration
+	if err != nil {
+		return fmt.Errorf("failed to read %s for backup: %w", path, err)
+	}
+	if err := os.WriteFile(path+OutputBackupExtension, existing, FilePermDefault); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// outputUnchanged reports whether the file at path already holds content, so
+// it does not need to be written again.
+func (g *Generator) outputUnchanged(path string, content []byte) bool {
+	existing, err := os.ReadFile(path) // #nosec G304 -- output path from configuration
+	if err != nil || !bytes.Equal(existing, content) {
+		return false
+	}
+	if g.writes != nil {
+		g.writes.unchanged++
+	}
+	if g.Config.Verbose {
+		g.Output.Info("Unchanged: %s", path)
+	}
+
+	return true
+}
+
+// writeFileAtomic writes data with perm to a temporary file next to path and
+// renames it over path once replace, when not nil, allows it, so readers see
+// either the previous or the new content, never a partial write. Symlinked
+// files replace their target.
+func writeFileAtomic(path string, data []byte, perm os.FileMode, replace func() error) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved // Replace the target of a symlinked output, not the link
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }() // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if replace != nil {
+		if err := replace(); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestGenerator_WriteOutputBackup(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	readmePath := filepath.Join(tmpDir, "README.md")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	testutil.WriteTestFile(t, readmePath, "# Hand-written\n")
+
+	config := DefaultAppConfig()
+	config.Theme = ThemeMinimal
+	config.Quiet = true
+	generator := NewGenerator(config)
+	generator.Backup = true
+	testutil.AssertNoError(t, generator.GenerateFromFile(actionPath))
+
+	backup, err := os.ReadFile(readmePath + OutputBackupExtension)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "# Hand-written\n", string(backup))
+	readme, err := os.ReadFile(readmePath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(readme), "Simple JavaScript Action")
+
+	// No temporary files are left next to the output
+	entries, err := os.ReadDir(tmpDir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 3, len(entries))
+}
+
+func TestGenerator_WriteOutputChangedOnDisk(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	readmePath := filepath.Join(tmpDir, "README.md")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	testutil.WriteTestFile(t, readmePath, "# Draft\n")
+
+	// The hook saves the README while its documentation is being generated
+	config := DefaultAppConfig()
+	config.Theme = ThemeMinimal
+	config.Quiet = true
+	config.Hooks = HookSettings{PreGen: []string{"echo 'Edited in an editor' >> README.md"}}
+	err := NewGenerator(config).GenerateFromFile(actionPath)
+	testutil.AssertError(t, err)
+	testutil.AssertEqual(t, true, errors.Is(err, ErrOutputChanged))
+
+	readme, err := os.ReadFile(readmePath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "# Draft\nEdited in an editor\n", string(readme))
+}
+
+func TestGenerator_OutputFiles(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+
+	config := DefaultAppConfig()
+	config.Theme = ThemeMinimal
+	config.Quiet = true
+	generator := NewGenerator(config)
+	testutil.AssertNoError(t, generator.GenerateFromFile(actionPath))
+	indexPath := filepath.Join(tmpDir, "search-index.json")
+	testutil.AssertNoError(t, generator.WriteSearchIndex([]string{actionPath}, indexPath))
+
+	// Files left unchanged by a later run are reported as well
+	again := NewGenerator(config)
+	testutil.AssertNoError(t, again.GenerateFromFile(actionPath))
+
+	want := filepath.Join(tmpDir, "README.md")
+	testutil.AssertEqual(t, want+","+indexPath, strings.Join(generator.OutputFiles(), ","))
+	testutil.AssertEqual(t, want, strings.Join(again.OutputFiles(), ","))
+}
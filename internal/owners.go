@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/codeowners"
+)
+
+// ActionOwners returns the maintainers of the action at actionPath: the
+// owners: list of its sidecar metadata when set, otherwise the owners the
+// CODEOWNERS file of the repository at repoRoot assigns to the action file.
+// Without a repoRoot only the sidecar metadata is used.
+func ActionOwners(actionPath, repoRoot string) ([]string, error) {
+	metadata, err := LoadActionMetadata(actionPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata.Owners) > 0 || repoRoot == "" {
+		return metadata.Owners, nil
+	}
+
+	absPath, err := filepath.Abs(actionPath)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(repoRoot, absPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, nil
+	}
+	file, err := codeowners.Load(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return file.Owners(filepath.ToSlash(rel)), nil
+}
+
+// OwnerURL returns the GitHub profile or team page of a CODEOWNERS owner
+// (@user or @org/team), a mailto link for an email address, or "" otherwise.
+func OwnerURL(owner string) string {
+	name, ok := strings.CutPrefix(owner, "@")
+	switch {
+	case !ok && strings.Contains(owner, "@"):
+		return "mailto:" + owner
+	case !ok || name == "":
+		return ""
+	}
+	if org, team, isTeam := strings.Cut(name, "/"); isTeam {
+		return "https://github.com/orgs/" + org + "/teams/" + team
+	}
+
+	return "https://github.com/" + name
+}
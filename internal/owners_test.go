@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestActionOwners(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, ".github", "CODEOWNERS"),
+		"* @acme/platform\n/build/ @acme/build\n")
+
+	build := filepath.Join(tmpDir, "build", "action.yml")
+	deploy := filepath.Join(tmpDir, "deploy", "action.yml")
+	for _, path := range []string{build, deploy} {
+		testutil.WriteTestFile(t, path, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	}
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "deploy", "action.meta.yml"), "owners: ['@alice']\n")
+
+	tests := []struct {
+		name       string
+		actionPath string
+		repoRoot   string
+		expected   string
+	}{
+		{"codeowners rule", build, tmpDir, "@acme/build"},
+		{"sidecar overrides codeowners", deploy, tmpDir, "@alice"},
+		{"no repository", build, "", ""},
+		{"action outside repository", build, filepath.Join(tmpDir, "deploy"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			owners, err := ActionOwners(tt.actionPath, tt.repoRoot)
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tt.expected, strings.Join(owners, " "))
+		})
+	}
+}
+
+func TestOwnerURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"@alice":          "https://github.com/alice",
+		"@acme/platform":  "https://github.com/orgs/acme/teams/platform",
+		"dev@example.com": "mailto:dev@example.com",
+		"@":               "",
+		"plain":           "",
+	}
+	for owner, expected := range tests {
+		testutil.AssertEqual(t, expected, OwnerURL(owner))
+	}
+}
@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
+)
+
+// maxParsedActions bounds the parsed action files kept in memory.
+const maxParsedActions = 1024
+
+// parsedKey identifies action file content parsed under a set of limits.
+type parsedKey struct {
+	sum      [sha256.Size]byte
+	limits   ResourceLimits
+	yamlSafe yamlsafe.Limits
+}
+
+// parsedActions keeps the action files parsed in this process by content, so
+// commands that generate, validate and analyze the same files parse each one
+// once. Keying by content keeps long-running commands such as serve correct
+// when files change.
+var parsedActions = struct {
+	sync.Mutex
+	actions map[parsedKey]*ActionYML
+}{actions: make(map[parsedKey]*ActionYML)}
+
+// cachedActionContent returns the action parsed from content earlier under the
+// current limits, if any. The copy shares its maps with the cached action, so
+// callers may replace fields but must not modify inputs, outputs or runs in place.
+func cachedActionContent(content []byte) (*ActionYML, parsedKey, bool) {
+	key := parsedKey{
+		sum:      sha256.Sum256(content),
+		limits:   CurrentResourceLimits(),
+		yamlSafe: yamlsafe.CurrentLimits(),
+	}
+	parsedActions.Lock()
+	defer parsedActions.Unlock()
+	cached, ok := parsedActions.actions[key]
+	if !ok {
+		return nil, key, false
+	}
+	action := *cached
+
+	return &action, key, true
+}
+
+// storeParsedAction remembers the action parsed for key and returns a copy for
+// the caller, so later changes to the caller's fields do not reach the cache.
+func storeParsedAction(key parsedKey, action *ActionYML) *ActionYML {
+	parsedActions.Lock()
+	defer parsedActions.Unlock()
+	if len(parsedActions.actions) >= maxParsedActions {
+		clear(parsedActions.actions)
+	}
+	parsedActions.actions[key] = action
+	result := *action
+
+	return &result
+}
+
+// clearParsedActions forgets the parsed action files and the documents
+// checked by yamlsafe, so the next parse of each file does the full work.
+func clearParsedActions() {
+	parsedActions.Lock()
+	clear(parsedActions.actions)
+	parsedActions.Unlock()
+	yamlsafe.ClearChecked()
+}
+
+// discoveryKey identifies a discovery of action files.
+type discoveryKey struct {
+	dir       string
+	recursive bool
+	patterns  string
+}
+
+// discoveryCache keeps the action files discovered by a generator, so commands
+// running several services discover each directory once.
+type discoveryCache struct {
+	mu         sync.Mutex
+	discovered map[discoveryKey][]DiscoveredAction
+}
+
+// discoverCached returns the actions in dir, discovered once per generator.
+// Generators created without NewGeneratorWithDependencies do not cache.
+func (g *Generator) discoverCached(dir string, recursive bool) ([]DiscoveredAction, error) {
+	if g.discovery == nil {
+		return DiscoverActions(dir, recursive)
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		absDir = dir
+	}
+	key := discoveryKey{dir: absDir, recursive: recursive, patterns: strings.Join(CurrentDiscoveryPatterns(), "\x00")}
+	g.discovery.mu.Lock()
+	defer g.discovery.mu.Unlock()
+	if discovered, ok := g.discovery.discovered[key]; ok {
+		return slices.Clone(discovered), nil
+	}
+
+	discovered, err := DiscoverActions(dir, recursive)
+	if err != nil {
+		return nil, err
+	}
+	g.discovery.discovered[key] = discovered
+
+	return slices.Clone(discovered), nil
+}
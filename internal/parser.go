@@ -2,34 +2,73 @@ package internal
 
 import (
 	"fmt"
+	"maps"
 	"os"
-	"path/filepath"
+	"slices"
 	"strings"
 
-	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
 )
 
 // ActionYML models the action.yml metadata (fields are updateable as schema evolves).
 type ActionYML struct {
 	Name        string                  `yaml:"name"`
+	Author      string                  `yaml:"author,omitempty"`
 	Description string                  `yaml:"description"`
 	Inputs      map[string]ActionInput  `yaml:"inputs"`
 	Outputs     map[string]ActionOutput `yaml:"outputs"`
 	Runs        map[string]any          `yaml:"runs"`
 	Branding    *Branding               `yaml:"branding,omitempty"`
 	// Add more fields as the schema evolves
+
+	// Declaration order of inputs and outputs as written in the source file
+	InputOrder  []string `yaml:"-"`
+	OutputOrder []string `yaml:"-"`
 }
 
 // ActionInput represents an input parameter for a GitHub Action.
 type ActionInput struct {
-	Description string `yaml:"description"`
-	Required    bool   `yaml:"required"`
-	Default     any    `yaml:"default"`
+	Description        string `yaml:"description"`
+	Required           bool   `yaml:"required"`
+	Default            any    `yaml:"default"`
+	DeprecationMessage string `yaml:"deprecationMessage,omitempty"`
 }
 
 // ActionOutput represents an output parameter for a GitHub Action.
 type ActionOutput struct {
 	Description string `yaml:"description"`
+	// Value maps the output to a step output; required for composite actions.
+	Value string `yaml:"value,omitempty"`
+}
+
+// NamedInput pairs an input with its name so templates can iterate inputs in a stable order.
+type NamedInput struct {
+	Name string
+	ActionInput
+}
+
+// NamedOutput pairs an output with its name so templates can iterate outputs in a stable order.
+type NamedOutput struct {
+	Name string
+	ActionOutput
+}
+
+// Lifecycle stages of node and docker actions.
+const (
+	LifecycleStagePre  = "pre"
+	LifecycleStageMain = "main"
+	LifecycleStagePost = "post"
+)
+
+// LifecycleHook is one stage of a node or docker action run: the optional pre
+// and post scripts and the main entrypoint, with the condition that gates them.
+type LifecycleHook struct {
+	Stage      string `json:"stage"`
+	Entrypoint string `json:"entrypoint"`
+	If         string `json:"if,omitempty"`
 }
 
 // Branding represents the branding configuration for a GitHub Action.
@@ -38,64 +77,258 @@ type Branding struct {
 	Color string `yaml:"color"`
 }
 
+// InputList returns the action inputs in declaration order.
+func (a *ActionYML) InputList() []NamedInput {
+	return a.SortedInputs(SortInputsDeclaration)
+}
+
+// SortedInputs returns the action inputs ordered by the given sort mode.
+// Unknown modes fall back to declaration order.
+func (a *ActionYML) SortedInputs(mode string) []NamedInput {
+	var names []string
+	if mode == SortInputsAlpha {
+		names = slices.Sorted(maps.Keys(a.Inputs))
+	} else {
+		names = orderedKeys(a.Inputs, a.InputOrder)
+	}
+
+	inputs := make([]NamedInput, 0, len(names))
+	for _, name := range names {
+		inputs = append(inputs, NamedInput{Name: name, ActionInput: a.Inputs[name]})
+	}
+
+	if mode == SortInputsRequiredFirst {
+		slices.SortStableFunc(inputs, func(x, y NamedInput) int {
+			switch {
+			case x.Required == y.Required:
+				return 0
+			case x.Required:
+				return -1
+			default:
+				return 1
+			}
+		})
+	}
+
+	return inputs
+}
+
+// OutputList returns the action outputs in declaration order.
+func (a *ActionYML) OutputList() []NamedOutput {
+	return a.SortedOutputs(SortInputsDeclaration)
+}
+
+// SortedOutputs returns the action outputs ordered by the given sort mode.
+// Outputs have no required flag, so required-first behaves like declaration order.
+func (a *ActionYML) SortedOutputs(mode string) []NamedOutput {
+	var names []string
+	if mode == SortInputsAlpha {
+		names = slices.Sorted(maps.Keys(a.Outputs))
+	} else {
+		names = orderedKeys(a.Outputs, a.OutputOrder)
+	}
+
+	outputs := make([]NamedOutput, 0, len(names))
+	for _, name := range names {
+		outputs = append(outputs, NamedOutput{Name: name, ActionOutput: a.Outputs[name]})
+	}
+
+	return outputs
+}
+
+// Lifecycle returns the stages of a node or docker action in execution order.
+// It returns nil when the action declares no pre or post hooks, since the
+// main entrypoint alone is already covered by the runs section.
+func (a *ActionYML) Lifecycle() []LifecycleHook {
+	preKey, mainKey, postKey := "pre", "main", "post"
+	if using, _ := a.Runs["using"].(string); strings.EqualFold(using, "docker") {
+		preKey, mainKey, postKey = "pre-entrypoint", "entrypoint", "post-entrypoint"
+	}
+
+	pre, _ := a.Runs[preKey].(string)
+	post, _ := a.Runs[postKey].(string)
+	if pre == "" && post == "" {
+		return nil
+	}
+
+	var hooks []LifecycleHook
+	if pre != "" {
+		condition, _ := a.Runs["pre-if"].(string)
+		hooks = append(hooks, LifecycleHook{Stage: LifecycleStagePre, Entrypoint: pre, If: condition})
+	}
+	if main, _ := a.Runs[mainKey].(string); main != "" {
+		hooks = append(hooks, LifecycleHook{Stage: LifecycleStageMain, Entrypoint: main})
+	}
+	if post != "" {
+		condition, _ := a.Runs["post-if"].(string)
+		hooks = append(hooks, LifecycleHook{Stage: LifecycleStagePost, Entrypoint: post, If: condition})
+	}
+
+	return hooks
+}
+
+// orderedKeys returns the keys of m following order, with any keys missing
+// from order appended alphabetically so the result is always deterministic.
+func orderedKeys[V any](m map[string]V, order []string) []string {
+	keys := make([]string, 0, len(m))
+	seen := make(map[string]bool, len(m))
+	for _, key := range order {
+		if _, ok := m[key]; ok && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
 // ParseActionYML reads and parses action.yml from given path.
 func ParseActionYML(path string) (*ActionYML, error) {
-	f, err := os.Open(path) // #nosec G304 -- path from function parameter
+	info, err := os.Stat(path)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		_ = f.Close() // Ignore close error in defer
-	}()
+	if limit := CurrentResourceLimits().MaxFileSize; info.Size() > int64(limit) {
+		return nil, fmt.Errorf("%w: %s is %d bytes, more than limits.max_file_size (%d)",
+			ErrResourceLimit, path, info.Size(), limit)
+	}
+
+	content, err := os.ReadFile(path) // #nosec G304 -- path from function parameter
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseActionYMLContent(content)
+}
+
+// ParseActionYMLContent parses action.yml content that was not read from disk,
+// such as a version retrieved from git history. Anchors, aliases and merge keys
+// are resolved; documents beyond the current resource limits are rejected.
+// Content parsed before in this process is not parsed again; the returned
+// action shares its maps with the cached one and must not be modified in place.
+func ParseActionYMLContent(content []byte) (*ActionYML, error) {
+	cached, key, ok := cachedActionContent(content)
+	if ok {
+		return cached, nil
+	}
+
 	var a ActionYML
-	dec := yaml.NewDecoder(f)
-	if err := dec.Decode(&a); err != nil {
+	if err := yamlsafe.Unmarshal(content, &a); err != nil {
+		return nil, err
+	}
+	if err := checkStepCount(&a, CurrentResourceLimits().MaxSteps); err != nil {
 		return nil, err
 	}
 
-	return &a, nil
+	// Read the declaration order from the syntax tree, following aliases and
+	// merge keys the same way as the decoding above.
+	file, err := parser.ParseBytes(content, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Docs) > 0 && file.Docs[0].Body != nil {
+		doc := declarationDocument{anchors: make(map[string]ast.Node)}
+		for _, node := range ast.Filter(ast.AnchorType, file.Docs[0].Body) {
+			anchor, _ := node.(*ast.AnchorNode)
+			doc.anchors[anchor.Name.GetToken().Value] = anchor.Value
+		}
+		a.InputOrder = doc.keys(doc.section(file.Docs[0].Body, "inputs"))
+		a.OutputOrder = doc.keys(doc.section(file.Docs[0].Body, "outputs"))
+	}
+
+	return storeParsedAction(key, &a), nil
 }
 
-// DiscoverActionFiles finds action.yml and action.yaml files in the given directory.
-// This consolidates the file discovery logic from both generator.go and dependencies/parser.go.
-func DiscoverActionFiles(dir string, recursive bool) ([]string, error) {
-	var actionFiles []string
+// declarationDocument resolves the anchors of a parsed action document.
+type declarationDocument struct {
+	anchors map[string]ast.Node
+}
 
-	// Check if dir exists
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		return nil, fmt.Errorf("directory does not exist: %s", dir)
+// resolve returns the mapping or value behind anchors, aliases and tags.
+func (d declarationDocument) resolve(node ast.Node) ast.Node {
+	for {
+		switch n := node.(type) {
+		case *ast.AnchorNode:
+			node = n.Value
+		case *ast.AliasNode:
+			node = d.anchors[n.Value.GetToken().Value]
+		case *ast.TagNode:
+			node = n.Value
+		case *ast.MappingValueNode:
+			return &ast.MappingNode{Values: []*ast.MappingValueNode{n}}
+		default:
+			return node
+		}
 	}
+}
 
-	if recursive {
-		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
+// section returns the value stored under key in a mapping, or nil.
+func (d declarationDocument) section(node ast.Node, key string) ast.Node {
+	mapping, ok := d.resolve(node).(*ast.MappingNode)
+	if !ok {
+		return nil
+	}
+	for _, value := range mapping.Values {
+		if value.Key.GetToken().Value == key {
+			return value.Value
+		}
+	}
 
-			if info.IsDir() {
-				return nil
-			}
+	return nil
+}
 
-			// Check for action.yml or action.yaml files
-			filename := strings.ToLower(info.Name())
-			if filename == "action.yml" || filename == "action.yaml" {
-				actionFiles = append(actionFiles, path)
-			}
+// keys returns the keys of a mapping in declaration order, with merged keys
+// at the position of their merge key and each key listed once.
+func (d declarationDocument) keys(node ast.Node) []string {
+	mapping, ok := d.resolve(node).(*ast.MappingNode)
+	if !ok {
+		return nil
+	}
 
-			return nil
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to walk directory %s: %w", dir, err)
+	var keys []string
+	for _, value := range mapping.Values {
+		if _, merge := value.Key.(*ast.MergeKeyNode); !merge {
+			keys = append(keys, value.Key.GetToken().Value)
+
+			continue
 		}
-	} else {
-		// Check only the specified directory
-		for _, filename := range []string{"action.yml", "action.yaml"} {
-			path := filepath.Join(dir, filename)
-			if _, err := os.Stat(path); err == nil {
-				actionFiles = append(actionFiles, path)
-			}
+		merged := []ast.Node{value.Value}
+		if sequence, ok := d.resolve(value.Value).(*ast.SequenceNode); ok {
+			merged = sequence.Values
+		}
+		for _, source := range merged {
+			keys = append(keys, d.keys(source)...)
 		}
 	}
 
+	seen := make(map[string]bool, len(keys))
+
+	return slices.DeleteFunc(keys, func(key string) bool {
+		duplicate := seen[key]
+		seen[key] = true
+
+		return duplicate
+	})
+}
+
+// DiscoverActionFiles finds the action files in the given directory that match the
+// discovery patterns, action.yml and action.yaml by default (see DiscoverActions).
+// This consolidates the file discovery logic from both generator.go and dependencies/parser.go.
+func DiscoverActionFiles(dir string, recursive bool) ([]string, error) {
+	discovered, err := DiscoverActions(dir, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	actionFiles := make([]string, 0, len(discovered))
+	for _, action := range discovered {
+		actionFiles = append(actionFiles, action.Path)
+	}
+
 	return actionFiles, nil
 }
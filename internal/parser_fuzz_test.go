@@ -0,0 +1,32 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// FuzzParseActionYML parses arbitrary action.yml content, seeded with the
+// YAML fixtures. ParseActionYML reads the file and parses it the same way.
+func FuzzParseActionYML(f *testing.F) {
+	for _, name := range testutil.FixtureNames() {
+		f.Add([]byte(testutil.MustReadFixture(name)))
+	}
+	f.Add([]byte("name: a\nruns:\n  using: composite\n  steps:\n    - uses: &u actions/checkout@v4\n    - uses: *u\n"))
+	f.Add([]byte("inputs:\n  \"\\u00e9\\u200b\": {default: [1, {a: b}]}\n<<: {name: merged}\n"))
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		action, err := ParseActionYMLContent(content)
+		if err != nil {
+			return
+		}
+		if action == nil {
+			t.Fatal("expected an action without an error")
+		}
+		for _, name := range action.InputOrder {
+			if _, ok := action.Inputs[name]; !ok && action.Inputs != nil {
+				t.Errorf("input order lists %q, which is not an input", name)
+			}
+		}
+	})
+}
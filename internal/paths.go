@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/adrg/xdg"
+
+	"github.com/ivuorinen/gh-action-readme/internal/cache"
+	"github.com/ivuorinen/gh-action-readme/templates_embed"
+)
+
+// Template sources reported by Paths.
+const (
+	TemplatesSourceEmbedded   = "embedded"
+	TemplatesSourceFilesystem = "filesystem"
+)
+
+// PathsEnvPrefix prefixes the variables printed by Paths.ShellExports. The names
+// do not match configuration keys, so exporting them does not change settings.
+const PathsEnvPrefix = "GH_ACTION_README_"
+
+// Paths are the resolved locations the tool reads and writes, for the env command.
+type Paths struct {
+	ConfigFile       string `json:"config_file"`
+	ConfigFileExists bool   `json:"config_file_exists"`
+	CacheDir         string `json:"cache_dir"`
+	TemplatesSource  string `json:"templates_source"` // embedded or filesystem
+	TemplatePath     string `json:"template_path"`
+	DataDir          string `json:"data_dir"`
+}
+
+// ResolvePaths resolves the paths used with configFile, the value of the
+// --config flag, and the loaded configuration.
+func ResolvePaths(configFile string, config *AppConfig) (Paths, error) {
+	var paths Paths
+	var err error
+
+	paths.ConfigFile, paths.ConfigFileExists, err = GlobalConfigFile(configFile)
+	if err != nil {
+		return Paths{}, err
+	}
+	if paths.ConfigFile != "" {
+		paths.ConfigFile, _ = filepath.Abs(paths.ConfigFile)
+	}
+	if paths.CacheDir, err = cache.DefaultDir(); err != nil {
+		return Paths{}, fmt.Errorf("failed to resolve cache directory: %w", err)
+	}
+	paths.DataDir = filepath.Join(xdg.DataHome, "gh-action-readme")
+
+	// Same choice as the generator: the theme wins over an explicit template.
+	paths.TemplatePath = config.Template
+	if config.Theme != "" {
+		paths.TemplatePath = resolveThemeTemplate(config.Theme)
+	}
+	paths.TemplatesSource = TemplatesSourceFilesystem
+	if !filepath.IsAbs(paths.TemplatePath) && templates_embed.IsEmbeddedTemplateAvailable(paths.TemplatePath) {
+		paths.TemplatesSource = TemplatesSourceEmbedded
+	}
+
+	return paths, nil
+}
+
+// ShellExports returns the paths as POSIX shell export statements, one per line,
+// for use with eval "$(gh-action-readme env)".
+func (p Paths) ShellExports() string {
+	var b strings.Builder
+	for _, variable := range p.Variables() {
+		fmt.Fprintf(&b, "export %s=%s\n", variable[0], shellQuote(variable[1]))
+	}
+
+	return b.String()
+}
+
+// Variables returns the paths as environment variable name and value pairs.
+func (p Paths) Variables() [][2]string {
+	return [][2]string{
+		{PathsEnvPrefix + "CONFIG_FILE", p.ConfigFile},
+		{PathsEnvPrefix + "CONFIG_FILE_EXISTS", strconv.FormatBool(p.ConfigFileExists)},
+		{PathsEnvPrefix + "CACHE_DIR", p.CacheDir},
+		{PathsEnvPrefix + "TEMPLATES_SOURCE", p.TemplatesSource},
+		{PathsEnvPrefix + "TEMPLATE_PATH", p.TemplatePath},
+		{PathsEnvPrefix + "DATA_DIR", p.DataDir},
+	}
+}
+
+// shellQuote quotes value for POSIX shells.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
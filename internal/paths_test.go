@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestResolvePaths(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	testutil.WriteTestFile(t, configPath, "theme: github\n")
+
+	paths, err := ResolvePaths(configPath, &AppConfig{Theme: ThemeGitHub})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, configPath, paths.ConfigFile)
+	testutil.AssertEqual(t, true, paths.ConfigFileExists)
+	testutil.AssertEqual(t, TemplatePathGitHub, paths.TemplatePath)
+	testutil.AssertEqual(t, TemplatesSourceEmbedded, paths.TemplatesSource)
+	testutil.AssertEqual(t, "gh-action-readme", filepath.Base(paths.DataDir))
+
+	custom := filepath.Join(tmpDir, "custom.tmpl")
+	paths, err = ResolvePaths(filepath.Join(tmpDir, "missing.yaml"), &AppConfig{Template: custom})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, false, paths.ConfigFileExists)
+	testutil.AssertEqual(t, custom, paths.TemplatePath)
+	testutil.AssertEqual(t, TemplatesSourceFilesystem, paths.TemplatesSource)
+}
+
+func TestPaths_ShellExports(t *testing.T) {
+	t.Parallel()
+
+	paths := Paths{
+		ConfigFile:      "/home/o'brien/.config/gh-action-readme/config.yaml",
+		CacheDir:        "/tmp/cache dir",
+		TemplatesSource: TemplatesSourceEmbedded,
+	}
+	exports := paths.ShellExports()
+
+	testutil.AssertStringContains(t, exports,
+		`export GH_ACTION_README_CONFIG_FILE='/home/o'\''brien/.config/gh-action-readme/config.yaml'`)
+	testutil.AssertStringContains(t, exports, "export GH_ACTION_README_CONFIG_FILE_EXISTS='false'\n")
+	testutil.AssertStringContains(t, exports, "export GH_ACTION_README_CACHE_DIR='/tmp/cache dir'\n")
+	testutil.AssertStringContains(t, exports, "export GH_ACTION_README_DATA_DIR=''\n")
+}
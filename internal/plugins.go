@@ -0,0 +1,269 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Stages of generation that plugins run at.
+const (
+	// PluginStagePreRender runs before an action's documentation is rendered
+	// and may add template variables.
+	PluginStagePreRender = "pre_render"
+	// PluginStagePostRender runs after each generated file is written.
+	PluginStagePostRender = "post_render"
+)
+
+// PluginPayloadVersion is the version of the payload plugins read from stdin.
+const PluginPayloadVersion = 1
+
+// DefaultPluginTimeout is how long a plugin may run when it sets no timeout.
+const DefaultPluginTimeout = "30s"
+
+// maxPluginResponse bounds the bytes read from the stdout of a plugin.
+const maxPluginResponse = 1 << 20
+
+// maxPluginStderr bounds the bytes read from the stderr of a plugin.
+const maxPluginStderr = 64 << 10
+
+// pluginWaitDelay bounds how long a timed out plugin's children may keep its output open.
+const pluginWaitDelay = time.Second
+
+// pluginStages are the valid stages of a plugin.
+var pluginStages = []string{PluginStagePreRender, PluginStagePostRender}
+
+// PluginConfig declares a command run at stages of generation, which reads a
+// PluginPayload as JSON on stdin.
+type PluginConfig struct {
+	Name    string   `mapstructure:"name"    yaml:"name"`
+	Command []string `mapstructure:"command" yaml:"command"`           // Program and arguments, run without a shell
+	Stages  []string `mapstructure:"stages"  yaml:"stages"`            // pre_render and/or post_render
+	Timeout string   `mapstructure:"timeout" yaml:"timeout,omitempty"` // Such as "10s"; defaults to 30s
+}
+
+// PluginPayload is the JSON document a plugin reads from stdin.
+type PluginPayload struct {
+	Version    int               `json:"version"`
+	Stage      string            `json:"stage"`
+	ActionPath string            `json:"action_path"`
+	OutputDir  string            `json:"output_dir"`
+	OutputPath string            `json:"output_path,omitempty"` // post_render: the file written
+	Format     string            `json:"format"`
+	Action     json.RawMessage   `json:"action"` // Same document as --output-format json
+	Variables  map[string]string `json:"variables"`
+	Content    string            `json:"content,omitempty"` // post_render: the content written
+}
+
+// PluginResponse is the JSON document a pre_render plugin may print on stdout.
+type PluginResponse struct {
+	Variables map[string]string `json:"variables,omitempty"` // Added to the template variables
+}
+
+// ValidatePlugins rejects plugins without a unique name or a command, with
+// unknown stages, or with an invalid timeout.
+func ValidatePlugins(plugins []PluginConfig) error {
+	seen := map[string]bool{}
+	for _, plugin := range plugins {
+		switch {
+		case plugin.Name == "":
+			return errors.New("plugins need a name")
+		case seen[plugin.Name]:
+			return fmt.Errorf("plugin '%s' is declared twice", plugin.Name)
+		case len(plugin.Command) == 0 || plugin.Command[0] == "":
+			return fmt.Errorf("plugin '%s' needs a command", plugin.Name)
+		case len(plugin.Stages) == 0:
+			return fmt.Errorf("plugin '%s' needs stages, one or more of: %s",
+				plugin.Name, strings.Join(pluginStages, ", "))
+		}
+		for _, stage := range plugin.Stages {
+			if !containsString(pluginStages, stage) {
+				return fmt.Errorf("invalid stage '%s' of plugin '%s', must be one of: %s",
+					stage, plugin.Name, strings.Join(pluginStages, ", "))
+			}
+		}
+		if plugin.Timeout != "" {
+			if timeout, err := time.ParseDuration(plugin.Timeout); err != nil || timeout <= 0 {
+				return fmt.Errorf("invalid timeout '%s' of plugin '%s', must be a positive duration such as 10s",
+					plugin.Timeout, plugin.Name)
+			}
+		}
+		seen[plugin.Name] = true
+	}
+
+	return nil
+}
+
+// TimeoutDuration returns the plugin timeout, or the default for an empty or
+// invalid timeout.
+func (p PluginConfig) TimeoutDuration() time.Duration {
+	if timeout, err := time.ParseDuration(p.Timeout); err == nil && timeout > 0 {
+		return timeout
+	}
+	timeout, _ := time.ParseDuration(DefaultPluginTimeout)
+
+	return timeout
+}
+
+// RunPlugin runs plugin in dir with payload on stdin and returns its response.
+// A plugin printing nothing returns an empty response.
+func RunPlugin(plugin PluginConfig, dir string, payload *PluginPayload) (*PluginResponse, error) {
+	input, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), plugin.TimeoutDuration())
+	defer cancel()
+	// #nosec G204 -- plugins are configured in the global configuration only
+	cmd := exec.CommandContext(ctx, plugin.Command[0], plugin.Command[1:]...)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.WaitDelay = pluginWaitDelay
+	stdout := &cappedBuffer{limit: maxPluginResponse}
+	stderr := &cappedBuffer{limit: maxPluginStderr}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+
+	err = cmd.Run()
+	switch {
+	case ctx.Err() != nil:
+		return nil, fmt.Errorf("plugin %s timed out after %s", plugin.Name, plugin.TimeoutDuration())
+	case err != nil:
+		return nil, fmt.Errorf("plugin %s failed at %s: %w%s", plugin.Name, payload.Stage, err, stderrSuffix(stderr))
+	}
+
+	response := &PluginResponse{}
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return response, nil
+	}
+	if err := json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("plugin %s printed an invalid response: %w", plugin.Name, err)
+	}
+
+	return response, nil
+}
+
+// stderrSuffix returns the stderr of a failed plugin to append to its error.
+func stderrSuffix(stderr *cappedBuffer) string {
+	if text := strings.TrimSpace(string(stderr.Bytes())); text != "" {
+		return ": " + text
+	}
+
+	return ""
+}
+
+// pluginsAt returns the configured plugins running at stage, in order.
+func (g *Generator) pluginsAt(stage string) []PluginConfig {
+	var plugins []PluginConfig
+	for _, plugin := range g.Config.Plugins {
+		if slices.Contains(plugin.Stages, stage) {
+			plugins = append(plugins, plugin)
+		}
+	}
+
+	return plugins
+}
+
+// pluginPayload returns the payload of the plugins of an action at stage.
+func (g *Generator) pluginPayload(
+	stage string,
+	action *ActionYML,
+	actionPath, outputDir string,
+) (*PluginPayload, error) {
+	document, err := NewJSONWriter(g.Config).Marshal(action)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode action for plugins: %w", err)
+	}
+
+	return &PluginPayload{
+		Version:    PluginPayloadVersion,
+		Stage:      stage,
+		ActionPath: actionPath,
+		OutputDir:  outputDir,
+		Format:     g.Config.OutputFormat,
+		Action:     document,
+		Variables:  maps.Clone(g.Config.Variables),
+	}, nil
+}
+
+// runPreRenderPlugins runs the pre_render plugins of an action and returns a
+// generator whose template variables include the variables they returned, or
+// g itself when no plugin runs at pre_render.
+func (g *Generator) runPreRenderPlugins(action *ActionYML, actionPath, outputDir string) (*Generator, error) {
+	plugins := g.pluginsAt(PluginStagePreRender)
+	if len(plugins) == 0 {
+		return g, nil
+	}
+
+	config := *g.Config
+	config.Variables = maps.Clone(g.Config.Variables)
+	if config.Variables == nil {
+		config.Variables = map[string]string{}
+	}
+	enriched := *g
+	enriched.Config = &config
+	for _, plugin := range plugins {
+		// Each plugin sees the variables added by the plugins before it
+		payload, err := enriched.pluginPayload(PluginStagePreRender, action, actionPath, outputDir)
+		if err != nil {
+			return nil, err
+		}
+		response, err := RunPlugin(plugin, filepath.Dir(actionPath), payload)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(config.Variables, response.Variables)
+	}
+
+	return &enriched, nil
+}
+
+// runPostRenderPlugins runs the post_render plugins after content was written
+// to outputPath. Nothing runs in check mode, where no file is written.
+func (g *Generator) runPostRenderPlugins(action *ActionYML, actionPath, outputPath, content string) error {
+	plugins := g.pluginsAt(PluginStagePostRender)
+	if len(plugins) == 0 || g.Check {
+		return nil
+	}
+
+	payload, err := g.pluginPayload(PluginStagePostRender, action, actionPath, filepath.Dir(outputPath))
+	if err != nil {
+		return err
+	}
+	payload.OutputPath, payload.Content = outputPath, content
+	for _, plugin := range plugins {
+		if _, err := RunPlugin(plugin, filepath.Dir(actionPath), payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cappedBuffer collects up to limit bytes and fails writes beyond it. It only
+// implements io.Writer, so io.Copy cannot bypass the limit with ReadFrom.
+type cappedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.buf.Len()+len(p) > b.limit {
+		return 0, fmt.Errorf("%w: plugin output is larger than %d bytes", ErrResourceLimit, b.limit)
+	}
+
+	return b.buf.Write(p)
+}
+
+// Bytes returns the bytes collected.
+func (b *cappedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
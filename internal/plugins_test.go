@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestValidatePlugins(t *testing.T) {
+	t.Parallel()
+
+	valid := PluginConfig{Name: "enrich", Command: []string{"./enrich"}, Stages: []string{PluginStagePreRender}}
+	testutil.AssertNoError(t, ValidatePlugins(nil))
+	testutil.AssertNoError(t, ValidatePlugins([]PluginConfig{valid}))
+
+	for _, invalid := range []PluginConfig{
+		{Command: []string{"./enrich"}, Stages: []string{PluginStagePreRender}},
+		{Name: "enrich", Stages: []string{PluginStagePreRender}},
+		{Name: "enrich", Command: []string{"./enrich"}},
+		{Name: "enrich", Command: []string{"./enrich"}, Stages: []string{"render"}},
+		{Name: "enrich", Command: []string{"./enrich"}, Stages: []string{PluginStagePreRender}, Timeout: "soon"},
+	} {
+		if err := ValidatePlugins([]PluginConfig{invalid}); err == nil {
+			t.Errorf("ValidatePlugins() accepted %+v", invalid)
+		}
+	}
+	if err := ValidatePlugins([]PluginConfig{valid, valid}); err == nil {
+		t.Error("ValidatePlugins() accepted duplicate plugin names")
+	}
+}
+
+func TestMergeConfigs_PluginsGlobalOnly(t *testing.T) {
+	t.Parallel()
+
+	src := &AppConfig{Plugins: []PluginConfig{{Name: "upload", Command: []string{"./upload"}}}}
+	repo := &AppConfig{}
+	MergeConfigs(repo, src, false)
+	testutil.AssertEqual(t, 0, len(repo.Plugins))
+
+	global := &AppConfig{}
+	MergeConfigs(global, src, true)
+	testutil.AssertEqual(t, "upload", global.Plugins[0].Name)
+}
+
+func TestGenerator_Plugins(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	templatePath := filepath.Join(tmpDir, "theme", "readme.tmpl")
+	testutil.WriteTestFile(t, templatePath, `# {{.Name}} owned by {{index .Config.Variables "owner"}}`+"\n")
+
+	config := DefaultAppConfig()
+	config.Theme = ""
+	config.Template = templatePath
+	config.OutputDir = filepath.Join(tmpDir, "docs")
+	config.Quiet = true
+	config.Plugins = []PluginConfig{
+		{
+			Name:    "enrich",
+			Command: []string{"sh", "-c", `cat > /dev/null; echo '{"variables": {"owner": "platform"}}'`},
+			Stages:  []string{PluginStagePreRender},
+		},
+		{
+			Name:    "record",
+			Command: []string{"sh", "-c", "cat > payload.json"},
+			Stages:  []string{PluginStagePostRender},
+		},
+	}
+	testutil.AssertNoError(t, NewGenerator(config).GenerateFromFile(actionPath))
+
+	readme, err := os.ReadFile(filepath.Join(tmpDir, "docs", "README.md"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "# Simple JavaScript Action owned by platform\n", string(readme))
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "payload.json"))
+	testutil.AssertNoError(t, err)
+	var payload PluginPayload
+	testutil.AssertNoError(t, json.Unmarshal(content, &payload))
+	testutil.AssertEqual(t, PluginPayloadVersion, payload.Version)
+	testutil.AssertEqual(t, PluginStagePostRender, payload.Stage)
+	testutil.AssertEqual(t, filepath.Join(tmpDir, "docs", "README.md"), payload.OutputPath)
+	testutil.AssertEqual(t, string(readme), payload.Content)
+	testutil.AssertEqual(t, "platform", payload.Variables["owner"])
+	testutil.AssertStringContains(t, string(payload.Action), `"name":"Simple JavaScript Action"`)
+
+	config.Plugins = []PluginConfig{{
+		Name:    "broken",
+		Command: []string{"sh", "-c", "echo 'no metadata service' >&2; exit 3"},
+		Stages:  []string{PluginStagePreRender},
+	}}
+	err = NewGenerator(config).GenerateFromFile(actionPath)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "plugin broken failed at pre_render")
+	testutil.AssertStringContains(t, err.Error(), "no metadata service")
+
+	config.Plugins = []PluginConfig{{
+		Name:    "slow",
+		Command: []string{"sleep", "5"},
+		Stages:  []string{PluginStagePreRender},
+		Timeout: "50ms",
+	}}
+	err = NewGenerator(config).GenerateFromFile(actionPath)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "plugin slow timed out")
+
+	// A child process keeping stdout open does not hold up a timed out plugin
+	config.Plugins[0].Command = []string{"sh", "-c", "sleep 30 & sleep 30"}
+	start := time.Now()
+	err = NewGenerator(config).GenerateFromFile(actionPath)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "plugin slow timed out")
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("timed out plugin returned after %s", elapsed)
+	}
+
+	config.Plugins = []PluginConfig{{
+		Name:    "chatty",
+		Command: []string{"sh", "-c", "head -c 1000000 /dev/zero | tr '\\0' x >&2"},
+		Stages:  []string{PluginStagePreRender},
+	}}
+	err = NewGenerator(config).GenerateFromFile(actionPath)
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "plugin chatty failed at pre_render")
+	if len(err.Error()) > 2*maxPluginStderr {
+		t.Errorf("error holds %d bytes of stderr", len(err.Error()))
+	}
+}
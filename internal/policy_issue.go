@@ -0,0 +1,219 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+)
+
+// Defaults of deps policy check --create-issues.
+const (
+	// DefaultPolicyIssueLabel identifies the policy issue of a repository, so
+	// later runs update it instead of opening another one.
+	DefaultPolicyIssueLabel = "dependency-policy"
+	// DefaultPolicyIssueTitle is the title of the policy issue.
+	DefaultPolicyIssueTitle = "Dependency policy violations"
+)
+
+// Results of SyncPolicyIssue.
+const (
+	PolicyIssueCreated   = "created"
+	PolicyIssueUpdated   = "updated"
+	PolicyIssueUnchanged = "unchanged"
+	PolicyIssueClosed    = "closed"
+	PolicyIssueNone      = "none"
+)
+
+// PolicyAction holds the analyzed dependencies of an action file. Outdated is
+// nil when updates were not checked and Disallowed is nil when no allow-list
+// is configured.
+type PolicyAction struct {
+	File         string
+	Dependencies []dependencies.Dependency
+	Outdated     []dependencies.OutdatedDependency
+	Disallowed   []dependencies.Dependency // Dependencies missing from deps.allowlist
+}
+
+// PolicyFinding is a dependency that violates the dependency policy.
+type PolicyFinding struct {
+	File       string `json:"file"`
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Latest     string `json:"latest,omitempty"`
+	UpdateType string `json:"update_type,omitempty"`
+}
+
+// PolicyReport lists the dependency policy violations of a repository's
+// actions. It is the data of the policy issue template.
+type PolicyReport struct {
+	Organization     string          `json:"organization,omitempty"`
+	Repository       string          `json:"repository,omitempty"`
+	Actions          int             `json:"actions"`
+	Total            int             `json:"total"`
+	OutdatedChecked  bool            `json:"outdated_checked"`
+	Unpinned         []PolicyFinding `json:"unpinned"`
+	Outdated         []PolicyFinding `json:"outdated"`
+	Security         []PolicyFinding `json:"security"` // Potential security updates
+	Latest           []PolicyFinding `json:"latest"`   // docker:// images on the latest tag
+	AllowlistChecked bool            `json:"allowlist_checked"`
+	Disallowed       []PolicyFinding `json:"disallowed"` // Dependencies missing from deps.allowlist
+	FailOn           []string        `json:"fail_on,omitempty"`
+	Error            string          `json:"error,omitempty"` // Policy check failure
+}
+
+// Evaluate evaluates the policy against the dependencies of actions:
+// unpinned dependencies, available updates, potential security updates and
+// docker:// images on the latest tag and dependencies missing from the
+// allow-list. Error is set when a deps.fail_on rule fails or a dependency is
+// not on the allow-list.
+func (p DepsPolicy) Evaluate(actions []PolicyAction) *PolicyReport {
+	report := &PolicyReport{
+		Actions:    len(actions),
+		FailOn:     p.FailOn,
+		Unpinned:   []PolicyFinding{},
+		Outdated:   []PolicyFinding{},
+		Security:   []PolicyFinding{},
+		Latest:     []PolicyFinding{},
+		Disallowed: []PolicyFinding{},
+	}
+
+	var all []dependencies.Dependency
+	var allOutdated []dependencies.OutdatedDependency
+	for _, action := range actions {
+		for _, dep := range action.Dependencies {
+			finding := PolicyFinding{File: action.File, Name: dep.Name, Version: dep.Version}
+			if !dep.IsPinned {
+				report.Unpinned = append(report.Unpinned, finding)
+			}
+			if dep.Docker != nil && dep.Docker.IsLatest() {
+				report.Latest = append(report.Latest, finding)
+			}
+		}
+		for _, outdated := range action.Outdated {
+			if outdated.UpdateType == "" || outdated.UpdateType == "none" {
+				continue
+			}
+			finding := PolicyFinding{
+				File:       action.File,
+				Name:       outdated.Current.Name,
+				Version:    outdated.Current.Version,
+				Latest:     outdated.LatestVersion,
+				UpdateType: outdated.UpdateType,
+			}
+			report.Outdated = append(report.Outdated, finding)
+			if outdated.IsSecurityUpdate {
+				report.Security = append(report.Security, finding)
+			}
+		}
+		for _, dep := range action.Disallowed {
+			finding := PolicyFinding{File: action.File, Name: dep.Name, Version: dep.Version}
+			report.Disallowed = append(report.Disallowed, finding)
+		}
+		if action.Outdated != nil {
+			report.OutdatedChecked = true
+		}
+		if action.Disallowed != nil {
+			report.AllowlistChecked = true
+		}
+		all = append(all, action.Dependencies...)
+		allOutdated = append(allOutdated, action.Outdated...)
+	}
+	report.Total = len(report.Unpinned) + len(report.Outdated) + len(report.Latest) + len(report.Disallowed)
+
+	if err := errors.Join(
+		p.CheckSecurity(len(report.Unpinned)),
+		p.CheckLatest(all),
+		p.CheckOutdated(allOutdated),
+		checkAllowlist(len(report.Disallowed)),
+	); err != nil {
+		report.Error = err.Error()
+	}
+
+	return report
+}
+
+// checkAllowlist returns ErrDepsPolicy when dependencies are missing from the allow-list.
+func checkAllowlist(disallowed int) error {
+	if disallowed > 0 {
+		return fmt.Errorf("%w: %d dependencies not on the allow-list (deps.allowlist)", ErrDepsPolicy, disallowed)
+	}
+
+	return nil
+}
+
+// RenderPolicyIssue renders the body of the policy issue with the policy
+// issue template, or templatePath when it is not empty.
+func RenderPolicyIssue(report *PolicyReport, templatePath string) (string, error) {
+	if templatePath == "" {
+		templatePath = TemplatePathPolicyIssue
+	}
+
+	body, err := RenderReadme(report, TemplateOptions{TemplatePath: templatePath, Format: OutputFormatMD})
+	if err != nil {
+		return "", fmt.Errorf("failed to render policy issue: %w", err)
+	}
+
+	return body, nil
+}
+
+// SyncPolicyIssue keeps one open issue labelled label in owner/repo in sync
+// with the policy report: it opens the issue when there are violations,
+// updates its title and body on later runs and closes it once the violations
+// are resolved. It returns the result and the URL of the issue, if any.
+func SyncPolicyIssue(
+	ctx context.Context,
+	client *github.Client,
+	owner, repo, label, title, body string,
+	violations int,
+) (string, string, error) {
+	issues, _, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+		State:  "open",
+		Labels: []string{label},
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list issues of %s/%s: %w", owner, repo, err)
+	}
+	issues = slices.DeleteFunc(issues, func(issue *github.Issue) bool { return issue.IsPullRequest() })
+
+	if len(issues) == 0 {
+		if violations == 0 {
+			return PolicyIssueNone, "", nil
+		}
+		issue, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{
+			Title:  github.Ptr(title),
+			Body:   github.Ptr(body),
+			Labels: &[]string{label},
+		})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to create issue in %s/%s: %w", owner, repo, err)
+		}
+
+		return PolicyIssueCreated, issue.GetHTMLURL(), nil
+	}
+
+	// The oldest issue is kept when several carry the label
+	issue := slices.MinFunc(issues, func(a, b *github.Issue) int { return a.GetNumber() - b.GetNumber() })
+	request := &github.IssueRequest{Title: github.Ptr(title), Body: github.Ptr(body)}
+	result := PolicyIssueUpdated
+	switch {
+	case violations == 0:
+		request.State = github.Ptr("closed")
+		request.StateReason = github.Ptr("completed")
+		result = PolicyIssueClosed
+	case issue.GetTitle() == title && strings.TrimSpace(issue.GetBody()) == strings.TrimSpace(body):
+		return PolicyIssueUnchanged, issue.GetHTMLURL(), nil
+	}
+
+	issue, _, err = client.Issues.Edit(ctx, owner, repo, issue.GetNumber(), request)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to update issue in %s/%s: %w", owner, repo, err)
+	}
+
+	return result, issue.GetHTMLURL(), nil
+}
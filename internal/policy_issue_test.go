@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestDepsPolicy_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	checkout := dependencies.Dependency{Name: "actions/checkout", Version: "v4"}
+	pinned := dependencies.Dependency{Name: "actions/setup-go", Version: "v5.0.0", IsPinned: true}
+	actions := []PolicyAction{{
+		File:         "build/action.yml",
+		Dependencies: []dependencies.Dependency{checkout, pinned},
+		Outdated: []dependencies.OutdatedDependency{{
+			Current: pinned, LatestVersion: "v6.0.0", UpdateType: "major", IsSecurityUpdate: true,
+		}},
+	}}
+
+	report := DepsPolicy{FailOn: []string{FailOnFloating}}.Evaluate(actions)
+	testutil.AssertEqual(t, 2, report.Total)
+	testutil.AssertEqual(t, true, report.OutdatedChecked)
+	testutil.AssertEqual(t, "actions/checkout", report.Unpinned[0].Name)
+	testutil.AssertEqual(t, "v6.0.0", report.Outdated[0].Latest)
+	testutil.AssertEqual(t, 1, len(report.Security))
+	testutil.AssertStringContains(t, report.Error, "1 floating dependencies")
+
+	body, err := RenderPolicyIssue(report, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, body, "**2** dependency policy violation(s) in 1 action(s)")
+	testutil.AssertStringContains(t, body, "| `build/action.yml` | `actions/checkout` | `v4` |")
+	testutil.AssertStringContains(t, body, "### 🔒 Potential security updates")
+
+	disallowed := DepsPolicy{}.Evaluate([]PolicyAction{{
+		File:         "action.yml",
+		Dependencies: []dependencies.Dependency{pinned},
+		Disallowed:   []dependencies.Dependency{pinned},
+	}})
+	testutil.AssertEqual(t, 1, disallowed.Total)
+	testutil.AssertEqual(t, true, disallowed.AllowlistChecked)
+	testutil.AssertStringContains(t, disallowed.Error, "1 dependencies not on the allow-list")
+	body, err = RenderPolicyIssue(disallowed, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, body, "### 🚫 Dependencies not on the allow-list")
+	testutil.AssertStringContains(t, body, "| `action.yml` | `actions/setup-go` | `v5.0.0` |")
+
+	clean := DepsPolicy{}.Evaluate([]PolicyAction{{File: "action.yml", Dependencies: []dependencies.Dependency{pinned}}})
+	testutil.AssertEqual(t, 0, clean.Total)
+	testutil.AssertEqual(t, false, clean.OutdatedChecked)
+	testutil.AssertEqual(t, "", clean.Error)
+	testutil.AssertEqual(t, false, clean.AllowlistChecked)
+}
+
+func TestSyncPolicyIssue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		open       string // JSON of the open issues with the label
+		violations int
+		want       string
+		wantMethod string
+		wantState  string
+	}{
+		{"creates issue", `[]`, 2, PolicyIssueCreated, http.MethodPost, ""},
+		{"nothing to report", `[]`, 0, PolicyIssueNone, "", ""},
+		{
+			"updates oldest issue",
+			`[{"number": 9, "title": "old", "body": "old"}, {"number": 3, "title": "old", "body": "old"},
+			  {"number": 1, "pull_request": {}}]`,
+			2, PolicyIssueUpdated, http.MethodPatch, "",
+		},
+		{"skips unchanged issue", `[{"number": 3, "title": "Title", "body": "Body\n"}]`, 2, PolicyIssueUnchanged, "", ""},
+		{
+			"closes resolved issue", `[{"number": 3, "title": "Title", "body": "Body"}]`,
+			0, PolicyIssueClosed, http.MethodPatch, "closed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var method, path string
+			var request github.IssueRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					testutil.AssertEqual(t, "dependency-policy", r.URL.Query().Get("labels"))
+					_, _ = w.Write([]byte(tt.open))
+
+					return
+				}
+				method, path = r.Method, r.URL.Path
+				_ = json.NewDecoder(r.Body).Decode(&request)
+				_, _ = w.Write([]byte(`{"number": 3, "html_url": "https://github.com/org/repo/issues/3"}`))
+			}))
+			defer server.Close()
+
+			client := github.NewClient(server.Client())
+			client.BaseURL, _ = url.Parse(server.URL + "/")
+
+			result, _, err := SyncPolicyIssue(context.Background(), client,
+				"org", "repo", DefaultPolicyIssueLabel, "Title", "Body", tt.violations)
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tt.want, result)
+			testutil.AssertEqual(t, tt.wantMethod, method)
+			testutil.AssertEqual(t, tt.wantState, request.GetState())
+			switch tt.wantMethod {
+			case http.MethodPost:
+				testutil.AssertEqual(t, "/repos/org/repo/issues", path)
+				testutil.AssertEqual(t, DefaultPolicyIssueLabel, (*request.Labels)[0])
+			case http.MethodPatch:
+				testutil.AssertEqual(t, "/repos/org/repo/issues/3", path)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Runner operating system families of runs_on labels.
+const (
+	OSLinux   = "linux"
+	OSMacOS   = "macos"
+	OSWindows = "windows"
+)
+
+// bashism is bash syntax that POSIX sh, such as dash on Ubuntu runners, does not support.
+type bashism struct {
+	pattern     *regexp.Regexp
+	description string
+}
+
+// bashisms are the bash-only constructs reported in sh steps.
+var bashisms = []bashism{
+	{regexp.MustCompile(`\[\[`), "[[ ]] tests"},
+	{regexp.MustCompile(`(?m)^\s*function\s+\w+`), "the function keyword"},
+	{regexp.MustCompile(`(?m)(?:^|[;&|]\s*)source\s`), "source (use . in sh)"},
+	{regexp.MustCompile(`(?m)(?:^|[;&|]\s*)declare\s`), "declare"},
+	{regexp.MustCompile(`\$\{\w+/`), "${var/pattern/replacement} substitution"},
+	{regexp.MustCompile(`\$\{\w+:-?\d`), "${var:offset} substrings"},
+	{regexp.MustCompile(`\b\w+=\(`), "arrays"},
+	{regexp.MustCompile(`<<<`), "here-strings"},
+	{regexp.MustCompile(`[<>]\(`), "process substitution"},
+	{regexp.MustCompile(`&>`), "&> redirection"},
+}
+
+// platformCommands are commands that only exist on some runner operating systems.
+var platformCommands = map[string][]string{
+	"apt":        {OSLinux},
+	"apt-get":    {OSLinux},
+	"dpkg":       {OSLinux},
+	"yum":        {OSLinux},
+	"dnf":        {OSLinux},
+	"apk":        {OSLinux},
+	"snap":       {OSLinux},
+	"systemctl":  {OSLinux},
+	"brew":       {OSLinux, OSMacOS},
+	"sudo":       {OSLinux, OSMacOS},
+	"xcodebuild": {OSMacOS},
+	"xcrun":      {OSMacOS},
+	"launchctl":  {OSMacOS},
+	"sw_vers":    {OSMacOS},
+	"hdiutil":    {OSMacOS},
+	"choco":      {OSWindows},
+	"winget":     {OSWindows},
+	"reg":        {OSWindows},
+	"msbuild":    {OSWindows},
+}
+
+// platformShells are step shells that only exist on some runner operating systems.
+var platformShells = map[string][]string{
+	"powershell": {OSWindows},
+	"cmd":        {OSWindows},
+}
+
+// runnerOSCondition matches step conditions that select the runner operating system.
+var runnerOSCondition = regexp.MustCompile(`(?i)runner\.os`)
+
+// RunnerOSFamilies returns the operating system families of runs_on labels, in
+// order of first appearance. Labels of unknown families, such as self-hosted, are ignored.
+func RunnerOSFamilies(runsOn []string) []string {
+	var families []string
+	for _, label := range runsOn {
+		label = strings.ToLower(label)
+		var family string
+		switch {
+		case strings.Contains(label, "ubuntu"), strings.Contains(label, OSLinux):
+			family = OSLinux
+		case strings.Contains(label, OSMacOS):
+			family = OSMacOS
+		case strings.Contains(label, OSWindows):
+			family = OSWindows
+		default:
+			continue
+		}
+		if !slices.Contains(families, family) {
+			families = append(families, family)
+		}
+	}
+
+	return families
+}
+
+// findBashism returns the description of the first bash-only construct in script.
+func findBashism(script string) (string, bool) {
+	for _, b := range bashisms {
+		if b.pattern.MatchString(script) {
+			return b.description, true
+		}
+	}
+
+	return "", false
+}
+
+// validateStepShell reports run steps whose shell is sh while the script uses
+// bash syntax. Steps without a shell are reported by validateStepShape.
+func validateStepShell(index int, step map[string]any, result *ValidationResult) {
+	script, _ := step["run"].(string)
+	if shell, _ := step["shell"].(string); strings.TrimSpace(shell) != "sh" || script == "" {
+		return
+	}
+	if construct, found := findBashism(script); found {
+		result.addFinding(
+			RuleNonPortableShell,
+			SeverityWarning,
+			fmt.Sprintf("runs.steps[%d]", index),
+			fmt.Sprintf("Step runs with 'shell: sh' but uses bash-only %s", construct),
+		)
+		result.suggest("Use 'shell: bash', or rewrite the script in POSIX sh; sh is dash on Ubuntu runners")
+	}
+}
+
+// ValidatePlatformSupport reports composite steps that use commands or shells
+// missing on some of the operating systems in runsOn, unless the step selects
+// the runner with an if: runner.os condition. Findings carry the line of the
+// step in the action file and honor suppression comments.
+func ValidatePlatformSupport(path string, runsOn []string) ([]Finding, error) {
+	families := RunnerOSFamilies(runsOn)
+	if len(families) < 2 {
+		return nil, nil
+	}
+
+	action, err := ParseActionYML(path)
+	if err != nil {
+		return nil, err
+	}
+	steps, _ := action.Runs["steps"].([]any)
+
+	var findings []Finding
+	for i, raw := range steps {
+		step, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		if condition, _ := step["if"].(string); runnerOSCondition.MatchString(condition) {
+			continue
+		}
+		if finding, ok := platformFinding(i, step, families); ok {
+			findings = append(findings, finding)
+		}
+	}
+
+	if content, err := os.ReadFile(path); err == nil { // #nosec G304 -- path from function parameter
+		locateFindings(content, findings)
+		findings = suppressFindings(content, findings)
+	}
+
+	return findings, nil
+}
+
+// platformFinding returns a finding for the first shell or command of step that
+// is missing on one of families.
+func platformFinding(index int, step map[string]any, families []string) (Finding, bool) {
+	shell, _ := step["shell"].(string)
+	if available, ok := platformShells[strings.TrimSpace(shell)]; ok {
+		if missing := missingFamilies(available, families); len(missing) > 0 {
+			return Finding{
+				RuleID:   RulePlatformSpecificStep,
+				Severity: SeverityWarning,
+				Field:    fmt.Sprintf("runs.steps[%d]", index),
+				Message: fmt.Sprintf("Step uses shell '%s', but runs_on includes %s where it is not available",
+					shell, strings.Join(missing, ", ")),
+				Suggestion: "Use 'shell: pwsh' or 'shell: bash', which run on every runner",
+			}, true
+		}
+	}
+
+	script, _ := step["run"].(string)
+	for _, command := range scriptCommands(script) {
+		available, ok := platformCommands[command]
+		if !ok {
+			continue
+		}
+		if missing := missingFamilies(available, families); len(missing) > 0 {
+			return Finding{
+				RuleID:   RulePlatformSpecificStep,
+				Severity: SeverityWarning,
+				Field:    fmt.Sprintf("runs.steps[%d]", index),
+				Message: fmt.Sprintf("Step runs '%s', but runs_on includes %s where it is not available",
+					command, strings.Join(missing, ", ")),
+				Suggestion: "Add an 'if: runner.os == ...' condition to the step, or remove the " +
+					"operating system from runs_on",
+			}, true
+		}
+	}
+
+	return Finding{}, false
+}
+
+// missingFamilies returns the families that are not in available.
+func missingFamilies(available, families []string) []string {
+	var missing []string
+	for _, family := range families {
+		if !slices.Contains(available, family) {
+			missing = append(missing, family)
+		}
+	}
+
+	return missing
+}
+
+// scriptCommands returns the command names of the simple commands in script,
+// skipping comments and variable assignments. Commands run with sudo are
+// returned after sudo itself.
+func scriptCommands(script string) []string {
+	var commands []string
+	for _, line := range strings.Split(script, "\n") {
+		if before, _, found := strings.Cut(line, "#"); found {
+			line = before
+		}
+		for _, command := range strings.FieldsFunc(line, func(r rune) bool {
+			return strings.ContainsRune(";&|()`", r)
+		}) {
+			for _, word := range strings.Fields(command) {
+				if strings.Contains(word, "=") {
+					continue
+				}
+				commands = append(commands, word)
+				if word != "sudo" {
+					break
+				}
+			}
+		}
+	}
+
+	return commands
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestRunnerOSFamilies(t *testing.T) {
+	t.Parallel()
+
+	families := RunnerOSFamilies([]string{"ubuntu-latest", "ubuntu-22.04", "self-hosted", "windows-2022", "macos-14"})
+	if !slices.Equal([]string{OSLinux, OSWindows, OSMacOS}, families) {
+		t.Errorf("unexpected families: %v", families)
+	}
+	testutil.AssertEqual(t, 0, len(RunnerOSFamilies([]string{"self-hosted"})))
+}
+
+func TestValidateActionYML_ShellPortability(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{
+		Name:        "Portable",
+		Description: "Composite action for portability checks",
+		Runs: map[string]any{
+			"using": "composite",
+			"steps": []any{
+				map[string]any{"run": `if [[ -n "$X" ]]; then echo hi; fi`},
+				map[string]any{"shell": "sh", "run": "source ./env.sh"},
+				map[string]any{"shell": "sh", "run": `[ -n "$X" ] && . ./env.sh`},
+				map[string]any{"shell": "bash", "run": "arr=(a b)"},
+			},
+		},
+	}
+
+	result := ValidateActionYML(action)
+	var portability []Finding
+	for _, finding := range result.Findings {
+		if finding.RuleID == RuleNonPortableShell {
+			portability = append(portability, finding)
+		}
+	}
+	testutil.AssertEqual(t, 1, len(portability))
+	testutil.AssertEqual(t, "runs.steps[1]", portability[0].Field)
+	testutil.AssertStringContains(t, portability[0].Message, "source")
+
+	if !slices.Contains(result.Suggestions, "Add 'shell: bash'; the script uses bash-only [[ ]] tests") {
+		t.Errorf("expected a shell: bash suggestion for the step without shell, got %v", result.Suggestions)
+	}
+}
+
+func TestValidatePlatformSupport(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	path := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, path, `name: Portable
+description: Composite action for portability checks
+runs:
+  using: composite
+  steps:
+    - shell: bash
+      run: |
+        # apt-get is only mentioned here
+        sudo apt-get install -y jq
+    - shell: bash
+      if: runner.os == 'Linux'
+      run: apt-get install -y jq
+    - shell: powershell
+      run: Write-Host hi
+    # ghreadme:disable-next-line platform-specific-step
+    - shell: bash
+      run: brew install jq
+    - shell: bash
+      run: FOO=1 make build
+`)
+
+	findings, err := ValidatePlatformSupport(path, []string{"ubuntu-latest"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(findings))
+
+	findings, err = ValidatePlatformSupport(path, []string{"ubuntu-latest", "windows-latest"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(findings))
+	testutil.AssertEqual(t, RulePlatformSpecificStep, findings[0].RuleID)
+	testutil.AssertEqual(t, "runs.steps[0]", findings[0].Field)
+	testutil.AssertEqual(t, 6, findings[0].Line)
+	testutil.AssertStringContains(t, findings[0].Message, "'sudo'")
+	testutil.AssertStringContains(t, findings[0].Message, "windows")
+	testutil.AssertEqual(t, "runs.steps[2]", findings[1].Field)
+	testutil.AssertStringContains(t, findings[1].Message, "'powershell'")
+	testutil.AssertStringContains(t, findings[1].Message, "linux")
+}
+
+func TestScriptCommands(t *testing.T) {
+	t.Parallel()
+
+	commands := scriptCommands("FOO=1 make build && sudo apt-get update # brew\necho $(uname) | tee out")
+	if !slices.Equal([]string{"make", "sudo", "apt-get", "echo", "uname", "tee"}, commands) {
+		t.Errorf("unexpected commands: %v", commands)
+	}
+}
@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ErrNoAnswer is returned by prompters whose input ended before an answer was
+// given, such as a prompt read from a closed or non-interactive standard input.
+var ErrNoAnswer = errors.New("no answer on standard input, pass --yes to run without prompts")
+
+// ErrInputRequired is returned by NonInteractivePrompter for every question.
+var ErrInputRequired = errors.New("input required but prompts are disabled by --non-interactive, pass --yes to confirm")
+
+// Prompter asks the user questions in interactive flows.
+type Prompter interface {
+	// Prompt shows question and returns the trimmed answer, or ErrNoAnswer when
+	// no answer can be read.
+	Prompt(question string) (string, error)
+}
+
+// TTYPrompter prompts on a terminal: questions are written to out and answers
+// are read from in, one line each.
+type TTYPrompter struct {
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+// NewTTYPrompter creates a prompter reading answers from in and writing questions to out.
+func NewTTYPrompter(in io.Reader, out io.Writer) *TTYPrompter {
+	return &TTYPrompter{in: bufio.NewScanner(in), out: out}
+}
+
+// Prompt writes question and reads the next line of input.
+func (p *TTYPrompter) Prompt(question string) (string, error) {
+	_, _ = fmt.Fprint(p.out, question)
+	if !p.in.Scan() {
+		_, _ = fmt.Fprintln(p.out)
+
+		return "", ErrNoAnswer
+	}
+
+	return strings.TrimSpace(p.in.Text()), nil
+}
+
+// NonInteractivePrompter fails every question with ErrInputRequired, so runs
+// that must not wait for input fail fast instead.
+type NonInteractivePrompter struct{}
+
+// Prompt returns ErrInputRequired naming the question.
+func (NonInteractivePrompter) Prompt(question string) (string, error) {
+	return "", fmt.Errorf("%w: %s", ErrInputRequired, strings.TrimSpace(question))
+}
+
+// ScriptedPrompter answers questions from a fixed list, for tests. It records
+// the questions asked and returns ErrNoAnswer once the answers run out.
+type ScriptedPrompter struct {
+	Answers   []string
+	Questions []string
+}
+
+// NewScriptedPrompter creates a prompter giving answers in order.
+func NewScriptedPrompter(answers ...string) *ScriptedPrompter {
+	return &ScriptedPrompter{Answers: answers}
+}
+
+// Prompt records question and returns the next answer.
+func (p *ScriptedPrompter) Prompt(question string) (string, error) {
+	p.Questions = append(p.Questions, question)
+	if len(p.Answers) == 0 {
+		return "", ErrNoAnswer
+	}
+	answer := p.Answers[0]
+	p.Answers = p.Answers[1:]
+
+	return strings.TrimSpace(answer), nil
+}
+
+// Confirm asks a yes/no question, showing the default, and repeats it until the
+// answer is empty (the default), y/yes or n/no. Errors of the prompter are returned.
+func Confirm(p Prompter, question string, defaultValue bool) (bool, error) {
+	choices := "y/N"
+	if defaultValue {
+		choices = "Y/n"
+	}
+
+	for {
+		answer, err := p.Prompt(fmt.Sprintf("%s (%s): ", question, choices))
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return defaultValue, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// IsTerminal reports whether f is a character device other than the null
+// device, such as a terminal, rather than a pipe, file or /dev/null.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	null, err := os.Stat(os.DevNull)
+
+	return err != nil || !os.SameFile(info, null)
+}
+
+// MakeRaw puts the terminal f into raw mode, so keys are read one at a time
+// without echo, and returns a function restoring the previous mode.
+func MakeRaw(f *os.File) (func(), error) {
+	fd := int(f.Fd()) // #nosec G115 -- file descriptors fit in int
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to switch the terminal to raw mode: %w", err)
+	}
+
+	return func() { _ = term.Restore(fd, state) }, nil
+}
+
+// TerminalSize returns the width and height of the terminal f, or zeros when
+// f is not a terminal.
+func TerminalSize(f *os.File) (int, int) {
+	width, height, err := term.GetSize(int(f.Fd())) // #nosec G115 -- file descriptors fit in int
+	if err != nil {
+		return 0, 0
+	}
+
+	return width, height
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestTTYPrompter(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	prompter := NewTTYPrompter(strings.NewReader("  octocat \n"), &out)
+
+	answer, err := prompter.Prompt("Name: ")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "octocat", answer)
+	testutil.AssertEqual(t, "Name: ", out.String())
+
+	if _, err := prompter.Prompt("Again: "); !errors.Is(err, ErrNoAnswer) {
+		t.Errorf("expected ErrNoAnswer at the end of input, got %v", err)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		answers      []string
+		defaultValue bool
+		want         bool
+		wantErr      bool
+		wantAsked    int
+	}{
+		{name: "yes", answers: []string{"y"}, want: true, wantAsked: 1},
+		{name: "full word", answers: []string{"YES"}, want: true, wantAsked: 1},
+		{name: "no", answers: []string{"n"}, defaultValue: true, want: false, wantAsked: 1},
+		{name: "empty uses default", answers: []string{""}, defaultValue: true, want: true, wantAsked: 1},
+		{name: "invalid answer asks again", answers: []string{"maybe", "y"}, want: true, wantAsked: 2},
+		{name: "no input", wantErr: true, wantAsked: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			prompter := NewScriptedPrompter(tt.answers...)
+			got, err := Confirm(prompter, "Continue?", tt.defaultValue)
+			if tt.wantErr {
+				testutil.AssertError(t, err)
+			} else {
+				testutil.AssertNoError(t, err)
+				testutil.AssertEqual(t, tt.want, got)
+			}
+			testutil.AssertEqual(t, tt.wantAsked, len(prompter.Questions))
+		})
+	}
+
+	prompter := NewScriptedPrompter("y")
+	_, _ = Confirm(prompter, "Continue?", true)
+	if !slices.Equal(prompter.Questions, []string{"Continue? (Y/n): "}) {
+		t.Errorf("unexpected questions %q", prompter.Questions)
+	}
+}
+
+func TestNonInteractivePrompter(t *testing.T) {
+	t.Parallel()
+
+	_, err := Confirm(NonInteractivePrompter{}, "Continue?", false)
+	if !errors.Is(err, ErrInputRequired) {
+		t.Fatalf("expected ErrInputRequired, got %v", err)
+	}
+	testutil.AssertStringContains(t, err.Error(), "Continue? (y/N):")
+}
+
+func TestIsTerminal(t *testing.T) {
+	t.Parallel()
+
+	null, err := os.Open(os.DevNull)
+	testutil.AssertNoError(t, err)
+	defer func() { _ = null.Close() }()
+	file, err := os.CreateTemp(t.TempDir(), "input")
+	testutil.AssertNoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	for _, f := range []*os.File{null, file} {
+		if IsTerminal(f) {
+			t.Errorf("expected %s not to be a terminal", f.Name())
+		}
+	}
+}
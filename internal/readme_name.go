@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DefaultReadmeFilename is the README created when a directory has none and
+// readme_filename is not set.
+const DefaultReadmeFilename = "README.md"
+
+// readmeExtensions are the extensions of the READMEs gen updates, such as
+// Readme.md or README.markdown.
+var readmeExtensions = []string{".md", ".markdown"}
+
+// ValidateReadmeFilename rejects readme_filename values that are not the name
+// of a Markdown file in the output directory.
+func ValidateReadmeFilename(name string) error {
+	if name == "" {
+		return nil
+	}
+	if name != filepath.Base(name) || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid readme_filename '%s', must be a file name without directories", name)
+	}
+	if !slices.Contains(readmeExtensions, strings.ToLower(filepath.Ext(name))) {
+		return fmt.Errorf("invalid readme_filename '%s', must end with one of: %s",
+			name, strings.Join(readmeExtensions, ", "))
+	}
+
+	return nil
+}
+
+// FindReadmes returns the sorted names of the READMEs in dir: files named
+// readme with a Markdown extension, in any case.
+func FindReadmes(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var readmes []string
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		if entry.IsDir() || !strings.EqualFold(strings.TrimSuffix(name, ext), "readme") {
+			continue
+		}
+		if slices.Contains(readmeExtensions, strings.ToLower(ext)) {
+			readmes = append(readmes, name)
+		}
+	}
+	slices.Sort(readmes)
+
+	return readmes
+}
+
+// ReadmeFilename returns the name of the README to write in dir and the other
+// READMEs found there. The configured name is used when set; otherwise an
+// existing README is updated, preferring README.md when there are several,
+// and README.md is created when there is none.
+func ReadmeFilename(dir, configured string) (string, []string) {
+	existing := FindReadmes(dir)
+	name := configured
+	switch {
+	case name != "":
+	case len(existing) == 0 || slices.Contains(existing, DefaultReadmeFilename):
+		name = DefaultReadmeFilename
+	default:
+		name = existing[0]
+	}
+
+	return name, slices.DeleteFunc(existing, func(readme string) bool { return readme == name })
+}
+
+// readmeFilename returns the name of the README to write in outputDir.
+func (g *Generator) readmeFilename(outputDir string) string {
+	name, _ := ReadmeFilename(outputDir, g.Config.ReadmeFilename)
+
+	return name
+}
+
+// warnDuplicateReadmes warns when outputDir has other READMEs besides the one
+// written, which readers and hosting sites would show instead.
+func (g *Generator) warnDuplicateReadmes(outputDir string) {
+	name, duplicates := ReadmeFilename(outputDir, g.Config.ReadmeFilename)
+	if len(duplicates) > 0 {
+		g.Output.Warning("Updating %s, but %s also has %s; remove the duplicates or set readme_filename",
+			name, outputDir, strings.Join(duplicates, ", "))
+	}
+}
@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestReadmeFilename(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		existing       []string
+		configured     string
+		wantName       string
+		wantDuplicates []string
+	}{
+		{name: "no readme", wantName: "README.md"},
+		{name: "case variant", existing: []string{"Readme.md"}, wantName: "Readme.md"},
+		{name: "markdown extension", existing: []string{"README.markdown"}, wantName: "README.markdown"},
+		{
+			name:           "duplicates prefer README.md",
+			existing:       []string{"readme.markdown", "README.md"},
+			wantName:       "README.md",
+			wantDuplicates: []string{"readme.markdown"},
+		},
+		{
+			name:           "configured name",
+			existing:       []string{"Readme.md"},
+			configured:     "README.md",
+			wantName:       "README.md",
+			wantDuplicates: []string{"Readme.md"},
+		},
+		{name: "other files ignored", existing: []string{"README.txt", "readme-dev.md"}, wantName: "README.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir, cleanup := testutil.TempDir(t)
+			defer cleanup()
+			for _, name := range tt.existing {
+				testutil.WriteTestFile(t, filepath.Join(tmpDir, name), "# Action\n")
+			}
+
+			name, duplicates := ReadmeFilename(tmpDir, tt.configured)
+			testutil.AssertEqual(t, tt.wantName, name)
+			testutil.AssertEqual(t, strings.Join(tt.wantDuplicates, ","), strings.Join(duplicates, ","))
+		})
+	}
+}
+
+func TestValidateReadmeFilename(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertNoError(t, ValidateReadmeFilename(""))
+	testutil.AssertNoError(t, ValidateReadmeFilename("Readme.markdown"))
+	testutil.AssertError(t, ValidateReadmeFilename("docs/README.md"))
+	testutil.AssertError(t, ValidateReadmeFilename("README.txt"))
+}
+
+func TestGenerator_UpdatesExistingReadmeVariant(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "Readme.md"), "# Draft\n")
+
+	config := DefaultAppConfig()
+	config.Theme = ThemeMinimal
+	config.Quiet = true
+	testutil.AssertNoError(t, NewGenerator(config).GenerateFromFile(actionPath))
+
+	readme, err := os.ReadFile(filepath.Join(tmpDir, "Readme.md"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(readme), "Simple JavaScript Action")
+	_, err = os.Stat(filepath.Join(tmpDir, "README.md"))
+	testutil.AssertEqual(t, true, os.IsNotExist(err))
+}
@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+)
+
+// conventionalCommitPattern matches conventional commit subjects such as
+// "feat(parser)!: drop legacy syntax".
+var conventionalCommitPattern = regexp.MustCompile(`^(\w+)(?:\([^)]*\))?(!)?:\s`)
+
+// releaseVersionPattern matches release versions with an optional v prefix.
+var releaseVersionPattern = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)$`)
+
+// initialReleaseVersion is the version suggested for an action without a previous release.
+const initialReleaseVersion = "v1.0.0"
+
+// ReleaseSuggestion recommends the next version based on interface and commit changes.
+type ReleaseSuggestion struct {
+	CurrentVersion string         `json:"current_version"`
+	NextVersion    string         `json:"next_version"`
+	Level          ChangeLevel    `json:"level"`
+	InterfaceLevel ChangeLevel    `json:"interface_level"`
+	CommitLevel    ChangeLevel    `json:"commit_level"`
+	Interface      *InterfaceDiff `json:"interface"`
+	Commits        []git.Commit   `json:"commits"`
+}
+
+// SuggestRelease compares the action at from with the action at to and combines
+// the interface diff with conventional commit messages to recommend a version bump.
+// An empty from is the first release, covering the whole history of to.
+func SuggestRelease(repoRoot, actionPath, from, to string) (*ReleaseSuggestion, error) {
+	changes, err := loadReleaseRange(repoRoot, actionPath, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestion := &ReleaseSuggestion{
+		CurrentVersion: from,
+		Interface:      DiffInterfaces(changes.oldAction, changes.newAction),
+		Commits:        changes.commits,
+		CommitLevel:    commitsChangeLevel(changes.commits),
+	}
+	suggestion.InterfaceLevel = suggestion.Interface.Level
+	suggestion.Level = maxChangeLevel(suggestion.InterfaceLevel, suggestion.CommitLevel)
+
+	if from == "" {
+		suggestion.NextVersion = initialReleaseVersion
+
+		return suggestion, nil
+	}
+	suggestion.NextVersion, err = NextVersion(from, suggestion.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	return suggestion, nil
+}
+
+// CommitChangeLevel classifies a commit by its conventional commit type.
+// Commits that do not follow the convention have no release impact.
+func CommitChangeLevel(commit git.Commit) ChangeLevel {
+	match := conventionalCommitPattern.FindStringSubmatch(commit.Subject)
+	if match == nil {
+		return ChangeNone
+	}
+	if match[2] == "!" || strings.Contains(commit.Body, "BREAKING CHANGE:") ||
+		strings.Contains(commit.Body, "BREAKING-CHANGE:") {
+		return ChangeMajor
+	}
+
+	switch strings.ToLower(match[1]) {
+	case "feat":
+		return ChangeMinor
+	case "fix", "perf":
+		return ChangePatch
+	default:
+		return ChangeNone
+	}
+}
+
+// NextVersion bumps a semantic version by level, keeping any v prefix.
+func NextVersion(version string, level ChangeLevel) (string, error) {
+	match := releaseVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return "", fmt.Errorf("version %q is not a semantic version (X.Y.Z)", version)
+	}
+
+	major, _ := strconv.Atoi(match[2])
+	minor, _ := strconv.Atoi(match[3])
+	patch, _ := strconv.Atoi(match[4])
+
+	switch level {
+	case ChangeMajor:
+		major, minor, patch = major+1, 0, 0
+	case ChangeMinor:
+		minor, patch = minor+1, 0
+	case ChangePatch:
+		patch++
+	case ChangeNone:
+		return version, nil
+	default:
+		return "", fmt.Errorf("unknown change level %q", level)
+	}
+
+	return fmt.Sprintf("%s%d.%d.%d", match[1], major, minor, patch), nil
+}
+
+// ConventionalSummary renders the suggestion as a conventional commit message
+// suitable for a release pull request.
+func (s *ReleaseSuggestion) ConventionalSummary() string {
+	var sb strings.Builder
+
+	commitType := "chore"
+	switch s.Level {
+	case ChangeMajor:
+		commitType = "feat!"
+	case ChangeMinor:
+		commitType = "feat"
+	case ChangePatch:
+		commitType = "fix"
+	case ChangeNone:
+	}
+	sb.WriteString(fmt.Sprintf("%s: release %s\n", commitType, s.NextVersion))
+
+	var body []string
+	for _, change := range s.Interface.Changes {
+		body = append(body, "- "+change.Message)
+	}
+	for _, commit := range s.Commits {
+		if CommitChangeLevel(commit) != ChangeNone {
+			body = append(body, "- "+commit.Subject)
+		}
+	}
+	if len(body) > 0 {
+		sb.WriteString("\n" + strings.Join(body, "\n") + "\n")
+	}
+
+	var footers []string
+	for _, change := range s.Interface.Breaking() {
+		footers = append(footers, "BREAKING CHANGE: "+change.Message)
+	}
+	if len(footers) > 0 {
+		sb.WriteString("\n" + strings.Join(footers, "\n") + "\n")
+	}
+
+	return sb.String()
+}
+
+// releaseRange holds both versions of an action and the commits between them.
+type releaseRange struct {
+	oldAction *ActionYML
+	newAction *ActionYML
+	commits   []git.Commit
+}
+
+// loadReleaseRange loads the action at from and to and the commits in between.
+// Without from, the release is the first one: its interface has nothing to be
+// compared with and every commit reachable from to is included.
+func loadReleaseRange(repoRoot, actionPath, from, to string) (*releaseRange, error) {
+	newAction, err := LoadActionAtRef(repoRoot, to, actionPath)
+	if err != nil {
+		return nil, err
+	}
+	oldAction := newAction
+	if from != "" {
+		if oldAction, err = LoadActionAtRef(repoRoot, from, actionPath); err != nil {
+			return nil, err
+		}
+	}
+	commits, err := git.Commits(repoRoot, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &releaseRange{oldAction: oldAction, newAction: newAction, commits: commits}, nil
+}
+
+// commitsChangeLevel returns the highest change level of the given commits.
+func commitsChangeLevel(commits []git.Commit) ChangeLevel {
+	level := ChangeNone
+	for _, commit := range commits {
+		level = maxChangeLevel(level, CommitChangeLevel(commit))
+	}
+
+	return level
+}
+
+// maxChangeLevel returns the higher-impact of two change levels.
+func maxChangeLevel(a, b ChangeLevel) ChangeLevel {
+	if b.Rank() > a.Rank() {
+		return b
+	}
+
+	return a
+}
@@ -0,0 +1,186 @@
+package internal
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+	"github.com/ivuorinen/gh-action-readme/templates_embed"
+)
+
+// releaseNotesUnreleased is the version shown when the release version cannot be determined.
+const releaseNotesUnreleased = "Unreleased"
+
+// DependencyChange describes an action used by a composite step that was added,
+// removed or moved to a different ref between two releases.
+type DependencyChange struct {
+	Name string `json:"name"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// CommitGroup is a titled group of commits in the release notes.
+type CommitGroup struct {
+	Title   string       `json:"title"`
+	Commits []git.Commit `json:"commits"`
+}
+
+// ReleaseNotesData is the data available to release notes templates.
+type ReleaseNotesData struct {
+	Action       *ActionYML         `json:"action"`
+	Git          git.RepoInfo       `json:"git"`
+	From         string             `json:"from"`
+	To           string             `json:"to"`
+	Version      string             `json:"version"`
+	Date         string             `json:"date,omitempty"`
+	Commits      []git.Commit       `json:"commits"`
+	Interface    *InterfaceDiff     `json:"interface"`
+	Dependencies []DependencyChange `json:"dependencies"`
+}
+
+// BuildReleaseNotes collects commit history, interface changes and dependency
+// updates of the action between two refs.
+func BuildReleaseNotes(repoRoot, actionPath, from, to string) (*ReleaseNotesData, error) {
+	changes, err := loadReleaseRange(repoRoot, actionPath, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &ReleaseNotesData{
+		Action:       changes.newAction,
+		From:         from,
+		To:           to,
+		Commits:      changes.commits,
+		Interface:    DiffInterfaces(changes.oldAction, changes.newAction),
+		Dependencies: DiffDependencies(changes.oldAction, changes.newAction),
+	}
+	if info, err := git.DetectRepository(repoRoot); err == nil {
+		data.Git = *info
+	}
+	if len(data.Commits) > 0 {
+		data.Date = data.Commits[0].Date
+	}
+
+	level := maxChangeLevel(data.Interface.Level, commitsChangeLevel(data.Commits))
+	data.Version = releaseNotesVersion(from, to, level)
+
+	return data, nil
+}
+
+// DiffDependencies compares the external actions used by composite steps,
+// sorted by action name. Local (./) and docker:// references are ignored.
+func DiffDependencies(oldAction, newAction *ActionYML) []DependencyChange {
+	oldRefs, newRefs := stepDependencies(oldAction), stepDependencies(newAction)
+
+	var changes []DependencyChange
+	for _, name := range slices.Sorted(maps.Keys(oldRefs)) {
+		if newRefs[name] != oldRefs[name] {
+			changes = append(changes, DependencyChange{Name: name, From: oldRefs[name], To: newRefs[name]})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(newRefs)) {
+		if _, ok := oldRefs[name]; !ok {
+			changes = append(changes, DependencyChange{Name: name, To: newRefs[name]})
+		}
+	}
+	slices.SortFunc(changes, func(a, b DependencyChange) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return changes
+}
+
+// RenderReleaseNotes renders release notes with the release notes template of
+// the given theme, falling back to the default template. A non-empty
+// templatePath overrides the theme.
+func RenderReleaseNotes(data *ReleaseNotesData, theme, templatePath string) (string, error) {
+	if templatePath == "" {
+		templatePath = resolveReleaseNotesTemplate(theme)
+	}
+
+	notes, err := RenderReadme(data, TemplateOptions{TemplatePath: templatePath, Format: OutputFormatMD})
+	if err != nil {
+		return "", fmt.Errorf("failed to render release notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+// CommitGroups groups the commits by conventional commit impact. Empty groups are omitted.
+func (d *ReleaseNotesData) CommitGroups() []CommitGroup {
+	groups := []CommitGroup{
+		{Title: "Breaking changes"},
+		{Title: "Features"},
+		{Title: "Fixes"},
+		{Title: "Other changes"},
+	}
+	for _, commit := range d.Commits {
+		index := len(groups) - 1
+		switch CommitChangeLevel(commit) {
+		case ChangeMajor:
+			index = 0
+		case ChangeMinor:
+			index = 1
+		case ChangePatch:
+			index = 2
+		case ChangeNone:
+		}
+		groups[index].Commits = append(groups[index].Commits, commit)
+	}
+
+	return slices.DeleteFunc(groups, func(group CommitGroup) bool {
+		return len(group.Commits) == 0
+	})
+}
+
+// resolveReleaseNotesTemplate returns the release notes template for a theme.
+func resolveReleaseNotesTemplate(theme string) string {
+	themed := "templates/themes/" + theme + "/release-notes.tmpl"
+	if theme != "" && templates_embed.IsEmbeddedTemplateAvailable(themed) {
+		return themed
+	}
+
+	return TemplatePathReleaseNotes
+}
+
+// releaseNotesVersion picks the version heading: the target ref when it names a
+// release, otherwise the next version suggested from the previous release, or
+// the initial version when there is none.
+func releaseNotesVersion(from, to string, level ChangeLevel) string {
+	if releaseVersionPattern.MatchString(to) {
+		return to
+	}
+	if from == "" {
+		return initialReleaseVersion
+	}
+	if level == ChangeNone {
+		return releaseNotesUnreleased
+	}
+	if next, err := NextVersion(from, level); err == nil {
+		return next
+	}
+
+	return releaseNotesUnreleased
+}
+
+// stepDependencies maps external actions used by composite steps to their refs.
+func stepDependencies(action *ActionYML) map[string]string {
+	refs := make(map[string]string)
+	steps, _ := action.Runs["steps"].([]any)
+	for _, step := range steps {
+		stepMap, ok := step.(map[string]any)
+		if !ok {
+			continue
+		}
+		uses, _ := stepMap["uses"].(string)
+		if uses == "" || strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://") {
+			continue
+		}
+		name, ref, _ := strings.Cut(uses, "@")
+		refs[name] = ref
+	}
+
+	return refs
+}
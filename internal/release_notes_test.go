@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func compositeAction(uses ...string) *ActionYML {
+	steps := make([]any, 0, len(uses))
+	for _, ref := range uses {
+		steps = append(steps, map[string]any{"uses": ref})
+	}
+
+	return &ActionYML{Name: "Build", Runs: map[string]any{"using": "composite", "steps": steps}}
+}
+
+func TestDiffDependencies(t *testing.T) {
+	t.Parallel()
+
+	oldAction := compositeAction("actions/checkout@v4", "actions/setup-node@v4", "./local", "docker://alpine:3")
+	newAction := compositeAction("actions/setup-node@v5", "actions/cache@v4", "./other")
+
+	changes := DiffDependencies(oldAction, newAction)
+	testutil.AssertEqual(t, 3, len(changes))
+	testutil.AssertEqual(t, DependencyChange{Name: "actions/cache", To: "v4"}, changes[0])
+	testutil.AssertEqual(t, DependencyChange{Name: "actions/checkout", From: "v4"}, changes[1])
+	testutil.AssertEqual(t, DependencyChange{Name: "actions/setup-node", From: "v4", To: "v5"}, changes[2])
+}
+
+func TestReleaseNotesData_CommitGroups(t *testing.T) {
+	t.Parallel()
+
+	data := &ReleaseNotesData{Commits: []git.Commit{
+		{Subject: "docs: typo"},
+		{Subject: "fix: empty path"},
+		{Subject: "feat: cache input"},
+		{Subject: "fix: handle spaces"},
+	}}
+
+	groups := data.CommitGroups()
+	testutil.AssertEqual(t, 3, len(groups))
+	testutil.AssertEqual(t, "Features", groups[0].Title)
+	testutil.AssertEqual(t, "Fixes", groups[1].Title)
+	testutil.AssertEqual(t, 2, len(groups[1].Commits))
+	testutil.AssertEqual(t, "Other changes", groups[2].Title)
+}
+
+func TestReleaseNotesVersion(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertEqual(t, "v1.3.0", releaseNotesVersion("v1.2.0", "v1.3.0", ChangePatch))
+	testutil.AssertEqual(t, "v1.3.0", releaseNotesVersion("v1.2.0", "HEAD", ChangeMinor))
+	testutil.AssertEqual(t, "Unreleased", releaseNotesVersion("v1.2.0", "HEAD", ChangeNone))
+	testutil.AssertEqual(t, "Unreleased", releaseNotesVersion("v1", "HEAD", ChangeMajor))
+	testutil.AssertEqual(t, "v1.0.0", releaseNotesVersion("", "HEAD", ChangeNone))
+}
+
+func TestRenderReleaseNotes(t *testing.T) {
+	t.Parallel()
+
+	oldAction := compositeAction("actions/setup-node@v4")
+	oldAction.Inputs = map[string]ActionInput{"token": {Description: "Token"}}
+	newAction := compositeAction("actions/setup-node@v5")
+
+	data := &ReleaseNotesData{
+		Action:       newAction,
+		Git:          git.RepoInfo{Organization: "acme", Repository: "build"},
+		From:         "v1.0.0",
+		To:           "HEAD",
+		Version:      "v2.0.0",
+		Date:         "2024-05-01",
+		Commits:      []git.Commit{{Hash: "0123456789abcdef", Subject: "feat: faster builds"}},
+		Interface:    DiffInterfaces(oldAction, newAction),
+		Dependencies: DiffDependencies(oldAction, newAction),
+	}
+
+	notes, err := RenderReleaseNotes(data, ThemeDefault, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, notes, "## v2.0.0 (2024-05-01)")
+	testutil.AssertStringContains(t, notes, "### Breaking interface changes\n\n- Input `token` was removed")
+	testutil.AssertStringContains(t, notes, "### Features\n\n- feat: faster builds (0123456)")
+	testutil.AssertStringContains(t, notes, "- `actions/setup-node`: v4 → v5")
+	testutil.AssertStringContains(t, notes, "https://github.com/acme/build/compare/v1.0.0...HEAD")
+
+	themed, err := RenderReleaseNotes(data, ThemeGitHub, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, themed, "# 🚀 Build v2.0.0")
+	testutil.AssertStringContains(t, themed, "| `actions/setup-node` | `v4` | `v5` |")
+
+	// A first release has no previous tag to compare with
+	data.From = ""
+	notes, err = RenderReleaseNotes(data, ThemeGitHub, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, notes, "> Released 2024-05-01\n")
+	testutil.AssertStringContains(t, notes, "https://github.com/acme/build/commits/HEAD")
+}
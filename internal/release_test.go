@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestCommitChangeLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		commit git.Commit
+		want   ChangeLevel
+	}{
+		{git.Commit{Subject: "feat: add cache input"}, ChangeMinor},
+		{git.Commit{Subject: "feat(inputs): add cache input"}, ChangeMinor},
+		{git.Commit{Subject: "fix: handle empty path"}, ChangePatch},
+		{git.Commit{Subject: "perf: skip redundant install"}, ChangePatch},
+		{git.Commit{Subject: "feat!: drop node16"}, ChangeMajor},
+		{git.Commit{Subject: "refactor(runs)!: move to composite"}, ChangeMajor},
+		{git.Commit{Subject: "fix: rename input", Body: "BREAKING CHANGE: token is now github-token"}, ChangeMajor},
+		{git.Commit{Subject: "docs: update README"}, ChangeNone},
+		{git.Commit{Subject: "Update README"}, ChangeNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.commit.Subject, func(t *testing.T) {
+			t.Parallel()
+			testutil.AssertEqual(t, tt.want, CommitChangeLevel(tt.commit))
+		})
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		level   ChangeLevel
+		want    string
+	}{
+		{"v1.2.3", ChangeMajor, "v2.0.0"},
+		{"v1.2.3", ChangeMinor, "v1.3.0"},
+		{"v1.2.3", ChangePatch, "v1.2.4"},
+		{"v1.2.3", ChangeNone, "v1.2.3"},
+		{"0.9.9", ChangeMinor, "0.10.0"},
+	}
+
+	for _, tt := range tests {
+		got, err := NextVersion(tt.version, tt.level)
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, tt.want, got)
+	}
+
+	_, err := NextVersion("v1", ChangeMinor)
+	testutil.AssertError(t, err)
+	_, err = NextVersion("v1.0.0", ChangeLevel("huge"))
+	testutil.AssertError(t, err)
+}
+
+func TestReleaseSuggestion_ConventionalSummary(t *testing.T) {
+	t.Parallel()
+
+	oldAction := &ActionYML{Inputs: map[string]ActionInput{"token": {Description: "Token"}}}
+	suggestion := &ReleaseSuggestion{
+		NextVersion: "v2.0.0",
+		Level:       ChangeMajor,
+		Interface:   DiffInterfaces(oldAction, &ActionYML{}),
+		Commits: []git.Commit{
+			{Subject: "feat: remove token input"},
+			{Subject: "chore: bump dependencies"},
+		},
+	}
+
+	summary := suggestion.ConventionalSummary()
+	testutil.AssertStringContains(t, summary, "feat!: release v2.0.0\n")
+	testutil.AssertStringContains(t, summary, "- Input `token` was removed\n- feat: remove token input\n")
+	testutil.AssertStringContains(t, summary, "BREAKING CHANGE: Input `token` was removed")
+	if strings.Contains(summary, "chore: bump dependencies") {
+		t.Error("expected commits without release impact to be omitted")
+	}
+}
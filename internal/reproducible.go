@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/internal/clock"
+)
+
+// generationTime returns the time recorded in generated output: the Unix time
+// in SOURCE_DATE_EPOCH when it is set, otherwise the current time. In
+// reproducible mode without SOURCE_DATE_EPOCH it reports false, and the time is
+// left out of the output.
+func generationTime(config *AppConfig) (time.Time, bool) {
+	if epoch := os.Getenv(EnvSourceDateEpoch); epoch != "" {
+		if seconds, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC(), true
+		}
+	}
+	if config != nil && config.Reproducible {
+		return time.Time{}, false
+	}
+
+	return clock.Now().UTC(), true
+}
+
+// toolVersion returns the tool version recorded in generated output, empty in
+// reproducible mode so output does not change with the tool version.
+func toolVersion(config *AppConfig, version string) string {
+	if config != nil && config.Reproducible {
+		return ""
+	}
+
+	return version
+}
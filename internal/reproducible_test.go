@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/internal/clock"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestGenerationTime(t *testing.T) {
+	config := DefaultAppConfig()
+
+	t.Setenv(EnvSourceDateEpoch, "")
+	if _, ok := generationTime(config); !ok {
+		t.Error("expected the current time outside reproducible mode")
+	}
+	config.Reproducible = true
+	if _, ok := generationTime(config); ok {
+		t.Error("expected no time in reproducible mode without SOURCE_DATE_EPOCH")
+	}
+
+	t.Setenv(EnvSourceDateEpoch, "1700000000")
+	generatedAt, ok := generationTime(config)
+	testutil.AssertEqual(t, true, ok)
+	testutil.AssertEqual(t, time.Unix(1700000000, 0).UTC(), generatedAt)
+
+	t.Setenv(EnvSourceDateEpoch, "")
+	config.Reproducible = false
+	fixed := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	defer clock.Set(clock.NewFixed(fixed))()
+	generatedAt, _ = generationTime(config)
+	testutil.AssertEqual(t, fixed, generatedAt)
+}
+
+func TestJSONWriter_Reproducible(t *testing.T) {
+	t.Setenv(EnvSourceDateEpoch, "")
+
+	config := DefaultAppConfig()
+	config.Reproducible = true
+	action := &ActionYML{Name: "Build", Description: "Builds things"}
+	writer := NewJSONWriter(config)
+
+	first, err := writer.Marshal(action)
+	testutil.AssertNoError(t, err)
+	second, err := writer.Marshal(action)
+	testutil.AssertNoError(t, err)
+	if !bytes.Equal(first, second) {
+		t.Error("expected byte-identical output in reproducible mode")
+	}
+	for _, field := range []string{`"timestamp"`, `"version": "0.1.0"`} {
+		if strings.Contains(string(first), field) {
+			t.Errorf("expected %s to be left out of reproducible output", field)
+		}
+	}
+	if NewAttribution(config).Version != "" {
+		t.Error("expected no tool version in the attribution in reproducible mode")
+	}
+
+	drift, err := NewGenerator(config).DriftReport(nil, ".", nil)
+	testutil.AssertNoError(t, err)
+	if drift.GeneratedAt != nil {
+		t.Errorf("expected no drift report timestamp, got %v", drift.GeneratedAt)
+	}
+}
@@ -0,0 +1,229 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+	"github.com/ivuorinen/gh-action-readme/templates_embed"
+)
+
+// ErrTemplateSandbox is returned when a custom template uses a function outside
+// the allow-list or includes a file outside its allowed roots.
+var ErrTemplateSandbox = errors.New("template sandbox violation")
+
+// sandboxFuncNames is the allow-list of template functions available to custom
+// templates. Each one only transforms its arguments and template data: none
+// reads the environment, files or the network.
+var sandboxFuncNames = []string{
+	"cell", "lower", "upper", "replace", "join", "badgeText", "summary", "ownerURL",
+	"gitOrg", "gitRepo", "gitUsesString", "actionVersion",
+	"defaultValue", "isBlockDefault", "defaultLanguage", "defaultBlock", "yamlBlock",
+}
+
+// templateSandbox restricts the rendering of a custom template, one read from
+// the filesystem rather than embedded in the binary, which may come from an
+// untrusted repository's configuration. It allows only vetted functions, an
+// include helper confined to the template's directory and the configured
+// template_roots, and bounds the output size and rendering time.
+type templateSandbox struct {
+	dir     string // Directory of the template, for relative includes
+	roots   []string
+	limits  ResourceLimits
+	timeout time.Duration
+}
+
+// newTemplateSandbox returns the sandbox of the custom template at templatePath,
+// or nil for embedded templates, which are trusted.
+func newTemplateSandbox(templatePath string, data any) *templateSandbox {
+	if !filepath.IsAbs(templatePath) && templates_embed.IsEmbeddedTemplateAvailable(templatePath) {
+		return nil
+	}
+
+	limits := CurrentResourceLimits()
+	sandbox := &templateSandbox{
+		dir:     filepath.Dir(templatePath),
+		limits:  limits,
+		timeout: time.Duration(limits.RenderTimeout) * time.Second,
+	}
+	if dir, err := resolveRealPath(sandbox.dir); err == nil {
+		sandbox.dir = dir
+	}
+	roots := []string{sandbox.dir}
+	if td, ok := data.(*TemplateData); ok && td.Config != nil {
+		roots = append(roots, td.Config.TemplateRoots...)
+	}
+	for _, root := range roots {
+		if resolved, err := resolveRealPath(root); err == nil {
+			sandbox.roots = append(sandbox.roots, resolved)
+		}
+	}
+
+	return sandbox
+}
+
+// funcs returns the allow-listed functions of funcs and the include helper.
+func (s *templateSandbox) funcs(funcs template.FuncMap) template.FuncMap {
+	if s == nil {
+		return funcs
+	}
+
+	allowed := make(template.FuncMap, len(sandboxFuncNames)+1)
+	for _, name := range sandboxFuncNames {
+		if fn, ok := funcs[name]; ok {
+			allowed[name] = fn
+		}
+	}
+	allowed["include"] = s.include
+
+	return allowed
+}
+
+// data returns template data without credentials: the GitHub token, the
+// repository overrides of the global configuration and any user info in the
+// Git remote URL.
+func (s *templateSandbox) data(action any) any {
+	td, ok := action.(*TemplateData)
+	if s == nil || !ok {
+		return action
+	}
+
+	redacted := *td
+	if td.Config != nil {
+		config := *td.Config
+		config.GitHubToken = ""
+		config.Notify.SlackWebhook = ""
+		config.Notify.TeamsWebhook = ""
+		config.Deps.Registries = make(map[string]dependencies.RegistryCredentials, len(td.Config.Deps.Registries))
+		for host, creds := range td.Config.Deps.Registries {
+			creds.Token = ""
+			config.Deps.Registries[host] = creds
+		}
+		config.RepoOverrides = nil
+		redacted.Config = &config
+	}
+	if remote, err := url.Parse(td.Git.RemoteURL); err == nil && remote.User != nil {
+		remote.User = nil
+		redacted.Git.RemoteURL = remote.String()
+	}
+
+	return &redacted
+}
+
+// execute renders tmpl into w. Custom templates fail once their output passes
+// limits.max_render_size or rendering takes longer than limits.render_timeout.
+func (s *templateSandbox) execute(tmpl *template.Template, w io.Writer, data any) error {
+	if s == nil {
+		return tmpl.Execute(w, data)
+	}
+
+	var buf bytes.Buffer
+	limited := &limitedWriter{w: &buf, remaining: s.limits.MaxRenderSize}
+	done := make(chan error, 1)
+	go func() { done <- tmpl.Execute(limited, data) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(buf.Bytes())
+
+		return err
+	case <-time.After(s.timeout):
+		// Templates cannot be interrupted; the next write fails instead.
+		limited.stopped.Store(true)
+
+		return fmt.Errorf("%w: rendering %s took longer than limits.render_timeout (%s)",
+			ErrResourceLimit, tmpl.Name(), s.timeout)
+	}
+}
+
+// include returns the content of a file for a custom template. Relative names
+// are resolved against the template's directory, and the file must lie within
+// one of the allowed roots and be at most limits.max_file_size bytes.
+func (s *templateSandbox) include(name string) (string, error) {
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.dir, path)
+	}
+	resolved, err := resolveRealPath(path)
+	if abs, _ := filepath.Abs(path); err != nil && s.allows(abs) {
+		return "", fmt.Errorf("include %q: %w", name, err)
+	}
+	if err != nil || !s.allows(resolved) {
+		return "", fmt.Errorf("%w: include %q is outside the template directory and template_roots",
+			ErrTemplateSandbox, name)
+	}
+
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", fmt.Errorf("include %q: %w", name, err)
+	}
+	if info.Size() > int64(s.limits.MaxFileSize) {
+		return "", fmt.Errorf("%w: include %q is larger than limits.max_file_size (%d bytes)",
+			ErrResourceLimit, name, s.limits.MaxFileSize)
+	}
+	content, err := os.ReadFile(resolved) // #nosec G304 -- confined to the allowed template roots
+	if err != nil {
+		return "", fmt.Errorf("include %q: %w", name, err)
+	}
+
+	return string(content), nil
+}
+
+// allows reports whether path lies within one of the allowed roots.
+func (s *templateSandbox) allows(path string) bool {
+	for _, root := range s.roots {
+		if pathWithin(root, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pathWithin reports whether path is root or lies below it.
+func pathWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// resolveRealPath returns the absolute path of path with symbolic links resolved.
+func resolveRealPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.EvalSymlinks(abs)
+}
+
+// limitedWriter fails writes once remaining bytes are used up or it is stopped.
+type limitedWriter struct {
+	w         io.Writer
+	remaining int
+	stopped   atomic.Bool
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.stopped.Load() {
+		return 0, fmt.Errorf("%w: rendering stopped after limits.render_timeout", ErrResourceLimit)
+	}
+	if len(p) > l.remaining {
+		return 0, fmt.Errorf("%w: template output is larger than limits.max_render_size", ErrResourceLimit)
+	}
+	l.remaining -= len(p)
+
+	return l.w.Write(p)
+}
@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"html"
+	"maps"
+	"strings"
+)
+
+// htmlTagMarker stands in for the '<' opening a raw HTML tag in action.yml
+// text while a Markdown template renders. YAML text cannot contain NUL, so the
+// marker never clashes with real content.
+const htmlTagMarker = "\x00"
+
+// markHTMLTags replaces the '<' opening each HTML tag, closing tag, comment or
+// processing instruction of text with htmlTagMarker. Autolinks such as
+// <https://example.com> and comparisons such as "a < b" are kept.
+func markHTMLTags(text string) string {
+	if !strings.Contains(text, "<") {
+		return text
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '<' && opensHTMLTag(text[i+1:]) {
+			b.WriteString(htmlTagMarker)
+
+			continue
+		}
+		b.WriteByte(text[i])
+	}
+
+	return b.String()
+}
+
+// opensHTMLTag reports whether rest, the text after a '<', starts an HTML tag,
+// closing tag, comment or processing instruction rather than an autolink or a
+// comparison.
+func opensHTMLTag(rest string) bool {
+	if rest == "" {
+		return false
+	}
+	if rest[0] == '/' || rest[0] == '!' || rest[0] == '?' {
+		return true
+	}
+	if !isASCIILetter(rest[0]) {
+		return false
+	}
+	end := strings.IndexAny(rest, " \t\n/>")
+
+	return end < 0 || !strings.Contains(rest[:end], ":")
+}
+
+// isASCIILetter reports whether c is an ASCII letter.
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// resolveHTMLMarkers turns the markers left by markHTMLTags in rendered
+// Markdown back into text: '<' in fenced code blocks and code spans, which
+// Markdown shows literally, and "&lt;" elsewhere so the tags render as text.
+func resolveHTMLMarkers(content string) string {
+	if !strings.Contains(content, htmlTagMarker) {
+		return content
+	}
+
+	var b strings.Builder
+	inFence := false
+	for _, line := range strings.SplitAfter(content, "\n") {
+		fence := strings.HasPrefix(strings.TrimSpace(line), "```")
+		if fence {
+			inFence = !inFence
+		}
+		if fence || inFence {
+			b.WriteString(strings.ReplaceAll(line, htmlTagMarker, "<"))
+
+			continue
+		}
+		inCode := false
+		for _, r := range line {
+			switch {
+			case r == '`':
+				inCode = !inCode
+			case string(r) == htmlTagMarker && inCode:
+				r = '<'
+			case string(r) == htmlTagMarker:
+				b.WriteString("&lt;")
+
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// sanitizeTemplateData returns a copy of template data whose action.yml text
+// is made safe for the output format. In Markdown the raw HTML tags of the
+// text are marked for resolveHTMLMarkers; in HTML output, where the text is
+// embedded in the page as is, all markup is escaped. Other formats, other data
+// and configurations with trust_content set are returned unchanged.
+func sanitizeTemplateData(action any, format string) any {
+	data, ok := action.(*TemplateData)
+	if !ok || data.ActionYML == nil || (data.Config != nil && data.Config.TrustContent) {
+		return action
+	}
+
+	sanitized := *data
+	switch format {
+	case OutputFormatMD:
+		sanitized.ActionYML = sanitizeAction(data.ActionYML, markHTMLTags)
+	case OutputFormatHTML:
+		sanitized.ActionYML = sanitizeAction(data.ActionYML, html.EscapeString)
+	default:
+		return action
+	}
+
+	return &sanitized
+}
+
+// sanitizeAction returns a copy of action with its name, author, descriptions
+// and string defaults passed through escape.
+func sanitizeAction(action *ActionYML, escape func(string) string) *ActionYML {
+	sanitized := *action
+	sanitized.Name = escape(action.Name)
+	sanitized.Author = escape(action.Author)
+	sanitized.Description = escape(action.Description)
+
+	sanitized.Inputs = maps.Clone(action.Inputs)
+	for name, input := range sanitized.Inputs {
+		input.Description = escape(input.Description)
+		input.DeprecationMessage = escape(input.DeprecationMessage)
+		if value, ok := input.Default.(string); ok {
+			input.Default = escape(value)
+		}
+		sanitized.Inputs[name] = input
+	}
+	sanitized.Outputs = maps.Clone(action.Outputs)
+	for name, output := range sanitized.Outputs {
+		output.Description = escape(output.Description)
+		sanitized.Outputs[name] = output
+	}
+
+	return &sanitized
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestMarkHTMLTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "plain text", text: "Deploys the app", want: "Deploys the app"},
+		{name: "comparison", text: "if a < b and b <= c", want: "if a < b and b <= c"},
+		{name: "autolink", text: "see <https://example.com>", want: "see <https://example.com>"},
+		{name: "tag", text: "<script>x</script>", want: "&lt;script>x&lt;/script>"},
+		{name: "comment", text: "<!-- gh-action-readme:end inputs -->", want: "&lt;!-- gh-action-readme:end inputs -->"},
+		{name: "code span", text: "the `<path>` to use", want: "the `<path>` to use"},
+		{name: "fenced code", text: "run:\n```\n<b>\n```\n<b>", want: "run:\n```\n<b>\n```\n&lt;b>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			testutil.AssertEqual(t, tt.want, resolveHTMLMarkers(markHTMLTags(tt.text)))
+		})
+	}
+}
+
+func TestRenderReadme_SanitizesActionText(t *testing.T) {
+	t.Parallel()
+
+	action := &ActionYML{
+		Name:        "Greeter",
+		Description: "Greets <script>alert(1)</script> people",
+		Inputs: map[string]ActionInput{
+			"name": {Description: "The <name> to greet <!-- gh-action-readme:end inputs -->", Default: "<you>"},
+		},
+		Outputs: map[string]ActionOutput{"time": {Description: `Greeting <img src="x">`}},
+		Runs:    map[string]any{"using": "node20", "main": "index.js"},
+	}
+	config := DefaultAppConfig()
+	data := BuildTemplateData(action, config, "", "")
+	template := "templates/themes/github/readme.tmpl"
+
+	md, err := RenderReadme(data, TemplateOptions{TemplatePath: template, Format: OutputFormatMD})
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, md, "> Greets &lt;script>alert(1)&lt;/script> people")
+	testutil.AssertStringContains(t, md,
+		"| `name` | The &lt;name> to greet &lt;!-- gh-action-readme:end inputs --> | ❌ | `<you>` |")
+	testutil.AssertStringContains(t, md, "          name: \"<you>\"")
+	testutil.AssertStringContains(t, md, "| `time` | Greeting &lt;img src=\"x\"> |")
+
+	page, err := RenderReadme(data, TemplateOptions{TemplatePath: template, Format: OutputFormatHTML})
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, page, "> Greets &lt;script&gt;alert(1)&lt;/script&gt; people")
+	testutil.AssertStringContains(t, page, "`&lt;you&gt;`")
+	if strings.Contains(page, "<script>") || strings.Contains(page, "<img") {
+		t.Errorf("HTML output contains raw markup from action.yml:\n%s", page)
+	}
+
+	sections, err := RenderManagedSections(data)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, sections[SectionInputs], "&lt;!-- gh-action-readme:end inputs -->")
+
+	trusted := *config
+	trusted.TrustContent = true
+	md, err = RenderReadme(BuildTemplateData(action, &trusted, "", ""),
+		TemplateOptions{TemplatePath: template, Format: OutputFormatMD})
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, md, "> Greets <script>alert(1)</script> people")
+	testutil.AssertEqual(t, "Greets <script>alert(1)</script> people", action.Description)
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// SearchIndexSchemaVersion is the version of the search index JSON schema.
+const SearchIndexSchemaVersion = "1"
+
+// SearchIndexFileName is the default file name of a search index.
+const SearchIndexFileName = "search-index.json"
+
+// SearchIndex lists the searchable fields of every action in a catalog.
+type SearchIndex struct {
+	SchemaVersion string        `json:"schema_version"`
+	Actions       []SearchEntry `json:"actions"`
+}
+
+// SearchEntry holds the searchable fields of one action.
+type SearchEntry struct {
+	File        string   `json:"file"`
+	URL         string   `json:"url,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Inputs      []string `json:"inputs,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// BuildSearchIndex indexes the action files in paths. File names are relative to
+// baseDir with forward slashes; pageURL, when not nil, maps an action to its page URL.
+// Files that cannot be parsed are skipped.
+func BuildSearchIndex(
+	paths []string,
+	baseDir string,
+	pageURL func(actionPath string, action *ActionYML) string,
+) *SearchIndex {
+	index := &SearchIndex{
+		SchemaVersion: SearchIndexSchemaVersion,
+		Actions:       make([]SearchEntry, 0, len(paths)),
+	}
+	for _, path := range paths {
+		action, err := ParseActionYML(path)
+		if err != nil {
+			continue
+		}
+
+		entry := SearchEntry{
+			File:        relativeSlashPath(baseDir, path),
+			Name:        action.Name,
+			Description: action.Description,
+		}
+		if pageURL != nil {
+			entry.URL = pageURL(path, action)
+		}
+		for _, input := range action.InputList() {
+			entry.Inputs = append(entry.Inputs, input.Name)
+		}
+		if metadata, err := LoadActionMetadata(path); err == nil {
+			entry.Category = metadata.Category
+			entry.Tags = metadata.Tags
+		}
+		index.Actions = append(index.Actions, entry)
+	}
+
+	return index
+}
+
+// WriteSearchIndex writes the search index for paths to outputPath. Each entry
+// links to the documentation generated for it, relative to outputPath.
+func (g *Generator) WriteSearchIndex(paths []string, outputPath string) error {
+	baseDir := filepath.Dir(outputPath)
+	index := BuildSearchIndex(paths, baseDir, func(actionPath string, action *ActionYML) string {
+		return (&url.URL{Path: relativeSlashPath(baseDir, g.outputPath(action, actionPath))}).String()
+	})
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode search index: %w", err)
+	}
+	if err := os.WriteFile(outputPath, data, FilePermDefault); err != nil {
+		// #nosec G306 -- search index file permissions
+		return fmt.Errorf("failed to write search index to %s: %w", outputPath, err)
+	}
+	g.recordOutput(outputPath)
+
+	g.Output.Success("Generated search index: %s", outputPath)
+
+	return nil
+}
+
+// relativeSlashPath returns path relative to baseDir with forward slashes, or
+// path itself when it cannot be made relative.
+func relativeSlashPath(baseDir, path string) string {
+	rel, err := filepath.Rel(baseDir, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestLoadActionMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		files       map[string]string
+		expectTags  int
+		expectError bool
+	}{
+		{
+			name:       "no sidecar",
+			expectTags: 0,
+		},
+		{
+			name:       "yml sidecar",
+			files:      map[string]string{"action.meta.yml": "tags: [deploy, aws]\n"},
+			expectTags: 2,
+		},
+		{
+			name:       "yaml sidecar",
+			files:      map[string]string{"action.meta.yaml": "tags:\n  - lint\n"},
+			expectTags: 1,
+		},
+		{
+			name:        "invalid sidecar",
+			files:       map[string]string{"action.meta.yml": "tags: {broken\n"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir, cleanup := testutil.TempDir(t)
+			defer cleanup()
+			actionPath := filepath.Join(tmpDir, "action.yml")
+			testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+			for name, content := range tt.files {
+				testutil.WriteTestFile(t, filepath.Join(tmpDir, name), content)
+			}
+
+			metadata, err := LoadActionMetadata(actionPath)
+			if tt.expectError {
+				testutil.AssertError(t, err)
+
+				return
+			}
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, tt.expectTags, len(metadata.Tags))
+		})
+	}
+}
+
+func TestBuildSearchIndex(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	jsAction := filepath.Join(tmpDir, "action.yml")
+	compositeAction := filepath.Join(tmpDir, "build", "action.yml")
+	invalidAction := filepath.Join(tmpDir, "broken", "action.yml")
+	testutil.WriteTestFile(t, jsAction, testutil.MustReadFixture("actions/javascript/simple.yml"))
+	testutil.WriteTestFile(t, compositeAction, testutil.MustReadFixture("actions/composite/basic.yml"))
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "build", "action.meta.yml"), "tags: [build, node]\n")
+	testutil.WriteTestFile(t, invalidAction, "name: [unterminated\n")
+
+	index := BuildSearchIndex([]string{jsAction, compositeAction, invalidAction}, tmpDir, nil)
+
+	testutil.AssertEqual(t, SearchIndexSchemaVersion, index.SchemaVersion)
+	if len(index.Actions) != 2 {
+		t.Fatalf("expected 2 indexed actions, got %d", len(index.Actions))
+	}
+	js := index.Actions[0]
+	testutil.AssertEqual(t, "action.yml", js.File)
+	testutil.AssertEqual(t, "Simple JavaScript Action", js.Name)
+	testutil.AssertEqual(t, "", js.URL)
+	if len(js.Inputs) == 0 {
+		t.Error("expected inputs to be indexed")
+	}
+	composite := index.Actions[1]
+	testutil.AssertEqual(t, "build/action.yml", composite.File)
+	testutil.AssertEqual(t, "build node", strings.Join(composite.Tags, " "))
+}
+
+func TestGenerator_WriteSearchIndex(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "my action", "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+
+	config := DefaultAppConfig()
+	config.OutputFormat = OutputFormatHTML
+	generator := NewGeneratorWithDependencies(config, NewNullOutput(), NewNullProgressManager())
+
+	indexPath := filepath.Join(tmpDir, SearchIndexFileName)
+	testutil.AssertNoError(t, generator.WriteSearchIndex([]string{actionPath}, indexPath))
+
+	data, err := os.ReadFile(indexPath) // #nosec G304 -- test file path
+	testutil.AssertNoError(t, err)
+	var index SearchIndex
+	testutil.AssertNoError(t, json.Unmarshal(data, &index))
+	if len(index.Actions) != 1 {
+		t.Fatalf("expected 1 indexed action, got %d", len(index.Actions))
+	}
+	testutil.AssertEqual(t, "my action/action.yml", index.Actions[0].File)
+	testutil.AssertEqual(t, "my%20action/Simple%20JavaScript%20Action.html", index.Actions[0].URL)
+}
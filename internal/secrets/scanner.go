@@ -0,0 +1,157 @@
+// Package secrets detects accidental credential leaks in generated documentation.
+package secrets
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// redactKeepChars is the number of leading characters kept when redacting a match.
+	redactKeepChars = 4
+	// minSourceValueLength is the shortest registered value that is tracked as a secret source.
+	// Shorter values produce too many false positives when searched for literally.
+	minSourceValueLength = 8
+)
+
+// Detector describes a single secret pattern.
+type Detector struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// Finding represents a secret-looking string found in rendered content.
+type Finding struct {
+	Detector   string `json:"detector"`
+	Line       int    `json:"line"`
+	Match      string `json:"match"`      // Redacted match
+	Expression string `json:"expression"` // Template expression the value most likely came from
+}
+
+// Scanner scans rendered content for secrets.
+type Scanner struct {
+	detectors []Detector
+	sources   []source
+}
+
+// source links a known value to the template expression that exposes it.
+type source struct {
+	value      string
+	expression string
+	sensitive  bool // Report whenever the value appears, even without a detector match
+}
+
+// DefaultDetectors returns the built-in set of secret detectors.
+func DefaultDetectors() []Detector {
+	return []Detector{
+		{Name: "github-token", Pattern: regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,255}\b`)},
+		{Name: "github-fine-grained-token", Pattern: regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{22,255}\b`)},
+		{Name: "aws-access-key-id", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+		{Name: "slack-token", Pattern: regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`)},
+		{Name: "google-api-key", Pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+		{Name: "stripe-secret-key", Pattern: regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{24,}\b`)},
+		{Name: "private-key", Pattern: regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`)},
+	}
+}
+
+// NewScanner creates a scanner with the default detectors.
+func NewScanner() *Scanner {
+	return &Scanner{
+		detectors: DefaultDetectors(),
+	}
+}
+
+// WithSource registers a value and the template expression that exposes it, so that
+// detector matches inside the value can be attributed to that expression.
+func (s *Scanner) WithSource(expression, value string) *Scanner {
+	return s.addSource(expression, value, false)
+}
+
+// WithSecret registers a known secret value. Secrets are reported whenever they appear
+// in the content, even if no detector matches, so tokens with unknown formats are caught.
+func (s *Scanner) WithSecret(expression, value string) *Scanner {
+	return s.addSource(expression, value, true)
+}
+
+// Scan returns all findings in content, ordered by line number.
+func (s *Scanner) Scan(content string) []Finding {
+	var findings []Finding
+
+	for i, line := range strings.Split(content, "\n") {
+		findings = append(findings, s.scanLine(line, i+1)...)
+	}
+
+	return findings
+}
+
+// scanLine scans a single line with every detector and registered source.
+func (s *Scanner) scanLine(line string, lineNumber int) []Finding {
+	var findings []Finding
+	seen := make(map[string]bool)
+
+	for _, detector := range s.detectors {
+		for _, match := range detector.Pattern.FindAllString(line, -1) {
+			seen[match] = true
+			findings = append(findings, Finding{
+				Detector:   detector.Name,
+				Line:       lineNumber,
+				Match:      Redact(match),
+				Expression: s.expressionFor(match),
+			})
+		}
+	}
+
+	for _, src := range s.sources {
+		if !src.sensitive || seen[src.value] || !strings.Contains(line, src.value) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Detector:   "configured-value",
+			Line:       lineNumber,
+			Match:      Redact(src.value),
+			Expression: src.expression,
+		})
+	}
+
+	return findings
+}
+
+// expressionFor finds the registered template expression for a matched value.
+func (s *Scanner) expressionFor(match string) string {
+	for _, src := range s.sources {
+		if strings.Contains(src.value, match) || strings.Contains(match, src.value) {
+			return src.expression
+		}
+	}
+
+	return ""
+}
+
+// addSource registers a value unless it is too short to search for reliably.
+// A value registered again is only recorded once, as a secret when either
+// registration is one.
+func (s *Scanner) addSource(expression, value string, sensitive bool) *Scanner {
+	value = strings.TrimSpace(value)
+	if len(value) < minSourceValueLength {
+		return s
+	}
+	for i := range s.sources {
+		if s.sources[i].value == value {
+			s.sources[i].sensitive = s.sources[i].sensitive || sensitive
+
+			return s
+		}
+	}
+	s.sources = append(s.sources, source{value: value, expression: expression, sensitive: sensitive})
+
+	return s
+}
+
+// Redact masks a secret, keeping only a short prefix for identification.
+func Redact(value string) string {
+	if len(value) <= redactKeepChars {
+		return strings.Repeat("*", len(value))
+	}
+
+	return value[:redactKeepChars] + strings.Repeat("*", len(value)-redactKeepChars)
+}
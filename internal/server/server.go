@@ -0,0 +1,380 @@
+// Package server serves generated action documentation over HTTP, rendering each
+// page on request so changes to action files and templates show up immediately.
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/internal"
+	"github.com/ivuorinen/gh-action-readme/internal/clock"
+	"github.com/ivuorinen/gh-action-readme/internal/metrics"
+	"github.com/ivuorinen/gh-action-readme/schemas"
+)
+
+// Routes served by the server.
+const (
+	docsPrefix     = "/docs/"
+	apiPrefix      = "/api/actions"
+	metricsPath    = "/metrics"
+	searchPath     = "/" + internal.SearchIndexFileName
+	schemaPath     = "/" + schemas.ActionSchemaFile
+	liveReloadPath = "/__livereload"
+)
+
+// Server timeouts.
+const (
+	// readHeaderTimeout bounds the time a client may take to send request headers.
+	readHeaderTimeout = 10 * time.Second
+	// shutdownTimeout bounds the time open requests get to finish on shutdown.
+	shutdownTimeout = 5 * time.Second
+)
+
+// liveReloadScript polls the live-reload endpoint and reloads the page when
+// an action file or template changes.
+const liveReloadScript = `<script>
+(function () {
+  var version = null;
+  setInterval(function () {
+    fetch("` + liveReloadPath + `").then(function (r) { return r.json(); }).then(function (d) {
+      if (version !== null && d.version !== version) { location.reload(); }
+      version = d.version;
+    }).catch(function () {});
+  }, 1000);
+})();
+</script>
+`
+
+// indexTemplate lists the served actions.
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <title>GitHub Actions</title>
+  <meta name="viewport" content="width=device-width, initial-scale=1.0">
+  <style>body { font-family: system-ui, sans-serif; margin: 2rem; background: #f9f9fb; }</style>
+</head>
+<body>
+<h1>GitHub Actions</h1>
+<input id="search" type="search" placeholder="Search names, inputs, categories and tags" size="50" autofocus>
+<ul id="actions">
+{{- range .Actions}}
+  <li data-file="{{.File}}"><a href="{{.DocsURL}}">{{.Name}}</a> <code>{{.File}}</code>
+  {{- if .Description}} &mdash; {{.Description}}{{end}}
+  {{- if .APIURL}} (<a href="{{.APIURL}}">JSON</a>){{end}}</li>
+{{- else}}
+  <li>No action files found.</li>
+{{- end}}
+</ul>
+<script>
+(function () {
+  var entries = {};
+  fetch("{{.SearchURL}}").then(function (r) { return r.json(); }).then(function (index) {
+    index.actions.forEach(function (a) {
+      var words = [a.name, a.description, a.category || ""].concat(a.inputs || [], a.tags || []);
+      entries[a.file] = words.join(" ").toLowerCase();
+    });
+  });
+  document.getElementById("search").addEventListener("input", function (e) {
+    var terms = e.target.value.toLowerCase().split(/\s+/).filter(Boolean);
+    document.querySelectorAll("#actions li[data-file]").forEach(function (li) {
+      var text = entries[li.dataset.file] || li.textContent.toLowerCase();
+      li.hidden = !terms.every(function (t) { return text.indexOf(t) >= 0; });
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`))
+
+// Server serves documentation for the action files below Root.
+type Server struct {
+	Root       string
+	Recursive  bool
+	API        bool // Serve parsed action metadata under /api/actions
+	LiveReload bool // Reload open pages when action files or templates change
+
+	generator *internal.Generator
+}
+
+// ActionSummary is an entry in the action index and the JSON API listing.
+type ActionSummary struct {
+	File        string `json:"file"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	DocsURL     string `json:"docs_url"`
+	APIURL      string `json:"api_url,omitempty"`
+}
+
+// New creates a server for the actions below root, rendered with generator's configuration.
+func New(root string, generator *internal.Generator) *Server {
+	return &Server{
+		Root:       root,
+		Recursive:  true,
+		LiveReload: true,
+		generator:  generator,
+	}
+}
+
+// Handler returns the HTTP handler serving the documentation site, the JSON API
+// when enabled, the action.yml schema, live-reload and Prometheus metrics. Only
+// GET requests are served.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.serveIndex)
+	mux.HandleFunc("GET "+docsPrefix+"{file...}", s.serveDocs)
+	mux.HandleFunc("GET "+searchPath, s.serveSearchIndex)
+	mux.HandleFunc("GET "+schemaPath, serveSchema)
+	mux.Handle("GET "+metricsPath, metrics.Handler())
+	if s.LiveReload {
+		mux.HandleFunc("GET "+liveReloadPath, s.serveLiveReload)
+	}
+	if s.API {
+		mux.HandleFunc("GET "+apiPrefix, s.serveActionList)
+		mux.HandleFunc("GET "+apiPrefix+"/{file...}", s.serveAction)
+	}
+
+	return mux
+}
+
+// ListenAndServe serves Handler on addr until ctx is canceled, then shuts down
+// gracefully. It returns nil after a shutdown.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Actions lists the action files currently below Root.
+func (s *Server) Actions() ([]ActionSummary, error) {
+	files, err := internal.DiscoverActionFiles(s.Root, s.Recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]ActionSummary, 0, len(files))
+	for _, file := range files {
+		rel := s.relativePath(file)
+		summary := ActionSummary{File: rel, Name: rel, DocsURL: docsPrefix + rel}
+		if s.API {
+			summary.APIURL = apiPrefix + "/" + rel
+		}
+		if action, err := internal.ParseActionYML(file); err == nil {
+			summary.Name = action.Name
+			summary.Description = action.Description
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// serveIndex lists the actions with links to their documentation.
+func (s *Server) serveIndex(w http.ResponseWriter, _ *http.Request) {
+	actions, err := s.Actions()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	var b strings.Builder
+	data := struct {
+		Actions   []ActionSummary
+		SearchURL string
+	}{actions, searchPath}
+	if err := indexTemplate.Execute(&b, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+	s.writeHTML(w, b.String())
+}
+
+// serveDocs renders the HTML documentation of one action.
+func (s *Server) serveDocs(w http.ResponseWriter, r *http.Request) {
+	path, ok := s.resolveAction(r.PathValue("file"))
+	if !ok {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	start := clock.Now()
+	content, err := s.generator.RenderHTML(path)
+	if err != nil {
+		metrics.ObserveGeneration(internal.OutputFormatHTML, metrics.StatusError, clock.Since(start))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+	metrics.ObserveGeneration(internal.OutputFormatHTML, metrics.StatusSuccess, clock.Since(start))
+	s.writeHTML(w, content)
+}
+
+// serveSearchIndex returns the search index of the served actions.
+func (s *Server) serveSearchIndex(w http.ResponseWriter, _ *http.Request) {
+	files, err := internal.DiscoverActionFiles(s.Root, s.Recursive)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	index := internal.BuildSearchIndex(files, s.Root, func(actionPath string, _ *internal.ActionYML) string {
+		return docsPrefix + s.relativePath(actionPath)
+	})
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+	writeJSON(w, data)
+}
+
+// serveSchema serves the action.yml schema, for editors associating it with action files.
+func serveSchema(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, schemas.ActionSchema)
+}
+
+// serveActionList lists the actions as JSON.
+func (s *Server) serveActionList(w http.ResponseWriter, _ *http.Request) {
+	actions, err := s.Actions()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	data, err := json.MarshalIndent(actions, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+	writeJSON(w, data)
+}
+
+// serveAction returns the parsed metadata of one action, in the same shape as
+// the json output format.
+func (s *Server) serveAction(w http.ResponseWriter, r *http.Request) {
+	path, ok := s.resolveAction(r.PathValue("file"))
+	if !ok {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	data, err := s.generator.RenderJSON(path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+	writeJSON(w, data)
+}
+
+// serveLiveReload reports a version that changes whenever an action file or
+// template changes.
+func (s *Server) serveLiveReload(w http.ResponseWriter, _ *http.Request) {
+	data, err := json.Marshal(map[string]string{"version": s.version()})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, data)
+}
+
+// resolveAction maps a path relative to Root to a discovered action file, so
+// only action files can be served.
+func (s *Server) resolveAction(rel string) (string, bool) {
+	files, err := internal.DiscoverActionFiles(s.Root, s.Recursive)
+	if err != nil {
+		return "", false
+	}
+	for _, file := range files {
+		if s.relativePath(file) == rel {
+			return file, true
+		}
+	}
+
+	return "", false
+}
+
+// relativePath returns file relative to Root using forward slashes.
+func (s *Server) relativePath(file string) string {
+	rel, err := filepath.Rel(s.Root, file)
+	if err != nil {
+		rel = file
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// version fingerprints the modification times and sizes of the action files
+// and configured templates.
+func (s *Server) version() string {
+	files, _ := internal.DiscoverActionFiles(s.Root, s.Recursive)
+	config := s.generator.Config
+	files = append(files, config.Template, config.Header, config.Footer)
+
+	hash := sha256.New()
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+		if info, err := os.Stat(file); err == nil {
+			_, _ = fmt.Fprintf(hash, "%s\x00%d\x00%d\n", file, info.ModTime().UnixNano(), info.Size())
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// writeHTML writes an HTML page, adding the live-reload script when enabled.
+func (s *Server) writeHTML(w http.ResponseWriter, content string) {
+	if s.LiveReload {
+		if i := strings.LastIndex(content, "</body>"); i >= 0 {
+			content = content[:i] + liveReloadScript + content[i:]
+		} else {
+			content += liveReloadScript
+		}
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(content))
+}
+
+// writeJSON writes a JSON response body.
+func writeJSON(w http.ResponseWriter, data []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/internal"
+	"github.com/ivuorinen/gh-action-readme/schemas"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// newTestServer serves a directory with one JavaScript action and one composite action.
+func newTestServer(t *testing.T, api bool) (*httptest.Server, string) {
+	t.Helper()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	t.Cleanup(cleanup)
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "action.yml"),
+		testutil.MustReadFixture("actions/javascript/simple.yml"))
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "build", "action.yml"),
+		testutil.MustReadFixture("actions/composite/basic.yml"))
+
+	config := internal.DefaultAppConfig()
+	config.Theme = "github"
+	srv := New(tmpDir, internal.NewGenerator(config))
+	srv.API = api
+
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+
+	return ts, tmpDir
+}
+
+// get fetches path and returns the status code and body.
+func get(t *testing.T, ts *httptest.Server, path string) (int, string) {
+	t.Helper()
+
+	resp, err := http.Get(ts.URL + path)
+	testutil.AssertNoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	testutil.AssertNoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
+
+func TestServer_Pages(t *testing.T) {
+	t.Parallel()
+
+	ts, _ := newTestServer(t, false)
+
+	tests := []struct {
+		name         string
+		path         string
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name:         "index lists actions",
+			path:         "/",
+			expectStatus: http.StatusOK,
+			expectBody:   `href="/docs/build/action.yml"`,
+		},
+		{
+			name:         "docs render on demand",
+			path:         "/docs/action.yml",
+			expectStatus: http.StatusOK,
+			expectBody:   "Simple JavaScript Action",
+		},
+		{
+			name:         "docs include live-reload",
+			path:         "/docs/build/action.yml",
+			expectStatus: http.StatusOK,
+			expectBody:   liveReloadPath,
+		},
+		{
+			name:         "unknown action",
+			path:         "/docs/missing/action.yml",
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "path traversal",
+			path:         "/docs/..%2F..%2Fetc%2Fpasswd",
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "api disabled",
+			path:         "/api/actions",
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "metrics",
+			path:         "/metrics",
+			expectStatus: http.StatusOK,
+			expectBody:   `gh_action_readme_generations_total{format="html",status="success"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Render a page first so the metrics test has a sample to find
+			_, _ = get(t, ts, "/docs/action.yml")
+
+			status, body := get(t, ts, tt.path)
+			testutil.AssertEqual(t, tt.expectStatus, status)
+			if tt.expectBody != "" {
+				testutil.AssertStringContains(t, body, tt.expectBody)
+			}
+		})
+	}
+}
+
+func TestServer_API(t *testing.T) {
+	t.Parallel()
+
+	ts, _ := newTestServer(t, true)
+
+	status, body := get(t, ts, "/api/actions")
+	testutil.AssertEqual(t, http.StatusOK, status)
+	var actions []ActionSummary
+	testutil.AssertNoError(t, json.Unmarshal([]byte(body), &actions))
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 actions, got %d", len(actions))
+	}
+	testutil.AssertEqual(t, ActionSummary{
+		File:        "action.yml",
+		Name:        "Simple JavaScript Action",
+		Description: "A simple JavaScript action for testing",
+		DocsURL:     "/docs/action.yml",
+		APIURL:      "/api/actions/action.yml",
+	}, actions[0])
+
+	status, body = get(t, ts, "/api/actions/build/action.yml")
+	testutil.AssertEqual(t, http.StatusOK, status)
+	testutil.AssertStringContains(t, body, `"using": "composite"`)
+
+	resp, err := http.Post(ts.URL+"/api/actions", "application/json", nil)
+	testutil.AssertNoError(t, err)
+	_ = resp.Body.Close()
+	testutil.AssertEqual(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestServer_LiveReloadVersion(t *testing.T) {
+	t.Parallel()
+
+	ts, tmpDir := newTestServer(t, false)
+
+	version := func() string {
+		status, body := get(t, ts, liveReloadPath)
+		testutil.AssertEqual(t, http.StatusOK, status)
+		var payload map[string]string
+		testutil.AssertNoError(t, json.Unmarshal([]byte(body), &payload))
+
+		return payload["version"]
+	}
+
+	before := version()
+	testutil.AssertEqual(t, before, version())
+
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	later := time.Now().Add(time.Minute)
+	testutil.AssertNoError(t, os.Chtimes(actionPath, later, later))
+	if version() == before {
+		t.Error("expected version to change after an action file changed")
+	}
+}
+
+func TestServer_SearchIndex(t *testing.T) {
+	t.Parallel()
+
+	ts, tmpDir := newTestServer(t, false)
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "build", "action.meta.yml"), "tags: [ci]\n")
+
+	status, body := get(t, ts, searchPath)
+	testutil.AssertEqual(t, http.StatusOK, status)
+	var index internal.SearchIndex
+	testutil.AssertNoError(t, json.Unmarshal([]byte(body), &index))
+	if len(index.Actions) != 2 {
+		t.Fatalf("expected 2 indexed actions, got %d", len(index.Actions))
+	}
+	build := index.Actions[1]
+	testutil.AssertEqual(t, "/docs/build/action.yml", build.URL)
+	testutil.AssertEqual(t, "ci", build.Tags[0])
+
+	_, body = get(t, ts, "/")
+	testutil.AssertStringContains(t, body, `id="search"`)
+}
+
+func TestServer_Schema(t *testing.T) {
+	t.Parallel()
+
+	ts, _ := newTestServer(t, false)
+	status, body := get(t, ts, schemaPath)
+	testutil.AssertEqual(t, http.StatusOK, status)
+	testutil.AssertEqual(t, string(schemas.ActionSchema), body)
+}
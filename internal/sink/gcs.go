@@ -0,0 +1,59 @@
+package sink
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	// gcsEndpoint is the XML API of Google Cloud Storage.
+	gcsEndpoint = "https://storage.googleapis.com"
+	// EnvGCSAccessToken holds the OAuth access token of GCS uploads, such as
+	// the output of gcloud auth print-access-token.
+	EnvGCSAccessToken = "GOOGLE_OAUTH_ACCESS_TOKEN"
+)
+
+// gcsStore puts objects into a Google Cloud Storage bucket through the XML API,
+// which stores the Cache-Control and Content-Type headers as object metadata.
+type gcsStore struct {
+	bucket   string
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+// newGCSStore creates a GCS store with the access token of the environment.
+func newGCSStore(bucket string, opts Options, client *http.Client) (*gcsStore, error) {
+	token := os.Getenv(EnvGCSAccessToken)
+	if token == "" {
+		return nil, errors.New("GCS sink requires an OAuth access token in " + EnvGCSAccessToken)
+	}
+
+	return &gcsStore{
+		bucket:   bucket,
+		endpoint: strings.TrimSuffix(cmp.Or(opts.Endpoint, gcsEndpoint), "/"),
+		token:    token,
+		client:   client,
+	}, nil
+}
+
+// put uploads body as key.
+func (s *gcsStore) put(ctx context.Context, key string, body []byte, header http.Header) error {
+	target := s.endpoint + "/" + s.bucket + "/" + escapeKey(key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid GCS request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	return doPut(s.client, req)
+}
@@ -0,0 +1,160 @@
+package sink
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/internal/clock"
+)
+
+const (
+	// s3DefaultRegion is used when neither the options nor the environment name a region.
+	s3DefaultRegion = "us-east-1"
+	// sigV4Algorithm is the AWS Signature Version 4 algorithm name.
+	sigV4Algorithm = "AWS4-HMAC-SHA256"
+	// amzDateFormat and amzDayFormat are the timestamp formats of Signature Version 4.
+	amzDateFormat = "20060102T150405Z"
+	amzDayFormat  = "20060102"
+)
+
+// s3Store puts objects into an S3 bucket with Signature Version 4 requests.
+type s3Store struct {
+	bucket       string
+	region       string
+	endpoint     string // Path-style endpoint, empty for the AWS virtual-hosted endpoint
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	client       *http.Client
+}
+
+// newS3Store creates an S3 store with the credentials of the environment.
+func newS3Store(bucket string, opts Options, client *http.Client) (*s3Store, error) {
+	store := &s3Store{
+		bucket:       bucket,
+		region:       cmp.Or(opts.Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"), s3DefaultRegion),
+		endpoint:     strings.TrimSuffix(opts.Endpoint, "/"),
+		accessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		client:       client,
+	}
+	if store.accessKey == "" || store.secretKey == "" {
+		return nil, errors.New("S3 sink requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+
+	return store, nil
+}
+
+// put uploads body as key with a signed PUT request.
+func (s *s3Store) put(ctx context.Context, key string, body []byte, header http.Header) error {
+	target := "https://" + s.bucket + ".s3." + s.region + ".amazonaws.com/" + sigV4Escape(key)
+	if s.endpoint != "" {
+		target = s.endpoint + "/" + s.bucket + "/" + sigV4Escape(key)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid S3 request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	s.sign(req, body, clock.Now().UTC())
+
+	return doPut(s.client, req)
+}
+
+// sign adds the Signature Version 4 authorization of req, sent at now, to its headers.
+func (s *s3Store) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := SHA256Hex(body)
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		sigV4Escape(req.URL.Path),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := now.Format(amzDayFormat) + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm, now.Format(amzDateFormat), scope, SHA256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := signingKey(s.secretKey, now.Format(amzDayFormat), s.region, "s3")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, s.accessKey, scope, signedHeaders, signature))
+}
+
+// sigV4Escape percent-encodes path like Signature Version 4 canonical URIs:
+// every byte except unreserved characters and slashes.
+func sigV4Escape(path string) string {
+	var escaped strings.Builder
+	for _, b := range []byte(path) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~', b == '/':
+			escaped.WriteByte(b)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+
+	return escaped.String()
+}
+
+// signingKey derives the Signature Version 4 signing key of a day, region and service.
+func signingKey(secret, day, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+
+	return hmacSHA256(key, "aws4_request")
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data with key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of data.
+func SHA256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
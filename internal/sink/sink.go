@@ -0,0 +1,194 @@
+// Package sink uploads generated documentation to cloud storage buckets, so
+// a documentation site can be published from CI without extra tooling.
+package sink
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Supported sink URL schemes.
+const (
+	// SchemeS3 uploads to Amazon S3 or an S3-compatible store.
+	SchemeS3 = "s3"
+	// SchemeGCS uploads to Google Cloud Storage.
+	SchemeGCS = "gs"
+
+	// uploadTimeout bounds each upload.
+	uploadTimeout = time.Minute
+)
+
+// contentTypes are the content types of documentation files mime does not know everywhere.
+var contentTypes = map[string]string{
+	".adoc": "text/asciidoc; charset=utf-8",
+	".css":  "text/css; charset=utf-8",
+	".html": "text/html; charset=utf-8",
+	".json": "application/json",
+	".md":   "text/markdown; charset=utf-8",
+	".svg":  "image/svg+xml",
+}
+
+// Options configure a sink. Empty fields use the defaults of the provider.
+type Options struct {
+	// CacheControl is the Cache-Control header stored with every object
+	CacheControl string
+	// Region is the S3 region, AWS_REGION or AWS_DEFAULT_REGION when empty, else us-east-1
+	Region string
+	// Endpoint is the base URL of an S3-compatible or GCS-compatible service;
+	// objects are addressed as <endpoint>/<bucket>/<key>
+	Endpoint string
+	// HTTPClient sends the uploads, http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+// Object is an uploaded file.
+type Object struct {
+	Path string // Local file
+	Key  string // Object key in the bucket
+}
+
+// store puts objects into a bucket.
+type store interface {
+	put(ctx context.Context, key string, body []byte, header http.Header) error
+}
+
+// Sink uploads files to a bucket under a key prefix.
+type Sink struct {
+	URL    string // Sink URL, such as s3://bucket/docs
+	Bucket string
+	Prefix string // Key prefix without leading or trailing slashes
+
+	cacheControl string
+	store        store
+}
+
+// New creates the sink of rawURL: s3://bucket/prefix for S3 or
+// gs://bucket/prefix for Google Cloud Storage. S3 uploads are signed with
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN, GCS
+// uploads use the OAuth access token in GOOGLE_OAUTH_ACCESS_TOKEN.
+func New(rawURL string, opts Options) (*Sink, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid sink %q, expected s3://bucket/prefix or gs://bucket/prefix", rawURL)
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	sink := &Sink{
+		URL:          rawURL,
+		Bucket:       parsed.Host,
+		Prefix:       strings.Trim(parsed.Path, "/"),
+		cacheControl: opts.CacheControl,
+	}
+	switch parsed.Scheme {
+	case SchemeS3:
+		sink.store, err = newS3Store(sink.Bucket, opts, client)
+	case SchemeGCS:
+		sink.store, err = newGCSStore(sink.Bucket, opts, client)
+	default:
+		return nil, fmt.Errorf("unsupported sink scheme %q in %s, must be one of: s3, gs", parsed.Scheme, rawURL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return sink, nil
+}
+
+// ValidateURL rejects sink URLs without a bucket or with an unsupported scheme.
+func ValidateURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != SchemeS3 && parsed.Scheme != SchemeGCS) {
+		return fmt.Errorf("invalid sink %q, expected s3://bucket/prefix or gs://bucket/prefix", rawURL)
+	}
+
+	return nil
+}
+
+// Upload uploads files, keyed by their slash-separated path relative to
+// root under the prefix, and returns the uploaded objects. Files outside
+// root are rejected before anything is uploaded.
+func (s *Sink) Upload(ctx context.Context, root string, files []string) ([]Object, error) {
+	objects := make([]Object, 0, len(files))
+	for _, file := range files {
+		rel, err := filepath.Rel(root, file)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("%s is outside the site directory %s", file, root)
+		}
+		objects = append(objects, Object{Path: file, Key: path.Join(s.Prefix, filepath.ToSlash(rel))})
+	}
+
+	for _, object := range objects {
+		body, err := os.ReadFile(object.Path) // #nosec G304 -- generated output file
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", object.Path, err)
+		}
+		header := http.Header{"Content-Type": {contentType(object.Path)}}
+		if s.cacheControl != "" {
+			header.Set("Cache-Control", s.cacheControl)
+		}
+
+		uploadCtx, cancel := context.WithTimeout(ctx, uploadTimeout)
+		err = s.store.put(uploadCtx, object.Key, body, header)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload %s to %s: %w", object.Path, s.ObjectURL(object.Key), err)
+		}
+	}
+
+	return objects, nil
+}
+
+// ObjectURL returns the sink URL of the object with key.
+func (s *Sink) ObjectURL(key string) string {
+	scheme, _, _ := strings.Cut(s.URL, "://")
+
+	return scheme + "://" + s.Bucket + "/" + key
+}
+
+// contentType returns the content type of the file at path.
+func contentType(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if known, ok := contentTypes[ext]; ok {
+		return known
+	}
+	if byExt := mime.TypeByExtension(ext); byExt != "" {
+		return byExt
+	}
+
+	return "application/octet-stream"
+}
+
+// doPut sends a PUT request and fails on responses other than 2xx.
+func doPut(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("storage returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// escapeKey escapes each segment of an object key for a URL path.
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"github.com/ivuorinen/gh-action-readme/internal/sink"
+)
+
+// SinkSettings configures the cloud storage bucket gen --sink uploads the
+// generated documentation to.
+type SinkSettings struct {
+	// URL is the bucket and key prefix, such as s3://bucket/docs or gs://bucket/docs
+	URL string `mapstructure:"url"           yaml:"url,omitempty"`
+	// CacheControl is the Cache-Control header stored with every uploaded file
+	CacheControl string `mapstructure:"cache_control" yaml:"cache_control,omitempty"`
+	// Region is the S3 region, AWS_REGION when empty
+	Region string `mapstructure:"region"        yaml:"region,omitempty"`
+	// Endpoint is the base URL of an S3-compatible service such as MinIO
+	Endpoint string `mapstructure:"endpoint"      yaml:"endpoint,omitempty"`
+}
+
+// ValidateSinkSettings rejects sink URLs other than s3:// and gs:// bucket URLs.
+func ValidateSinkSettings(settings SinkSettings) error {
+	if settings.URL == "" {
+		return nil
+	}
+
+	return sink.ValidateURL(settings.URL)
+}
+
+// NewSink creates the sink configured by settings.
+func NewSink(settings SinkSettings) (*sink.Sink, error) {
+	return sink.New(settings.URL, sink.Options{
+		CacheControl: settings.CacheControl,
+		Region:       settings.Region,
+		Endpoint:     settings.Endpoint,
+	})
+}
@@ -0,0 +1,210 @@
+package internal
+
+import (
+	"bytes"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
+)
+
+// Community health file directories, in the order GitHub searches them.
+var communityDirs = []string{".github", "", "docs"}
+
+// SupportLink is a link in the Support section of generated documentation.
+type SupportLink struct {
+	Name  string `yaml:"name"  json:"name"`
+	URL   string `yaml:"url"   json:"url"`
+	About string `yaml:"about" json:"about,omitempty"`
+}
+
+// SupportInfo lists the support channels detected from a repository's .github
+// directory and community health files. Empty fields were not found.
+type SupportInfo struct {
+	IssueTemplates      []SupportLink `json:"issue_templates,omitempty"`
+	ContactLinks        []SupportLink `json:"contact_links,omitempty"`
+	Discussions         string        `json:"discussions,omitempty"`
+	SupportGuide        string        `json:"support_guide,omitempty"`
+	SecurityPolicy      string        `json:"security_policy,omitempty"`
+	Contributing        string        `json:"contributing,omitempty"`
+	PullRequestTemplate string        `json:"pull_request_template,omitempty"`
+}
+
+// issueTemplateHeader holds the fields of an issue template or issue form used for links.
+type issueTemplateHeader struct {
+	Name  string `yaml:"name"`
+	About string `yaml:"about"`
+	// Description is the issue form equivalent of About.
+	Description string `yaml:"description"`
+}
+
+// issueTemplateConfig is .github/ISSUE_TEMPLATE/config.yml.
+type issueTemplateConfig struct {
+	ContactLinks []SupportLink `yaml:"contact_links"`
+}
+
+// DetectSupport finds the issue templates, contact links, discussions, support
+// guide, security policy, contributing guide and pull request template of the
+// repository at repoRoot. Links point at github.com, so it returns nil without
+// the repository name or when nothing is found.
+func DetectSupport(repoRoot string, info git.RepoInfo) *SupportInfo {
+	repoName := info.GetRepositoryName()
+	if repoRoot == "" || repoName == "" {
+		return nil
+	}
+	repoURL := "https://github.com/" + repoName
+	blobURL := func(name string) string {
+		if rel := findCommunityFile(repoRoot, name); rel != "" {
+			return repoURL + "/blob/" + info.LinkRef() + "/" + rel
+		}
+
+		return ""
+	}
+
+	support := &SupportInfo{
+		SupportGuide:        blobURL("SUPPORT.md"),
+		Contributing:        blobURL("CONTRIBUTING.md"),
+		PullRequestTemplate: blobURL("pull_request_template.md"),
+	}
+	support.IssueTemplates, support.ContactLinks = issueTemplates(repoRoot, repoURL)
+	if dirExists(filepath.Join(repoRoot, ".github", "DISCUSSION_TEMPLATE")) ||
+		slices.ContainsFunc(support.ContactLinks, isDiscussionsLink) {
+		support.Discussions = repoURL + "/discussions"
+	}
+	if findCommunityFile(repoRoot, "SECURITY.md") != "" {
+		support.SecurityPolicy = repoURL + "/security/policy"
+	}
+
+	if len(support.Links()) == 0 {
+		return nil
+	}
+
+	return support
+}
+
+// Links returns every support channel in display order.
+func (s *SupportInfo) Links() []SupportLink {
+	links := slices.Concat(s.IssueTemplates, s.ContactLinks)
+	for _, link := range []SupportLink{
+		{Name: "Discussions", URL: s.Discussions, About: "Ask questions and share ideas"},
+		{Name: "Support guide", URL: s.SupportGuide},
+		{Name: "Security policy", URL: s.SecurityPolicy, About: "Report vulnerabilities privately"},
+		{Name: "Contributing guide", URL: s.Contributing},
+		{Name: "Pull request template", URL: s.PullRequestTemplate},
+	} {
+		if link.URL != "" {
+			links = append(links, link)
+		}
+	}
+
+	return links
+}
+
+// issueTemplates reads the issue templates and contact links in .github/ISSUE_TEMPLATE.
+// Each template links to a new issue pre-filled from it.
+func issueTemplates(repoRoot, repoURL string) ([]SupportLink, []SupportLink) {
+	dir := filepath.Join(repoRoot, ".github", "ISSUE_TEMPLATE")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil
+	}
+
+	var templates, contacts []SupportLink
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+		if entry.IsDir() || (ext != ".md" && ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, name)) // #nosec G304 -- file listed in the template directory
+		if err != nil {
+			continue
+		}
+
+		if strings.TrimSuffix(strings.ToLower(name), ext) == "config" {
+			var config issueTemplateConfig
+			if yamlsafe.Unmarshal(content, &config) == nil {
+				contacts = append(contacts, config.ContactLinks...)
+			}
+
+			continue
+		}
+		templates = append(templates, issueTemplateLink(name, ext, content, repoURL))
+	}
+
+	return templates, contacts
+}
+
+// issueTemplateLink builds the link for one issue template or issue form,
+// named after its name: field or, without one, its file name.
+func issueTemplateLink(name, ext string, content []byte, repoURL string) SupportLink {
+	if ext == ".md" {
+		content = frontMatter(content)
+	}
+	var header issueTemplateHeader
+	_ = yamlsafe.Unmarshal(content, &header)
+
+	link := SupportLink{
+		Name:  header.Name,
+		URL:   repoURL + "/issues/new?template=" + url.QueryEscape(name),
+		About: header.About,
+	}
+	if link.Name == "" {
+		link.Name = strings.TrimSuffix(name, ext)
+	}
+	if link.About == "" {
+		link.About = header.Description
+	}
+
+	return link
+}
+
+// frontMatter returns the YAML front matter of a markdown issue template.
+func frontMatter(content []byte) []byte {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	rest, ok := bytes.CutPrefix(content, []byte("---\n"))
+	if !ok {
+		return nil
+	}
+	header, _, ok := bytes.Cut(rest, []byte("\n---"))
+	if !ok {
+		return nil
+	}
+
+	return header
+}
+
+// findCommunityFile returns the repository-relative path of a community health
+// file, matching its name case-insensitively in .github, the root and docs.
+func findCommunityFile(repoRoot, name string) string {
+	for _, dir := range communityDirs {
+		entries, err := os.ReadDir(filepath.Join(repoRoot, dir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(entry.Name(), name) {
+				return path.Join(dir, entry.Name())
+			}
+		}
+	}
+
+	return ""
+}
+
+// isDiscussionsLink reports whether a contact link points at GitHub Discussions.
+func isDiscussionsLink(link SupportLink) bool {
+	return strings.Contains(link.URL, "/discussions")
+}
+
+// dirExists reports whether path is a directory.
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
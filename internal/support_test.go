@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal/git"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestDetectSupport(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	templates := filepath.Join(tmpDir, ".github", "ISSUE_TEMPLATE")
+	testutil.WriteTestFile(t, filepath.Join(templates, "bug_report.md"),
+		"---\nname: Bug report\nabout: Report a bug\nlabels: bug\n---\n\n**Describe the bug**\n")
+	testutil.WriteTestFile(t, filepath.Join(templates, "feature.yml"),
+		"name: Feature request\ndescription: Suggest an idea\nbody: []\n")
+	testutil.WriteTestFile(t, filepath.Join(templates, "untitled.md"), "No front matter\n")
+	testutil.WriteTestFile(t, filepath.Join(templates, "config.yml"),
+		"blank_issues_enabled: false\ncontact_links:\n  - name: Questions\n"+
+			"    url: https://github.com/acme/tools/discussions/categories/q-a\n    about: Ask here\n")
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "SECURITY.md"), "# Security\n")
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "docs", "support.md"), "# Support\n")
+
+	info := git.RepoInfo{Organization: "acme", Repository: "tools", DefaultBranch: "trunk"}
+	support := DetectSupport(tmpDir, info)
+	if support == nil {
+		t.Fatal("expected support links")
+	}
+
+	expected := []SupportLink{
+		{Name: "Bug report", URL: "https://github.com/acme/tools/issues/new?template=bug_report.md", About: "Report a bug"},
+		{
+			Name:  "Feature request",
+			URL:   "https://github.com/acme/tools/issues/new?template=feature.yml",
+			About: "Suggest an idea",
+		},
+		{Name: "untitled", URL: "https://github.com/acme/tools/issues/new?template=untitled.md"},
+		{Name: "Questions", URL: "https://github.com/acme/tools/discussions/categories/q-a", About: "Ask here"},
+		{Name: "Discussions", URL: "https://github.com/acme/tools/discussions", About: "Ask questions and share ideas"},
+		{Name: "Support guide", URL: "https://github.com/acme/tools/blob/trunk/docs/support.md"},
+		{
+			Name:  "Security policy",
+			URL:   "https://github.com/acme/tools/security/policy",
+			About: "Report vulnerabilities privately",
+		},
+	}
+	links := support.Links()
+	if len(links) != len(expected) {
+		t.Fatalf("expected %d links, got %d: %+v", len(expected), len(links), links)
+	}
+	for i, link := range links {
+		testutil.AssertEqual(t, expected[i], link)
+	}
+}
+
+func TestDetectSupport_NothingFound(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, ".github", "CONTRIBUTING.md"), "# Contributing\n")
+
+	if support := DetectSupport(tmpDir, git.RepoInfo{}); support != nil {
+		t.Errorf("expected no support links without a repository name, got %+v", support)
+	}
+	if support := DetectSupport(t.TempDir(), git.RepoInfo{Organization: "acme", Repository: "tools"}); support != nil {
+		t.Errorf("expected no support links without community files, got %+v", support)
+	}
+
+	support := DetectSupport(tmpDir, git.RepoInfo{Organization: "acme", Repository: "tools"})
+	if support == nil {
+		t.Fatal("expected a contributing link")
+	}
+	testutil.AssertEqual(t, "https://github.com/acme/tools/blob/main/.github/CONTRIBUTING.md", support.Contributing)
+}
+
+func TestDetectSupport_LinkRef(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	testutil.WriteTestFile(t, filepath.Join(tmpDir, "CONTRIBUTING.md"), "# Contributing\n")
+
+	tests := []struct {
+		name   string
+		config AppConfig
+		want   string
+	}{
+		{name: "checked out ref", want: "feature/docs"},
+		{name: "link_ref", config: AppConfig{LinkRef: "v2.0.0"}, want: "v2.0.0"},
+		{name: "link_default_branch", config: AppConfig{LinkDefaultBranch: true}, want: "trunk"},
+		{name: "link_ref wins", config: AppConfig{LinkRef: "v2.0.0", LinkDefaultBranch: true}, want: "v2.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			info := git.RepoInfo{Organization: "acme", Repository: "tools", DefaultBranch: "trunk", Ref: "feature/docs"}
+			tt.config.applyLinkRef(&info)
+			support := DetectSupport(tmpDir, info)
+			if support == nil {
+				t.Fatal("expected a contributing link")
+			}
+			testutil.AssertEqual(t, "https://github.com/acme/tools/blob/"+tt.want+"/CONTRIBUTING.md", support.Contributing)
+		})
+	}
+}
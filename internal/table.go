@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// tableColumnGap separates adjacent table columns.
+const tableColumnGap = "  "
+
+// ansiEscapePattern matches ANSI color sequences, which occupy no terminal columns.
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// Table renders rows of text as aligned columns for terminal output.
+// Column widths are measured in terminal cells, so emoji and CJK text line up.
+type Table struct {
+	headers     []string
+	rows        [][]string
+	rightAlign  map[int]bool
+	indentation string
+}
+
+// NewTable creates a table with the given column headers.
+// Pass no headers to render rows only.
+func NewTable(headers ...string) *Table {
+	return &Table{
+		headers:    headers,
+		rightAlign: make(map[int]bool),
+	}
+}
+
+// AddRow appends a row of cells to the table.
+func (t *Table) AddRow(cells ...string) *Table {
+	t.rows = append(t.rows, cells)
+
+	return t
+}
+
+// AlignRight right-aligns the given zero-based columns, useful for numbers.
+func (t *Table) AlignRight(columns ...int) *Table {
+	for _, column := range columns {
+		t.rightAlign[column] = true
+	}
+
+	return t
+}
+
+// Indent prefixes every rendered line with the given string.
+func (t *Table) Indent(prefix string) *Table {
+	t.indentation = prefix
+
+	return t
+}
+
+// Len returns the number of data rows in the table.
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// String renders the table with a trailing newline after every line.
+func (t *Table) String() string {
+	widths := t.columnWidths()
+	if len(widths) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	if len(t.headers) > 0 {
+		t.writeLine(&b, t.headers, widths)
+		separators := make([]string, len(widths))
+		for i, width := range widths {
+			separators[i] = strings.Repeat("-", width)
+		}
+		t.writeLine(&b, separators, widths)
+	}
+	for _, row := range t.rows {
+		t.writeLine(&b, row, widths)
+	}
+
+	return b.String()
+}
+
+// columnWidths returns the display width of the widest cell in each column.
+func (t *Table) columnWidths() []int {
+	var widths []int
+	measure := func(cells []string) {
+		for i, cell := range cells {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], DisplayWidth(cell))
+		}
+	}
+
+	measure(t.headers)
+	for _, row := range t.rows {
+		measure(row)
+	}
+
+	return widths
+}
+
+// writeLine writes a single padded line, trimming trailing whitespace.
+func (t *Table) writeLine(b *strings.Builder, cells []string, widths []int) {
+	var line strings.Builder
+	line.WriteString(t.indentation)
+	for i, width := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		if i > 0 {
+			line.WriteString(tableColumnGap)
+		}
+		line.WriteString(PadDisplay(cell, width, t.rightAlign[i]))
+	}
+
+	b.WriteString(strings.TrimRight(line.String(), " "))
+	b.WriteString("\n")
+}
+
+// DisplayWidth returns the number of terminal cells needed to display s.
+// Wide characters such as emoji and CJK count as two cells and ANSI color
+// sequences count as zero.
+func DisplayWidth(s string) int {
+	return uniseg.StringWidth(ansiEscapePattern.ReplaceAllString(s, ""))
+}
+
+// PadDisplay pads s with spaces to the given display width.
+// Strings already at least width cells wide are returned unchanged.
+func PadDisplay(s string, width int, alignRight bool) string {
+	padding := width - DisplayWidth(s)
+	if padding <= 0 {
+		return s
+	}
+	if alignRight {
+		return strings.Repeat(" ", padding) + s
+	}
+
+	return s + strings.Repeat(" ", padding)
+}
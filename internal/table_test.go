@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{name: "empty", input: "", expected: 0},
+		{name: "ascii", input: "checkout", expected: 8},
+		{name: "emoji", input: "🔒", expected: 2},
+		{name: "emoji with text", input: "📌 v4", expected: 5},
+		{name: "cjk", input: "日本語", expected: 6},
+		{name: "ansi color", input: "\x1b[32mok\x1b[0m", expected: 2},
+		{name: "combining accent", input: "é", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testutil.AssertEqual(t, tt.expected, DisplayWidth(tt.input))
+		})
+	}
+}
+
+func TestPadDisplay(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertEqual(t, "🔒  ", PadDisplay("🔒", 4, false))
+	testutil.AssertEqual(t, "  42", PadDisplay("42", 4, true))
+	testutil.AssertEqual(t, "toolong", PadDisplay("toolong", 3, false))
+}
+
+func TestTable_String(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable("", "Name", "Count").Indent("  ").AlignRight(2)
+	table.AddRow("🔒", "checkout", "1")
+	table.AddRow("📌", "日本語", "12")
+
+	expected := "" +
+		"      Name      Count\n" +
+		"  --  --------  -----\n" +
+		"  🔒  checkout      1\n" +
+		"  📌  日本語       12\n"
+
+	testutil.AssertEqual(t, expected, table.String())
+	testutil.AssertEqual(t, 2, table.Len())
+}
+
+func TestTable_StringWithoutHeaders(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable().AddRow("a", "long value").AddRow("longer", "b", "extra")
+
+	expected := "" +
+		"a       long value\n" +
+		"longer  b           extra\n"
+
+	testutil.AssertEqual(t, expected, table.String())
+	testutil.AssertEqual(t, "", NewTable().String())
+}
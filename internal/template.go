@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"text/template"
+	"unicode/utf8"
 
 	"github.com/google/go-github/v74/github"
 
@@ -44,19 +45,59 @@ type TemplateData struct {
 
 	// Dependencies (populated by dependency analysis)
 	Dependencies []dependencies.Dependency `json:"dependencies,omitempty"`
+
+	// Pinned and floating dependency counts (populated when show_security_info is enabled)
+	Security *dependencies.SecuritySummary `json:"security,omitempty"`
+
+	// Size and complexity metrics (populated when show_metrics is enabled)
+	Metrics *ActionMetrics `json:"metrics,omitempty"`
+
+	// Catalog metadata from the action.meta.yml sidecar file, nil when there is none
+	Metadata *ActionMetadata `json:"metadata,omitempty"`
+
+	// Maintainers from the sidecar owners: list or CODEOWNERS
+	Owners []string `json:"owners,omitempty"`
+
+	// Support channels detected from .github (populated when show_support is enabled)
+	Support *SupportInfo `json:"support,omitempty"`
+
+	// Operating system compatibility matrix for runs_on (populated when show_compatibility is enabled)
+	Compatibility *Compatibility `json:"compatibility,omitempty"`
+
+	// "Generated by" line, nil when the attribution setting is "off"
+	Attribution *Attribution `json:"attribution,omitempty"`
+
+	// Logo copied next to the generated file (set by the generator), nil when there is none
+	Logo *ActionLogo `json:"logo,omitempty"`
+
+	// Link to the full README from a marketplace listing (set by the generator), empty otherwise
+	FullReadme string `json:"full_readme,omitempty"`
+
+	// How the generated documentation is regenerated and checked (set by the generator for Markdown)
+	Toolchain *DocsToolchain `json:"toolchain,omitempty"`
 }
 
-// templateFuncs returns a map of custom template functions.
-func templateFuncs() template.FuncMap {
+// templateFuncs returns a map of custom template functions. cell formats
+// table cells with the table settings.
+func templateFuncs(tables TableSettings) template.FuncMap {
 	return template.FuncMap{
-		"lower":         strings.ToLower,
-		"upper":         strings.ToUpper,
-		"replace":       strings.ReplaceAll,
-		"join":          strings.Join,
-		"gitOrg":        getGitOrg,
-		"gitRepo":       getGitRepo,
-		"gitUsesString": getGitUsesString,
-		"actionVersion": getActionVersion,
+		"cell":            tables.Cell,
+		"lower":           strings.ToLower,
+		"upper":           strings.ToUpper,
+		"replace":         strings.ReplaceAll,
+		"join":            strings.Join,
+		"gitOrg":          getGitOrg,
+		"gitRepo":         getGitRepo,
+		"gitUsesString":   getGitUsesString,
+		"actionVersion":   getActionVersion,
+		"badgeText":       badgeText,
+		"summary":         summary,
+		"ownerURL":        OwnerURL,
+		"defaultValue":    FormatDefault,
+		"isBlockDefault":  IsBlockDefault,
+		"defaultLanguage": DefaultLanguage,
+		"defaultBlock":    DefaultCodeBlock,
+		"yamlBlock":       YAMLBlockScalar,
 	}
 }
 
@@ -144,6 +185,32 @@ func buildUsesString(td *TemplateData, org, repo, version string) string {
 	return validation.FormatUsesStatement(org, repo, version)
 }
 
+// badgeText escapes text for a shields.io static badge path segment.
+func badgeText(text string) string {
+	return strings.NewReplacer("-", "--", "_", "__", " ", "%20").Replace(text)
+}
+
+// marketplaceDescriptionLength is the number of characters of an action's
+// description that GitHub Marketplace shows in its listing.
+const marketplaceDescriptionLength = 125
+
+// summary returns the first paragraph of text on one line, cut at a word
+// boundary with an ellipsis when it is longer than the marketplace shows.
+func summary(text string) string {
+	paragraph, _, _ := strings.Cut(strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n")), "\n\n")
+	line := strings.Join(strings.Fields(paragraph), " ")
+	if utf8.RuneCountInString(line) <= marketplaceDescriptionLength {
+		return line
+	}
+
+	runes := []rune(line)[:marketplaceDescriptionLength-1]
+	if cut := strings.LastIndex(string(runes), " "); cut > 0 {
+		return string(runes)[:cut] + "…"
+	}
+
+	return string(runes) + "…"
+}
+
 // getActionVersion returns the action version from template data.
 func getActionVersion(data any) string {
 	if td, ok := data.(*TemplateData); ok {
@@ -155,6 +222,25 @@ func getActionVersion(data any) string {
 	return "v1"
 }
 
+// InputList returns the action inputs ordered according to the configured sort mode.
+func (td *TemplateData) InputList() []NamedInput {
+	return td.SortedInputs(td.sortMode())
+}
+
+// OutputList returns the action outputs ordered according to the configured sort mode.
+func (td *TemplateData) OutputList() []NamedOutput {
+	return td.SortedOutputs(td.sortMode())
+}
+
+// sortMode returns the configured input sort mode, defaulting to declaration order.
+func (td *TemplateData) sortMode() string {
+	if td.Config == nil || td.Config.SortInputs == "" {
+		return SortInputsDeclaration
+	}
+
+	return td.Config.SortInputs
+}
+
 // BuildTemplateData constructs comprehensive template data from action and configuration.
 func BuildTemplateData(action *ActionYML, config *AppConfig, repoRoot, actionPath string) *TemplateData {
 	data := &TemplateData{
@@ -176,6 +262,7 @@ func BuildTemplateData(action *ActionYML, config *AppConfig, repoRoot, actionPat
 	if config.Repository != "" {
 		data.Git.Repository = config.Repository
 	}
+	config.applyLinkRef(&data.Git)
 
 	// Build uses statement
 	data.UsesStatement = getGitUsesString(data)
@@ -183,16 +270,55 @@ func BuildTemplateData(action *ActionYML, config *AppConfig, repoRoot, actionPat
 	// Add dependency analysis if enabled
 	if config.AnalyzeDependencies && actionPath != "" {
 		data.Dependencies = analyzeDependencies(actionPath, config, data.Git)
+		if config.ShowSecurityInfo && len(data.Dependencies) > 0 {
+			security := dependencies.SummarizeSecurity(data.Dependencies)
+			data.Security = &security
+		}
+	}
+
+	data.Metadata = templateMetadata(actionPath)
+	data.Attribution = NewAttribution(config)
+	addOptionalSections(data, repoRoot)
+	if actionPath != "" {
+		data.Owners, _ = ActionOwners(actionPath, repoRoot)
 	}
 
 	return data
 }
 
+// addOptionalSections populates the sections that are enabled with show_ settings.
+func addOptionalSections(data *TemplateData, repoRoot string) {
+	if data.Config.ShowMetrics {
+		metrics := ComputeMetrics(data.ActionYML)
+		data.Metrics = &metrics
+	}
+	if data.Config.ShowSupport {
+		data.Support = DetectSupport(repoRoot, data.Git)
+	}
+	if data.Config.ShowCompatibility {
+		data.Compatibility = ActionCompatibility(data.ActionYML, data.Config.RunsOn)
+	}
+}
+
+// templateMetadata loads the sidecar metadata of the action at actionPath, or
+// returns nil when there is none or it cannot be read.
+func templateMetadata(actionPath string) *ActionMetadata {
+	if actionPath == "" {
+		return nil
+	}
+	metadata, err := LoadActionMetadata(actionPath)
+	if err != nil || metadata.IsEmpty() {
+		return nil
+	}
+
+	return metadata
+}
+
 // analyzeDependencies performs dependency analysis on the action file.
 func analyzeDependencies(actionPath string, config *AppConfig, gitInfo git.RepoInfo) []dependencies.Dependency {
-	// Create GitHub client if we have a token
+	// Create GitHub client if we have a token and enrichment is enabled
 	var client *GitHubClient
-	if token := GetGitHubToken(config); token != "" {
+	if token := GetGitHubToken(config); token != "" && config.EnrichmentEnabled() {
 		var err error
 		client, err = NewGitHubClient(token)
 		if err != nil {
@@ -203,7 +329,7 @@ func analyzeDependencies(actionPath string, config *AppConfig, gitInfo git.RepoI
 
 	// Create high-performance cache
 	var depCache dependencies.DependencyCache
-	if cacheInstance, err := cache.NewCache(cache.DefaultConfig()); err == nil {
+	if cacheInstance, err := cache.NewCache(NewCacheConfig(config.Cache)); err == nil {
 		depCache = dependencies.NewCacheAdapter(cacheInstance)
 	} else {
 		// Fallback to no-op cache if cache creation fails
@@ -217,6 +343,7 @@ func analyzeDependencies(actionPath string, config *AppConfig, gitInfo git.RepoI
 	}
 
 	analyzer := dependencies.NewAnalyzer(githubClient, gitInfo, depCache)
+	analyzer.CacheTTL = config.Cache.TTLDuration()
 
 	// Analyze dependencies
 	deps, err := analyzer.AnalyzeActionFile(actionPath)
@@ -229,17 +356,22 @@ func analyzeDependencies(actionPath string, config *AppConfig, gitInfo git.RepoI
 }
 
 // RenderReadme renders a README using a Go template and the parsed action.yml data.
+// Custom templates, read from the filesystem, are rendered in a templateSandbox.
 func RenderReadme(action any, opts TemplateOptions) (string, error) {
 	tmplContent, err := templates_embed.ReadTemplate(opts.TemplatePath)
 	if err != nil {
 		return "", err
 	}
-	var tmpl *template.Template
+	tables := tableSettingsOf(action)
+	action = sanitizeTemplateData(action, opts.Format)
+	sandbox := newTemplateSandbox(opts.TemplatePath, action)
+	action = sandbox.data(action)
+
+	tmpl, err := template.New("readme").Funcs(sandbox.funcs(templateFuncs(tables))).Parse(string(tmplContent))
+	if err != nil {
+		return "", err
+	}
 	if opts.Format == OutputFormatHTML {
-		tmpl, err = template.New("readme").Funcs(templateFuncs()).Parse(string(tmplContent))
-		if err != nil {
-			return "", err
-		}
 		var head, foot string
 		if opts.HeaderPath != "" {
 			h, _ := templates_embed.ReadTemplate(opts.HeaderPath)
@@ -252,7 +384,7 @@ func RenderReadme(action any, opts TemplateOptions) (string, error) {
 		// Wrap template output in header/footer
 		buf := &bytes.Buffer{}
 		buf.WriteString(head)
-		if err := tmpl.Execute(buf, action); err != nil {
+		if err := sandbox.execute(tmpl, buf, action); err != nil {
 			return "", err
 		}
 		buf.WriteString(foot)
@@ -260,14 +392,24 @@ func RenderReadme(action any, opts TemplateOptions) (string, error) {
 		return buf.String(), nil
 	}
 
-	tmpl, err = template.New("readme").Funcs(templateFuncs()).Parse(string(tmplContent))
-	if err != nil {
-		return "", err
-	}
 	buf := &bytes.Buffer{}
-	if err := tmpl.Execute(buf, action); err != nil {
+	if err := sandbox.execute(tmpl, buf, action); err != nil {
 		return "", err
 	}
+	content := resolveHTMLMarkers(buf.String())
+	if opts.Format == OutputFormatMD && tables.Style == TableStyleAligned {
+		return AlignMarkdownTables(content), nil
+	}
+
+	return content, nil
+}
+
+// tableSettingsOf returns the table settings of the configuration in template
+// data, or the defaults for other data.
+func tableSettingsOf(action any) TableSettings {
+	if data, ok := action.(*TemplateData); ok && data.Config != nil {
+		return data.Config.Tables
+	}
 
-	return buf.String(), nil
+	return DefaultTableSettings()
 }
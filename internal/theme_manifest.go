@@ -0,0 +1,269 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
+	"github.com/ivuorinen/gh-action-readme/templates_embed"
+)
+
+// ThemeManifestFile is the manifest declaring the documents, variables and
+// options of a theme, read from the directory of its README template.
+const ThemeManifestFile = "theme.yml"
+
+// ThemeDocumentReadme names the README document of a theme, which is always generated.
+const ThemeDocumentReadme = "readme"
+
+// embeddedTemplateRoot is the directory of the templates embedded in the
+// binary, which built-in theme manifests may reference shared templates from.
+const embeddedTemplateRoot = "templates"
+
+// themeVariableName matches the names of theme variables and options.
+var themeVariableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ThemeDocument is a Markdown document a theme generates for each action.
+type ThemeDocument struct {
+	Name string `yaml:"name"`
+	// Template of the document, relative to the manifest; the README defaults to the theme template
+	Template string `yaml:"template,omitempty"`
+	// File written, relative to the output directory; the README defaults to README.md
+	Output string `yaml:"output,omitempty"`
+	// Stubs are written once as a starting point and never overwritten
+	Stub bool `yaml:"stub,omitempty"`
+}
+
+// ThemeVariable is a template variable a theme requires to be set in variables.
+type ThemeVariable struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// ThemeOption is a template variable of a theme that users may set in
+// variables, with the value used when they do not.
+type ThemeOption struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Default     string `yaml:"default,omitempty"`
+}
+
+// ThemeManifest describes a theme: the documents it generates and the
+// template variables its templates read.
+type ThemeManifest struct {
+	Name        string          `yaml:"name,omitempty"`
+	Description string          `yaml:"description,omitempty"`
+	Documents   []ThemeDocument `yaml:"documents"`
+	Variables   []ThemeVariable `yaml:"variables,omitempty"`
+	Options     []ThemeOption   `yaml:"options,omitempty"`
+
+	// README template the manifest was loaded for
+	TemplatePath string `yaml:"-"`
+}
+
+// LoadTheme returns the manifest of a built-in theme, or of the custom theme
+// whose README template is at the given path.
+func LoadTheme(theme string) (*ThemeManifest, error) {
+	templatePath := resolveThemeTemplate(theme)
+	if templatePath == "" {
+		if !filepath.IsAbs(theme) && !strings.Contains(theme, "/") {
+			return nil, fmt.Errorf("unknown theme %q", theme)
+		}
+		templatePath = theme
+	}
+
+	return LoadThemeManifest(templatePath)
+}
+
+// LoadThemeManifest reads and validates the manifest next to the README
+// template at templatePath, embedded or on disk, and resolves the templates of
+// its documents against the manifest's directory. Themes without a manifest
+// get the documents and options of the default theme.
+func LoadThemeManifest(templatePath string) (*ThemeManifest, error) {
+	dir := filepath.Dir(templatePath)
+	manifestPath := filepath.Join(dir, ThemeManifestFile)
+	if !filepath.IsAbs(templatePath) {
+		// Embedded paths use slashes; keep them clean for templates_embed
+		dir = path.Dir(filepath.ToSlash(templatePath))
+		manifestPath = path.Join(dir, ThemeManifestFile)
+	}
+
+	content, err := templates_embed.ReadTemplate(manifestPath)
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, os.ErrNotExist) {
+		return defaultThemeManifest(templatePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme manifest %s: %w", manifestPath, err)
+	}
+
+	var manifest ThemeManifest
+	if err := yamlsafe.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse theme manifest %s: %w", manifestPath, err)
+	}
+	if err := manifest.validate(); err != nil {
+		return nil, fmt.Errorf("invalid theme manifest %s: %w", manifestPath, err)
+	}
+	if err := manifest.resolveTemplates(dir, templates_embed.IsEmbeddedTemplateAvailable(manifestPath)); err != nil {
+		return nil, fmt.Errorf("invalid theme manifest %s: %w", manifestPath, err)
+	}
+	manifest.TemplatePath = templatePath
+
+	return &manifest, nil
+}
+
+// defaultThemeManifest returns the manifest of a theme without one: the
+// documents and options of the default theme around its own README template.
+func defaultThemeManifest(templatePath string) (*ThemeManifest, error) {
+	if templatePath == TemplatePathDefault {
+		return nil, fmt.Errorf("default theme manifest %s is missing", ThemeManifestFile)
+	}
+	manifest, err := LoadThemeManifest(TemplatePathDefault)
+	if err != nil {
+		return nil, err
+	}
+	for i, doc := range manifest.Documents {
+		if doc.Name == ThemeDocumentReadme {
+			manifest.Documents[i] = ThemeDocument{Name: ThemeDocumentReadme}
+		}
+	}
+	manifest.Name, manifest.Description = "", ""
+	manifest.TemplatePath = templatePath
+
+	return manifest, nil
+}
+
+// validate rejects documents without a unique name, documents other than the
+// README without a template or output, outputs leaving the output directory,
+// and variables and options without a unique, valid name.
+func (m *ThemeManifest) validate() error {
+	seen := map[string]bool{}
+	for _, doc := range m.Documents {
+		switch {
+		case doc.Name == "":
+			return errors.New("document without a name")
+		case seen[doc.Name]:
+			return fmt.Errorf("document %q is declared twice", doc.Name)
+		case doc.Name != ThemeDocumentReadme && (doc.Template == "" || doc.Output == ""):
+			return fmt.Errorf("document %q needs a template and an output", doc.Name)
+		case leavesDirectory(doc.Output):
+			return fmt.Errorf("document %q must be written inside the output directory", doc.Name)
+		}
+		seen[doc.Name] = true
+	}
+
+	names := make([]string, 0, len(m.Variables)+len(m.Options))
+	for _, variable := range m.Variables {
+		names = append(names, variable.Name)
+	}
+	for _, option := range m.Options {
+		names = append(names, option.Name)
+	}
+	seen = map[string]bool{}
+	for _, name := range names {
+		switch {
+		case !themeVariableName.MatchString(name):
+			return fmt.Errorf("invalid variable name %q, must start with a letter or underscore "+
+				"followed by letters, digits or '_'", name)
+		case seen[name]:
+			return fmt.Errorf("variable %q is declared twice", name)
+		}
+		seen[name] = true
+	}
+
+	return nil
+}
+
+// resolveTemplates makes the templates of the documents relative to dir, the
+// directory of the manifest. Templates must stay inside the theme directory;
+// embedded themes may also use the shared embedded templates.
+func (m *ThemeManifest) resolveTemplates(dir string, embedded bool) error {
+	root := dir
+	if embedded {
+		root = embeddedTemplateRoot
+	}
+	for i, doc := range m.Documents {
+		if doc.Template == "" {
+			continue
+		}
+		var resolved string
+		if filepath.IsAbs(dir) {
+			resolved = filepath.Join(dir, filepath.FromSlash(doc.Template))
+		} else {
+			resolved = path.Join(dir, filepath.ToSlash(doc.Template))
+		}
+		if filepath.IsAbs(doc.Template) || path.IsAbs(filepath.ToSlash(doc.Template)) || !pathWithin(root, resolved) {
+			return fmt.Errorf("template of document %q must be inside the theme directory", doc.Name)
+		}
+		m.Documents[i].Template = resolved
+	}
+
+	return nil
+}
+
+// Document returns the document named name, or false when the theme has none.
+func (m *ThemeManifest) Document(name string) (ThemeDocument, bool) {
+	for _, doc := range m.Documents {
+		if doc.Name == name {
+			return doc, true
+		}
+	}
+
+	return ThemeDocument{}, false
+}
+
+// Names returns the names of the documents of the theme.
+func (m *ThemeManifest) Names() []string {
+	names := make([]string, 0, len(m.Documents))
+	for _, doc := range m.Documents {
+		names = append(names, doc.Name)
+	}
+
+	return names
+}
+
+// ResolveVariables returns the configured variables with the defaults of the
+// options left unset, or an error naming the required variables that are not
+// set.
+func (m *ThemeManifest) ResolveVariables(configured map[string]string) (map[string]string, error) {
+	var missing []string
+	for _, variable := range m.Variables {
+		if configured[variable.Name] == "" {
+			missing = append(missing, variable.Name)
+		}
+	}
+	if len(missing) > 0 {
+		theme := m.Name
+		if theme == "" {
+			theme = m.TemplatePath
+		}
+
+		return nil, fmt.Errorf("theme %s requires variables %s; set them under variables in the configuration",
+			theme, strings.Join(missing, ", "))
+	}
+
+	resolved := make(map[string]string, len(configured)+len(m.Options))
+	for _, option := range m.Options {
+		resolved[option.Name] = option.Default
+	}
+	for name, value := range configured {
+		// Options set to an empty value keep their default
+		if _, isOption := resolved[name]; !isOption || value != "" {
+			resolved[name] = value
+		}
+	}
+
+	return resolved, nil
+}
+
+// leavesDirectory reports whether the relative path p is absolute or climbs
+// out of the directory it is relative to.
+func leavesDirectory(p string) bool {
+	clean := path.Clean(filepath.ToSlash(p))
+
+	return p != "" && (path.IsAbs(clean) || filepath.IsAbs(p) || clean == ".." || strings.HasPrefix(clean, "../"))
+}
@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestLoadThemeManifest(t *testing.T) {
+	t.Parallel()
+
+	for _, theme := range []string{
+		ThemeDefault, ThemeGitHub, ThemeGitLab, ThemeMinimal, ThemeProfessional, ThemeDocsSite, ThemeBadgeMinimal,
+	} {
+		manifest, err := LoadTheme(theme)
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, theme, manifest.Name)
+		testutil.AssertEqual(t, "readme, contributing, security, documentation", strings.Join(manifest.Names(), ", "))
+		security, _ := manifest.Document("security")
+		testutil.AssertEqual(t, "templates/documents/security.tmpl", security.Template)
+	}
+	if _, err := LoadTheme("unknown"); err == nil {
+		t.Error("LoadTheme() accepted an unknown theme")
+	}
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	templatePath := filepath.Join(tmpDir, "readme.tmpl")
+	testutil.WriteTestFile(t, templatePath, "# {{.Name}}\n")
+
+	// Custom themes without a manifest get the documents of the default theme
+	manifest, err := LoadThemeManifest(templatePath)
+	testutil.AssertNoError(t, err)
+	readme, _ := manifest.Document(ThemeDocumentReadme)
+	testutil.AssertEqual(t, "", readme.Template)
+	testutil.AssertEqual(t, "readme, contributing, security, documentation", strings.Join(manifest.Names(), ", "))
+
+	manifestPath := filepath.Join(tmpDir, ThemeManifestFile)
+	testutil.WriteTestFile(t, manifestPath, "name: custom\ndocuments:\n  - name: readme\n"+
+		"  - name: support\n    template: docs/support.tmpl\n    output: .github/SUPPORT.md\n"+
+		"variables:\n  - name: team\noptions:\n  - name: channel\n    default: '#help'\n")
+	manifest, err = LoadThemeManifest(templatePath)
+	testutil.AssertNoError(t, err)
+	support, ok := manifest.Document("support")
+	if !ok {
+		t.Fatal("support document not loaded")
+	}
+	testutil.AssertEqual(t, filepath.Join(tmpDir, "docs", "support.tmpl"), support.Template)
+	testutil.AssertEqual(t, ".github/SUPPORT.md", support.Output)
+
+	_, err = manifest.ResolveVariables(map[string]string{"channel": "#ops"})
+	testutil.AssertError(t, err)
+	variables, err := manifest.ResolveVariables(map[string]string{"team": "platform", "channel": ""})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "platform", variables["team"])
+	testutil.AssertEqual(t, "#help", variables["channel"])
+
+	for _, invalid := range []string{
+		"documents:\n  - template: a.tmpl\n    output: A.md\n",
+		"documents:\n  - name: a\n    template: a.tmpl\n",
+		"documents:\n  - name: a\n    template: a.tmpl\n    output: ../A.md\n",
+		"documents:\n  - name: a\n    template: ../a.tmpl\n    output: A.md\n",
+		"documents:\n  - name: a\n    template: /etc/a.tmpl\n    output: A.md\n",
+		"documents:\n  - name: readme\n  - name: readme\n",
+		"documents:\n  - name: readme\nvariables:\n  - name: team-name\n",
+		"documents:\n  - name: readme\nvariables:\n  - name: team\noptions:\n  - name: team\n",
+	} {
+		testutil.WriteTestFile(t, manifestPath, invalid)
+		if _, err := LoadThemeManifest(templatePath); err == nil {
+			t.Errorf("LoadThemeManifest() accepted %q", invalid)
+		}
+	}
+}
+
+func TestGenerator_ThemeDocuments(t *testing.T) {
+	t.Parallel()
+
+	tmpDir, cleanup := testutil.TempDir(t)
+	defer cleanup()
+	actionPath := filepath.Join(tmpDir, "action.yml")
+	testutil.WriteTestFile(t, actionPath, testutil.MustReadFixture("actions/javascript/simple.yml"))
+
+	config := DefaultAppConfig()
+	config.Theme = ThemeGitHub
+	config.OutputDir = tmpDir
+	config.Quiet = true
+	config.Organization = "acme"
+	config.Repository = "simple"
+	config.Documents = []string{"contributing", "security"}
+	config.Variables = map[string]string{"security_contact": "security@example.com"}
+	testutil.AssertNoError(t, NewGenerator(config).GenerateFromFile(actionPath))
+
+	contributing, err := os.ReadFile(filepath.Join(tmpDir, "CONTRIBUTING.md"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(contributing), "# Contributing to Simple JavaScript Action")
+	testutil.AssertStringContains(t, string(contributing), "(https://github.com/acme/simple/issues)")
+	security, err := os.ReadFile(filepath.Join(tmpDir, "SECURITY.md"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(security), "Contact security@example.com")
+
+	// Stubs are edited by hand and never overwritten, even in check mode
+	securityPath := filepath.Join(tmpDir, "SECURITY.md")
+	testutil.WriteTestFile(t, securityPath, "# Our policy\n")
+	testutil.AssertNoError(t, NewGenerator(config).GenerateFromFile(actionPath))
+	security, err = os.ReadFile(securityPath)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "# Our policy\n", string(security))
+	checker := NewGenerator(config)
+	checker.Check = true
+	testutil.AssertNoError(t, checker.GenerateFromFile(actionPath))
+
+	testutil.AssertNoError(t, os.Remove(filepath.Join(tmpDir, "CONTRIBUTING.md")))
+	testutil.AssertError(t, checker.GenerateFromFile(actionPath))
+
+	config.Documents = []string{"changelog"}
+	testutil.AssertError(t, NewGenerator(config).GenerateFromFile(actionPath))
+
+	// Custom themes read their variables and options from the configuration
+	themeDir := filepath.Join(tmpDir, "theme")
+	testutil.WriteTestFile(t, filepath.Join(themeDir, "readme.tmpl"),
+		`# {{.Name}} by {{index .Config.Variables "team"}} in {{index .Config.Variables "channel"}}`+"\n")
+	testutil.WriteTestFile(t, filepath.Join(themeDir, ThemeManifestFile), "documents:\n  - name: readme\n"+
+		"variables:\n  - name: team\noptions:\n  - name: channel\n    default: '#help'\n")
+	config.Theme = ""
+	config.Template = filepath.Join(themeDir, "readme.tmpl")
+	config.Documents = nil
+	testutil.AssertError(t, NewGenerator(config).GenerateFromFile(actionPath))
+	config.Variables = map[string]string{"team": "platform"}
+	testutil.AssertNoError(t, NewGenerator(config).GenerateFromFile(actionPath))
+	readme, err := os.ReadFile(filepath.Join(tmpDir, "README.md"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, string(readme), "# Simple JavaScript Action by platform in #help")
+}
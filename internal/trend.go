@@ -0,0 +1,256 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
+)
+
+// Trend metrics recorded by the reporting commands.
+const (
+	TrendActions            = "actions"
+	TrendOutdated           = "outdated"
+	TrendUnpinned           = "unpinned"
+	TrendValidationErrors   = "validation_errors"
+	TrendValidationWarnings = "validation_warnings"
+	TrendStaleDocs          = "stale_docs"
+)
+
+// TrendMetricOrder lists the trend metrics in display order.
+var TrendMetricOrder = []string{
+	TrendOutdated, TrendUnpinned, TrendValidationErrors, TrendValidationWarnings, TrendStaleDocs, TrendActions,
+}
+
+// DefaultTrendMaxEntries is the number of runs kept per project by default.
+const DefaultTrendMaxEntries = 1000
+
+// sparkBars are the levels of a sparkline, lowest first.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// unsafeTrendName matches characters left out of trend file names.
+var unsafeTrendName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// TrendSettings configures the run history report trend reads.
+type TrendSettings struct {
+	// Disabled stops recording the summary of runs
+	Disabled bool `mapstructure:"disabled"    yaml:"disabled,omitempty"`
+	// MaxEntries is the number of runs kept per project, 1000 when zero
+	MaxEntries int `mapstructure:"max_entries" yaml:"max_entries,omitempty"`
+}
+
+// TrendEntry is the summary of one recorded run. Metrics holds only the
+// metrics the command measured.
+type TrendEntry struct {
+	Time    time.Time      `json:"time"`
+	Command string         `json:"command"`
+	Metrics map[string]int `json:"metrics"`
+}
+
+// TrendMetric is the history of one metric across the runs that measured it.
+type TrendMetric struct {
+	Name   string `json:"name"`
+	First  int    `json:"first"`
+	Last   int    `json:"last"`
+	Change int    `json:"change"` // Last minus first; negative is an improvement
+	Min    int    `json:"min"`
+	Max    int    `json:"max"`
+	Values []int  `json:"values"`
+}
+
+// ValidateTrendSettings rejects negative history sizes.
+func ValidateTrendSettings(settings TrendSettings) error {
+	if settings.MaxEntries < 0 {
+		return fmt.Errorf("invalid trends.max_entries %d, must not be negative", settings.MaxEntries)
+	}
+
+	return nil
+}
+
+// TrendFile returns the history file of the project at root in the XDG
+// state directory, such as ~/.local/state/gh-action-readme/trends/repo-1a2b3c4d.jsonl.
+func TrendFile(root string) (string, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", root, err)
+	}
+	sum := sha256.Sum256([]byte(abs))
+	name := unsafeTrendName.ReplaceAllString(filepath.Base(abs), "_") + "-" + hex.EncodeToString(sum[:4]) + ".jsonl"
+
+	path, err := xdg.StateFile(filepath.Join(toolName, "trends", name))
+	if err != nil {
+		return "", fmt.Errorf("failed to get XDG state directory: %w", err)
+	}
+
+	return path, nil
+}
+
+// RecordTrend appends entry to the history file at path and drops the oldest
+// runs beyond maxEntries, DefaultTrendMaxEntries when zero.
+func RecordTrend(path string, entry TrendEntry, maxEntries int) error {
+	if maxEntries <= 0 {
+		maxEntries = DefaultTrendMaxEntries
+	}
+	entries, err := LoadTrend(path)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+	if len(entries) > maxEntries {
+		entries = entries[len(entries)-maxEntries:]
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := encoder.Encode(e); err != nil {
+			return fmt.Errorf("failed to encode trend entry: %w", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil { // #nosec G301 -- state directory permissions
+		return fmt.Errorf("failed to create trend directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), FilePermDefault); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// LoadTrend reads the history file at path, oldest run first. A missing file
+// is an empty history; unreadable lines are skipped.
+func LoadTrend(path string) ([]TrendEntry, error) {
+	file, err := os.Open(path) // #nosec G304 -- trend file in the state directory
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []TrendEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry TrendEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil && len(entry.Metrics) > 0 {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+// SummarizeTrend returns the history of each metric measured in entries, in
+// TrendMetricOrder.
+func SummarizeTrend(entries []TrendEntry) []TrendMetric {
+	metrics := make([]TrendMetric, 0, len(TrendMetricOrder))
+	for _, name := range TrendMetricOrder {
+		metric := TrendMetric{Name: name}
+		for _, entry := range entries {
+			if value, ok := entry.Metrics[name]; ok {
+				metric.Values = append(metric.Values, value)
+			}
+		}
+		if len(metric.Values) == 0 {
+			continue
+		}
+		metric.First, metric.Last = metric.Values[0], metric.Values[len(metric.Values)-1]
+		metric.Change = metric.Last - metric.First
+		metric.Min, metric.Max = metric.First, metric.First
+		for _, value := range metric.Values {
+			metric.Min, metric.Max = min(metric.Min, value), max(metric.Max, value)
+		}
+		metrics = append(metrics, metric)
+	}
+
+	return metrics
+}
+
+// Sparkline draws values as a line of block characters scaled between
+// their minimum and maximum.
+func Sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lowest, highest := values[0], values[0]
+	for _, value := range values {
+		lowest, highest = min(lowest, value), max(highest, value)
+	}
+
+	var line strings.Builder
+	for _, value := range values {
+		level := 0
+		if highest > lowest {
+			level = (value - lowest) * (len(sparkBars) - 1) / (highest - lowest)
+		}
+		line.WriteRune(sparkBars[level])
+	}
+
+	return line.String()
+}
+
+// DriftTrendMetrics returns the trend metrics of a drift report. Outdated
+// dependencies are left out when they were not checked.
+func DriftTrendMetrics(report *DriftReport) map[string]int {
+	metrics := map[string]int{
+		TrendActions:   report.Summary.Actions,
+		TrendStaleDocs: report.Summary.StaleDocs,
+	}
+	unpinned, validationErrors, validationWarnings, outdatedChecked := 0, 0, 0, false
+	for _, action := range report.Actions {
+		unpinned += action.Pins.Floating
+		validationErrors += action.Validation.Errors
+		validationWarnings += action.Validation.Warnings
+		outdatedChecked = outdatedChecked || action.Outdated != nil
+	}
+	metrics[TrendUnpinned] = unpinned
+	metrics[TrendValidationErrors] = validationErrors
+	metrics[TrendValidationWarnings] = validationWarnings
+	if outdatedChecked {
+		metrics[TrendOutdated] = report.Summary.OutdatedDeps
+	}
+
+	return metrics
+}
+
+// OutdatedTrendMetrics returns the trend metrics of a deps outdated run.
+// Dependencies reported only for their age do not count.
+func OutdatedTrendMetrics(outdated []dependencies.OutdatedDependency) map[string]int {
+	updates := 0
+	for _, dep := range outdated {
+		if dep.UpdateType != "" && dep.UpdateType != "none" {
+			updates++
+		}
+	}
+
+	return map[string]int{TrendOutdated: updates}
+}
+
+// PolicyTrendMetrics returns the trend metrics of a dependency policy check.
+func PolicyTrendMetrics(report *PolicyReport) map[string]int {
+	metrics := map[string]int{
+		TrendActions:  report.Actions,
+		TrendUnpinned: len(report.Unpinned),
+	}
+	if report.OutdatedChecked {
+		metrics[TrendOutdated] = len(report.Outdated)
+	}
+
+	return metrics
+}
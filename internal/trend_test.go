@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestRecordTrend(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "trends", "repo.jsonl")
+	entries, err := LoadTrend(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(entries))
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, outdated := range []int{9, 7, 4} {
+		entry := TrendEntry{
+			Time:    start.AddDate(0, 0, i),
+			Command: "deps outdated",
+			Metrics: map[string]int{TrendOutdated: outdated},
+		}
+		testutil.AssertNoError(t, RecordTrend(path, entry, 2))
+	}
+
+	entries, err = LoadTrend(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(entries))
+	testutil.AssertEqual(t, 7, entries[0].Metrics[TrendOutdated])
+	testutil.AssertEqual(t, start.AddDate(0, 0, 2), entries[1].Time)
+
+	// Unreadable lines are skipped
+	data, err := os.ReadFile(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, os.WriteFile(path, append(data, "not json\n"...), FilePermDefault))
+	entries, err = LoadTrend(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(entries))
+}
+
+func TestSummarizeTrend(t *testing.T) {
+	t.Parallel()
+
+	entries := []TrendEntry{
+		{Command: "report drift", Metrics: map[string]int{TrendOutdated: 8, TrendUnpinned: 5}},
+		{Command: "deps security", Metrics: map[string]int{TrendUnpinned: 6}},
+		{Command: "report drift", Metrics: map[string]int{TrendOutdated: 3, TrendUnpinned: 2}},
+	}
+
+	metrics := SummarizeTrend(entries)
+	testutil.AssertEqual(t, 2, len(metrics))
+	testutil.AssertEqual(t, TrendOutdated, metrics[0].Name)
+	testutil.AssertEqual(t, -5, metrics[0].Change)
+	testutil.AssertEqual(t, TrendUnpinned, metrics[1].Name)
+	testutil.AssertEqual(t, 3, len(metrics[1].Values))
+	testutil.AssertEqual(t, 6, metrics[1].Max)
+	testutil.AssertEqual(t, 2, metrics[1].Min)
+}
+
+func TestSparkline(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertEqual(t, "", Sparkline(nil))
+	testutil.AssertEqual(t, "▁▁▁", Sparkline([]int{4, 4, 4}))
+	testutil.AssertEqual(t, "▁▄█", Sparkline([]int{0, 5, 10}))
+}
+
+func TestTrendFile(t *testing.T) {
+	t.Parallel()
+
+	path, err := TrendFile(filepath.Join(t.TempDir(), "my repo"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "trends", filepath.Base(filepath.Dir(path)))
+	testutil.AssertEqual(t, true, strings.HasPrefix(filepath.Base(path), "my_repo-"))
+	testutil.AssertEqual(t, ".jsonl", filepath.Ext(path))
+}
+
+func TestDriftTrendMetrics(t *testing.T) {
+	t.Parallel()
+
+	report := &DriftReport{
+		Summary: DriftSummary{Actions: 2, StaleDocs: 1, OutdatedDeps: 4},
+		Actions: []ActionDrift{
+			{Pins: PinCompliance{Floating: 2}, Validation: ValidationStatus{Errors: 1, Warnings: 3}},
+			{Pins: PinCompliance{Floating: 1}},
+		},
+	}
+
+	metrics := DriftTrendMetrics(report)
+	testutil.AssertEqual(t, 3, metrics[TrendUnpinned])
+	testutil.AssertEqual(t, 1, metrics[TrendValidationErrors])
+	testutil.AssertEqual(t, 3, metrics[TrendValidationWarnings])
+	_, hasOutdated := metrics[TrendOutdated]
+	testutil.AssertEqual(t, false, hasOutdated)
+
+	report.Actions[0].Outdated = &OutdatedSummary{Total: 4}
+	testutil.AssertEqual(t, 4, DriftTrendMetrics(report)[TrendOutdated])
+}
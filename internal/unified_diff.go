@@ -0,0 +1,184 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change.
+const diffContextLines = 3
+
+// lineEditKind is the kind of a line in an edit script.
+type lineEditKind int
+
+const (
+	lineEqual lineEditKind = iota
+	lineDelete
+	lineInsert
+)
+
+// lineEdit is one line of an edit script.
+type lineEdit struct {
+	kind lineEditKind
+	text string
+}
+
+// UnifiedDiff returns a unified diff turning from into to, or "" when they are
+// equal. fromName and toName label the two versions.
+func UnifiedDiff(fromName, toName, from, to string) string {
+	if from == to {
+		return ""
+	}
+
+	edits := lineEdits(splitLines(from), splitLines(to))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+	for _, hunk := range diffHunks(edits) {
+		writeHunk(&b, edits, hunk)
+	}
+
+	return b.String()
+}
+
+// splitLines splits text into lines without their line endings.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// lineEdits computes a shortest edit script from a to b with Myers' algorithm.
+func lineEdits(a, b []string) []lineEdit {
+	n, m := len(a), len(b)
+	limit := n + m
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			x := v[offset+k-1] + 1
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackEdits(trace, a, b, offset)
+			}
+		}
+	}
+
+	return nil
+}
+
+// backtrackEdits walks the Myers trace back from the end of both inputs.
+func backtrackEdits(trace [][]int, a, b []string, offset int) []lineEdit {
+	x, y := len(a), len(b)
+	var edits []lineEdit
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			edits = append(edits, lineEdit{lineEqual, a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, lineEdit{lineInsert, b[prevY]})
+			} else {
+				edits = append(edits, lineEdit{lineDelete, a[prevX]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+
+	return edits
+}
+
+// diffHunk is a range of the edit script shown as one hunk.
+type diffHunk struct {
+	start, end int
+}
+
+// diffHunks groups changed lines that are close together, with context.
+func diffHunks(edits []lineEdit) []diffHunk {
+	var hunks []diffHunk
+	for i, edit := range edits {
+		if edit.kind == lineEqual {
+			continue
+		}
+		start := max(i-diffContextLines, 0)
+		end := min(i+diffContextLines+1, len(edits))
+		if n := len(hunks); n > 0 && start <= hunks[n-1].end {
+			hunks[n-1].end = end
+		} else {
+			hunks = append(hunks, diffHunk{start, end})
+		}
+	}
+
+	return hunks
+}
+
+// writeHunk writes one hunk with its @@ header.
+func writeHunk(b *strings.Builder, edits []lineEdit, hunk diffHunk) {
+	oldStart, newStart := 1, 1
+	for _, edit := range edits[:hunk.start] {
+		if edit.kind != lineInsert {
+			oldStart++
+		}
+		if edit.kind != lineDelete {
+			newStart++
+		}
+	}
+
+	var oldLines, newLines int
+	var body strings.Builder
+	for _, edit := range edits[hunk.start:hunk.end] {
+		switch edit.kind {
+		case lineEqual:
+			oldLines++
+			newLines++
+			body.WriteString(" " + edit.text + "\n")
+		case lineDelete:
+			oldLines++
+			body.WriteString("-" + edit.text + "\n")
+		case lineInsert:
+			newLines++
+			body.WriteString("+" + edit.text + "\n")
+		}
+	}
+
+	fmt.Fprintf(b, "@@ -%s +%s @@\n%s", hunkRange(oldStart, oldLines), hunkRange(newStart, newLines), body.String())
+}
+
+// hunkRange formats a hunk line range; empty ranges start before the first line.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+
+	return fmt.Sprintf("%d,%d", start, count)
+}
@@ -2,75 +2,492 @@ package internal
 
 import (
 	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+
+	errCodes "github.com/ivuorinen/gh-action-readme/internal/errors"
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
+)
+
+// Severity classifies how serious a validation finding is.
+type Severity string
+
+// Validation finding severities.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Validation rule identifiers.
+const (
+	RuleMissingName           = "missing-name"
+	RuleMissingDescription    = "missing-description"
+	RuleMissingRuns           = "missing-runs"
+	RuleMissingRunsUsing      = "missing-runs-using"
+	RuleInvalidRuntime        = "invalid-runtime"
+	RuleMissingBranding       = "missing-branding"
+	RuleMissingInputs         = "missing-inputs"
+	RuleMissingOutputs        = "missing-outputs"
+	RuleDescriptionTooShort   = "description-too-short"
+	RuleUnpinnedStep          = "unpinned-step"
+	RuleInvalidStep           = "invalid-step"
+	RuleMissingOutputValue    = "missing-output-value"
+	RuleUnquotedBoolean       = "unquoted-boolean-default"
+	RuleUndeclaredInput       = "undeclared-input"
+	RuleUndeclaredOutput      = "undeclared-output"
+	RuleNonPortableShell      = "non-portable-shell"
+	RulePlatformSpecificStep  = "platform-specific-step"
+	RuleInvalidExample        = "invalid-example"
+	RuleUnresolvedExampleUses = "unresolved-example-uses"
 )
 
+// RuleOff disables a rule when used as a rule override.
+const RuleOff = "off"
+
+// ruleSuppressionDirective disables the listed rules for the following line of action.yml.
+const ruleSuppressionDirective = "ghreadme:disable-next-line"
+
+// validationRuleIDs lists every rule ValidateActionYML, ValidateActionFile,
+// ValidateEntrypoints, ValidatePlatformSupport and ValidateExamples can report.
+var validationRuleIDs = []string{
+	RuleMissingName,
+	RuleMissingDescription,
+	RuleMissingRuns,
+	RuleMissingRunsUsing,
+	RuleInvalidRuntime,
+	RuleMissingBranding,
+	RuleMissingInputs,
+	RuleMissingOutputs,
+	RuleDescriptionTooShort,
+	RuleUnpinnedStep,
+	RuleInvalidStep,
+	RuleMissingOutputValue,
+	RuleUnquotedBoolean,
+	RuleUndeclaredInput,
+	RuleUndeclaredOutput,
+	RuleNonPortableShell,
+	RulePlatformSpecificStep,
+	RuleInvalidExample,
+	RuleUnresolvedExampleUses,
+}
+
+// minDescriptionLength is the shortest description that is not flagged as too short.
+const minDescriptionLength = 20
+
+// pinnedRefPattern matches step references pinned to a full commit SHA. Tags,
+// even full semantic versions, can be moved and do not count as pinned.
+var pinnedRefPattern = regexp.MustCompile(`^[a-f0-9]{40}$`)
+
+// Finding is a single validation result produced by a rule.
+type Finding struct {
+	RuleID   string   `json:"rule_id"`
+	Severity Severity `json:"severity"`
+	Field    string   `json:"field"`
+	Message  string   `json:"message"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	// Suggestion explains how to resolve the finding, when there is a known fix.
+	Suggestion string `json:"suggestion,omitempty"`
+	// Fix is a machine-applicable fix for the finding, when one can be derived from the source.
+	Fix *errCodes.Fix `json:"fix,omitempty"`
+}
+
+// Location returns the file and line of the finding, when known.
+func (f Finding) Location() string {
+	switch {
+	case f.File == "":
+		return ""
+	case f.Line > 0:
+		return fmt.Sprintf("%s:%d", f.File, f.Line)
+	default:
+		return f.File
+	}
+}
+
 // ValidationResult holds the results of action.yml validation.
 type ValidationResult struct {
+	File          string
+	Findings      []Finding
 	MissingFields []string
 	Warnings      []string
 	Suggestions   []string
 }
 
+// HasErrors reports whether any finding has error severity.
+func (r *ValidationResult) HasErrors() bool {
+	return r.Count(SeverityError) > 0
+}
+
+// Failed reports whether the result should fail validation.
+// In strict mode warnings fail validation as well as errors.
+func (r *ValidationResult) Failed(strict bool) bool {
+	if strict {
+		return r.HasErrors() || r.Count(SeverityWarning) > 0
+	}
+
+	return r.HasErrors()
+}
+
+// Count returns the number of findings with the given severity.
+func (r *ValidationResult) Count(severity Severity) int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			count++
+		}
+	}
+
+	return count
+}
+
+// ApplyRules disables findings or overrides their severity using rule overrides
+// keyed by rule ID. Values are "off" or a severity name.
+func (r *ValidationResult) ApplyRules(rules map[string]string) {
+	if len(rules) == 0 {
+		return
+	}
+
+	findings := r.Findings[:0]
+	for _, finding := range r.Findings {
+		override, ok := rules[finding.RuleID]
+		switch {
+		case !ok:
+		case override == RuleOff:
+			continue
+		default:
+			finding.Severity = Severity(override)
+		}
+		findings = append(findings, finding)
+	}
+	r.Findings = findings
+}
+
+// addFinding records a finding produced by a rule.
+func (r *ValidationResult) addFinding(ruleID string, severity Severity, field, message string) {
+	r.Findings = append(r.Findings, Finding{
+		RuleID:   ruleID,
+		Severity: severity,
+		Field:    field,
+		Message:  message,
+	})
+}
+
+// suggest records a suggestion for the most recent finding and for the result as a whole.
+func (r *ValidationResult) suggest(suggestion string) {
+	r.Suggestions = append(r.Suggestions, suggestion)
+	if n := len(r.Findings); n > 0 {
+		r.Findings[n-1].Suggestion = suggestion
+	}
+}
+
 // ValidateActionYML checks if required fields are present and valid.
 func ValidateActionYML(action *ActionYML) ValidationResult {
 	result := ValidationResult{}
 
-	// Validate required fields with helpful suggestions
+	validateRequiredFields(action, &result)
+	validateRunsSection(action, &result)
+	validateRecommendedFields(action, &result)
+	validateCompositeSteps(action, &result)
+	validateCompositeOutputs(action, &result)
+
+	return result
+}
+
+// ValidateActionFile parses and validates an action file, attaching file and line
+// locations to every finding.
+func ValidateActionFile(path string) (ValidationResult, error) {
+	action, err := ParseActionYML(path)
+	if err != nil {
+		return ValidationResult{}, err
+	}
+
+	result := ValidateActionYML(action)
+	result.File = path
+	if content, err := os.ReadFile(path); err == nil { // #nosec G304 -- path from function parameter
+		locateFindings(content, result.Findings)
+		result.Findings = append(result.Findings, unquotedBooleanFindings(content, path)...)
+		attachFixes(content, path, result.Findings)
+		result.Findings = suppressFindings(content, result.Findings)
+	}
+
+	return result, nil
+}
+
+// ValidateRuleConfig checks that rule overrides reference known rules and valid values.
+func ValidateRuleConfig(rules map[string]string) error {
+	validValues := []string{RuleOff, string(SeverityError), string(SeverityWarning), string(SeverityInfo)}
+	for _, ruleID := range slices.Sorted(maps.Keys(rules)) {
+		if !slices.Contains(validationRuleIDs, ruleID) {
+			return fmt.Errorf("unknown validation rule '%s', must be one of: %s",
+				ruleID, strings.Join(validationRuleIDs, ", "))
+		}
+		if !slices.Contains(validValues, rules[ruleID]) {
+			return fmt.Errorf("invalid setting '%s' for rule '%s', must be one of: %s",
+				rules[ruleID], ruleID, strings.Join(validValues, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateRequiredFields checks the top-level fields every action must declare.
+func validateRequiredFields(action *ActionYML, result *ValidationResult) {
 	if action.Name == "" {
 		result.MissingFields = append(result.MissingFields, "name")
-		result.Suggestions = append(result.Suggestions, "Add 'name: Your Action Name' to describe your action")
+		result.addFinding(RuleMissingName, SeverityError, "name", "Missing required field: name")
+		result.suggest("Add 'name: Your Action Name' to describe your action")
 	}
 	if action.Description == "" {
 		result.MissingFields = append(result.MissingFields, "description")
-		result.Suggestions = append(
-			result.Suggestions,
-			"Add 'description: Brief description of what your action does' for better documentation",
+		result.addFinding(RuleMissingDescription, SeverityError, "description", "Missing required field: description")
+		result.suggest("Add 'description: Brief description of what your action does' for better documentation")
+	} else if len(strings.TrimSpace(action.Description)) < minDescriptionLength {
+		result.addFinding(
+			RuleDescriptionTooShort,
+			SeverityWarning,
+			"description",
+			fmt.Sprintf("Description is shorter than %d characters", minDescriptionLength),
 		)
 	}
+}
+
+// validateRunsSection checks the runs section and its runtime.
+func validateRunsSection(action *ActionYML, result *ValidationResult) {
 	if len(action.Runs) == 0 {
 		result.MissingFields = append(result.MissingFields, "runs")
-		result.Suggestions = append(
-			result.Suggestions,
-			"Add 'runs:' section with 'using: node20' or 'using: docker' and specify the main file",
+		result.addFinding(RuleMissingRuns, SeverityError, "runs", "Missing required field: runs")
+		result.suggest("Add 'runs:' section with 'using: node20' or 'using: docker' and specify the main file")
+
+		return
+	}
+
+	using, ok := action.Runs["using"].(string)
+	if !ok {
+		result.MissingFields = append(result.MissingFields, "runs.using")
+		result.addFinding(RuleMissingRunsUsing, SeverityError, "runs", "Missing required field: runs.using")
+		result.suggest(
+			"Missing 'using' field in runs section. Specify 'using: node20', 'using: docker', or 'using: composite'",
+		)
+
+		return
+	}
+
+	if !isValidRuntime(using) {
+		result.MissingFields = append(result.MissingFields, "runs.using")
+		result.addFinding(RuleInvalidRuntime, SeverityError, "runs.using", fmt.Sprintf("Invalid runtime '%s'", using))
+		result.suggest(
+			fmt.Sprintf("Invalid runtime '%s'. Valid runtimes: node12, node16, node20, node24, docker, composite", using),
 		)
-	} else {
-		// Validate the runs section content
-		if using, ok := action.Runs["using"].(string); ok {
-			if !isValidRuntime(using) {
-				result.MissingFields = append(result.MissingFields, "runs.using")
-				result.Suggestions = append(
-					result.Suggestions,
-					fmt.Sprintf("Invalid runtime '%s'. Valid runtimes: node12, node16, node20, docker, composite", using),
-				)
-			}
-		} else {
-			result.MissingFields = append(result.MissingFields, "runs.using")
-			result.Suggestions = append(
-				result.Suggestions,
-				"Missing 'using' field in runs section. Specify 'using: node20', 'using: docker', or 'using: composite'",
-			)
-		}
 	}
+}
 
-	// Add warnings for optional but recommended fields
+// validateRecommendedFields adds warnings for optional but recommended fields.
+func validateRecommendedFields(action *ActionYML, result *ValidationResult) {
 	if action.Branding == nil {
 		result.Warnings = append(result.Warnings, "branding")
-		result.Suggestions = append(
-			result.Suggestions,
-			"Consider adding 'branding:' with 'icon' and 'color' for better marketplace appearance",
-		)
+		result.addFinding(RuleMissingBranding, SeverityWarning, "branding", "Missing recommended field: branding")
+		result.suggest("Consider adding 'branding:' with 'icon' and 'color' for better marketplace appearance")
 	}
 	if len(action.Inputs) == 0 {
 		result.Warnings = append(result.Warnings, "inputs")
-		result.Suggestions = append(result.Suggestions, "Consider adding 'inputs:' if your action accepts parameters")
+		result.addFinding(RuleMissingInputs, SeverityInfo, "inputs", "No inputs declared")
+		result.suggest("Consider adding 'inputs:' if your action accepts parameters")
 	}
 	if len(action.Outputs) == 0 {
 		result.Warnings = append(result.Warnings, "outputs")
-		result.Suggestions = append(result.Suggestions, "Consider adding 'outputs:' if your action produces results")
+		result.addFinding(RuleMissingOutputs, SeverityInfo, "outputs", "No outputs declared")
+		result.suggest("Consider adding 'outputs:' if your action produces results")
 	}
+}
 
-	return result
+// validateCompositeSteps warns about composite steps that use actions without a pinned ref.
+func validateCompositeSteps(action *ActionYML, result *ValidationResult) {
+	steps, ok := action.Runs["steps"].([]any)
+	if !ok {
+		return
+	}
+
+	for i, step := range steps {
+		stepMap, ok := step.(map[string]any)
+		if !ok {
+			continue
+		}
+		validateStepShape(i, stepMap, result)
+		validateStepShell(i, stepMap, result)
+
+		uses, ok := stepMap["uses"].(string)
+		if !ok || strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://") {
+			continue
+		}
+
+		_, ref, found := strings.Cut(uses, "@")
+		if !found || !pinnedRefPattern.MatchString(ref) {
+			result.addFinding(
+				RuleUnpinnedStep,
+				SeverityWarning,
+				fmt.Sprintf("runs.steps[%d].uses", i),
+				fmt.Sprintf("Step uses '%s' without a pinned commit SHA", uses),
+			)
+		}
+	}
+}
+
+// validateStepShape reports composite steps that neither run a command nor use
+// an action, or that run a command without the shell composite steps require.
+func validateStepShape(index int, step map[string]any, result *ValidationResult) {
+	field := fmt.Sprintf("runs.steps[%d]", index)
+	_, hasRun := step["run"]
+	_, hasUses := step["uses"]
+	switch {
+	case hasRun && hasUses:
+		result.addFinding(RuleInvalidStep, SeverityError, field, "Step cannot declare both 'run' and 'uses'")
+	case !hasRun && !hasUses:
+		result.addFinding(RuleInvalidStep, SeverityError, field, "Step must declare either 'run' or 'uses'")
+	case hasRun && step["shell"] == nil:
+		result.addFinding(RuleInvalidStep, SeverityError, field, "Step with 'run' must declare 'shell'")
+		if script, _ := step["run"].(string); script != "" {
+			if construct, found := findBashism(script); found {
+				result.suggest(fmt.Sprintf("Add 'shell: bash'; the script uses bash-only %s", construct))
+			}
+		}
+	}
+}
+
+// validateCompositeOutputs reports composite action outputs without a value,
+// which GitHub requires to map the output to a step output.
+func validateCompositeOutputs(action *ActionYML, result *ValidationResult) {
+	if using, _ := action.Runs["using"].(string); using != "composite" {
+		return
+	}
+
+	for _, output := range action.OutputList() {
+		if output.Value == "" {
+			result.addFinding(
+				RuleMissingOutputValue,
+				SeverityError,
+				"outputs."+output.Name,
+				fmt.Sprintf("Composite action output '%s' is missing 'value'", output.Name),
+			)
+		}
+	}
+}
+
+// locateFindings resolves the position of each finding's field in the source
+// file. Findings for absent fields point at their closest present parent, or
+// at the first key of the document for absent top-level fields.
+func locateFindings(content []byte, findings []Finding) {
+	file, err := parser.ParseBytes(content, 0)
+	if err != nil || len(file.Docs) == 0 || file.Docs[0].Body == nil {
+		return
+	}
+
+	for i := range findings {
+		if position := fieldPosition(file.Docs[0].Body, findings[i].Field); position != nil {
+			findings[i].Line = position.Line
+			findings[i].Column = position.Column
+		}
+	}
+}
+
+// fieldPosition returns the position of field, a path such as
+// runs.steps[0].uses, in the YAML document root. Scalars are located at their
+// value and mappings and sequences at their key. An absent field resolves to
+// its closest present parent.
+func fieldPosition(root ast.Node, field string) *token.Position {
+	node, position := root, nodePosition(root)
+	for segment := range strings.SplitSeq(field, ".") {
+		key, indexes, _ := strings.Cut(segment, "[")
+		pair := yamlsafe.MappingPair(node, key)
+		if pair == nil {
+			return position
+		}
+		node = unwrapAnchor(pair.Value)
+		switch {
+		case node != nil && node.GetToken() != nil && len(yamlsafe.MappingValues(node)) == 0 && !isSequence(node):
+			position = node.GetToken().Position
+		case pair.Key.GetToken() != nil:
+			position = pair.Key.GetToken().Position
+		}
+
+		for index := range strings.SplitSeq(strings.TrimSuffix(indexes, "]"), "][") {
+			n, err := strconv.Atoi(index)
+			sequence, ok := node.(*ast.SequenceNode)
+			if err != nil || !ok || n < 0 || n >= len(sequence.Values) {
+				break
+			}
+			node = unwrapAnchor(sequence.Values[n])
+			position = nodePosition(sequence.Values[n])
+		}
+	}
+
+	return position
+}
+
+// nodePosition returns the position of the first key of a mapping node, or
+// of the node itself.
+func nodePosition(node ast.Node) *token.Position {
+	if values := yamlsafe.MappingValues(node); len(values) > 0 && values[0].Key != nil && values[0].Key.GetToken() != nil {
+		return values[0].Key.GetToken().Position
+	}
+	if node.GetToken() == nil {
+		return nil
+	}
+
+	return node.GetToken().Position
+}
+
+// isSequence reports whether node is a YAML sequence.
+func isSequence(node ast.Node) bool {
+	_, ok := node.(*ast.SequenceNode)
+
+	return ok
+}
+
+// suppressFindings drops findings disabled by a "# ghreadme:disable-next-line" comment
+// on the line above them. Without rule IDs the comment suppresses every rule.
+func suppressFindings(content []byte, findings []Finding) []Finding {
+	lines := strings.Split(string(content), "\n")
+	kept := findings[:0]
+	for _, finding := range findings {
+		if finding.Line < 2 || finding.Line > len(lines) {
+			kept = append(kept, finding)
+
+			continue
+		}
+		rules, ok := suppressedRules(lines[finding.Line-2])
+		if ok && (len(rules) == 0 || slices.Contains(rules, finding.RuleID)) {
+			continue
+		}
+		kept = append(kept, finding)
+	}
+
+	return kept
+}
+
+// suppressedRules parses a suppression comment and returns the rule IDs it names.
+func suppressedRules(line string) ([]string, bool) {
+	_, comment, found := strings.Cut(line, "#")
+	if !found {
+		return nil, false
+	}
+	rest, found := strings.CutPrefix(strings.TrimSpace(comment), ruleSuppressionDirective)
+	if !found {
+		return nil, false
+	}
+
+	return strings.FieldsFunc(rest, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}), true
 }
 
 // isValidRuntime checks if the given runtime is valid for GitHub Actions.
@@ -79,6 +496,7 @@ func isValidRuntime(runtime string) bool {
 		"node12",    // Legacy Node.js runtime (deprecated)
 		"node16",    // Legacy Node.js runtime (deprecated)
 		"node20",    // Current Node.js runtime
+		"node24",    // Current Node.js runtime
 		"docker",    // Docker container runtime
 		"composite", // Composite action runtime
 	}
@@ -0,0 +1,129 @@
+package wizard
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/ivuorinen/gh-action-readme/internal"
+)
+
+// AnswersEnvPrefix prefixes the environment variables that answer wizard
+// questions in a non-interactive run, such as GH_ACTION_README_WIZARD_THEME
+// for theme and GH_ACTION_README_WIZARD_CACHE_TTL for cache.ttl.
+const AnswersEnvPrefix = "GH_ACTION_README_WIZARD_"
+
+// answerKeys are the questions of the wizard that can be answered non-interactively.
+var answerKeys = []string{
+	"organization",
+	"repository",
+	"version",
+	internal.ConfigKeyTheme,
+	internal.ConfigKeyOutputFormat,
+	internal.ConfigKeyOutputDir,
+	internal.ConfigKeyAnalyzeDependencies,
+	internal.ConfigKeyShowSecurityInfo,
+	"test_token",
+	"setup_token",
+	internal.ConfigKeyGitHubToken,
+	"keep_unverified_token",
+	"cache.ttl",
+	"cache.backend",
+	"deps.pin_strategy",
+	"deps.fail_on",
+	"confirm",
+}
+
+// Answers answer the wizard questions in a non-interactive run, keyed by the
+// setting they configure, such as "theme" or "cache.ttl". Options are answered
+// by name or number, yes/no questions with y/n or true/false, and lists are
+// comma-separated.
+type Answers map[string]string
+
+// LoadAnswers reads answers from a YAML file. Nested mappings such as
+// "cache: {ttl: 24h}" answer the dotted key cache.ttl.
+func LoadAnswers(path string) (Answers, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- answers path from user input
+	if err != nil {
+		return nil, fmt.Errorf("failed to read answers %s: %w", path, err)
+	}
+
+	var values map[string]any
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse answers %s: %w", path, err)
+	}
+	answers := Answers{}
+	if err := flattenAnswers("", values, answers); err != nil {
+		return nil, fmt.Errorf("invalid answers %s: %w", path, err)
+	}
+
+	return answers, nil
+}
+
+// AnswersFromEnv returns answers with the answers set in AnswersEnvPrefix
+// environment variables added, overriding answers to the same question.
+func AnswersFromEnv(answers Answers) Answers {
+	merged := maps.Clone(answers)
+	if merged == nil {
+		merged = Answers{}
+	}
+	for _, key := range answerKeys {
+		if value, ok := os.LookupEnv(answerEnvName(key)); ok {
+			merged[key] = value
+		}
+	}
+
+	return merged
+}
+
+// Validate rejects answers to unknown questions, which are usually misspelled.
+func (a Answers) Validate() error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(a)) {
+		if !slices.Contains(answerKeys, key) {
+			errs = append(errs, fmt.Errorf("unknown answer %s", key))
+		}
+	}
+	if len(errs) > 0 {
+		errs = append(errs, errors.New("valid answers: "+strings.Join(answerKeys, ", ")))
+	}
+
+	return errors.Join(errs...)
+}
+
+// answerEnvName returns the environment variable that answers key.
+func answerEnvName(key string) string {
+	return AnswersEnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// flattenAnswers adds the scalar values of value to answers under dotted keys.
+func flattenAnswers(prefix string, value any, answers Answers) error {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, nested := range value {
+			if err := flattenAnswers(prefix+key+".", nested, answers); err != nil {
+				return err
+			}
+		}
+	case []any:
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = fmt.Sprint(item)
+		}
+		answers[strings.TrimSuffix(prefix, ".")] = strings.Join(items, ",")
+	case nil:
+		answers[strings.TrimSuffix(prefix, ".")] = ""
+	default:
+		if prefix == "" {
+			return errors.New("answers must be a mapping of questions to answers")
+		}
+		answers[strings.TrimSuffix(prefix, ".")] = fmt.Sprint(value)
+	}
+
+	return nil
+}
@@ -9,11 +9,10 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/goccy/go-yaml"
-
 	"github.com/ivuorinen/gh-action-readme/internal"
 	"github.com/ivuorinen/gh-action-readme/internal/git"
 	"github.com/ivuorinen/gh-action-readme/internal/helpers"
+	"github.com/ivuorinen/gh-action-readme/internal/yamlsafe"
 )
 
 const (
@@ -300,7 +299,7 @@ func (d *ProjectDetector) parseActionFile(actionFile string) (map[string]any, er
 	}
 
 	var action map[string]any
-	if err := yaml.Unmarshal(data, &action); err != nil {
+	if err := yamlsafe.Unmarshal(data, &action); err != nil {
 		return nil, fmt.Errorf("failed to parse action YAML: %w", err)
 	}
 
@@ -4,8 +4,12 @@ package wizard
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/goccy/go-yaml"
 
@@ -166,6 +170,7 @@ func (e *ConfigExporter) sanitizeConfig(config *internal.AppConfig) *internal.Ap
 	sanitized := *config
 
 	// Remove sensitive information
+	sanitized.ConfigVersion = internal.CurrentConfigVersion
 	sanitized.GitHubToken = ""    // Never export tokens
 	sanitized.RepoOverrides = nil // Don't export repo overrides
 
@@ -200,6 +205,7 @@ func (e *ConfigExporter) sanitizeConfig(config *internal.AppConfig) *internal.Ap
 
 // writeTOMLConfig writes a basic TOML configuration.
 func (e *ConfigExporter) writeTOMLConfig(file *os.File, config *internal.AppConfig) {
+	_, _ = fmt.Fprintf(file, "config_version = %d\n\n", internal.CurrentConfigVersion)
 	e.writeRepositorySection(file, config)
 	e.writeTemplateSection(file, config)
 	e.writeFeaturesSection(file, config)
@@ -207,6 +213,8 @@ func (e *ConfigExporter) writeTOMLConfig(file *os.File, config *internal.AppConf
 	e.writeWorkflowSection(file, config)
 	e.writePermissionsSection(file, config)
 	e.writeVariablesSection(file, config)
+	e.writeCacheSection(file, config)
+	e.writeDepsSection(file, config)
 }
 
 // writeRepositorySection writes the repository information section.
@@ -229,6 +237,9 @@ func (e *ConfigExporter) writeTemplateSection(file *os.File, config *internal.Ap
 	_, _ = fmt.Fprintf(file, "theme = %q\n", config.Theme)
 	_, _ = fmt.Fprintf(file, "output_format = %q\n", config.OutputFormat)
 	_, _ = fmt.Fprintf(file, "output_dir = %q\n", config.OutputDir)
+	if config.SortInputs != "" {
+		_, _ = fmt.Fprintf(file, "sort_inputs = %q\n", config.SortInputs)
+	}
 }
 
 // writeFeaturesSection writes the features section.
@@ -236,6 +247,9 @@ func (e *ConfigExporter) writeFeaturesSection(file *os.File, config *internal.Ap
 	_, _ = fmt.Fprintf(file, "\n# Features\n")
 	_, _ = fmt.Fprintf(file, "analyze_dependencies = %t\n", config.AnalyzeDependencies)
 	_, _ = fmt.Fprintf(file, "show_security_info = %t\n", config.ShowSecurityInfo)
+	_, _ = fmt.Fprintf(file, "show_metrics = %t\n", config.ShowMetrics)
+	_, _ = fmt.Fprintf(file, "show_support = %t\n", config.ShowSupport)
+	_, _ = fmt.Fprintf(file, "show_compatibility = %t\n", config.ShowCompatibility)
 }
 
 // writeBehaviorSection writes the behavior section.
@@ -269,8 +283,8 @@ func (e *ConfigExporter) writePermissionsSection(file *os.File, config *internal
 	}
 
 	_, _ = fmt.Fprintf(file, "\n[permissions]\n")
-	for key, value := range config.Permissions {
-		_, _ = fmt.Fprintf(file, "%s = %q\n", key, value)
+	for _, key := range slices.Sorted(maps.Keys(config.Permissions)) {
+		_, _ = fmt.Fprintf(file, "%s = %q\n", key, config.Permissions[key])
 	}
 }
 
@@ -281,7 +295,41 @@ func (e *ConfigExporter) writeVariablesSection(file *os.File, config *internal.A
 	}
 
 	_, _ = fmt.Fprintf(file, "\n[variables]\n")
-	for key, value := range config.Variables {
-		_, _ = fmt.Fprintf(file, "%s = %q\n", key, value)
+	for _, key := range slices.Sorted(maps.Keys(config.Variables)) {
+		_, _ = fmt.Fprintf(file, "%s = %q\n", key, config.Variables[key])
+	}
+}
+
+// writeCacheSection writes the dependency cache section.
+func (e *ConfigExporter) writeCacheSection(file *os.File, config *internal.AppConfig) {
+	if config.Cache.TTL == "" && config.Cache.Backend == "" {
+		return
+	}
+
+	_, _ = fmt.Fprintf(file, "\n[cache]\n")
+	if config.Cache.TTL != "" {
+		_, _ = fmt.Fprintf(file, "ttl = %q\n", config.Cache.TTL)
+	}
+	if config.Cache.Backend != "" {
+		_, _ = fmt.Fprintf(file, "backend = %q\n", config.Cache.Backend)
+	}
+}
+
+// writeDepsSection writes the dependency policy section.
+func (e *ConfigExporter) writeDepsSection(file *os.File, config *internal.AppConfig) {
+	if config.Deps.PinStrategy == "" && len(config.Deps.FailOn) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(file, "\n[deps]\n")
+	if config.Deps.PinStrategy != "" {
+		_, _ = fmt.Fprintf(file, "pin_strategy = %q\n", config.Deps.PinStrategy)
+	}
+	if len(config.Deps.FailOn) > 0 {
+		quoted := make([]string, len(config.Deps.FailOn))
+		for i, rule := range config.Deps.FailOn {
+			quoted[i] = strconv.Quote(rule)
+		}
+		_, _ = fmt.Fprintf(file, "fail_on = [%s]\n", strings.Join(quoted, ", "))
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -53,6 +54,8 @@ func createTestConfig() *internal.AppConfig {
 		Variables:           map[string]string{"TEST_VAR": "test_value"},
 		Permissions:         map[string]string{"contents": "read"},
 		RunsOn:              []string{"ubuntu-latest"},
+		Cache:               internal.CacheSettings{TTL: "24h", Backend: "memory"},
+		Deps:                internal.DepsPolicy{PinStrategy: "tag", FailOn: []string{"floating", "major"}},
 	}
 }
 
@@ -134,6 +137,12 @@ func verifyYAMLContent(t *testing.T, outputPath string, expected *internal.AppCo
 	if yamlConfig.Theme != expected.Theme {
 		t.Errorf("Theme = %v, want %v", yamlConfig.Theme, expected.Theme)
 	}
+	if yamlConfig.Cache != expected.Cache {
+		t.Errorf("Cache = %v, want %v", yamlConfig.Cache, expected.Cache)
+	}
+	if !slices.Equal(yamlConfig.Deps.FailOn, expected.Deps.FailOn) {
+		t.Errorf("Deps.FailOn = %v, want %v", yamlConfig.Deps.FailOn, expected.Deps.FailOn)
+	}
 }
 
 // verifyJSONContent verifies JSON content is valid and contains expected data.
@@ -172,6 +181,11 @@ func verifyTOMLContent(t *testing.T, outputPath string) {
 	if !strings.Contains(content, `theme = "github"`) {
 		t.Error("TOML should contain theme field")
 	}
+	for _, want := range []string{"[cache]\nttl = \"24h\"\nbackend = \"memory\"", `fail_on = ["floating", "major"]`} {
+		if !strings.Contains(content, want) {
+			t.Errorf("TOML should contain %q, got:\n%s", want, content)
+		}
+	}
 }
 
 func TestConfigExporter_sanitizeConfig(t *testing.T) {
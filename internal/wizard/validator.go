@@ -3,9 +3,11 @@ package wizard
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/ivuorinen/gh-action-readme/internal"
@@ -205,7 +207,7 @@ func (v *ConfigValidator) validateVersion(version string, result *ValidationResu
 
 // validateTheme validates the theme field.
 func (v *ConfigValidator) validateTheme(theme string, result *ValidationResult) {
-	validThemes := []string{"default", "github", "gitlab", "minimal", "professional"}
+	validThemes := []string{"default", "github", "gitlab", "minimal", "professional", "docs-site", "badge-minimal"}
 
 	found := false
 	for _, validTheme := range validThemes {
@@ -341,7 +343,8 @@ func (v *ConfigValidator) validatePermissions(permissions map[string]string, res
 		"statuses":            {"read", "write"},
 	}
 
-	for permission, value := range permissions {
+	for _, permission := range slices.Sorted(maps.Keys(permissions)) {
+		value := permissions[permission]
 		// Check if permission is valid
 		validValues, permissionExists := validPermissions[permission]
 		if !permissionExists {
@@ -428,7 +431,8 @@ func (v *ConfigValidator) validateVariables(variables map[string]string, result
 		return
 	}
 
-	for key, value := range variables {
+	for _, key := range slices.Sorted(maps.Keys(variables)) {
+		value := variables[key]
 		// Check for reserved variable names
 		reservedNames := []string{"GITHUB_TOKEN", "GITHUB_ACTOR", "GITHUB_REPOSITORY", "GITHUB_SHA"}
 		for _, reserved := range reservedNames {
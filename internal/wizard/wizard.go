@@ -2,37 +2,76 @@
 package wizard
 
 import (
-	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ivuorinen/gh-action-readme/internal"
+	"github.com/ivuorinen/gh-action-readme/internal/cache"
+	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
 	"github.com/ivuorinen/gh-action-readme/internal/git"
 	"github.com/ivuorinen/gh-action-readme/internal/helpers"
 )
 
+// tokenCheckTimeout bounds the test request made with a GitHub token.
+const tokenCheckTimeout = 10 * time.Second
+
 // ConfigWizard handles interactive configuration setup.
 type ConfigWizard struct {
 	output    *internal.ColoredOutput
-	scanner   *bufio.Scanner
+	prompter  internal.Prompter
 	config    *internal.AppConfig
 	repoInfo  *git.RepoInfo
 	actionDir string
+	// checkToken makes a test request with a token and returns the login it authenticates as
+	checkToken func(token string) (string, error)
+	// answers replace interactive input in a non-interactive run, nil otherwise
+	answers Answers
+	// answerErrs collects the answers of a non-interactive run that were rejected
+	answerErrs []error
+}
+
+// wizardOption is a numbered choice of a wizard prompt.
+type wizardOption struct {
+	name string
+	desc string
 }
 
-// NewConfigWizard creates a new configuration wizard instance.
-func NewConfigWizard(output *internal.ColoredOutput) *ConfigWizard {
+// NewConfigWizard creates a new configuration wizard instance asking its
+// questions with prompter.
+func NewConfigWizard(output *internal.ColoredOutput, prompter internal.Prompter) *ConfigWizard {
 	return &ConfigWizard{
-		output:  output,
-		scanner: bufio.NewScanner(os.Stdin),
-		config:  internal.DefaultAppConfig(),
+		output:     output,
+		prompter:   prompter,
+		config:     internal.DefaultAppConfig(),
+		checkToken: checkGitHubToken,
 	}
 }
 
+// NewNonInteractiveWizard creates a wizard that takes its answers from answers
+// instead of standard input. Unanswered questions use their defaults, and
+// answers are validated like interactive input, except that Run fails on
+// invalid answers instead of asking again.
+func NewNonInteractiveWizard(output *internal.ColoredOutput, answers Answers) (*ConfigWizard, error) {
+	if err := answers.Validate(); err != nil {
+		return nil, err
+	}
+
+	w := NewConfigWizard(output, nil)
+	w.answers = answers
+	if w.answers == nil {
+		w.answers = Answers{}
+	}
+
+	return w, nil
+}
+
 // Run executes the interactive configuration wizard.
 func (w *ConfigWizard) Run() (*internal.AppConfig, error) {
 	w.output.Bold("🧙 Welcome to gh-action-readme Configuration Wizard!")
@@ -55,7 +94,17 @@ func (w *ConfigWizard) Run() (*internal.AppConfig, error) {
 	// Step 5: Configure GitHub integration
 	w.configureGitHubIntegration()
 
-	// Step 6: Summary and confirmation
+	// Step 6: Configure the dependency cache
+	w.configureCache()
+
+	// Step 7: Configure the dependency policy
+	w.configureDepsPolicy()
+
+	if err := errors.Join(w.answerErrs...); err != nil {
+		return nil, fmt.Errorf("invalid answers: %w", err)
+	}
+
+	// Step 8: Summary and confirmation
 	if err := w.showSummaryAndConfirm(); err != nil {
 		return nil, fmt.Errorf("configuration canceled: %w", err)
 	}
@@ -103,13 +152,13 @@ func (w *ConfigWizard) configureBasicSettings() {
 	w.output.Bold("\n⚙️  Step 2: Basic Settings")
 
 	// Organization
-	w.config.Organization = w.promptWithDefault("Organization/Owner", w.config.Organization)
+	w.config.Organization = w.promptWithDefault("organization", "Organization/Owner", w.config.Organization)
 
 	// Repository
-	w.config.Repository = w.promptWithDefault("Repository Name", w.config.Repository)
+	w.config.Repository = w.promptWithDefault("repository", "Repository Name", w.config.Repository)
 
 	// Version (optional)
-	version := w.promptWithDefault("Version (optional)", "")
+	version := w.promptWithDefault("version", "Version (optional)", "")
 	if version != "" {
 		w.config.Version = version
 	}
@@ -127,74 +176,31 @@ func (w *ConfigWizard) configureTemplateSettings() {
 // configureThemeSelection handles theme selection.
 func (w *ConfigWizard) configureThemeSelection() {
 	w.output.Info("Available themes:")
-	themes := w.getAvailableThemes()
-
-	w.displayThemeOptions(themes)
-
-	themeChoice := w.promptWithDefault("Choose theme (1-5)", "1")
-	if choice, err := strconv.Atoi(themeChoice); err == nil && choice >= 1 && choice <= len(themes) {
-		w.config.Theme = themes[choice-1].name
-	}
+	w.config.Theme = w.promptOption(internal.ConfigKeyTheme, "Choose theme", []wizardOption{
+		{internal.ThemeDefault, "Original simple template"},
+		{internal.ThemeGitHub, "GitHub-style with badges and collapsible sections"},
+		{internal.ThemeGitLab, "GitLab-focused with CI/CD examples"},
+		{internal.ThemeMinimal, "Clean and concise documentation"},
+		{internal.ThemeProfessional, "Comprehensive with troubleshooting and ToC"},
+		{internal.ThemeDocsSite, "Front matter for MkDocs and Docusaurus docs sites"},
+		{internal.ThemeBadgeMinimal, "Compact marketplace listing with badges and one example"},
+	}, w.config.Theme)
 }
 
 // configureOutputFormat handles output format selection.
 func (w *ConfigWizard) configureOutputFormat() {
 	w.output.Info("\nAvailable output formats:")
-	formats := []string{"md", "html", "json", "asciidoc"}
-
-	w.displayFormatOptions(formats)
-
-	formatChoice := w.promptWithDefault("Choose output format (1-4)", "1")
-	if choice, err := strconv.Atoi(formatChoice); err == nil && choice >= 1 && choice <= len(formats) {
-		w.config.OutputFormat = formats[choice-1]
-	}
+	w.config.OutputFormat = w.promptOption(internal.ConfigKeyOutputFormat, "Choose output format", []wizardOption{
+		{"md", "Markdown README"},
+		{"html", "Standalone HTML page"},
+		{"json", "Machine-readable action metadata"},
+		{"asciidoc", "AsciiDoc README"},
+	}, w.config.OutputFormat)
 }
 
 // configureOutputDirectory handles output directory configuration.
 func (w *ConfigWizard) configureOutputDirectory() {
-	w.config.OutputDir = w.promptWithDefault("Output directory", w.config.OutputDir)
-}
-
-// getAvailableThemes returns the list of available themes.
-func (w *ConfigWizard) getAvailableThemes() []struct {
-	name string
-	desc string
-} {
-	return []struct {
-		name string
-		desc string
-	}{
-		{"default", "Original simple template"},
-		{"github", "GitHub-style with badges and collapsible sections"},
-		{"gitlab", "GitLab-focused with CI/CD examples"},
-		{"minimal", "Clean and concise documentation"},
-		{"professional", "Comprehensive with troubleshooting and ToC"},
-	}
-}
-
-// displayThemeOptions displays the theme options with current selection.
-func (w *ConfigWizard) displayThemeOptions(themes []struct {
-	name string
-	desc string
-}) {
-	for i, theme := range themes {
-		marker := " "
-		if theme.name == w.config.Theme {
-			marker = "►"
-		}
-		w.output.Printf("  %s %d. %s - %s", marker, i+1, theme.name, theme.desc)
-	}
-}
-
-// displayFormatOptions displays the output format options with current selection.
-func (w *ConfigWizard) displayFormatOptions(formats []string) {
-	for i, format := range formats {
-		marker := " "
-		if format == w.config.OutputFormat {
-			marker = "►"
-		}
-		w.output.Printf("  %s %d. %s", marker, i+1, format)
-	}
+	w.config.OutputDir = w.promptWithDefault(internal.ConfigKeyOutputDir, "Output directory", w.config.OutputDir)
 }
 
 // configureFeatures handles feature configuration.
@@ -203,13 +209,13 @@ func (w *ConfigWizard) configureFeatures() {
 
 	// Dependency analysis
 	w.output.Info("Dependency analysis provides detailed information about GitHub Action dependencies.")
-	analyzeDeps := w.promptYesNo("Enable dependency analysis?", w.config.AnalyzeDependencies)
-	w.config.AnalyzeDependencies = analyzeDeps
+	w.config.AnalyzeDependencies = w.promptYesNo(internal.ConfigKeyAnalyzeDependencies,
+		"Enable dependency analysis?", w.config.AnalyzeDependencies)
 
 	// Security information
 	w.output.Info("Security information shows pinned vs floating versions and security recommendations.")
-	showSecurity := w.promptYesNo("Show security information?", w.config.ShowSecurityInfo)
-	w.config.ShowSecurityInfo = showSecurity
+	w.config.ShowSecurityInfo = w.promptYesNo(internal.ConfigKeyShowSecurityInfo,
+		"Show security information?", w.config.ShowSecurityInfo)
 }
 
 // configureGitHubIntegration handles GitHub API configuration.
@@ -220,57 +226,120 @@ func (w *ConfigWizard) configureGitHubIntegration() {
 	existingToken := internal.GetGitHubToken(w.config)
 	if existingToken != "" {
 		w.output.Success("GitHub token already configured ✓")
+		if w.promptYesNo("test_token", "Test the configured token now?", true) {
+			w.testToken(existingToken)
+		}
 
 		return
 	}
 
 	w.output.Info("GitHub integration requires a personal access token for:")
-	w.output.Printf("  • Enhanced dependency analysis")
-	w.output.Printf("  • Latest version checking")
-	w.output.Printf("  • Repository information")
-	w.output.Printf("  • Rate limit improvements")
+	w.output.Printf("  • Enhanced dependency analysis\n")
+	w.output.Printf("  • Latest version checking\n")
+	w.output.Printf("  • Repository information\n")
+	w.output.Printf("  • Rate limit improvements\n")
 
-	setupToken := w.promptYesNo("Set up GitHub token now?", false)
+	setupToken := w.promptYesNo("setup_token", "Set up GitHub token now?", w.answers[internal.ConfigKeyGitHubToken] != "")
 	if !setupToken {
 		w.output.Info("You can set up the token later using environment variables:")
-		w.output.Printf("  export GITHUB_TOKEN=your_personal_access_token")
+		w.output.Printf("  export GITHUB_TOKEN=your_personal_access_token\n")
 
 		return
 	}
 
 	w.output.Info("\nTo create a personal access token:")
-	w.output.Printf("  1. Visit: https://github.com/settings/tokens")
-	w.output.Printf("  2. Click 'Generate new token (classic)'")
-	w.output.Printf("  3. Select scopes: 'repo' (for private repos) or 'public_repo' (for public only)")
-	w.output.Printf("  4. Copy the generated token")
-
-	token := w.promptSensitive("Enter your GitHub token (or press Enter to skip)")
-	if token != "" {
-		// Validate token format (basic check)
-		if strings.HasPrefix(token, "ghp_") || strings.HasPrefix(token, "github_pat_") {
-			w.config.GitHubToken = token
-			w.output.Success("GitHub token configured ✓")
-		} else {
-			w.output.Warning("Token format looks unusual. You can update it later if needed.")
-			w.config.GitHubToken = token
+	w.output.Printf("  1. Visit: https://github.com/settings/tokens\n")
+	w.output.Printf("  2. Click 'Generate new token (classic)'\n")
+	w.output.Printf("  3. Select scopes: 'repo' (for private repos) or 'public_repo' (for public only)\n")
+	w.output.Printf("  4. Copy the generated token\n")
+
+	token := w.promptSensitive(internal.ConfigKeyGitHubToken, "Enter your GitHub token (or press Enter to skip)")
+	if token == "" {
+		return
+	}
+
+	// Validate token format (basic check)
+	if !strings.HasPrefix(token, "ghp_") && !strings.HasPrefix(token, "github_pat_") {
+		w.output.Warning("Token format looks unusual. You can update it later if needed.")
+	}
+	if !w.testToken(token) && !w.promptYesNo("keep_unverified_token", "Keep this token anyway?", false) {
+		if w.answers != nil {
+			w.reject(internal.ConfigKeyGitHubToken, "token test failed; answer keep_unverified_token to keep it", "")
 		}
+		w.output.Info("Token discarded. Set GITHUB_TOKEN later to enable GitHub integration.")
+
+		return
+	}
+	w.config.GitHubToken = token
+	w.output.Success("GitHub token configured ✓")
+}
+
+// configureCache handles the dependency cache settings.
+func (w *ConfigWizard) configureCache() {
+	w.output.Bold("\n🗄️  Step 6: Cache Settings")
+
+	w.output.Info("GitHub API responses, such as latest versions, are cached to save rate limit.")
+	ttl := w.promptWithDefault("cache.ttl", "Cache TTL (e.g. 30m, 1h, 24h)", w.config.Cache.TTL)
+	if err := internal.ValidateCacheSettings(internal.CacheSettings{TTL: ttl}); err != nil {
+		w.reject("cache.ttl", err.Error(), w.config.Cache.TTL)
+	} else {
+		w.config.Cache.TTL = ttl
 	}
+
+	w.output.Info("\nCache backends:")
+	w.config.Cache.Backend = w.promptOption("cache.backend", "Choose cache backend", []wizardOption{
+		{cache.BackendDisk, "Shared between runs in the XDG cache directory"},
+		{cache.BackendMemory, "Kept for the current run only"},
+	}, w.config.Cache.Backend)
+}
+
+// configureDepsPolicy handles the default policy of the deps commands.
+func (w *ConfigWizard) configureDepsPolicy() {
+	w.output.Bold("\n📌 Step 7: Dependency Policy")
+
+	w.output.Info("Pin strategy used by 'deps pin' and 'deps upgrade':")
+	w.config.Deps.PinStrategy = w.promptOption("deps.pin_strategy", "Choose pin strategy", []wizardOption{
+		{dependencies.PinStrategySHA, "Commit SHA with the version as a comment (most secure)"},
+		{dependencies.PinStrategyTag, "Exact version tag, such as v4.1.1"},
+	}, w.config.Deps.PinStrategy)
+
+	w.output.Info("\nFail rules make 'deps security' and 'deps outdated' exit with an error, for CI.")
+	if w.answers != nil {
+		w.answerFailOn()
+
+		return
+	}
+	rules := []struct {
+		name   string
+		prompt string
+	}{
+		{internal.FailOnFloating, "Fail when a dependency is not pinned?"},
+		{internal.FailOnOutdated, "Fail when any dependency has an update?"},
+		{internal.FailOnMajor, "Fail when a dependency has a major update?"},
+	}
+	var failOn []string
+	for _, rule := range rules {
+		if w.promptYesNo("", rule.prompt, slices.Contains(w.config.Deps.FailOn, rule.name)) {
+			failOn = append(failOn, rule.name)
+		}
+	}
+	w.config.Deps.FailOn = failOn
 }
 
 // showSummaryAndConfirm displays configuration summary and asks for confirmation.
 func (w *ConfigWizard) showSummaryAndConfirm() error {
-	w.output.Bold("\n📋 Step 6: Configuration Summary")
+	w.output.Bold("\n📋 Step 8: Configuration Summary")
 
 	w.output.Info("Your configuration:")
-	w.output.Printf("  Repository: %s/%s", w.config.Organization, w.config.Repository)
+	w.output.Printf("  Repository: %s/%s\n", w.config.Organization, w.config.Repository)
 	if w.config.Version != "" {
-		w.output.Printf("  Version: %s", w.config.Version)
+		w.output.Printf("  Version: %s\n", w.config.Version)
 	}
-	w.output.Printf("  Theme: %s", w.config.Theme)
-	w.output.Printf("  Output Format: %s", w.config.OutputFormat)
-	w.output.Printf("  Output Directory: %s", w.config.OutputDir)
-	w.output.Printf("  Dependency Analysis: %t", w.config.AnalyzeDependencies)
-	w.output.Printf("  Security Information: %t", w.config.ShowSecurityInfo)
+	w.output.Printf("  Theme: %s\n", w.config.Theme)
+	w.output.Printf("  Output Format: %s\n", w.config.OutputFormat)
+	w.output.Printf("  Output Directory: %s\n", w.config.OutputDir)
+	w.output.Printf("  Dependency Analysis: %t\n", w.config.AnalyzeDependencies)
+	w.output.Printf("  Security Information: %t\n", w.config.ShowSecurityInfo)
 
 	tokenStatus := "Not configured"
 	if w.config.GitHubToken != "" {
@@ -278,7 +347,14 @@ func (w *ConfigWizard) showSummaryAndConfirm() error {
 	} else if internal.GetGitHubToken(w.config) != "" {
 		tokenStatus = "Configured via environment ✓" // #nosec G101 -- status message, not actual token
 	}
-	w.output.Printf("  GitHub Token: %s", tokenStatus)
+	w.output.Printf("  GitHub Token: %s\n", tokenStatus)
+	w.output.Printf("  Cache: %s, TTL %s\n", w.config.Cache.Backend, w.config.Cache.TTL)
+	w.output.Printf("  Pin Strategy: %s\n", w.config.Deps.PinStrategy)
+	failOn := "none"
+	if len(w.config.Deps.FailOn) > 0 {
+		failOn = strings.Join(w.config.Deps.FailOn, ", ")
+	}
+	w.output.Printf("  Fail On: %s\n", failOn)
 
 	return w.confirmConfiguration()
 }
@@ -286,7 +362,7 @@ func (w *ConfigWizard) showSummaryAndConfirm() error {
 // confirmConfiguration asks user to confirm the configuration.
 func (w *ConfigWizard) confirmConfiguration() error {
 	w.output.Info("")
-	confirmed := w.promptYesNo("Save this configuration?", true)
+	confirmed := w.promptYesNo("confirm", "Save this configuration?", true)
 	if !confirmed {
 		return errors.New("configuration canceled by user")
 	}
@@ -294,62 +370,146 @@ func (w *ConfigWizard) confirmConfiguration() error {
 	return nil
 }
 
-// promptWithDefault prompts for input with a default value.
-func (w *ConfigWizard) promptWithDefault(prompt, defaultValue string) string {
+// promptWithDefault prompts for input with a default value. key names the
+// answer used in a non-interactive run.
+func (w *ConfigWizard) promptWithDefault(key, prompt, defaultValue string) string {
+	question := prompt + ": "
 	if defaultValue != "" {
-		w.output.Printf("%s [%s]: ", prompt, defaultValue)
-	} else {
-		w.output.Printf("%s: ", prompt)
+		question = fmt.Sprintf("%s [%s]: ", prompt, defaultValue)
 	}
 
-	if w.scanner.Scan() {
-		input := strings.TrimSpace(w.scanner.Text())
-		if input == "" {
-			return defaultValue
+	input := w.input(key, question, false)
+	if input == "" {
+		return defaultValue
+	}
+
+	return input
+}
+
+// promptOption prompts for one of the numbered options and returns its name,
+// or current when the answer is not a valid choice. Answers may also name the option.
+func (w *ConfigWizard) promptOption(key, prompt string, options []wizardOption, current string) string {
+	defaultChoice := 1
+	for i, option := range options {
+		marker := " "
+		if option.name == current {
+			marker = "►"
+			defaultChoice = i + 1
 		}
+		w.output.Printf("  %s %d. %s - %s\n", marker, i+1, option.name, option.desc)
+	}
 
-		return input
+	answer := w.promptWithDefault(key, fmt.Sprintf("%s (1-%d)", prompt, len(options)), strconv.Itoa(defaultChoice))
+	if choice, err := strconv.Atoi(answer); err == nil && choice >= 1 && choice <= len(options) {
+		return options[choice-1].name
+	}
+	for _, option := range options {
+		if strings.EqualFold(answer, option.name) {
+			return option.name
+		}
+	}
+	names := make([]string, len(options))
+	for i, option := range options {
+		names[i] = option.name
 	}
+	w.reject(key, fmt.Sprintf("'%s' is not one of 1-%d or %s", answer, len(options), strings.Join(names, ", ")), current)
 
-	return defaultValue
+	return current
 }
 
 // promptSensitive prompts for sensitive input (like tokens) without echoing.
-func (w *ConfigWizard) promptSensitive(prompt string) string {
-	w.output.Printf("%s: ", prompt)
-	if w.scanner.Scan() {
-		return strings.TrimSpace(w.scanner.Text())
-	}
-
-	return ""
+func (w *ConfigWizard) promptSensitive(key, prompt string) string {
+	return w.input(key, prompt+": ", true)
 }
 
 // promptYesNo prompts for a yes/no answer.
-func (w *ConfigWizard) promptYesNo(prompt string, defaultValue bool) bool {
+func (w *ConfigWizard) promptYesNo(key, prompt string, defaultValue bool) bool {
 	defaultStr := "y/N"
 	if defaultValue {
 		defaultStr = "Y/n"
 	}
 
-	w.output.Printf("%s [%s]: ", prompt, defaultStr)
+	answer := w.input(key, fmt.Sprintf("%s [%s]: ", prompt, defaultStr), false)
+	switch strings.ToLower(answer) {
+	case "y", "yes", "true":
+		return true
+	case "n", "no", "false":
+		return false
+	case "":
+		return defaultValue
+	default:
+		w.reject(key, fmt.Sprintf("'%s' is not y or n", answer), strconv.FormatBool(defaultValue))
+
+		return defaultValue
+	}
+}
+
+// input asks question and returns the answer: the prompter's, empty when it
+// has none, or the answer for key in a non-interactive run, echoed unless secret.
+func (w *ConfigWizard) input(key, question string, secret bool) string {
+	if w.answers == nil {
+		answer, _ := w.prompter.Prompt(question) // No answer keeps the default
+
+		return answer
+	}
+
+	w.output.Printf("%s", question)
+	answer := strings.TrimSpace(w.answers[key])
+	if secret && answer != "" {
+		w.output.Printf("********\n")
+	} else {
+		w.output.Printf("%s\n", answer)
+	}
+
+	return answer
+}
+
+// reject reports an invalid answer to the question key: a warning in an
+// interactive run, which continues with kept, and an error that fails a
+// non-interactive run.
+func (w *ConfigWizard) reject(key, problem, kept string) {
+	if w.answers == nil {
+		w.output.Warning("%s. Using %s.", problem, kept)
+
+		return
+	}
+	w.answerErrs = append(w.answerErrs, fmt.Errorf("%s: %s", key, problem))
+}
 
-	if w.scanner.Scan() {
-		input := strings.ToLower(strings.TrimSpace(w.scanner.Text()))
-		switch input {
-		case "y", "yes":
-			return true
-		case "n", "no":
-			return false
-		case "":
-			return defaultValue
-		default:
-			w.output.Warning("Please answer 'y' or 'n'. Using default.")
+// answerFailOn sets deps.fail_on from the comma-separated answer of a non-interactive run.
+func (w *ConfigWizard) answerFailOn() {
+	answer, ok := w.answers["deps.fail_on"]
+	if !ok {
+		return
+	}
 
-			return defaultValue
+	var failOn []string
+	for rule := range strings.SplitSeq(answer, ",") {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			failOn = append(failOn, rule)
 		}
 	}
+	if err := internal.ValidateDepsPolicy(internal.DepsPolicy{FailOn: failOn}); err != nil {
+		w.reject("deps.fail_on", err.Error(), "")
+
+		return
+	}
+	w.config.Deps.FailOn = failOn
+	w.output.Printf("  Fail on: %s\n", strings.Join(failOn, ", "))
+}
+
+// testToken makes a test request with token and reports whether it succeeded.
+func (w *ConfigWizard) testToken(token string) bool {
+	w.output.Info("Testing the token with a GitHub API request...")
+	login, err := w.checkToken(token)
+	if err != nil {
+		w.output.Warning("Token test failed: %v", err)
 
-	return defaultValue
+		return false
+	}
+	w.output.Success("Token works, authenticated as %s ✓", login)
+
+	return true
 }
 
 // findActionFiles discovers action files in the given directory.
@@ -366,3 +526,20 @@ func (w *ConfigWizard) findActionFiles(dir string) []string {
 
 	return actionFiles
 }
+
+// checkGitHubToken requests the authenticated user with token and returns its login.
+func checkGitHubToken(token string) (string, error) {
+	client, err := internal.NewGitHubClient(token)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tokenCheckTimeout)
+	defer cancel()
+	user, _, err := client.Client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("GitHub API request failed: %w", err)
+	}
+
+	return user.GetLogin(), nil
+}
@@ -0,0 +1,174 @@
+package wizard
+
+import (
+	"errors"
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/internal"
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+// newTestWizard returns a wizard that reads the given answers, one per line,
+// and accepts only the token "ghp_valid".
+func newTestWizard(answers ...string) *ConfigWizard {
+	return &ConfigWizard{
+		output:   internal.NewColoredOutput(true),
+		prompter: internal.NewScriptedPrompter(answers...),
+		config:   internal.DefaultAppConfig(),
+		checkToken: func(token string) (string, error) {
+			if token != "ghp_valid" {
+				return "", errors.New("401 Bad credentials")
+			}
+
+			return "octocat", nil
+		},
+	}
+}
+
+func TestConfigWizard_ConfigureGitHubIntegration(t *testing.T) {
+	t.Setenv(internal.EnvGitHubToken, "")
+	t.Setenv(internal.EnvGitHubTokenStandard, "")
+
+	tests := []struct {
+		name    string
+		answers []string
+		want    string
+	}{
+		{name: "valid token", answers: []string{"y", "ghp_valid"}, want: "ghp_valid"},
+		{name: "rejected token discarded", answers: []string{"y", "ghp_invalid", "n"}, want: ""},
+		{name: "rejected token kept", answers: []string{"y", "ghp_invalid", "y"}, want: "ghp_invalid"},
+		{name: "skipped", answers: []string{"n"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newTestWizard(tt.answers...)
+			w.configureGitHubIntegration()
+			testutil.AssertEqual(t, tt.want, w.config.GitHubToken)
+		})
+	}
+}
+
+func TestConfigWizard_ConfigureCache(t *testing.T) {
+	t.Parallel()
+
+	w := newTestWizard("24h", "2")
+	w.configureCache()
+	testutil.AssertEqual(t, internal.CacheSettings{TTL: "24h", Backend: "memory"}, w.config.Cache)
+
+	// Invalid answers keep the current settings
+	w = newTestWizard("soon", "9")
+	w.configureCache()
+	testutil.AssertEqual(t, internal.DefaultCacheSettings(), w.config.Cache)
+}
+
+func TestConfigWizard_ConfigureDepsPolicy(t *testing.T) {
+	t.Parallel()
+
+	w := newTestWizard("2", "y", "n", "y")
+	w.configureDepsPolicy()
+	testutil.AssertEqual(t, "tag", w.config.Deps.PinStrategy)
+	if want := []string{internal.FailOnFloating, internal.FailOnMajor}; !slices.Equal(w.config.Deps.FailOn, want) {
+		t.Errorf("FailOn = %v, want %v", w.config.Deps.FailOn, want)
+	}
+
+	// Defaults keep the SHA strategy and no fail rules
+	w = newTestWizard("", "", "", "")
+	w.configureDepsPolicy()
+	testutil.AssertEqual(t, "sha", w.config.Deps.PinStrategy)
+	if len(w.config.Deps.FailOn) != 0 {
+		t.Errorf("FailOn = %v, want none", w.config.Deps.FailOn)
+	}
+}
+
+func TestConfigWizard_NonInteractive(t *testing.T) {
+	t.Setenv(internal.EnvGitHubToken, "")
+	t.Setenv(internal.EnvGitHubTokenStandard, "")
+
+	w, err := NewNonInteractiveWizard(internal.NewColoredOutput(true), Answers{
+		"theme":              "GitHub",
+		"output_format":      "3",
+		"show_security_info": "true",
+		"github_token":       "ghp_valid",
+		"cache.ttl":          "24h",
+		"deps.pin_strategy":  "tag",
+		"deps.fail_on":       "floating, major",
+	})
+	testutil.AssertNoError(t, err)
+	w.checkToken = newTestWizard().checkToken
+
+	config, err := w.Run()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "github", config.Theme)
+	testutil.AssertEqual(t, "json", config.OutputFormat)
+	testutil.AssertEqual(t, true, config.ShowSecurityInfo)
+	testutil.AssertEqual(t, "ghp_valid", config.GitHubToken)
+	testutil.AssertEqual(t, internal.CacheSettings{TTL: "24h", Backend: "disk"}, config.Cache)
+	testutil.AssertEqual(t, "tag", config.Deps.PinStrategy)
+	if want := []string{"floating", "major"}; !slices.Equal(config.Deps.FailOn, want) {
+		t.Errorf("FailOn = %v, want %v", config.Deps.FailOn, want)
+	}
+}
+
+func TestConfigWizard_NonInteractiveRejectsInvalidAnswers(t *testing.T) {
+	t.Setenv(internal.EnvGitHubToken, "")
+	t.Setenv(internal.EnvGitHubTokenStandard, "")
+
+	w, err := NewNonInteractiveWizard(internal.NewColoredOutput(true), Answers{
+		"theme":                "fancy",
+		"analyze_dependencies": "maybe",
+		"github_token":         "ghp_invalid",
+		"cache.ttl":            "soon",
+		"deps.fail_on":         "stale",
+	})
+	testutil.AssertNoError(t, err)
+	w.checkToken = newTestWizard().checkToken
+
+	_, err = w.Run()
+	testutil.AssertError(t, err)
+	for _, key := range []string{"theme", "analyze_dependencies", "github_token", "cache.ttl", "deps.fail_on"} {
+		testutil.AssertStringContains(t, err.Error(), key+":")
+	}
+
+	_, err = NewNonInteractiveWizard(internal.NewColoredOutput(true), Answers{"colour": "red"})
+	testutil.AssertError(t, err)
+	testutil.AssertStringContains(t, err.Error(), "unknown answer colour")
+}
+
+func TestLoadAnswers(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "answers.yml")
+	testutil.WriteTestFile(t, path, "theme: minimal\nanalyze_dependencies: true\ncache:\n  ttl: 2h\n"+
+		"deps:\n  fail_on: [floating, outdated]\n")
+
+	answers, err := LoadAnswers(path)
+	testutil.AssertNoError(t, err)
+	want := Answers{
+		"theme":                "minimal",
+		"analyze_dependencies": "true",
+		"cache.ttl":            "2h",
+		"deps.fail_on":         "floating,outdated",
+	}
+	if !maps.Equal(answers, want) {
+		t.Errorf("LoadAnswers() = %v, want %v", answers, want)
+	}
+
+	testutil.WriteTestFile(t, path, "- theme\n")
+	_, err = LoadAnswers(path)
+	testutil.AssertError(t, err)
+}
+
+func TestAnswersFromEnv(t *testing.T) {
+	t.Setenv(AnswersEnvPrefix+"THEME", "gitlab")
+	t.Setenv(AnswersEnvPrefix+"CACHE_BACKEND", "memory")
+
+	answers := AnswersFromEnv(Answers{"theme": "minimal", "output_dir": "docs"})
+	want := Answers{"theme": "gitlab", "cache.backend": "memory", "output_dir": "docs"}
+	if !maps.Equal(answers, want) {
+		t.Errorf("AnswersFromEnv() = %v, want %v", answers, want)
+	}
+}
@@ -0,0 +1,38 @@
+package yamlsafe
+
+import "github.com/goccy/go-yaml/ast"
+
+// MappingValues returns the key/value pairs of a YAML mapping node, looking
+// through anchors.
+func MappingValues(node ast.Node) []*ast.MappingValueNode {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		return n.Values
+	case *ast.MappingValueNode:
+		return []*ast.MappingValueNode{n}
+	case *ast.AnchorNode:
+		return MappingValues(n.Value)
+	default:
+		return nil
+	}
+}
+
+// MappingValue returns the value of key in a YAML mapping node, or nil.
+func MappingValue(node ast.Node, key string) ast.Node {
+	if pair := MappingPair(node, key); pair != nil {
+		return pair.Value
+	}
+
+	return nil
+}
+
+// MappingPair returns the key/value pair of key in a YAML mapping node, or nil.
+func MappingPair(node ast.Node, key string) *ast.MappingValueNode {
+	for _, value := range MappingValues(node) {
+		if value.Key != nil && value.Key.String() == key {
+			return value
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package yamlsafe
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestMappingValue(t *testing.T) {
+	t.Parallel()
+
+	file, err := parser.ParseBytes([]byte("runs: &runs\n  using: node20\n  main: index.js\nname: Single\n"), 0)
+	testutil.AssertNoError(t, err)
+	root := file.Docs[0].Body
+
+	runs := MappingValue(root, "runs")
+	testutil.AssertEqual(t, 2, len(MappingValues(runs)))
+	using, ok := MappingValue(runs, "using").(*ast.StringNode)
+	testutil.AssertEqual(t, true, ok)
+	testutil.AssertEqual(t, "node20", using.Value)
+	testutil.AssertEqual(t, 4, MappingPair(root, "name").Key.GetToken().Position.Line)
+	testutil.AssertEqual(t, true, MappingValue(root, "missing") == nil)
+	testutil.AssertEqual(t, 0, len(MappingValues(using)))
+}
@@ -0,0 +1,248 @@
+// Package yamlsafe decodes action metadata that may use YAML anchors, aliases and
+// merge keys, rejecting documents whose aliases expand beyond safe limits.
+package yamlsafe
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// Default limits applied to every document.
+const (
+	// DefaultMaxBytes is the largest document accepted, before parsing.
+	DefaultMaxBytes = 1 << 20
+	// DefaultMaxNodes is the largest number of nodes a document may expand to.
+	DefaultMaxNodes = 100000
+	// DefaultMaxDepth is the deepest nesting a document may expand to.
+	DefaultMaxDepth = 64
+)
+
+// ErrLimitExceeded is returned when a document is larger, expands to more nodes
+// or nests deeper than the configured limits.
+var ErrLimitExceeded = errors.New("YAML document exceeds safety limits")
+
+// Limits bounds the size of documents accepted by Unmarshal. Zero fields use the defaults.
+type Limits struct {
+	MaxBytes int
+	MaxNodes int
+	MaxDepth int
+}
+
+// limits holds the limits set with SetLimits.
+var limits atomic.Pointer[Limits]
+
+// maxCheckedDocuments bounds the documents remembered as within the limits.
+const maxCheckedDocuments = 4096
+
+// checkedKey identifies a document checked against a set of limits.
+type checkedKey struct {
+	sum    [sha256.Size]byte
+	limits Limits
+}
+
+// checked remembers the documents found within the limits, and whether they
+// use merge keys, so that a file decoded by several commands in one process is
+// parsed and measured once.
+var checked = struct {
+	sync.Mutex
+	documents map[checkedKey]bool
+}{documents: make(map[checkedKey]bool)}
+
+// ClearChecked forgets the documents found within the limits, so the next
+// Unmarshal of each measures it again.
+func ClearChecked() {
+	checked.Lock()
+	defer checked.Unlock()
+	clear(checked.documents)
+}
+
+// SetLimits replaces the limits used by Unmarshal.
+func SetLimits(l Limits) {
+	limits.Store(&l)
+}
+
+// CurrentLimits returns the limits used by Unmarshal, with defaults filled in.
+func CurrentLimits() Limits {
+	var l Limits
+	if current := limits.Load(); current != nil {
+		l = *current
+	}
+	if l.MaxBytes <= 0 {
+		l.MaxBytes = DefaultMaxBytes
+	}
+	if l.MaxNodes <= 0 {
+		l.MaxNodes = DefaultMaxNodes
+	}
+	if l.MaxDepth <= 0 {
+		l.MaxDepth = DefaultMaxDepth
+	}
+
+	return l
+}
+
+// Unmarshal decodes content into v after checking that the document and its
+// alias expansion stay within the current limits. Keys set next to a merge key
+// (<<) override the merged keys; keys written twice in the same mapping are
+// still rejected by the parser.
+func Unmarshal(content []byte, v any, opts ...yaml.DecodeOption) error {
+	l := CurrentLimits()
+	if len(content) > l.MaxBytes {
+		return fmt.Errorf("%w: document is %d bytes, larger than %d", ErrLimitExceeded, len(content), l.MaxBytes)
+	}
+
+	mergeKeys, err := checkDocument(content, l)
+	if err != nil {
+		return err
+	}
+	if mergeKeys {
+		opts = append(opts, yaml.AllowDuplicateMapKey())
+	}
+
+	return yaml.UnmarshalWithOptions(content, v, opts...)
+}
+
+// checkDocument parses content and checks that its alias expansion stays
+// within l, reporting whether it uses merge keys. Documents already found
+// within the same limits are not parsed again.
+func checkDocument(content []byte, l Limits) (bool, error) {
+	key := checkedKey{sum: sha256.Sum256(content), limits: l}
+	checked.Lock()
+	mergeKeys, ok := checked.documents[key]
+	checked.Unlock()
+	if ok {
+		return mergeKeys, nil
+	}
+
+	file, err := parser.ParseBytes(content, 0)
+	if err != nil {
+		return false, err
+	}
+	w := &walker{limits: l, anchors: make(map[string]extent)}
+	for _, doc := range file.Docs {
+		if _, err := w.measure(doc.Body, 0); err != nil {
+			return false, err
+		}
+	}
+
+	checked.Lock()
+	if len(checked.documents) >= maxCheckedDocuments {
+		clear(checked.documents)
+	}
+	checked.documents[key] = w.mergeKeys
+	checked.Unlock()
+
+	return w.mergeKeys, nil
+}
+
+// extent is the expanded size and nesting height of a node.
+type extent struct {
+	size   int
+	height int
+}
+
+// walker measures nodes in document order, so every alias refers to an anchor
+// that has already been measured.
+type walker struct {
+	limits    Limits
+	anchors   map[string]extent
+	mergeKeys bool
+}
+
+// measure returns the expanded extent of node found at the given depth.
+func (w *walker) measure(node ast.Node, depth int) (extent, error) {
+	if depth > w.limits.MaxDepth {
+		return extent{}, w.depthError()
+	}
+
+	result, err := w.extentOf(node, depth)
+	if err != nil {
+		return extent{}, err
+	}
+
+	return w.check(result, depth)
+}
+
+// extentOf measures a node by type, recording anchors and resolving aliases.
+func (w *walker) extentOf(node ast.Node, depth int) (extent, error) {
+	switch n := node.(type) {
+	case nil:
+		return extent{}, nil
+	case *ast.MappingNode:
+		return w.measureMapping(n, depth)
+	case *ast.MappingValueNode:
+		return w.measureMapping(&ast.MappingNode{Values: []*ast.MappingValueNode{n}}, depth)
+	case *ast.SequenceNode:
+		return w.measureChildren(n.Values, depth)
+	case *ast.AnchorNode:
+		result, err := w.measure(n.Value, depth)
+		w.anchors[n.Name.GetToken().Value] = result
+
+		return result, err
+	case *ast.AliasNode:
+		name := n.Value.GetToken().Value
+		if anchor, ok := w.anchors[name]; ok {
+			return anchor, nil
+		}
+
+		return extent{}, fmt.Errorf("undefined alias %q", name)
+	case *ast.TagNode:
+		return w.measure(n.Value, depth)
+	default:
+		return extent{size: 1}, nil
+	}
+}
+
+// measureMapping measures the values of a mapping, noting merge keys.
+func (w *walker) measureMapping(mapping *ast.MappingNode, depth int) (extent, error) {
+	children := make([]ast.Node, 0, len(mapping.Values))
+	for _, value := range mapping.Values {
+		if _, ok := value.Key.(*ast.MergeKeyNode); ok {
+			w.mergeKeys = true
+		}
+		children = append(children, value.Value)
+	}
+
+	return w.measureChildren(children, depth)
+}
+
+// measureChildren sums the extents of child nodes one level below depth.
+func (w *walker) measureChildren(children []ast.Node, depth int) (extent, error) {
+	result := extent{size: 1}
+	for _, child := range children {
+		childExtent, err := w.measure(child, depth+1)
+		if err != nil {
+			return extent{}, err
+		}
+		result.size += childExtent.size
+		result.height = max(result.height, childExtent.height+1)
+		if _, err := w.check(result, depth); err != nil {
+			return extent{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// check rejects an extent that exceeds the limits when placed at depth.
+func (w *walker) check(result extent, depth int) (extent, error) {
+	if result.size > w.limits.MaxNodes {
+		return extent{}, fmt.Errorf("%w: expands to more than %d nodes", ErrLimitExceeded, w.limits.MaxNodes)
+	}
+	if depth+result.height > w.limits.MaxDepth {
+		return extent{}, w.depthError()
+	}
+
+	return result, nil
+}
+
+// depthError reports nesting beyond the depth limit.
+func (w *walker) depthError() error {
+	return fmt.Errorf("%w: nesting deeper than %d levels", ErrLimitExceeded, w.limits.MaxDepth)
+}
@@ -0,0 +1,106 @@
+package yamlsafe
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ivuorinen/gh-action-readme/testutil"
+)
+
+func TestUnmarshal_MergeKeys(t *testing.T) {
+	t.Parallel()
+
+	content := `
+x-input: &input
+  description: Shared description
+  required: true
+inputs:
+  token:
+    <<: *input
+    description: GitHub token
+  path: *input
+`
+	var action struct {
+		Inputs map[string]struct {
+			Description string `yaml:"description"`
+			Required    bool   `yaml:"required"`
+		} `yaml:"inputs"`
+	}
+	testutil.AssertNoError(t, Unmarshal([]byte(content), &action))
+	testutil.AssertEqual(t, "GitHub token", action.Inputs["token"].Description)
+	testutil.AssertEqual(t, true, action.Inputs["token"].Required)
+	testutil.AssertEqual(t, "Shared description", action.Inputs["path"].Description)
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	t.Parallel()
+
+	laughs := []string{"a: &a [lol, lol, lol, lol, lol, lol, lol, lol, lol, lol]"}
+	for i := 1; i < 9; i++ {
+		prev, name := string(rune('a'+i-1)), string(rune('a'+i))
+		laughs = append(laughs, name+": &"+name+" ["+strings.Repeat("*"+prev+", ", 9)+"*"+prev+"]")
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		limitErr    bool
+		errContains string
+	}{
+		{
+			name:     "billion laughs",
+			content:  strings.Join(laughs, "\n"),
+			limitErr: true,
+		},
+		{
+			name:     "deep nesting",
+			content:  strings.Repeat("[", DefaultMaxDepth+2) + strings.Repeat("]", DefaultMaxDepth+2),
+			limitErr: true,
+		},
+		{
+			name:        "duplicate explicit key next to merge key",
+			content:     "base: &base {a: 1}\nvalue:\n  <<: *base\n  b: 2\n  b: 3\n",
+			errContains: "already defined",
+		},
+		{
+			name:        "duplicate key without merge key",
+			content:     "a: 1\na: 2\n",
+			errContains: "already defined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var value any
+			err := Unmarshal([]byte(tt.content), &value)
+			testutil.AssertError(t, err)
+			testutil.AssertEqual(t, tt.limitErr, errors.Is(err, ErrLimitExceeded))
+			if tt.errContains != "" {
+				testutil.AssertStringContains(t, err.Error(), tt.errContains)
+			}
+		})
+	}
+}
+
+// TestSetLimits is not parallel because it changes the package limits.
+func TestSetLimits(t *testing.T) {
+	defer SetLimits(Limits{})
+
+	// Documents checked under the default limits are checked again under new ones
+	var value any
+	testutil.AssertNoError(t, Unmarshal([]byte("[a, b, c, d]"), &value))
+
+	SetLimits(Limits{MaxBytes: 16, MaxNodes: 4})
+	testutil.AssertEqual(t, Limits{MaxBytes: 16, MaxNodes: 4, MaxDepth: DefaultMaxDepth}, CurrentLimits())
+
+	err := Unmarshal([]byte("name: a rather long action name\n"), &value)
+	testutil.AssertEqual(t, true, errors.Is(err, ErrLimitExceeded))
+	testutil.AssertStringContains(t, err.Error(), "larger than 16")
+
+	err = Unmarshal([]byte("[a, b, c, d]"), &value)
+	testutil.AssertEqual(t, true, errors.Is(err, ErrLimitExceeded))
+	testutil.AssertStringContains(t, err.Error(), "more than 4 nodes")
+}
@@ -2,21 +2,35 @@
 package main
 
 import (
+	"cmp"
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
 
 	"github.com/ivuorinen/gh-action-readme/internal"
 	"github.com/ivuorinen/gh-action-readme/internal/cache"
+	"github.com/ivuorinen/gh-action-readme/internal/clock"
+	"github.com/ivuorinen/gh-action-readme/internal/consumers"
 	"github.com/ivuorinen/gh-action-readme/internal/dependencies"
 	"github.com/ivuorinen/gh-action-readme/internal/errors"
+	"github.com/ivuorinen/gh-action-readme/internal/git"
 	"github.com/ivuorinen/gh-action-readme/internal/helpers"
+	"github.com/ivuorinen/gh-action-readme/internal/locale"
+	"github.com/ivuorinen/gh-action-readme/internal/metrics"
+	"github.com/ivuorinen/gh-action-readme/internal/server"
 	"github.com/ivuorinen/gh-action-readme/internal/wizard"
 )
 
@@ -25,6 +39,33 @@ const (
 	formatJSON = "json"
 	formatTOML = "toml"
 	formatYAML = "yaml"
+
+	// exitInterrupted is the exit code of a command stopped by SIGINT or
+	// SIGTERM, 128 plus the signal number of SIGINT as shells report it.
+	exitInterrupted = 130
+
+	// orgSearchTimeout bounds the time spent searching an organization.
+	orgSearchTimeout = 2 * time.Minute
+
+	// announcePostTimeout bounds the time spent posting a release announcement.
+	announcePostTimeout = time.Minute
+	// notifyTimeout bounds the time spent posting --notify summaries.
+	notifyTimeout = 30 * time.Second
+	// defaultTrendLimit is the number of recent runs report trend shows.
+	defaultTrendLimit = 20
+	// policyIssueTimeout bounds the time spent syncing the dependency policy issue.
+	policyIssueTimeout = time.Minute
+	// allowlistTimeout bounds the time spent fetching a remote deps.allowlist.
+	allowlistTimeout = 30 * time.Second
+
+	// publishTimeout bounds the time spent pushing a documentation artifact.
+	publishTimeout = 5 * time.Minute
+
+	// defaultCacheWarmTTL keeps warmed dependency metadata valid for a day of CI runs.
+	defaultCacheWarmTTL = 24 * time.Hour
+
+	// themeFlagUsage describes the --theme flag of the gen and serve commands.
+	themeFlagUsage = "template theme: github, gitlab, minimal, professional, docs-site, badge-minimal"
 )
 
 var (
@@ -35,35 +76,142 @@ var (
 	builtBy = "unknown"
 
 	// Application state.
-	globalConfig *internal.AppConfig
-	configFile   string
-	verbose      bool
-	quiet        bool
+	globalConfig    *internal.AppConfig
+	configFile      string
+	diagnosticsFile string
+	verbose         bool
+	quiet           bool
+	reproducible    bool
+	noEnrich        bool
+	assumeYes       bool
+	nonInteractive  bool
+	allowDirty      bool
 )
 
 // Helper functions to reduce duplication.
 
+// runningInCI reports whether the CI environment variable, set by GitHub Actions,
+// GitLab CI and most other CI services, is true.
+func runningInCI() bool {
+	ci, _ := strconv.ParseBool(os.Getenv("CI"))
+
+	return ci
+}
+
+// newPrompter returns the prompter of interactive flows, reading answers from
+// standard input, or failing every question with --non-interactive.
+func newPrompter() internal.Prompter {
+	if nonInteractive {
+		return internal.NonInteractivePrompter{}
+	}
+
+	return internal.NewTTYPrompter(os.Stdin, os.Stdout)
+}
+
+// confirm asks a yes/no question defaulting to no, and returns true without
+// asking with --yes. A required confirmation that cannot be answered, with
+// --non-interactive or without input, fails the run; an optional one is only
+// asked on a terminal and is declined when it cannot be answered.
+func confirm(output *internal.ColoredOutput, question string, required bool) bool {
+	if assumeYes {
+		return true
+	}
+	if !required && (nonInteractive || !internal.IsTerminal(os.Stdin)) {
+		return false
+	}
+
+	confirmed, err := internal.Confirm(newPrompter(), question, false)
+	if err != nil && required {
+		output.Error("%v", err)
+		exit(1)
+	}
+
+	return confirmed
+}
+
 func createOutputManager(quiet bool) *internal.ColoredOutput {
-	return internal.NewColoredOutput(quiet)
+	output := internal.NewColoredOutput(quiet)
+	output.RecordDiagnostics = true
+
+	return output
 }
 
-// formatSize formats a byte size into a human-readable string.
-func formatSize(totalSize int64) string {
-	if totalSize == 0 {
-		return "0 bytes"
+// exit prints the GitHub API usage of the run in verbose mode, writes the
+// --diagnostics-file, when requested, and exits with code.
+func exit(code int) {
+	if usage := metrics.Usage(); usage.Active() && globalConfig != nil && globalConfig.Verbose {
+		fmt.Fprintln(os.Stderr, usage.String())
 	}
+	if err := internal.FlushDiagnostics(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing diagnostics file: %v\n", err)
+		code = 1
+	}
+	os.Exit(code)
+}
 
-	const unit = 1024
-	switch {
-	case totalSize < unit:
-		return fmt.Sprintf("%d bytes", totalSize)
-	case totalSize < unit*unit:
-		return fmt.Sprintf("%.2f KB", float64(totalSize)/unit)
-	case totalSize < unit*unit*unit:
-		return fmt.Sprintf("%.2f MB", float64(totalSize)/(unit*unit))
-	default:
-		return fmt.Sprintf("%.2f GB", float64(totalSize)/(unit*unit*unit))
+// allowDirtyUsage is the usage of the --allow-dirty flag of commands modifying action files.
+const allowDirtyUsage = "modify files with uncommitted changes"
+
+// guardDirtyFiles exits when any of files has uncommitted changes in its git
+// repository, unless --allow-dirty is set, so the changes of a command are not
+// mixed with uncommitted work. Files outside a git repository are not guarded.
+func guardDirtyFiles(output *internal.ColoredOutput, files []string) {
+	if allowDirty {
+		return
+	}
+	filesByRoot := make(map[string][]string)
+	for _, file := range files {
+		if root := helpers.FindGitRepoRoot(filepath.Dir(file)); root != "" {
+			filesByRoot[root] = append(filesByRoot[root], file)
+		}
 	}
+
+	var dirty []string
+	for _, root := range slices.Sorted(maps.Keys(filesByRoot)) {
+		changed, err := git.DirtyFiles(root, filesByRoot[root])
+		if err != nil {
+			output.Warning("Could not check %s for uncommitted changes: %v", root, err)
+
+			continue
+		}
+		for _, file := range changed {
+			dirty = append(dirty, filepath.Join(root, filepath.FromSlash(file)))
+		}
+	}
+	if len(dirty) == 0 {
+		return
+	}
+
+	output.Error("%d file(s) to modify have uncommitted changes; commit or stash them, or use --allow-dirty:",
+		len(dirty))
+	for _, file := range dirty {
+		output.Printf("  %s\n", file)
+	}
+	exit(1)
+}
+
+// interruptContext returns a context canceled by SIGINT or SIGTERM, so long
+// operations stop scheduling work and finish the file being written. A second
+// signal terminates the process right away.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	context.AfterFunc(ctx, stop)
+
+	return ctx, stop
+}
+
+// exitIfInterrupted exits with exitInterrupted when ctx, if any, was canceled
+// by a signal.
+func exitIfInterrupted(ctx context.Context, output internal.MessageLogger) {
+	if ctx != nil && ctx.Err() != nil {
+		output.Warning("Interrupted, stopped before finishing")
+		exit(exitInterrupted)
+	}
+}
+
+// formatSize formats a byte size into a human-readable string.
+func formatSize(totalSize int64) string {
+	return locale.Current().Size(totalSize)
 }
 
 // resolveExportFormat converts a format string to wizard.ExportFormat.
@@ -108,46 +256,52 @@ func main() {
 	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file (default: XDG config directory)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "quiet output (overrides verbose)")
+	rootCmd.PersistentFlags().StringVar(&diagnosticsFile, "diagnostics-file", "",
+		"write every warning and error of the run to this JSON file")
+	rootCmd.PersistentFlags().BoolVar(&reproducible, "reproducible", false,
+		"leave timestamps and the tool version out of generated output for byte-identical regeneration")
+	rootCmd.PersistentFlags().BoolVar(&noEnrich, "no-enrich", false,
+		"skip GitHub API lookups and use only local parsing, the cache and an imported metadata snapshot")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false,
+		"answer yes to confirmations and accept defaults instead of prompting")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false,
+		"never prompt: fail when input is required, and answer the wizard from --answers and environment variables")
 
 	rootCmd.AddCommand(newGenCmd())
 	rootCmd.AddCommand(newValidateCmd())
+	rootCmd.AddCommand(newCheckAllCmd())
 	rootCmd.AddCommand(newSchemaCmd())
-	rootCmd.AddCommand(&cobra.Command{
-		Use:   "version",
-		Short: "Print the version number",
-		Long:  "Print the version number and build information",
-		Run: func(cmd *cobra.Command, _ []string) {
-			verbose, _ := cmd.Flags().GetBool("verbose")
-			if verbose {
-				fmt.Printf("gh-action-readme version %s\n", version)
-				fmt.Printf("  commit: %s\n", commit)
-				fmt.Printf("  built at: %s\n", date)
-				fmt.Printf("  built by: %s\n", builtBy)
-			} else {
-				fmt.Println(version)
-			}
-		},
-	})
-	rootCmd.AddCommand(&cobra.Command{
-		Use:   "about",
-		Short: "About this tool",
-		Run: func(_ *cobra.Command, _ []string) {
-			fmt.Println("gh-action-readme: Generates README.md and HTML for GitHub Actions. MIT License.")
-		},
-	})
+	rootCmd.AddCommand(newVersionCmd())
+	rootCmd.AddCommand(newAboutCmd())
+	rootCmd.AddCommand(newEnvCmd())
 	rootCmd.AddCommand(newConfigCmd())
 	rootCmd.AddCommand(newDepsCmd())
 	rootCmd.AddCommand(newCacheCmd())
+	rootCmd.AddCommand(newMetadataCmd())
+	rootCmd.AddCommand(newReportCmd())
+	rootCmd.AddCommand(newOrgCmd())
+	rootCmd.AddCommand(newCompatCmd())
+	rootCmd.AddCommand(newReleaseCmd())
+	rootCmd.AddCommand(newBenchCmd())
+	rootCmd.AddCommand(newServeCmd())
+	rootCmd.AddCommand(newAdoptCmd())
+	rootCmd.AddCommand(newPublishCmd())
+	rootCmd.AddCommand(newIDECmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		exit(1)
 	}
+	exit(0)
 }
 
-func initConfig(_ *cobra.Command, _ []string) {
+func initConfig(cmd *cobra.Command, _ []string) {
 	var err error
 
+	if diagnosticsFile != "" {
+		internal.EnableDiagnostics(diagnosticsFile, strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "))
+	}
+
 	// Use ConfigurationLoader for loading global configuration
 	loader := internal.NewConfigurationLoader()
 	globalConfig, err = loader.LoadGlobalConfig(configFile)
@@ -163,6 +317,147 @@ func initConfig(_ *cobra.Command, _ []string) {
 		globalConfig.Quiet = true
 		globalConfig.Verbose = false // quiet overrides verbose
 	}
+	if noEnrich {
+		globalConfig.Enrichment = new(bool)
+	}
+
+	internal.SetResourceLimits(globalConfig.Limits)
+	internal.SetDiscoveryPatterns(globalConfig.Discovery.Patterns)
+	internal.SetToolInfo(version, cmd.CommandPath())
+	if _, ok := locale.Parse(globalConfig.Locale); globalConfig.Locale != "" && !ok {
+		createOutputManager(globalConfig.Quiet).Warning("Unknown %s '%s', using the environment's locale",
+			internal.ConfigKeyLocale, globalConfig.Locale)
+	}
+	locale.Set(locale.Resolve(globalConfig.Locale))
+}
+
+func newVersionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the version number",
+		Long: `Print the version number and build information.
+
+With --json the version, commit, build date, Go version, platform and enabled features
+are printed as one JSON object, for wrapper scripts and packaging.`,
+		Run: versionHandler,
+	}
+	cmd.Flags().Bool("json", false, "print the build information as JSON")
+
+	return cmd
+}
+
+func newAboutCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "about",
+		Short: "About this tool",
+		Run: func(cmd *cobra.Command, _ []string) {
+			if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+				printBuildInfoJSON()
+
+				return
+			}
+			fmt.Println("gh-action-readme: Generates README.md and HTML for GitHub Actions. MIT License.")
+		},
+	}
+	cmd.Flags().Bool("json", false, "print the build information as JSON")
+
+	return cmd
+}
+
+func versionHandler(cmd *cobra.Command, _ []string) {
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		printBuildInfoJSON()
+
+		return
+	}
+
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	if !verbose {
+		fmt.Println(version)
+
+		return
+	}
+
+	info := internal.NewBuildInfo(version, commit, date, builtBy)
+	fmt.Printf("gh-action-readme version %s\n", info.Version)
+	fmt.Printf("  commit: %s\n", info.Commit)
+	fmt.Printf("  built at: %s\n", formatBuildDate(info.Date))
+	fmt.Printf("  built by: %s\n", info.BuiltBy)
+	fmt.Printf("  go: %s %s\n", info.GoVersion, info.Platform)
+	for _, feature := range slices.Sorted(maps.Keys(info.Features)) {
+		fmt.Printf("  %s: %t\n", feature, info.Features[feature])
+	}
+}
+
+// formatBuildDate formats an RFC 3339 build date for the locale, leaving other
+// values, such as "unknown", as they are.
+func formatBuildDate(value string) string {
+	built, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+
+	return locale.Current().DateTime(built)
+}
+
+// printBuildInfoJSON prints the build information of the binary as indented JSON.
+func printBuildInfoJSON() {
+	data, err := json.MarshalIndent(internal.NewBuildInfo(version, commit, date, builtBy), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to encode build information: %v\n", err)
+		exit(1)
+	}
+	fmt.Println(string(data))
+}
+
+func newEnvCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env [name]",
+		Short: "Print the resolved configuration, cache, template and data paths",
+		Long: `Print the paths gh-action-readme resolves on this system: the configuration file,
+cache directory, template source and data directory.
+
+The paths are printed as POSIX shell exports, so eval "$(gh-action-readme env)" sets them,
+or as JSON with --json. With a variable name, such as GH_ACTION_README_CACHE_DIR, only its
+value is printed.`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  envHandler,
+	}
+	cmd.Flags().Bool("json", false, "print the paths as JSON")
+
+	return cmd
+}
+
+func envHandler(cmd *cobra.Command, args []string) {
+	paths, err := internal.ResolvePaths(configFile, globalConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to resolve paths: %v\n", err)
+		exit(1)
+	}
+
+	if len(args) == 1 {
+		for _, variable := range paths.Variables() {
+			if variable[0] == args[0] {
+				fmt.Println(variable[1])
+
+				return
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Unknown variable %s\n", args[0])
+		exit(1)
+	}
+
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		data, err := json.MarshalIndent(paths, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode paths: %v\n", err)
+			exit(1)
+		}
+		fmt.Println(string(data))
+
+		return
+	}
+	fmt.Print(paths.ShellExports())
 }
 
 func newGenCmd() *cobra.Command {
@@ -177,7 +472,13 @@ Examples:
 	gh-action-readme gen testdata/action.yml          # Specific file
 	gh-action-readme gen -f html testdata/action/     # HTML format
 	gh-action-readme gen -f html --output custom.html testdata/action/
-	gh-action-readme gen --output docs/action1.html testdata/action1/`,
+	gh-action-readme gen --output docs/action1.html testdata/action1/
+	gh-action-readme gen --check                      # Fail if generated docs are out of date
+	gh-action-readme gen -r --summary-file summary.json  # Also write the summary as JSON for CI
+	gh-action-readme gen -r --keep-going              # Generate every valid action, then report failures
+	gh-action-readme gen -r -f html --search-index search-index.json  # HTML catalog with search index
+	gh-action-readme gen -r --filter tag=deploy       # Only actions tagged deploy in action.meta.yml
+	gh-action-readme gen --site -o site --sink s3://bucket/docs  # Build an HTML site and upload it`,
 		Args: cobra.MaximumNArgs(1),
 		Run:  genHandler,
 	}
@@ -185,31 +486,259 @@ Examples:
 	cmd.Flags().StringP("output-format", "f", "md", "output format: md, html, json, asciidoc")
 	cmd.Flags().StringP("output-dir", "o", ".", "output directory")
 	cmd.Flags().StringP("output", "", "", "custom output filename (overrides default naming)")
-	cmd.Flags().StringP("theme", "t", "", "template theme: github, gitlab, minimal, professional")
+	cmd.Flags().String("assets-dir", "", "write the styles of HTML pages to a shared stylesheet in this directory")
+	cmd.Flags().String("marketplace-file", "",
+		"also write a compact marketplace listing (badge-minimal theme) to this file")
+	cmd.Flags().StringP("theme", "t", "", themeFlagUsage)
 	cmd.Flags().BoolP("recursive", "r", false, "search for action.yml files recursively")
+	cmd.Flags().String("ref", "", "branch or tag links into the repository point at (default: the checked out one)")
+	cmd.Flags().Bool("check", false, "check that generated docs are up to date without writing them")
+	cmd.Flags().String("search-index", "", "also write a JSON search index of the processed actions to this file")
+	cmd.Flags().Bool("keep-going", runningInCI(),
+		"process every file after a failure and report all failures at the end (default true when CI is set)")
+	cmd.Flags().Bool("backup", false, "keep a copy of replaced documentation with a .backup extension")
+	cmd.Flags().String("summary-file", "",
+		"write the summary of processed, generated, unchanged and failed files as JSON to this file")
+	cmd.Flags().Bool("site", false,
+		"build an HTML site: recursive HTML pages with a shared stylesheet and search-index.json in the output directory")
+	cmd.Flags().String("sink", "", "upload the generated files to a bucket: s3://bucket/prefix or gs://bucket/prefix")
+	cmd.Flags().String("sink-cache-control", "", "Cache-Control header of the files uploaded to --sink")
+	cmd.Flags().Bool("commit", false, "commit the generated files to the git repository")
+	cmd.Flags().String("commit-message", internal.DefaultCommitMessage,
+		"template of the --commit message, with {{.Count}} and {{.Files}} of the committed files")
+	cmd.Flags().Bool("signoff", false, "add a Signed-off-by trailer to the --commit message")
+	addFilterFlag(cmd)
 
 	return cmd
 }
 
 func newValidateCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "validate",
+	cmd := &cobra.Command{
+		Use:   "validate [directory_or_file]",
 		Short: "Validate action.yml files and optionally autofill missing fields.",
-		Run:   validateHandler,
+		Long: `Validate GitHub Action files.
+
+Examples:
+	gh-action-readme validate                              # Current directory, recursively
+	gh-action-readme validate testdata/example-action/    # Specific directory
+	gh-action-readme validate testdata/action.yml         # Specific file
+	gh-action-readme validate --recursive=false .         # Only the top-level directory
+	gh-action-readme validate --strict                     # Fail on warnings too (for CI)
+	gh-action-readme validate --baseline baseline.json --update-baseline  # Record current findings
+	gh-action-readme validate --strict --baseline baseline.json           # Fail only on new findings
+	gh-action-readme validate --apply-suggestions          # Apply safe fixes, then validate
+	gh-action-readme validate --js                         # Also check node entrypoints for undeclared inputs/outputs`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  validateHandler,
+	}
+
+	cmd.Flags().BoolP("recursive", "r", true, "search for action.yml files recursively")
+	cmd.Flags().Bool("strict", false, "treat validation warnings as failures")
+	cmd.Flags().String("baseline", "", "baseline file of known findings to ignore")
+	cmd.Flags().Bool("update-baseline", false, "write current findings to the --baseline file")
+	cmd.Flags().Bool("apply-suggestions", false, "apply safe fixes to action files before validating")
+	cmd.Flags().BoolVar(&allowDirty, "allow-dirty", false, allowDirtyUsage)
+	cmd.Flags().Bool("js", false,
+		"scan node action entrypoints for core.getInput/core.setOutput names missing from action.yml")
+	cmd.Flags().Bool("examples", false, "validate the YAML examples of the documentation generated for each action")
+
+	return cmd
+}
+
+func newCheckAllCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check-all [directory_or_file]",
+		Short: "Run validation, documentation, dependency security and lint checks in one pass.",
+		Long: `Run every check of GitHub Action files in one pass, for a single CI step.
+
+The checks are validation (as validate), documentation freshness (as gen --check),
+dependency security (as deps security) and lint (validation warnings). A summary
+of the checks is shown at the end. The exit code adds up the failed checks:
+2 validation, 4 documentation, 8 dependency security and 16 lint; 1 means
+the checks could not run.
+
+Examples:
+	gh-action-readme check-all                      # Current directory, recursively
+	gh-action-readme check-all testdata/action.yml  # Specific file
+	gh-action-readme check-all --strict             # Fail on lint warnings too`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  checkAllHandler,
 	}
+
+	cmd.Flags().BoolP("recursive", "r", true, "search for action.yml files recursively")
+	cmd.Flags().StringP("theme", "t", "", themeFlagUsage)
+	cmd.Flags().Bool("strict", false, "treat lint warnings as failures")
+
+	return cmd
 }
 
 func newSchemaCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "schema",
 		Short: "Show the action.yml schema info.",
 		Run:   schemaHandler,
 	}
+
+	pathCmd := &cobra.Command{
+		Use:   "path",
+		Short: "Print the action.yml schema location for editors",
+		Long: `Print where editors find the action.yml schema embedded in the binary, in a
+form they accept. The schema is written to the XDG data directory first and
+refreshed when the binary's schema changes; --remote uses the published schema
+instead. serve also serves it at /action.schema.json.
+
+Formats:
+	path            Path of the local schema file
+	jsonschema-url  URL of the schema, for JetBrains IDEs (Settings > JSON Schema Mappings)
+	vscode          yaml.schemas entry for .vscode/settings.json (with the Red Hat YAML extension)
+	modeline        Comment for the top of action.yml, read by editors using
+	                yaml-language-server, such as Neovim, Helix, Zed and VS Code
+
+Examples:
+	gh-action-readme schema path                           # Local schema file
+	gh-action-readme schema path --format vscode           # Settings snippet for VS Code
+	gh-action-readme schema path --format modeline --remote
+	gh-action-readme ide init vscode                       # Write the VS Code settings`,
+		Args: cobra.NoArgs,
+		Run:  schemaPathHandler,
+	}
+	pathCmd.Flags().String("format", internal.SchemaFormatPath,
+		"output format: "+strings.Join(internal.SchemaFormats, ", "))
+	pathCmd.Flags().Bool("remote", false, "use the published schema URL instead of the local copy")
+	cmd.AddCommand(pathCmd)
+
+	return cmd
 }
 
 func genHandler(cmd *cobra.Command, args []string) {
 	output := createOutputManager(globalConfig.Quiet)
+	site, _ := cmd.Flags().GetBool("site")
+	if site && !cmd.Flags().Changed("recursive") {
+		_ = cmd.Flags().Set("recursive", "true")
+	}
+	workingDir, actionFiles := resolveActionTargets(cmd, args, output, "documentation generation")
+	actionFiles = filterActionFiles(cmd, output, actionFiles)
+	if len(actionFiles) == 0 {
+		return
+	}
+
+	repoRoot := helpers.FindGitRepoRoot(workingDir)
+	config := loadGenConfig(repoRoot, workingDir)
+	applyGlobalFlags(config)
+	applyCommandFlags(cmd, config)
+	siteRoot := workingDir
+	if config.OutputDir != "" && config.OutputDir != "." {
+		siteRoot, _ = filepath.Abs(config.OutputDir)
+	}
+	indexPath, _ := cmd.Flags().GetString("search-index")
+	if site {
+		config.OutputFormat = internal.OutputFormatHTML
+		config.AssetsDir = cmp.Or(config.AssetsDir, siteRoot)
+		indexPath = cmp.Or(indexPath, filepath.Join(siteRoot, "search-index.json"))
+	}
+
+	generator := internal.NewGenerator(config)
+	generator.Check, _ = cmd.Flags().GetBool("check")
+	generator.KeepGoing, _ = cmd.Flags().GetBool("keep-going")
+	generator.Backup, _ = cmd.Flags().GetBool("backup")
+	logConfigInfo(generator, config, repoRoot)
+
+	ctx, stop := interruptContext()
+	defer stop()
+	generator.Context = ctx
+
+	summaryFile, _ := cmd.Flags().GetString("summary-file")
+	processActionFiles(generator, actionFiles, summaryFile)
+
+	if indexPath != "" && !generator.Check {
+		if err := generator.WriteSearchIndex(actionFiles, indexPath); err != nil {
+			generator.Output.Error("Error writing search index: %v", err)
+			exit(1)
+		}
+	}
+	commitGeneratedFiles(cmd, generator, repoRoot)
+	uploadToSink(ctx, cmd, generator, siteRoot)
+}
+
+// commitGeneratedFiles commits the files written by gen when --commit is set.
+func commitGeneratedFiles(cmd *cobra.Command, generator *internal.Generator, repoRoot string) {
+	if commit, _ := cmd.Flags().GetBool("commit"); !commit || generator.Check {
+		return
+	}
+	if repoRoot == "" {
+		generator.Output.Error("--commit needs a git repository")
+		exit(1)
+	}
+
+	files, err := git.StageFiles(repoRoot, generator.OutputFiles())
+	if err != nil {
+		generator.Output.Error("Error staging generated files: %v", err)
+		exit(1)
+	}
+	if len(files) == 0 {
+		generator.Output.Info("No documentation changes to commit")
+
+		return
+	}
 
+	messageTemplate, _ := cmd.Flags().GetString("commit-message")
+	data := internal.CommitMessageData{Count: len(files), Files: files}
+	message, err := internal.RenderCommitMessage(messageTemplate, data)
+	if err != nil {
+		generator.Output.Error("%v", err)
+		exit(1)
+	}
+	signoff, _ := cmd.Flags().GetBool("signoff")
+	hash, err := git.CommitFiles(repoRoot, files, message, signoff)
+	if err != nil {
+		generator.Output.Error("Error committing generated files: %v", err)
+		exit(1)
+	}
+	generator.Output.Success("Committed %d file(s) as %s", len(files), hash[:min(len(hash), 12)])
+}
+
+// uploadToSink uploads the files generated by generator, relative to siteRoot,
+// to the bucket of --sink or sink.url. Nothing is uploaded in check mode, and
+// an interrupt canceling ctx stops the upload with exitInterrupted.
+func uploadToSink(ctx context.Context, cmd *cobra.Command, generator *internal.Generator, siteRoot string) {
+	settings := generator.Config.Sink
+	if sinkURL, _ := cmd.Flags().GetString("sink"); sinkURL != "" {
+		settings.URL = sinkURL
+	}
+	if cacheControl, _ := cmd.Flags().GetString("sink-cache-control"); cacheControl != "" {
+		settings.CacheControl = cacheControl
+	}
+	if settings.URL == "" || generator.Check {
+		return
+	}
+
+	target, err := internal.NewSink(settings)
+	if err != nil {
+		generator.Output.Error("Error configuring sink: %v", err)
+		exit(1)
+	}
+	objects, err := target.Upload(ctx, siteRoot, generator.OutputFiles())
+	if err != nil {
+		exitIfInterrupted(ctx, generator.Output)
+		generator.Output.Error("Error uploading to %s: %v", settings.URL, err)
+		exit(1)
+	}
+	if generator.Config.Verbose {
+		for _, object := range objects {
+			generator.Output.Info("Uploaded %s", target.ObjectURL(object.Key))
+		}
+	}
+	generator.Output.Success("Uploaded %d file(s) to %s", len(objects), settings.URL)
+}
+
+// resolveActionTargets resolves the optional path argument into a working directory and
+// the action files to process. Directories are searched according to the --recursive flag.
+func resolveActionTargets(
+	cmd *cobra.Command,
+	args []string,
+	output *internal.ColoredOutput,
+	operation string,
+) (string, []string) {
 	// Determine target path from arguments or current directory
 	var targetPath string
 	if len(args) > 0 {
@@ -219,7 +748,7 @@ func genHandler(cmd *cobra.Command, args []string) {
 		targetPath, err = helpers.GetCurrentDir()
 		if err != nil {
 			output.Error("Error getting current directory: %v", err)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -227,52 +756,67 @@ func genHandler(cmd *cobra.Command, args []string) {
 	absTargetPath, err := filepath.Abs(targetPath)
 	if err != nil {
 		output.Error("Error resolving path %s: %v", targetPath, err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Check if target exists
 	info, err := os.Stat(absTargetPath)
 	if err != nil {
 		output.Error("Path does not exist: %s", targetPath)
-		os.Exit(1)
+		exit(1)
 	}
 
-	var workingDir string
-	var actionFiles []string
-
-	if info.IsDir() {
-		// Target is a directory
-		workingDir = absTargetPath
-		generator := internal.NewGenerator(globalConfig) // Temporary generator for discovery
-		recursive, _ := cmd.Flags().GetBool("recursive")
-		actionFiles, err = generator.DiscoverActionFilesWithValidation(
-			workingDir,
-			recursive,
-			"documentation generation",
-		)
-		if err != nil {
-			os.Exit(1)
-		}
-	} else {
+	if !info.IsDir() {
 		// Target is a file - validate it's an action file
 		lowerPath := strings.ToLower(absTargetPath)
 		if !strings.HasSuffix(lowerPath, ".yml") && !strings.HasSuffix(lowerPath, ".yaml") {
 			output.Error("File must be a YAML file (.yml or .yaml): %s", targetPath)
-			os.Exit(1)
+			exit(1)
 		}
-		workingDir = filepath.Dir(absTargetPath)
-		actionFiles = []string{absTargetPath}
+
+		return filepath.Dir(absTargetPath), []string{absTargetPath}
 	}
 
-	repoRoot := helpers.FindGitRepoRoot(workingDir)
-	config := loadGenConfig(repoRoot, workingDir)
-	applyGlobalFlags(config)
-	applyCommandFlags(cmd, config)
+	// Target is a directory; its repository configuration may set discovery patterns
+	discoveryConfig := loadGenConfig(helpers.FindGitRepoRoot(absTargetPath), absTargetPath)
+	applyGlobalFlags(discoveryConfig)
+	generator := internal.NewGenerator(discoveryConfig) // Temporary generator for discovery
+	recursive, _ := cmd.Flags().GetBool("recursive")
+	actionFiles, err := generator.DiscoverActionFilesWithValidation(absTargetPath, recursive, operation)
+	if err != nil {
+		exit(1)
+	}
 
-	generator := internal.NewGenerator(config)
-	logConfigInfo(generator, config, repoRoot)
+	return absTargetPath, actionFiles
+}
+
+// addFilterFlag adds the repeatable --filter flag used with filterActionFiles.
+func addFilterFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArray("filter", nil,
+		"only process actions whose action.meta.yml matches key=value or key!=value "+
+			"(keys: tag, category; repeatable, comma-separated values match any)")
+}
+
+// filterActionFiles keeps the action files matching every --filter expression.
+// It warns when no file matches and exits on an invalid expression.
+func filterActionFiles(cmd *cobra.Command, output *internal.ColoredOutput, actionFiles []string) []string {
+	exprs, _ := cmd.Flags().GetStringArray("filter")
+	filters, err := internal.ParseActionFilters(exprs)
+	if err != nil {
+		output.Error("Error parsing --filter: %v", err)
+		exit(1)
+	}
 
-	processActionFiles(generator, actionFiles)
+	matched, err := internal.FilterActionFiles(actionFiles, filters)
+	if err != nil {
+		output.Error("Error reading action metadata: %v", err)
+		exit(1)
+	}
+	if len(matched) == 0 {
+		output.Warning("No action files match the filter %s", strings.Join(exprs, " "))
+	}
+
+	return matched
 }
 
 // loadGenConfig loads multi-level configuration using ConfigurationLoader.
@@ -281,20 +825,28 @@ func loadGenConfig(repoRoot, currentDir string) *internal.AppConfig {
 	config, err := loader.LoadConfiguration(configFile, repoRoot, currentDir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Validate the loaded configuration
 	if err := loader.ValidateConfiguration(config); err != nil {
 		fmt.Fprintf(os.Stderr, "Configuration validation error: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
+	internal.SetResourceLimits(config.Limits)
+	internal.SetDiscoveryPatterns(config.Discovery.Patterns)
 
 	return config
 }
 
-// applyGlobalFlags applies global verbose/quiet flags.
+// applyGlobalFlags applies global verbose/quiet/reproducible/no-enrich flags.
 func applyGlobalFlags(config *internal.AppConfig) {
+	if reproducible {
+		config.Reproducible = true
+	}
+	if noEnrich {
+		config.Enrichment = new(bool)
+	}
 	if verbose {
 		config.Verbose = true
 	}
@@ -310,6 +862,9 @@ func applyCommandFlags(cmd *cobra.Command, config *internal.AppConfig) {
 	outputDir, _ := cmd.Flags().GetString("output-dir")
 	outputFilename, _ := cmd.Flags().GetString("output")
 	theme, _ := cmd.Flags().GetString("theme")
+	assetsDir, _ := cmd.Flags().GetString("assets-dir")
+	marketplaceFile, _ := cmd.Flags().GetString("marketplace-file")
+	ref, _ := cmd.Flags().GetString("ref")
 
 	if outputFormat != "md" {
 		config.OutputFormat = outputFormat
@@ -323,6 +878,15 @@ func applyCommandFlags(cmd *cobra.Command, config *internal.AppConfig) {
 	if theme != "" {
 		config.Theme = theme
 	}
+	if assetsDir != "" {
+		config.AssetsDir = assetsDir
+	}
+	if marketplaceFile != "" {
+		config.MarketplaceFile = marketplaceFile
+	}
+	if ref != "" {
+		config.LinkRef = ref
+	}
 }
 
 // logConfigInfo logs configuration details if verbose.
@@ -335,33 +899,68 @@ func logConfigInfo(generator *internal.Generator, config *internal.AppConfig, re
 	}
 }
 
-// processActionFiles processes discovered files.
-func processActionFiles(generator *internal.Generator, actionFiles []string) {
-	if err := generator.ProcessBatch(actionFiles); err != nil {
+// processActionFiles processes discovered files and writes the summary of the
+// batch to summaryFile, when set, also when generation fails.
+func processActionFiles(generator *internal.Generator, actionFiles []string, summaryFile string) {
+	summary, err := generator.ProcessBatchWithSummary(actionFiles)
+	if summary != nil && summaryFile != "" {
+		if err := summary.Save(summaryFile); err != nil {
+			generator.Output.Error("Error writing summary: %v", err)
+			exit(1)
+		}
+	}
+	if summary != nil && summary.Interrupted {
+		exit(exitInterrupted) // The summary reported the files left out
+	}
+	if err != nil {
 		generator.Output.Error("Error during generation: %v", err)
-		os.Exit(1)
+		exit(1)
 	}
 }
 
-func validateHandler(_ *cobra.Command, _ []string) {
-	currentDir, err := helpers.GetCurrentDir()
-	if err != nil {
-		_, errorHandler := setupOutputAndErrorHandling()
-		errorHandler.HandleSimpleError("Unable to determine current directory", err)
+func validateHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	workingDir, actionFiles := resolveActionTargets(cmd, args, output, "validation")
+
+	// Load repository and action level configuration so rule overrides apply
+	repoRoot := helpers.FindGitRepoRoot(workingDir)
+	config := loadGenConfig(repoRoot, workingDir)
+	applyGlobalFlags(config)
+	if strict, _ := cmd.Flags().GetBool("strict"); strict {
+		config.Strict = true
 	}
 
-	generator := internal.NewGenerator(globalConfig)
-	actionFiles, err := generator.DiscoverActionFilesWithValidation(
-		currentDir,
-		true,
-		"validation",
-	) // Recursive for validation
-	if err != nil {
-		os.Exit(1)
+	generator := internal.NewGenerator(config)
+	generator.ScanEntrypoints, _ = cmd.Flags().GetBool("js")
+	generator.CheckExamples, _ = cmd.Flags().GetBool("examples")
+
+	if apply, _ := cmd.Flags().GetBool("apply-suggestions"); apply {
+		guardDirtyFiles(output, generator.FilesWithSuggestions(actionFiles))
+		if _, err := generator.ApplySuggestions(actionFiles); err != nil {
+			generator.Output.Error("%v", err)
+			exit(1)
+		}
+	}
+
+	baselinePath, _ := cmd.Flags().GetString("baseline")
+	if update, _ := cmd.Flags().GetBool("update-baseline"); update {
+		writeValidationBaseline(generator, actionFiles, baselinePath)
+
+		return
+	}
+
+	var baseline *internal.Baseline
+	if baselinePath != "" {
+		var err error
+		baseline, err = internal.LoadBaseline(baselinePath)
+		if err != nil {
+			generator.Output.Error("%v", err)
+			exit(1)
+		}
 	}
 
 	// Validate the discovered files
-	if err := generator.ValidateFiles(actionFiles); err != nil {
+	if err := generator.ValidateFilesWithBaseline(actionFiles, baseline); err != nil {
 		generator.Output.ErrorWithContext(
 			errors.ErrCodeValidation,
 			"validation failed",
@@ -370,12 +969,66 @@ func validateHandler(_ *cobra.Command, _ []string) {
 				internal.ContextKeyError: err.Error(),
 			},
 		)
-		os.Exit(1)
+		exit(1)
 	}
 
 	generator.Output.Success("\nAll validations passed successfully!")
 }
 
+// writeValidationBaseline records the current findings of actionFiles in a baseline file.
+func writeValidationBaseline(generator *internal.Generator, actionFiles []string, baselinePath string) {
+	if baselinePath == "" {
+		generator.Output.Error("--update-baseline requires --baseline <file>")
+		exit(1)
+	}
+
+	count, err := generator.WriteBaseline(actionFiles, baselinePath)
+	if err != nil {
+		generator.Output.Error("Failed to write baseline: %v", err)
+		exit(1)
+	}
+
+	generator.Output.Success("Recorded %d finding(s) in %s", count, baselinePath)
+}
+
+func checkAllHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	workingDir, actionFiles := resolveActionTargets(cmd, args, output, "check-all")
+
+	repoRoot := helpers.FindGitRepoRoot(workingDir)
+	config := loadGenConfig(repoRoot, workingDir)
+	applyGlobalFlags(config)
+	if theme, _ := cmd.Flags().GetString("theme"); theme != "" {
+		config.Theme = theme
+	}
+	if strict, _ := cmd.Flags().GetBool("strict"); strict {
+		config.Strict = true
+	}
+
+	generator := internal.NewGenerator(config)
+	logConfigInfo(generator, config, repoRoot)
+	report := generator.CheckAll(actionFiles, createAnalyzer(generator, output))
+	displayCheckAllSummary(output, report)
+	exit(report.ExitCode())
+}
+
+// displayCheckAllSummary shows the outcome of each check of check-all.
+func displayCheckAllSummary(output *internal.ColoredOutput, report internal.CheckAllReport) {
+	output.Bold("\nCheck Summary:")
+	table := internal.NewTable("Check", "Status", "Details").Indent("  ")
+	for _, result := range report.Results {
+		table.AddRow(result.Check, string(result.Status), result.Details)
+	}
+	output.Table(table)
+
+	if report.Failed() {
+		output.Error("Checks failed (exit code %d)", report.ExitCode())
+
+		return
+	}
+	output.Success("All checks passed")
+}
+
 func schemaHandler(_ *cobra.Command, _ []string) {
 	output := internal.NewColoredOutput(globalConfig.Quiet)
 	if globalConfig.Verbose {
@@ -384,6 +1037,32 @@ func schemaHandler(_ *cobra.Command, _ []string) {
 	output.Printf("Schema: schemas/action.schema.json (replaceable, editable)")
 }
 
+func schemaPathHandler(cmd *cobra.Command, _ []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	format, _ := cmd.Flags().GetString("format")
+	if !slices.Contains(internal.SchemaFormats, format) {
+		output.Error("Unknown format %q, supported: %s", format, strings.Join(internal.SchemaFormats, ", "))
+		exit(1)
+	}
+
+	location := internal.ActionSchemaURL
+	if remote, _ := cmd.Flags().GetBool("remote"); !remote {
+		path, err := internal.WriteActionSchema()
+		if err != nil {
+			output.Error("Failed to write the schema: %v", err)
+			exit(1)
+		}
+		location = path
+	}
+
+	association, err := internal.FormatSchemaAssociation(format, location)
+	if err != nil {
+		output.Error("%v", err)
+		exit(1)
+	}
+	fmt.Println(association)
+}
+
 func newConfigCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "config",
@@ -413,11 +1092,22 @@ func newConfigCmd() *cobra.Command {
 	initCmd := &cobra.Command{
 		Use:   "wizard",
 		Short: "Interactive configuration wizard",
-		Long:  "Launch an interactive wizard to set up your configuration step by step",
-		Run:   configWizardHandler,
+		Long: `Launch an interactive wizard to set up your configuration step by step.
+
+With --answers or --non-interactive the wizard runs without prompting, for provisioning
+scripts and dotfiles managers. Answers come from a YAML file keyed by setting (theme,
+cache.ttl, deps.fail_on, ...) and from ` + wizard.AnswersEnvPrefix + `* environment
+variables, which take precedence. Unanswered questions use their defaults and invalid
+answers fail the run.
+
+Examples:
+	gh-action-readme config wizard --answers answers.yml
+	` + wizard.AnswersEnvPrefix + `THEME=github gh-action-readme config wizard --non-interactive`,
+		Run: configWizardHandler,
 	}
 	initCmd.Flags().String("format", "yaml", "Export format: yaml, json, toml")
 	initCmd.Flags().String("output", "", "Output path (default: XDG config directory)")
+	initCmd.Flags().String("answers", "", "YAML file answering the wizard questions; implies --non-interactive")
 	cmd.AddCommand(initCmd)
 
 	cmd.AddCommand(&cobra.Command{
@@ -426,11 +1116,43 @@ func newConfigCmd() *cobra.Command {
 		Run:   configShowHandler,
 	})
 
-	cmd.AddCommand(&cobra.Command{
+	themesCmd := &cobra.Command{
 		Use:   "themes",
 		Short: "List available themes",
 		Run:   configThemesHandler,
+	}
+	themesCmd.AddCommand(&cobra.Command{
+		Use:   "show <name>",
+		Short: "Show the documents, variables and options of a theme",
+		Long: `Show the theme manifest (` + internal.ThemeManifestFile + `) of a built-in theme, or of a custom theme
+given as the path to its README template: the documents it generates, the variables it
+requires and its options with their defaults and configured values.
+
+Examples:
+	gh-action-readme config themes show github
+	gh-action-readme config themes show ./my-theme/readme.tmpl`,
+		Args: cobra.ExactArgs(1),
+		Run:  configThemesShowHandler,
 	})
+	cmd.AddCommand(themesCmd)
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate [config_file]",
+		Short: "Upgrade a configuration file to the current config_version",
+		Long: `Upgrade a configuration file written for an older version of gh-action-readme to the
+current config_version. Renamed keys are updated, a template that points at a built-in theme
+becomes the theme setting and legacy paths equal to their defaults are removed. The original
+file is kept next to it with a .backup extension.
+
+Examples:
+	gh-action-readme config migrate                    # Global configuration file
+	gh-action-readme config migrate .ghreadme.yaml     # Repository configuration
+	gh-action-readme config migrate --dry-run          # Show the changes without writing`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  configMigrateHandler,
+	}
+	migrateCmd.Flags().Bool("dry-run", false, "Show what would change without writing the file")
+	cmd.AddCommand(migrateCmd)
 
 	return cmd
 }
@@ -442,26 +1164,71 @@ func configInitHandler(_ *cobra.Command, _ []string) {
 	configPath, err := internal.GetConfigPath()
 	if err != nil {
 		output.Error("Failed to get config path: %v", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	if _, err := os.Stat(configPath); err == nil {
 		output.Warning("Configuration file already exists at: %s", configPath)
-		output.Info("Use 'gh-action-readme config show' to view current configuration")
+		if !confirm(output, "Replace it with the default configuration?", false) {
+			output.Info("Use 'gh-action-readme config show' to view current configuration")
 
-		return
+			return
+		}
 	}
 
 	// Create default config
 	if err := internal.WriteDefaultConfig(); err != nil {
 		output.Error("Failed to write default configuration: %v", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	output.Success("Created default configuration at: %s", configPath)
 	output.Info("Edit this file to customize your settings")
 }
 
+func configMigrateHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+
+	path := ""
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		configPath, err := internal.GetConfigPath()
+		if err != nil {
+			output.Error("Failed to get config path: %v", err)
+			exit(1)
+		}
+		path = configPath
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	migration, err := internal.MigrateConfigFile(path, dryRun)
+	if err != nil {
+		output.Error("%v", err)
+		exit(1)
+	}
+	if migration.FromVersion == migration.ToVersion {
+		output.Success("%s is already at config_version %d", path, migration.ToVersion)
+
+		return
+	}
+
+	if dryRun {
+		output.Bold("Would migrate %s from config_version %d to %d:", path, migration.FromVersion, migration.ToVersion)
+	} else {
+		output.Success("Migrated %s from config_version %d to %d:", path, migration.FromVersion, migration.ToVersion)
+	}
+	for _, change := range migration.Changes {
+		output.Printf("  - %s\n", change)
+	}
+	for _, warning := range migration.Warnings {
+		output.Warning("%s", warning)
+	}
+	if !dryRun {
+		output.Info("Backup of the original: %s", internal.ConfigBackupPath(path))
+	}
+}
+
 func configShowHandler(_ *cobra.Command, _ []string) {
 	output := createOutputManager(globalConfig.Quiet)
 
@@ -479,28 +1246,100 @@ func configThemesHandler(_ *cobra.Command, _ []string) {
 	output := createOutputManager(globalConfig.Quiet)
 
 	output.Bold("Available Themes:")
-	themes := []struct {
-		name string
-		desc string
-	}{
-		{internal.ThemeDefault, "Original simple template"},
-		{internal.ThemeGitHub, "GitHub-style with badges and collapsible sections"},
-		{internal.ThemeGitLab, "GitLab-focused with CI/CD examples"},
-		{internal.ThemeMinimal, "Clean and concise documentation"},
-		{internal.ThemeProfessional, "Comprehensive with troubleshooting and ToC"},
+	themes := []string{
+		internal.ThemeDefault,
+		internal.ThemeGitHub,
+		internal.ThemeGitLab,
+		internal.ThemeMinimal,
+		internal.ThemeProfessional,
+		internal.ThemeDocsSite,
+		internal.ThemeBadgeMinimal,
 	}
 
 	for _, theme := range themes {
-		if theme.name == globalConfig.Theme {
-			output.Success("• %s - %s (current)", theme.name, theme.desc)
+		desc := ""
+		if manifest, err := internal.LoadTheme(theme); err == nil {
+			desc = manifest.Description
+		}
+		if theme == globalConfig.Theme {
+			output.Success("• %s - %s (current)", theme, desc)
 		} else {
-			output.Printf("• %s - %s\n", theme.name, theme.desc)
+			output.Printf("• %s - %s\n", theme, desc)
 		}
 	}
 
+	output.Info("\nRun 'config themes show <name>' for the documents and options of a theme")
 	output.Info("\nUse --theme flag or set 'theme' in config file to change theme")
 }
 
+func configThemesShowHandler(_ *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+
+	manifest, err := internal.LoadTheme(args[0])
+	if err != nil {
+		output.Error("Failed to load theme: %v", err)
+		exit(1)
+	}
+
+	name := manifest.Name
+	if name == "" {
+		name = args[0]
+	}
+	output.Bold("Theme: %s", name)
+	if manifest.Description != "" {
+		output.Printf("%s\n", manifest.Description)
+	}
+	output.Printf("Template: %s\n", manifest.TemplatePath)
+
+	output.Bold("\nDocuments:")
+	for _, doc := range manifest.Documents {
+		docOutput := doc.Output
+		if docOutput == "" {
+			docOutput = "README.md"
+		}
+		kind := ""
+		if doc.Stub {
+			kind = " (stub, written once)"
+		}
+		output.Printf("• %s -> %s%s\n", doc.Name, docOutput, kind)
+	}
+
+	if len(manifest.Variables) > 0 {
+		output.Bold("\nRequired variables:")
+		for _, variable := range manifest.Variables {
+			value := globalConfig.Variables[variable.Name]
+			if value == "" {
+				value = "not set"
+			}
+			output.Printf("• %s (%s)%s\n", variable.Name, value, themeDescriptionSuffix(variable.Description))
+		}
+	}
+
+	if len(manifest.Options) > 0 {
+		output.Bold("\nOptions:")
+		for _, option := range manifest.Options {
+			output.Printf("• %s%s\n", option.Name, themeDescriptionSuffix(option.Description))
+			output.Printf("    default: %q", option.Default)
+			if value, ok := globalConfig.Variables[option.Name]; ok {
+				output.Printf(", configured: %q", value)
+			}
+			output.Printf("\n")
+		}
+	}
+
+	output.Info("\nSet variables and options under 'variables' and pick documents with 'documents' in the config file")
+}
+
+// themeDescriptionSuffix returns the description of a theme variable or option
+// to show after its name, or nothing when it has none.
+func themeDescriptionSuffix(description string) string {
+	if description == "" {
+		return ""
+	}
+
+	return " - " + description
+}
+
 func newDepsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "deps",
@@ -508,11 +1347,24 @@ func newDepsCmd() *cobra.Command {
 		Long:  "Analyze and manage GitHub Action dependencies",
 	}
 
-	cmd.AddCommand(&cobra.Command{
-		Use:   "list",
-		Short: "List all dependencies in action files",
-		Run:   depsListHandler,
-	})
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List all dependencies in action files",
+		Long: `List the dependencies of the action files as a tree grouped by file and step.
+
+Examples:
+	gh-action-readme deps ls                      # Tree of every dependency
+	gh-action-readme deps ls --floating-only      # Dependencies to pin
+	gh-action-readme deps ls --external-only --wide  # Versions, resolved SHAs and descriptions`,
+		Run: depsListHandler,
+	}
+	addFilterFlag(listCmd)
+	listCmd.Flags().Bool("pinned-only", false, "Only list dependencies pinned to a commit SHA or full version")
+	listCmd.Flags().Bool("floating-only", false, "Only list dependencies with floating versions such as v4 or main")
+	listCmd.Flags().Bool("external-only", false, "Only list actions from other repositories, without shell steps")
+	listCmd.Flags().Bool("wide", false, "List versions, resolved commit SHAs and descriptions in columns")
+	cmd.AddCommand(listCmd)
 
 	cmd.AddCommand(&cobra.Command{
 		Use:   "security",
@@ -520,11 +1372,16 @@ func newDepsCmd() *cobra.Command {
 		Run:   depsSecurityHandler,
 	})
 
-	cmd.AddCommand(&cobra.Command{
+	outdatedCmd := &cobra.Command{
 		Use:   "outdated",
 		Short: "Check for outdated dependencies",
-		Run:   depsOutdatedHandler,
-	})
+		Long: "Check for outdated dependencies. Use --max-age to also flag pins whose release or commit " +
+			"is older than the given age, even when no newer version exists.",
+		Run: depsOutdatedHandler,
+	}
+	outdatedCmd.Flags().String("max-age", "", "Flag pins older than this age (e.g. 365d, 52w, 8760h)")
+	outdatedCmd.Flags().Bool("notify", false, "Post a summary to the configured Slack and Teams webhooks")
+	cmd.AddCommand(outdatedCmd)
 
 	cmd.AddCommand(&cobra.Command{
 		Use:   "graph",
@@ -541,8 +1398,21 @@ func newDepsCmd() *cobra.Command {
 	upgradeCmd.Flags().Bool("ci", false, "CI/CD mode: automatically pin all updates to commit SHAs")
 	upgradeCmd.Flags().Bool("all", false, "Update all outdated dependencies without prompts")
 	upgradeCmd.Flags().Bool("dry-run", false, "Show what would be updated without making changes")
+	upgradeCmd.Flags().BoolVar(&allowDirty, "allow-dirty", false, allowDirtyUsage)
 	cmd.AddCommand(upgradeCmd)
 
+	tuiCmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Browse dependencies in a terminal UI and pick the ones to pin or upgrade",
+		Long: `Browse the dependencies of the action files in a full-screen terminal UI: move with the
+arrow keys, filter and sort them, read the release notes of newer versions and select the
+dependencies to pin or upgrade. The selected updates are applied like deps upgrade. Use
+deps upgrade --ci in CI instead.`,
+		Run: depsTUIHandler,
+	}
+	tuiCmd.Flags().BoolVar(&allowDirty, "allow-dirty", false, allowDirtyUsage)
+	cmd.AddCommand(tuiCmd)
+
 	pinCmd := &cobra.Command{
 		Use:   "pin",
 		Short: "Pin floating versions to specific commits",
@@ -551,8 +1421,83 @@ func newDepsCmd() *cobra.Command {
 	}
 	pinCmd.Flags().Bool("all", false, "Pin all floating dependencies")
 	pinCmd.Flags().Bool("dry-run", false, "Show what would be pinned without making changes")
+	pinCmd.Flags().BoolVar(&allowDirty, "allow-dirty", false, allowDirtyUsage)
 	cmd.AddCommand(pinCmd)
 
+	policyCmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Dependency policy commands",
+	}
+	policyCheckCmd := &cobra.Command{
+		Use:   "check [directory_or_file]",
+		Short: "Check dependencies against the policy and report violations",
+		Long: `Check the dependencies of the action files for unpinned versions, available and
+potential security updates, and docker:// images on the latest tag. The command fails when a
+deps.fail_on rule is violated. Updates are only checked when a GitHub token is configured.
+
+With --create-issues, a GitHub issue in the repository summarizes the violations. The issue is
+found again by its label: later runs update it and close it once the violations are resolved.
+
+Examples:
+	gh-action-readme deps policy check
+	gh-action-readme deps policy check --create-issues
+	gh-action-readme deps policy check --create-issues --dry-run   # Print the issue body`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  depsPolicyCheckHandler,
+	}
+	policyCheckCmd.Flags().BoolP("recursive", "r", true, "search for action.yml files recursively")
+	policyCheckCmd.Flags().Bool("create-issues", false, "open or update a GitHub issue summarizing the violations")
+	policyCheckCmd.Flags().String("issue-label", internal.DefaultPolicyIssueLabel,
+		"label that identifies the policy issue")
+	policyCheckCmd.Flags().String("issue-title", internal.DefaultPolicyIssueTitle, "title of the policy issue")
+	policyCheckCmd.Flags().String("template", "", "custom issue body template")
+	policyCheckCmd.Flags().Bool("dry-run", false, "print the issue body instead of posting it")
+	policyCmd.AddCommand(policyCheckCmd)
+	cmd.AddCommand(policyCmd)
+
+	whyCmd := &cobra.Command{
+		Use:   "why <owner/repo> [directory_or_file]",
+		Short: "Explain which action files and steps use a dependency",
+		Long: `List the steps that use an action or docker:// image, the versions they use and,
+with --transitive, the composite actions through which it is used indirectly. Use it to find
+duplicate dependencies at different versions to consolidate. --transitive reads the action files
+of composite dependencies from GitHub and works best with a GitHub token.
+
+Examples:
+	gh-action-readme deps why actions/checkout
+	gh-action-readme deps why actions/cache --transitive --depth 2
+	gh-action-readme deps why docker://docker.io/library/alpine --json`,
+		Args: cobra.RangeArgs(1, 2),
+		Run:  depsWhyHandler,
+	}
+	whyCmd.Flags().BoolP("recursive", "r", true, "search for action.yml files recursively")
+	whyCmd.Flags().Bool("transitive", false, "follow the dependencies of composite actions")
+	whyCmd.Flags().Int("depth", internal.DefaultWhyDepth, "levels of composite actions to follow with --transitive")
+	whyCmd.Flags().Bool("json", false, "print the explanation as JSON")
+	cmd.AddCommand(whyCmd)
+
+	consolidateCmd := &cobra.Command{
+		Use:   "consolidate [directory_or_file]",
+		Short: "Find actions used at several versions and move them to one",
+		Long: `Find actions that the action files reference at different versions and suggest the
+version to consolidate on: the one set under deps.consolidate, or the highest version in use.
+With --fix, the uses are updated like deps upgrade, pinned to the commit SHA of the version
+unless deps.pin_strategy is tag.
+
+Examples:
+	gh-action-readme deps consolidate
+	gh-action-readme deps consolidate --fix --dry-run   # Show the planned updates
+	gh-action-readme deps consolidate --fix --yes`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  depsConsolidateHandler,
+	}
+	consolidateCmd.Flags().BoolP("recursive", "r", true, "search for action.yml files recursively")
+	consolidateCmd.Flags().Bool("fix", false, "update the action files to the suggested versions")
+	consolidateCmd.Flags().Bool("dry-run", false, "with --fix, show the updates without making changes")
+	consolidateCmd.Flags().Bool("json", false, "print the suggestions and planned updates as JSON")
+	consolidateCmd.Flags().BoolVar(&allowDirty, "allow-dirty", false, allowDirtyUsage)
+	cmd.AddCommand(consolidateCmd)
+
 	return cmd
 }
 
@@ -581,15 +1526,77 @@ func newCacheCmd() *cobra.Command {
 		Run:   cachePathHandler,
 	})
 
+	warmCmd := &cobra.Command{
+		Use:   "warm",
+		Short: "Pre-fetch dependency metadata into the cache",
+		Long: `Fetch and cache release, tag and repository metadata for dependencies, so later
+'deps outdated' runs are served from the cache and can run offline.
+
+Examples:
+	gh-action-readme cache warm                                   # Dependencies of the action files here
+	gh-action-readme cache warm --deps actions/checkout@v4,actions/setup-node@v4
+	gh-action-readme cache warm --from-files --deps org/tool@v1 --ttl 72h`,
+		Run: cacheWarmHandler,
+	}
+	warmCmd.Flags().Bool("from-files", false,
+		"warm dependencies of the action files in the current directory (default without --deps)")
+	warmCmd.Flags().StringSlice("deps", nil, "comma-separated dependencies to warm, such as actions/checkout@v4")
+	warmCmd.Flags().Duration("ttl", defaultCacheWarmTTL, "how long the warmed entries stay valid")
+	cmd.AddCommand(warmCmd)
+
+	return cmd
+}
+
+func newMetadataCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metadata",
+		Short: "Export and import dependency metadata for offline use",
+		Long: `Move dependency metadata to machines without access to GitHub.
+
+'metadata export' fetches the releases, commit SHAs, descriptions, release notes and
+action files of dependencies into a bundle on a connected machine. 'metadata import'
+stores a bundle as the snapshot deps commands read without GitHub lookups, when no
+token is configured or enrichment is disabled.
+
+Examples:
+	gh-action-readme metadata export                                   # Dependencies of the action files here
+	gh-action-readme metadata export -o bundle.json --deps org/tool@v1
+	gh-action-readme metadata import bundle.json                       # On the air-gapped machine`,
+	}
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export dependency metadata into a bundle",
+		Run:   metadataExportHandler,
+	}
+	exportCmd.Flags().StringP("output", "o", internal.DefaultMetadataBundleFile, "bundle file to write")
+	exportCmd.Flags().Bool("from-files", false,
+		"export dependencies of the action files in the current directory (default without --deps)")
+	exportCmd.Flags().StringSlice("deps", nil, "comma-separated dependencies to export, such as actions/checkout@v4")
+	cmd.AddCommand(exportCmd)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "import <bundle>",
+		Short: "Import a metadata bundle as the offline snapshot",
+		Args:  cobra.ExactArgs(1),
+		Run:   metadataImportHandler,
+	})
+
 	return cmd
 }
 
-func depsListHandler(_ *cobra.Command, _ []string) {
+func depsListHandler(cmd *cobra.Command, _ []string) {
 	output := createOutputManager(globalConfig.Quiet)
 	currentDir, err := helpers.GetCurrentDir()
 	if err != nil {
 		output.Error("Error getting current directory: %v", err)
-		os.Exit(1)
+		exit(1)
+	}
+
+	filter := depsListFilter(cmd)
+	if err := filter.Validate(); err != nil {
+		output.Error("%v", err)
+		exit(1)
 	}
 
 	generator := internal.NewGenerator(globalConfig)
@@ -600,67 +1607,115 @@ func depsListHandler(_ *cobra.Command, _ []string) {
 
 		return
 	}
+	actionFiles = filterActionFiles(cmd, output, actionFiles)
+	if len(actionFiles) == 0 {
+		return
+	}
 
 	analyzer := createAnalyzer(generator, output)
-	totalDeps := analyzeDependencies(output, actionFiles, analyzer)
+	files, failures := analyzeDependencies(output, actionFiles, analyzer, filter)
 
+	wide, _ := cmd.Flags().GetBool("wide")
+	totalDeps := displayDependencies(output, files, analyzer, wide)
 	if totalDeps > 0 {
 		output.Bold("\nTotal dependencies: %d", totalDeps)
 	}
+	if wide && totalDeps > 0 && !globalConfig.GitHubLookups() && !internal.HasMetadataSnapshot() {
+		output.Info("Resolved SHAs and descriptions need a GitHub token or an imported metadata snapshot")
+	}
+
+	// Warnings follow the results so they do not break up the listing
+	if len(failures) > 0 {
+		output.Bold("\nWarnings:")
+	}
+	for _, failure := range failures {
+		output.Report(failure, "Error analyzing %s: %s", failure.File, failure.Message)
+	}
 }
 
-// analyzeDependencies analyzes and displays dependencies.
-func analyzeDependencies(output *internal.ColoredOutput, actionFiles []string, analyzer *dependencies.Analyzer) int {
-	totalDeps := 0
-	output.Bold("Dependencies found in action files:")
+// depsListFilter reads the filter flags of deps list.
+func depsListFilter(cmd *cobra.Command) internal.DependencyFilter {
+	pinnedOnly, _ := cmd.Flags().GetBool("pinned-only")
+	floatingOnly, _ := cmd.Flags().GetBool("floating-only")
+	externalOnly, _ := cmd.Flags().GetBool("external-only")
 
-	// Create progress bar for multiple files
-	progressMgr := internal.NewProgressBarManager(output.IsQuiet())
+	return internal.DependencyFilter{PinnedOnly: pinnedOnly, FloatingOnly: floatingOnly, ExternalOnly: externalOnly}
+}
+
+// analyzeDependencies analyzes the dependencies of the action files, keeping
+// those selected by filter, and returns the files that could not be analyzed
+// as diagnostics. Without an analyzer no file is analyzed.
+func analyzeDependencies(
+	output *internal.ColoredOutput,
+	actionFiles []string,
+	analyzer *dependencies.Analyzer,
+	filter internal.DependencyFilter,
+) ([]internal.FileDependencies, []internal.Diagnostic) {
+	var files []internal.FileDependencies
+	var failures []internal.Diagnostic
 
+	progressMgr := internal.NewProgressBarManager(output.IsQuiet())
 	progressMgr.ProcessWithProgressBar(
 		"Analyzing dependencies",
 		actionFiles,
-		func(actionFile string, bar *progressbar.ProgressBar) {
-			if bar == nil {
-				output.Info("\n📄 %s", actionFile)
+		func(actionFile string, _ *progressbar.ProgressBar) {
+			if analyzer == nil {
+				files = append(files, internal.FileDependencies{File: actionFile})
+
+				return
+			}
+			deps, err := analyzer.AnalyzeActionFile(actionFile)
+			if err != nil {
+				failures = append(failures, internal.Diagnostic{
+					Severity: internal.SeverityWarning,
+					Code:     string(errors.ErrCodeDependencyAnalysis),
+					Source:   internal.DiagnosticSourceDependencies,
+					Message:  err.Error(),
+					File:     actionFile,
+				})
+				files = append(files, internal.FileDependencies{File: actionFile})
+
+				return
 			}
-			totalDeps += analyzeActionFileDeps(output, actionFile, analyzer)
+			files = append(files, internal.FileDependencies{
+				File: actionFile, Dependencies: filter.Apply(deps), Total: len(deps), Analyzed: true,
+			})
 		},
 	)
 
-	return totalDeps
+	return files, failures
 }
 
-// analyzeActionFileDeps analyzes dependencies in a single action file.
-func analyzeActionFileDeps(output *internal.ColoredOutput, actionFile string, analyzer *dependencies.Analyzer) int {
-	if analyzer == nil {
-		output.Printf("  • Cannot analyze (no GitHub token)\n")
-
-		return 0
+// displayDependencies prints the dependencies as a tree, or with --wide as a
+// table per file with resolved SHAs and descriptions, and returns their count.
+func displayDependencies(
+	output *internal.ColoredOutput,
+	files []internal.FileDependencies,
+	analyzer *dependencies.Analyzer,
+	wide bool,
+) int {
+	output.Bold("Dependencies found in action files:")
+	totalDeps := 0
+	for _, file := range files {
+		totalDeps += len(file.Dependencies)
 	}
+	if !wide {
+		output.Printf("%s", internal.DependencyTree(files))
 
-	deps, err := analyzer.AnalyzeActionFile(actionFile)
-	if err != nil {
-		output.Warning("  ⚠️  Error analyzing: %v", err)
-
-		return 0
+		return totalDeps
 	}
 
-	if len(deps) == 0 {
-		output.Printf("  • No dependencies (not a composite action)\n")
-
-		return 0
-	}
+	for _, file := range files {
+		output.Printf("\n📄 %s\n", file.File)
+		if note := file.EmptyNote(); note != "" {
+			output.Printf("  %s\n", note)
 
-	for _, dep := range deps {
-		if dep.IsPinned {
-			output.Success("  🔒 %s @ %s - %s", dep.Name, dep.Version, dep.Description)
-		} else {
-			output.Warning("  📌 %s @ %s - %s", dep.Name, dep.Version, dep.Description)
+			continue
 		}
+		output.Table(internal.DependencyWideTable(file.Dependencies, analyzer.ResolveSHA).Indent("  "))
 	}
 
-	return len(deps)
+	return totalDeps
 }
 
 func depsSecurityHandler(_ *cobra.Command, _ []string) {
@@ -674,7 +1729,7 @@ func depsSecurityHandler(_ *cobra.Command, _ []string) {
 	generator := internal.NewGenerator(globalConfig)
 	actionFiles, err := generator.DiscoverActionFilesWithValidation(currentDir, true, "security analysis")
 	if err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	analyzer := createAnalyzer(generator, output)
@@ -684,6 +1739,23 @@ func depsSecurityHandler(_ *cobra.Command, _ []string) {
 
 	pinnedCount, floatingDeps := analyzeSecurityDeps(output, actionFiles, analyzer)
 	displaySecuritySummary(output, currentDir, pinnedCount, floatingDeps)
+	recordTrend(output, globalConfig, currentDir, "deps security", map[string]int{
+		internal.TrendActions:  len(actionFiles),
+		internal.TrendUnpinned: len(floatingDeps),
+	})
+	floating := make([]dependencies.Dependency, 0, len(floatingDeps))
+	for _, fd := range floatingDeps {
+		floating = append(floating, fd.dep)
+	}
+	for _, err := range []error{
+		globalConfig.Deps.CheckSecurity(len(floatingDeps)),
+		globalConfig.Deps.CheckLatest(floating),
+	} {
+		if err != nil {
+			output.Error("%v", err)
+			exit(1)
+		}
+	}
 }
 
 // analyzeSecurityDeps analyzes dependencies for security issues.
@@ -754,19 +1826,35 @@ func displayFloatingDeps(output *internal.ColoredOutput, currentDir string, floa
 	dep  dependencies.Dependency
 }) {
 	output.Bold("\nFloating dependencies that should be pinned:")
+	table := internal.NewTable("Dependency", "Version", "File").Indent("  ")
 	for _, fd := range floatingDeps {
 		relPath, _ := filepath.Rel(currentDir, fd.file)
-		output.Warning("  • %s @ %s", fd.dep.Name, fd.dep.Version)
-		output.Printf("    in %s\n", relPath)
+		version := fd.dep.Version
+		if fd.dep.Docker != nil && fd.dep.Docker.IsLatest() {
+			version += " (mutable)"
+		}
+		table.AddRow(fd.dep.Name, version, relPath)
 	}
+	output.Table(table)
 }
 
-func depsOutdatedHandler(_ *cobra.Command, _ []string) {
+func depsOutdatedHandler(cmd *cobra.Command, _ []string) {
 	output := createOutputManager(globalConfig.Quiet)
+
+	var maxAge time.Duration
+	if value, _ := cmd.Flags().GetString("max-age"); value != "" {
+		parsed, err := dependencies.ParseMaxAge(value)
+		if err != nil {
+			output.Error("Error parsing --max-age: %v", err)
+			exit(1)
+		}
+		maxAge = parsed
+	}
+
 	currentDir, err := helpers.GetCurrentDir()
 	if err != nil {
 		output.Error("Error getting current directory: %v", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	generator := internal.NewGenerator(globalConfig)
@@ -783,49 +1871,440 @@ func depsOutdatedHandler(_ *cobra.Command, _ []string) {
 		return
 	}
 
-	if !validateGitHubToken(output) {
+	if !canLookUpDependencies(output) {
 		return
 	}
 
-	allOutdated := checkAllOutdated(output, actionFiles, analyzer)
-	displayOutdatedResults(output, allOutdated)
+	allOutdated := checkAllOutdated(output, actionFiles, analyzer, maxAge)
+	displayOutdatedResults(output, allOutdated, maxAge > 0)
+	recordTrend(output, globalConfig, currentDir, "deps outdated", internal.OutdatedTrendMetrics(allOutdated))
+	if notify, _ := cmd.Flags().GetBool("notify"); notify {
+		sendNotification(output, globalConfig, internal.OutdatedNotification(globalConfig.Notify, allOutdated))
+	}
+	if err := globalConfig.Deps.CheckOutdated(allOutdated); err != nil {
+		output.Error("%v", err)
+		exit(1)
+	}
 }
 
-// validateGitHubToken checks if GitHub token is available.
-func validateGitHubToken(output *internal.ColoredOutput) bool {
-	if globalConfig.GitHubToken == "" {
-		contextualErr := errors.New(errors.ErrCodeGitHubAuth, "GitHub token not found").
-			WithSuggestions(errors.GetSuggestions(errors.ErrCodeGitHubAuth, map[string]string{})...).
-			WithHelpURL(errors.GetHelpURL(errors.ErrCodeGitHubAuth))
-
-		output.Warning("⚠️  %s", contextualErr.Error())
+func depsPolicyCheckHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	createIssues, _ := cmd.Flags().GetBool("create-issues")
 
-		return false
+	workingDir, actionFiles := resolveActionTargets(cmd, args, output, "dependency policy check")
+	generator := internal.NewGenerator(globalConfig)
+	analyzer := createAnalyzer(generator, output)
+	if analyzer == nil {
+		exit(1)
 	}
 
-	return true
+	allowlist := loadAllowlist(output, workingDir)
+	checkOutdated := globalConfig.GitHubLookups() || internal.HasMetadataSnapshot()
+	actions := analyzePolicyActions(output, actionFiles, workingDir, analyzer, checkOutdated, allowlist)
+	report := globalConfig.Deps.Evaluate(actions)
+	displayPolicyReport(output, report)
+	recordTrend(output, globalConfig, workingDir, "deps policy check", internal.PolicyTrendMetrics(report))
+	if createIssues {
+		syncPolicyIssue(cmd, output, report, workingDir)
+	}
+	if report.Error != "" {
+		output.Error("%s", report.Error)
+		exit(1)
+	}
 }
 
-// checkAllOutdated checks all action files for outdated dependencies.
-func checkAllOutdated(
-	output *internal.ColoredOutput,
-	actionFiles []string,
-	analyzer *dependencies.Analyzer,
-) []dependencies.OutdatedDependency {
-	output.Bold("Checking for outdated dependencies...")
-	var allOutdated []dependencies.OutdatedDependency
+func depsWhyHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	transitive, _ := cmd.Flags().GetBool("transitive")
+	depth, _ := cmd.Flags().GetInt("depth")
+	asJSON, _ := cmd.Flags().GetBool("json")
+	if depth < 1 {
+		output.Error("Invalid --depth %d, must be at least 1", depth)
+		exit(1)
+	}
+	if asJSON {
+		output = createOutputManager(true) // Keep stdout valid JSON
+	}
+	if limit := internal.CurrentResourceLimits().MaxTransitiveDepth; depth > limit {
+		output.Warning("--depth %d is above limits.max_transitive_depth, following %d levels", depth, limit)
+		depth = limit
+	}
 
-	for _, actionFile := range actionFiles {
-		deps, err := analyzer.AnalyzeActionFile(actionFile)
-		if err != nil {
-			output.Warning("Error analyzing %s: %v", actionFile, err)
+	workingDir, actionFiles := resolveActionTargets(cmd, args[1:], output, "dependency explanation")
+	generator := internal.NewGenerator(globalConfig)
+	analyzer := createAnalyzer(generator, output)
+	if analyzer == nil {
+		exit(1)
+	}
+	files, failures := analyzeDependencies(output, actionFiles, analyzer, internal.DependencyFilter{})
+	for i := range files {
+		if rel, err := filepath.Rel(workingDir, files[i].File); err == nil {
+			files[i].File = filepath.ToSlash(rel)
+		}
+	}
+	for _, failure := range failures {
+		output.Report(failure, "Error analyzing %s: %s", failure.File, failure.Message)
+	}
 
-			continue
+	var expand internal.ActionDependencyFunc
+	if transitive {
+		if !globalConfig.GitHubLookups() {
+			output.Warning("GitHub lookups unavailable; --transitive only reads cached action files")
 		}
+		expand = analyzer.ActionDependencies
+	}
+	explanation := internal.ExplainDependency(args[0], files, expand, depth)
 
-		outdated, err := analyzer.CheckOutdated(deps)
+	if asJSON {
+		data, err := json.MarshalIndent(explanation, "", "  ")
 		if err != nil {
-			output.Warning("Error checking outdated for %s: %v", actionFile, err)
+			output.Error("Failed to encode explanation: %v", err)
+			exit(1)
+		}
+		fmt.Println(string(data))
+
+		return
+	}
+	displayDependencyExplanation(output, explanation)
+}
+
+// consolidationPlan is the JSON output of deps consolidate.
+type consolidationPlan struct {
+	Consolidations []internal.Consolidation   `json:"consolidations"`
+	Updates        []dependencies.PinnedUpdate `json:"updates"`
+}
+
+func depsConsolidateHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	fix, _ := cmd.Flags().GetBool("fix")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	asJSON, _ := cmd.Flags().GetBool("json")
+	if asJSON && fix {
+		output.Error("--json cannot be combined with --fix")
+		exit(1)
+	}
+	if asJSON {
+		output = createOutputManager(true) // Keep stdout valid JSON
+	}
+
+	workingDir, actionFiles := resolveActionTargets(cmd, args, output, "dependency consolidation")
+	generator := internal.NewGenerator(globalConfig)
+	analyzer := createAnalyzer(generator, output)
+	if analyzer == nil {
+		exit(1)
+	}
+	files, failures := analyzeDependencies(output, actionFiles, analyzer, internal.DependencyFilter{})
+	for _, failure := range failures {
+		output.Report(failure, "Error analyzing %s: %s", failure.File, failure.Message)
+	}
+
+	consolidations := internal.FindConsolidations(files, globalConfig.Deps.Consolidate, analyzer.UpdateType)
+	var updates []dependencies.PinnedUpdate
+	if fix || asJSON {
+		resolve := func(dep dependencies.Dependency) string {
+			if analyzer.PinStrategy == dependencies.PinStrategyTag {
+				return ""
+			}
+
+			return analyzer.ResolveSHA(dep)
+		}
+		updates = internal.ConsolidationUpdates(consolidations, resolve, analyzer.UpdateType)
+	}
+
+	if asJSON {
+		plan := consolidationPlan{Consolidations: consolidations, Updates: updates}
+		if plan.Consolidations == nil {
+			plan.Consolidations = []internal.Consolidation{}
+		}
+		if plan.Updates == nil {
+			plan.Updates = []dependencies.PinnedUpdate{}
+		}
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			output.Error("Failed to encode consolidation plan: %v", err)
+			exit(1)
+		}
+		fmt.Println(string(data))
+
+		return
+	}
+
+	displayConsolidations(output, consolidations, workingDir)
+	switch {
+	case len(consolidations) == 0:
+		return
+	case !fix:
+		output.Info("\nRun deps consolidate --fix to update the action files")
+	case len(updates) == 0:
+		output.Info("\nNo updates to apply; set deps.consolidate for actions without a suggested version")
+	default:
+		output.Printf("\n")
+		showPendingUpdates(output, updates, workingDir)
+		if dryRun {
+			output.Info("\n🔍 Dry run complete - no changes made")
+
+			return
+		}
+		applyUpdates(output, analyzer, updates, assumeYes)
+	}
+}
+
+// displayConsolidations prints the actions used at several versions and the
+// version suggested for each.
+func displayConsolidations(output *internal.ColoredOutput, consolidations []internal.Consolidation, baseDir string) {
+	if len(consolidations) == 0 {
+		output.Success("✅ Every action is used at a single version")
+
+		return
+	}
+
+	output.Warning("Found %d action(s) used at several versions:", len(consolidations))
+	table := internal.NewTable("Dependency", "Versions", "Consolidate on", "Used in").Indent("  ")
+	for _, consolidation := range consolidations {
+		target := consolidation.Target
+		switch {
+		case target == "":
+			target = "- (set deps.consolidate)"
+		case consolidation.Configured:
+			target += " (deps.consolidate)"
+		}
+		var files []string
+		for _, use := range consolidation.Uses {
+			file := use.File
+			if rel, err := filepath.Rel(baseDir, use.File); err == nil {
+				file = filepath.ToSlash(rel)
+			}
+			if use.Line > 0 {
+				file += ":" + strconv.Itoa(use.Line)
+			}
+			files = append(files, file+" ("+use.Version+")")
+		}
+		table.AddRow(consolidation.Dependency, strings.Join(consolidation.Versions, ", "), target,
+			strings.Join(files, ", "))
+	}
+	output.Table(table)
+}
+
+// displayDependencyExplanation prints the steps using the dependency and a
+// hint to consolidate them when they use several versions.
+func displayDependencyExplanation(output *internal.ColoredOutput, explanation *internal.DependencyExplanation) {
+	for _, uses := range explanation.Unresolved {
+		output.Warning("Could not read the dependencies of %s", uses)
+	}
+	if len(explanation.Uses) == 0 {
+		output.Info("%s is not used by the action files", explanation.Dependency)
+
+		return
+	}
+
+	output.Bold("%s is used by %d step(s):", explanation.Dependency, len(explanation.Uses))
+	table := internal.NewTable("File", "Step", "Version", "Via").Indent("  ")
+	for _, use := range explanation.Uses {
+		file := use.File
+		if use.Line > 0 {
+			file += ":" + strconv.Itoa(use.Line)
+		}
+		version := use.Version
+		if !use.Pinned {
+			version += " (floating)"
+		}
+		via := "direct"
+		if len(use.Via) > 0 {
+			via = strings.Join(use.Via, " → ")
+		}
+		table.AddRow(file, use.Step, version, via)
+	}
+	output.Table(table)
+
+	if len(explanation.Versions) > 1 {
+		output.Printf("\n")
+		output.Warning("%d versions in use: %s", len(explanation.Versions), strings.Join(explanation.Versions, ", "))
+		output.Info("Consolidate them on one version, for example with deps upgrade or deps pin")
+	}
+}
+
+// analyzePolicyActions analyzes the dependencies of each action file, their
+// updates when checkOutdated is set and the dependencies missing from
+// allowlist when it is not nil. Files are named relative to baseDir.
+func analyzePolicyActions(
+	output *internal.ColoredOutput,
+	actionFiles []string,
+	baseDir string,
+	analyzer *dependencies.Analyzer,
+	checkOutdated bool,
+	allowlist *internal.ActionAllowlist,
+) []internal.PolicyAction {
+	actions := make([]internal.PolicyAction, 0, len(actionFiles))
+	for _, actionFile := range actionFiles {
+		deps, err := analyzer.AnalyzeActionFile(actionFile)
+		if err != nil {
+			output.Warning("Error analyzing %s: %v", actionFile, err)
+
+			continue
+		}
+		action := internal.PolicyAction{
+			File:         actionFile,
+			Dependencies: deps,
+			Disallowed:   internal.DisallowedDependencies(allowlist, deps, analyzer.ResolveSHA),
+		}
+		if rel, err := filepath.Rel(baseDir, actionFile); err == nil {
+			action.File = filepath.ToSlash(rel)
+		}
+		if checkOutdated {
+			outdated, err := analyzer.CheckOutdated(deps)
+			if err != nil {
+				output.Warning("Error checking outdated for %s: %v", actionFile, err)
+			}
+			action.Outdated = append([]dependencies.OutdatedDependency{}, outdated...)
+		}
+		actions = append(actions, action)
+	}
+
+	return actions
+}
+
+// displayPolicyReport prints the counts of the policy report.
+func displayPolicyReport(output *internal.ColoredOutput, report *internal.PolicyReport) {
+	if report.Total == 0 {
+		output.Success("✅ No dependency policy violations in %d action(s)", report.Actions)
+
+		return
+	}
+
+	output.Warning("Found %d dependency policy violation(s) in %d action(s):", report.Total, report.Actions)
+	table := internal.NewTable("Violation", "Count").Indent("  ").AlignRight(1)
+	l := locale.Current()
+	table.AddRow("Unpinned", l.Int(len(report.Unpinned)))
+	if report.OutdatedChecked {
+		table.AddRow("Outdated", l.Int(len(report.Outdated)))
+		table.AddRow("Potential security updates", l.Int(len(report.Security)))
+	}
+	table.AddRow("Images on :latest", l.Int(len(report.Latest)))
+	if report.AllowlistChecked {
+		table.AddRow("Not on the allow-list", l.Int(len(report.Disallowed)))
+	}
+	output.Table(table)
+	if !report.OutdatedChecked {
+		output.Info("Outdated dependencies were not checked, configure a GitHub token to include them")
+	}
+}
+
+// syncPolicyIssue opens, updates or closes the policy issue of the repository
+// of workingDir, or prints its body with --dry-run.
+func syncPolicyIssue(
+	cmd *cobra.Command,
+	output *internal.ColoredOutput,
+	report *internal.PolicyReport,
+	workingDir string,
+) {
+	label, _ := cmd.Flags().GetString("issue-label")
+	title, _ := cmd.Flags().GetString("issue-title")
+	templatePath, _ := cmd.Flags().GetString("template")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	report.Organization, report.Repository = globalConfig.Organization, globalConfig.Repository
+	if report.Organization == "" || report.Repository == "" {
+		if info, err := git.DetectRepository(helpers.FindGitRepoRoot(workingDir)); err == nil {
+			report.Organization = cmp.Or(report.Organization, info.Organization)
+			report.Repository = cmp.Or(report.Repository, info.Repository)
+		}
+	}
+	body, err := internal.RenderPolicyIssue(report, templatePath)
+	if err != nil {
+		output.Error("%v", err)
+		exit(1)
+	}
+	if dryRun {
+		fmt.Print(body)
+
+		return
+	}
+
+	if report.Organization == "" || report.Repository == "" {
+		output.Error("Repository unknown, set organization and repository or add a GitHub remote")
+		exit(1)
+	}
+	if !validateGitHubToken(output) {
+		exit(1)
+	}
+	client, err := internal.NewGitHubClient(globalConfig.GitHubToken)
+	if err != nil {
+		output.Error("Failed to create GitHub client: %v", err)
+		exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), policyIssueTimeout)
+	defer cancel()
+
+	result, url, err := internal.SyncPolicyIssue(ctx, client.Client,
+		report.Organization, report.Repository, label, title, body, report.Total)
+	if err != nil {
+		output.Error("Failed to sync policy issue: %v", err)
+		exit(1)
+	}
+	switch result {
+	case internal.PolicyIssueNone:
+		output.Info("No policy issue needed")
+	default:
+		output.Success("Policy issue %s: %s", result, url)
+	}
+}
+
+// validateGitHubToken checks if GitHub token is available.
+func validateGitHubToken(output *internal.ColoredOutput) bool {
+	if globalConfig.GitHubToken == "" {
+		contextualErr := errors.New(errors.ErrCodeGitHubAuth, "GitHub token not found").
+			WithSuggestions(errors.GetSuggestions(errors.ErrCodeGitHubAuth, map[string]string{})...).
+			WithHelpURL(errors.GetHelpURL(errors.ErrCodeGitHubAuth))
+
+		output.Warning("⚠️  %s", contextualErr.Error())
+
+		return false
+	}
+
+	return true
+}
+
+// canLookUpDependencies reports whether dependency metadata can be read from
+// GitHub or, without GitHub lookups, from an imported metadata snapshot.
+func canLookUpDependencies(output *internal.ColoredOutput) bool {
+	switch {
+	case globalConfig.GitHubLookups():
+		return true
+	case internal.HasMetadataSnapshot():
+		output.Info("GitHub lookups unavailable; using the imported metadata snapshot")
+
+		return true
+	case !globalConfig.EnrichmentEnabled():
+		output.Warning("GitHub lookups are disabled by enrichment: false or --no-enrich")
+
+		return false
+	default:
+		return validateGitHubToken(output)
+	}
+}
+
+// checkAllOutdated checks all action files for outdated dependencies.
+func checkAllOutdated(
+	output *internal.ColoredOutput,
+	actionFiles []string,
+	analyzer *dependencies.Analyzer,
+	maxAge time.Duration,
+) []dependencies.OutdatedDependency {
+	output.Bold("Checking for outdated dependencies...")
+	var allOutdated []dependencies.OutdatedDependency
+
+	for _, actionFile := range actionFiles {
+		deps, err := analyzer.AnalyzeActionFile(actionFile)
+		if err != nil {
+			output.Warning("Error analyzing %s: %v", actionFile, err)
+
+			continue
+		}
+
+		outdated, err := analyzer.CheckOutdatedWithMaxAge(deps, maxAge)
+		if err != nil {
+			output.Warning("Error checking outdated for %s: %v", actionFile, err)
 
 			continue
 		}
@@ -836,8 +2315,12 @@ func checkAllOutdated(
 	return allOutdated
 }
 
-// displayOutdatedResults shows outdated dependency results.
-func displayOutdatedResults(output *internal.ColoredOutput, allOutdated []dependencies.OutdatedDependency) {
+// displayOutdatedResults shows outdated dependency results, with pin age when showAge is set.
+func displayOutdatedResults(
+	output *internal.ColoredOutput,
+	allOutdated []dependencies.OutdatedDependency,
+	showAge bool,
+) {
 	if len(allOutdated) == 0 {
 		output.Success("✅ All dependencies are up to date!")
 
@@ -845,26 +2328,81 @@ func displayOutdatedResults(output *internal.ColoredOutput, allOutdated []depend
 	}
 
 	output.Warning("Found %d outdated dependencies:", len(allOutdated))
+	headers := []string{"Dependency", "Current", "Latest", "Update", "Security"}
+	if showAge {
+		headers = append(headers, "Age", "Stale")
+	}
+	table := internal.NewTable(headers...).Indent("  ")
 	for _, outdated := range allOutdated {
-		output.Printf("  • %s: %s → %s (%s update)",
+		security := ""
+		if outdated.IsSecurityUpdate {
+			security = "🔒 potential"
+		}
+		row := []string{
 			outdated.Current.Name,
 			outdated.Current.Version,
 			outdated.LatestVersion,
-			outdated.UpdateType)
-		if outdated.IsSecurityUpdate {
-			output.Warning("    🔒 Potential security update")
+			outdated.UpdateType,
+			security,
+		}
+		if showAge {
+			row = append(row, formatPinAge(outdated), formatStaleness(outdated))
+		}
+		table.AddRow(row...)
+	}
+	output.Table(table)
+
+	var hints []string
+	for _, outdated := range allOutdated {
+		for _, hint := range outdated.RiskHints {
+			hints = append(hints, outdated.Current.Name+" "+hint)
 		}
 	}
+	displayRiskHints(output, hints)
 
 	output.Info("\nRun 'gh-action-readme deps upgrade' to update dependencies")
 }
 
+// displayRiskHints lists the breaking changes named in the release notes of
+// the updates.
+func displayRiskHints(output *internal.ColoredOutput, hints []string) {
+	if len(hints) == 0 {
+		return
+	}
+	output.Bold("\nRisk hints:")
+	for _, hint := range hints {
+		output.Printf("  ⚠️  %s\n", hint)
+	}
+}
+
+// formatPinAge renders the age of a pin in days, or "unknown" when it could not be retrieved.
+func formatPinAge(outdated dependencies.OutdatedDependency) string {
+	if outdated.PinnedAt.IsZero() {
+		return "unknown"
+	}
+
+	return locale.Current().Int(outdated.AgeDays) + "d"
+}
+
+// formatStaleness renders the staleness score, marking pins older than the max age.
+func formatStaleness(outdated dependencies.OutdatedDependency) string {
+	if outdated.PinnedAt.IsZero() {
+		return ""
+	}
+	score := locale.Current().Float(outdated.StalenessScore, 2)
+	if outdated.IsStale {
+		return "⏳ " + score
+	}
+
+	return score
+}
+
 func depsUpgradeHandler(cmd *cobra.Command, _ []string) {
 	output := createOutputManager(globalConfig.Quiet)
 	currentDir, err := helpers.GetCurrentDir()
 	if err != nil {
 		output.Error("Error getting current directory: %v", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Setup and validation
@@ -879,10 +2417,15 @@ func depsUpgradeHandler(cmd *cobra.Command, _ []string) {
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	isPinCmd := cmd.Use == "pin"
 
-	showUpgradeMode(output, ciMode, isPinCmd)
+	showUpgradeMode(output, ciMode, isPinCmd, analyzer.PinStrategy == dependencies.PinStrategyTag)
+
+	ctx, stop := interruptContext()
+	defer stop()
+	analyzer.Context = ctx
 
 	// Collect all updates
-	allUpdates := collectAllUpdates(output, analyzer, actionFiles)
+	allUpdates := collectAllUpdates(output, analyzer, actionFiles, loadAllowlist(output, currentDir))
+	exitIfInterrupted(ctx, output)
 	if len(allUpdates) == 0 {
 		output.Success("✅ No updates needed - all dependencies are current and pinned!")
 
@@ -892,7 +2435,7 @@ func depsUpgradeHandler(cmd *cobra.Command, _ []string) {
 	// Show and apply updates
 	showPendingUpdates(output, allUpdates, currentDir)
 	if !dryRun {
-		applyUpdates(output, analyzer, allUpdates, ciMode || allFlag)
+		applyUpdates(output, analyzer, allUpdates, ciMode || allFlag || assumeYes)
 	} else {
 		output.Info("\n🔍 Dry run complete - no changes made")
 	}
@@ -904,7 +2447,7 @@ func setupDepsUpgrade(output *internal.ColoredOutput, currentDir string) (*depen
 	actionFiles, err := generator.DiscoverActionFiles(currentDir, true)
 	if err != nil {
 		output.Error("Error discovering action files: %v", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	if len(actionFiles) == 0 {
@@ -920,9 +2463,7 @@ func setupDepsUpgrade(output *internal.ColoredOutput, currentDir string) (*depen
 		return nil, nil
 	}
 
-	if globalConfig.GitHubToken == "" {
-		output.Warning("No GitHub token found. Set GITHUB_TOKEN environment variable")
-
+	if !canLookUpDependencies(output) {
 		return nil, nil
 	}
 
@@ -930,22 +2471,28 @@ func setupDepsUpgrade(output *internal.ColoredOutput, currentDir string) (*depen
 }
 
 // showUpgradeMode displays the current upgrade mode to the user.
-func showUpgradeMode(output *internal.ColoredOutput, ciMode, isPinCmd bool) {
+func showUpgradeMode(output *internal.ColoredOutput, ciMode, isPinCmd, pinTags bool) {
+	target := "commit SHAs"
+	if pinTags {
+		target = "exact version tags"
+	}
 	switch {
 	case ciMode:
-		output.Bold("🤖 CI/CD Mode: Automated dependency updates with pinned commit SHAs")
+		output.Bold("🤖 CI/CD Mode: Automated dependency updates pinned to %s", target)
 	case isPinCmd:
-		output.Bold("📌 Pinning floating dependencies to commit SHAs")
+		output.Bold("📌 Pinning floating dependencies to %s", target)
 	default:
 		output.Bold("🔄 Interactive dependency upgrade")
 	}
 }
 
-// collectAllUpdates gathers all available updates from action files.
+// collectAllUpdates gathers all available updates from action files. With an
+// allow-list, only approved versions are proposed.
 func collectAllUpdates(
 	output *internal.ColoredOutput,
 	analyzer *dependencies.Analyzer,
 	actionFiles []string,
+	allowlist *internal.ActionAllowlist,
 ) []dependencies.PinnedUpdate {
 	var allUpdates []dependencies.PinnedUpdate
 
@@ -965,6 +2512,14 @@ func collectAllUpdates(
 		}
 
 		for _, outdatedDep := range outdated {
+			if allowlist != nil {
+				var ok bool
+				if outdatedDep, ok = approvedUpdate(analyzer, allowlist, outdatedDep); !ok {
+					output.Warning("No approved update for %s in %s", outdatedDep.Current.Uses, allowlist.Source)
+
+					continue
+				}
+			}
 			update, err := analyzer.GeneratePinnedUpdate(
 				actionFile,
 				outdatedDep.Current,
@@ -976,6 +2531,7 @@ func collectAllUpdates(
 
 				continue
 			}
+			update.RiskHints = outdatedDep.RiskHints
 			allUpdates = append(allUpdates, *update)
 		}
 	}
@@ -983,6 +2539,57 @@ func collectAllUpdates(
 	return allUpdates
 }
 
+// approvedUpdate moves the update of outdated to the version allowlist
+// approves, resolving the commit SHA of that version. It returns false when
+// no newer version is approved.
+func approvedUpdate(
+	analyzer *dependencies.Analyzer,
+	allowlist *internal.ActionAllowlist,
+	outdated dependencies.OutdatedDependency,
+) (dependencies.OutdatedDependency, bool) {
+	target, ok := allowlist.UpgradeTarget(outdated.Current, outdated.LatestVersion, analyzer.UpdateType)
+	if !ok {
+		return outdated, false
+	}
+	if target == outdated.LatestVersion {
+		return outdated, true
+	}
+	if outdated.Current.Docker != nil {
+		return outdated, false // Only the digest of the latest tag is known
+	}
+
+	outdated.LatestVersion, outdated.LatestSHA, outdated.RiskHints = target, "", nil
+	if analyzer.PinStrategy != dependencies.PinStrategyTag {
+		outdated.LatestSHA = analyzer.ResolveSHA(dependencies.Dependency{
+			Name: outdated.Current.Name,
+			Uses: outdated.Current.Name + "@" + target,
+		})
+	}
+
+	return outdated, true
+}
+
+// loadAllowlist loads deps.allowlist relative to the root of the repository
+// containing dir. It returns nil when no allow-list is configured and exits
+// when it cannot be read, so unapproved dependencies never pass unnoticed.
+func loadAllowlist(output *internal.ColoredOutput, dir string) *internal.ActionAllowlist {
+	if globalConfig.Deps.Allowlist == "" {
+		return nil
+	}
+	baseDir := cmp.Or(helpers.FindGitRepoRoot(dir), dir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), allowlistTimeout)
+	defer cancel()
+
+	allowlist, err := internal.LoadAllowlist(ctx, nil, globalConfig.Deps.Allowlist, baseDir)
+	if err != nil {
+		output.Error("%v", err)
+		exit(1)
+	}
+
+	return allowlist
+}
+
 // showPendingUpdates displays what updates will be applied.
 func showPendingUpdates(
 	output *internal.ColoredOutput,
@@ -990,12 +2597,20 @@ func showPendingUpdates(
 	currentDir string,
 ) {
 	output.Info("Found %d dependencies to update:", len(allUpdates))
+	table := internal.NewTable("Current", "New", "Update", "File").Indent("  ")
+	var hints []string
 	for _, update := range allUpdates {
 		relPath, _ := filepath.Rel(currentDir, update.FilePath)
-		output.Printf("  • %s (%s update)", update.OldUses, update.UpdateType)
-		output.Printf("    → %s", update.NewUses)
-		output.Printf("    in %s", relPath)
+		if update.LineNumber > 0 {
+			relPath += ":" + strconv.Itoa(update.LineNumber)
+		}
+		table.AddRow(update.OldUses, update.NewUses, update.UpdateType, relPath)
+		for _, hint := range update.RiskHints {
+			hints = append(hints, update.OldUses+" "+hint)
+		}
 	}
+	output.Table(table)
+	displayRiskHints(output, hints)
 }
 
 // applyUpdates applies the collected updates either automatically or interactively.
@@ -1005,19 +2620,23 @@ func applyUpdates(
 	allUpdates []dependencies.PinnedUpdate,
 	automatic bool,
 ) {
+	files := make([]string, 0, len(allUpdates))
+	for _, update := range allUpdates {
+		files = append(files, update.FilePath)
+	}
+	guardDirtyFiles(output, slices.Compact(slices.Sorted(slices.Values(files))))
+
 	if automatic {
 		output.Info("\n🚀 Applying updates...")
 		if err := analyzer.ApplyPinnedUpdates(allUpdates); err != nil {
+			exitIfInterrupted(analyzer.Context, output)
 			output.Error("Failed to apply updates: %v", err)
-			os.Exit(1)
+			exit(1)
 		}
 		output.Success("✅ Successfully updated %d dependencies with pinned commit SHAs", len(allUpdates))
 	} else {
 		// Interactive mode
-		output.Info("\n❓ This will modify your action.yml files. Continue? (y/N): ")
-		var response string
-		_, _ = fmt.Scanln(&response) // User input, scan error not critical
-		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+		if !confirm(output, "\n❓ This will modify your action.yml files. Continue?", true) {
 			output.Info("Canceled")
 
 			return
@@ -1025,89 +2644,1323 @@ func applyUpdates(
 
 		output.Info("🚀 Applying updates...")
 		if err := analyzer.ApplyPinnedUpdates(allUpdates); err != nil {
+			exitIfInterrupted(analyzer.Context, output)
 			output.Error("Failed to apply updates: %v", err)
-			os.Exit(1)
+			exit(1)
 		}
 		output.Success("✅ Successfully updated %d dependencies", len(allUpdates))
 	}
 }
 
-func depsGraphHandler(_ *cobra.Command, _ []string) {
-	output := createOutputManager(globalConfig.Quiet)
-	output.Bold("Dependency Graph:")
-	output.Info("Generating visual dependency graph...")
-	output.Printf("This feature is not yet implemented\n")
-}
-
-func cacheClearHandler(_ *cobra.Command, _ []string) {
+func depsTUIHandler(_ *cobra.Command, _ []string) {
 	output := createOutputManager(globalConfig.Quiet)
-	output.Info("Clearing dependency cache...")
-
-	// Create a cache instance
-	cacheInstance, err := cache.NewCache(cache.DefaultConfig())
+	currentDir, err := helpers.GetCurrentDir()
 	if err != nil {
-		output.Error("Failed to access cache: %v", err)
-		os.Exit(1)
+		output.Error("Error getting current directory: %v", err)
+		exit(1)
+	}
+	if nonInteractive || !internal.IsTerminal(os.Stdin) || !internal.IsTerminal(os.Stdout) {
+		output.Error("deps tui needs an interactive terminal, use deps upgrade --ci to update without prompts")
+		exit(1)
 	}
 
-	if err := cacheInstance.Clear(); err != nil {
-		output.Error("Failed to clear cache: %v", err)
-		os.Exit(1)
+	analyzer, actionFiles := setupDepsUpgrade(output, currentDir)
+	if analyzer == nil || len(actionFiles) == 0 {
+		return
 	}
 
-	output.Success("Cache cleared successfully")
-}
+	entries := collectBrowserEntries(output, analyzer, actionFiles)
+	if len(entries) == 0 {
+		output.Info("No action dependencies found")
 
-func cacheStatsHandler(_ *cobra.Command, _ []string) {
-	output := createOutputManager(globalConfig.Quiet)
+		return
+	}
 
-	// Create a cache instance
-	cacheInstance, err := cache.NewCache(cache.DefaultConfig())
+	restore, err := internal.MakeRaw(os.Stdin)
 	if err != nil {
-		output.Error("Failed to access cache: %v", err)
-		os.Exit(1)
+		output.Error("%v", err)
+		exit(1)
+	}
+	browser := internal.NewDependencyBrowser(entries, os.Stdin, os.Stdout)
+	browser.Root = currentDir
+	browser.ReleaseNotes = analyzer.ReleaseNotes
+	browser.Size = func() (int, int) { return internal.TerminalSize(os.Stdout) }
+	selection, err := browser.Run()
+	restore()
+	if err != nil {
+		output.Error("%v", err)
+		exit(1)
+	}
+	if len(selection) == 0 {
+		return
 	}
 
-	stats := cacheInstance.Stats()
-
-	output.Bold("Cache Statistics:")
-	output.Printf("Cache location: %s\n", stats["cache_dir"])
-	output.Printf("Total entries: %d\n", stats["total_entries"])
-	output.Printf("Expired entries: %d\n", stats["expired_count"])
+	updates := browserUpdates(output, analyzer, selection)
+	if len(updates) == 0 {
+		return
+	}
+	showPendingUpdates(output, updates, currentDir)
+	if !assumeYes {
+		confirmed, err := internal.Confirm(newPrompter(), "\n❓ This will modify your action.yml files. Continue?", false)
+		if err != nil || !confirmed {
+			output.Info("Canceled")
 
-	// Format size nicely
+			return
+		}
+	}
+	applyUpdates(output, analyzer, updates, true)
+}
+
+// collectBrowserEntries analyzes the action dependencies of the action files
+// and the updates available for them.
+func collectBrowserEntries(
+	output *internal.ColoredOutput,
+	analyzer *dependencies.Analyzer,
+	actionFiles []string,
+) []internal.BrowserEntry {
+	var entries []internal.BrowserEntry
+	var failures []string
+
+	progressMgr := internal.NewProgressBarManager(output.IsQuiet())
+	progressMgr.ProcessWithProgressBar(
+		"Checking dependencies",
+		actionFiles,
+		func(actionFile string, _ *progressbar.ProgressBar) {
+			deps, err := analyzer.AnalyzeActionFile(actionFile)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("Error analyzing %s: %v", actionFile, err))
+
+				return
+			}
+			outdated, _ := analyzer.CheckOutdated(deps)
+			updates := make(map[int]*dependencies.OutdatedDependency, len(outdated))
+			for i := range outdated {
+				updates[outdated[i].Current.StepNumber] = &outdated[i]
+			}
+			for _, dep := range deps {
+				if dep.IsShellScript {
+					continue
+				}
+				entries = append(entries, internal.BrowserEntry{
+					File: actionFile, Dependency: dep, Update: updates[dep.StepNumber],
+				})
+			}
+		},
+	)
+	for _, failure := range failures {
+		output.Warning("%s", failure)
+	}
+
+	return entries
+}
+
+// browserUpdates generates the pinned updates of the entries selected in the
+// dependency browser: outdated dependencies move to the latest version and
+// floating ones are pinned at their current version.
+func browserUpdates(
+	output *internal.ColoredOutput,
+	analyzer *dependencies.Analyzer,
+	selection []internal.BrowserEntry,
+) []dependencies.PinnedUpdate {
+	var updates []dependencies.PinnedUpdate
+	for _, entry := range selection {
+		version, sha := entry.Dependency.Version, ""
+		var hints []string
+		if entry.Update != nil {
+			version, sha, hints = entry.Update.LatestVersion, entry.Update.LatestSHA, entry.Update.RiskHints
+		} else {
+			sha = analyzer.ResolveSHA(entry.Dependency)
+		}
+
+		update, err := analyzer.GeneratePinnedUpdate(entry.File, entry.Dependency, version, sha)
+		if err != nil {
+			output.Warning("Error generating update for %s: %v", entry.Dependency.Name, err)
+
+			continue
+		}
+		update.RiskHints = hints
+		updates = append(updates, *update)
+	}
+
+	return updates
+}
+
+func depsGraphHandler(_ *cobra.Command, _ []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	output.Bold("Dependency Graph:")
+	output.Info("Generating visual dependency graph...")
+	output.Printf("This feature is not yet implemented\n")
+}
+
+func cacheClearHandler(_ *cobra.Command, _ []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	output.Info("Clearing dependency cache...")
+
+	// Create a cache instance
+	cacheInstance, err := cache.NewCache(cache.DefaultConfig())
+	if err != nil {
+		output.Error("Failed to access cache: %v", err)
+		exit(1)
+	}
+
+	if err := cacheInstance.Clear(); err != nil {
+		output.Error("Failed to clear cache: %v", err)
+		exit(1)
+	}
+
+	output.Success("Cache cleared successfully")
+}
+
+func cacheStatsHandler(_ *cobra.Command, _ []string) {
+	output := createOutputManager(globalConfig.Quiet)
+
+	// Create a cache instance
+	cacheInstance, err := cache.NewCache(cache.DefaultConfig())
+	if err != nil {
+		output.Error("Failed to access cache: %v", err)
+		exit(1)
+	}
+
+	stats := cacheInstance.Stats()
+
+	output.Bold("Cache Statistics:")
+	output.Printf("Cache location: %s\n", stats["cache_dir"])
+	totalEntries, _ := stats["total_entries"].(int)
+	expiredCount, _ := stats["expired_count"].(int)
+	output.Printf("Total entries: %s\n", locale.Current().Int(totalEntries))
+	output.Printf("Expired entries: %s\n", locale.Current().Int(expiredCount))
+
+	// Format size nicely
 	totalSize, ok := stats["total_size"].(int64)
 	if !ok {
 		totalSize = 0
 	}
-	sizeStr := formatSize(totalSize)
-	output.Printf("Total size: %s\n", sizeStr)
+	sizeStr := formatSize(totalSize)
+	output.Printf("Total size: %s\n", sizeStr)
+}
+
+func cacheWarmHandler(cmd *cobra.Command, _ []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	if !validateGitHubToken(output) {
+		exit(1)
+	}
+
+	generator := internal.NewGenerator(globalConfig)
+	analyzer := createAnalyzer(generator, output)
+	if analyzer == nil {
+		exit(1)
+	}
+
+	deps := cacheWarmTargets(cmd, output, generator, analyzer)
+	if len(deps) == 0 {
+		output.Warning("No dependencies to warm")
+
+		return
+	}
+
+	ttl, _ := cmd.Flags().GetDuration("ttl")
+	output.Bold("Warming cache for %d dependencies...", len(deps))
+	warmed, failures, err := analyzer.WarmCache(deps, ttl)
+	if closeErr := analyzer.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to save cache: %w", closeErr)
+	}
+	if err != nil {
+		output.Error("Failed to warm cache: %v", err)
+		exit(1)
+	}
+
+	for _, failure := range failures {
+		output.Warning("Could not fetch %s: %v", failure.Uses, failure.Err)
+	}
+	output.Success("Cached metadata for %d repositories, valid for %s", warmed, ttl)
+}
+
+// cacheWarmTargets returns the dependencies named with --deps and, with
+// --from-files or without --deps, those of the action files in the current directory.
+func cacheWarmTargets(
+	cmd *cobra.Command,
+	output *internal.ColoredOutput,
+	generator *internal.Generator,
+	analyzer *dependencies.Analyzer,
+) []dependencies.Dependency {
+	named, _ := cmd.Flags().GetStringSlice("deps")
+	fromFiles, _ := cmd.Flags().GetBool("from-files")
+
+	deps := make([]dependencies.Dependency, 0, len(named))
+	for _, uses := range named {
+		deps = append(deps, dependencies.Dependency{Name: uses, Uses: strings.TrimSpace(uses)})
+	}
+	if len(named) > 0 && !fromFiles {
+		return deps
+	}
+
+	currentDir, err := helpers.GetCurrentDir()
+	if err != nil {
+		output.Error("Error getting current directory: %v", err)
+		exit(1)
+	}
+	actionFiles, err := generator.DiscoverActionFiles(currentDir, true)
+	if err != nil {
+		output.Error("Error discovering action files: %v", err)
+		exit(1)
+	}
+	for _, actionFile := range actionFiles {
+		fileDeps, err := analyzer.AnalyzeActionFile(actionFile)
+		if err != nil {
+			output.Warning("Error analyzing %s: %v", actionFile, err)
+
+			continue
+		}
+		deps = append(deps, fileDeps...)
+	}
+
+	return deps
+}
+
+func metadataExportHandler(cmd *cobra.Command, _ []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	outputFile, _ := cmd.Flags().GetString("output")
+	if !validateGitHubToken(output) {
+		exit(1)
+	}
+
+	generator := internal.NewGenerator(globalConfig)
+	analyzer := createAnalyzer(generator, output)
+	if analyzer == nil {
+		exit(1)
+	}
+
+	deps := cacheWarmTargets(cmd, output, generator, analyzer)
+	if len(deps) == 0 {
+		output.Warning("No dependencies to export")
+
+		return
+	}
+
+	output.Bold("Exporting metadata of %d dependencies...", len(deps))
+	bundle, failures, err := analyzer.ExportMetadata(deps, clock.Now())
+	if closeErr := analyzer.Close(); err == nil && closeErr != nil {
+		output.Warning("Failed to save cache: %v", closeErr)
+	}
+	if err != nil {
+		output.Error("Failed to export metadata: %v", err)
+		exit(1)
+	}
+	for _, failure := range failures {
+		output.Warning("Could not fetch %s: %v", failure.Uses, failure.Err)
+	}
+
+	if err := internal.WriteMetadataBundle(outputFile, bundle); err != nil {
+		output.Error("%v", err)
+		exit(1)
+	}
+	output.Success("Exported metadata of %d repositories to %s", len(bundle.Repositories), outputFile)
+}
+
+func metadataImportHandler(_ *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	snapshotFile, err := internal.MetadataSnapshotFile()
+	if err != nil {
+		output.Error("%v", err)
+		exit(1)
+	}
+
+	bundle, err := internal.ImportMetadataBundle(args[0], snapshotFile)
+	if err != nil {
+		output.Error("Failed to import metadata: %v", err)
+		exit(1)
+	}
+	output.Success("Imported metadata of %d repositories exported on %s",
+		len(bundle.Repositories), bundle.CreatedAt.Format(time.DateOnly))
+	output.Info("Snapshot: %s", snapshotFile)
+	if globalConfig.GitHubLookups() {
+		output.Info("The snapshot is only read when no GitHub token is configured or enrichment is disabled")
+	}
+}
+
+func cachePathHandler(_ *cobra.Command, _ []string) {
+	output := createOutputManager(globalConfig.Quiet)
+
+	// Create a cache instance
+	cacheInstance, err := cache.NewCache(cache.DefaultConfig())
+	if err != nil {
+		output.Error("Failed to access cache: %v", err)
+		exit(1)
+	}
+
+	stats := cacheInstance.Stats()
+	cachePath, ok := stats["cache_dir"].(string)
+	if !ok {
+		cachePath = "unknown"
+	}
+
+	output.Bold("Cache Directory:")
+	output.Printf("%s\n", cachePath)
+
+	// Check if directory exists
+	if _, err := os.Stat(cachePath); err == nil {
+		output.Success("Directory exists")
+	} else if os.IsNotExist(err) {
+		output.Warning("Directory does not exist (will be created on first use)")
+	}
+}
+
+func newReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report [directory_or_file]",
+		Short: "Report analysis results for GitHub Action files.",
+		Long: `Report analysis results for GitHub Actions.
+
+Examples:
+	gh-action-readme report --metrics                   # Metrics for every action below the current directory
+	gh-action-readme report --metrics actions/build/    # Metrics for a specific directory
+	gh-action-readme report --metrics --json            # Machine-readable output
+	gh-action-readme report drift --format json         # Drift summary for dashboards`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  reportHandler,
+	}
+
+	cmd.Flags().Bool("metrics", false, "report size and complexity metrics per action")
+	cmd.Flags().Bool("json", false, "print the report as JSON")
+	cmd.Flags().BoolP("recursive", "r", true, "search for action.yml files recursively")
+
+	driftCmd := &cobra.Command{
+		Use:   "drift [directory_or_file]",
+		Short: "Report documentation, pinning, dependency and validation drift per action",
+		Long: `Report per action whether the generated documentation is up to date, whether
+dependencies are pinned, how many dependencies are outdated and whether validation passes.
+Outdated dependencies are only checked when a GitHub token is configured.
+
+The JSON format carries a schema_version field and is meant to be published as a CI
+artifact and collected into dashboards.
+
+Examples:
+	gh-action-readme report drift                          # Table for every action below the current directory
+	gh-action-readme report drift --format json > drift.json
+	gh-action-readme report drift --notify                 # Also post a summary to Slack or Teams`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  driftReportHandler,
+	}
+	driftCmd.Flags().String("format", "text", "output format: text, json")
+	driftCmd.Flags().Bool("notify", false, "post a summary to the configured Slack and Teams webhooks")
+	driftCmd.Flags().BoolP("recursive", "r", true, "search for action.yml files recursively")
+	cmd.AddCommand(driftCmd)
+
+	trendCmd := &cobra.Command{
+		Use:   "trend [directory]",
+		Short: "Show how outdated, unpinned and validation counts changed over recorded runs",
+		Long: `Show the summary metrics recorded by report drift, deps outdated, deps security and
+deps policy check in the XDG state directory, so teams can see whether dependency and
+documentation hygiene is improving. Runs are recorded per repository unless trends.disabled is set.
+
+Examples:
+	gh-action-readme report trend                      # Table of the recent runs and the change per metric
+	gh-action-readme report trend --format sparkline   # One sparkline per metric
+	gh-action-readme report trend --format json --limit 0`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  trendReportHandler,
+	}
+	trendCmd.Flags().String("format", "table", "output format: table, sparkline, json")
+	trendCmd.Flags().Int("limit", defaultTrendLimit, "number of most recent runs to show, 0 for all")
+	cmd.AddCommand(trendCmd)
+
+	return cmd
+}
+
+// actionMetricsReport is a metrics report entry for one action file.
+type actionMetricsReport struct {
+	File    string                 `json:"file"`
+	Name    string                 `json:"name"`
+	Metrics internal.ActionMetrics `json:"metrics"`
+}
+
+func reportHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+
+	if metrics, _ := cmd.Flags().GetBool("metrics"); !metrics {
+		output.Error("No report selected. Use --metrics or the drift subcommand")
+		exit(1)
+	}
+
+	workingDir, actionFiles := resolveActionTargets(cmd, args, output, "metrics report")
+
+	reports := make([]actionMetricsReport, 0, len(actionFiles))
+	for _, actionFile := range actionFiles {
+		action, err := internal.ParseActionYML(actionFile)
+		if err != nil {
+			output.Warning("Skipping %s: %v", actionFile, err)
+
+			continue
+		}
+		relPath, err := filepath.Rel(workingDir, actionFile)
+		if err != nil {
+			relPath = actionFile
+		}
+		reports = append(reports, actionMetricsReport{
+			File:    relPath,
+			Name:    action.Name,
+			Metrics: internal.ComputeMetrics(action),
+		})
+	}
+
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			output.Error("Failed to encode report: %v", err)
+			exit(1)
+		}
+		fmt.Println(string(data))
+
+		return
+	}
+
+	displayMetricsReport(output, reports)
+}
+
+func driftReportHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+
+	format, _ := cmd.Flags().GetString("format")
+	if format != "text" && format != formatJSON {
+		output.Error("Invalid format '%s', must be one of: text, json", format)
+		exit(1)
+	}
+
+	notify, _ := cmd.Flags().GetBool("notify")
+	workingDir, actionFiles := resolveActionTargets(cmd, args, output, "drift report")
+	config := loadGenConfig(helpers.FindGitRepoRoot(workingDir), workingDir)
+	applyGlobalFlags(config)
+	generator := internal.NewGenerator(config)
+
+	var analyzer *dependencies.Analyzer
+	if config.GitHubLookups() {
+		analyzer, _ = generator.CreateDependencyAnalyzer()
+	}
+
+	report, err := generator.DriftReport(actionFiles, workingDir, analyzer)
+	if err != nil {
+		output.Error("Failed to build drift report: %v", err)
+		exit(1)
+	}
+	recordTrend(output, config, workingDir, "report drift", internal.DriftTrendMetrics(report))
+
+	if format == formatJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			output.Error("Failed to encode report: %v", err)
+			exit(1)
+		}
+		fmt.Println(string(data))
+		if notify {
+			// Keep the notification result out of the JSON on stdout
+			sendNotification(createOutputManager(true), config, internal.DriftNotification(config.Notify, report))
+		}
+
+		return
+	}
+
+	displayDriftReport(output, report)
+	if notify {
+		sendNotification(output, config, internal.DriftNotification(config.Notify, report))
+	}
+}
+
+// sendNotification posts notification to the webhooks of config and exits
+// when none is configured or posting fails.
+func sendNotification(output *internal.ColoredOutput, config *internal.AppConfig, notification internal.Notification) {
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+
+	if err := internal.SendNotification(ctx, nil, config.Notify, notification); err != nil {
+		output.Error("Failed to send notification: %v", err)
+		exit(1)
+	}
+	output.Success("Notification sent")
+}
+
+// recordTrend appends the metrics of a run of command to the history of the
+// repository containing dir, unless trends are disabled. Failures only warn.
+func recordTrend(
+	output *internal.ColoredOutput,
+	config *internal.AppConfig,
+	dir, command string,
+	metrics map[string]int,
+) {
+	if config.Trends.Disabled {
+		return
+	}
+	path, err := internal.TrendFile(cmp.Or(helpers.FindGitRepoRoot(dir), dir))
+	if err == nil {
+		entry := internal.TrendEntry{Time: clock.Now().UTC().Truncate(time.Second), Command: command, Metrics: metrics}
+		err = internal.RecordTrend(path, entry, config.Trends.MaxEntries)
+	}
+	if err != nil {
+		output.Warning("Could not record trend: %v", err)
+	}
+}
+
+// trendReport is the JSON output of report trend.
+type trendReport struct {
+	Project string                 `json:"project"`
+	Entries []internal.TrendEntry  `json:"entries"`
+	Metrics []internal.TrendMetric `json:"metrics"`
+}
+
+func trendReportHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	format, _ := cmd.Flags().GetString("format")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if format != "table" && format != "sparkline" && format != formatJSON {
+		output.Error("Invalid format '%s', must be one of: table, sparkline, json", format)
+		exit(1)
+	}
+
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	project, err := filepath.Abs(dir)
+	if err != nil {
+		output.Error("Error resolving path %s: %v", dir, err)
+		exit(1)
+	}
+	project = cmp.Or(helpers.FindGitRepoRoot(project), project)
+	path, err := internal.TrendFile(project)
+	if err != nil {
+		output.Error("%v", err)
+		exit(1)
+	}
+	entries, err := internal.LoadTrend(path)
+	if err != nil {
+		output.Error("%v", err)
+		exit(1)
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	report := trendReport{Project: project, Entries: entries, Metrics: internal.SummarizeTrend(entries)}
+	if report.Entries == nil {
+		report.Entries = []internal.TrendEntry{}
+	}
+	switch format {
+	case formatJSON:
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			output.Error("Failed to encode report: %v", err)
+			exit(1)
+		}
+		fmt.Println(string(data))
+	case "sparkline":
+		displayTrendSparklines(output, report)
+	default:
+		displayTrendTable(output, report)
+	}
+}
+
+// displayTrendTable prints the recorded runs as a table followed by the change of each metric.
+func displayTrendTable(output *internal.ColoredOutput, report trendReport) {
+	if len(report.Entries) == 0 {
+		output.Info("No runs recorded for %s yet; run report drift or deps outdated first", report.Project)
+
+		return
+	}
+
+	output.Bold("Trend of %d run(s) for %s:", len(report.Entries), report.Project)
+	l := locale.Current()
+	headers := []string{"Time", "Command"}
+	for _, metric := range report.Metrics {
+		headers = append(headers, trendMetricLabel(metric.Name))
+	}
+	table := internal.NewTable(headers...).Indent("  ")
+	for i := range report.Metrics {
+		table.AlignRight(i + 2)
+	}
+	for _, entry := range report.Entries {
+		row := []string{l.DateTime(entry.Time.Local()), entry.Command}
+		for _, metric := range report.Metrics {
+			value, ok := entry.Metrics[metric.Name]
+			if !ok {
+				row = append(row, "-")
+
+				continue
+			}
+			row = append(row, l.Int(value))
+		}
+		table.AddRow(row...)
+	}
+	output.Table(table)
+	displayTrendChanges(output, report.Metrics)
+}
+
+// displayTrendSparklines prints a sparkline per metric.
+func displayTrendSparklines(output *internal.ColoredOutput, report trendReport) {
+	if len(report.Metrics) == 0 {
+		output.Info("No runs recorded for %s yet; run report drift or deps outdated first", report.Project)
+
+		return
+	}
+
+	output.Bold("Trend of %d run(s) for %s:", len(report.Entries), report.Project)
+	l := locale.Current()
+	table := internal.NewTable("Metric", "Trend", "First", "Last", "Change").Indent("  ").AlignRight(2, 3, 4)
+	for _, metric := range report.Metrics {
+		table.AddRow(trendMetricLabel(metric.Name), internal.Sparkline(metric.Values),
+			l.Int(metric.First), l.Int(metric.Last), formatTrendChange(metric.Change))
+	}
+	output.Table(table)
+}
+
+// displayTrendChanges prints whether each metric improved between the first and last run.
+func displayTrendChanges(output *internal.ColoredOutput, metrics []internal.TrendMetric) {
+	for _, metric := range metrics {
+		if metric.Name == internal.TrendActions {
+			continue
+		}
+		label, change := trendMetricLabel(metric.Name), formatTrendChange(metric.Change)
+		switch {
+		case metric.Change < 0:
+			output.Success("%s improved: %d → %d (%s)", label, metric.First, metric.Last, change)
+		case metric.Change > 0:
+			output.Warning("%s worsened: %d → %d (%s)", label, metric.First, metric.Last, change)
+		default:
+			output.Info("%s unchanged at %d", label, metric.Last)
+		}
+	}
+}
+
+// trendMetricLabel returns the column label of a trend metric.
+func trendMetricLabel(name string) string {
+	label := strings.ReplaceAll(name, "_", " ")
+
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// formatTrendChange formats a change with its sign.
+func formatTrendChange(change int) string {
+	if change > 0 {
+		return "+" + locale.Current().Int(change)
+	}
+
+	return locale.Current().Int(change)
+}
+
+// displayDriftReport prints the drift report as a table followed by the totals.
+func displayDriftReport(output *internal.ColoredOutput, report *internal.DriftReport) {
+	output.Bold("Drift report for %d action(s):", report.Summary.Actions)
+	table := internal.NewTable("File", "Docs", "Pinned", "Outdated", "Validation", "Owners").Indent("  ")
+	for _, action := range report.Actions {
+		pins := locale.Current().Int(action.Pins.Pinned) + "/" + locale.Current().Int(action.Pins.Total)
+		if action.Pins.Error != "" {
+			pins = "error"
+		}
+		outdated := "-"
+		if action.Outdated != nil {
+			outdated = locale.Current().Int(action.Outdated.Total)
+		}
+		owners := "-"
+		if len(action.Owners) > 0 {
+			owners = strings.Join(action.Owners, " ")
+		}
+		table.AddRow(action.File, action.Docs.Status, pins, outdated, action.Validation.Status, owners)
+	}
+	output.Table(table)
+
+	summary, l := report.Summary, locale.Current()
+	output.Info("Stale docs: %s, unpinned: %s, outdated dependencies: %s, validation failures: %s, unowned: %s",
+		l.Int(summary.StaleDocs), l.Int(summary.NonCompliantPins), l.Int(summary.OutdatedDeps),
+		l.Int(summary.ValidationFailures), l.Int(summary.Unowned))
+}
+
+// displayMetricsReport prints the metrics report as a table.
+func displayMetricsReport(output *internal.ColoredOutput, reports []actionMetricsReport) {
+	output.Bold("Action metrics for %d file(s):", len(reports))
+	table := internal.NewTable(
+		"File", "Runtime", "Inputs", "Required", "Outputs", "Steps", "Deps", "Run LOC", "Cold start",
+	).Indent("  ").AlignRight(2, 3, 4, 5, 6, 7)
+	l := locale.Current()
+	for _, report := range reports {
+		m := report.Metrics
+		table.AddRow(
+			report.File,
+			m.Runtime,
+			l.Int(m.Inputs),
+			l.Int(m.RequiredInputs),
+			l.Int(m.Outputs),
+			l.Int(m.Steps),
+			l.Int(m.ExternalDependencies),
+			l.Int(m.RunLines),
+			m.ColdStart+" ("+m.ColdStartReason+")",
+		)
+	}
+	output.Table(table)
+}
+
+func newOrgCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "org",
+		Short: "Organization-wide action analytics",
+		Long:  "Analyze how actions are used across a GitHub organization.",
+	}
+
+	consumersCmd := &cobra.Command{
+		Use:   "consumers <organization>",
+		Short: "Find workflows in an organization that use an action",
+		Long: `Search an organization's workflows with the GitHub code search API and report
+which repositories use the given action and at which versions.
+
+Examples:
+	gh-action-readme org consumers my-org --action my-org/setup-tool
+	gh-action-readme org consumers my-org --action my-org/setup-tool --json`,
+		Args: cobra.ExactArgs(1),
+		Run:  orgConsumersHandler,
+	}
+	consumersCmd.Flags().String("action", "", "action to search for, in owner/repo format")
+	consumersCmd.Flags().Bool("json", false, "print the consumers as JSON")
+	_ = consumersCmd.MarkFlagRequired("action")
+	cmd.AddCommand(consumersCmd)
+
+	return cmd
+}
+
+// consumersReport is the JSON output of the org consumers command.
+type consumersReport struct {
+	Organization string                   `json:"organization"`
+	Action       string                   `json:"action"`
+	Owners       []string                 `json:"owners"` // CODEOWNERS owners of the action's action.yml
+	Consumers    []consumers.Consumer     `json:"consumers"`
+	Versions     []consumers.VersionUsage `json:"versions"`
+}
+
+func orgConsumersHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	action, _ := cmd.Flags().GetString("action")
+	org := args[0]
+
+	if !validateGitHubToken(output) {
+		exit(1)
+	}
+	client, err := internal.NewGitHubClient(globalConfig.GitHubToken)
+	if err != nil {
+		output.Error("Failed to create GitHub client: %v", err)
+		exit(1)
+	}
+
+	interrupt, stop := interruptContext()
+	defer stop()
+	ctx, cancel := context.WithTimeout(interrupt, orgSearchTimeout)
+	defer cancel()
+
+	finder := consumers.NewFinder(client.Client)
+	found, err := finder.Find(ctx, org, action)
+	if err != nil {
+		exitIfInterrupted(interrupt, output)
+		output.Error("Failed to find consumers: %v", err)
+		exit(1)
+	}
+
+	asJSON, _ := cmd.Flags().GetBool("json")
+	owners, err := finder.Owners(ctx, action)
+	if err != nil && !asJSON {
+		output.Warning("Could not read CODEOWNERS of %s: %v", action, err)
+	}
+
+	report := consumersReport{
+		Organization: org,
+		Action:       action,
+		Owners:       owners,
+		Consumers:    found,
+		Versions:     consumers.GroupByRef(found),
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			output.Error("Failed to encode report: %v", err)
+			exit(1)
+		}
+		fmt.Println(string(data))
+
+		return
+	}
+
+	displayConsumersReport(output, report)
+}
+
+// displayConsumersReport prints consumers grouped by version followed by each usage.
+func displayConsumersReport(output *internal.ColoredOutput, report consumersReport) {
+	if len(report.Owners) > 0 {
+		output.Info("Maintainers of %s: %s", report.Action, strings.Join(report.Owners, ", "))
+	}
+	if len(report.Consumers) == 0 {
+		output.Info("No workflows in %s use %s", report.Organization, report.Action)
+
+		return
+	}
+
+	output.Bold("%s is used %d time(s) in %s:", report.Action, len(report.Consumers), report.Organization)
+	versions := internal.NewTable("Version", "Repositories").Indent("  ")
+	for _, usage := range report.Versions {
+		versions.AddRow(usage.Ref, strings.Join(usage.Repositories, ", "))
+	}
+	output.Table(versions)
+
+	output.Printf("\n")
+	usages := internal.NewTable("Repository", "Workflow", "Uses").Indent("  ")
+	for _, consumer := range report.Consumers {
+		usages.AddRow(consumer.Repository, consumer.Workflow+":"+strconv.Itoa(consumer.Line), consumer.Uses)
+	}
+	output.Table(usages)
+}
+
+func newCompatCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compat <old-ref> [new-ref]",
+		Short: "Detect breaking changes between two versions of an action",
+		Long: `Compare the interface of an action between two git refs and classify the change
+as major, minor or patch. When new-ref is omitted the working tree is used.
+
+Examples:
+	gh-action-readme compat v1.2.0 v2.0.0              # Compare two tags
+	gh-action-readme compat --against v1.2.0           # Compare a tag with the working tree
+	gh-action-readme compat v1.2.0 HEAD --markdown     # Release notes section`,
+		Args: cobra.MaximumNArgs(2),
+		Run:  compatHandler,
+	}
+
+	cmd.Flags().String("against", "", "git ref to compare the working tree against")
+	cmd.Flags().StringP("file", "f", "action.yml", "path to the action file")
+	cmd.Flags().Bool("json", false, "print the diff as JSON")
+	cmd.Flags().Bool("markdown", false, "print the diff as a markdown release-notes section")
+
+	return cmd
+}
+
+func compatHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	oldRef, newRef := resolveCompatRefs(cmd, args, output)
+	actionPath, _ := cmd.Flags().GetString("file")
+
+	absPath, repoRoot := resolveRepoActionFile(actionPath, output)
+
+	oldAction, err := internal.LoadActionAtRef(repoRoot, oldRef, absPath)
+	if err != nil {
+		output.Error("Failed to load old version: %v", err)
+		exit(1)
+	}
+	newAction, err := internal.LoadActionAtRef(repoRoot, newRef, absPath)
+	if err != nil {
+		output.Error("Failed to load new version: %v", err)
+		exit(1)
+	}
+
+	diff := internal.DiffInterfaces(oldAction, newAction)
+	asJSON, _ := cmd.Flags().GetBool("json")
+	asMarkdown, _ := cmd.Flags().GetBool("markdown")
+	switch {
+	case asJSON:
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			output.Error("Failed to encode diff: %v", err)
+			exit(1)
+		}
+		fmt.Println(string(data))
+	case asMarkdown:
+		fmt.Println(diff.Markdown())
+	default:
+		displayInterfaceDiff(output, diff, oldRef, newRef)
+	}
+}
+
+// resolveRepoActionFile returns the absolute path of an action file and the root
+// of the git repository containing it.
+func resolveRepoActionFile(actionPath string, output *internal.ColoredOutput) (string, string) {
+	absPath, err := filepath.Abs(actionPath)
+	if err != nil {
+		output.Error("Error resolving path %s: %v", actionPath, err)
+		exit(1)
+	}
+	repoRoot := helpers.FindGitRepoRoot(filepath.Dir(absPath))
+	if repoRoot == "" {
+		output.Error("%s is not inside a git repository", actionPath)
+		exit(1)
+	}
+
+	return absPath, repoRoot
+}
+
+// resolveCompatRefs returns the old and new refs to compare. An empty new ref
+// means the working tree.
+func resolveCompatRefs(cmd *cobra.Command, args []string, output *internal.ColoredOutput) (string, string) {
+	against, _ := cmd.Flags().GetString("against")
+	switch {
+	case against != "" && len(args) > 0:
+		output.Error("Use either --against or <old-ref> [new-ref], not both")
+		exit(1)
+	case against != "":
+		return against, ""
+	case len(args) == 0:
+		output.Error("Specify <old-ref> [new-ref] or --against <ref>")
+		exit(1)
+	case len(args) == 1:
+		return args[0], ""
+	}
+
+	return args[0], args[1]
+}
+
+// displayInterfaceDiff prints the interface changes as a table.
+func displayInterfaceDiff(output *internal.ColoredOutput, diff *internal.InterfaceDiff, oldRef, newRef string) {
+	if newRef == "" {
+		newRef = "working tree"
+	}
+	if len(diff.Changes) == 0 {
+		output.Success("No interface changes between %s and %s", oldRef, newRef)
+
+		return
+	}
+
+	output.Bold("Interface changes between %s and %s:", oldRef, newRef)
+	table := internal.NewTable("Level", "Change").Indent("  ")
+	for _, change := range diff.Changes {
+		table.AddRow(string(change.Level), change.Message)
+	}
+	output.Table(table)
+
+	if diff.Level == internal.ChangeMajor {
+		output.Warning("Suggested version bump: %s (%d breaking change(s))", diff.Level, len(diff.Breaking()))
+	} else {
+		output.Info("Suggested version bump: %s", diff.Level)
+	}
 }
 
-func cachePathHandler(_ *cobra.Command, _ []string) {
+func newReleaseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release",
+		Short: "Release helpers for the action itself",
+		Long:  "Helpers for versioning and releasing the action in the current repository.",
+	}
+
+	suggestCmd := &cobra.Command{
+		Use:   "suggest",
+		Short: "Suggest the next version from changes since the last tag",
+		Long: `Inspect interface changes and conventional commit messages since the last tag
+and recommend the next semantic version.
+
+Examples:
+	gh-action-readme release suggest                   # Compare the latest tag with HEAD
+	gh-action-readme release suggest --from v1.4.0     # Compare a specific tag with HEAD
+	gh-action-readme release suggest --summary         # Conventional commit message for the release PR`,
+		Args: cobra.NoArgs,
+		Run:  releaseSuggestHandler,
+	}
+	suggestCmd.Flags().String("from", "", "ref of the previous release (default: latest tag)")
+	suggestCmd.Flags().String("to", "HEAD", "ref of the upcoming release")
+	suggestCmd.Flags().StringP("file", "f", "action.yml", "path to the action file")
+	suggestCmd.Flags().Bool("summary", false, "print a conventional commit summary for the release PR")
+	suggestCmd.Flags().Bool("json", false, "print the suggestion as JSON")
+	cmd.AddCommand(suggestCmd)
+
+	notesCmd := &cobra.Command{
+		Use:   "notes",
+		Short: "Generate markdown release notes for the action",
+		Long: `Combine commit history, interface changes and dependency updates between two
+refs into markdown release notes, styled by the selected theme.
+
+Examples:
+	gh-action-readme release notes                         # Latest tag to HEAD
+	gh-action-readme release notes --from v1.0.0 --to v1.1.0
+	gh-action-readme release notes --theme github -o RELEASE_NOTES.md`,
+		Args: cobra.NoArgs,
+		Run:  releaseNotesHandler,
+	}
+	notesCmd.Flags().String("from", "", "ref of the previous release (default: latest tag)")
+	notesCmd.Flags().String("to", "HEAD", "ref of the upcoming release")
+	notesCmd.Flags().StringP("file", "f", "action.yml", "path to the action file")
+	notesCmd.Flags().StringP("theme", "t", "", "theme used to style the notes (default: configured theme)")
+	notesCmd.Flags().String("template", "", "custom release notes template")
+	notesCmd.Flags().StringP("output", "o", "", "write the notes to a file instead of stdout")
+	cmd.AddCommand(notesCmd)
+
+	announceCmd := &cobra.Command{
+		Use:   "announce",
+		Short: "Generate a release announcement for GitHub Discussions or the release description",
+		Long: `Combine interface changes, dependency updates and the CHANGELOG.md section of the
+release, or its commits when there is none, into a markdown announcement. With --post the
+announcement replaces the description of the GitHub release of --to, or is posted as a
+discussion with --target discussion.
+
+Examples:
+	gh-action-readme release announce                               # Latest tag to HEAD, printed
+	gh-action-readme release announce --to v1.2.0 --post            # Fill in the v1.2.0 release description
+	gh-action-readme release announce --to v1.2.0 --post --target discussion --category Announcements`,
+		Args: cobra.NoArgs,
+		Run:  releaseAnnounceHandler,
+	}
+	announceCmd.Flags().String("from", "", "ref of the previous release (default: latest tag)")
+	announceCmd.Flags().String("to", "HEAD", "ref of the release, its tag when posting")
+	announceCmd.Flags().StringP("file", "f", "action.yml", "path to the action file")
+	announceCmd.Flags().String("template", "", "custom announcement template")
+	announceCmd.Flags().StringP("output", "o", "", "write the announcement to a file instead of stdout")
+	announceCmd.Flags().Bool("post", false, "post the announcement with the GitHub API")
+	announceCmd.Flags().String("target", internal.AnnounceTargetRelease, "where to post: release or discussion")
+	announceCmd.Flags().String("category", internal.DefaultAnnouncementCategory,
+		"discussion category used with --target discussion")
+	cmd.AddCommand(announceCmd)
+
+	return cmd
+}
+
+func releaseSuggestHandler(cmd *cobra.Command, _ []string) {
 	output := createOutputManager(globalConfig.Quiet)
+	actionPath, _ := cmd.Flags().GetString("file")
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
 
-	// Create a cache instance
-	cacheInstance, err := cache.NewCache(cache.DefaultConfig())
+	absPath, repoRoot := resolveRepoActionFile(actionPath, output)
+	from = resolvePreviousRelease(repoRoot, from, to, output)
+
+	suggestion, err := internal.SuggestRelease(repoRoot, absPath, from, to)
 	if err != nil {
-		output.Error("Failed to access cache: %v", err)
-		os.Exit(1)
+		output.Error("Failed to suggest release: %v", err)
+		exit(1)
 	}
 
-	stats := cacheInstance.Stats()
-	cachePath, ok := stats["cache_dir"].(string)
-	if !ok {
-		cachePath = "unknown"
+	asJSON, _ := cmd.Flags().GetBool("json")
+	summary, _ := cmd.Flags().GetBool("summary")
+	switch {
+	case asJSON:
+		data, err := json.MarshalIndent(suggestion, "", "  ")
+		if err != nil {
+			output.Error("Failed to encode suggestion: %v", err)
+			exit(1)
+		}
+		fmt.Println(string(data))
+	case summary:
+		fmt.Print(suggestion.ConventionalSummary())
+	default:
+		displayReleaseSuggestion(output, suggestion)
 	}
+}
 
-	output.Bold("Cache Directory:")
-	output.Printf("%s\n", cachePath)
+func releaseNotesHandler(cmd *cobra.Command, _ []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	actionPath, _ := cmd.Flags().GetString("file")
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	theme, _ := cmd.Flags().GetString("theme")
+	templatePath, _ := cmd.Flags().GetString("template")
+	outputPath, _ := cmd.Flags().GetString("output")
+	if theme == "" {
+		theme = globalConfig.Theme
+	}
 
-	// Check if directory exists
-	if _, err := os.Stat(cachePath); err == nil {
-		output.Success("Directory exists")
-	} else if os.IsNotExist(err) {
-		output.Warning("Directory does not exist (will be created on first use)")
+	absPath, repoRoot := resolveRepoActionFile(actionPath, output)
+	from = resolvePreviousRelease(repoRoot, from, to, output)
+
+	data, err := internal.BuildReleaseNotes(repoRoot, absPath, from, to)
+	if err != nil {
+		output.Error("Failed to collect release changes: %v", err)
+		exit(1)
+	}
+	notes, err := internal.RenderReleaseNotes(data, theme, templatePath)
+	if err != nil {
+		output.Error("%v", err)
+		exit(1)
+	}
+
+	if outputPath == "" {
+		fmt.Print(notes)
+
+		return
+	}
+	if err := os.WriteFile(outputPath, []byte(notes), internal.FilePermDefault); err != nil {
+		output.Error("Failed to write %s: %v", outputPath, err)
+		exit(1)
+	}
+	output.Success("Release notes written to %s", outputPath)
+}
+
+func releaseAnnounceHandler(cmd *cobra.Command, _ []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	actionPath, _ := cmd.Flags().GetString("file")
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	templatePath, _ := cmd.Flags().GetString("template")
+	outputPath, _ := cmd.Flags().GetString("output")
+	post, _ := cmd.Flags().GetBool("post")
+
+	absPath, repoRoot := resolveRepoActionFile(actionPath, output)
+	from = resolvePreviousRelease(repoRoot, from, to, output)
+
+	data, err := internal.BuildAnnouncement(repoRoot, absPath, from, to, globalConfig)
+	if err != nil {
+		output.Error("Failed to collect release changes: %v", err)
+		exit(1)
+	}
+	body, err := internal.RenderAnnouncement(data, templatePath)
+	if err != nil {
+		output.Error("%v", err)
+		exit(1)
+	}
+
+	switch {
+	case post:
+		postAnnouncement(cmd, output, data, body)
+	case outputPath == "":
+		fmt.Print(body)
+	default:
+		if err := os.WriteFile(outputPath, []byte(body), internal.FilePermDefault); err != nil {
+			output.Error("Failed to write %s: %v", outputPath, err)
+			exit(1)
+		}
+		output.Success("Announcement written to %s", outputPath)
+	}
+}
+
+// postAnnouncement posts the announcement body to the target given on the command line.
+func postAnnouncement(
+	cmd *cobra.Command,
+	output *internal.ColoredOutput,
+	data *internal.AnnouncementData,
+	body string,
+) {
+	target, _ := cmd.Flags().GetString("target")
+	category, _ := cmd.Flags().GetString("category")
+	if target == internal.AnnounceTargetRelease && data.Version != data.To {
+		output.Error("Posting to a release needs --to set to the release tag, got %s", data.To)
+		exit(1)
+	}
+	if !validateGitHubToken(output) {
+		exit(1)
+	}
+	client, err := internal.NewGitHubClient(globalConfig.GitHubToken)
+	if err != nil {
+		output.Error("Failed to create GitHub client: %v", err)
+		exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), announcePostTimeout)
+	defer cancel()
+
+	url, err := internal.PostAnnouncement(ctx, client.Client, data, body, target, category)
+	if err != nil {
+		output.Error("Failed to post announcement: %v", err)
+		exit(1)
+	}
+	output.Success("Announcement posted: %s", url)
+}
+
+// resolvePreviousRelease returns from, or when from is empty the latest tag
+// reachable from to that is not on to itself. Without such a tag the release
+// is the first one and an empty ref, covering the whole history, is returned.
+func resolvePreviousRelease(repoRoot, from, to string, output *internal.ColoredOutput) string {
+	if from != "" {
+		return from
+	}
+
+	tag, err := git.PreviousTag(repoRoot, to)
+	if err != nil {
+		output.Error("Failed to find the previous release, use --from: %v", err)
+		exit(1)
+	}
+
+	return tag
+}
+
+// displayReleaseSuggestion prints the recommended version and the changes behind it.
+func displayReleaseSuggestion(output *internal.ColoredOutput, suggestion *internal.ReleaseSuggestion) {
+	switch {
+	case suggestion.CurrentVersion == "":
+		output.Bold("Suggested first release: %s", suggestion.NextVersion)
+	case suggestion.Level == internal.ChangeNone:
+		output.Info("No releasable changes since %s", suggestion.CurrentVersion)
+
+		return
+	default:
+		output.Bold("Suggested release: %s → %s (%s)",
+			suggestion.CurrentVersion, suggestion.NextVersion, suggestion.Level)
+	}
+	output.Printf("  Interface changes: %s, commits: %s\n", suggestion.InterfaceLevel, suggestion.CommitLevel)
+
+	if len(suggestion.Interface.Changes) > 0 {
+		output.Printf("\n")
+		table := internal.NewTable("Level", "Change").Indent("  ")
+		for _, change := range suggestion.Interface.Changes {
+			table.AddRow(string(change.Level), change.Message)
+		}
+		output.Table(table)
+	}
+}
+
+func newBenchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "bench",
+		Short:  "Run a synthetic monorepo workload and report throughput",
+		Long:   "Benchmark discovery, parsing, validation and rendering against a generated monorepo.",
+		Hidden: true,
+		Run:    benchHandler,
+	}
+
+	cmd.Flags().Int("actions", 200, "number of synthetic actions to generate")
+	cmd.Flags().Int("iterations", 3, "number of times each stage is repeated")
+	cmd.Flags().StringP("theme", "t", internal.ThemeDefault, "theme used for the render stage")
+	cmd.Flags().StringToString("threshold", map[string]string{},
+		"minimum ops/s per stage, e.g. --threshold parse=500,render=100")
+
+	return cmd
+}
+
+func benchHandler(cmd *cobra.Command, _ []string) {
+	output := createOutputManager(globalConfig.Quiet)
+
+	actions, _ := cmd.Flags().GetInt("actions")
+	iterations, _ := cmd.Flags().GetInt("iterations")
+	theme, _ := cmd.Flags().GetString("theme")
+	rawThresholds, _ := cmd.Flags().GetStringToString("threshold")
+
+	thresholds := make(map[string]float64, len(rawThresholds))
+	for stage, value := range rawThresholds {
+		minimum, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			output.Error("Invalid threshold for %s: %s", stage, value)
+			exit(1)
+		}
+		thresholds[stage] = minimum
+	}
+
+	output.Bold("Benchmarking %d synthetic actions (%d iterations)...", actions, iterations)
+	report, err := internal.RunBenchmark(internal.BenchmarkOptions{
+		Actions:    actions,
+		Iterations: iterations,
+		Theme:      theme,
+	})
+	if err != nil {
+		output.Error("Benchmark failed: %v", err)
+		exit(1)
+	}
+
+	table := internal.NewTable("Stage", "Ops", "Duration", "Ops/s").Indent("  ").AlignRight(1, 2, 3)
+	for _, stage := range report.Stages {
+		table.AddRow(
+			stage.Name,
+			locale.Current().Int(stage.Ops),
+			stage.Duration.Round(time.Microsecond).String(),
+			locale.Current().Float(stage.Throughput(), 1),
+		)
+	}
+	output.Table(table)
+
+	if violations := report.CheckThresholds(thresholds); len(violations) > 0 {
+		output.Error("Performance regression detected:")
+		for _, violation := range violations {
+			output.Error("  %s", violation)
+		}
+		exit(1)
+	}
+
+	if len(thresholds) > 0 {
+		output.Success("All thresholds met")
 	}
 }
 
@@ -1115,11 +3968,15 @@ func configWizardHandler(cmd *cobra.Command, _ []string) {
 	output := createOutputManager(globalConfig.Quiet)
 
 	// Create and run the wizard
-	configWizard := wizard.NewConfigWizard(output)
+	configWizard, err := newConfigWizard(cmd, output)
+	if err != nil {
+		output.Error("Invalid wizard answers: %v", err)
+		exit(1)
+	}
 	config, err := configWizard.Run()
 	if err != nil {
 		output.Error("Wizard failed: %v", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Get export format and output path
@@ -1135,7 +3992,7 @@ func configWizardHandler(cmd *cobra.Command, _ []string) {
 		defaultPath, err := exporter.GetDefaultOutputPath(exportFormat)
 		if err != nil {
 			output.Error("Failed to get default output path: %v", err)
-			os.Exit(1)
+			exit(1)
 		}
 		outputPath = defaultPath
 	}
@@ -1145,9 +4002,324 @@ func configWizardHandler(cmd *cobra.Command, _ []string) {
 
 	if err := exporter.ExportConfig(config, exportFormat, outputPath); err != nil {
 		output.Error("Failed to export configuration: %v", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	output.Info("\n🎉 Configuration wizard completed successfully!")
 	output.Info("You can now use 'gh-action-readme gen' to generate documentation.")
 }
+
+// newConfigWizard creates the interactive wizard, or a non-interactive one when
+// --answers, --non-interactive or --yes is set.
+func newConfigWizard(cmd *cobra.Command, output *internal.ColoredOutput) (*wizard.ConfigWizard, error) {
+	answersPath, _ := cmd.Flags().GetString("answers")
+	if answersPath == "" && !nonInteractive && !assumeYes {
+		return wizard.NewConfigWizard(output, newPrompter()), nil
+	}
+
+	var answers wizard.Answers
+	if answersPath != "" {
+		loaded, err := wizard.LoadAnswers(answersPath)
+		if err != nil {
+			return nil, err
+		}
+		answers = loaded
+	}
+
+	return wizard.NewNonInteractiveWizard(output, wizard.AnswersFromEnv(answers))
+}
+
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve [directory]",
+		Short: "Serve HTML documentation with live-reload",
+		Long: `Serve HTML documentation for the actions in a directory, rendering each page on
+request. Open pages reload when an action file or template changes.
+
+Routes:
+	/                       Index of actions with search
+	/search-index.json      Search index over names, descriptions, inputs and tags
+	/action.schema.json     The action.yml schema, for editors (see schema path)
+	/docs/<path>            HTML documentation, e.g. /docs/build/action.yml
+	/api/actions            Action list as JSON (with --api)
+	/api/actions/<path>     Parsed action metadata as JSON (with --api)
+	/metrics                Prometheus metrics
+
+Examples:
+	gh-action-readme serve                          # Serve actions below the current directory
+	gh-action-readme serve --theme professional     # Preview a theme
+	gh-action-readme serve --addr :9000 --api       # Internal catalog with JSON API`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  serveHandler,
+	}
+
+	cmd.Flags().String("addr", "127.0.0.1:8080", "address to listen on")
+	cmd.Flags().StringP("theme", "t", "", themeFlagUsage)
+	cmd.Flags().Bool("api", false, "serve a read-only JSON API with parsed action metadata")
+	cmd.Flags().Bool("live-reload", true, "reload open pages when action files or templates change")
+	cmd.Flags().BoolP("recursive", "r", true, "search for action.yml files recursively")
+
+	return cmd
+}
+
+func serveHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	workingDir, _ := resolveActionTargets(cmd, args, output, "documentation server")
+
+	config := loadGenConfig(helpers.FindGitRepoRoot(workingDir), workingDir)
+	applyGlobalFlags(config)
+	if theme, _ := cmd.Flags().GetString("theme"); theme != "" {
+		config.Theme = theme
+	}
+
+	srv := server.New(workingDir, internal.NewGenerator(config))
+	srv.Recursive, _ = cmd.Flags().GetBool("recursive")
+	srv.API, _ = cmd.Flags().GetBool("api")
+	srv.LiveReload, _ = cmd.Flags().GetBool("live-reload")
+
+	addr, _ := cmd.Flags().GetString("addr")
+	ctx, stop := interruptContext()
+	defer stop()
+
+	output.Success("Serving documentation for %s at http://%s/", workingDir, addr)
+	if err := srv.ListenAndServe(ctx, addr); err != nil {
+		output.Error("Server failed: %v", err)
+		exit(1)
+	}
+}
+
+func newAdoptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "adopt [directory_or_file]",
+		Short: "Bring a hand-written README under management of the generator",
+		Long: `Analyze an existing hand-written README, find the usage, inputs and outputs
+sections the generator would manage and propose a merged README in which those
+sections are wrapped in marker comments. Missing sections are added and everything
+else is preserved. Once adopted, gen only regenerates the marked sections.
+
+By default the proposal is printed as a diff for review and nothing is written.
+
+Examples:
+	gh-action-readme adopt                                  # ./action.yml and ./README.md
+	gh-action-readme adopt actions/build/                   # Specific action directory
+	gh-action-readme adopt --output README.proposed.md      # Save the proposed README
+	gh-action-readme adopt --write                          # Replace the README with the proposal`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  adoptHandler,
+	}
+
+	cmd.Flags().String("readme", "", "README to adopt (default: the README next to the action file)")
+	cmd.Flags().String("output", "", "write the proposed README to this file")
+	cmd.Flags().Bool("write", false, "replace the README with the proposed version")
+
+	return cmd
+}
+
+func adoptHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	workingDir, actionFiles := resolveActionTargets(cmd, args, output, "README adoption")
+	if len(actionFiles) != 1 {
+		output.Error("Found %d action files, adopt works on one action at a time", len(actionFiles))
+		exit(1)
+	}
+	actionFile := actionFiles[0]
+	config := loadGenConfig(helpers.FindGitRepoRoot(workingDir), workingDir)
+	applyGlobalFlags(config)
+	readmePath, _ := cmd.Flags().GetString("readme")
+	if readmePath == "" {
+		actionDir := filepath.Dir(actionFile)
+		readmeName, _ := internal.ReadmeFilename(actionDir, config.ReadmeFilename)
+		readmePath = filepath.Join(actionDir, readmeName)
+	}
+	generator := internal.NewGenerator(config)
+	result, err := generator.Adopt(actionFile, readmePath)
+	if err != nil {
+		output.Error("Error adopting %s: %v", readmePath, err)
+		exit(1)
+	}
+
+	displayAdoptedSections(output, result)
+	writeAdoptResult(cmd, output, readmePath, result)
+}
+
+// displayAdoptedSections lists the sections the adopted README manages.
+func displayAdoptedSections(output *internal.ColoredOutput, result *internal.AdoptResult) {
+	for _, section := range result.Sections {
+		if section.Added {
+			output.Info("Adding %s section (not found in the README)", section.Name)
+		} else {
+			output.Info("Managing %s section %q at line %d", section.Name, section.Heading, section.Line)
+		}
+	}
+}
+
+// writeAdoptResult writes the proposed README where the flags ask for it and
+// prints the diff when it is not written over the original.
+func writeAdoptResult(
+	cmd *cobra.Command,
+	output *internal.ColoredOutput,
+	readmePath string,
+	result *internal.AdoptResult,
+) {
+	outputPath, _ := cmd.Flags().GetString("output")
+	if write, _ := cmd.Flags().GetBool("write"); write {
+		outputPath = readmePath
+	}
+
+	if outputPath == "" || outputPath != readmePath {
+		original, err := os.ReadFile(readmePath) // #nosec G304 -- README path from user input
+		if err != nil {
+			output.Error("Error reading %s: %v", readmePath, err)
+			exit(1)
+		}
+		fmt.Print(internal.UnifiedDiff(readmePath, readmePath+" (proposed)", string(original), result.Content))
+	}
+	if outputPath == "" && confirm(output, "Replace "+readmePath+" with the proposed README?", false) {
+		outputPath = readmePath
+	}
+	if outputPath == "" {
+		return
+	}
+
+	if err := os.WriteFile(outputPath, []byte(result.Content), internal.FilePermDefault); err != nil {
+		output.Error("Error writing %s: %v", outputPath, err)
+		exit(1)
+	}
+	output.Success("Wrote adopted README: %s", outputPath)
+}
+
+func newPublishCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "publish",
+		Short: "Publish generated documentation",
+	}
+
+	ociCmd := &cobra.Command{
+		Use:   "oci <reference> [directory_or_file]",
+		Short: "Push the generated documentation to an OCI registry",
+		Long: `Package the documentation generated for the actions in a directory into an OCI
+artifact and push it to a registry such as GHCR. The artifact holds the documentation
+as a gzipped tar archive, a manifest listing each file with its action and SHA-256,
+and a CycloneDX SBOM of the actions' dependencies.
+
+Run gen first; publish oci packages the documentation as it is on disk. Registries
+authenticate with the credentials under deps.registries, and GHCR also with the
+GitHub token.
+
+Examples:
+	gh-action-readme publish oci ghcr.io/org/actions-docs:v1.2.0
+	gh-action-readme publish oci ghcr.io/org/actions-docs:main actions/
+	gh-action-readme publish oci registry.example.com/docs:1.0 --dry-run`,
+		Args: cobra.RangeArgs(1, 2),
+		Run:  publishOCIHandler,
+	}
+	ociCmd.Flags().BoolP("recursive", "r", true, "search for action.yml files recursively")
+	ociCmd.Flags().Bool("dry-run", false, "package the artifact and list its files without pushing")
+	cmd.AddCommand(ociCmd)
+
+	return cmd
+}
+
+func publishOCIHandler(cmd *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	image, err := dependencies.ParseDockerImage(args[0])
+	if err != nil || image.Digest != "" {
+		output.Error("Invalid reference %s, expected registry/repository:tag", args[0])
+		exit(1)
+	}
+
+	workingDir, actionFiles := resolveActionTargets(cmd, args[1:], output, "documentation publishing")
+	config := loadGenConfig(helpers.FindGitRepoRoot(workingDir), workingDir)
+	applyGlobalFlags(config)
+	generator := internal.NewGenerator(config)
+
+	artifact, err := generator.BuildDocsArtifact(workingDir, actionFiles, nil)
+	if err != nil {
+		output.Error("Failed to package documentation: %v", err)
+		exit(1)
+	}
+
+	table := internal.NewTable("File", "Action", "Size").Indent("  ").AlignRight(2)
+	for _, file := range artifact.Manifest.Files {
+		table.AddRow(file.Path, file.Action, locale.Current().Int(file.Size))
+	}
+	output.Bold("Documentation artifact for %s:", image)
+	output.Table(table)
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		output.Info("Dry run, nothing pushed")
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	digest, err := internal.PushDocsArtifact(ctx, internal.NewRegistryClient(config), image, artifact)
+	if err != nil {
+		output.Error("Failed to publish %s: %v", image, err)
+		exit(1)
+	}
+	output.Success("Published %s@%s", image, digest)
+}
+
+func newIDECmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ide",
+		Short: "Editor integration",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "init <editor> [directory]",
+		Short: "Add gh-action-readme tasks and settings to an editor's workspace files",
+		Long: `Add tasks and settings for gh-action-readme to the workspace files of an
+editor, so contributors get the documentation toolchain with one command.
+
+For vscode, .vscode/tasks.json gets generate, validate and watch tasks, where watch
+runs serve to preview the documentation with live-reload and validate reports its
+findings in the Problems panel. .vscode/settings.json associates the action.yml
+schema with action files through yaml.schemas, and .vscode/extensions.json
+recommends the YAML extension that reads it. Existing files are merged; tasks from
+an earlier run are replaced. The directory defaults to the repository root.
+
+Examples:
+	gh-action-readme ide init vscode               # Workspace at the repository root
+	gh-action-readme ide init vscode actions/build # Another workspace directory`,
+		Args:      cobra.RangeArgs(1, 2),
+		ValidArgs: []string{internal.IDEVSCode},
+		Run:       ideInitHandler,
+	})
+
+	return cmd
+}
+
+func ideInitHandler(_ *cobra.Command, args []string) {
+	output := createOutputManager(globalConfig.Quiet)
+	if args[0] != internal.IDEVSCode {
+		output.Error("Unsupported editor %q, supported: %s", args[0], internal.IDEVSCode)
+		exit(1)
+	}
+
+	dir := ""
+	if len(args) > 1 {
+		dir = args[1]
+	} else {
+		currentDir, err := helpers.GetCurrentDir()
+		if err != nil {
+			output.Error("Error getting current directory: %v", err)
+			exit(1)
+		}
+		dir = cmp.Or(helpers.FindGitRepoRoot(currentDir), currentDir)
+	}
+
+	files, err := internal.InitVSCode(dir)
+	for _, file := range files {
+		if file.Changed {
+			output.Success("Updated %s", file.Path)
+		} else {
+			output.Info("Unchanged %s", file.Path)
+		}
+	}
+	if err != nil {
+		output.Error("Failed to write VS Code settings: %v", err)
+		exit(1)
+	}
+}
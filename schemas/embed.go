@@ -0,0 +1,13 @@
+// Package schemas embeds the JSON schemas of gh-action-readme in the binary,
+// so editors can be pointed at them without a checkout of the repository.
+package schemas
+
+import _ "embed" // Embeds the schema files
+
+// ActionSchemaFile is the file name of the action.yml schema.
+const ActionSchemaFile = "action.schema.json"
+
+// ActionSchema is the JSON schema of action.yml files.
+//
+//go:embed action.schema.json
+var ActionSchema []byte
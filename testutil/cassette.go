@@ -0,0 +1,200 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// EnvRecordCassettes re-records cassettes against the GitHub API when set to
+// 1, authenticated with GH_README_GITHUB_TOKEN or GITHUB_TOKEN when set.
+const EnvRecordCassettes = "GH_README_RECORD_CASSETTES"
+
+// redacted replaces the token wherever it appears in a recorded interaction.
+const redacted = "REDACTED"
+
+// cassetteHeaders are the response headers kept in cassettes; request headers
+// are never recorded, so credentials stay out of them.
+var cassetteHeaders = []string{"Content-Type", "Link"}
+
+// Cassette is a recording of GitHub API interactions, replayed by tests
+// without a token or network access.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Method   string            `json:"method"`
+	URL      string            `json:"url"`
+	Status   int               `json:"status"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Response json.RawMessage   `json:"response,omitempty"`
+}
+
+// NewCassetteClient returns a GitHub client replaying the cassette
+// testdata/cassettes/<name>.json. Requests missing from the cassette fail the
+// test. With GH_README_RECORD_CASSETTES=1 the client sends its requests to the
+// GitHub API instead and the cassette is rewritten when the test ends.
+func NewCassetteClient(t *testing.T, name string) *github.Client {
+	t.Helper()
+
+	recorder := &cassetteRecorder{t: t, path: cassettePath(name)}
+	if os.Getenv(EnvRecordCassettes) == "1" {
+		recorder.recording = true
+		recorder.transport = http.DefaultTransport
+		recorder.token = os.Getenv("GH_README_GITHUB_TOKEN")
+		if recorder.token == "" {
+			recorder.token = os.Getenv("GITHUB_TOKEN")
+		}
+		t.Cleanup(recorder.save)
+	} else {
+		content, err := os.ReadFile(recorder.path) // #nosec G304 -- cassette below testdata
+		if err != nil {
+			t.Fatalf("failed to read cassette, record it with %s=1: %v", EnvRecordCassettes, err)
+		}
+		if err := json.Unmarshal(content, &recorder.cassette); err != nil {
+			t.Fatalf("failed to parse cassette %s: %v", recorder.path, err)
+		}
+	}
+
+	return github.NewClient(&http.Client{Transport: recorder})
+}
+
+// cassettePath returns the path of the named cassette in testdata/cassettes.
+func cassettePath(name string) string {
+	_, currentFile, _, ok := runtime.Caller(0)
+	if !ok {
+		panic("failed to get current file path")
+	}
+
+	return filepath.Join(filepath.Dir(filepath.Dir(currentFile)), "testdata", "cassettes", name+".json")
+}
+
+// cassetteRecorder replays the interactions of a cassette, or records them.
+type cassetteRecorder struct {
+	t         *testing.T
+	path      string
+	recording bool
+	token     string
+	transport http.RoundTripper // Sends the requests while recording
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *cassetteRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.recording {
+		return r.record(req)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, interaction := range r.cassette.Interactions {
+		if interaction.Method == req.Method && interaction.URL == req.URL.String() {
+			return interaction.response(req), nil
+		}
+	}
+	r.t.Errorf("cassette %s has no response to %s %s, re-record it with %s=1",
+		filepath.Base(r.path), req.Method, req.URL, EnvRecordCassettes)
+
+	return nil, fmt.Errorf("no recorded response to %s %s", req.Method, req.URL)
+}
+
+// record sends req to the GitHub API and records the sanitized interaction.
+func (r *cassetteRecorder) record(req *http.Request) (*http.Response, error) {
+	sent := req.Clone(req.Context())
+	if r.token != "" {
+		sent.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	resp, err := r.transport.RoundTrip(sent)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	interaction := Interaction{Method: req.Method, URL: req.URL.String(), Status: resp.StatusCode}
+	for _, name := range cassetteHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			if interaction.Headers == nil {
+				interaction.Headers = make(map[string]string)
+			}
+			interaction.Headers[name] = r.sanitize(value)
+		}
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		sanitized := []byte(r.sanitize(string(body)))
+		if !json.Valid(sanitized) {
+			return nil, fmt.Errorf("%s %s returned a response that is not JSON", req.Method, req.URL)
+		}
+		interaction.Response = sanitized
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return interaction.response(req), nil
+}
+
+// sanitize replaces the token in value.
+func (r *cassetteRecorder) sanitize(value string) string {
+	if r.token == "" {
+		return value
+	}
+
+	return strings.ReplaceAll(value, r.token, redacted)
+}
+
+// save writes the recorded cassette.
+func (r *cassetteRecorder) save() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var content bytes.Buffer
+	encoder := json.NewEncoder(&content)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(r.cassette)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(r.path), 0750) // #nosec G301 -- testdata directory permissions
+	}
+	if err == nil {
+		err = os.WriteFile(r.path, content.Bytes(), 0600) // #nosec G306 -- testdata file permissions
+	}
+	if err != nil {
+		r.t.Errorf("failed to save cassette %s: %v", r.path, err)
+	}
+}
+
+// response returns the recorded response to req.
+func (i Interaction) response(req *http.Request) *http.Response {
+	header := make(http.Header)
+	for name, value := range i.Headers {
+		header.Set(name, value)
+	}
+
+	return &http.Response{
+		StatusCode: i.Status,
+		Status:     fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(i.Response)),
+		Request:    req,
+	}
+}
@@ -0,0 +1,45 @@
+package testutil
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewCassetteClient_Replay(t *testing.T) {
+	t.Parallel()
+
+	client := NewCassetteClient(t, "deps-upgrade")
+	repo, resp, err := client.Repositories.Get(context.Background(), "actions", "checkout")
+	AssertNoError(t, err)
+	AssertEqual(t, http.StatusOK, resp.StatusCode)
+	AssertEqual(t, "Action for checking out a repo", repo.GetDescription())
+}
+
+func TestCassetteRecorder_Record(t *testing.T) {
+	t.Parallel()
+
+	server := newGitHubServer(map[string]string{
+		"GET https://api.github.com/repos/acme/tool": `{"name": "tool", "description": "uses secret-token"}`,
+	})
+	recorder := &cassetteRecorder{t: t, recording: true, token: "secret-token", transport: handlerTransport{handler: server}}
+	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/repos/acme/tool", nil)
+	AssertNoError(t, err)
+
+	resp, err := recorder.RoundTrip(req)
+	AssertNoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	AssertEqual(t, http.StatusOK, resp.StatusCode)
+
+	recorded, err := json.Marshal(recorder.cassette)
+	AssertNoError(t, err)
+	if strings.Contains(string(recorded), "secret-token") {
+		t.Errorf("recorded cassette contains the token: %s", recorded)
+	}
+	AssertStringContains(t, string(recorded), `"description":"uses REDACTED"`)
+	if strings.Contains(string(recorded), "X-Ratelimit") {
+		t.Errorf("recorded cassette contains headers besides %v: %s", cassetteHeaders, recorded)
+	}
+}
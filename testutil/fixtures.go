@@ -3,6 +3,7 @@ package testutil
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -25,6 +26,32 @@ func MustReadFixture(filename string) string {
 	return mustReadFixture(filename)
 }
 
+// FixtureNames returns the names of every YAML fixture in testdata/yaml-fixtures,
+// relative to it, such as seeds for fuzz targets.
+func FixtureNames() []string {
+	_, currentFile, _, ok := runtime.Caller(0)
+	if !ok {
+		panic("failed to get current file path")
+	}
+	fixturesDir := filepath.Join(filepath.Dir(filepath.Dir(currentFile)), "testdata", "yaml-fixtures")
+
+	var names []string
+	err := filepath.WalkDir(fixturesDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(path) != ".yml" {
+			return err
+		}
+		name, err := filepath.Rel(fixturesDir, path)
+		names = append(names, filepath.ToSlash(name))
+
+		return err
+	})
+	if err != nil {
+		panic("failed to list fixtures: " + err.Error())
+	}
+
+	return names
+}
+
 // mustReadFixture reads a YAML fixture file from testdata/yaml-fixtures with caching.
 func mustReadFixture(filename string) string {
 	// Try to get from cache first (read lock)
@@ -267,7 +294,8 @@ const GitHubErrorResponse = `{
 // MockGitHubResponses returns a map of URL patterns to mock responses.
 func MockGitHubResponses() map[string]string {
 	return map[string]string{
-		"GET https://api.github.com/repos/actions/checkout/releases/latest": GitHubReleaseResponse,
+		"GET https://api.github.com/repos/actions/checkout/releases/latest":      GitHubReleaseResponse,
+		"GET https://api.github.com/repos/actions/checkout/releases/tags/v4.1.1": GitHubReleaseResponse,
 		"GET https://api.github.com/repos/actions/checkout/git/ref/tags/v4.1.1": `{
 	"ref": "refs/tags/v4.1.1",
 	"node_id": "REF_kwDOAJy2KM9yZXJlZnMvdGFncy92NC4xLjE",
@@ -689,6 +717,7 @@ func isValidRuntime(runtime string) bool {
 		"node12",    // Legacy Node.js runtime (deprecated)
 		"node16",    // Legacy Node.js runtime (deprecated)
 		"node20",    // Current Node.js runtime
+		"node24",    // Current Node.js runtime
 		"docker",    // Docker container runtime
 		"composite", // Composite action runtime
 	}
@@ -0,0 +1,294 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// githubAPIURL is the base URL the keys of mock responses start with.
+const githubAPIURL = "https://api.github.com"
+
+// Pagination and rate limits of the fake GitHub API, matching github.com.
+const (
+	githubDefaultPerPage = 30
+	githubRateLimit      = 5000
+)
+
+// GitHubServer is a fake GitHub REST API. It serves canned JSON responses
+// keyed like MockGitHubResponses, paginates list responses with Link headers,
+// sets rate-limit headers on every response and fails requests on demand.
+type GitHubServer struct {
+	// URL is the base URL of the API started by NewGitHubServer
+	URL string
+
+	mu        sync.Mutex
+	responses map[string]string
+	failures  map[string][]githubFailure
+	remaining int
+	reset     time.Time
+	requests  []string
+}
+
+// githubFailure is an injected error response.
+type githubFailure struct {
+	status int
+	header http.Header
+	body   string
+}
+
+// NewGitHubServer starts a fake GitHub API serving responses, closed when the
+// test ends. Keys are a method and a URL below https://api.github.com or a
+// path, such as "GET /repos/actions/checkout".
+func NewGitHubServer(t *testing.T, responses map[string]string) *GitHubServer {
+	t.Helper()
+
+	server := newGitHubServer(responses)
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	server.URL = httpServer.URL
+
+	return server
+}
+
+// newGitHubServer returns a fake GitHub API serving responses without a listener.
+func newGitHubServer(responses map[string]string) *GitHubServer {
+	server := &GitHubServer{
+		responses: make(map[string]string),
+		failures:  make(map[string][]githubFailure),
+		remaining: githubRateLimit,
+		reset:     time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+	for key, body := range responses {
+		method, target, _ := strings.Cut(key, " ")
+		server.Handle(method, target, body)
+	}
+
+	return server
+}
+
+// Client returns a GitHub client sending its requests to the server.
+func (s *GitHubServer) Client() *github.Client {
+	if s.URL == "" {
+		// Served in-process, requests keep their github.com URLs
+		return github.NewClient(&http.Client{Transport: handlerTransport{handler: s}})
+	}
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(s.URL + "/")
+	client.UploadURL = client.BaseURL
+
+	return client
+}
+
+// Handle serves body as the response to method requests of target, a URL
+// below https://api.github.com or a path with an optional query.
+func (s *GitHubServer) Handle(method, target, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[routeKey(method, target)] = body
+}
+
+// Fail makes the next times requests of method and path fail with status.
+func (s *GitHubServer) Fail(method, path string, status, times int) {
+	message, _ := json.Marshal(http.StatusText(status))
+	s.inject(method, path, times, githubFailure{
+		status: status,
+		header: make(http.Header),
+		body:   fmt.Sprintf(`{"message": %s, "documentation_url": "https://docs.github.com/rest"}`, message),
+	})
+}
+
+// FailSecondaryRateLimit makes the next request of method and path hit a
+// secondary rate limit, retried after retryAfter seconds.
+func (s *GitHubServer) FailSecondaryRateLimit(method, path string, retryAfter int) {
+	header := make(http.Header)
+	header.Set("Retry-After", strconv.Itoa(retryAfter))
+	s.inject(method, path, 1, githubFailure{
+		status: http.StatusForbidden,
+		header: header,
+		body: `{"message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again.",` +
+			` "documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`,
+	})
+}
+
+// SetRateLimitRemaining sets the requests left before the server answers
+// every request with a primary rate limit error.
+func (s *GitHubServer) SetRateLimitRemaining(remaining int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remaining = remaining
+}
+
+// Requests returns the requests served so far as a method and a path with
+// the query, such as "GET /repos/actions/checkout/tags?per_page=10".
+func (s *GitHubServer) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *GitHubServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	s.requests = append(s.requests, routeKey(r.Method, r.URL.Path+"?"+query.Encode()))
+
+	if s.remaining == 0 {
+		s.writeRateLimitHeaders(w.Header())
+		writeGitHubJSON(w, http.StatusForbidden, `{"message": "API rate limit exceeded.",`+
+			` "documentation_url": "https://docs.github.com/rest/overview/resources-in-the-rest-api#rate-limiting"}`)
+
+		return
+	}
+	s.remaining--
+	s.writeRateLimitHeaders(w.Header())
+
+	key := routeKey(r.Method, r.URL.Path)
+	if failures := s.failures[key]; len(failures) > 0 {
+		s.failures[key] = failures[1:]
+		for name, values := range failures[0].header {
+			w.Header()[name] = values
+		}
+		writeGitHubJSON(w, failures[0].status, failures[0].body)
+
+		return
+	}
+
+	// Responses match the query without the page, falling back to the path
+	page := query.Get("page")
+	query.Del("page")
+	body, ok := s.responses[routeKey(r.Method, r.URL.Path+"?"+query.Encode())]
+	if !ok {
+		body, ok = s.responses[key]
+	}
+	if !ok {
+		writeGitHubJSON(w, http.StatusNotFound, `{"message": "Not Found", "documentation_url": "https://docs.github.com/rest"}`)
+
+		return
+	}
+
+	writeGitHubJSON(w, http.StatusOK, paginate(w.Header(), r.URL, r.Host, page, body))
+}
+
+// inject queues failures of method and path.
+func (s *GitHubServer) inject(method, path string, times int, failure githubFailure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := routeKey(method, path)
+	for range times {
+		s.failures[key] = append(s.failures[key], failure)
+	}
+}
+
+// writeRateLimitHeaders sets the rate-limit headers of the core API.
+func (s *GitHubServer) writeRateLimitHeaders(header http.Header) {
+	header.Set("X-RateLimit-Limit", strconv.Itoa(githubRateLimit))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(s.remaining))
+	header.Set("X-RateLimit-Used", strconv.Itoa(githubRateLimit-s.remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(s.reset.Unix(), 10))
+	header.Set("X-RateLimit-Resource", "core")
+}
+
+// routeKey returns the key of the responses of method requests of target,
+// with the query parameters sorted.
+func routeKey(method, target string) string {
+	path, rawQuery, _ := strings.Cut(strings.TrimPrefix(target, githubAPIURL), "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil || len(query) == 0 {
+		return method + " " + path
+	}
+
+	return method + " " + path + "?" + query.Encode()
+}
+
+// paginate returns the page of the list in body, a JSON array or a search
+// result with an items array, and sets the Link header to the next and last
+// pages. Other bodies are returned as is.
+func paginate(header http.Header, requestURL *url.URL, host, page, body string) string {
+	var items []json.RawMessage
+	var result map[string]json.RawMessage
+	if json.Unmarshal([]byte(body), &items) != nil {
+		if json.Unmarshal([]byte(body), &result) != nil || json.Unmarshal(result["items"], &items) != nil {
+			return body
+		}
+	}
+
+	perPage, err := strconv.Atoi(requestURL.Query().Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = githubDefaultPerPage
+	}
+	current, err := strconv.Atoi(page)
+	if err != nil || current <= 0 {
+		current = 1
+	}
+	last := max(1, (len(items)+perPage-1)/perPage)
+	if current == 1 && last == 1 {
+		return body
+	}
+
+	if current < last {
+		header.Set("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`,
+			pageURL(requestURL, host, current+1), pageURL(requestURL, host, last)))
+	}
+	start := min((current-1)*perPage, len(items))
+	pageItems, _ := json.Marshal(items[start:min(start+perPage, len(items))])
+	if result == nil {
+		return string(pageItems)
+	}
+	result["items"] = pageItems
+	pageResult, _ := json.Marshal(result)
+
+	return string(pageResult)
+}
+
+// pageURL returns the URL of page of the list requested at requestURL.
+func pageURL(requestURL *url.URL, host string, page int) string {
+	link := *requestURL
+	if !link.IsAbs() {
+		link.Scheme, link.Host = "http", host
+	}
+	query := link.Query()
+	query.Set("page", strconv.Itoa(page))
+	link.RawQuery = query.Encode()
+
+	return link.String()
+}
+
+// writeGitHubJSON writes a JSON response with status.
+func writeGitHubJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, body)
+}
+
+// handlerTransport serves requests with a handler in-process.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	t.handler.ServeHTTP(recorder, req)
+	resp := recorder.Result()
+	resp.Request = req
+
+	return resp, nil
+}
@@ -0,0 +1,129 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// tagsResponse returns a tags list with count tags named v1 to v<count>.
+func tagsResponse(count int) string {
+	tags := make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		tags = append(tags, fmt.Sprintf(`{"name": "v%d"}`, i))
+	}
+
+	return "[" + strings.Join(tags, ",") + "]"
+}
+
+func TestGitHubServer_Pagination(t *testing.T) {
+	t.Parallel()
+
+	for name, client := range map[string]func() *github.Client{
+		"listening": func() *github.Client {
+			return NewGitHubServer(t, map[string]string{"GET /repos/acme/tool/tags": tagsResponse(45)}).Client()
+		},
+		"in-process": func() *github.Client {
+			return MockGitHubClient(map[string]string{
+				"GET https://api.github.com/repos/acme/tool/tags": tagsResponse(45),
+			})
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := client()
+			opts := &github.ListOptions{PerPage: 20}
+			var names []string
+			pages := 0
+			for {
+				tags, resp, err := client.Repositories.ListTags(context.Background(), "acme", "tool", opts)
+				AssertNoError(t, err)
+				pages++
+				for _, tag := range tags {
+					names = append(names, tag.GetName())
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				AssertEqual(t, 3, resp.LastPage)
+				opts.Page = resp.NextPage
+			}
+
+			AssertEqual(t, 3, pages)
+			AssertEqual(t, 45, len(names))
+			AssertEqual(t, "v1", names[0])
+			AssertEqual(t, "v45", names[44])
+		})
+	}
+}
+
+func TestGitHubServer_SearchPagination(t *testing.T) {
+	t.Parallel()
+
+	items := make([]string, 0, 5)
+	for i := range 5 {
+		items = append(items, fmt.Sprintf(`{"name": "file%d.yml", "path": "file%d.yml"}`, i, i))
+	}
+	server := NewGitHubServer(t, map[string]string{
+		"GET /search/code": `{"total_count": 5, "items": [` + strings.Join(items, ",") + `]}`,
+	})
+
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 2, Page: 3}}
+	result, resp, err := server.Client().Search.Code(context.Background(), "acme", opts)
+	AssertNoError(t, err)
+	AssertEqual(t, 5, result.GetTotal())
+	AssertEqual(t, 1, len(result.CodeResults))
+	AssertEqual(t, "file4.yml", result.CodeResults[0].GetPath())
+	AssertEqual(t, 0, resp.NextPage)
+}
+
+func TestGitHubServer_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := NewGitHubServer(t, map[string]string{"GET /repos/acme/tool": GitHubRepoResponse})
+	client := server.Client()
+
+	_, resp, err := client.Repositories.Get(context.Background(), "acme", "tool")
+	AssertNoError(t, err)
+	AssertEqual(t, 5000, resp.Rate.Limit)
+	AssertEqual(t, 4999, resp.Rate.Remaining)
+
+	server.SetRateLimitRemaining(0)
+	_, _, err = client.Repositories.Get(context.Background(), "acme", "tool")
+	var rateLimitErr *github.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+	AssertEqual(t, 0, rateLimitErr.Rate.Remaining)
+}
+
+func TestGitHubServer_Failures(t *testing.T) {
+	t.Parallel()
+
+	server := NewGitHubServer(t, map[string]string{"GET /repos/acme/tool": GitHubRepoResponse})
+	server.Fail(http.MethodGet, "/repos/acme/tool", http.StatusBadGateway, 2)
+	server.FailSecondaryRateLimit(http.MethodGet, "/repos/acme/tool", 1)
+	client := server.Client()
+
+	for _, want := range []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusForbidden} {
+		_, resp, _ := client.Repositories.Get(context.Background(), "acme", "tool")
+		AssertEqual(t, want, resp.StatusCode)
+	}
+
+	// The client holds back requests after a secondary rate limit, a new one does not
+	client = server.Client()
+	_, resp, err := client.Repositories.Get(context.Background(), "acme", "tool")
+	AssertNoError(t, err)
+	AssertEqual(t, http.StatusOK, resp.StatusCode)
+
+	_, resp, err = client.Repositories.Get(context.Background(), "acme", "missing")
+	AssertError(t, err)
+	AssertEqual(t, http.StatusNotFound, resp.StatusCode)
+	AssertEqual(t, "GET /repos/acme/missing", server.Requests()[4])
+}
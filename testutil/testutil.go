@@ -53,31 +53,10 @@ func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	}, nil
 }
 
-// MockGitHubClient creates a GitHub client with mocked responses.
+// MockGitHubClient creates a GitHub client served by a fake GitHub API with
+// the responses, see GitHubServer. Requests are served in-process.
 func MockGitHubClient(responses map[string]string) *github.Client {
-	mockClient := &MockHTTPClient{
-		Responses: make(map[string]*http.Response),
-	}
-
-	for key, body := range responses {
-		mockClient.Responses[key] = &http.Response{
-			StatusCode: http.StatusOK,
-			Body:       io.NopCloser(strings.NewReader(body)),
-			Header:     make(http.Header),
-		}
-	}
-
-	client := github.NewClient(&http.Client{Transport: &mockTransport{client: mockClient}})
-
-	return client
-}
-
-type mockTransport struct {
-	client *MockHTTPClient
-}
-
-func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	return t.client.Do(req)
+	return newGitHubServer(responses).Client()
 }
 
 // TempDir creates a temporary directory for testing and returns cleanup function.
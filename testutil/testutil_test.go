@@ -202,18 +202,11 @@ func TestMockGitHubClient(t *testing.T) {
 	})
 }
 
-func TestMockTransport(t *testing.T) {
+func TestHandlerTransport(t *testing.T) {
 	t.Parallel()
-	client := &MockHTTPClient{
-		Responses: map[string]*http.Response{
-			"GET https://api.github.com/test": {
-				StatusCode: 200,
-				Body:       io.NopCloser(strings.NewReader(`{"success": true}`)),
-			},
-		},
-	}
-
-	transport := &mockTransport{client: client}
+	transport := handlerTransport{handler: newGitHubServer(map[string]string{
+		"GET https://api.github.com/test": `{"success": true}`,
+	})}
 
 	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/test", nil)
 	if err != nil {